@@ -7,10 +7,15 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
@@ -31,9 +36,18 @@ var (
 	// IdentityPath is the path to the identity the inspector should use for network communication.
 	IdentityPath = flag.String("identity-path", "", "path to the identity certificate for use on the network")
 
+	// Timeout is the maximum duration of a single request to the peer.
+	Timeout = flag.Duration("timeout", 30*time.Second, "timeout of a single request to the peer")
+
 	// CSVPath is the csv path where command output is written.
 	CSVPath string
 
+	// Format is the format of the command output, either csv or json.
+	Format string
+
+	// EncryptedPathFile is the path of a file containing the base64 encoded encrypted path of an object.
+	EncryptedPathFile string
+
 	// ErrInspectorDial throws when there are errors dialing the inspector server.
 	ErrInspectorDial = errs.Class("dialing inspector server")
 
@@ -60,9 +74,10 @@ var (
 		Short: "commands for querying health of a stored data",
 	}
 	objectHealthCmd = &cobra.Command{
-		Use:   "object <project-id> <bucket> <encrypted-path>",
+		Use:   "object <project-id> <bucket> [<encrypted-path>]",
 		Short: "Get stats about an object's health",
-		Args:  cobra.MinimumNArgs(3),
+		Long:  "Get stats about an object's health. The encrypted path can be read from a file with --encrypted-path-file instead, in which case the positional argument must be omitted or empty.",
+		Args:  cobra.MinimumNArgs(2),
 		RunE:  ObjectHealth,
 	}
 	segmentHealthCmd = &cobra.Command{
@@ -71,10 +86,18 @@ var (
 		Args:  cobra.MinimumNArgs(4),
 		RunE:  SegmentHealth,
 	}
+	batchHealthCmd = &cobra.Command{
+		Use:   "batch <input.csv>",
+		Short: "Get stats about the health of many objects",
+		Long:  "Get stats about the health of many objects. Every row of the input csv has to contain the project id, bucket and base64 encoded encrypted path of an object.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  BatchHealth,
+	}
 )
 
 // Inspector gives access to overlay.
 type Inspector struct {
+	address      string
 	conn         *rpc.Conn
 	identity     *identity.FullIdentity
 	healthclient internalpb.DRPCHealthInspectorClient
@@ -90,12 +113,14 @@ func NewInspector(ctx context.Context, address, path string) (*Inspector, error)
 		return nil, ErrIdentity.Wrap(err)
 	}
 
+	// the connection is reused for all requests of the inspector
 	conn, err := rpc.NewDefaultDialer(nil).DialAddressUnencrypted(ctx, address)
 	if err != nil {
-		return &Inspector{}, ErrInspectorDial.Wrap(err)
+		return nil, ErrInspectorDial.Wrap(err)
 	}
 
 	return &Inspector{
+		address:      address,
 		conn:         conn,
 		identity:     id,
 		healthclient: internalpb.NewDRPCHealthInspectorClient(conn),
@@ -105,9 +130,45 @@ func NewInspector(ctx context.Context, address, path string) (*Inspector, error)
 // Close closes the inspector.
 func (i *Inspector) Close() error { return i.conn.Close() }
 
+// objectHealth requests the health of an object, failing when the request takes longer than --timeout.
+func (i *Inspector) objectHealth(ctx context.Context, req *internalpb.ObjectHealthRequest) (*internalpb.ObjectHealthResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, *Timeout)
+	defer cancel()
+
+	resp, err := i.healthclient.ObjectHealth(ctx, req)
+	if err != nil {
+		return nil, i.requestError(ctx, err)
+	}
+	return resp, nil
+}
+
+// segmentHealth requests the health of a segment, failing when the request takes longer than --timeout.
+func (i *Inspector) segmentHealth(ctx context.Context, req *internalpb.SegmentHealthRequest) (*internalpb.SegmentHealthResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, *Timeout)
+	defer cancel()
+
+	resp, err := i.healthclient.SegmentHealth(ctx, req)
+	if err != nil {
+		return nil, i.requestError(ctx, err)
+	}
+	return resp, nil
+}
+
+// requestError wraps err of a request made with ctx, making timeouts distinguishable.
+func (i *Inspector) requestError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrRequest.New("request to %s timed out after %s: %w", i.address, *Timeout, err)
+	}
+	return ErrRequest.Wrap(err)
+}
+
 // ObjectHealth gets information about the health of an object on the network.
 func ObjectHealth(cmd *cobra.Command, args []string) (err error) {
 	ctx, _ := process.Ctx(cmd)
+	if err := verifyFormat(); err != nil {
+		return err
+	}
+
 	i, err := NewInspector(ctx, *Addr, *IdentityPath)
 	if err != nil {
 		return ErrArgs.Wrap(err)
@@ -139,7 +200,11 @@ func ObjectHealth(cmd *cobra.Command, args []string) (err error) {
 		fallthrough
 	default:
 	}
-	decodedPath, err := base64.URLEncoding.DecodeString(args[2])
+	encodedPath, err := objectEncryptedPath(args)
+	if err != nil {
+		return err
+	}
+	decodedPath, err := base64.URLEncoding.DecodeString(encodedPath)
 	if err != nil {
 		return err
 	}
@@ -152,9 +217,9 @@ func ObjectHealth(cmd *cobra.Command, args []string) (err error) {
 		Limit:             int32(limit),
 	}
 
-	resp, err := i.healthclient.ObjectHealth(ctx, req)
+	resp, err := i.objectHealth(ctx, req)
 	if err != nil {
-		return ErrRequest.Wrap(err)
+		return err
 	}
 
 	f, err := csvOutput()
@@ -168,28 +233,21 @@ func ObjectHealth(cmd *cobra.Command, args []string) (err error) {
 		}
 	}()
 
-	w := csv.NewWriter(f)
-	defer w.Flush()
-
 	redundancy, err := eestream.NewRedundancyStrategyFromProto(resp.GetRedundancy())
 	if err != nil {
 		return ErrRequest.Wrap(err)
 	}
 
-	if err := printRedundancyTable(w, redundancy); err != nil {
-		return err
-	}
-
-	if err := printSegmentHealthAndNodeTables(w, redundancy, resp.GetSegments()); err != nil {
-		return err
-	}
-
-	return nil
+	return printHealth(f, redundancy, resp.GetSegments())
 }
 
 // SegmentHealth gets information about the health of a segment on the network.
 func SegmentHealth(cmd *cobra.Command, args []string) (err error) {
 	ctx, _ := process.Ctx(cmd)
+	if err := verifyFormat(); err != nil {
+		return err
+	}
+
 	i, err := NewInspector(ctx, *Addr, *IdentityPath)
 	if err != nil {
 		return ErrArgs.Wrap(err)
@@ -208,9 +266,9 @@ func SegmentHealth(cmd *cobra.Command, args []string) (err error) {
 		EncryptedPath: []byte(args[3]),
 	}
 
-	resp, err := i.healthclient.SegmentHealth(ctx, req)
+	resp, err := i.segmentHealth(ctx, req)
 	if err != nil {
-		return ErrRequest.Wrap(err)
+		return err
 	}
 
 	f, err := csvOutput()
@@ -224,25 +282,144 @@ func SegmentHealth(cmd *cobra.Command, args []string) (err error) {
 		}
 	}()
 
-	w := csv.NewWriter(f)
-	defer w.Flush()
-
 	redundancy, err := eestream.NewRedundancyStrategyFromProto(resp.GetRedundancy())
 	if err != nil {
 		return ErrRequest.Wrap(err)
 	}
 
-	if err := printRedundancyTable(w, redundancy); err != nil {
-		return err
+	return printHealth(f, redundancy, []*internalpb.SegmentHealth{resp.GetHealth()})
+}
+
+// objectEncryptedPath returns the base64 encoded encrypted path either from
+// the positional arguments or from the file specified by --encrypted-path-file.
+func objectEncryptedPath(args []string) (string, error) {
+	var positional string
+	if len(args) > 2 {
+		positional = args[2]
+	}
+
+	if EncryptedPathFile == "" {
+		if positional == "" {
+			return "", ErrArgs.New("encrypted-path argument or --encrypted-path-file flag is required")
+		}
+		return positional, nil
+	}
+
+	if positional != "" {
+		return "", ErrArgs.New("encrypted-path argument and --encrypted-path-file flag are mutually exclusive")
 	}
 
-	if err := printSegmentHealthAndNodeTables(w, redundancy, []*internalpb.SegmentHealth{resp.GetHealth()}); err != nil {
+	data, err := os.ReadFile(EncryptedPathFile)
+	if err != nil {
+		return "", ErrArgs.Wrap(err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// BatchHealth gets aggregated information about the health of all objects listed in a csv file.
+// Errors of individual objects are recorded in the output instead of aborting the batch.
+func BatchHealth(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	input, err := os.Open(args[0])
+	if err != nil {
+		return ErrArgs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, input.Close()) }()
+
+	r := csv.NewReader(input)
+	r.FieldsPerRecord = 3
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return ErrArgs.Wrap(err)
+	}
+
+	i, err := NewInspector(ctx, *Addr, *IdentityPath)
+	if err != nil {
+		return ErrArgs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, i.Close()) }()
+
+	f, err := csvOutput()
+	if err != nil {
 		return err
 	}
+	defer func() {
+		err := f.Close()
+		if err != nil {
+			fmt.Printf("error closing file: %+v\n", err)
+		}
+	}()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	header := []string{
+		"Project ID", "Bucket", "Encrypted Path", "Status",
+		"Segments", "Min Healthy Nodes", "Unhealthy Nodes", "Offline Nodes",
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("error writing record to csv: %w", err)
+	}
+
+	for _, row := range rows {
+		summary, err := i.objectHealthSummary(ctx, row[0], row[1], row[2])
+		if err != nil {
+			summary = []string{"error: " + err.Error(), "", "", "", ""}
+		}
+
+		if err := w.Write(append(row, summary...)); err != nil {
+			return fmt.Errorf("error writing record to csv: %w", err)
+		}
+	}
 
 	return nil
 }
 
+// objectHealthSummary requests the health of an object and summarizes it as a csv record
+// containing the status, segment count, minimum healthy nodes of a segment and the total
+// number of unhealthy and offline nodes.
+func (i *Inspector) objectHealthSummary(ctx context.Context, projectID, bucket, encodedPath string) ([]string, error) {
+	decodedPath, err := base64.URLEncoding.DecodeString(encodedPath)
+	if err != nil {
+		return nil, ErrArgs.Wrap(err)
+	}
+
+	resp, err := i.objectHealth(ctx, &internalpb.ObjectHealthRequest{
+		ProjectId:     []byte(projectID),
+		Bucket:        []byte(bucket),
+		EncryptedPath: decodedPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeObjectHealth(resp.GetSegments()), nil
+}
+
+// summarizeObjectHealth summarizes the health of the segments of an object as
+// a csv record.
+func summarizeObjectHealth(segments []*internalpb.SegmentHealth) []string {
+	minHealthy, unhealthy, offline := 0, 0, 0
+	for k, segment := range segments {
+		if k == 0 || len(segment.HealthyIds) < minHealthy {
+			minHealthy = len(segment.HealthyIds)
+		}
+		unhealthy += len(segment.UnhealthyIds)
+		offline += len(segment.OfflineIds)
+	}
+
+	return []string{
+		"ok",
+		strconv.Itoa(len(segments)),
+		strconv.Itoa(minHealthy),
+		strconv.Itoa(unhealthy),
+		strconv.Itoa(offline),
+	}
+}
+
 func csvOutput() (*os.File, error) {
 	if CSVPath == "stdout" {
 		return os.Stdout, nil
@@ -251,6 +428,89 @@ func csvOutput() (*os.File, error) {
 	return os.Create(CSVPath)
 }
 
+func verifyFormat() error {
+	switch Format {
+	case "csv", "json":
+		return nil
+	default:
+		return ErrArgs.New("unsupported format %q, expected csv or json", Format)
+	}
+}
+
+func printHealth(out io.Writer, redundancy eestream.RedundancyStrategy, segments []*internalpb.SegmentHealth) error {
+	if Format == "json" {
+		return printHealthJSON(out, redundancy, segments)
+	}
+
+	w := csv.NewWriter(out)
+	defer w.Flush()
+
+	if err := printRedundancyTable(w, redundancy); err != nil {
+		return err
+	}
+
+	return printSegmentHealthAndNodeTables(w, redundancy, segments)
+}
+
+// healthJSON is the json output of the health commands.
+type healthJSON struct {
+	Redundancy redundancyJSON      `json:"redundancy"`
+	Segments   []segmentHealthJSON `json:"segments"`
+}
+
+// redundancyJSON contains the redundancy scheme of the inspected segments.
+type redundancyJSON struct {
+	Total            int `json:"total"`
+	Required         int `json:"required"`
+	OptimalThreshold int `json:"optimalThreshold"`
+	RepairThreshold  int `json:"repairThreshold"`
+}
+
+// segmentHealthJSON contains the nodes holding pieces of a segment grouped by their health.
+type segmentHealthJSON struct {
+	Segment        string         `json:"segment"`
+	HealthyNodes   []storj.NodeID `json:"healthyNodes"`
+	UnhealthyNodes []storj.NodeID `json:"unhealthyNodes"`
+	OfflineNodes   []storj.NodeID `json:"offlineNodes"`
+}
+
+func printHealthJSON(out io.Writer, redundancy eestream.RedundancyStrategy, segments []*internalpb.SegmentHealth) error {
+	output := healthJSON{
+		Redundancy: redundancyJSON{
+			Total:            redundancy.TotalCount(),
+			Required:         redundancy.RequiredCount(),
+			OptimalThreshold: redundancy.OptimalThreshold(),
+			RepairThreshold:  redundancy.RepairThreshold(),
+		},
+		Segments: make([]segmentHealthJSON, 0, len(segments)),
+	}
+
+	for _, segment := range segments {
+		output.Segments = append(output.Segments, segmentHealthJSON{
+			Segment:        string(segment.GetSegment()),
+			HealthyNodes:   nonNilNodeIDs(segment.HealthyIds),
+			UnhealthyNodes: nonNilNodeIDs(segment.UnhealthyIds),
+			OfflineNodes:   nonNilNodeIDs(segment.OfflineIds),
+		})
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return fmt.Errorf("error writing json: %w", err)
+	}
+
+	return nil
+}
+
+// nonNilNodeIDs ensures that empty node lists are encoded as an empty json array.
+func nonNilNodeIDs(ids []storj.NodeID) []storj.NodeID {
+	if ids == nil {
+		return []storj.NodeID{}
+	}
+	return ids
+}
+
 func printSegmentHealthAndNodeTables(w *csv.Writer, redundancy eestream.RedundancyStrategy, segments []*internalpb.SegmentHealth) error {
 	segmentTableHeader := []string{
 		"Segment Index", "Healthy Nodes", "Unhealthy Nodes", "Offline Nodes",
@@ -356,12 +616,17 @@ func init() {
 
 	healthCmd.AddCommand(objectHealthCmd)
 	healthCmd.AddCommand(segmentHealthCmd)
+	healthCmd.AddCommand(batchHealthCmd)
 
-	objectHealthCmd.Flags().StringVar(&CSVPath, "csv-path", "stdout", "csv path where command output is written")
-
-	flag.Parse()
+	for _, cmd := range []*cobra.Command{objectHealthCmd, segmentHealthCmd} {
+		cmd.Flags().StringVar(&CSVPath, "csv-path", "stdout", "csv path where command output is written")
+		cmd.Flags().StringVar(&Format, "format", "csv", "format of the command output (csv|json)")
+	}
+	batchHealthCmd.Flags().StringVar(&CSVPath, "csv-path", "stdout", "csv path where command output is written")
+	objectHealthCmd.Flags().StringVar(&EncryptedPathFile, "encrypted-path-file", "", "file containing the base64 encoded encrypted path, used instead of the encrypted-path argument")
 }
 
 func main() {
+	flag.Parse()
 	process.Exec(rootCmd)
 }
@@ -0,0 +1,288 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/internalpb"
+	"storj.io/uplink/private/eestream"
+)
+
+func TestPrintHealth(t *testing.T) {
+	redundancy, err := eestream.NewRedundancyStrategyFromStorj(storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      256,
+		RequiredShares: 2,
+		RepairShares:   3,
+		OptimalShares:  4,
+		TotalShares:    5,
+	})
+	require.NoError(t, err)
+
+	node1, node2, node3 := storj.NodeID{1}, storj.NodeID{2}, storj.NodeID{3}
+	segments := []*internalpb.SegmentHealth{
+		{
+			Segment:      []byte("s0"),
+			HealthyIds:   []storj.NodeID{node1, node2},
+			UnhealthyIds: []storj.NodeID{node3},
+		},
+		{
+			Segment:    []byte("l"),
+			HealthyIds: []storj.NodeID{node2},
+			OfflineIds: []storj.NodeID{node1},
+		},
+	}
+
+	defer func(format string) { Format = format }(Format)
+
+	for _, tt := range []struct {
+		name     string
+		format   string
+		segments []*internalpb.SegmentHealth
+		expected string
+	}{
+		{
+			name:     "csv",
+			format:   "csv",
+			segments: segments,
+			expected: "Total Pieces (n),Minimum Required (k),Optimal Threshold (o),Repair Threshold (m)\n" +
+				"5,2,4,3\n" +
+				"\n" +
+				"Segment Index,Healthy Nodes,Unhealthy Nodes,Offline Nodes\n" +
+				"s0,2,1,0\n" +
+				"l,1,0,1\n" +
+				"\n" +
+				"," + node1.String() + "," + node2.String() + "," + node3.String() + "\n" +
+				"s0,healthy,healthy,unhealthy\n" +
+				"l,offline,healthy,\n",
+		},
+		{
+			name:     "csv without segments",
+			format:   "csv",
+			segments: nil,
+			expected: "Total Pieces (n),Minimum Required (k),Optimal Threshold (o),Repair Threshold (m)\n" +
+				"5,2,4,3\n" +
+				"\n" +
+				"Segment Index,Healthy Nodes,Unhealthy Nodes,Offline Nodes\n" +
+				"\n" +
+				"\n",
+		},
+		{
+			name:     "json",
+			format:   "json",
+			segments: segments,
+			expected: `{
+				"redundancy": {"total": 5, "required": 2, "optimalThreshold": 4, "repairThreshold": 3},
+				"segments": [
+					{
+						"segment": "s0",
+						"healthyNodes": ["` + node1.String() + `", "` + node2.String() + `"],
+						"unhealthyNodes": ["` + node3.String() + `"],
+						"offlineNodes": []
+					},
+					{
+						"segment": "l",
+						"healthyNodes": ["` + node2.String() + `"],
+						"unhealthyNodes": [],
+						"offlineNodes": ["` + node1.String() + `"]
+					}
+				]
+			}`,
+		},
+		{
+			name:     "json without segments",
+			format:   "json",
+			segments: nil,
+			expected: `{
+				"redundancy": {"total": 5, "required": 2, "optimalThreshold": 4, "repairThreshold": 3},
+				"segments": []
+			}`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			Format = tt.format
+
+			var out bytes.Buffer
+			require.NoError(t, printHealth(&out, redundancy, tt.segments))
+
+			if tt.format == "json" {
+				require.True(t, json.Valid(out.Bytes()))
+				require.JSONEq(t, tt.expected, out.String())
+			} else {
+				require.Equal(t, tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestVerifyFormat(t *testing.T) {
+	defer func(format string) { Format = format }(Format)
+
+	for _, tt := range []struct {
+		format string
+		valid  bool
+	}{
+		{format: "csv", valid: true},
+		{format: "json", valid: true},
+		{format: "xml", valid: false},
+		{format: "", valid: false},
+	} {
+		Format = tt.format
+
+		err := verifyFormat()
+		if tt.valid {
+			require.NoError(t, err, tt.format)
+		} else {
+			require.True(t, ErrArgs.Has(err), tt.format)
+		}
+	}
+}
+
+func TestObjectEncryptedPath(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	pathFile := ctx.File("encrypted-path")
+	require.NoError(t, os.WriteFile(pathFile, []byte("ZnJvbS1maWxl\n"), 0644))
+
+	defer func(file string) { EncryptedPathFile = file }(EncryptedPathFile)
+
+	for _, tt := range []struct {
+		name     string
+		args     []string
+		file     string
+		expected string
+		errText  string
+	}{
+		{
+			name:     "positional argument",
+			args:     []string{"project", "bucket", "cG9zaXRpb25hbA=="},
+			expected: "cG9zaXRpb25hbA==",
+		},
+		{
+			name:     "file",
+			args:     []string{"project", "bucket"},
+			file:     pathFile,
+			expected: "ZnJvbS1maWxl",
+		},
+		{
+			name:     "file with empty positional argument",
+			args:     []string{"project", "bucket", ""},
+			file:     pathFile,
+			expected: "ZnJvbS1maWxl",
+		},
+		{
+			name:    "positional argument and file",
+			args:    []string{"project", "bucket", "cG9zaXRpb25hbA=="},
+			file:    pathFile,
+			errText: "mutually exclusive",
+		},
+		{
+			name:    "missing",
+			args:    []string{"project", "bucket"},
+			errText: "is required",
+		},
+		{
+			name:    "missing file",
+			args:    []string{"project", "bucket"},
+			file:    ctx.File("missing"),
+			errText: "no such file",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			EncryptedPathFile = tt.file
+
+			path, err := objectEncryptedPath(tt.args)
+			if tt.errText != "" {
+				require.True(t, ErrArgs.Has(err))
+				require.Contains(t, err.Error(), tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, path)
+		})
+	}
+}
+
+func TestSummarizeObjectHealth(t *testing.T) {
+	node1, node2, node3 := storj.NodeID{1}, storj.NodeID{2}, storj.NodeID{3}
+
+	for _, tt := range []struct {
+		name     string
+		segments []*internalpb.SegmentHealth
+		expected []string
+	}{
+		{
+			name:     "no segments",
+			expected: []string{"ok", "0", "0", "0", "0"},
+		},
+		{
+			name: "single segment",
+			segments: []*internalpb.SegmentHealth{
+				{HealthyIds: []storj.NodeID{node1, node2}, OfflineIds: []storj.NodeID{node3}},
+			},
+			expected: []string{"ok", "1", "2", "0", "1"},
+		},
+		{
+			name: "minimum healthy of all segments",
+			segments: []*internalpb.SegmentHealth{
+				{HealthyIds: []storj.NodeID{node1, node2, node3}},
+				{HealthyIds: []storj.NodeID{node1}, UnhealthyIds: []storj.NodeID{node2}, OfflineIds: []storj.NodeID{node3}},
+				{HealthyIds: []storj.NodeID{node1, node2}, UnhealthyIds: []storj.NodeID{node3}},
+			},
+			expected: []string{"ok", "3", "1", "2", "1"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, summarizeObjectHealth(tt.segments))
+		})
+	}
+}
+
+func TestRequestError(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	inspector := &Inspector{address: "127.0.0.1:7778"}
+
+	expiredCtx, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	for _, tt := range []struct {
+		name     string
+		ctx      context.Context
+		err      error
+		timedOut bool
+	}{
+		{name: "expired context", ctx: expiredCtx, err: errors.New("canceled"), timedOut: true},
+		{name: "deadline exceeded", ctx: ctx, err: context.DeadlineExceeded, timedOut: true},
+		{name: "other error", ctx: ctx, err: errors.New("failure"), timedOut: false},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := inspector.requestError(tt.ctx, tt.err)
+			require.True(t, ErrRequest.Has(err))
+			require.ErrorIs(t, err, tt.err)
+
+			if tt.timedOut {
+				require.Contains(t, err.Error(), "timed out")
+				require.Contains(t, err.Error(), inspector.address)
+			} else {
+				require.NotContains(t, err.Error(), "timed out")
+			}
+		})
+	}
+}
@@ -53,6 +53,12 @@ func VerifyCommand(log *zap.Logger) *cobra.Command {
 	flag.Float64Var(&verifyConfig.Loop.RateLimit, "loop.rate-limit", 0, "rate limit (default is 0 which is unlimited segments per second)")
 	flag.IntVar(&verifyConfig.Loop.ListLimit, "loop.list-limit", 2500, "how many items to query in a batch")
 
+	flag.BoolVar(&verifyConfig.VerifyPieceCounts, "verify-piece-counts", true, "verify that segment piece counts are within redundancy bounds")
+
+	flag.StringVar(&verifyConfig.ReportJSON, "report-json", "", "path where to write the verification report as JSON (disabled when empty)")
+
+	flag.IntVar(&verifyConfig.Workers, "workers", 1, "number of workers verifying segments concurrently")
+
 	flag.Int64Var(&verifyConfig.ProgressPrintFrequency, "progress-frequency", 1000000, "how often should we print progress (every object)")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
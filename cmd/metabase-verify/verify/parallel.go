@@ -0,0 +1,148 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package verify
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/segmentloop"
+)
+
+// Parallel distributes segments from the loop between workers, where each
+// worker has its own set of observers.
+//
+// Segments of the same stream are always handled by the same worker and in
+// the loop order, so observers that track per stream state keep working.
+// Segments are still produced by a single loop, hence the loop rate limit
+// applies to all workers together.
+type Parallel struct {
+	workers []*parallelWorker
+
+	mu  sync.Mutex
+	err error
+	// failed is closed when any of the workers fails.
+	failed chan struct{}
+}
+
+type parallelWorker struct {
+	observers []segmentloop.Observer
+	queue     chan *segmentloop.Segment
+}
+
+// NewParallel creates a new parallel observer, with a worker for each set of observers.
+func NewParallel(observers [][]segmentloop.Observer, queueSize int) *Parallel {
+	parallel := &Parallel{
+		failed: make(chan struct{}),
+	}
+	for _, workerObservers := range observers {
+		parallel.workers = append(parallel.workers, &parallelWorker{
+			observers: workerObservers,
+			queue:     make(chan *segmentloop.Segment, queueSize),
+		})
+	}
+	return parallel
+}
+
+// Run joins the loop and processes segments until the loop finishes.
+func (parallel *Parallel) Run(ctx context.Context, loop *segmentloop.Service) error {
+	var wg sync.WaitGroup
+	for _, worker := range parallel.workers {
+		worker := worker
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := worker.process(ctx); err != nil {
+				parallel.fail(err)
+				// drain the queue so the loop doesn't block on a failed worker
+				for range worker.queue {
+				}
+			}
+		}()
+	}
+
+	err := loop.Join(ctx, parallel)
+
+	for _, worker := range parallel.workers {
+		close(worker.queue)
+	}
+	wg.Wait()
+
+	parallel.mu.Lock()
+	defer parallel.mu.Unlock()
+	return errs.Combine(err, parallel.err)
+}
+
+// fail records the first worker error and stops dispatching segments.
+func (parallel *Parallel) fail(err error) {
+	if err == nil {
+		return
+	}
+
+	parallel.mu.Lock()
+	defer parallel.mu.Unlock()
+	if parallel.err == nil {
+		parallel.err = err
+		close(parallel.failed)
+	}
+}
+
+// LoopStarted is called at each start of a loop.
+func (parallel *Parallel) LoopStarted(ctx context.Context, info segmentloop.LoopInfo) error {
+	for _, worker := range parallel.workers {
+		for _, observer := range worker.observers {
+			if err := observer.LoopStarted(ctx, info); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RemoteSegment implements the Observer interface.
+func (parallel *Parallel) RemoteSegment(ctx context.Context, seg *segmentloop.Segment) error {
+	return parallel.dispatch(ctx, seg)
+}
+
+// InlineSegment implements the Observer interface.
+func (parallel *Parallel) InlineSegment(ctx context.Context, seg *segmentloop.Segment) error {
+	return parallel.dispatch(ctx, seg)
+}
+
+func (parallel *Parallel) dispatch(ctx context.Context, seg *segmentloop.Segment) error {
+	// the loop reuses the entry, so the worker needs its own copy
+	copied := *seg
+	copied.Pieces = append(metabase.Pieces(nil), seg.Pieces...)
+
+	worker := parallel.workers[binary.BigEndian.Uint64(seg.StreamID[:8])%uint64(len(parallel.workers))]
+	select {
+	case worker.queue <- &copied:
+		return nil
+	case <-parallel.failed:
+		return Error.New("worker failed")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (worker *parallelWorker) process(ctx context.Context) error {
+	for seg := range worker.queue {
+		for _, observer := range worker.observers {
+			var err error
+			if seg.Inline() {
+				err = observer.InlineSegment(ctx, seg)
+			} else {
+				err = observer.RemoteSegment(ctx, seg)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
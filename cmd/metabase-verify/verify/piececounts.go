@@ -0,0 +1,122 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package verify
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/segmentloop"
+)
+
+// maxPieceCountIssues is the maximum number of issues kept in memory,
+// the remaining ones are only logged and counted.
+const maxPieceCountIssues = 1000
+
+// PieceCountIssue describes a segment with a piece count outside of its redundancy bounds.
+type PieceCountIssue struct {
+	StreamID uuid.UUID
+	Position metabase.SegmentPosition
+
+	Pieces       int
+	RepairShares int16
+	TotalShares  int16
+}
+
+// PieceCounts verifies that remote segments have at least repair shares
+// and at most total shares pieces.
+type PieceCounts struct {
+	Log    *zap.Logger
+	Report *Report
+
+	BelowRepairCount int64
+	AboveTotalCount  int64
+
+	Issues []PieceCountIssue
+}
+
+// MergePieceCounts combines results of piece count verifications, which
+// handled different streams, into a single result.
+func MergePieceCounts(log *zap.Logger, pieceCounts ...*PieceCounts) *PieceCounts {
+	if len(pieceCounts) == 0 {
+		return nil
+	}
+
+	merged := &PieceCounts{
+		Log:    log,
+		Report: pieceCounts[0].Report,
+	}
+	for _, counts := range pieceCounts {
+		merged.BelowRepairCount += counts.BelowRepairCount
+		merged.AboveTotalCount += counts.AboveTotalCount
+		merged.Issues = append(merged.Issues, counts.Issues...)
+	}
+
+	// each verification keeps the issues in the loop order, so keeping the lowest
+	// issues gives the same result as a single verification over all streams.
+	sort.Slice(merged.Issues, func(i, k int) bool {
+		a, b := merged.Issues[i], merged.Issues[k]
+		if a.StreamID != b.StreamID {
+			return a.StreamID.Less(b.StreamID)
+		}
+		return a.Position.Less(b.Position)
+	})
+	if len(merged.Issues) > maxPieceCountIssues {
+		merged.Issues = merged.Issues[:maxPieceCountIssues]
+	}
+	return merged
+}
+
+// LoopStarted is called at each start of a loop.
+func (verify *PieceCounts) LoopStarted(ctx context.Context, info segmentloop.LoopInfo) (err error) {
+	return nil
+}
+
+// RemoteSegment implements the Observer interface.
+func (verify *PieceCounts) RemoteSegment(ctx context.Context, seg *segmentloop.Segment) error {
+	pieces := len(seg.Pieces)
+
+	switch {
+	case pieces < int(seg.Redundancy.RepairShares):
+		verify.BelowRepairCount++
+		verify.Report.Add(CheckPiecesBelowRepair, seg)
+		verify.Log.Error("piece count below repair threshold",
+			zap.Any("stream_id", seg.StreamID.String()),
+			zap.Any("position", seg.Position),
+
+			zap.Int("pieces", pieces),
+			zap.Int16("repair shares", seg.Redundancy.RepairShares))
+	case pieces > int(seg.Redundancy.TotalShares):
+		verify.AboveTotalCount++
+		verify.Report.Add(CheckPiecesAboveTotal, seg)
+		verify.Log.Error("piece count above total shares",
+			zap.Any("stream_id", seg.StreamID.String()),
+			zap.Any("position", seg.Position),
+
+			zap.Int("pieces", pieces),
+			zap.Int16("total shares", seg.Redundancy.TotalShares))
+	default:
+		return nil
+	}
+
+	if len(verify.Issues) < maxPieceCountIssues {
+		verify.Issues = append(verify.Issues, PieceCountIssue{
+			StreamID:     seg.StreamID,
+			Position:     seg.Position,
+			Pieces:       pieces,
+			RepairShares: seg.Redundancy.RepairShares,
+			TotalShares:  seg.Redundancy.TotalShares,
+		})
+	}
+	return nil
+}
+
+// InlineSegment implements the Observer interface.
+func (verify *PieceCounts) InlineSegment(ctx context.Context, seg *segmentloop.Segment) error {
+	return nil
+}
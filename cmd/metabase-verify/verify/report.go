@@ -0,0 +1,115 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package verify
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/segmentloop"
+)
+
+// Names of the checks included in the report.
+const (
+	CheckPlainSize         = "plain-size"
+	CheckPlainOffset       = "plain-offset"
+	CheckPiecesBelowRepair = "pieces-below-repair"
+	CheckPiecesAboveTotal  = "pieces-above-total"
+)
+
+// maxReportSamplesPerCheck is the number of offending segments kept per check.
+const maxReportSamplesPerCheck = 10
+
+// Report contains findings of all checks of a verification run.
+type Report struct {
+	mu     sync.Mutex
+	Checks map[string]*CheckReport `json:"checks"`
+}
+
+// CheckReport contains findings of a single check.
+type CheckReport struct {
+	Count   int64        `json:"count"`
+	Samples []SegmentKey `json:"samples"`
+}
+
+// SegmentKey identifies an offending segment.
+type SegmentKey struct {
+	StreamID uuid.UUID `json:"stream_id"`
+	Part     uint32    `json:"part"`
+	Index    uint32    `json:"index"`
+}
+
+// Less returns whether key is ordered before other.
+func (key SegmentKey) Less(other SegmentKey) bool {
+	if key.StreamID != other.StreamID {
+		return key.StreamID.Less(other.StreamID)
+	}
+	return metabase.SegmentPosition{Part: key.Part, Index: key.Index}.Less(
+		metabase.SegmentPosition{Part: other.Part, Index: other.Index})
+}
+
+// NewReport creates a report with the specified checks.
+func NewReport(checks ...string) *Report {
+	report := &Report{
+		Checks: map[string]*CheckReport{},
+	}
+	for _, check := range checks {
+		report.Checks[check] = &CheckReport{Samples: []SegmentKey{}}
+	}
+	return report
+}
+
+// Add records a finding of check for segment.
+//
+// Only the lowest keys are kept as samples, so that the report doesn't depend
+// on the order the findings were added.
+func (report *Report) Add(check string, seg *segmentloop.Segment) {
+	if report == nil {
+		return
+	}
+
+	report.mu.Lock()
+	defer report.mu.Unlock()
+
+	checkReport, ok := report.Checks[check]
+	if !ok {
+		checkReport = &CheckReport{Samples: []SegmentKey{}}
+		report.Checks[check] = checkReport
+	}
+	checkReport.Count++
+
+	key := SegmentKey{
+		StreamID: seg.StreamID,
+		Part:     seg.Position.Part,
+		Index:    seg.Position.Index,
+	}
+	at := sort.Search(len(checkReport.Samples), func(i int) bool {
+		return key.Less(checkReport.Samples[i])
+	})
+	if at >= maxReportSamplesPerCheck {
+		return
+	}
+	checkReport.Samples = append(checkReport.Samples, SegmentKey{})
+	copy(checkReport.Samples[at+1:], checkReport.Samples[at:])
+	checkReport.Samples[at] = key
+	if len(checkReport.Samples) > maxReportSamplesPerCheck {
+		checkReport.Samples = checkReport.Samples[:maxReportSamplesPerCheck]
+	}
+}
+
+// WriteJSON writes the report as JSON to the specified path.
+func (report *Report) WriteJSON(path string) error {
+	report.mu.Lock()
+	defer report.mu.Unlock()
+
+	data, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(os.WriteFile(path, data, 0644))
+}
@@ -14,7 +14,8 @@ import (
 
 // SegmentSizes verifies segments table plain_offset and plain_size.
 type SegmentSizes struct {
-	Log *zap.Logger
+	Log    *zap.Logger
+	Report *Report
 
 	segmentState
 }
@@ -48,6 +49,7 @@ func (verify *SegmentSizes) advanceSegment(ctx context.Context, seg *segmentloop
 	}
 
 	if seg.PlainSize > seg.EncryptedSize {
+		verify.Report.Add(CheckPlainSize, seg)
 		verify.Log.Error("plain size larger than encrypted size",
 			zap.Any("stream_id", seg.StreamID.String()),
 			zap.Any("position", seg.Position),
@@ -57,6 +59,7 @@ func (verify *SegmentSizes) advanceSegment(ctx context.Context, seg *segmentloop
 	}
 
 	if verify.ExpectedOffset != seg.PlainOffset {
+		verify.Report.Add(CheckPlainOffset, seg)
 		verify.Log.Error("invalid offset",
 			zap.Any("stream_id", seg.StreamID.String()),
 			zap.Any("position", seg.Position),
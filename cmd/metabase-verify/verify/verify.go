@@ -23,11 +23,21 @@ type Chore struct {
 	Config Config
 
 	DB segmentloop.MetabaseDB
+
+	// PieceCounts contains the results of the piece count verification
+	// from the last RunOnce, when it's enabled.
+	PieceCounts *PieceCounts
+
+	// Report contains the findings of the last RunOnce.
+	Report *Report
 }
 
 // Config contains configuration for all the services.
 type Config struct {
 	ProgressPrintFrequency int64
+	VerifyPieceCounts      bool
+	ReportJSON             string
+	Workers                int
 	Loop                   segmentloop.Config
 }
 
@@ -44,14 +54,53 @@ func New(log *zap.Logger, mdb segmentloop.MetabaseDB, config Config) *Chore {
 func (chore *Chore) RunOnce(ctx context.Context) error {
 	loop := segmentloop.New(chore.Log, chore.Config.Loop, chore.DB)
 
+	chore.PieceCounts = nil
+
+	checks := []string{CheckPlainSize, CheckPlainOffset}
+	if chore.Config.VerifyPieceCounts {
+		checks = append(checks, CheckPiecesBelowRepair, CheckPiecesAboveTotal)
+	}
+	report := NewReport(checks...)
+	chore.Report = report
+
+	workers := chore.Config.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var pieceCounts []*PieceCounts
+	observers := make([][]segmentloop.Observer, workers)
+	for i := range observers {
+		observers[i] = append(observers[i], &SegmentSizes{
+			Log:    chore.Log.Named("segment-sizes"),
+			Report: report,
+		})
+		if chore.Config.VerifyPieceCounts {
+			counts := &PieceCounts{
+				Log:    chore.Log.Named("piece-counts"),
+				Report: report,
+			}
+			pieceCounts = append(pieceCounts, counts)
+			observers[i] = append(observers[i], counts)
+		}
+	}
+
 	var group errs2.Group
-	group.Go(func() error {
-		plainOffset := &SegmentSizes{
-			Log: chore.Log.Named("segment-sizes"),
+	if workers == 1 {
+		for _, observer := range observers[0] {
+			observer := observer
+			group.Go(func() error {
+				err := loop.Join(ctx, observer)
+				return Error.Wrap(err)
+			})
 		}
-		err := loop.Join(ctx, plainOffset)
-		return Error.Wrap(err)
-	})
+	} else {
+		parallel := NewParallel(observers, chore.Config.Loop.ListLimit)
+		group.Go(func() error {
+			err := parallel.Run(ctx, loop)
+			return Error.Wrap(err)
+		})
+	}
 
 	group.Go(func() error {
 		progress := &ProgressObserver{
@@ -65,5 +114,14 @@ func (chore *Chore) RunOnce(ctx context.Context) error {
 	group.Go(func() error {
 		return Error.Wrap(loop.RunOnce(ctx))
 	})
-	return Error.Wrap(errs.Combine(group.Wait()...))
+	if err := errs.Combine(group.Wait()...); err != nil {
+		return Error.Wrap(err)
+	}
+
+	chore.PieceCounts = MergePieceCounts(chore.Log.Named("piece-counts"), pieceCounts...)
+
+	if chore.Config.ReportJSON != "" {
+		return report.WriteJSON(chore.Config.ReportJSON)
+	}
+	return nil
 }
@@ -0,0 +1,181 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package verify_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/cmd/metabase-verify/verify"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+	"storj.io/storj/satellite/metabase/segmentloop"
+)
+
+func TestPieceCounts(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		// healthy segments
+		metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 2)
+
+		// segment below the repair threshold
+		belowRepair := metabasetest.RandObjectStream()
+		redundancy := metabasetest.DefaultRedundancy
+		redundancy.RepairShares = 2
+		redundancy.TotalShares = 3
+		metabasetest.CreateTestObject{
+			Redundancy: &redundancy,
+		}.Run(ctx, t, db, belowRepair, 1)
+
+		// segment with more pieces than total shares
+		aboveTotal := metabasetest.RandObjectStream()
+		_, segments := metabasetest.CreateTestObject{}.Run(ctx, t, db, aboveTotal, 1)
+		err := db.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+			StreamID:      aboveTotal.StreamID,
+			Position:      segments[0].Position,
+			OldPieces:     segments[0].Pieces,
+			NewRedundancy: segments[0].Redundancy,
+			NewPieces: metabase.Pieces{
+				{Number: 0, StorageNode: testrand.NodeID()},
+				{Number: 1, StorageNode: testrand.NodeID()},
+			},
+		})
+		require.NoError(t, err)
+
+		chore := verify.New(zaptest.NewLogger(t), db, verify.Config{
+			ProgressPrintFrequency: 1000,
+			VerifyPieceCounts:      true,
+			Loop: segmentloop.Config{
+				CoalesceDuration: time.Second,
+				ListLimit:        2,
+			},
+		})
+		require.NoError(t, chore.RunOnce(ctx))
+
+		require.NotNil(t, chore.PieceCounts)
+		require.EqualValues(t, 1, chore.PieceCounts.BelowRepairCount)
+		require.EqualValues(t, 1, chore.PieceCounts.AboveTotalCount)
+		require.ElementsMatch(t, []verify.PieceCountIssue{
+			{
+				StreamID:     belowRepair.StreamID,
+				Position:     metabase.SegmentPosition{},
+				Pieces:       1,
+				RepairShares: 2,
+				TotalShares:  3,
+			},
+			{
+				StreamID:     aboveTotal.StreamID,
+				Position:     metabase.SegmentPosition{},
+				Pieces:       2,
+				RepairShares: 1,
+				TotalShares:  1,
+			},
+		}, chore.PieceCounts.Issues)
+
+		// disabled verification shouldn't report anything
+		chore.Config.VerifyPieceCounts = false
+		require.NoError(t, chore.RunOnce(ctx))
+		require.Nil(t, chore.PieceCounts)
+	})
+}
+
+func TestReportJSON(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 3)
+
+		redundancy := metabasetest.DefaultRedundancy
+		redundancy.RepairShares = 2
+		redundancy.TotalShares = 3
+		belowRepair := metabasetest.RandObjectStream()
+		metabasetest.CreateTestObject{
+			Redundancy: &redundancy,
+		}.Run(ctx, t, db, belowRepair, 2)
+
+		reportPath := ctx.File("report.json")
+		chore := verify.New(zaptest.NewLogger(t), db, verify.Config{
+			ProgressPrintFrequency: 1000,
+			VerifyPieceCounts:      true,
+			ReportJSON:             reportPath,
+			Loop: segmentloop.Config{
+				CoalesceDuration: time.Second,
+				ListLimit:        2,
+			},
+		})
+		require.NoError(t, chore.RunOnce(ctx))
+
+		data, err := os.ReadFile(reportPath)
+		require.NoError(t, err)
+
+		var report struct {
+			Checks map[string]struct {
+				Count   int64 `json:"count"`
+				Samples []struct {
+					StreamID uuid.UUID `json:"stream_id"`
+					Part     uint32    `json:"part"`
+					Index    uint32    `json:"index"`
+				} `json:"samples"`
+			} `json:"checks"`
+		}
+		require.NoError(t, json.Unmarshal(data, &report))
+
+		require.Len(t, report.Checks, 4)
+		require.Zero(t, report.Checks[verify.CheckPlainSize].Count)
+		require.Zero(t, report.Checks[verify.CheckPlainOffset].Count)
+		require.Zero(t, report.Checks[verify.CheckPiecesAboveTotal].Count)
+
+		below := report.Checks[verify.CheckPiecesBelowRepair]
+		require.EqualValues(t, 2, below.Count)
+		require.Len(t, below.Samples, 2)
+		for i, sample := range below.Samples {
+			require.Equal(t, belowRepair.StreamID, sample.StreamID)
+			require.EqualValues(t, 0, sample.Part)
+			require.EqualValues(t, i, sample.Index)
+		}
+	})
+}
+
+func TestWorkers(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		redundancy := metabasetest.DefaultRedundancy
+		redundancy.RepairShares = 2
+		redundancy.TotalShares = 3
+
+		for i := 0; i < 10; i++ {
+			metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 2)
+			metabasetest.CreateTestObject{
+				Redundancy: &redundancy,
+			}.Run(ctx, t, db, metabasetest.RandObjectStream(), 3)
+		}
+
+		run := func(workers int) *verify.Chore {
+			chore := verify.New(zaptest.NewLogger(t), db, verify.Config{
+				ProgressPrintFrequency: 1000,
+				VerifyPieceCounts:      true,
+				Workers:                workers,
+				Loop: segmentloop.Config{
+					CoalesceDuration: time.Second,
+					ListLimit:        4,
+				},
+			})
+			require.NoError(t, chore.RunOnce(ctx))
+			return chore
+		}
+
+		sequential := run(1)
+		parallel := run(4)
+
+		require.EqualValues(t, 30, sequential.PieceCounts.BelowRepairCount)
+		require.Equal(t, sequential.PieceCounts.BelowRepairCount, parallel.PieceCounts.BelowRepairCount)
+		require.Equal(t, sequential.PieceCounts.AboveTotalCount, parallel.PieceCounts.AboveTotalCount)
+		require.Equal(t, sequential.PieceCounts.Issues, parallel.PieceCounts.Issues)
+		require.Equal(t, sequential.Report.Checks, parallel.Report.Checks)
+	})
+}
@@ -5,11 +5,18 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
@@ -65,6 +72,37 @@ $ multinode add node.json
 
 # read nodes data from stdin
 $ cat nodes.json | multinode add -
+
+# add nodes from csv file with columns id,publicAddress,apiSecret,name
+$ multinode add nodes.csv
+
+# read csv nodes data from stdin
+$ cat nodes.csv | multinode add --format csv -
+
+# update name, public address and api secret of already added nodes
+$ multinode add --update nodes.json
+
+# check that every node is reachable before adding any of them
+$ multinode add --verify nodes.json
+
+# read the api secret from an environment variable instead of the command line
+$ multinode add --node-id <id> --public-address <address> --api-secret-env NODE_API_SECRET
+
+# api secrets in json and csv files can also refer to environment variables
+$ echo '{"id": "<id>", "publicAddress": "<address>", "apiSecret": "${ENV:NODE_API_SECRET}"}' | multinode add -
+`,
+	}
+	listCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List storage nodes added to multinode dashboard",
+		RunE:  cmdList,
+		Args:  cobra.NoArgs,
+		Example: `
+# print a table of added nodes
+$ multinode list
+
+# export nodes in the format accepted by multinode add
+$ multinode list --json --include-secrets > nodes.json
 `,
 	}
 
@@ -74,7 +112,17 @@ $ cat nodes.json | multinode add -
 		NodeID        string `help:"ID of the storage node" default:""`
 		Name          string `help:"Name of the storage node" default:""`
 		APISecret     string `help:"API Secret of the storage node" default:""`
+		APISecretEnv  string `help:"Name of the environment variable holding the API Secret of the storage node" default:""`
 		PublicAddress string `help:"Public IP Address of the storage node" default:""`
+		Update        bool   `help:"Update name, public address and api secret of already added nodes instead of failing" default:"false"`
+		Verify        bool   `help:"Check that all nodes are reachable and accept their api secrets before adding any of them" default:"false"`
+		Format        string `help:"Format of the nodes file (json|csv), detected from the file extension when empty" default:""`
+
+		Config
+	}
+	listCfg struct {
+		JSON           bool `help:"Print nodes as json, in the format accepted by the add command" default:"false"`
+		IncludeSecrets bool `help:"Include api secrets of the nodes in the json output" default:"false"`
 
 		Config
 	}
@@ -96,10 +144,12 @@ func init() {
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(listCmd)
 
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(addCmd, &addCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(listCmd, &listCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 }
 
 func cmdSetup(cmd *cobra.Command, args []string) (err error) {
@@ -157,7 +207,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 type nodeInfo struct {
 	NodeID        storj.NodeID `json:"id"`
 	PublicAddress string       `json:"publicAddress"`
-	APISecret     string       `json:"apiSecret"`
+	APISecret     string       `json:"apiSecret,omitempty"`
 	Name          string       `json:"name"`
 }
 
@@ -189,10 +239,14 @@ func cmdAdd(cmd *cobra.Command, args []string) (err error) {
 
 	var nodeList []nodeInfo
 
-	hasRequiredFlags := addCfg.NodeID != "" && addCfg.APISecret != "" && addCfg.PublicAddress != ""
+	if addCfg.APISecret != "" && addCfg.APISecretEnv != "" {
+		return errs.New("only one of --api-secret and --api-secret-env can be provided")
+	}
+
+	hasRequiredFlags := addCfg.NodeID != "" && (addCfg.APISecret != "" || addCfg.APISecretEnv != "") && addCfg.PublicAddress != ""
 
 	if len(args) == 0 && !hasRequiredFlags {
-		return errs.New("--node-id, --api-secret and --public-address flags are required if no file is provided")
+		return errs.New("--node-id, --api-secret (or --api-secret-env) and --public-address flags are required if no file is provided")
 	}
 
 	if hasRequiredFlags {
@@ -200,11 +254,15 @@ func cmdAdd(cmd *cobra.Command, args []string) (err error) {
 		if err != nil {
 			return err
 		}
+		apiSecret := addCfg.APISecret
+		if addCfg.APISecretEnv != "" {
+			apiSecret = envSecretPrefix + addCfg.APISecretEnv + envSecretSuffix
+		}
 		nodeList = []nodeInfo{
 			{
 				NodeID:        nodeID,
 				PublicAddress: addCfg.PublicAddress,
-				APISecret:     addCfg.APISecret,
+				APISecret:     apiSecret,
 				Name:          addCfg.Name,
 			},
 		}
@@ -225,29 +283,75 @@ func cmdAdd(cmd *cobra.Command, args []string) (err error) {
 			}
 		}
 
-		nodeList, err = unmarshalJSONNodes(nodesData)
+		format := strings.ToLower(addCfg.Format)
+		if format == "" {
+			format = "json"
+			if strings.EqualFold(filepath.Ext(path), ".csv") {
+				format = "csv"
+			}
+		}
+
+		switch format {
+		case "json":
+			nodeList, err = unmarshalJSONNodes(nodesData)
+		case "csv":
+			nodeList, err = unmarshalCSVNodes(nodesData)
+		default:
+			return errs.New("unsupported format %q, expected json or csv", addCfg.Format)
+		}
 		if err != nil {
 			return err
 		}
 	}
 
+	service := nodes.NewService(log, dialer, db.Nodes())
+
+	dashboardNodes := make([]nodes.Node, 0, len(nodeList))
+	existing := make(map[storj.NodeID]bool, len(nodeList))
+
 	for _, node := range nodeList {
-		if _, err := db.Nodes().Get(ctx, node.NodeID); err == nil {
+		_, err := db.Nodes().Get(ctx, node.NodeID)
+		exists := err == nil
+		if exists && !addCfg.Update {
 			return errs.New("Node with ID %s is already added to the multinode dashboard", node.NodeID)
 		}
+		existing[node.NodeID] = exists
 
-		apiSecret, err := multinodeauth.SecretFromBase64(node.APISecret)
+		apiSecret, err := resolveAPISecret(node.APISecret)
 		if err != nil {
-			return err
+			return errs.New("invalid api secret of node %s: %w", node.NodeID, err)
 		}
 
-		service := nodes.NewService(log, dialer, db.Nodes())
-		err = service.Add(ctx, nodes.Node{
+		dashboardNodes = append(dashboardNodes, nodes.Node{
 			ID:            node.NodeID,
 			APISecret:     apiSecret[:],
 			PublicAddress: node.PublicAddress,
 			Name:          node.Name,
 		})
+	}
+
+	// verifying all nodes upfront, so that a single broken entry doesn't leave the
+	// dashboard with only a part of the nodes added.
+	if addCfg.Verify {
+		for _, node := range dashboardNodes {
+			if err := service.Verify(ctx, node); err != nil {
+				return errs.New("node %s at %s failed verification: %w", node.ID, node.PublicAddress, err)
+			}
+		}
+	}
+
+	for _, node := range dashboardNodes {
+		switch {
+		// the nodes were verified already, so they are stored without dialing them again.
+		case addCfg.Verify && existing[node.ID]:
+			err = nodes.Error.Wrap(db.Nodes().Update(ctx, node))
+		case addCfg.Verify:
+			err = nodes.Error.Wrap(db.Nodes().Add(ctx, node))
+		case existing[node.ID]:
+			err = service.Update(ctx, node)
+		default:
+			err = service.Add(ctx, node)
+		}
 		if err != nil {
 			return err
 		}
@@ -256,6 +360,91 @@ func cmdAdd(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+const (
+	envSecretPrefix = "${ENV:"
+	envSecretSuffix = "}"
+)
+
+// resolveAPISecret decodes a base64 api secret. A value of the form ${ENV:NAME}
+// is replaced with the contents of the environment variable NAME first, so
+// that secrets don't have to be stored in files or passed on the command line.
+func resolveAPISecret(value string) (multinodeauth.Secret, error) {
+	if strings.HasPrefix(value, envSecretPrefix) && strings.HasSuffix(value, envSecretSuffix) {
+		name := strings.TrimSuffix(strings.TrimPrefix(value, envSecretPrefix), envSecretSuffix)
+		if name == "" {
+			return multinodeauth.Secret{}, errs.New("empty environment variable name")
+		}
+
+		secret, ok := os.LookupEnv(name)
+		if !ok || secret == "" {
+			return multinodeauth.Secret{}, errs.New("environment variable %s is not set", name)
+		}
+		value = secret
+	}
+
+	return multinodeauth.SecretFromBase64(value)
+}
+
+func cmdList(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	db, err := multinodedb.Open(ctx, log.Named("db"), listCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on multinode: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	nodeList, err := listNodes(ctx, db.Nodes(), listCfg.IncludeSecrets)
+	if err != nil {
+		return err
+	}
+
+	if listCfg.JSON {
+		return writeJSONNodes(cmd.OutOrStdout(), nodeList)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tName\tPublic Address\t")
+	for _, node := range nodeList {
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", node.NodeID, node.Name, node.PublicAddress)
+	}
+	return w.Flush()
+}
+
+// listNodes returns all nodes added to the dashboard. Api secrets are left
+// empty unless includeSecrets is set.
+func listNodes(ctx context.Context, db nodes.DB, includeSecrets bool) ([]nodeInfo, error) {
+	list, err := db.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeList := make([]nodeInfo, 0, len(list))
+	for _, node := range list {
+		info := nodeInfo{
+			NodeID:        node.ID,
+			PublicAddress: node.PublicAddress,
+			Name:          node.Name,
+		}
+		if includeSecrets {
+			info.APISecret = base64.URLEncoding.EncodeToString(node.APISecret)
+		}
+		nodeList = append(nodeList, info)
+	}
+
+	return nodeList, nil
+}
+
+// writeJSONNodes writes nodes as a json array, which can be read back by unmarshalJSONNodes.
+func writeJSONNodes(w io.Writer, nodeList []nodeInfo) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(nodeList)
+}
+
 func unmarshalJSONNodes(nodesData []byte) ([]nodeInfo, error) {
 	var nodes []nodeInfo
 	nodesData = bytes.TrimLeft(nodesData, " \t\r\n")
@@ -279,3 +468,56 @@ func unmarshalJSONNodes(nodesData []byte) ([]nodeInfo, error) {
 
 	return nodes, nil
 }
+
+// unmarshalCSVNodes parses nodes from csv data with the columns id, publicAddress,
+// apiSecret and an optional name. The data may start with a header row.
+func unmarshalCSVNodes(nodesData []byte) ([]nodeInfo, error) {
+	r := csv.NewReader(bytes.NewReader(nodesData))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var nodes []nodeInfo
+	for row := 1; ; row++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errs.New("invalid CSV format: %v", err)
+		}
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+
+		if row == 1 && strings.EqualFold(record[0], "id") {
+			continue
+		}
+
+		if len(record) != 3 && len(record) != 4 {
+			return nil, errs.New("invalid CSV format: expected 3 or 4 columns in row %d, got %d", row, len(record))
+		}
+
+		nodeID, err := storj.NodeIDFromString(record[0])
+		if err != nil {
+			return nil, err
+		}
+
+		node := nodeInfo{
+			NodeID:        nodeID,
+			PublicAddress: record[1],
+			APISecret:     record[2],
+		}
+		if len(record) == 4 {
+			node.Name = record[3]
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		return nil, errs.New("invalid CSV format: no nodes")
+	}
+
+	return nodes, nil
+}
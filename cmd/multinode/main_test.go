@@ -4,11 +4,19 @@
 package main
 
 import (
+	"bytes"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode"
+	"storj.io/storj/multinode/multinodedb/multinodedbtest"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/private/multinodeauth"
 )
 
 func Test_unmarshalJSONNodes(t *testing.T) {
@@ -65,3 +73,170 @@ func Test_unmarshalJSONNodes(t *testing.T) {
 		require.Equal(t, expectedNodeInfo, got)
 	})
 }
+
+func Test_unmarshalCSVNodes(t *testing.T) {
+	nodeID, err := storj.NodeIDFromString("1MJ7R1cqGrFnELPY3YKd62TBJ6vE8x9yPKPwUFHUx6G8oypezR")
+	require.NoError(t, err)
+
+	expectedNodeInfo := []nodeInfo{
+		{
+			NodeID:        nodeID,
+			PublicAddress: "awn7k09ts6mxbgau.myfritz.net:13010",
+			APISecret:     "b_yeI0OBKBusBVN4_dHxpxlwdTyoFPwtEuHv9ACl9jI=",
+			Name:          "Storagenode 1",
+		},
+	}
+
+	t.Run("valid csv without header", func(t *testing.T) {
+		nodesCSVData := `1MJ7R1cqGrFnELPY3YKd62TBJ6vE8x9yPKPwUFHUx6G8oypezR, awn7k09ts6mxbgau.myfritz.net:13010 , b_yeI0OBKBusBVN4_dHxpxlwdTyoFPwtEuHv9ACl9jI=,Storagenode 1
+`
+		got, err := unmarshalCSVNodes([]byte(nodesCSVData))
+		require.NoError(t, err)
+
+		require.Equal(t, expectedNodeInfo, got)
+	})
+
+	t.Run("valid csv with header", func(t *testing.T) {
+		nodesCSVData := `id,publicAddress,apiSecret,name
+1MJ7R1cqGrFnELPY3YKd62TBJ6vE8x9yPKPwUFHUx6G8oypezR,awn7k09ts6mxbgau.myfritz.net:13010,b_yeI0OBKBusBVN4_dHxpxlwdTyoFPwtEuHv9ACl9jI=,Storagenode 1
+`
+		got, err := unmarshalCSVNodes([]byte(nodesCSVData))
+		require.NoError(t, err)
+
+		require.Equal(t, expectedNodeInfo, got)
+	})
+
+	t.Run("name is optional", func(t *testing.T) {
+		nodesCSVData := `1MJ7R1cqGrFnELPY3YKd62TBJ6vE8x9yPKPwUFHUx6G8oypezR,awn7k09ts6mxbgau.myfritz.net:13010,b_yeI0OBKBusBVN4_dHxpxlwdTyoFPwtEuHv9ACl9jI=`
+
+		got, err := unmarshalCSVNodes([]byte(nodesCSVData))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, nodeID, got[0].NodeID)
+		require.Empty(t, got[0].Name)
+	})
+
+	t.Run("malformed rows", func(t *testing.T) {
+		for _, data := range []string{
+			``,
+			`id,publicAddress,apiSecret,name`,
+			`1MJ7R1cqGrFnELPY3YKd62TBJ6vE8x9yPKPwUFHUx6G8oypezR,awn7k09ts6mxbgau.myfritz.net:13010`,
+			`invalid-node-id,awn7k09ts6mxbgau.myfritz.net:13010,b_yeI0OBKBusBVN4_dHxpxlwdTyoFPwtEuHv9ACl9jI=`,
+		} {
+			_, err := unmarshalCSVNodes([]byte(data))
+			require.Error(t, err, data)
+		}
+	})
+}
+
+func Test_listNodes(t *testing.T) {
+	multinodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db multinode.DB) {
+		added := make(map[storj.NodeID]nodes.Node)
+		for i := 0; i < 3; i++ {
+			secret, err := multinodeauth.NewSecret()
+			require.NoError(t, err)
+
+			node := nodes.Node{
+				ID:            testrand.NodeID(),
+				APISecret:     secret[:],
+				PublicAddress: "127.0.0.1:1300" + strconv.Itoa(i),
+				Name:          "Storagenode " + strconv.Itoa(i+1),
+			}
+			require.NoError(t, db.Nodes().Add(ctx, node))
+			added[node.ID] = node
+		}
+
+		t.Run("with secrets", func(t *testing.T) {
+			list, err := listNodes(ctx, db.Nodes(), true)
+			require.NoError(t, err)
+			require.Len(t, list, len(added))
+
+			var buf bytes.Buffer
+			require.NoError(t, writeJSONNodes(&buf, list))
+
+			imported, err := unmarshalJSONNodes(buf.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, list, imported)
+
+			for _, info := range imported {
+				node, ok := added[info.NodeID]
+				require.True(t, ok)
+				require.Equal(t, node.PublicAddress, info.PublicAddress)
+				require.Equal(t, node.Name, info.Name)
+
+				secret, err := multinodeauth.SecretFromBase64(info.APISecret)
+				require.NoError(t, err)
+				require.Equal(t, node.APISecret, secret[:])
+			}
+		})
+
+		t.Run("without secrets", func(t *testing.T) {
+			list, err := listNodes(ctx, db.Nodes(), false)
+			require.NoError(t, err)
+			require.Len(t, list, len(added))
+
+			var buf bytes.Buffer
+			require.NoError(t, writeJSONNodes(&buf, list))
+			require.NotContains(t, buf.String(), "apiSecret")
+
+			imported, err := unmarshalJSONNodes(buf.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, list, imported)
+		})
+	})
+}
+
+func Test_resolveAPISecret(t *testing.T) {
+	secret, err := multinodeauth.NewSecret()
+	require.NoError(t, err)
+
+	t.Setenv("MULTINODE_TEST_API_SECRET", secret.String())
+
+	t.Run("plain value", func(t *testing.T) {
+		got, err := resolveAPISecret(secret.String())
+		require.NoError(t, err)
+		require.Equal(t, secret, got)
+	})
+
+	t.Run("environment variable", func(t *testing.T) {
+		got, err := resolveAPISecret("${ENV:MULTINODE_TEST_API_SECRET}")
+		require.NoError(t, err)
+		require.Equal(t, secret, got)
+	})
+
+	t.Run("missing environment variable", func(t *testing.T) {
+		_, err := resolveAPISecret("${ENV:MULTINODE_TEST_MISSING_API_SECRET}")
+		require.Error(t, err)
+
+		_, err = resolveAPISecret("${ENV:}")
+		require.Error(t, err)
+	})
+
+	t.Run("node is added with resolved secret", func(t *testing.T) {
+		multinodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db multinode.DB) {
+			nodesJSONData := `{
+	"name": "Storagenode 1",
+	"id": "1MJ7R1cqGrFnELPY3YKd62TBJ6vE8x9yPKPwUFHUx6G8oypezR",
+	"publicAddress": "awn7k09ts6mxbgau.myfritz.net:13010",
+	"apiSecret": "${ENV:MULTINODE_TEST_API_SECRET}"
+}`
+			nodeList, err := unmarshalJSONNodes([]byte(nodesJSONData))
+			require.NoError(t, err)
+			require.Len(t, nodeList, 1)
+
+			apiSecret, err := resolveAPISecret(nodeList[0].APISecret)
+			require.NoError(t, err)
+
+			require.NoError(t, db.Nodes().Add(ctx, nodes.Node{
+				ID:            nodeList[0].NodeID,
+				APISecret:     apiSecret[:],
+				PublicAddress: nodeList[0].PublicAddress,
+				Name:          nodeList[0].Name,
+			}))
+
+			node, err := db.Nodes().Get(ctx, nodeList[0].NodeID)
+			require.NoError(t, err)
+			require.Equal(t, secret[:], node.APISecret)
+		})
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// Cursor is the last processed row of a table, which can be used to resume the migration.
+//
+// It's formatted as "table:id", except for value_attributions, which is
+// formatted as "value_attributions:project_id:bucket_name". Values are hex encoded.
+type Cursor struct {
+	Table string
+	// ID is the primary key of the row, for value_attributions it's the project_id.
+	ID []byte
+	// BucketName is the bucket_name of a value_attributions row.
+	BucketName []byte
+}
+
+// String returns the cursor in the format accepted by --start-after-id.
+func (cursor Cursor) String() string {
+	s := cursor.Table + ":" + hex.EncodeToString(cursor.ID)
+	if cursor.Table == "value_attributions" {
+		s += ":" + hex.EncodeToString(cursor.BucketName)
+	}
+	return s
+}
+
+// ParseCursor parses a cursor from the --start-after-id format.
+func ParseCursor(s string) (cursor Cursor, err error) {
+	parts := strings.Split(s, ":")
+
+	cursor.Table = parts[0]
+	expectedParts := 2
+	if cursor.Table == "value_attributions" {
+		expectedParts = 3
+	}
+	if tableIndex(cursor.Table) < 0 {
+		return Cursor{}, errs.New("unknown table %q in cursor", cursor.Table)
+	}
+	if len(parts) != expectedParts {
+		return Cursor{}, errs.New("invalid cursor %q", s)
+	}
+
+	cursor.ID, err = hex.DecodeString(parts[1])
+	if err != nil {
+		return Cursor{}, errs.New("invalid cursor id: %w", err)
+	}
+	if expectedParts == 3 {
+		cursor.BucketName, err = hex.DecodeString(parts[2])
+		if err != nil {
+			return Cursor{}, errs.New("invalid cursor bucket name: %w", err)
+		}
+	}
+	return cursor, nil
+}
+
+// tableIndex returns the position of table in the migration order or -1 when it's not migrated.
+func tableIndex(table string) int {
+	for i, migrated := range migratedTables {
+		if migrated == table {
+			return i
+		}
+	}
+	return -1
+}
+
+// skipTable returns whether table was already migrated according to --start-after-id.
+func (config *Config) skipTable(table string) bool {
+	if config.StartAfterID == "" {
+		return false
+	}
+	cursor, err := ParseCursor(config.StartAfterID)
+	if err != nil {
+		return false
+	}
+	return tableIndex(table) < tableIndex(cursor.Table)
+}
+
+// startAfter returns the cursor from which to start migrating table. The cursor
+// is empty when the table should be migrated from the beginning.
+func (config *Config) startAfter(table string) (Cursor, error) {
+	start := Cursor{Table: table, ID: []byte{}, BucketName: []byte{}}
+	if config.StartAfterID == "" {
+		return start, nil
+	}
+
+	cursor, err := ParseCursor(config.StartAfterID)
+	if err != nil {
+		return Cursor{}, err
+	}
+	if cursor.Table != table {
+		return start, nil
+	}
+	if cursor.BucketName == nil {
+		cursor.BucketName = []byte{}
+	}
+	return cursor, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+
+	pgx "github.com/jackc/pgx/v4"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+)
+
+// migratedTables are the tables affected by the migration.
+var migratedTables = []string{"users", "projects", "api_keys", "bucket_metainfos", "value_attributions"}
+
+// CountTables counts the rows the migration would update in each table, without updating them.
+func CountTables(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (counts map[string]int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	counts = make(map[string]int, len(migratedTables))
+	for _, table := range migratedTables {
+		var count int
+		// table names come from the fixed list above, so it's safe to format them into the query.
+		err = conn.QueryRow(ctx, `
+			SELECT count(*) FROM `+table+`
+			WHERE user_agent = COALESCE($1::bytea, partner_id)
+		`, sentinel).Scan(&count)
+		if err != nil {
+			return nil, errs.New("error counting %s: %w", table, err)
+		}
+
+		log.Info("dry run", zap.String("table", table), zap.Int("rows to update", count))
+		counts[table] = count
+	}
+	return counts, nil
+}
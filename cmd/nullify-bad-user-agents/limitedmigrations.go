@@ -16,25 +16,35 @@ import (
 
 // MigrateTablesLimited runs the migration for each table with update count limits.
 func MigrateTablesLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
-	err = MigrateUsersLimited(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating users: %w", err)
+	if !config.skipTable("users") {
+		err = MigrateUsersLimited(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating users: %w", err)
+		}
 	}
-	err = MigrateProjectsLimited(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating projects: %w", err)
+	if !config.skipTable("projects") {
+		err = MigrateProjectsLimited(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating projects: %w", err)
+		}
 	}
-	err = MigrateAPIKeysLimited(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating api_keys: %w", err)
+	if !config.skipTable("api_keys") {
+		err = MigrateAPIKeysLimited(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating api_keys: %w", err)
+		}
 	}
-	err = MigrateBucketMetainfosLimited(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating bucket_metainfos: %w", err)
+	if !config.skipTable("bucket_metainfos") {
+		err = MigrateBucketMetainfosLimited(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating bucket_metainfos: %w", err)
+		}
 	}
-	err = MigrateValueAttributionsLimited(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating value_attributions: %w", err)
+	if !config.skipTable("value_attributions") {
+		err = MigrateValueAttributionsLimited(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating value_attributions: %w", err)
+		}
 	}
 	return nil
 }
@@ -44,15 +54,27 @@ func MigrateTablesLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn,
 func MigrateUsersLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
+	start, err := config.startAfter("users")
+	if err != nil {
+		return err
+	}
+
 	log.Info("beginning users migration", zap.Int("max updates", config.MaxUpdates))
 
 	// wrap select in anonymous function for deferred rows.Close()
 	selected, err := func() (ids [][]byte, err error) {
 		rows, err := conn.Query(ctx, `
-			SELECT id FROM users 
-			WHERE user_agent = partner_id
+			SELECT id FROM users
+			WHERE user_agent = COALESCE($2::bytea, partner_id)
+				AND id > $3
+			ORDER BY id
 			LIMIT $1
-		`, config.MaxUpdates)
+		`, config.MaxUpdates, sentinel, start.ID)
 		if err != nil {
 			return nil, errs.New("selecting ids for update: %w", err)
 		}
@@ -89,6 +111,10 @@ func MigrateUsersLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn, c
 		return errs.New("error scanning results: %w", err)
 	}
 	log.Info("updated rows", zap.Int("count", updated))
+	if len(selected) > 0 && len(selected) >= config.MaxUpdates {
+		// there may be more rows to update, log the cursor to resume from.
+		log.Info("max updates reached", zap.Stringer("cursor", Cursor{Table: "users", ID: selected[len(selected)-1]}))
+	}
 	return nil
 }
 
@@ -97,15 +123,27 @@ func MigrateUsersLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn, c
 func MigrateProjectsLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
+	start, err := config.startAfter("projects")
+	if err != nil {
+		return err
+	}
+
 	log.Info("beginning projects migration", zap.Int("max updates", config.MaxUpdates))
 
 	// wrap select in anonymous function for deferred rows.Close()
 	selected, err := func() (ids [][]byte, err error) {
 		rows, err := conn.Query(ctx, `
-			SELECT id FROM projects 
-			WHERE user_agent = partner_id
+			SELECT id FROM projects
+			WHERE user_agent = COALESCE($2::bytea, partner_id)
+				AND id > $3
+			ORDER BY id
 			LIMIT $1
-		`, config.MaxUpdates)
+		`, config.MaxUpdates, sentinel, start.ID)
 		if err != nil {
 			return nil, errs.New("selecting ids for update: %w", err)
 		}
@@ -142,6 +180,10 @@ func MigrateProjectsLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn
 		return errs.New("error scanning results: %w", err)
 	}
 	log.Info("updated rows", zap.Int("count", updated))
+	if len(selected) > 0 && len(selected) >= config.MaxUpdates {
+		// there may be more rows to update, log the cursor to resume from.
+		log.Info("max updates reached", zap.Stringer("cursor", Cursor{Table: "projects", ID: selected[len(selected)-1]}))
+	}
 	return nil
 }
 
@@ -150,15 +192,27 @@ func MigrateProjectsLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn
 func MigrateAPIKeysLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
+	start, err := config.startAfter("api_keys")
+	if err != nil {
+		return err
+	}
+
 	log.Info("beginning api_keys migration", zap.Int("max updates", config.MaxUpdates))
 
 	// wrap select in anonymous function for deferred rows.Close()
 	selected, err := func() (ids [][]byte, err error) {
 		rows, err := conn.Query(ctx, `
-			SELECT id FROM api_keys 
-			WHERE user_agent = partner_id
+			SELECT id FROM api_keys
+			WHERE user_agent = COALESCE($2::bytea, partner_id)
+				AND id > $3
+			ORDER BY id
 			LIMIT $1
-		`, config.MaxUpdates)
+		`, config.MaxUpdates, sentinel, start.ID)
 		if err != nil {
 			return nil, errs.New("selecting ids for update: %w", err)
 		}
@@ -195,6 +249,10 @@ func MigrateAPIKeysLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn,
 		return errs.New("error scanning results: %w", err)
 	}
 	log.Info("updated rows", zap.Int("count", updated))
+	if len(selected) > 0 && len(selected) >= config.MaxUpdates {
+		// there may be more rows to update, log the cursor to resume from.
+		log.Info("max updates reached", zap.Stringer("cursor", Cursor{Table: "api_keys", ID: selected[len(selected)-1]}))
+	}
 	return nil
 }
 
@@ -203,15 +261,27 @@ func MigrateAPIKeysLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn,
 func MigrateBucketMetainfosLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
+	start, err := config.startAfter("bucket_metainfos")
+	if err != nil {
+		return err
+	}
+
 	log.Info("beginning bucket_metainfos migration", zap.Int("max updates", config.MaxUpdates))
 
 	// wrap select in anonymous function for deferred rows.Close()
 	selected, err := func() (ids [][]byte, err error) {
 		rows, err := conn.Query(ctx, `
 			SELECT id FROM bucket_metainfos
-			WHERE user_agent = partner_id
+			WHERE user_agent = COALESCE($2::bytea, partner_id)
+				AND id > $3
+			ORDER BY id
 			LIMIT $1
-		`, config.MaxUpdates)
+		`, config.MaxUpdates, sentinel, start.ID)
 		if err != nil {
 			return nil, errs.New("selecting ids for update: %w", err)
 		}
@@ -248,6 +318,10 @@ func MigrateBucketMetainfosLimited(ctx context.Context, log *zap.Logger, conn *p
 		return errs.New("error scanning results: %w", err)
 	}
 	log.Info("updated rows", zap.Int("count", updated))
+	if len(selected) > 0 && len(selected) >= config.MaxUpdates {
+		// there may be more rows to update, log the cursor to resume from.
+		log.Info("max updates reached", zap.Stringer("cursor", Cursor{Table: "bucket_metainfos", ID: selected[len(selected)-1]}))
+	}
 	return nil
 }
 
@@ -256,15 +330,32 @@ func MigrateBucketMetainfosLimited(ctx context.Context, log *zap.Logger, conn *p
 func MigrateValueAttributionsLimited(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
+	start, err := config.startAfter("value_attributions")
+	if err != nil {
+		return err
+	}
+
 	log.Info("beginning value_attributions migration", zap.Int("max updates", config.MaxUpdates))
 
 	// wrap select in anonymous function for deferred rows.Close()
 	projects, buckets, err := func() (projectIDs [][]byte, buckets [][]byte, err error) {
 		rows, err := conn.Query(ctx, `
 			SELECT project_id, bucket_name FROM value_attributions
-			WHERE user_agent = partner_id
+			WHERE user_agent = COALESCE($2::bytea, partner_id)
+				AND (
+					project_id > $3
+					OR (
+						project_id = $3 AND bucket_name > $4
+					)
+				)
+			ORDER BY project_id, bucket_name
 			LIMIT $1
-		`, config.MaxUpdates)
+		`, config.MaxUpdates, sentinel, start.ID, start.BucketName)
 		if err != nil {
 			return nil, nil, errs.New("selecting rows for update: %w", err)
 		}
@@ -291,12 +382,12 @@ func MigrateValueAttributionsLimited(ctx context.Context, log *zap.Logger, conn
 			SET user_agent = NULL
 			WHERE value_attributions.project_id IN (SELECT unnest($1::bytea[]))
 				AND value_attributions.bucket_name IN (SELECT unnest($2::bytea[]))
-				AND user_agent = partner_id
+				AND user_agent = COALESCE($3::bytea, partner_id)
 			RETURNING 1
 		)
 		SELECT count(*)
 		FROM updated
-		`, pgutil.ByteaArray(projects), pgutil.ByteaArray(buckets),
+		`, pgutil.ByteaArray(projects), pgutil.ByteaArray(buckets), sentinel,
 	)
 	var updated int
 	err = row.Scan(&updated)
@@ -304,5 +395,9 @@ func MigrateValueAttributionsLimited(ctx context.Context, log *zap.Logger, conn
 		return errs.New("error scanning results: %w", err)
 	}
 	log.Info("updated rows", zap.Int("count", updated))
+	if len(projects) > 0 && len(projects) >= config.MaxUpdates {
+		// there may be more rows to update, log the cursor to resume from.
+		log.Info("max updates reached", zap.Stringer("cursor", Cursor{Table: "value_attributions", ID: projects[len(projects)-1], BucketName: buckets[len(buckets)-1]}))
+	}
 	return nil
 }
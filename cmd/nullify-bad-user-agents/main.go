@@ -5,7 +5,9 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"strings"
 
 	pgx "github.com/jackc/pgx/v4"
 	"github.com/spacemonkeygo/monkit/v3"
@@ -45,6 +47,13 @@ type Config struct {
 	SatelliteDB string
 	Limit       int
 	MaxUpdates  int
+	DryRun      bool
+	// Sentinel is the hex encoded user_agent value to nullify. When empty,
+	// rows where user_agent equals partner_id are nullified.
+	Sentinel string
+	// StartAfterID is the cursor of the last processed row, which allows
+	// resuming an interrupted migration.
+	StartAfterID string
 }
 
 // BindFlags adds bench flags to the the flagset.
@@ -52,6 +61,9 @@ func (config *Config) BindFlags(flag *flag.FlagSet) {
 	flag.StringVar(&config.SatelliteDB, "satellitedb", "", "connection URL for satelliteDB")
 	flag.IntVar(&config.Limit, "limit", 1000, "number of updates to perform at once")
 	flag.IntVar(&config.MaxUpdates, "max-updates", 0, "max number of updates to perform on each table")
+	flag.StringVar(&config.Sentinel, "sentinel", "", "hex encoded user_agent value to nullify (default is user_agent equal to partner_id)")
+	flag.StringVar(&config.StartAfterID, "start-after-id", "", "resume the migration after the cursor logged by a previous run, e.g. users:<hex id>")
+	flag.BoolVar(&config.DryRun, "dry-run", false, "only count the rows that would be updated in each table")
 }
 
 // VerifyFlags verifies whether the values provided are valid.
@@ -60,9 +72,32 @@ func (config *Config) VerifyFlags() error {
 	if config.SatelliteDB == "" {
 		errlist.Add(errors.New("flag '--satellitedb' is not set"))
 	}
+	if _, err := config.SentinelBytes(); err != nil {
+		errlist.Add(err)
+	}
+	if config.StartAfterID != "" {
+		if _, err := ParseCursor(config.StartAfterID); err != nil {
+			errlist.Add(errs.New("flag '--start-after-id' is not valid: %w", err))
+		}
+	}
 	return errlist.Err()
 }
 
+// SentinelBytes returns the decoded sentinel value or nil when it's not set.
+func (config *Config) SentinelBytes() ([]byte, error) {
+	if config.Sentinel == "" {
+		return nil, nil
+	}
+	sentinel, err := hex.DecodeString(strings.TrimPrefix(config.Sentinel, `\x`))
+	if err != nil {
+		return nil, errs.New("flag '--sentinel' is not valid hex: %w", err)
+	}
+	if len(sentinel) == 0 {
+		return nil, errs.New("flag '--sentinel' is empty")
+	}
+	return sentinel, nil
+}
+
 func run(cmd *cobra.Command, args []string) error {
 	if err := config.VerifyFlags(); err != nil {
 		return err
@@ -77,7 +112,7 @@ func main() {
 	process.Exec(rootCmd)
 }
 
-// Migrate updates the user_agent column if user_agent = '\x00000000000000000000000000000000' and sets
+// Migrate updates the user_agent column if user_agent = partner_id (or the configured sentinel) and sets
 // it to NULL.
 // Affected tables:
 //
@@ -97,6 +132,11 @@ func Migrate(ctx context.Context, log *zap.Logger, config Config) (err error) {
 		err = errs.Combine(err, conn.Close(ctx))
 	}()
 
+	if config.DryRun {
+		_, err = CountTables(ctx, log, conn, config)
+		return err
+	}
+
 	// The original migrations are already somewhat complex and in my opinion,
 	// trying to edit them to be able to handle conditionally limiting updates increased the
 	// complexity. While I think splitting out the limited update migrations isn't the
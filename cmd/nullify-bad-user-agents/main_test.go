@@ -5,13 +5,16 @@ package main_test
 
 import (
 	"context"
+	"encoding/hex"
 	"strings"
 	"testing"
 
 	pgx "github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 
 	"storj.io/common/macaroon"
 	"storj.io/common/storj"
@@ -1145,6 +1148,256 @@ func TestMigrateValueAttributionsLimited(t *testing.T) {
 	})
 }
 
+// Test only rows matching a custom sentinel are updated.
+func TestMigrateUsersCustomSentinel(t *testing.T) {
+	t.Parallel()
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	sentinel := make([]byte, 16)
+
+	var matching, other []uuid.UUID
+	prepare := func(t *testing.T, ctx *testcontext.Context, rawDB *dbutil.TempDatabase, db satellite.DB) {
+		matching, other = nil, nil
+		for i := 0; i < 2; i++ {
+			// insert with user_agent = sentinel
+			id := testrand.UUID()
+			_, err := db.Console().Users().Insert(ctx, &console.User{
+				ID:           id,
+				Email:        "test@storj.test",
+				FullName:     "Test Test",
+				PasswordHash: []byte{0, 1, 2, 3},
+				PartnerID:    testrand.UUID(),
+				UserAgent:    sentinel,
+			})
+			require.NoError(t, err)
+			matching = append(matching, id)
+		}
+
+		// insert with user_agent = partner_id, which doesn't match the sentinel
+		id := testrand.UUID()
+		_, err := db.Console().Users().Insert(ctx, &console.User{
+			ID:           id,
+			Email:        "test@storj.test",
+			FullName:     "Test Test",
+			PasswordHash: []byte{0, 1, 2, 3},
+			PartnerID:    id,
+			UserAgent:    id.Bytes(),
+		})
+		require.NoError(t, err)
+		other = append(other, id)
+
+		id = testrand.UUID()
+		_, err = db.Console().Users().Insert(ctx, &console.User{
+			ID:           id,
+			Email:        "test@storj.test",
+			FullName:     "Test Test",
+			PasswordHash: []byte{0, 1, 2, 3},
+			UserAgent:    []byte("teststorj"),
+		})
+		require.NoError(t, err)
+		other = append(other, id)
+	}
+
+	check := func(t *testing.T, ctx context.Context, db satellite.DB) {
+		for _, id := range matching {
+			user, err := db.Console().Users().Get(ctx, id)
+			require.NoError(t, err)
+			require.Nil(t, user.UserAgent)
+		}
+		for _, id := range other {
+			user, err := db.Console().Users().Get(ctx, id)
+			require.NoError(t, err)
+			require.NotNil(t, user.UserAgent)
+		}
+	}
+
+	config := &migrator.Config{
+		Limit:    1,
+		Sentinel: hex.EncodeToString(sentinel),
+	}
+	sentinelBytes, err := config.SentinelBytes()
+	require.NoError(t, err)
+	require.Equal(t, sentinel, sentinelBytes)
+
+	test(t, prepare, migrator.MigrateUsers, check, config)
+}
+
+// Test invalid sentinel values are rejected.
+func TestVerifySentinel(t *testing.T) {
+	for _, sentinel := range []string{"xyz", "123", `\x`} {
+		config := migrator.Config{SatelliteDB: "postgres://", Sentinel: sentinel}
+		require.Error(t, config.VerifyFlags(), sentinel)
+	}
+
+	config := migrator.Config{SatelliteDB: "postgres://", Sentinel: `\x00000000000000000000000000000000`}
+	require.NoError(t, config.VerifyFlags())
+}
+
+// Test an interrupted limited migration can be resumed from the logged cursor.
+func TestMigrateUsersResume(t *testing.T) {
+	t.Parallel()
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	var ids []uuid.UUID
+	prepare := func(t *testing.T, ctx *testcontext.Context, rawDB *dbutil.TempDatabase, db satellite.DB) {
+		ids = nil
+		for i := 0; i < 3; i++ {
+			id := testrand.UUID()
+			_, err := db.Console().Users().Insert(ctx, &console.User{
+				ID:           id,
+				Email:        "test@storj.test",
+				FullName:     "Test Test",
+				PasswordHash: []byte{0, 1, 2, 3},
+				PartnerID:    id,
+				UserAgent:    id.Bytes(),
+			})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+	}
+
+	var cursor string
+	migrate := func(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config migrator.Config) error {
+		core, logs := observer.New(zap.InfoLevel)
+		err := migrator.MigrateUsersLimited(ctx, zap.New(core), conn, config)
+		if err != nil {
+			return err
+		}
+
+		var updated int
+		err = conn.QueryRow(ctx, "SELECT count(*) FROM users WHERE user_agent IS NULL").Scan(&updated)
+		if err != nil {
+			return err
+		}
+		if updated != config.MaxUpdates {
+			return errs.New("expected %d updated rows, got %d", config.MaxUpdates, updated)
+		}
+
+		entries := logs.FilterMessage("max updates reached").All()
+		if len(entries) != 1 {
+			return errs.New("expected a cursor to be logged")
+		}
+		cursor = entries[0].ContextMap()["cursor"].(string)
+
+		config.MaxUpdates = 0
+		config.StartAfterID = cursor
+		return migrator.MigrateTables(ctx, log, conn, config)
+	}
+
+	check := func(t *testing.T, ctx context.Context, db satellite.DB) {
+		require.True(t, strings.HasPrefix(cursor, "users:"))
+		for _, id := range ids {
+			user, err := db.Console().Users().Get(ctx, id)
+			require.NoError(t, err)
+			require.Nil(t, user.UserAgent)
+		}
+	}
+
+	test(t, prepare, migrate, check, &migrator.Config{
+		Limit:      1,
+		MaxUpdates: 1,
+	})
+}
+
+// Test the cursor format round trips.
+func TestCursor(t *testing.T) {
+	for _, cursor := range []migrator.Cursor{
+		{Table: "users", ID: testrand.UUID().Bytes()},
+		{Table: "api_keys", ID: testrand.UUID().Bytes()},
+		{Table: "value_attributions", ID: testrand.UUID().Bytes(), BucketName: []byte("bucket")},
+	} {
+		parsed, err := migrator.ParseCursor(cursor.String())
+		require.NoError(t, err)
+		require.Equal(t, cursor, parsed)
+	}
+
+	for _, invalid := range []string{"", "unknown:00", "users", "users:xyz", "value_attributions:00"} {
+		_, err := migrator.ParseCursor(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+// Test dry run counts matching rows without updating them.
+func TestCountTables(t *testing.T) {
+	t.Parallel()
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	var userIDs []uuid.UUID
+	prepare := func(t *testing.T, ctx *testcontext.Context, rawDB *dbutil.TempDatabase, db satellite.DB) {
+		userIDs = nil
+		for i := 0; i < 2; i++ {
+			id := testrand.UUID()
+			_, err := db.Console().Users().Insert(ctx, &console.User{
+				ID:           id,
+				Email:        "test@storj.test",
+				FullName:     "Test Test",
+				PasswordHash: []byte{0, 1, 2, 3},
+				PartnerID:    id,
+				UserAgent:    id.Bytes(),
+			})
+			require.NoError(t, err)
+			userIDs = append(userIDs, id)
+		}
+		// insert an entry with something not matching
+		_, err := db.Console().Users().Insert(ctx, &console.User{
+			ID:           testrand.UUID(),
+			Email:        "test@storj.test",
+			FullName:     "Test Test",
+			PasswordHash: []byte{0, 1, 2, 3},
+			UserAgent:    []byte("teststorj"),
+		})
+		require.NoError(t, err)
+
+		id := testrand.UUID()
+		_, err = db.Console().Projects().Insert(ctx, &console.Project{
+			Name:        "test",
+			Description: "test",
+			OwnerID:     testrand.UUID(),
+			PartnerID:   id,
+			UserAgent:   id.Bytes(),
+		})
+		require.NoError(t, err)
+
+		id = testrand.UUID()
+		_, err = db.Attribution().Insert(ctx, &attribution.Info{
+			ProjectID:  id,
+			PartnerID:  id,
+			BucketName: []byte("test"),
+			UserAgent:  id.Bytes(),
+		})
+		require.NoError(t, err)
+	}
+
+	var counts map[string]int
+	count := func(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config migrator.Config) (err error) {
+		counts, err = migrator.CountTables(ctx, log, conn, config)
+		return err
+	}
+
+	check := func(t *testing.T, ctx context.Context, db satellite.DB) {
+		require.Equal(t, map[string]int{
+			"users":              2,
+			"projects":           1,
+			"api_keys":           0,
+			"bucket_metainfos":   0,
+			"value_attributions": 1,
+		}, counts)
+
+		for _, id := range userIDs {
+			user, err := db.Console().Users().Get(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, id.Bytes(), user.UserAgent)
+		}
+	}
+
+	test(t, prepare, count, check, &migrator.Config{
+		DryRun: true,
+	})
+}
+
 func test(t *testing.T, prepare func(t *testing.T, ctx *testcontext.Context, rawDB *dbutil.TempDatabase, db satellite.DB),
 	migrate func(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config migrator.Config) (err error),
 	check func(t *testing.T, ctx context.Context, db satellite.DB), config *migrator.Config) {
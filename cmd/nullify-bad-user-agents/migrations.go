@@ -15,25 +15,35 @@ import (
 
 // MigrateTables runs the migration for each table.
 func MigrateTables(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
-	err = MigrateUsers(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating users: %w", err)
+	if !config.skipTable("users") {
+		err = MigrateUsers(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating users: %w", err)
+		}
 	}
-	err = MigrateProjects(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating projects: %w", err)
+	if !config.skipTable("projects") {
+		err = MigrateProjects(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating projects: %w", err)
+		}
 	}
-	err = MigrateAPIKeys(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating api_keys: %w", err)
+	if !config.skipTable("api_keys") {
+		err = MigrateAPIKeys(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating api_keys: %w", err)
+		}
 	}
-	err = MigrateBucketMetainfos(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating bucket_metainfos: %w", err)
+	if !config.skipTable("bucket_metainfos") {
+		err = MigrateBucketMetainfos(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating bucket_metainfos: %w", err)
+		}
 	}
-	err = MigrateValueAttributions(ctx, log, conn, config)
-	if err != nil {
-		return errs.New("error migrating value_attributions: %w", err)
+	if !config.skipTable("value_attributions") {
+		err = MigrateValueAttributions(ctx, log, conn, config)
+		if err != nil {
+			return errs.New("error migrating value_attributions: %w", err)
+		}
 	}
 	return nil
 }
@@ -42,10 +52,19 @@ func MigrateTables(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config
 func MigrateUsers(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
 	// We select the next id then use limit as an offset which actually gives us limit+1 rows.
 	offset := config.Limit - 1
 
-	startID := []byte{}
+	start, err := config.startAfter("users")
+	if err != nil {
+		return err
+	}
+	startID := start.ID
 	nextID := []byte{}
 	var total int
 	more := true
@@ -62,7 +81,7 @@ func MigrateUsers(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config C
 			UPDATE users
 			SET user_agent = NULL
 			WHERE users.id > $1 AND users.id <= $2
-				AND user_agent = partner_id
+				AND user_agent = COALESCE($3::bytea, partner_id)
 			RETURNING 1
 		)
 		SELECT count(*)
@@ -87,7 +106,7 @@ func MigrateUsers(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config C
 		for {
 			var row pgx.Row
 			if more {
-				row = conn.QueryRow(ctx, "update-limited-users", startID, nextID)
+				row = conn.QueryRow(ctx, "update-limited-users", startID, nextID, sentinel)
 			} else {
 				// if !more then the select statement reached the end of the table. Update to the end of the table.
 				row = conn.QueryRow(ctx, `
@@ -95,12 +114,12 @@ func MigrateUsers(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config C
 						UPDATE users
 						SET user_agent = NULL
 						WHERE users.id > $1
-							AND user_agent = partner_id
+							AND user_agent = COALESCE($2::bytea, partner_id)
 						RETURNING 1
 					)
 					SELECT count(*)
 					FROM updated;
-				`, startID,
+				`, startID, sentinel,
 				)
 			}
 			err := row.Scan(&updated)
@@ -117,10 +136,11 @@ func MigrateUsers(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config C
 
 		total += updated
 		if !more {
-			log.Info("batch update complete", zap.Int("rows updated", updated))
+			log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total))
 			break
 		}
-		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Binary("last id", nextID))
+		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total), zap.Binary("last id", nextID),
+			zap.Stringer("cursor", Cursor{Table: "users", ID: nextID}))
 		startID = nextID
 	}
 	log.Info("users migration complete", zap.Int("total rows updated", total))
@@ -131,10 +151,19 @@ func MigrateUsers(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config C
 func MigrateProjects(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
 	// We select the next id then use limit as an offset which actually gives us limit+1 rows.
 	offset := config.Limit - 1
 
-	startID := []byte{}
+	start, err := config.startAfter("projects")
+	if err != nil {
+		return err
+	}
+	startID := start.ID
 	nextID := []byte{}
 	var total int
 	more := true
@@ -151,7 +180,7 @@ func MigrateProjects(ctx context.Context, log *zap.Logger, conn *pgx.Conn, confi
 			UPDATE projects
 			SET user_agent = NULL
 			WHERE projects.id > $1 AND projects.id <= $2
-				AND user_agent = partner_id
+				AND user_agent = COALESCE($3::bytea, partner_id)
 			RETURNING 1
 		)
 		SELECT count(*)
@@ -176,7 +205,7 @@ func MigrateProjects(ctx context.Context, log *zap.Logger, conn *pgx.Conn, confi
 		for {
 			var row pgx.Row
 			if more {
-				row = conn.QueryRow(ctx, "update-limited-projects", startID, nextID)
+				row = conn.QueryRow(ctx, "update-limited-projects", startID, nextID, sentinel)
 			} else {
 				// if !more then the select statement reached the end of the table. Update to the end of the table.
 				row = conn.QueryRow(ctx, `
@@ -184,12 +213,12 @@ func MigrateProjects(ctx context.Context, log *zap.Logger, conn *pgx.Conn, confi
 						UPDATE projects
 						SET user_agent = NULL
 						WHERE projects.id > $1
-							AND user_agent = partner_id
+							AND user_agent = COALESCE($2::bytea, partner_id)
 						RETURNING 1
 					)
 					SELECT count(*)
 					FROM updated;
-				`, startID,
+				`, startID, sentinel,
 				)
 			}
 
@@ -207,10 +236,11 @@ func MigrateProjects(ctx context.Context, log *zap.Logger, conn *pgx.Conn, confi
 
 		total += updated
 		if !more {
-			log.Info("batch update complete", zap.Int("rows updated", updated))
+			log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total))
 			break
 		}
-		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Binary("last id", nextID))
+		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total), zap.Binary("last id", nextID),
+			zap.Stringer("cursor", Cursor{Table: "projects", ID: nextID}))
 		startID = nextID
 	}
 	log.Info("projects migration complete", zap.Int("total rows updated", total))
@@ -221,10 +251,19 @@ func MigrateProjects(ctx context.Context, log *zap.Logger, conn *pgx.Conn, confi
 func MigrateAPIKeys(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
 	// We select the next id then use limit as an offset which actually gives us limit+1 rows.
 	offset := config.Limit - 1
 
-	startID := []byte{}
+	start, err := config.startAfter("api_keys")
+	if err != nil {
+		return err
+	}
+	startID := start.ID
 	nextID := []byte{}
 	var total int
 	more := true
@@ -241,7 +280,7 @@ func MigrateAPIKeys(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config
 			UPDATE api_keys
 			SET user_agent = NULL
 			WHERE api_keys.id > $1 AND api_keys.id <= $2
-				AND user_agent = partner_id
+				AND user_agent = COALESCE($3::bytea, partner_id)
 			RETURNING 1
 		)
 		SELECT count(*)
@@ -265,7 +304,7 @@ func MigrateAPIKeys(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config
 		for {
 			var row pgx.Row
 			if more {
-				row = conn.QueryRow(ctx, "update-limited-api-keys", startID, nextID)
+				row = conn.QueryRow(ctx, "update-limited-api-keys", startID, nextID, sentinel)
 			} else {
 				// if !more then the select statement reached the end of the table. Update to the end of the table.
 				row = conn.QueryRow(ctx, `
@@ -273,12 +312,12 @@ func MigrateAPIKeys(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config
 						UPDATE api_keys
 						SET user_agent = NULL
 						WHERE api_keys.id > $1
-							AND user_agent = partner_id
+							AND user_agent = COALESCE($2::bytea, partner_id)
 						RETURNING 1
 					)
 					SELECT count(*)
 					FROM updated;
-				`, startID,
+				`, startID, sentinel,
 				)
 			}
 			err := row.Scan(&updated)
@@ -295,10 +334,11 @@ func MigrateAPIKeys(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config
 
 		total += updated
 		if !more {
-			log.Info("batch update complete", zap.Int("rows updated", updated))
+			log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total))
 			break
 		}
-		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Binary("last id", nextID))
+		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total), zap.Binary("last id", nextID),
+			zap.Stringer("cursor", Cursor{Table: "api_keys", ID: nextID}))
 		startID = nextID
 	}
 	log.Info("api_keys migration complete", zap.Int("total rows updated", total))
@@ -309,10 +349,19 @@ func MigrateAPIKeys(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config
 func MigrateBucketMetainfos(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
 	// We select the next id then use limit as an offset which actually gives us limit+1 rows.
 	offset := config.Limit - 1
 
-	startID := []byte{}
+	start, err := config.startAfter("bucket_metainfos")
+	if err != nil {
+		return err
+	}
+	startID := start.ID
 	nextID := []byte{}
 	var total int
 	more := true
@@ -329,7 +378,7 @@ func MigrateBucketMetainfos(ctx context.Context, log *zap.Logger, conn *pgx.Conn
 			UPDATE bucket_metainfos
 			SET user_agent = NULL
 			WHERE bucket_metainfos.id > $1 AND bucket_metainfos.id <= $2
-				AND user_agent = partner_id
+				AND user_agent = COALESCE($3::bytea, partner_id)
 			RETURNING 1
 		)
 		SELECT count(*)
@@ -353,7 +402,7 @@ func MigrateBucketMetainfos(ctx context.Context, log *zap.Logger, conn *pgx.Conn
 		for {
 			var row pgx.Row
 			if more {
-				row = conn.QueryRow(ctx, "update-limited-bucket-metainfos", startID, nextID)
+				row = conn.QueryRow(ctx, "update-limited-bucket-metainfos", startID, nextID, sentinel)
 			} else {
 				// if !more then the select statement reached the end of the table. Update to the end of the table.
 				row = conn.QueryRow(ctx, `
@@ -361,12 +410,12 @@ func MigrateBucketMetainfos(ctx context.Context, log *zap.Logger, conn *pgx.Conn
 						UPDATE bucket_metainfos
 						SET user_agent = NULL
 						WHERE bucket_metainfos.id > $1
-							AND user_agent = partner_id
+							AND user_agent = COALESCE($2::bytea, partner_id)
 						RETURNING 1
 					)
 					SELECT count(*)
 					FROM updated;
-				`, startID,
+				`, startID, sentinel,
 				)
 			}
 			err := row.Scan(&updated)
@@ -383,10 +432,11 @@ func MigrateBucketMetainfos(ctx context.Context, log *zap.Logger, conn *pgx.Conn
 
 		total += updated
 		if !more {
-			log.Info("batch update complete", zap.Int("rows updated", updated))
+			log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total))
 			break
 		}
-		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Binary("last id", nextID))
+		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total), zap.Binary("last id", nextID),
+			zap.Stringer("cursor", Cursor{Table: "bucket_metainfos", ID: nextID}))
 		startID = nextID
 	}
 	log.Info("bucket_metainfos migration complete", zap.Int("total rows updated", total))
@@ -397,11 +447,20 @@ func MigrateBucketMetainfos(ctx context.Context, log *zap.Logger, conn *pgx.Conn
 func MigrateValueAttributions(ctx context.Context, log *zap.Logger, conn *pgx.Conn, config Config) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	sentinel, err := config.SentinelBytes()
+	if err != nil {
+		return err
+	}
+
 	// We select the next id then use limit as an offset which actually gives us limit+1 rows.
 	offset := config.Limit - 1
 
-	startProjectID := []byte{}
-	startBucket := []byte{}
+	start, err := config.startAfter("value_attributions")
+	if err != nil {
+		return err
+	}
+	startProjectID := start.ID
+	startBucket := start.BucketName
 	nextProjectID := []byte{}
 	nextBucket := []byte{}
 	var total int
@@ -428,7 +487,7 @@ func MigrateValueAttributions(ctx context.Context, log *zap.Logger, conn *pgx.Co
 		WITH updated as (
 			UPDATE value_attributions
 			SET user_agent = NULL
-			WHERE user_agent = partner_id
+			WHERE user_agent = COALESCE($5::bytea, partner_id)
 				AND (
 					value_attributions.project_id > $1
 					OR (
@@ -464,14 +523,14 @@ func MigrateValueAttributions(ctx context.Context, log *zap.Logger, conn *pgx.Co
 		for {
 			var row pgx.Row
 			if more {
-				row = conn.QueryRow(ctx, "update-limited-value-attributions", startProjectID, nextProjectID, startBucket, nextBucket)
+				row = conn.QueryRow(ctx, "update-limited-value-attributions", startProjectID, nextProjectID, startBucket, nextBucket, sentinel)
 			} else {
 				// if !more then the select statement reached the end of the table. Update to the end of the table.
 				row = conn.QueryRow(ctx, `
 					WITH updated as (
 						UPDATE value_attributions
 						SET user_agent = NULL
-						WHERE user_agent = partner_id
+						WHERE user_agent = COALESCE($3::bytea, partner_id)
 							AND (
 								value_attributions.project_id > $1
 								OR (
@@ -482,7 +541,7 @@ func MigrateValueAttributions(ctx context.Context, log *zap.Logger, conn *pgx.Co
 					)
 					SELECT count(*)
 					FROM updated;
-				`, startProjectID, startBucket,
+				`, startProjectID, startBucket, sentinel,
 				)
 			}
 			err := row.Scan(&updated)
@@ -499,10 +558,11 @@ func MigrateValueAttributions(ctx context.Context, log *zap.Logger, conn *pgx.Co
 
 		total += updated
 		if !more {
-			log.Info("batch update complete", zap.Int("rows updated", updated))
+			log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total))
 			break
 		}
-		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Binary("last project id", nextProjectID), zap.String("last bucket name", string(nextBucket)))
+		log.Info("batch update complete", zap.Int("rows updated", updated), zap.Int("total rows updated", total), zap.Binary("last project id", nextProjectID), zap.String("last bucket name", string(nextBucket)),
+			zap.Stringer("cursor", Cursor{Table: "value_attributions", ID: nextProjectID, BucketName: nextBucket}))
 		startProjectID = nextProjectID
 		startBucket = nextBucket
 	}
@@ -90,6 +90,7 @@ func cmdFetchPieces(cmd *cobra.Command, args []string) (err error) {
 		metabaseDB,
 		revocationDB,
 		db.RepairQueue(),
+		db.RepairCheckpoints(),
 		db.Buckets(),
 		db.OverlayCache(),
 		db.Reputation(),
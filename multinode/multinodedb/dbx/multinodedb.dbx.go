@@ -424,7 +424,9 @@ type Node struct {
 func (Node) _Table() string { return "nodes" }
 
 type Node_Update_Fields struct {
-	Name Node_Name_Field
+	Name          Node_Name_Field
+	PublicAddress Node_PublicAddress_Field
+	ApiSecret     Node_ApiSecret_Field
 }
 
 type Node_Id_Field struct {
@@ -1079,6 +1081,16 @@ func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
 	}
 
+	if update.PublicAddress._set {
+		__values = append(__values, update.PublicAddress.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("public_address = ?"))
+	}
+
+	if update.ApiSecret._set {
+		__values = append(__values, update.ApiSecret.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("api_secret = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -1120,6 +1132,16 @@ func (obj *pgxImpl) UpdateNoReturn_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
 	}
 
+	if update.PublicAddress._set {
+		__values = append(__values, update.PublicAddress.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("public_address = ?"))
+	}
+
+	if update.ApiSecret._set {
+		__values = append(__values, update.ApiSecret.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("api_secret = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return emptyUpdate()
 	}
@@ -1354,6 +1376,16 @@ func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
 	}
 
+	if update.PublicAddress._set {
+		__values = append(__values, update.PublicAddress.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("public_address = ?"))
+	}
+
+	if update.ApiSecret._set {
+		__values = append(__values, update.ApiSecret.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("api_secret = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -1405,6 +1437,16 @@ func (obj *sqlite3Impl) UpdateNoReturn_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
 	}
 
+	if update.PublicAddress._set {
+		__values = append(__values, update.PublicAddress.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("public_address = ?"))
+	}
+
+	if update.ApiSecret._set {
+		__values = append(__values, update.ApiSecret.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("api_secret = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return emptyUpdate()
 	}
@@ -136,6 +136,19 @@ func (n *nodesdb) UpdateName(ctx context.Context, id storj.NodeID, name string)
 	return ErrNodesDB.Wrap(err)
 }
 
+// Update will update name, public address and api secret of the specified node in database.
+func (n *nodesdb) Update(ctx context.Context, node nodes.Node) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = n.methods.UpdateNoReturn_Node_By_Id(ctx, dbx.Node_Id(node.ID.Bytes()), dbx.Node_Update_Fields{
+		Name:          dbx.Node_Name(node.Name),
+		PublicAddress: dbx.Node_PublicAddress(node.PublicAddress),
+		ApiSecret:     dbx.Node_ApiSecret(node.APISecret),
+	})
+
+	return ErrNodesDB.Wrap(err)
+}
+
 // fromDBXNode converts dbx.Node to console.Node.
 func fromDBXNode(ctx context.Context, node *dbx.Node) (_ nodes.Node, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -30,6 +30,8 @@ type DB interface {
 	Remove(ctx context.Context, id storj.NodeID) error
 	// UpdateName will update name of the specified node in database.
 	UpdateName(ctx context.Context, id storj.NodeID, name string) error
+	// Update will update name, public address and api secret of the specified node in database.
+	Update(ctx context.Context, node Node) error
 }
 
 var (
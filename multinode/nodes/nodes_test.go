@@ -50,6 +50,19 @@ func TestNodesDB(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, node.Name, newName)
 
+		updated := nodes.Node{
+			ID:            nodeID,
+			APISecret:     []byte("new secret"),
+			PublicAddress: "228.13.38.2:8082",
+			Name:          "Bob",
+		}
+		err = nodesRepository.Update(ctx, updated)
+		assert.NoError(t, err)
+
+		node, err = nodesRepository.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.Equal(t, updated, node)
+
 		err = nodesRepository.Remove(ctx, nodeID)
 		assert.NoError(t, err)
 
@@ -51,6 +51,28 @@ func NewService(log *zap.Logger, dialer rpc.Dialer, nodes DB) *Service {
 func (service *Service) Add(ctx context.Context, node Node) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	if err = service.Verify(ctx, node); err != nil {
+		return err
+	}
+
+	return Error.Wrap(service.nodes.Add(ctx, node))
+}
+
+// Update updates name, public address and api secret of an already added node.
+func (service *Service) Update(ctx context.Context, node Node) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = service.Verify(ctx, node); err != nil {
+		return err
+	}
+
+	return Error.Wrap(service.nodes.Update(ctx, node))
+}
+
+// Verify checks that the node is reachable on its public address and accepts its api secret.
+func (service *Service) Verify(ctx context.Context, node Node) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	// trying to connect to node to check its availability.
 	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
 		ID:      node.ID,
@@ -77,7 +99,7 @@ func (service *Service) Add(ctx context.Context, node Node) (err error) {
 		return Error.Wrap(err)
 	}
 
-	return Error.Wrap(service.nodes.Add(ctx, node))
+	return nil
 }
 
 // List returns list of all nodes.
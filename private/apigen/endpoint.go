@@ -76,10 +76,35 @@ func (eg *EndpointGroup) addEndpoint(path, method string, endpoint *Endpoint) {
 	eg.endpoints = append(eg.endpoints, ep)
 }
 
+// Validator is implemented by request body types which are able to validate
+// their content. Generated handlers call Validate after decoding the body and
+// respond with 400 Bad Request when it fails.
+type Validator interface {
+	Validate() error
+}
+
+// validatorType is used to detect body types implementing Validator.
+var validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+
+// ParamLocation defines where the value of a param is read from.
+type ParamLocation int
+
+const (
+	// ParamDefault is read from the location implied by the endpoint's method:
+	// the query string for GET, the route for uuid.UUID params of PATCH and
+	// DELETE and the request body otherwise.
+	ParamDefault ParamLocation = iota
+	// ParamQuery is read from the query string regardless of the endpoint's method.
+	ParamQuery
+)
+
 // Param represents string interpretation of param's name and type.
 type Param struct {
-	Name string
-	Type reflect.Type
+	Name     string
+	Type     reflect.Type
+	Location ParamLocation
+	// Optional query params may be omitted, in which case the zero value is used.
+	Optional bool
 }
 
 // NewParam constructor which creates new Param entity by given name and type.
@@ -89,3 +114,29 @@ func NewParam(name string, instance interface{}) Param {
 		Type: reflect.TypeOf(instance),
 	}
 }
+
+// NewQueryParam constructor which creates new Param entity by given name and type,
+// which is read from the query string.
+func NewQueryParam(name string, instance interface{}) Param {
+	return Param{
+		Name:     name,
+		Type:     reflect.TypeOf(instance),
+		Location: ParamQuery,
+	}
+}
+
+// NewOptionalQueryParam constructor which creates new Param entity by given name and type,
+// which is read from the query string and may be omitted.
+func NewOptionalQueryParam(name string, instance interface{}) Param {
+	return Param{
+		Name:     name,
+		Type:     reflect.TypeOf(instance),
+		Location: ParamQuery,
+		Optional: true,
+	}
+}
+
+// isQuery returns whether the param of an endpoint with the method is read from the query string.
+func (p Param) isQuery(method string) bool {
+	return p.Location == ParamQuery || method == http.MethodGet
+}
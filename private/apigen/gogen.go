@@ -90,9 +90,6 @@ func (a *API) generateGo() ([]byte, error) {
 		}
 	}
 
-	p("const dateLayout = \"2006-01-02T15:04:05.000Z\"")
-	p("")
-
 	for _, group := range a.EndpointGroups {
 		i("github.com/zeebo/errs")
 		p("var Err%sAPI = errs.Class(\"%s %s api\")", cases.Title(language.Und).String(group.Prefix), a.PackageName, group.Prefix)
@@ -182,37 +179,40 @@ func (a *API) generateGo() ([]byte, error) {
 				p("")
 			}
 
-			switch endpoint.Method {
-			case http.MethodGet:
-				for _, param := range endpoint.Params {
+			for _, param := range endpoint.Params {
+				if param.isQuery(endpoint.Method) {
+					if param.Optional && param.Type != reflect.TypeOf("") && param.Type != reflect.TypeOf(uint(0)) {
+						return nil, errs.New("unsupported type %s of optional query param %q in %s", param.Type, param.Name, endpoint.MethodName)
+					}
+
 					switch param.Type {
 					case reflect.TypeOf(uuid.UUID{}):
 						i("storj.io/common/uuid")
 						handleUUIDQuery(p, param)
-						continue
 					case reflect.TypeOf(time.Time{}):
 						i("time")
 						handleTimeQuery(p, param)
-						continue
 					case reflect.TypeOf(""):
 						handleStringQuery(p, param)
-						continue
+					case reflect.TypeOf(uint(0)):
+						i("strconv")
+						handleUintQuery(p, param)
+					default:
+						return nil, errs.New("unsupported type %s of query param %q in %s", param.Type, param.Name, endpoint.MethodName)
 					}
+					continue
 				}
-			case http.MethodPatch:
-				for _, param := range endpoint.Params {
+
+				switch endpoint.Method {
+				case http.MethodPatch:
 					if param.Type == reflect.TypeOf(uuid.UUID{}) {
 						handleUUIDParam(p, param)
 					} else {
 						handleBody(p, param)
 					}
-				}
-			case http.MethodPost:
-				for _, param := range endpoint.Params {
+				case http.MethodPost:
 					handleBody(p, param)
-				}
-			case http.MethodDelete:
-				for _, param := range endpoint.Params {
+				case http.MethodDelete:
 					handleUUIDParam(p, param)
 				}
 			}
@@ -224,25 +224,15 @@ func (a *API) generateGo() ([]byte, error) {
 				methodFormat = "httpErr := h.service.%s(ctx, "
 			}
 
-			switch endpoint.Method {
-			case http.MethodGet:
-				for _, methodParam := range endpoint.Params {
+			for _, methodParam := range endpoint.Params {
+				switch {
+				case methodParam.isQuery(endpoint.Method):
 					methodFormat += methodParam.Name + ", "
-				}
-			case http.MethodPatch:
-				for _, methodParam := range endpoint.Params {
-					if methodParam.Type == reflect.TypeOf(uuid.UUID{}) {
-						methodFormat += methodParam.Name + ", "
-					} else {
-						methodFormat += "*" + methodParam.Name + ", "
-					}
-				}
-			case http.MethodPost:
-				for _, methodParam := range endpoint.Params {
+				case endpoint.Method == http.MethodPatch && methodParam.Type == reflect.TypeOf(uuid.UUID{}):
+					methodFormat += methodParam.Name + ", "
+				case endpoint.Method == http.MethodPatch || endpoint.Method == http.MethodPost:
 					methodFormat += "*" + methodParam.Name + ", "
-				}
-			case http.MethodDelete:
-				for _, methodParam := range endpoint.Params {
+				default:
 					methodFormat += methodParam.Name + ", "
 				}
 			}
@@ -317,6 +307,10 @@ func (a *API) handleTypesPackage(t reflect.Type) interface{} {
 // handleStringQuery handles request query param of type string.
 func handleStringQuery(p func(format string, a ...interface{}), param Param) {
 	p("%s := r.URL.Query().Get(\"%s\")", param.Name, param.Name)
+	if param.Optional {
+		p("")
+		return
+	}
 	p("if %s == \"\" {", param.Name)
 	p("api.ServeError(h.log, w, http.StatusBadRequest, errs.New(\"parameter '%s' can't be empty\"))", param.Name)
 	p("return")
@@ -324,6 +318,26 @@ func handleStringQuery(p func(format string, a ...interface{}), param Param) {
 	p("")
 }
 
+// handleUintQuery handles request query param of type uint.
+// Optional param which is omitted is zero.
+func handleUintQuery(p func(format string, a ...interface{}), param Param) {
+	p("var %s uint", param.Name)
+	p("if %sQuery := r.URL.Query().Get(\"%s\"); %sQuery != \"\" {", param.Name, param.Name, param.Name)
+	p("%sValue, err := strconv.ParseUint(%sQuery, 10, 32)", param.Name, param.Name)
+	p("if err != nil {")
+	p("api.ServeError(h.log, w, http.StatusBadRequest, errs.New(\"parameter '%s' must be a non-negative integer\"))", param.Name)
+	p("return")
+	p("}")
+	p("%s = uint(%sValue)", param.Name, param.Name)
+	if !param.Optional {
+		p("} else {")
+		p("api.ServeError(h.log, w, http.StatusBadRequest, errs.New(\"parameter '%s' can't be empty\"))", param.Name)
+		p("return")
+	}
+	p("}")
+	p("")
+}
+
 // handleUUIDQuery handles request query param of type uuid.UUID.
 func handleUUIDQuery(p func(format string, a ...interface{}), param Param) {
 	p("%s, err := uuid.FromString(r.URL.Query().Get(\"%s\"))", param.Name, param.Name)
@@ -336,7 +350,7 @@ func handleUUIDQuery(p func(format string, a ...interface{}), param Param) {
 
 // handleTimeQuery handles request query param of type time.Time.
 func handleTimeQuery(p func(format string, a ...interface{}), param Param) {
-	p("%s, err := time.Parse(dateLayout, r.URL.Query().Get(\"%s\"))", param.Name, param.Name)
+	p("%s, err := time.Parse(time.RFC3339, r.URL.Query().Get(\"%s\"))", param.Name, param.Name)
 	p("if err != nil {")
 	p("api.ServeError(h.log, w, http.StatusBadRequest, err)")
 	p("return")
@@ -362,6 +376,7 @@ func handleUUIDParam(p func(format string, a ...interface{}), param Param) {
 }
 
 // handleBody handles request body.
+// Body is validated when its type implements Validator.
 func handleBody(p func(format string, a ...interface{}), param Param) {
 	p("%s := &%s{}", param.Name, param.Type)
 	p("if err = json.NewDecoder(r.Body).Decode(&%s); err != nil {", param.Name)
@@ -369,4 +384,12 @@ func handleBody(p func(format string, a ...interface{}), param Param) {
 	p("return")
 	p("}")
 	p("")
+
+	if param.Type.Implements(validatorType) || reflect.PtrTo(param.Type).Implements(validatorType) {
+		p("if err = %s.Validate(); err != nil {", param.Name)
+		p("api.ServeError(h.log, w, http.StatusBadRequest, err)")
+		p("return")
+		p("}")
+		p("")
+	}
 }
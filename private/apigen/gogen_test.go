@@ -0,0 +1,100 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen_test
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/apigen"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// Document is a request body which validates its content.
+type Document struct {
+	Title string `json:"title"`
+}
+
+// Validate validates the document.
+func (doc Document) Validate() error {
+	if doc.Title == "" {
+		return errors.New("title can't be empty")
+	}
+	return nil
+}
+
+// Note is a request body without validation.
+type Note struct {
+	Text string `json:"text"`
+}
+
+// NotesPage is a page of notes.
+type NotesPage struct {
+	Notes []Note `json:"notes"`
+	More  bool   `json:"more"`
+}
+
+func TestGenerateGo(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	a := &apigen.API{
+		Version:     "v0",
+		PackageName: "apigen_test",
+	}
+
+	g := a.Group("DocumentManagement", "documents")
+
+	g.Post("/create", &apigen.Endpoint{
+		Name:        "Create Document",
+		Description: "Creates new document",
+		MethodName:  "CreateDocument",
+		Response:    &Document{},
+		Params: []apigen.Param{
+			apigen.NewParam("document", Document{}),
+		},
+	})
+
+	g.Post("/note", &apigen.Endpoint{
+		Name:        "Add Note",
+		Description: "Adds a note without validation",
+		MethodName:  "AddNote",
+		Params: []apigen.Param{
+			apigen.NewParam("note", Note{}),
+		},
+	})
+
+	g.Get("/notes", &apigen.Endpoint{
+		Name:        "List Notes",
+		Description: "Lists a page of notes",
+		MethodName:  "ListNotes",
+		Response:    &NotesPage{},
+		Params: []apigen.Param{
+			apigen.NewOptionalQueryParam("limit", uint(0)),
+			apigen.NewQueryParam("page", uint(0)),
+		},
+	})
+
+	generated := ctx.File("api.gen.go")
+	a.MustWriteGo(generated)
+
+	actual, err := os.ReadFile(generated)
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "api.gen.go")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, actual, 0644))
+	}
+
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual), "run the test with -update to update the golden file")
+}
@@ -0,0 +1,293 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+)
+
+// MustWriteOpenAPI writes generated OpenAPI 3.0 specification into a file.
+func (a *API) MustWriteOpenAPI(path string) {
+	generated, err := a.generateOpenAPI()
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+
+	err = os.WriteFile(path, generated, 0644)
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+}
+
+// openAPIDocument is the root object of OpenAPI specification.
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+// openAPIInfo contains metadata of the API.
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// openAPIComponents contains reusable schemas.
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas"`
+}
+
+// openAPIOperation describes a single API operation on a path.
+type openAPIOperation struct {
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	OperationID string                      `json:"operationId"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+// openAPIParameter describes a single path or query parameter.
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+// openAPIRequestBody describes a request body.
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+// openAPIResponse describes a response of an operation.
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+// openAPIMediaType describes the schema of a content type.
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+// openAPISchema describes a data type.
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// generateOpenAPI generates OpenAPI 3.0 specification in JSON and returns an output.
+func (a *API) generateOpenAPI() ([]byte, error) {
+	title := a.PackageName
+	if title == "" {
+		title = "API"
+	}
+
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       title,
+			Description: a.Description,
+			Version:     a.Version,
+		},
+		Paths: map[string]map[string]*openAPIOperation{},
+		Components: openAPIComponents{
+			Schemas: map[string]*openAPISchema{},
+		},
+	}
+
+	errorSchema := &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"error": {Type: "string"},
+		},
+	}
+
+	for _, group := range a.EndpointGroups {
+		for _, endpoint := range group.endpoints {
+			operation := &openAPIOperation{
+				Summary:     endpoint.Name,
+				Description: endpoint.Description,
+				OperationID: endpoint.MethodName,
+				Tags:        []string{group.Name},
+				Responses: map[string]*openAPIResponse{
+					"default": {
+						Description: "error",
+						Content:     jsonContent(errorSchema),
+					},
+				},
+			}
+
+			for _, param := range endpoint.Params {
+				switch {
+				case param.isQuery(endpoint.Method):
+					operation.Parameters = append(operation.Parameters, openAPIParameter{
+						Name:     param.Name,
+						In:       "query",
+						Required: !param.Optional,
+						Schema:   doc.schema(param.Type),
+					})
+				case endpoint.Method == http.MethodDelete ||
+					endpoint.Method == http.MethodPatch && param.Type == reflect.TypeOf(uuid.UUID{}):
+					operation.Parameters = append(operation.Parameters, openAPIParameter{
+						Name:     param.Name,
+						In:       "path",
+						Required: true,
+						Schema:   doc.schema(param.Type),
+					})
+				default:
+					if operation.RequestBody != nil {
+						return nil, errs.New("%s has more than one body param", endpoint.MethodName)
+					}
+					operation.RequestBody = &openAPIRequestBody{
+						Required: true,
+						Content:  jsonContent(doc.schema(param.Type)),
+					}
+				}
+			}
+
+			ok := &openAPIResponse{Description: "OK"}
+			if endpoint.Response != nil {
+				ok.Content = jsonContent(doc.schema(reflect.TypeOf(endpoint.Response)))
+			}
+			operation.Responses["200"] = ok
+
+			path := "/api/" + a.Version + "/" + group.Prefix + endpoint.Path
+			if doc.Paths[path] == nil {
+				doc.Paths[path] = map[string]*openAPIOperation{}
+			}
+			doc.Paths[path][strings.ToLower(endpoint.Method)] = operation
+		}
+	}
+
+	generated, err := json.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(generated, '\n'), nil
+}
+
+// jsonContent returns the content of a json request or response with the schema.
+func jsonContent(schema *openAPISchema) map[string]openAPIMediaType {
+	return map[string]openAPIMediaType{
+		"application/json": {Schema: schema},
+	}
+}
+
+// schema returns the schema of t. Named struct types are added to
+// the components, deduplicated by their name, and referenced.
+func (doc *openAPIDocument) schema(t reflect.Type) *openAPISchema {
+	switch t {
+	case reflect.TypeOf(uuid.UUID{}):
+		return &openAPISchema{Type: "string", Format: "uuid"}
+	case reflect.TypeOf(time.Time{}):
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	}
+
+	// types with custom json encoding, e.g. memory.Size, are encoded as strings.
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return &openAPISchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return doc.schema(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: doc.schema(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: doc.schema(t.Elem())}
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return doc.objectSchema(t)
+		}
+		ref := &openAPISchema{Ref: "#/components/schemas/" + t.Name()}
+		if _, ok := doc.Components.Schemas[t.Name()]; ok {
+			return ref
+		}
+		// the placeholder breaks the recursion of self-referencing types.
+		doc.Components.Schemas[t.Name()] = &openAPISchema{}
+		doc.Components.Schemas[t.Name()] = doc.objectSchema(t)
+		return ref
+	default:
+		return &openAPISchema{}
+	}
+}
+
+// objectSchema returns the schema of a struct type with its fields as properties.
+func (doc *openAPIDocument) objectSchema(t reflect.Type) *openAPISchema {
+	object := &openAPISchema{
+		Type:       "object",
+		Properties: map[string]*openAPISchema{},
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			if comma := strings.IndexByte(tag, ','); comma >= 0 {
+				tag = tag[:comma]
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		if field.Anonymous && name == field.Name && field.Type.Kind() == reflect.Struct {
+			for name, property := range doc.objectSchema(field.Type).Properties {
+				object.Properties[name] = property
+			}
+			continue
+		}
+
+		property := doc.schema(field.Type)
+		if field.Type.Kind() == reflect.Ptr && property.Ref == "" {
+			property.Nullable = true
+		}
+		object.Properties[name] = property
+	}
+
+	return object
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/apigen"
+)
+
+func TestGenerateOpenAPI(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	a := &apigen.API{
+		Version:     "v0",
+		Description: "documents api",
+		PackageName: "apigen_test",
+	}
+
+	g := a.Group("DocumentManagement", "documents")
+
+	g.Post("/create", &apigen.Endpoint{
+		Name:        "Create Document",
+		Description: "Creates new document",
+		MethodName:  "CreateDocument",
+		Response:    &Document{},
+		Params: []apigen.Param{
+			apigen.NewParam("document", Document{}),
+		},
+	})
+
+	g.Patch("/update/{id}", &apigen.Endpoint{
+		Name:        "Update Document",
+		Description: "Updates document",
+		MethodName:  "UpdateDocument",
+		Response:    &Document{},
+		Params: []apigen.Param{
+			apigen.NewParam("id", uuid.UUID{}),
+			apigen.NewParam("document", Document{}),
+		},
+	})
+
+	g.Get("/", &apigen.Endpoint{
+		Name:        "List Documents",
+		Description: "Lists documents",
+		MethodName:  "ListDocuments",
+		Response:    []Document{},
+		Params: []apigen.Param{
+			apigen.NewQueryParam("since", time.Time{}),
+		},
+	})
+
+	g.Delete("/delete/{id}", &apigen.Endpoint{
+		Name:        "Delete Document",
+		Description: "Deletes document",
+		MethodName:  "DeleteDocument",
+		Params: []apigen.Param{
+			apigen.NewParam("id", uuid.UUID{}),
+		},
+	})
+
+	generated := ctx.File("openapi.json")
+	a.MustWriteOpenAPI(generated)
+
+	data, err := os.ReadFile(generated)
+	require.NoError(t, err)
+
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+			Parameters  []struct {
+				Name string `json:"name"`
+				In   string `json:"in"`
+			} `json:"parameters"`
+			RequestBody *json.RawMessage `json:"requestBody"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+	require.Equal(t, "3.0.3", spec.OpenAPI)
+
+	for _, endpoint := range []struct {
+		path, method, operationID string
+	}{
+		{"/api/v0/documents/create", "post", "CreateDocument"},
+		{"/api/v0/documents/update/{id}", "patch", "UpdateDocument"},
+		{"/api/v0/documents/", "get", "ListDocuments"},
+		{"/api/v0/documents/delete/{id}", "delete", "DeleteDocument"},
+	} {
+		operation, ok := spec.Paths[endpoint.path][endpoint.method]
+		require.True(t, ok, "%s %s", endpoint.method, endpoint.path)
+		require.Equal(t, endpoint.operationID, operation.OperationID)
+	}
+
+	update := spec.Paths["/api/v0/documents/update/{id}"]["patch"]
+	require.Len(t, update.Parameters, 1)
+	require.Equal(t, "id", update.Parameters[0].Name)
+	require.Equal(t, "path", update.Parameters[0].In)
+	require.NotNil(t, update.RequestBody)
+
+	list := spec.Paths["/api/v0/documents/"]["get"]
+	require.Len(t, list.Parameters, 1)
+	require.Equal(t, "since", list.Parameters[0].Name)
+	require.Equal(t, "query", list.Parameters[0].In)
+
+	// the document is used by several endpoints, but declared once.
+	require.Len(t, spec.Components.Schemas, 1)
+	require.Contains(t, spec.Components.Schemas["Document"].Properties, "title")
+}
@@ -0,0 +1,159 @@
+// AUTOGENERATED BY private/apigen
+// DO NOT EDIT.
+
+package apigen_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/api"
+)
+
+var ErrDocumentsAPI = errs.Class("apigen_test documents api")
+
+type DocumentManagementService interface {
+	CreateDocument(context.Context, apigen_test.Document) (*apigen_test.Document, api.HTTPError)
+	AddNote(context.Context, apigen_test.Note) api.HTTPError
+	ListNotes(context.Context, uint, uint) (*apigen_test.NotesPage, api.HTTPError)
+}
+
+// DocumentManagementHandler is an api handler that exposes all documents related functionality.
+type DocumentManagementHandler struct {
+	log     *zap.Logger
+	service DocumentManagementService
+	auth    api.Auth
+}
+
+func NewDocumentManagement(log *zap.Logger, service DocumentManagementService, router *mux.Router, auth api.Auth) *DocumentManagementHandler {
+	handler := &DocumentManagementHandler{
+		log:     log,
+		service: service,
+		auth:    auth,
+	}
+
+	documentsRouter := router.PathPrefix("/api/v0/documents").Subrouter()
+	documentsRouter.HandleFunc("/create", handler.handleCreateDocument).Methods("POST")
+	documentsRouter.HandleFunc("/note", handler.handleAddNote).Methods("POST")
+	documentsRouter.HandleFunc("/notes", handler.handleListNotes).Methods("GET")
+
+	return handler
+}
+
+func (h *DocumentManagementHandler) handleCreateDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	document := &apigen_test.Document{}
+	if err = json.NewDecoder(r.Body).Decode(&document); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = document.Validate(); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	retVal, httpErr := h.service.CreateDocument(ctx, *document)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json CreateDocument response", zap.Error(ErrDocumentsAPI.Wrap(err)))
+	}
+}
+
+func (h *DocumentManagementHandler) handleAddNote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	note := &apigen_test.Note{}
+	if err = json.NewDecoder(r.Body).Decode(&note); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	httpErr := h.service.AddNote(ctx, *note)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+	}
+}
+
+func (h *DocumentManagementHandler) handleListNotes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	var limit uint
+	if limitQuery := r.URL.Query().Get("limit"); limitQuery != "" {
+		limitValue, err := strconv.ParseUint(limitQuery, 10, 32)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, errs.New("parameter 'limit' must be a non-negative integer"))
+			return
+		}
+		limit = uint(limitValue)
+	}
+
+	var page uint
+	if pageQuery := r.URL.Query().Get("page"); pageQuery != "" {
+		pageValue, err := strconv.ParseUint(pageQuery, 10, 32)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, errs.New("parameter 'page' must be a non-negative integer"))
+			return
+		}
+		page = uint(pageValue)
+	} else {
+		api.ServeError(h.log, w, http.StatusBadRequest, errs.New("parameter 'page' can't be empty"))
+		return
+	}
+
+	retVal, httpErr := h.service.ListNotes(ctx, limit, page)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json ListNotes response", zap.Error(ErrDocumentsAPI.Wrap(err)))
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+)
+
+// MustWriteTS writes generated TypeScript code into a file.
+func (a *API) MustWriteTS(path string) {
+	generated, err := a.generateTS()
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+
+	err = os.WriteFile(path, generated, 0644)
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+}
+
+// generateTS generates TypeScript types and api client code and returns an output.
+func (a *API) generateTS() ([]byte, error) {
+	var result string
+
+	p := func(format string, a ...interface{}) {
+		result += fmt.Sprintf(format+"\n", a...)
+	}
+
+	types := newTSTypes()
+	for _, group := range a.EndpointGroups {
+		for _, endpoint := range group.endpoints {
+			if endpoint.Response != nil {
+				types.register(reflect.TypeOf(endpoint.Response))
+			}
+			for _, param := range endpoint.Params {
+				types.register(param.Type)
+			}
+		}
+	}
+
+	p("// AUTOGENERATED BY private/apigen")
+	p("// DO NOT EDIT.")
+	p("")
+
+	for _, t := range types.order {
+		p("export interface %s {", types.names[t])
+		for _, field := range types.fields(t) {
+			p("    %s;", field)
+		}
+		p("}")
+		p("")
+	}
+
+	p("async function handleError(response: Response): Promise<never> {")
+	p("    const body = await response.json().catch(() => ({}));")
+	p("    throw new Error(body.error || response.statusText);")
+	p("}")
+
+	for _, group := range a.EndpointGroups {
+		p("")
+		p("export class %sHttpApi%s {", group.Name, strings.ToUpper(a.Version))
+		p("    private readonly ROOT_PATH: string = '/api/%s/%s';", a.Version, group.Prefix)
+
+		for _, endpoint := range group.endpoints {
+			var args []string
+			var query []string
+			var body string
+
+			path := endpoint.Path
+			for _, param := range endpoint.Params {
+				switch {
+				case param.isQuery(endpoint.Method):
+					optional := ""
+					if param.Optional {
+						optional = "?"
+					}
+					args = append(args, param.Name+optional+": "+tsParamType(param.Type))
+					query = append(query, param.Name)
+				case param.Type == reflect.TypeOf(uuid.UUID{}):
+					args = append(args, param.Name+": string")
+					path = strings.ReplaceAll(path, "{"+param.Name+"}", "${"+param.Name+"}")
+				default:
+					args = append(args, param.Name+": "+types.register(param.Type))
+					body = param.Name
+				}
+			}
+
+			returnType := "void"
+			if endpoint.Response != nil {
+				returnType = types.register(reflect.TypeOf(endpoint.Response))
+			}
+
+			p("")
+			p("    /**")
+			p("     * %s.", strings.TrimSuffix(endpoint.Description, "."))
+			p("     */")
+			p("    public async %s(%s): Promise<%s> {", lowerFirst(endpoint.MethodName), strings.Join(args, ", "), returnType)
+			if len(query) > 0 {
+				p("        const query = new URLSearchParams();")
+				for _, name := range query {
+					for _, param := range endpoint.Params {
+						switch {
+						case param.Name != name:
+						case param.Optional:
+							p("        if (%s !== undefined) {", name)
+							p("            query.set('%s', %s);", name, tsParamValue(param))
+							p("        }")
+						default:
+							p("        query.set('%s', %s);", name, tsParamValue(param))
+						}
+					}
+				}
+				p("        const path = `${this.ROOT_PATH}%s?${query.toString()}`;", path)
+			} else {
+				p("        const path = `${this.ROOT_PATH}%s`;", path)
+			}
+
+			p("        const response = await fetch(path, {")
+			p("            method: '%s',", endpoint.Method)
+			if body != "" {
+				p("            headers: { 'Content-Type': 'application/json' },")
+				p("            body: JSON.stringify(%s),", body)
+			}
+			p("        });")
+			p("        if (!response.ok) {")
+			p("            return handleError(response);")
+			p("        }")
+			if endpoint.Response != nil {
+				p("        return response.json().then((body) => body as %s);", returnType)
+			}
+			p("    }")
+		}
+		p("}")
+	}
+
+	return []byte(result), nil
+}
+
+// tsTypes collects struct types which need to be declared as TypeScript interfaces.
+type tsTypes struct {
+	names map[reflect.Type]string
+	order []reflect.Type
+}
+
+// newTSTypes creates a new tsTypes.
+func newTSTypes() *tsTypes {
+	return &tsTypes{
+		names: map[reflect.Type]string{},
+	}
+}
+
+// jsonMarshalerType is used to detect types with custom json encoding.
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// register returns the TypeScript type for t and declares all the struct types used by it.
+func (types *tsTypes) register(t reflect.Type) string {
+	// types with custom json encoding, e.g. uuid.UUID, time.Time and memory.Size,
+	// are encoded as strings.
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return types.register(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are encoded as base64 strings.
+			return "string"
+		}
+		return types.register(t.Elem()) + "[]"
+	case reflect.Map:
+		return "Record<string, " + types.register(t.Elem()) + ">"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Struct:
+		if t.Name() == "" {
+			return "{ " + strings.Join(types.fields(t), "; ") + " }"
+		}
+		if name, ok := types.names[t]; ok {
+			return name
+		}
+		types.names[t] = t.Name()
+		types.order = append(types.order, t)
+		// declare the types of the fields as well.
+		types.fields(t)
+		return t.Name()
+	default:
+		return "unknown"
+	}
+}
+
+// fields returns TypeScript field declarations of a struct type.
+func (types *tsTypes) fields(t reflect.Type) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, options := field.Name, ""
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			if comma := strings.IndexByte(tag, ','); comma >= 0 {
+				tag, options = tag[:comma], tag[comma:]
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		if field.Anonymous && name == field.Name && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, types.fields(field.Type)...)
+			continue
+		}
+
+		optional := ""
+		if strings.Contains(options, ",omitempty") {
+			optional = "?"
+		}
+
+		typ := types.register(field.Type)
+		if field.Type.Kind() == reflect.Ptr {
+			typ += " | null"
+		}
+
+		fields = append(fields, name+optional+": "+typ)
+	}
+	return fields
+}
+
+// tsParamType returns the TypeScript type of a query param.
+func tsParamType(t reflect.Type) string {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return "Date"
+	case reflect.TypeOf(uint(0)):
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// tsParamValue returns the TypeScript expression encoding the query param.
+func tsParamValue(param Param) string {
+	switch param.Type {
+	case reflect.TypeOf(time.Time{}):
+		// toISOString produces RFC 3339 expected by the generated Go handlers.
+		return param.Name + ".toISOString()"
+	case reflect.TypeOf(uint(0)):
+		return "String(" + param.Name + ")"
+	default:
+		return param.Name
+	}
+}
+
+// lowerFirst returns s with its first letter in lower case.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
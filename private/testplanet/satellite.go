@@ -39,6 +39,7 @@ import (
 	"storj.io/storj/satellite/compensation"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleweb"
+	"storj.io/storj/satellite/console/dataerasure"
 	"storj.io/storj/satellite/contact"
 	"storj.io/storj/satellite/gc"
 	"storj.io/storj/satellite/gracefulexit"
@@ -117,8 +118,9 @@ type Satellite struct {
 	}
 
 	Repair struct {
-		Checker  *checker.Checker
-		Repairer *repairer.Service
+		Checker     *checker.Checker
+		HealthChore *checker.HealthChore
+		Repairer    *repairer.Service
 	}
 
 	Audit struct {
@@ -145,6 +147,10 @@ type Satellite struct {
 		Chore *zombiedeletion.Chore
 	}
 
+	DataErasure struct {
+		Chore *dataerasure.Chore
+	}
+
 	Accounting struct {
 		Tally            *tally.Service
 		NodeTally        *nodetally.Service
@@ -577,6 +583,7 @@ func createNewSystem(name string, log *zap.Logger, config satellite.Config, peer
 	system.Orders.Chore = api.Orders.Chore
 
 	system.Repair.Checker = peer.Repair.Checker
+	system.Repair.HealthChore = peer.Repair.HealthChore
 	system.Repair.Repairer = repairerPeer.Repairer
 
 	system.Audit.Queues = peer.Audit.Queues
@@ -589,6 +596,7 @@ func createNewSystem(name string, log *zap.Logger, config satellite.Config, peer
 
 	system.ExpiredDeletion.Chore = peer.ExpiredDeletion.Chore
 	system.ZombieDeletion.Chore = peer.ZombieDeletion.Chore
+	system.DataErasure.Chore = peer.DataErasure.Chore
 
 	system.Accounting.Tally = peer.Accounting.Tally
 	system.Accounting.NodeTally = peer.Accounting.NodeTally
@@ -657,7 +665,7 @@ func (planet *Planet) newRepairer(ctx context.Context, index int, identity *iden
 	rollupsWriteCache := orders.NewRollupsWriteCache(log.Named("orders-write-cache"), db.Orders(), config.Orders.FlushBatchSize)
 	planet.databases = append(planet.databases, rollupsWriteCacheCloser{rollupsWriteCache})
 
-	return satellite.NewRepairer(log, identity, metabaseDB, revocationDB, db.RepairQueue(), db.Buckets(), db.OverlayCache(), db.Reputation(), db.Containment(), rollupsWriteCache, versionInfo, &config, nil)
+	return satellite.NewRepairer(log, identity, metabaseDB, revocationDB, db.RepairQueue(), db.RepairCheckpoints(), db.Buckets(), db.OverlayCache(), db.Reputation(), db.Containment(), rollupsWriteCache, versionInfo, &config, nil)
 }
 
 type rollupsWriteCacheCloser struct {
@@ -21,6 +21,9 @@ const (
 	LastRollup = "LastRollup"
 )
 
+// MaxBucketUsageSeriesPoints is the maximum number of intervals of a bucket usage series.
+const MaxBucketUsageSeriesPoints = 1000
+
 var (
 	// ErrInvalidArgument is returned when a function argument has an invalid
 	// business domain value.
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"storj.io/common/memory"
+	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/compensation"
@@ -34,6 +35,13 @@ type StoragenodeBandwidthRollup struct {
 	Settled       uint64
 }
 
+// RepairBandwidthRollup contains the repair bandwidth allocated for segments of a placement.
+type RepairBandwidthRollup struct {
+	Placement storj.PlacementConstraint
+	Action    pb.PieceAction
+	Allocated int64
+}
+
 // Rollup mirrors dbx.AccountingRollup, allowing us to use that struct without leaking dbx.
 type Rollup struct {
 	ID             int64
@@ -139,6 +147,36 @@ type BucketUsagePage struct {
 	TotalCount  uint64
 }
 
+// ProjectActivityCursor holds info for project activity cursor pagination.
+type ProjectActivityCursor struct {
+	Limit uint
+	Page  uint
+}
+
+// ProjectActivity is the upload or download traffic of a single bucket
+// during a single rollup interval.
+type ProjectActivity struct {
+	BucketName    string
+	Action        pb.PieceAction
+	IntervalStart time.Time
+
+	Inline    int64
+	Allocated int64
+	Settled   int64
+}
+
+// ProjectActivityPage represents project activity page result.
+type ProjectActivityPage struct {
+	Activities []ProjectActivity
+
+	Limit  uint
+	Offset uint64
+
+	PageCount   uint
+	CurrentPage uint
+	TotalCount  uint64
+}
+
 // BucketUsageRollup is total bucket usage info
 // for certain period.
 type BucketUsageRollup struct {
@@ -177,6 +215,8 @@ type StoragenodeAccounting interface {
 	GetTalliesSince(ctx context.Context, latestRollup time.Time) ([]*StoragenodeStorageTally, error)
 	// GetBandwidthSince retrieves all bandwidth rollup entires since latestRollup
 	GetBandwidthSince(ctx context.Context, latestRollup time.Time, cb func(context.Context, *StoragenodeBandwidthRollup) error) error
+	// GetRepairBandwidthByPlacement returns the repair bandwidth allocated in the given period, summed by placement and action
+	GetRepairBandwidthByPlacement(ctx context.Context, from, to time.Time) ([]RepairBandwidthRollup, error)
 	// SaveRollup records tally and bandwidth rollup aggregations to the database
 	SaveRollup(ctx context.Context, latestTally time.Time, stats RollupStats) error
 	// LastTimestamp records and returns the latest last tallied time.
@@ -234,14 +274,23 @@ type ProjectAccounting interface {
 	GetProjectLimits(ctx context.Context, projectID uuid.UUID) (ProjectLimits, error)
 	// GetProjectTotal returns project usage summary for specified period of time.
 	GetProjectTotal(ctx context.Context, projectID uuid.UUID, since, before time.Time) (*ProjectUsage, error)
+	// GetProjectTotalByInterval returns project usage summaries for consecutive intervals of specified period of time.
+	GetProjectTotalByInterval(ctx context.Context, projectID uuid.UUID, since, before time.Time, interval time.Duration) ([]ProjectUsage, error)
 	// GetProjectObjectsSegments returns project objects and segments for specified period of time.
 	GetProjectObjectsSegments(ctx context.Context, projectID uuid.UUID) (*ProjectObjectsSegments, error)
 	// GetBucketUsageRollups returns usage rollup per each bucket for specified period of time.
 	GetBucketUsageRollups(ctx context.Context, projectID uuid.UUID, since, before time.Time) ([]BucketUsageRollup, error)
+	// GetBucketUsageRollupsBatched returns usage rollup per each bucket for specified period of time,
+	// retrieving the usage of all buckets at once.
+	GetBucketUsageRollupsBatched(ctx context.Context, projectID uuid.UUID, since, before time.Time) ([]BucketUsageRollup, error)
 	// GetSingleBucketUsageRollup returns usage rollup per single bucket for specified period of time.
 	GetSingleBucketUsageRollup(ctx context.Context, projectID uuid.UUID, bucket string, since, before time.Time) (*BucketUsageRollup, error)
+	// GetBucketUsageSeries returns usage rollups of a single bucket for every interval of specified period of time.
+	GetBucketUsageSeries(ctx context.Context, projectID uuid.UUID, bucket string, since, before time.Time, interval time.Duration) ([]BucketUsageRollup, error)
 	// GetBucketTotals returns per bucket total usage summary since bucket creation.
 	GetBucketTotals(ctx context.Context, projectID uuid.UUID, cursor BucketUsageCursor, before time.Time) (*BucketUsagePage, error)
+	// GetProjectActivity returns paged upload and download activity of the project buckets, newest first.
+	GetProjectActivity(ctx context.Context, projectID uuid.UUID, cursor ProjectActivityCursor) (*ProjectActivityPage, error)
 	// ArchiveRollupsBefore archives rollups older than a given time and returns number of bucket bandwidth rollups archived.
 	ArchiveRollupsBefore(ctx context.Context, before time.Time, batchSize int) (numArchivedBucketBW int, err error)
 	// GetRollupsSince retrieves all archived bandwidth rollup records since a given time. A hard limit batch size is used for results.
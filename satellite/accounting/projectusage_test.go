@@ -718,6 +718,36 @@ func TestUsageRollups(t *testing.T) {
 			require.NotZero(t, projTotal3Prev3Hours.Egress)
 		})
 
+		t.Run("test project total by interval", func(t *testing.T) {
+			since := start.Add(-30 * time.Minute)
+			usages, err := usageRollups.GetProjectTotalByInterval(ctx, project3, since, now, 2*time.Hour)
+			require.NoError(t, err)
+			require.NotEmpty(t, usages)
+
+			// every interval matches the usage of GetProjectTotal over the same period.
+			i := 0
+			for from := since; from.Before(now); from = from.Add(2 * time.Hour) {
+				to := from.Add(2 * time.Hour)
+				if to.After(now) {
+					to = now
+				}
+				expected, err := usageRollups.GetProjectTotal(ctx, project3, from, to)
+				require.NoError(t, err)
+
+				require.Equal(t, expected.Since, usages[i].Since)
+				require.Equal(t, expected.Before, usages[i].Before)
+				require.InDelta(t, expected.Storage, usages[i].Storage, 1e-6)
+				require.InDelta(t, expected.SegmentCount, usages[i].SegmentCount, 1e-6)
+				require.InDelta(t, expected.ObjectCount, usages[i].ObjectCount, 1e-6)
+				require.Equal(t, expected.Egress, usages[i].Egress)
+				i++
+			}
+			require.Len(t, usages, i)
+
+			_, err = usageRollups.GetProjectTotalByInterval(ctx, project3, since, now, 0)
+			require.True(t, accounting.ErrInvalidArgument.Has(err))
+		})
+
 		t.Run("test bucket usage rollups", func(t *testing.T) {
 			rollups1, err := usageRollups.GetBucketUsageRollups(ctx, project1, start, now)
 			require.NoError(t, err)
@@ -740,6 +770,95 @@ func TestUsageRollups(t *testing.T) {
 			require.NotNil(t, rollups3Prev3Hours)
 		})
 
+		t.Run("test batched bucket usage rollups", func(t *testing.T) {
+			periods := []struct {
+				since, before time.Time
+			}{
+				{start, now},
+				{now.Add(-time.Hour * 2), now.Add(-time.Hour * 1)},
+				{now.Add(-time.Hour * 3), now.Add(-time.Hour * 2)},
+			}
+
+			for _, projectID := range []uuid.UUID{project1, project2, project3} {
+				for _, period := range periods {
+					expected, err := usageRollups.GetBucketUsageRollups(ctx, projectID, period.since, period.before)
+					require.NoError(t, err)
+
+					batched, err := usageRollups.GetBucketUsageRollupsBatched(ctx, projectID, period.since, period.before)
+					require.NoError(t, err)
+					require.Len(t, batched, len(expected))
+
+					byBucket := make(map[string]accounting.BucketUsageRollup)
+					for _, rollup := range batched {
+						byBucket[rollup.BucketName] = rollup
+					}
+					for _, rollup := range expected {
+						require.Equal(t, rollup, byBucket[rollup.BucketName], rollup.BucketName)
+					}
+				}
+			}
+		})
+
+		t.Run("test bucket usage series", func(t *testing.T) {
+			since := time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), 0, 0, 0, start.Location()).UTC()
+			before := since.Add(12 * time.Hour)
+
+			_, err := usageRollups.GetBucketUsageSeries(ctx, project3, buckets[0], since, before, 5*time.Hour)
+			require.True(t, accounting.ErrInvalidArgument.Has(err))
+
+			_, err = usageRollups.GetBucketUsageSeries(ctx, project3, buckets[0], since, before, 30*time.Minute)
+			require.True(t, accounting.ErrInvalidArgument.Has(err))
+
+			_, err = usageRollups.GetBucketUsageSeries(ctx, project3, buckets[0], since, since.Add((accounting.MaxBucketUsageSeriesPoints+1)*time.Hour), time.Hour)
+			require.True(t, accounting.ErrInvalidArgument.Has(err))
+
+			_, err = usageRollups.GetBucketUsageSeries(ctx, project3, buckets[0], before, since, time.Hour)
+			require.True(t, accounting.ErrInvalidArgument.Has(err))
+
+			_, err = usageRollups.GetBucketUsageSeries(ctx, project3, buckets[0], since, before, -time.Hour)
+			require.True(t, accounting.ErrInvalidArgument.Has(err))
+
+			for _, projectID := range []uuid.UUID{project1, project3} {
+				for _, bucket := range buckets {
+					total, err := usageRollups.GetSingleBucketUsageRollup(ctx, projectID, bucket, since, before)
+					require.NoError(t, err)
+
+					for _, interval := range []time.Duration{time.Hour, 3 * time.Hour, 12 * time.Hour} {
+						series, err := usageRollups.GetBucketUsageSeries(ctx, projectID, bucket, since, before, interval)
+						require.NoError(t, err)
+						require.Len(t, series, int(before.Sub(since)/interval))
+						require.Equal(t, since, series[0].Since)
+						require.Equal(t, before, series[len(series)-1].Before)
+
+						var sum accounting.BucketUsageRollup
+						for i, point := range series {
+							require.Equal(t, interval, point.Before.Sub(point.Since))
+							if i > 0 {
+								require.Equal(t, series[i-1].Before, point.Since)
+							}
+
+							sum.TotalStoredData += point.TotalStoredData
+							sum.TotalSegments += point.TotalSegments
+							sum.ObjectCount += point.ObjectCount
+							sum.MetadataSize += point.MetadataSize
+							sum.RepairEgress += point.RepairEgress
+							sum.GetEgress += point.GetEgress
+							sum.AuditEgress += point.AuditEgress
+						}
+
+						const delta = 1e-6
+						require.InDelta(t, total.TotalStoredData, sum.TotalStoredData, delta)
+						require.InDelta(t, total.TotalSegments, sum.TotalSegments, delta)
+						require.InDelta(t, total.ObjectCount, sum.ObjectCount, delta)
+						require.InDelta(t, total.MetadataSize, sum.MetadataSize, delta)
+						require.InDelta(t, total.RepairEgress, sum.RepairEgress, delta)
+						require.InDelta(t, total.GetEgress, sum.GetEgress, delta)
+						require.InDelta(t, total.AuditEgress, sum.AuditEgress, delta)
+					}
+				}
+			}
+		})
+
 		t.Run("test bucket totals", func(t *testing.T) {
 			cursor := accounting.BucketUsageCursor{
 				Limit: 20,
@@ -130,6 +130,10 @@ func (server *Server) getProjectLimit(w http.ResponseWriter, r *http.Request) {
 		Rate struct {
 			RPS int `json:"rps"`
 		} `json:"rate"`
+		EgressRate struct {
+			Amount memory.Size `json:"amount"`
+			Bytes  int64       `json:"bytes"`
+		} `json:"egressRate"`
 		Buckets  int   `json:"maxBuckets"`
 		Segments int64 `json:"maxSegments"`
 	}
@@ -150,6 +154,10 @@ func (server *Server) getProjectLimit(w http.ResponseWriter, r *http.Request) {
 	if project.SegmentLimit != nil {
 		output.Segments = *project.SegmentLimit
 	}
+	if project.AdminEgressRateLimit != nil {
+		output.EgressRate.Amount = *project.AdminEgressRateLimit
+		output.EgressRate.Bytes = project.AdminEgressRateLimit.Int64()
+	}
 
 	data, err := json.Marshal(output)
 	if err != nil {
@@ -180,12 +188,13 @@ func (server *Server) putProjectLimit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var arguments struct {
-		Usage     *memory.Size `schema:"usage"`
-		Bandwidth *memory.Size `schema:"bandwidth"`
-		Rate      *int         `schema:"rate"`
-		Burst     *int         `schema:"burst"`
-		Buckets   *int         `schema:"buckets"`
-		Segments  *int64       `schema:"segments"`
+		Usage      *memory.Size `schema:"usage"`
+		Bandwidth  *memory.Size `schema:"bandwidth"`
+		Rate       *int         `schema:"rate"`
+		Burst      *int         `schema:"burst"`
+		Buckets    *int         `schema:"buckets"`
+		Segments   *int64       `schema:"segments"`
+		EgressRate *memory.Size `schema:"egressRate"`
 	}
 
 	if err := r.ParseForm(); err != nil {
@@ -304,6 +313,21 @@ func (server *Server) putProjectLimit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+
+	if arguments.EgressRate != nil {
+		if *arguments.EgressRate < 0 {
+			sendJSONError(w, "negative egress rate",
+				fmt.Sprintf("%v", arguments.EgressRate), http.StatusBadRequest)
+			return
+		}
+
+		err = server.db.Console().Projects().UpdateAdminEgressRateLimit(ctx, projectUUID, *arguments.EgressRate)
+		if err != nil {
+			sendJSONError(w, "failed to update egress rate limit",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 }
 
 func (server *Server) addProject(w http.ResponseWriter, r *http.Request) {
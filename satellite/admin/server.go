@@ -62,12 +62,14 @@ type Server struct {
 
 	nowFn func() time.Time
 
+	passwords *console.PasswordHasher
+
 	console consoleweb.Config
 	config  Config
 }
 
 // NewServer returns a new administration Server.
-func NewServer(log *zap.Logger, listener net.Listener, db DB, buckets *buckets.Service, restKeys *restkeys.Service, accounts payments.Accounts, console consoleweb.Config, config Config) *Server {
+func NewServer(log *zap.Logger, listener net.Listener, db DB, buckets *buckets.Service, restKeys *restkeys.Service, accounts payments.Accounts, passwords *console.PasswordHasher, console consoleweb.Config, config Config) *Server {
 	server := &Server{
 		log: log,
 
@@ -80,6 +82,8 @@ func NewServer(log *zap.Logger, listener net.Listener, db DB, buckets *buckets.S
 
 		nowFn: time.Now,
 
+		passwords: passwords,
+
 		console: console,
 		config:  config,
 	}
@@ -91,6 +95,7 @@ func NewServer(log *zap.Logger, listener net.Listener, db DB, buckets *buckets.S
 
 	// When adding new options, also update README.md
 	api.HandleFunc("/users", server.addUser).Methods("POST")
+	api.HandleFunc("/users/lookup", server.lookupUsers).Methods("POST")
 	api.HandleFunc("/users/{useremail}", server.updateUser).Methods("PUT")
 	api.HandleFunc("/users/{useremail}", server.userInfo).Methods("GET")
 	api.HandleFunc("/users/{useremail}", server.deleteUser).Methods("DELETE")
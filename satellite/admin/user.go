@@ -13,7 +13,7 @@ import (
 	"strconv"
 
 	"github.com/gorilla/mux"
-	"golang.org/x/crypto/bcrypt"
+	"go.uber.org/zap"
 
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/console"
@@ -64,7 +64,7 @@ func (server *Server) addUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), 0)
+	hash, err := server.passwords.Hash(ctx, []byte(input.Password))
 	if err != nil {
 		sendJSONError(w, "unable to save password hash",
 			"", http.StatusInternalServerError)
@@ -197,6 +197,84 @@ func (server *Server) userInfo(w http.ResponseWriter, r *http.Request) {
 	sendJSONData(w, http.StatusOK, data)
 }
 
+// maxLookupEmails is the maximum number of emails which can be looked up in a single request.
+const maxLookupEmails = 100
+
+func (server *Server) lookupUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		Emails []string `json:"emails"`
+	}
+
+	err = json.Unmarshal(body, &input)
+	if err != nil {
+		sendJSONError(w, "failed to unmarshal request",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(input.Emails) == 0 {
+		sendJSONError(w, "emails missing",
+			"", http.StatusBadRequest)
+		return
+	}
+	if len(input.Emails) > maxLookupEmails {
+		sendJSONError(w, "too many emails",
+			fmt.Sprintf("at most %d emails can be looked up at once", maxLookupEmails), http.StatusBadRequest)
+		return
+	}
+
+	// the emails aren't logged, since they are personal data. The admin is
+	// identified by the email set by the authenticating proxy, if any.
+	server.log.Info("looking up users by email",
+		zap.Int("count", len(input.Emails)),
+		zap.String("admin", r.Header.Get("X-Forwarded-Email")),
+		zap.String("remote address", r.RemoteAddr))
+
+	type User struct {
+		Email  string             `json:"email"`
+		Found  bool               `json:"found"`
+		ID     uuid.UUID          `json:"id"`
+		Status console.UserStatus `json:"status"`
+	}
+
+	output := make([]User, 0, len(input.Emails))
+	for _, email := range input.Emails {
+		verified, unverified, err := server.db.Console().Users().GetByEmailWithUnverified(ctx, email)
+		if err != nil {
+			sendJSONError(w, "failed to get user",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		user := User{Email: email}
+		switch {
+		case verified != nil:
+			user.Found, user.ID, user.Status = true, verified.ID, verified.Status
+		case len(unverified) > 0:
+			user.Found, user.ID, user.Status = true, unverified[0].ID, unverified[0].Status
+		}
+		output = append(output, user)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
 func (server *Server) updateUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -51,6 +51,40 @@ func TestUserGet(t *testing.T) {
 	})
 }
 
+func TestUserLookup(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		owner := planet.Uplinks[0].Projects[0].Owner
+		authToken := sat.Config.Console.AuthToken
+
+		link := "http://" + address.String() + "/api/users/lookup"
+		body := fmt.Sprintf(`{"emails":["%s","unknown@mail.test"]}`, owner.Email)
+		expectedBody := `[` +
+			fmt.Sprintf(`{"email":"%s","found":true,"id":"%s","status":%d},`, owner.Email, owner.ID, console.Active) +
+			`{"email":"unknown@mail.test","found":false,"id":"00000000-0000-0000-0000-000000000000","status":0}]`
+
+		assertReq(ctx, t, link, http.MethodPost, body, http.StatusOK, expectedBody, authToken)
+		assertReq(ctx, t, link, http.MethodPost, `{"emails":[]}`, http.StatusBadRequest, "", authToken)
+
+		// the number of emails per request is limited
+		tooMany := `{"emails":["` + strings.Repeat(`unknown@mail.test","`, 100) + `unknown@mail.test"]}`
+		assertReq(ctx, t, link, http.MethodPost, tooMany, http.StatusBadRequest, "", authToken)
+
+		// the lookup is only available with the admin authorization token
+		assertReq(ctx, t, link, http.MethodPost, body, http.StatusForbidden, "", "wrong token")
+	})
+}
+
 func TestUserAdd(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
@@ -142,6 +142,13 @@ type TrackCreateUserFields struct {
 	HubspotUTK       string
 }
 
+// TestingSetSegmentClient enables reporting and replaces the Segment client.
+// Use only for testing purposes.
+func (service *Service) TestingSetSegmentClient(client segment.Client) {
+	service.config.Enabled = true
+	service.segment = client
+}
+
 func (service *Service) enqueueMessage(message segment.Message) {
 	err := service.segment.Enqueue(message)
 	if err != nil {
@@ -404,13 +411,14 @@ func (service *Service) PageVisitEvent(pageName string, userID uuid.UUID, email
 }
 
 // TrackProjectLimitError sends an "Project Limit Error" event to Segment.
-func (service *Service) TrackProjectLimitError(userID uuid.UUID, email string) {
+func (service *Service) TrackProjectLimitError(userID uuid.UUID, email string, limit int) {
 	if !service.config.Enabled {
 		return
 	}
 
 	props := segment.NewProperties()
 	props.Set("email", email)
+	props.Set("limit", limit)
 
 	service.enqueueMessage(segment.Track{
 		UserId:     userID.String(),
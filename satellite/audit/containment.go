@@ -44,4 +44,6 @@ type Containment interface {
 	Get(ctx context.Context, nodeID pb.NodeID) (*PendingAudit, error)
 	IncrementPending(ctx context.Context, pendingAudit *PendingAudit) error
 	Delete(ctx context.Context, nodeID pb.NodeID) (bool, error)
+	// List returns up to limit pending audits for nodes with an ID greater than cursor, ordered by node ID.
+	List(ctx context.Context, cursor storj.NodeID, limit int) ([]*PendingAudit, error)
 }
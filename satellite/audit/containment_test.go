@@ -4,16 +4,19 @@
 package audit_test
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"storj.io/common/pkcrypto"
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/reputation"
 )
@@ -140,3 +143,50 @@ func TestContainUpdateStats(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestContainList(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		containment := planet.Satellites[0].DB.Containment()
+
+		var expected []*audit.PendingAudit
+		for i, node := range planet.StorageNodes {
+			pending := &audit.PendingAudit{
+				NodeID:            node.ID(),
+				PieceID:           testrand.PieceID(),
+				ExpectedShareHash: pkcrypto.SHA256Hash(testrand.Bytes(10)),
+				StreamID:          testrand.UUID(),
+				Position:          metabase.SegmentPosition{Part: 1, Index: uint32(i)},
+			}
+			require.NoError(t, containment.IncrementPending(ctx, pending))
+			expected = append(expected, pending)
+		}
+
+		// bump the reverify count of the first entry
+		require.NoError(t, containment.IncrementPending(ctx, expected[0]))
+		expected[0].ReverifyCount = 1
+
+		sort.Slice(expected, func(i, k int) bool {
+			return expected[i].NodeID.Less(expected[k].NodeID)
+		})
+
+		_, err := containment.List(ctx, storj.NodeID{}, 0)
+		require.Error(t, err)
+
+		var listed []*audit.PendingAudit
+		var cursor storj.NodeID
+		for {
+			page, err := containment.List(ctx, cursor, 3)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page), 3)
+			if len(page) == 0 {
+				break
+			}
+			listed = append(listed, page...)
+			cursor = page[len(page)-1].NodeID
+		}
+
+		require.Equal(t, expected, listed)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/metabase"
 )
 
 func TestChoreAndWorkerIntegration(t *testing.T) {
@@ -67,3 +68,54 @@ func TestChoreAndWorkerIntegration(t *testing.T) {
 		require.EqualValues(t, 0, queue.Size(), "audit queue")
 	})
 }
+
+func TestWorkerAuditSegment(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 5, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		audits := satellite.Audit
+		audits.Worker.Loop.Pause()
+		audits.Chore.Loop.Pause()
+
+		ul := planet.Uplinks[0]
+		testData := testrand.Bytes(8 * memory.KiB)
+		err := ul.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, ul.Projects[0].ID, "testbucket")
+
+		corruptedPiece := segment.Pieces[0]
+		corruptedNode := planet.FindNode(corruptedPiece.StorageNode)
+		corruptPieceData(ctx, t, planet, corruptedNode, segment.RootPieceID.Derive(corruptedNode.ID(), int32(corruptedPiece.Number)))
+
+		reputationBefore, err := satellite.Reputation.Service.Get(ctx, corruptedNode.ID())
+		require.NoError(t, err)
+
+		report, err := audits.Worker.AuditSegment(ctx, segment.StreamID, segment.Position)
+		require.NoError(t, err)
+		require.Len(t, report.Fails, 1)
+		require.Equal(t, corruptedNode.ID(), report.Fails[0])
+		require.Len(t, report.Successes, len(segment.Pieces)-1)
+		require.NotContains(t, report.Successes, corruptedNode.ID())
+
+		reputationAfter, err := satellite.Reputation.Service.Get(ctx, corruptedNode.ID())
+		require.NoError(t, err)
+		require.Equal(t, reputationBefore.TotalAuditCount+1, reputationAfter.TotalAuditCount)
+		require.Equal(t, reputationBefore.AuditSuccessCount, reputationAfter.AuditSuccessCount)
+		require.Greater(t, reputationAfter.AuditReputationBeta, reputationBefore.AuditReputationBeta)
+
+		for _, nodeID := range report.Successes {
+			info, err := satellite.Reputation.Service.Get(ctx, nodeID)
+			require.NoError(t, err)
+			require.EqualValues(t, 1, info.AuditSuccessCount)
+		}
+
+		// the queue isn't touched by out-of-band audits
+		require.EqualValues(t, 0, audits.Queues.Fetch().Size())
+
+		_, err = audits.Worker.AuditSegment(ctx, testrand.UUID(), metabase.SegmentPosition{})
+		require.Error(t, err)
+		require.True(t, metabase.ErrSegmentNotFound.Has(err))
+	})
+}
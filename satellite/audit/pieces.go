@@ -37,6 +37,11 @@ type Pieces struct {
 	Offline    metabase.Pieces
 	Contained  metabase.Pieces
 	Unknown    metabase.Pieces
+
+	// MinRequired is the number of pieces needed to reconstruct the segment.
+	MinRequired int
+	// Reconstructable is true when enough pieces were downloaded to reconstruct the segment.
+	Reconstructable bool
 }
 
 // PieceAuditFromErr returns piece audit based on error.
@@ -13,6 +13,9 @@ import (
 	"storj.io/common/memory"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/overlay"
 )
 
 // Error is the default audit errs class.
@@ -114,49 +117,105 @@ func (worker *Worker) process(ctx context.Context) (err error) {
 func (worker *Worker) work(ctx context.Context, segment Segment) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	_, err = worker.audit(ctx, segment)
+	return err
+}
+
+// AuditSegment audits the segment at the given position out-of-band, without
+// waiting for it to be picked from the audit queue. It reverifies the nodes
+// which are contained for the segment, verifies the remaining nodes and records
+// the results. The returned report contains the outcome of every audited node.
+func (worker *Worker) AuditSegment(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition) (report Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	segmentInfo, err := worker.verifier.metabase.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+		StreamID: streamID,
+		Position: position,
+	})
+	if err != nil {
+		return Report{}, Error.Wrap(err)
+	}
+	if segmentInfo.Inline() {
+		return Report{}, Error.New("inline segments can't be audited")
+	}
+
+	return worker.audit(ctx, Segment{
+		StreamID:      segmentInfo.StreamID,
+		Position:      segmentInfo.Position,
+		ExpiresAt:     segmentInfo.ExpiresAt,
+		EncryptedSize: segmentInfo.EncryptedSize,
+	})
+}
+
+// audit reverifies and verifies the segment and records the results. It
+// returns the combined reports of both steps.
+func (worker *Worker) audit(ctx context.Context, segment Segment) (_ Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	var errlist errs.Group
 
 	// First, attempt to reverify nodes for this segment that are in containment mode.
-	report, err := worker.verifier.Reverify(ctx, segment)
+	reverifyReport, err := worker.verifier.Reverify(ctx, segment)
 	if err != nil {
 		errlist.Add(err)
 	}
 
 	// TODO(moby) we need to decide if we want to do something with nodes that the reporter failed to update
-	_, err = worker.reporter.RecordAudits(ctx, report)
+	_, err = worker.reporter.RecordAudits(ctx, reverifyReport)
 	if err != nil {
 		errlist.Add(err)
 	}
 
 	// Skip all reverified nodes in the next Verify step.
 	skip := make(map[storj.NodeID]bool)
-	for _, nodeID := range report.Successes {
+	for _, nodeID := range reverifyReport.Successes {
 		skip[nodeID] = true
 	}
-	for _, nodeID := range report.Offlines {
+	for _, nodeID := range reverifyReport.Offlines {
 		skip[nodeID] = true
 	}
-	for _, nodeID := range report.Fails {
+	for _, nodeID := range reverifyReport.Fails {
 		skip[nodeID] = true
 	}
-	for _, pending := range report.PendingAudits {
+	for _, pending := range reverifyReport.PendingAudits {
 		skip[pending.NodeID] = true
 	}
-	for _, nodeID := range report.Unknown {
+	for _, nodeID := range reverifyReport.Unknown {
 		skip[nodeID] = true
 	}
 
 	// Next, audit the the remaining nodes that are not in containment mode.
-	report, err = worker.verifier.Verify(ctx, segment, skip)
+	verifyReport, err := worker.verifier.Verify(ctx, segment, skip)
 	if err != nil {
 		errlist.Add(err)
 	}
 
 	// TODO(moby) we need to decide if we want to do something with nodes that the reporter failed to update
-	_, err = worker.reporter.RecordAudits(ctx, report)
+	_, err = worker.reporter.RecordAudits(ctx, verifyReport)
 	if err != nil {
 		errlist.Add(err)
 	}
 
-	return errlist.Err()
+	return combineReports(reverifyReport, verifyReport), errlist.Err()
+}
+
+// combineReports merges the results of two reports about distinct nodes.
+func combineReports(a, b Report) Report {
+	report := Report{
+		Successes:     append(append(storj.NodeIDList{}, a.Successes...), b.Successes...),
+		Fails:         append(append(storj.NodeIDList{}, a.Fails...), b.Fails...),
+		Offlines:      append(append(storj.NodeIDList{}, a.Offlines...), b.Offlines...),
+		PendingAudits: append(append([]*PendingAudit{}, a.PendingAudits...), b.PendingAudits...),
+		Unknown:       append(append(storj.NodeIDList{}, a.Unknown...), b.Unknown...),
+	}
+	if len(a.NodesReputation)+len(b.NodesReputation) > 0 {
+		report.NodesReputation = make(map[storj.NodeID]overlay.ReputationStatus, len(a.NodesReputation)+len(b.NodesReputation))
+		for nodeID, status := range a.NodesReputation {
+			report.NodesReputation[nodeID] = status
+		}
+		for nodeID, status := range b.NodesReputation {
+			report.NodesReputation[nodeID] = status
+		}
+	}
+	return report
 }
@@ -29,6 +29,13 @@ type DB interface {
 	GetBucket(ctx context.Context, bucketName []byte, projectID uuid.UUID) (bucket storj.Bucket, err error)
 	// GetBucketPlacement returns with the placement constraint identifier.
 	GetBucketPlacement(ctx context.Context, bucketName []byte, projectID uuid.UUID) (placement storj.PlacementConstraint, err error)
+	// GetBucketPlacementAndVersioning returns with the placement constraint identifier
+	// and whether new writes to the bucket create new object versions.
+	GetBucketPlacementAndVersioning(ctx context.Context, bucketName []byte, projectID uuid.UUID) (placement storj.PlacementConstraint, versioning bool, err error)
+	// GetBucketVersioning returns whether new writes to the bucket create new object versions.
+	GetBucketVersioning(ctx context.Context, bucketName []byte, projectID uuid.UUID) (enabled bool, err error)
+	// SetBucketVersioning sets whether new writes to the bucket create new object versions.
+	SetBucketVersioning(ctx context.Context, bucketName []byte, projectID uuid.UUID, enabled bool) (err error)
 	// GetMinimalBucket returns existing bucket with minimal number of fields.
 	GetMinimalBucket(ctx context.Context, bucketName []byte, projectID uuid.UUID) (bucket Bucket, err error)
 	// HasBucket returns if a bucket exists.
@@ -89,6 +89,20 @@ func TestBasicBucketOperations(t *testing.T) {
 		_, err = bucketsDB.GetBucketPlacement(ctx, []byte("not-existing-bucket"), project.ID)
 		require.True(t, storj.ErrBucketNotFound.Has(err), err)
 
+		// GetBucketPlacementAndVersioning
+		placement, versioning, err := bucketsDB.GetBucketPlacementAndVersioning(ctx, []byte("testbucket"), project.ID)
+		require.NoError(t, err)
+		require.Equal(t, expectedBucket.Placement, placement)
+		require.False(t, versioning)
+
+		require.NoError(t, bucketsDB.SetBucketVersioning(ctx, []byte("testbucket"), project.ID, true))
+		_, versioning, err = bucketsDB.GetBucketPlacementAndVersioning(ctx, []byte("testbucket"), project.ID)
+		require.NoError(t, err)
+		require.True(t, versioning)
+
+		_, _, err = bucketsDB.GetBucketPlacementAndVersioning(ctx, []byte("not-existing-bucket"), project.ID)
+		require.True(t, storj.ErrBucketNotFound.Has(err), err)
+
 		// CountBuckets
 		count, err = bucketsDB.CountBuckets(ctx, project.ID)
 		require.NoError(t, err)
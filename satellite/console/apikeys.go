@@ -22,6 +22,8 @@ type APIKeys interface {
 	GetByHead(ctx context.Context, head []byte) (*APIKeyInfo, error)
 	// GetByNameAndProjectID retrieves APIKeyInfo for given key name and projectID
 	GetByNameAndProjectID(ctx context.Context, name string, projectID uuid.UUID) (*APIKeyInfo, error)
+	// GetExpiringByProjectID retrieves APIKeyInfos of the project which expire after the given time, but not later than before
+	GetExpiringByProjectID(ctx context.Context, projectID uuid.UUID, after, before time.Time) ([]APIKeyInfo, error)
 	// Create creates and stores new APIKeyInfo
 	Create(ctx context.Context, head []byte, info APIKeyInfo) (*APIKeyInfo, error)
 	// Update updates APIKeyInfo in store
@@ -43,6 +45,33 @@ type CreateAPIKeyRequest struct {
 	Name      string `json:"name"`
 }
 
+// Validate validates that the project id and name of the API key are set.
+func (request CreateAPIKeyRequest) Validate() error {
+	if request.ProjectID == "" {
+		return ErrValidation.New("project id can't be empty")
+	}
+	if _, err := uuid.FromString(request.ProjectID); err != nil {
+		return ErrValidation.New("invalid project id: %v", err)
+	}
+	if request.Name == "" {
+		return ErrValidation.New("api key name can't be empty")
+	}
+	return nil
+}
+
+// RenameAPIKeyRequest holds rename API key info.
+type RenameAPIKeyRequest struct {
+	Name string `json:"name"`
+}
+
+// Validate validates that the new name of the API key is set.
+func (request RenameAPIKeyRequest) Validate() error {
+	if request.Name == "" {
+		return ErrValidation.New("api key name can't be empty")
+	}
+	return nil
+}
+
 // CreateAPIKeyResponse holds macaroon.APIKey and APIKeyInfo.
 type CreateAPIKeyResponse struct {
 	Key     string      `json:"key"`
@@ -51,14 +80,15 @@ type CreateAPIKeyResponse struct {
 
 // APIKeyInfo describing api key model in the database.
 type APIKeyInfo struct {
-	ID        uuid.UUID `json:"id"`
-	ProjectID uuid.UUID `json:"projectId"`
-	PartnerID uuid.UUID `json:"partnerId"`
-	UserAgent []byte    `json:"userAgent"`
-	Name      string    `json:"name"`
-	Head      []byte    `json:"-"`
-	Secret    []byte    `json:"-"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID        uuid.UUID  `json:"id"`
+	ProjectID uuid.UUID  `json:"projectId"`
+	PartnerID uuid.UUID  `json:"partnerId"`
+	UserAgent []byte     `json:"userAgent"`
+	Name      string     `json:"name"`
+	Head      []byte     `json:"-"`
+	Secret    []byte     `json:"-"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt"`
 }
 
 // APIKeyCursor holds info for api keys cursor pagination.
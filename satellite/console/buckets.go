@@ -30,4 +30,8 @@ type Buckets interface {
 	ListBuckets(ctx context.Context, projectID uuid.UUID, listOpts storj.BucketListOptions, allowedBuckets macaroon.AllowedBuckets) (bucketList storj.BucketList, err error)
 	// CountBuckets returns the number of buckets a project currently has.
 	CountBuckets(ctx context.Context, projectID uuid.UUID) (int, error)
+	// GetBucketVersioning returns whether new writes to the bucket create new object versions.
+	GetBucketVersioning(ctx context.Context, bucketName []byte, projectID uuid.UUID) (enabled bool, err error)
+	// SetBucketVersioning sets whether new writes to the bucket create new object versions.
+	SetBucketVersioning(ctx context.Context, bucketName []byte, projectID uuid.UUID, enabled bool) (err error)
 }
@@ -13,11 +13,25 @@ import (
 
 // TODO: change to JWT or Macaroon based auth
 
+// Purpose restricts what a token can be used for.
+type Purpose string
+
+const (
+	// PurposeActivation is the purpose of account activation tokens.
+	PurposeActivation Purpose = "activation"
+	// PurposeEmailChange is the purpose of email change confirmation tokens.
+	PurposeEmailChange Purpose = "email-change"
+)
+
 // Claims represents data signed by server and used for authentication.
 type Claims struct {
 	ID         uuid.UUID `json:"id"`
 	Email      string    `json:"email,omitempty"`
 	Expiration time.Time `json:"expires,omitempty"`
+	Purpose    Purpose   `json:"purpose,omitempty"`
+	// Nonce ties the token to a single request, so that it can be invalidated
+	// by a later request.
+	Nonce string `json:"nonce,omitempty"`
 }
 
 // JSON returns json representation of Claims.
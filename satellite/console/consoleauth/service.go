@@ -40,12 +40,13 @@ type Signer interface {
 	Sign(data []byte) ([]byte, error)
 }
 
-// CreateToken creates a new auth token.
+// CreateToken creates a new account activation token.
 func (s *Service) CreateToken(ctx context.Context, id uuid.UUID, email string) (_ string, err error) {
 	defer mon.Task()(&ctx)(&err)
 	claims := &Claims{
 		ID:         id,
 		Expiration: time.Now().Add(s.config.TokenExpirationTime),
+		Purpose:    PurposeActivation,
 	}
 	if email != "" {
 		claims.Email = email
@@ -54,6 +55,21 @@ func (s *Service) CreateToken(ctx context.Context, id uuid.UUID, email string) (
 	return s.createToken(ctx, claims)
 }
 
+// CreateEmailChangeToken creates a token confirming the change of the user's
+// email to email. The nonce identifies the email change request.
+func (s *Service) CreateEmailChangeToken(ctx context.Context, id uuid.UUID, email, nonce string) (_ string, err error) {
+	defer mon.Task()(&ctx)(&err)
+	claims := &Claims{
+		ID:         id,
+		Email:      email,
+		Expiration: time.Now().Add(s.config.TokenExpirationTime),
+		Purpose:    PurposeEmailChange,
+		Nonce:      nonce,
+	}
+
+	return s.createToken(ctx, claims)
+}
+
 // createToken creates string representation.
 func (s *Service) createToken(ctx context.Context, claims *Claims) (_ string, err error) {
 	defer mon.Task()(&ctx)(&err)
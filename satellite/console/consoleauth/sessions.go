@@ -16,6 +16,8 @@ type WebappSessions interface {
 	Create(ctx context.Context, id, userID uuid.UUID, ip, userAgent string, expires time.Time) (WebappSession, error)
 	// GetBySessionID gets the session info from the session ID.
 	GetBySessionID(ctx context.Context, sessionID uuid.UUID) (WebappSession, error)
+	// UpdateLastUsedAt updates the time the webapp session was last used.
+	UpdateLastUsedAt(ctx context.Context, sessionID uuid.UUID, lastUsedAt time.Time) error
 	// GetAllByUserID gets all webapp sessions with userID.
 	GetAllByUserID(ctx context.Context, userID uuid.UUID) ([]WebappSession, error)
 	// DeleteBySessionID deletes a webapp session by ID.
@@ -32,4 +34,22 @@ type WebappSession struct {
 	UserAgent string
 	Status    int
 	ExpiresAt time.Time
+	// LastUsedAt is the time of the last request authenticated with the
+	// session. It's not set for sessions created before it was tracked.
+	LastUsedAt *time.Time
+}
+
+// sessionID is the context key for the ID of the session a request was
+// authenticated with.
+type sessionID struct{}
+
+// WithSessionID creates context with the ID of the webapp session.
+func WithSessionID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, sessionID{}, id)
+}
+
+// GetSessionID returns the ID of the webapp session from context if it exists.
+func GetSessionID(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(sessionID{}).(uuid.UUID)
+	return id, ok
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -19,8 +20,6 @@ import (
 	"storj.io/storj/satellite/console"
 )
 
-const dateLayout = "2006-01-02T15:04:05.000Z"
-
 var ErrProjectsAPI = errs.Class("consoleapi projects api")
 var ErrApikeysAPI = errs.Class("consoleapi apikeys api")
 var ErrUsersAPI = errs.Class("consoleapi users api")
@@ -29,13 +28,15 @@ type ProjectManagementService interface {
 	GenCreateProject(context.Context, console.ProjectInfo) (*console.Project, api.HTTPError)
 	GenUpdateProject(context.Context, uuid.UUID, console.ProjectInfo) (*console.Project, api.HTTPError)
 	GenDeleteProject(context.Context, uuid.UUID) api.HTTPError
-	GenGetUsersProjects(context.Context) ([]console.Project, api.HTTPError)
+	GenGetUsersProjects(context.Context, uint, uint) (*console.ProjectsPage, api.HTTPError)
 	GenGetSingleBucketUsageRollup(context.Context, uuid.UUID, string, time.Time, time.Time) (*accounting.BucketUsageRollup, api.HTTPError)
 	GenGetBucketUsageRollups(context.Context, uuid.UUID, time.Time, time.Time) ([]accounting.BucketUsageRollup, api.HTTPError)
+	GenGetBucketUsageSeries(context.Context, uuid.UUID, string, time.Time, time.Time, string) ([]accounting.BucketUsageRollup, api.HTTPError)
 }
 
 type APIKeyManagementService interface {
 	GenCreateAPIKey(context.Context, console.CreateAPIKeyRequest) (*console.CreateAPIKeyResponse, api.HTTPError)
+	GenRenameAPIKey(context.Context, uuid.UUID, console.RenameAPIKeyRequest) (*console.APIKeyInfo, api.HTTPError)
 }
 
 type UserManagementService interface {
@@ -77,6 +78,7 @@ func NewProjectManagement(log *zap.Logger, service ProjectManagementService, rou
 	projectsRouter.HandleFunc("/", handler.handleGenGetUsersProjects).Methods("GET")
 	projectsRouter.HandleFunc("/bucket-rollup", handler.handleGenGetSingleBucketUsageRollup).Methods("GET")
 	projectsRouter.HandleFunc("/bucket-rollups", handler.handleGenGetBucketUsageRollups).Methods("GET")
+	projectsRouter.HandleFunc("/bucket-usage-series", handler.handleGenGetBucketUsageSeries).Methods("GET")
 
 	return handler
 }
@@ -90,6 +92,7 @@ func NewAPIKeyManagement(log *zap.Logger, service APIKeyManagementService, route
 
 	apikeysRouter := router.PathPrefix("/api/v0/apikeys").Subrouter()
 	apikeysRouter.HandleFunc("/create", handler.handleGenCreateAPIKey).Methods("POST")
+	apikeysRouter.HandleFunc("/rename/{id}", handler.handleGenRenameAPIKey).Methods("PATCH")
 
 	return handler
 }
@@ -126,6 +129,11 @@ func (h *ProjectManagementHandler) handleGenCreateProject(w http.ResponseWriter,
 		return
 	}
 
+	if err = projectInfo.Validate(); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
 	retVal, httpErr := h.service.GenCreateProject(ctx, *projectInfo)
 	if httpErr.Err != nil {
 		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
@@ -170,6 +178,11 @@ func (h *ProjectManagementHandler) handleGenUpdateProject(w http.ResponseWriter,
 		return
 	}
 
+	if err = projectInfo.Validate(); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
 	retVal, httpErr := h.service.GenUpdateProject(ctx, id, *projectInfo)
 	if httpErr.Err != nil {
 		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
@@ -227,7 +240,27 @@ func (h *ProjectManagementHandler) handleGenGetUsersProjects(w http.ResponseWrit
 		return
 	}
 
-	retVal, httpErr := h.service.GenGetUsersProjects(ctx)
+	var limit uint
+	if limitQuery := r.URL.Query().Get("limit"); limitQuery != "" {
+		limitValue, err := strconv.ParseUint(limitQuery, 10, 32)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, errs.New("parameter 'limit' must be a non-negative integer"))
+			return
+		}
+		limit = uint(limitValue)
+	}
+
+	var page uint
+	if pageQuery := r.URL.Query().Get("page"); pageQuery != "" {
+		pageValue, err := strconv.ParseUint(pageQuery, 10, 32)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, errs.New("parameter 'page' must be a non-negative integer"))
+			return
+		}
+		page = uint(pageValue)
+	}
+
+	retVal, httpErr := h.service.GenGetUsersProjects(ctx, limit, page)
 	if httpErr.Err != nil {
 		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
 		return
@@ -265,13 +298,13 @@ func (h *ProjectManagementHandler) handleGenGetSingleBucketUsageRollup(w http.Re
 		return
 	}
 
-	since, err := time.Parse(dateLayout, r.URL.Query().Get("since"))
+	since, err := time.Parse(time.RFC3339, r.URL.Query().Get("since"))
 	if err != nil {
 		api.ServeError(h.log, w, http.StatusBadRequest, err)
 		return
 	}
 
-	before, err := time.Parse(dateLayout, r.URL.Query().Get("before"))
+	before, err := time.Parse(time.RFC3339, r.URL.Query().Get("before"))
 	if err != nil {
 		api.ServeError(h.log, w, http.StatusBadRequest, err)
 		return
@@ -309,13 +342,13 @@ func (h *ProjectManagementHandler) handleGenGetBucketUsageRollups(w http.Respons
 		return
 	}
 
-	since, err := time.Parse(dateLayout, r.URL.Query().Get("since"))
+	since, err := time.Parse(time.RFC3339, r.URL.Query().Get("since"))
 	if err != nil {
 		api.ServeError(h.log, w, http.StatusBadRequest, err)
 		return
 	}
 
-	before, err := time.Parse(dateLayout, r.URL.Query().Get("before"))
+	before, err := time.Parse(time.RFC3339, r.URL.Query().Get("before"))
 	if err != nil {
 		api.ServeError(h.log, w, http.StatusBadRequest, err)
 		return
@@ -333,6 +366,62 @@ func (h *ProjectManagementHandler) handleGenGetBucketUsageRollups(w http.Respons
 	}
 }
 
+func (h *ProjectManagementHandler) handleGenGetBucketUsageSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	projectID, err := uuid.FromString(r.URL.Query().Get("projectID"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if bucket == "" {
+		api.ServeError(h.log, w, http.StatusBadRequest, errs.New("parameter 'bucket' can't be empty"))
+		return
+	}
+
+	since, err := time.Parse(time.RFC3339, r.URL.Query().Get("since"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	before, err := time.Parse(time.RFC3339, r.URL.Query().Get("before"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		api.ServeError(h.log, w, http.StatusBadRequest, errs.New("parameter 'interval' can't be empty"))
+		return
+	}
+
+	retVal, httpErr := h.service.GenGetBucketUsageSeries(ctx, projectID, bucket, since, before, interval)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenGetBucketUsageSeries response", zap.Error(ErrProjectsAPI.Wrap(err)))
+	}
+}
+
 func (h *APIKeyManagementHandler) handleGenCreateAPIKey(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var err error
@@ -353,6 +442,11 @@ func (h *APIKeyManagementHandler) handleGenCreateAPIKey(w http.ResponseWriter, r
 		return
 	}
 
+	if err = apikeyInfo.Validate(); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
 	retVal, httpErr := h.service.GenCreateAPIKey(ctx, *apikeyInfo)
 	if httpErr.Err != nil {
 		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
@@ -365,6 +459,55 @@ func (h *APIKeyManagementHandler) handleGenCreateAPIKey(w http.ResponseWriter, r
 	}
 }
 
+func (h *APIKeyManagementHandler) handleGenRenameAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		api.ServeError(h.log, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	apikeyInfo := &console.RenameAPIKeyRequest{}
+	if err = json.NewDecoder(r.Body).Decode(&apikeyInfo); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = apikeyInfo.Validate(); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	retVal, httpErr := h.service.GenRenameAPIKey(ctx, id, *apikeyInfo)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenRenameAPIKey response", zap.Error(ErrApikeysAPI.Wrap(err)))
+	}
+}
+
 func (h *UserManagementHandler) handleGenGetUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var err error
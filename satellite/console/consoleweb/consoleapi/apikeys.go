@@ -4,7 +4,9 @@
 package consoleapi
 
 import (
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -69,6 +71,45 @@ func (keys *APIKeys) DeleteByNameAndProjectID(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// ListExpiring returns the api keys of the project which expire within the given duration.
+func (keys *APIKeys) ListExpiring(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, err := uuid.FromString(r.URL.Query().Get("projectID"))
+	if err != nil {
+		keys.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	within, err := time.ParseDuration(r.URL.Query().Get("within"))
+	if err != nil {
+		keys.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	expiring, err := keys.service.ListExpiringAPIKeys(ctx, projectID, within)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+			keys.serveJSONError(w, http.StatusUnauthorized, err)
+		case console.ErrValidation.Has(err):
+			keys.serveJSONError(w, http.StatusBadRequest, err)
+		default:
+			keys.serveJSONError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(expiring)
+	if err != nil {
+		keys.log.Error("failed to write json expiring api keys response", zap.Error(ErrAPIKeysAPI.Wrap(err)))
+	}
+}
+
 // serveJSONError writes JSON error to response output stream.
 func (keys *APIKeys) serveJSONError(w http.ResponseWriter, status int, err error) {
 	serveJSONError(keys.log, w, status, err)
@@ -4,6 +4,7 @@
 package consoleapi_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
@@ -90,3 +91,66 @@ func Test_DeleteAPIKeyByNameAndProjectID(t *testing.T) {
 		}()
 	})
 }
+
+func Test_ListExpiringAPIKeys(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.RateLimit.Burst = 10
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "test_name",
+			Email:    "expiringkeys@test.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, user.ID, "expiringkeys")
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		expiring, _, err := sat.API.Console.Service.CreateAPIKeyWithExpiration(userCtx, project.ID, "expiring", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		_, _, err = sat.API.Console.Service.CreateAPIKeyWithExpiration(userCtx, project.ID, "later", time.Now().Add(72*time.Hour))
+		require.NoError(t, err)
+
+		// we are using full name as a password
+		token, err := sat.API.Console.Service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName})
+		require.NoError(t, err)
+
+		request := func(within string) *http.Response {
+			req, err := http.NewRequestWithContext(ctx, "GET", "http://"+sat.API.Console.Listener.Addr().String()+"/api/v0/api-keys/expiring?projectID="+project.ID.String()+"&within="+within, nil)
+			require.NoError(t, err)
+
+			req.AddCookie(&http.Cookie{
+				Name:    "_tokenKey",
+				Path:    "/",
+				Value:   token.String(),
+				Expires: time.Now().AddDate(0, 0, 1),
+			})
+
+			result, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			return result
+		}
+
+		result := request("24h")
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		var keys []console.APIKeyInfo
+		require.NoError(t, json.NewDecoder(result.Body).Decode(&keys))
+		require.NoError(t, result.Body.Close())
+		require.Len(t, keys, 1)
+		require.Equal(t, expiring.ID, keys[0].ID)
+
+		result = request("invalid")
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -51,6 +52,7 @@ type Auth struct {
 	PasswordRecoveryURL       string
 	CancelPasswordRecoveryURL string
 	ActivateAccountURL        string
+	ConfirmEmailChangeURL     string
 	service                   *console.Service
 	analytics                 *analytics.Service
 	mailService               *mailservice.Service
@@ -69,6 +71,7 @@ func NewAuth(log *zap.Logger, service *console.Service, mailService *mailservice
 		PasswordRecoveryURL:       externalAddress + "password-recovery/",
 		CancelPasswordRecoveryURL: externalAddress + "cancel-password-recovery/",
 		ActivateAccountURL:        externalAddress + "activation/",
+		ConfirmEmailChangeURL:     externalAddress + "confirm-email-change/",
 		service:                   service,
 		mailService:               mailService,
 		cookieAuth:                cookieAuth,
@@ -459,7 +462,8 @@ func (a *Auth) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 	a.serveJSONError(w, errNotImplemented)
 }
 
-// ChangeEmail auth user, changes users email for a new one.
+// ChangeEmail auth user, sends a confirmation link to the new email. The email
+// is changed once the link is opened.
 func (a *Auth) ChangeEmail(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var err error
@@ -475,11 +479,32 @@ func (a *Auth) ChangeEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = a.service.ChangeEmail(ctx, emailChange.NewEmail)
+	token, err := a.service.ChangeEmail(ctx, emailChange.NewEmail)
 	if err != nil {
 		a.serveJSONError(w, err)
 		return
 	}
+
+	user, err := console.GetUser(ctx)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	userName := user.ShortName
+	if user.ShortName == "" {
+		userName = user.FullName
+	}
+
+	a.mailService.SendRenderedAsync(
+		ctx,
+		[]post.Address{{Address: emailChange.NewEmail, Name: userName}},
+		&consoleql.EmailChangeVerificationEmail{
+			ConfirmationLink: a.ConfirmEmailChangeURL + "?token=" + token,
+			Origin:           a.ExternalAddress,
+			UserName:         userName,
+		},
+	)
 }
 
 // ChangePassword auth user, changes users password for a new one.
@@ -506,6 +531,116 @@ func (a *Auth) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetSecurityEvents returns a page of the user's account security events.
+func (a *Auth) GetSecurityEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	// limit and page are optional, the service defaults to the first page.
+	var cursor console.SecurityEventsCursor
+
+	if limitQuery := r.URL.Query().Get("limit"); limitQuery != "" {
+		limit, err := strconv.ParseUint(limitQuery, 10, 32)
+		if err != nil {
+			a.serveJSONError(w, console.ErrValidation.New("parameter 'limit' must be a non-negative integer"))
+			return
+		}
+		cursor.Limit = int(limit)
+	}
+
+	if pageQuery := r.URL.Query().Get("page"); pageQuery != "" {
+		page, err := strconv.ParseUint(pageQuery, 10, 32)
+		if err != nil {
+			a.serveJSONError(w, console.ErrValidation.New("parameter 'page' must be a non-negative integer"))
+			return
+		}
+		cursor.Page = int(page)
+	}
+
+	page, err := a.service.GetSecurityEvents(ctx, cursor)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		a.log.Error("could not encode security events", zap.Error(ErrAuthAPI.Wrap(err)))
+		return
+	}
+}
+
+// MarkSecurityEventsReviewed marks the user's account security events as reviewed.
+func (a *Auth) MarkSecurityEventsReviewed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request struct {
+		IDs []uuid.UUID `json:"ids"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	err = a.service.MarkSecurityEventsReviewed(ctx, request.IDs)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+}
+
+// RequestDataErasure schedules the erasure of all of the user's objects.
+func (a *Auth) RequestDataErasure(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	job, err := a.service.RequestDataErasure(ctx)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(job)
+	if err != nil {
+		a.log.Error("could not encode data erasure job", zap.Error(ErrAuthAPI.Wrap(err)))
+		return
+	}
+}
+
+// GetDataErasureJob returns the status of the user's data erasure job.
+func (a *Auth) GetDataErasureJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		a.serveJSONError(w, console.ErrValidation.Wrap(err))
+		return
+	}
+
+	job, err := a.service.GetDataErasureJob(ctx, id)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(job)
+	if err != nil {
+		a.log.Error("could not encode data erasure job", zap.Error(ErrAuthAPI.Wrap(err)))
+		return
+	}
+}
+
 // ForgotPassword creates password-reset token and sends email to user.
 func (a *Auth) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -774,6 +909,8 @@ func (a *Auth) getStatusCode(err error) int {
 		return http.StatusUnauthorized
 	case console.ErrEmailUsed.Has(err), console.ErrMFAConflict.Has(err):
 		return http.StatusConflict
+	case console.ErrDataErasureJobNotFound.Has(err):
+		return http.StatusNotFound
 	case errors.Is(err, errNotImplemented):
 		return http.StatusNotImplemented
 	case console.ErrMFAPasscode.Has(err), console.ErrMFARecoveryCode.Has(err):
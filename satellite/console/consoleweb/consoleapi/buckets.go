@@ -6,10 +6,12 @@ package consoleapi
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/console"
 )
@@ -66,6 +68,113 @@ func (b *Buckets) AllBucketNames(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetVersioning returns whether versioning is enabled on a bucket.
+func (b *Buckets) GetVersioning(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, err := uuid.FromString(r.URL.Query().Get("projectID"))
+	if err != nil {
+		b.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if bucket == "" {
+		b.serveJSONError(w, http.StatusBadRequest, ErrBucketsAPI.New("bucket is required"))
+		return
+	}
+
+	enabled, err := b.service.GetBucketVersioning(ctx, projectID, bucket)
+	if err != nil {
+		b.serveServiceError(w, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(struct {
+		Enabled bool `json:"enabled"`
+	}{enabled})
+	if err != nil {
+		b.log.Error("failed to write json bucket versioning response", zap.Error(ErrBucketsAPI.Wrap(err)))
+	}
+}
+
+// SetVersioning enables or disables versioning on a bucket.
+func (b *Buckets) SetVersioning(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request struct {
+		ProjectID uuid.UUID `json:"projectID"`
+		Bucket    string    `json:"bucket"`
+		Enabled   bool      `json:"enabled"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		b.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	if request.Bucket == "" {
+		b.serveJSONError(w, http.StatusBadRequest, ErrBucketsAPI.New("bucket is required"))
+		return
+	}
+
+	err = b.service.SetBucketVersioning(ctx, request.ProjectID, request.Bucket, request.Enabled)
+	if err != nil {
+		b.serveServiceError(w, err)
+		return
+	}
+}
+
+// CreateShareLink creates a time-limited, read-only share link for an object.
+func (b *Buckets) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request struct {
+		ProjectID   uuid.UUID                `json:"projectID"`
+		Bucket      string                   `json:"bucket"`
+		Key         string                   `json:"key"`
+		ExpiresAt   time.Time                `json:"expiresAt"`
+		Permissions console.SharePermissions `json:"permissions"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		b.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	link, err := b.service.CreateShareLink(ctx, request.ProjectID, request.Bucket, request.Key, request.ExpiresAt, request.Permissions)
+	if err != nil {
+		b.serveServiceError(w, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(link)
+	if err != nil {
+		b.log.Error("failed to write json share link response", zap.Error(ErrBucketsAPI.Wrap(err)))
+	}
+}
+
+// serveServiceError writes the JSON error of a failed service call.
+func (b *Buckets) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+		b.serveJSONError(w, http.StatusUnauthorized, err)
+	case console.ErrValidation.Has(err):
+		b.serveJSONError(w, http.StatusBadRequest, err)
+	case storj.ErrBucketNotFound.Has(err):
+		b.serveJSONError(w, http.StatusNotFound, err)
+	default:
+		b.serveJSONError(w, http.StatusInternalServerError, err)
+	}
+}
+
 // serveJSONError writes JSON error to response output stream.
 func (b *Buckets) serveJSONError(w http.ResponseWriter, status int, err error) {
 	serveJSONError(b.log, w, status, err)
@@ -11,6 +11,8 @@ import (
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/console"
 )
 
 var (
@@ -25,6 +27,10 @@ func serveJSONError(log *zap.Logger, w http.ResponseWriter, status int, err erro
 
 // serveCustomJSONError writes a JSON error with a custom message to the response output stream.
 func serveCustomJSONError(log *zap.Logger, w http.ResponseWriter, status int, err error, msg string) {
+	if console.ErrRateLimited.Has(err) {
+		status = http.StatusTooManyRequests
+	}
+
 	fields := []zap.Field{
 		zap.Int("code", status),
 		zap.String("message", msg),
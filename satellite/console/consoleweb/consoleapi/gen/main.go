@@ -58,9 +58,13 @@ func main() {
 
 		g.Get("/", &apigen.Endpoint{
 			Name:        "Get Projects",
-			Description: "Gets all projects user has",
+			Description: "Gets a page of projects user has",
 			MethodName:  "GenGetUsersProjects",
-			Response:    []console.Project{},
+			Response:    &console.ProjectsPage{},
+			Params: []apigen.Param{
+				apigen.NewOptionalQueryParam("limit", uint(0)),
+				apigen.NewOptionalQueryParam("page", uint(0)),
+			},
 		})
 
 		g.Get("/bucket-rollup", &apigen.Endpoint{
@@ -69,10 +73,10 @@ func main() {
 			MethodName:  "GenGetSingleBucketUsageRollup",
 			Response:    &accounting.BucketUsageRollup{},
 			Params: []apigen.Param{
-				apigen.NewParam("projectID", uuid.UUID{}),
-				apigen.NewParam("bucket", ""),
-				apigen.NewParam("since", time.Time{}),
-				apigen.NewParam("before", time.Time{}),
+				apigen.NewQueryParam("projectID", uuid.UUID{}),
+				apigen.NewQueryParam("bucket", ""),
+				apigen.NewQueryParam("since", time.Time{}),
+				apigen.NewQueryParam("before", time.Time{}),
 			},
 		})
 
@@ -82,9 +86,23 @@ func main() {
 			MethodName:  "GenGetBucketUsageRollups",
 			Response:    []accounting.BucketUsageRollup{},
 			Params: []apigen.Param{
-				apigen.NewParam("projectID", uuid.UUID{}),
-				apigen.NewParam("since", time.Time{}),
-				apigen.NewParam("before", time.Time{}),
+				apigen.NewQueryParam("projectID", uuid.UUID{}),
+				apigen.NewQueryParam("since", time.Time{}),
+				apigen.NewQueryParam("before", time.Time{}),
+			},
+		})
+
+		g.Get("/bucket-usage-series", &apigen.Endpoint{
+			Name:        "Get Project's Single Bucket Usage Series",
+			Description: "Gets project's single bucket usage for every interval of the period, the interval is a duration, e.g. 1h or 24h",
+			MethodName:  "GenGetBucketUsageSeries",
+			Response:    []accounting.BucketUsageRollup{},
+			Params: []apigen.Param{
+				apigen.NewQueryParam("projectID", uuid.UUID{}),
+				apigen.NewQueryParam("bucket", ""),
+				apigen.NewQueryParam("since", time.Time{}),
+				apigen.NewQueryParam("before", time.Time{}),
+				apigen.NewQueryParam("interval", ""),
 			},
 		})
 	}
@@ -101,6 +119,17 @@ func main() {
 				apigen.NewParam("apikeyInfo", console.CreateAPIKeyRequest{}),
 			},
 		})
+
+		g.Patch("/rename/{id}", &apigen.Endpoint{
+			Name:        "Rename API key",
+			Description: "Renames API key with given id, the key's secret is left unchanged",
+			MethodName:  "GenRenameAPIKey",
+			Response:    &console.APIKeyInfo{},
+			Params: []apigen.Param{
+				apigen.NewParam("id", uuid.UUID{}),
+				apigen.NewParam("apikeyInfo", console.RenameAPIKeyRequest{}),
+			},
+		})
 	}
 
 	{
@@ -115,4 +144,6 @@ func main() {
 	}
 
 	a.MustWriteGo("satellite/console/consoleweb/consoleapi/api.gen.go")
+	a.MustWriteTS("web/satellite/src/api/v0.gen.ts")
+	a.MustWriteOpenAPI("satellite/console/consoleweb/consoleapi/openapi.gen.json")
 }
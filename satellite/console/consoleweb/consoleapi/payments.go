@@ -127,6 +127,45 @@ func (p *Payments) ProjectsCharges(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// EstimateStorageCost returns how much money in cents current user would be charged for storing
+// the amount of bytes for the duration given in query params.
+func (p *Payments) EstimateStorageCost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	bytes, err := strconv.ParseInt(r.URL.Query().Get("bytes"), 10, 64)
+	if err != nil {
+		p.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	duration, err := time.ParseDuration(r.URL.Query().Get("duration"))
+	if err != nil {
+		p.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cost, err := p.service.Payments().EstimateStorageCost(ctx, bytes, duration)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err):
+			p.serveJSONError(w, http.StatusUnauthorized, err)
+		case console.ErrValidation.Has(err):
+			p.serveJSONError(w, http.StatusBadRequest, err)
+		default:
+			p.serveJSONError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(cost)
+	if err != nil {
+		p.log.Error("failed to write json response", zap.Error(ErrPaymentsAPI.Wrap(err)))
+	}
+}
+
 // AddCreditCard is used to save new credit card and attach it to payment account.
 func (p *Payments) AddCreditCard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -341,7 +380,14 @@ func (p *Payments) ApplyCouponCode(w http.ResponseWriter, r *http.Request) {
 
 	coupon, err := p.service.Payments().ApplyCouponCode(ctx, couponCode)
 	if err != nil {
-		p.serveJSONError(w, http.StatusInternalServerError, err)
+		switch {
+		case console.ErrUnauthorized.Has(err):
+			p.serveJSONError(w, http.StatusUnauthorized, err)
+		case console.ErrValidation.Has(err):
+			p.serveJSONError(w, http.StatusBadRequest, err)
+		default:
+			p.serveJSONError(w, http.StatusInternalServerError, err)
+		}
 		return
 	}
 
@@ -374,6 +420,30 @@ func (p *Payments) GetCoupon(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetCreditBalance returns the remaining coupon and credit balance of the user's account.
+func (p *Payments) GetCreditBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	balance, err := p.service.Payments().GetCreditBalance(ctx)
+	if err != nil {
+		if console.ErrUnauthorized.Has(err) {
+			p.serveJSONError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		p.serveJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(balance); err != nil {
+		p.log.Error("failed to encode credit balance", zap.Error(ErrPaymentsAPI.Wrap(err)))
+	}
+}
+
 // GetWallet returns the wallet address (with balance) already assigned to the user.
 func (p *Payments) GetWallet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -0,0 +1,165 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+var (
+	// ErrProjectMembersAPI - console project members api error type.
+	ErrProjectMembersAPI = errs.Class("console project members")
+)
+
+// ProjectMembers is an api controller that exposes project member related functionality.
+type ProjectMembers struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewProjectMembers is a constructor for api project members controller.
+func NewProjectMembers(log *zap.Logger, service *console.Service) *ProjectMembers {
+	return &ProjectMembers{
+		log:     log,
+		service: service,
+	}
+}
+
+// AddMembers adds users by email to a project and returns the outcome for every email.
+func (pm *ProjectMembers) AddMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		pm.serveJSONError(w, http.StatusBadRequest, errs.New("invalid project id: %v", err))
+		return
+	}
+
+	var request struct {
+		Emails []string `json:"emails"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		pm.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	results, err := pm.service.AddProjectMembersBulk(ctx, projectID, request.Emails)
+	if err != nil {
+		pm.serveJSONError(w, pm.getStatusCode(err), err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(results)
+	if err != nil {
+		pm.log.Error("error encoding project members results", zap.Error(ErrProjectMembersAPI.Wrap(err)))
+	}
+}
+
+// memberRole is the json representation of a project member role.
+type memberRole struct {
+	Role string `json:"role"`
+}
+
+// GetMemberRole returns the role of a member within a project.
+func (pm *ProjectMembers) GetMemberRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, memberID, err := parseProjectMemberParams(r)
+	if err != nil {
+		pm.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	role, err := pm.service.GetMemberRole(ctx, projectID, memberID)
+	if err != nil {
+		pm.serveJSONError(w, pm.getStatusCode(err), err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(memberRole{Role: role.String()})
+	if err != nil {
+		pm.log.Error("error encoding project member role", zap.Error(ErrProjectMembersAPI.Wrap(err)))
+	}
+}
+
+// SetMemberRole changes the role of a member within a project.
+func (pm *ProjectMembers) SetMemberRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, memberID, err := parseProjectMemberParams(r)
+	if err != nil {
+		pm.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request memberRole
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		pm.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	role, err := console.ParseProjectMemberRole(request.Role)
+	if err != nil {
+		pm.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = pm.service.SetMemberRole(ctx, projectID, memberID, role)
+	if err != nil {
+		pm.serveJSONError(w, pm.getStatusCode(err), err)
+		return
+	}
+}
+
+// parseProjectMemberParams parses the project and member IDs from the route params.
+func parseProjectMemberParams(r *http.Request) (projectID, memberID uuid.UUID, err error) {
+	vars := mux.Vars(r)
+
+	projectID, err = uuid.FromString(vars["id"])
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, errs.New("invalid project id: %v", err)
+	}
+
+	memberID, err = uuid.FromString(vars["memberID"])
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, errs.New("invalid member id: %v", err)
+	}
+
+	return projectID, memberID, nil
+}
+
+// getStatusCode returns http.StatusCode depends on console error class.
+func (pm *ProjectMembers) getStatusCode(err error) int {
+	switch {
+	case console.ErrValidation.Has(err):
+		return http.StatusBadRequest
+	case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (pm *ProjectMembers) serveJSONError(w http.ResponseWriter, status int, err error) {
+	serveJSONError(pm.log, w, status, err)
+}
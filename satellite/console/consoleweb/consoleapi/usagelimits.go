@@ -13,6 +13,7 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/memory"
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/console"
@@ -103,6 +104,29 @@ func (ul *UsageLimits) TotalUsageLimits(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// ProjectCreationEligibility returns whether the user is able to create a new project.
+func (ul *UsageLimits) ProjectCreationEligibility(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	eligibility, err := ul.service.CanCreateProject(ctx)
+	if err != nil {
+		if console.ErrUnauthorized.Has(err) {
+			ul.serveJSONError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		ul.serveJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(eligibility)
+	if err != nil {
+		ul.log.Error("error encoding project creation eligibility", zap.Error(ErrUsageLimitsAPI.Wrap(err)))
+	}
+}
+
 // DailyUsage returns daily usage by project ID.
 func (ul *UsageLimits) DailyUsage(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -153,6 +177,114 @@ func (ul *UsageLimits) DailyUsage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// UsageCSV returns project usage over time as csv by project ID.
+func (ul *UsageLimits) UsageCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var ok bool
+	var idParam string
+
+	if idParam, ok = mux.Vars(r)["id"]; !ok {
+		ul.serveJSONError(w, http.StatusBadRequest, errs.New("missing project id route param"))
+		return
+	}
+	projectID, err := uuid.FromString(idParam)
+	if err != nil {
+		ul.serveJSONError(w, http.StatusBadRequest, errs.New("invalid project id: %v", err))
+		return
+	}
+
+	sinceStamp, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		ul.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	beforeStamp, err := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		ul.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	granularity := 24 * time.Hour
+	if param := r.URL.Query().Get("granularity"); param != "" {
+		granularity, err = time.ParseDuration(param)
+		if err != nil {
+			ul.serveJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	since := time.Unix(sinceStamp, 0)
+	before := time.Unix(beforeStamp, 0)
+
+	usageCSV, err := ul.service.ExportProjectUsageCSV(ctx, projectID, since, before, granularity)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+			ul.serveJSONError(w, http.StatusUnauthorized, err)
+		case console.ErrValidation.Has(err):
+			ul.serveJSONError(w, http.StatusBadRequest, err)
+		default:
+			ul.serveJSONError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"project-usage.csv\"")
+
+	if _, err = w.Write(usageCSV); err != nil {
+		ul.log.Error("error writing project usage csv", zap.Error(ErrUsageLimitsAPI.Wrap(err)))
+	}
+}
+
+// egressRateLimit is the json representation of a project egress rate limit.
+type egressRateLimit struct {
+	BytesPerSec memory.Size `json:"bytesPerSec"`
+}
+
+// SetEgressRateLimit sets the egress rate limit of the project.
+func (ul *UsageLimits) SetEgressRateLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var ok bool
+	var idParam string
+
+	if idParam, ok = mux.Vars(r)["id"]; !ok {
+		ul.serveJSONError(w, http.StatusBadRequest, errs.New("missing project id route param"))
+		return
+	}
+
+	projectID, err := uuid.FromString(idParam)
+	if err != nil {
+		ul.serveJSONError(w, http.StatusBadRequest, errs.New("invalid project id: %v", err))
+		return
+	}
+
+	var request egressRateLimit
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		ul.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = ul.service.SetProjectEgressRateLimit(ctx, projectID, request.BytesPerSec)
+	if err != nil {
+		switch {
+		case console.ErrValidation.Has(err):
+			ul.serveJSONError(w, http.StatusBadRequest, err)
+		case console.ErrUnauthorized.Has(err):
+			ul.serveJSONError(w, http.StatusUnauthorized, err)
+		default:
+			ul.serveJSONError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+}
+
 // serveJSONError writes JSON error to response output stream.
 func (ul *UsageLimits) serveJSONError(w http.ResponseWriter, status int, err error) {
 	serveJSONError(ul.log, w, status, err)
@@ -35,6 +35,19 @@ func (*AccountActivationEmail) Template() string { return "Welcome" }
 // Subject gets email subject.
 func (*AccountActivationEmail) Subject() string { return "Activate your email" }
 
+// EmailChangeVerificationEmail is mailservice template with data for confirming a new email.
+type EmailChangeVerificationEmail struct {
+	Origin           string
+	ConfirmationLink string
+	UserName         string
+}
+
+// Template returns email template name.
+func (*EmailChangeVerificationEmail) Template() string { return "ChangeEmail" }
+
+// Subject gets email subject.
+func (*EmailChangeVerificationEmail) Subject() string { return "Confirm your new email" }
+
 // ForgotPasswordEmail is mailservice template with reset password data.
 type ForgotPasswordEmail struct {
 	Origin                     string
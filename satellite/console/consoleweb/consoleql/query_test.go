@@ -98,6 +98,7 @@ func TestGraphqlQuery(t *testing.T) {
 				PasswordCost:        console.TestPasswordCost,
 				DefaultProjectLimit: 5,
 				SessionDuration:     time.Hour,
+				Argon2:              console.Argon2Config{Time: 1, Memory: console.TestArgon2Memory, Threads: 1, Concurrency: 1},
 			},
 		)
 		require.NoError(t, err)
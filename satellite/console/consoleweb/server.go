@@ -293,10 +293,36 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 		"/api/v0/projects/usage-limits",
 		server.withAuth(http.HandlerFunc(usageLimitsController.TotalUsageLimits)),
 	).Methods(http.MethodGet)
+	router.Handle(
+		"/api/v0/projects/creation-eligibility",
+		server.withAuth(http.HandlerFunc(usageLimitsController.ProjectCreationEligibility)),
+	).Methods(http.MethodGet)
 	router.Handle(
 		"/api/v0/projects/{id}/daily-usage",
 		server.withAuth(http.HandlerFunc(usageLimitsController.DailyUsage)),
 	).Methods(http.MethodGet)
+	router.Handle(
+		"/api/v0/projects/{id}/usage-csv",
+		server.withAuth(http.HandlerFunc(usageLimitsController.UsageCSV)),
+	).Methods(http.MethodGet)
+	router.Handle(
+		"/api/v0/projects/{id}/egress-rate-limit",
+		server.withAuth(http.HandlerFunc(usageLimitsController.SetEgressRateLimit)),
+	).Methods(http.MethodPut)
+
+	projectMembersController := consoleapi.NewProjectMembers(logger, service)
+	router.Handle(
+		"/api/v0/projects/{id}/members",
+		server.withAuth(http.HandlerFunc(projectMembersController.AddMembers)),
+	).Methods(http.MethodPost)
+	router.Handle(
+		"/api/v0/projects/{id}/members/{memberID}/role",
+		server.withAuth(http.HandlerFunc(projectMembersController.GetMemberRole)),
+	).Methods(http.MethodGet)
+	router.Handle(
+		"/api/v0/projects/{id}/members/{memberID}/role",
+		server.withAuth(http.HandlerFunc(projectMembersController.SetMemberRole)),
+	).Methods(http.MethodPut)
 
 	authController := consoleapi.NewAuth(logger, service, mailService, server.cookieAuth, partners, server.analytics, server.config.ExternalAddress, config.LetUsKnowURL, config.TermsAndConditionsURL, config.ContactInfoURL)
 	authRouter := router.PathPrefix("/api/v0/auth").Subrouter()
@@ -305,6 +331,10 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	authRouter.Handle("/account/change-email", server.withAuth(http.HandlerFunc(authController.ChangeEmail))).Methods(http.MethodPost)
 	authRouter.Handle("/account/change-password", server.withAuth(http.HandlerFunc(authController.ChangePassword))).Methods(http.MethodPost)
 	authRouter.Handle("/account/delete", server.withAuth(http.HandlerFunc(authController.DeleteAccount))).Methods(http.MethodPost)
+	authRouter.Handle("/account/security-events", server.withAuth(http.HandlerFunc(authController.GetSecurityEvents))).Methods(http.MethodGet)
+	authRouter.Handle("/account/security-events/review", server.withAuth(http.HandlerFunc(authController.MarkSecurityEventsReviewed))).Methods(http.MethodPost)
+	authRouter.Handle("/account/erasure", server.withAuth(http.HandlerFunc(authController.RequestDataErasure))).Methods(http.MethodPost)
+	authRouter.Handle("/account/erasure/{id}", server.withAuth(http.HandlerFunc(authController.GetDataErasureJob))).Methods(http.MethodGet)
 	authRouter.Handle("/mfa/enable", server.withAuth(http.HandlerFunc(authController.EnableUserMFA))).Methods(http.MethodPost)
 	authRouter.Handle("/mfa/disable", server.withAuth(http.HandlerFunc(authController.DisableUserMFA))).Methods(http.MethodPost)
 	authRouter.Handle("/mfa/generate-secret-key", server.withAuth(http.HandlerFunc(authController.GenerateMFASecretKey))).Methods(http.MethodPost)
@@ -324,7 +354,9 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	paymentsRouter.HandleFunc("/cards", paymentController.ListCreditCards).Methods(http.MethodGet)
 	paymentsRouter.HandleFunc("/cards/{cardId}", paymentController.RemoveCreditCard).Methods(http.MethodDelete)
 	paymentsRouter.HandleFunc("/account/charges", paymentController.ProjectsCharges).Methods(http.MethodGet)
+	paymentsRouter.HandleFunc("/account/storage-cost-estimate", paymentController.EstimateStorageCost).Methods(http.MethodGet)
 	paymentsRouter.HandleFunc("/account/balance", paymentController.AccountBalance).Methods(http.MethodGet)
+	paymentsRouter.HandleFunc("/account/credit-balance", paymentController.GetCreditBalance).Methods(http.MethodGet)
 	paymentsRouter.HandleFunc("/account", paymentController.SetupAccount).Methods(http.MethodPost)
 	paymentsRouter.HandleFunc("/wallet", paymentController.GetWallet).Methods(http.MethodGet)
 	paymentsRouter.HandleFunc("/wallet", paymentController.ClaimWallet).Methods(http.MethodPost)
@@ -338,11 +370,15 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	bucketsRouter := router.PathPrefix("/api/v0/buckets").Subrouter()
 	bucketsRouter.Use(server.withAuth)
 	bucketsRouter.HandleFunc("/bucket-names", bucketsController.AllBucketNames).Methods(http.MethodGet)
+	bucketsRouter.HandleFunc("/versioning", bucketsController.GetVersioning).Methods(http.MethodGet)
+	bucketsRouter.HandleFunc("/versioning", bucketsController.SetVersioning).Methods(http.MethodPatch)
+	bucketsRouter.HandleFunc("/share-link", bucketsController.CreateShareLink).Methods(http.MethodPost)
 
 	apiKeysController := consoleapi.NewAPIKeys(logger, service)
 	apiKeysRouter := router.PathPrefix("/api/v0/api-keys").Subrouter()
 	apiKeysRouter.Use(server.withAuth)
 	apiKeysRouter.HandleFunc("/delete-by-name", apiKeysController.DeleteByNameAndProjectID).Methods(http.MethodDelete)
+	apiKeysRouter.HandleFunc("/expiring", apiKeysController.ListExpiring).Methods(http.MethodGet)
 
 	analyticsController := consoleapi.NewAnalytics(logger, service, server.analytics)
 	analyticsRouter := router.PathPrefix("/api/v0/analytics").Subrouter()
@@ -364,6 +400,7 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 		router.PathPrefix("/static/").Handler(server.brotliMiddleware(http.StripPrefix("/static", fs)))
 
 		router.HandleFunc("/activation/", server.accountActivationHandler)
+		router.HandleFunc("/confirm-email-change/", server.emailChangeConfirmationHandler)
 		router.HandleFunc("/cancel-password-recovery/", server.cancelPasswordRecoveryHandler)
 		router.HandleFunc("/usage-report", server.bucketUsageReportHandler)
 		router.PathPrefix("/").Handler(http.HandlerFunc(server.appHandler))
@@ -713,6 +750,33 @@ func (server *Server) accountActivationHandler(w http.ResponseWriter, r *http.Re
 	http.Redirect(w, r, server.config.ExternalAddress, http.StatusTemporaryRedirect)
 }
 
+// emailChangeConfirmationHandler is web app http handler function for confirming a new email.
+func (server *Server) emailChangeConfirmationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	defer mon.Task()(&ctx)(nil)
+	token := r.URL.Query().Get("token")
+
+	_, err := server.service.ConfirmEmailChange(ctx, token)
+	if err != nil {
+		server.log.Error("email change: failed to confirm email change", zap.Error(err))
+
+		if console.ErrEmailUsed.Has(err) || console.ErrTokenExpiration.Has(err) {
+			http.Redirect(w, r, server.config.ExternalAddress+"login?emailChanged=false", http.StatusTemporaryRedirect)
+			return
+		}
+
+		if console.Error.Has(err) {
+			server.serveError(w, http.StatusInternalServerError)
+			return
+		}
+
+		server.serveError(w, http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, server.config.ExternalAddress, http.StatusTemporaryRedirect)
+}
+
 func (server *Server) cancelPasswordRecoveryHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	defer mon.Task()(&ctx)(nil)
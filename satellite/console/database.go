@@ -27,6 +27,10 @@ type DB interface {
 	ResetPasswordTokens() ResetPasswordTokens
 	// WebappSessions is a getter for WebappSessions repository.
 	WebappSessions() consoleauth.WebappSessions
+	// SecurityEvents is a getter for SecurityEvents repository.
+	SecurityEvents() SecurityEvents
+	// DataErasureJobs is a getter for DataErasureJobs repository.
+	DataErasureJobs() DataErasureJobs
 
 	// WithTx is a method for executing transactions with retrying as necessary.
 	WithTx(ctx context.Context, fn func(ctx context.Context, tx DBTx) error) error
@@ -0,0 +1,70 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+)
+
+// ErrDataErasureJobNotFound occurs when the user has no data erasure job with the requested ID.
+var ErrDataErasureJobNotFound = errs.Class("data erasure job not found")
+
+// DataErasureJobs exposes methods to manage data erasure jobs.
+//
+// architecture: Database
+type DataErasureJobs interface {
+	// Insert stores a new data erasure job. When the job is pending and the user
+	// already has a pending job, the existing job is returned instead.
+	Insert(ctx context.Context, job DataErasureJob) (*DataErasureJob, error)
+	// Get returns the data erasure job with the given ID.
+	Get(ctx context.Context, id uuid.UUID) (*DataErasureJob, error)
+	// ListPending returns up to limit pending jobs, oldest first.
+	ListPending(ctx context.Context, limit int) ([]DataErasureJob, error)
+	// AddDeletedObjects adds count to the number of objects deleted by the job.
+	AddDeletedObjects(ctx context.Context, id uuid.UUID, count int64) error
+	// Complete marks the job as completed.
+	Complete(ctx context.Context, id uuid.UUID, completedAt time.Time) error
+}
+
+// DataErasureJobStatus is the status of a data erasure job.
+type DataErasureJobStatus int
+
+const (
+	// DataErasurePending means that the user's data has not been erased yet.
+	DataErasurePending DataErasureJobStatus = 0
+	// DataErasureCompleted means that all of the user's objects have been deleted.
+	DataErasureCompleted DataErasureJobStatus = 1
+)
+
+// String returns a string representation of the status.
+func (status DataErasureJobStatus) String() string {
+	switch status {
+	case DataErasurePending:
+		return "pending"
+	case DataErasureCompleted:
+		return "completed"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (status DataErasureJobStatus) MarshalText() ([]byte, error) {
+	return []byte(status.String()), nil
+}
+
+// DataErasureJob is a user's request to erase all objects in the projects they own.
+type DataErasureJob struct {
+	ID             uuid.UUID            `json:"id"`
+	UserID         uuid.UUID            `json:"-"`
+	Status         DataErasureJobStatus `json:"status"`
+	DeletedObjects int64                `json:"deletedObjects"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	CompletedAt    *time.Time           `json:"completedAt"`
+}
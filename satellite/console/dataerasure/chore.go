@@ -0,0 +1,183 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package dataerasure
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	// Error defines the dataerasure chore errors class.
+	Error = errs.Class("data erasure chore")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for the data erasure chore.
+type Config struct {
+	Interval  time.Duration `help:"how often to process pending data erasure jobs" releaseDefault:"1h" devDefault:"10s"`
+	Enabled   bool          `help:"set if data erasure jobs are processed or not" default:"true"`
+	ListLimit int           `help:"how many pending jobs to process in a batch" default:"10"`
+}
+
+// Chore deletes all objects of users who requested data erasure.
+//
+// architecture: Chore
+type Chore struct {
+	log       *zap.Logger
+	config    Config
+	consoleDB console.DB
+	buckets   buckets.DB
+	metabase  *metabase.DB
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore creates a new instance of the dataerasure chore.
+func NewChore(log *zap.Logger, config Config, consoleDB console.DB, buckets buckets.DB, metabase *metabase.DB) *Chore {
+	return &Chore{
+		log:       log,
+		config:    config,
+		consoleDB: consoleDB,
+		buckets:   buckets,
+		metabase:  metabase,
+
+		nowFn: time.Now,
+		Loop:  sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the dataerasure chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !chore.config.Enabled {
+		return nil
+	}
+
+	return chore.Loop.Run(ctx, chore.processPendingJobs)
+}
+
+// Close stops the dataerasure chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+func (chore *Chore) processPendingJobs(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	jobs, err := chore.consoleDB.DataErasureJobs().ListPending(ctx, chore.config.ListLimit)
+	if err != nil {
+		chore.log.Error("unable to list pending data erasure jobs", zap.Error(err))
+		return nil
+	}
+
+	for _, job := range jobs {
+		deleted, nonEmpty, err := chore.eraseUserData(ctx, job.UserID)
+
+		// objects deleted by a failed attempt are counted as well, since
+		// they won't be found again when the job is retried.
+		if deleted > 0 {
+			if err := chore.consoleDB.DataErasureJobs().AddDeletedObjects(ctx, job.ID, deleted); err != nil {
+				chore.log.Error("unable to update deleted objects of data erasure job", zap.Stringer("Job ID", job.ID), zap.Error(err))
+			}
+		}
+
+		// in both cases the job stays pending and is retried on the next iteration.
+		if err != nil {
+			chore.log.Error("unable to erase user data",
+				zap.Stringer("Job ID", job.ID),
+				zap.Stringer("User ID", job.UserID),
+				zap.Error(err))
+			continue
+		}
+		if nonEmpty > 0 {
+			chore.log.Warn("objects remain after data erasure",
+				zap.Stringer("Job ID", job.ID),
+				zap.Stringer("User ID", job.UserID),
+				zap.Int("Non-empty Buckets", nonEmpty))
+			continue
+		}
+
+		err = chore.consoleDB.DataErasureJobs().Complete(ctx, job.ID, chore.nowFn())
+		if err != nil {
+			chore.log.Error("unable to complete data erasure job", zap.Stringer("Job ID", job.ID), zap.Error(err))
+			continue
+		}
+		mon.Counter("data_erasure_jobs_completed").Inc(1)
+
+		chore.log.Info("data erasure completed",
+			zap.Stringer("Job ID", job.ID),
+			zap.Stringer("User ID", job.UserID),
+			zap.Int64("Deleted Objects", job.DeletedObjects+deleted))
+	}
+
+	return nil
+}
+
+// eraseUserData deletes the objects of all buckets in the projects owned by the user.
+// It returns the number of deleted objects and the number of buckets which still
+// contain objects afterwards, e.g. because they were uploaded concurrently.
+func (chore *Chore) eraseUserData(ctx context.Context, userID uuid.UUID) (deleted int64, nonEmpty int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	projects, err := chore.consoleDB.Projects().GetOwn(ctx, userID)
+	if err != nil {
+		return 0, 0, Error.Wrap(err)
+	}
+
+	for _, project := range projects {
+		listOpts := storj.BucketListOptions{Direction: storj.Forward}
+		for {
+			list, err := chore.buckets.ListBuckets(ctx, project.ID, listOpts, macaroon.AllowedBuckets{All: true})
+			if err != nil {
+				return deleted, nonEmpty, Error.Wrap(err)
+			}
+
+			for _, bucket := range list.Items {
+				location := metabase.BucketLocation{ProjectID: project.ID, BucketName: bucket.Name}
+
+				count, err := chore.metabase.DeleteBucketObjects(ctx, metabase.DeleteBucketObjects{
+					Bucket: location,
+				})
+				deleted += count
+				if err != nil {
+					return deleted, nonEmpty, Error.Wrap(err)
+				}
+
+				empty, err := chore.metabase.BucketEmpty(ctx, metabase.BucketEmpty{
+					ProjectID:  location.ProjectID,
+					BucketName: location.BucketName,
+				})
+				if err != nil {
+					return deleted, nonEmpty, Error.Wrap(err)
+				}
+				if !empty {
+					nonEmpty++
+				}
+			}
+
+			if !list.More || len(list.Items) == 0 {
+				break
+			}
+			listOpts = listOpts.NextPage(list)
+		}
+	}
+
+	return deleted, nonEmpty, nil
+}
@@ -107,6 +107,8 @@ func (s *Service) EnableUserMFA(ctx context.Context, passcode string, t time.Tim
 		return Error.Wrap(err)
 	}
 
+	s.recordSecurityEvent(ctx, user.ID, SecurityEventMFAEnabled)
+
 	return nil
 }
 
@@ -165,6 +167,8 @@ func (s *Service) DisableUserMFA(ctx context.Context, passcode string, t time.Ti
 		return Error.Wrap(err)
 	}
 
+	s.recordSecurityEvent(ctx, user.ID, SecurityEventMFADisabled)
+
 	return nil
 }
 
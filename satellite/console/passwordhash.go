@@ -0,0 +1,217 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"storj.io/common/memory"
+)
+
+// Password hashing algorithms.
+const (
+	PasswordHashBcrypt   = "bcrypt"
+	PasswordHashArgon2id = "argon2id"
+)
+
+const (
+	argon2idPrefix  = "$argon2id$"
+	argon2SaltSize  = 16
+	argon2KeyLength = 32
+)
+
+// ErrPasswordHash describes password hashing errors.
+var ErrPasswordHash = errs.Class("password hash")
+
+// Argon2Config contains the parameters for argon2id password hashing.
+type Argon2Config struct {
+	Time        uint        `help:"number of passes over the memory when hashing passwords with argon2id" default:"1"`
+	Memory      memory.Size `help:"amount of memory used when hashing passwords with argon2id" default:"64MiB" testDefault:"1MiB"`
+	Threads     uint        `help:"number of threads used when hashing passwords with argon2id" default:"4"`
+	Concurrency int         `help:"maximum number of argon2id hashes computed at the same time, bounding the memory used to concurrency*memory" default:"4"`
+}
+
+// Validate checks that the argon2id parameters are usable.
+func (config Argon2Config) Validate() error {
+	switch {
+	case config.Time < 1 || config.Time > math.MaxUint32:
+		return ErrPasswordHash.New("argon2id time must be between 1 and %d, got %d", uint32(math.MaxUint32), config.Time)
+	case config.Threads < 1 || config.Threads > math.MaxUint8:
+		return ErrPasswordHash.New("argon2id threads must be between 1 and %d, got %d", math.MaxUint8, config.Threads)
+	case config.Memory < 8*memory.KiB*memory.Size(config.Threads) || config.Memory/memory.KiB > math.MaxUint32:
+		return ErrPasswordHash.New("argon2id memory must be at least 8 KiB per thread, got %s", config.Memory)
+	case config.Concurrency < 1:
+		return ErrPasswordHash.New("argon2id concurrency must be positive, got %d", config.Concurrency)
+	}
+	return nil
+}
+
+// PasswordHasher hashes passwords using the configured algorithm.
+type PasswordHasher struct {
+	algorithm  string
+	bcryptCost int
+	argon2     argon2Params
+
+	// argon2Limit bounds the number of concurrent argon2id computations,
+	// each of them allocates the configured amount of memory.
+	argon2Limit chan struct{}
+}
+
+// NewPasswordHasher returns a password hasher for the algorithm. A bcryptCost
+// of 0 uses the default bcrypt cost.
+func NewPasswordHasher(algorithm string, bcryptCost int, argon2Config Argon2Config) (*PasswordHasher, error) {
+	switch algorithm {
+	case PasswordHashBcrypt, PasswordHashArgon2id, "":
+	default:
+		return nil, ErrPasswordHash.New("unknown password hash algorithm %q", algorithm)
+	}
+	if bcryptCost == 0 {
+		bcryptCost = bcrypt.DefaultCost
+	}
+	if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+		return nil, ErrPasswordHash.New("bcrypt cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, bcryptCost)
+	}
+	// argon2id hashes are verified even when new passwords are hashed with
+	// bcrypt, so the parameters are always validated.
+	if err := argon2Config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &PasswordHasher{
+		algorithm:  algorithm,
+		bcryptCost: bcryptCost,
+		argon2: argon2Params{
+			time:    uint32(argon2Config.Time),
+			memory:  uint32(argon2Config.Memory / memory.KiB),
+			threads: uint8(argon2Config.Threads),
+		},
+		argon2Limit: make(chan struct{}, argon2Config.Concurrency),
+	}, nil
+}
+
+// Hash returns the hash of the password. The hash is tagged with the
+// algorithm and its parameters, so it can be verified after the
+// configuration changes.
+func (hasher *PasswordHasher) Hash(ctx context.Context, password []byte) (_ []byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	switch hasher.algorithm {
+	case PasswordHashBcrypt, "":
+		hash, err := bcrypt.GenerateFromPassword(password, hasher.bcryptCost)
+		return hash, ErrPasswordHash.Wrap(err)
+	case PasswordHashArgon2id:
+		salt := make([]byte, argon2SaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, ErrPasswordHash.Wrap(err)
+		}
+
+		params := hasher.argon2
+		key, err := hasher.argon2IDKey(ctx, password, salt, params, argon2KeyLength)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2idPrefix, argon2.Version,
+			params.memory, params.time, params.threads,
+			base64.RawStdEncoding.EncodeToString(salt),
+			base64.RawStdEncoding.EncodeToString(key),
+		)), nil
+	default:
+		return nil, ErrPasswordHash.New("unknown password hash algorithm %q", hasher.algorithm)
+	}
+}
+
+// Compare compares the password with the hash. The algorithm is determined
+// from the hash, hashes without an algorithm tag are bcrypt hashes.
+func (hasher *PasswordHasher) Compare(ctx context.Context, hash, password []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !bytes.HasPrefix(hash, []byte(argon2idPrefix)) {
+		return bcrypt.CompareHashAndPassword(hash, password)
+	}
+
+	params, salt, key, err := parseArgon2idHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	other, err := hasher.argon2IDKey(ctx, password, salt, params, uint32(len(key)))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrPasswordHash.New("password does not match")
+	}
+	return nil
+}
+
+// argon2IDKey derives the argon2id key, waiting until fewer than the
+// configured number of keys are being derived.
+func (hasher *PasswordHasher) argon2IDKey(ctx context.Context, password, salt []byte, params argon2Params, keyLength uint32) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, ErrPasswordHash.Wrap(err)
+	}
+	select {
+	case hasher.argon2Limit <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ErrPasswordHash.Wrap(ctx.Err())
+	}
+	defer func() { <-hasher.argon2Limit }()
+
+	return argon2.IDKey(password, salt, params.time, params.memory, params.threads, keyLength), nil
+}
+
+// argon2Params are the parameters of an argon2id hash.
+type argon2Params struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+// parseArgon2idHash parses a hash in the format
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>.
+func parseArgon2idHash(hash string) (params argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(hash, argon2idPrefix), "$")
+	if len(parts) != 4 {
+		return params, nil, nil, ErrPasswordHash.New("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil {
+		return params, nil, nil, ErrPasswordHash.Wrap(err)
+	}
+	if version != argon2.Version {
+		return params, nil, nil, ErrPasswordHash.New("unsupported argon2 version %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return params, nil, nil, ErrPasswordHash.Wrap(err)
+	}
+	if params.time < 1 || params.threads < 1 {
+		return params, nil, nil, ErrPasswordHash.New("invalid argon2id parameters")
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return params, nil, nil, ErrPasswordHash.Wrap(err)
+	}
+	key, err = base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return params, nil, nil, ErrPasswordHash.Wrap(err)
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/console"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	password := []byte("password123")
+	argon2Config := console.Argon2Config{Time: 1, Memory: console.TestArgon2Memory, Threads: 1, Concurrency: 1}
+
+	for _, algorithm := range []string{console.PasswordHashBcrypt, console.PasswordHashArgon2id} {
+		t.Run(algorithm, func(t *testing.T) {
+			hasher, err := console.NewPasswordHasher(algorithm, console.TestPasswordCost, argon2Config)
+			require.NoError(t, err)
+
+			hash, err := hasher.Hash(ctx, password)
+			require.NoError(t, err)
+			require.NotEqual(t, password, hash)
+
+			require.NoError(t, hasher.Compare(ctx, hash, password))
+			require.Error(t, hasher.Compare(ctx, hash, []byte("wrong password")))
+
+			// hashes are salted
+			other, err := hasher.Hash(ctx, password)
+			require.NoError(t, err)
+			require.NotEqual(t, hash, other)
+		})
+	}
+
+	t.Run("tagged argon2id hash", func(t *testing.T) {
+		hasher, err := console.NewPasswordHasher(console.PasswordHashArgon2id, console.TestPasswordCost,
+			console.Argon2Config{Time: 2, Memory: console.TestArgon2Memory, Threads: 2, Concurrency: 1})
+		require.NoError(t, err)
+		hash, err := hasher.Hash(ctx, password)
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(hash, []byte("$argon2id$v=19$m=1024,t=2,p=2$")), string(hash))
+
+		// the parameters are stored with the hash, so changing them doesn't affect verification
+		newHasher, err := console.NewPasswordHasher(console.PasswordHashArgon2id, console.TestPasswordCost,
+			console.Argon2Config{Time: 1, Memory: 2 * console.TestArgon2Memory, Threads: 1, Concurrency: 1})
+		require.NoError(t, err)
+		newHash, err := newHasher.Hash(ctx, password)
+		require.NoError(t, err)
+		require.NoError(t, newHasher.Compare(ctx, hash, password))
+		require.NoError(t, hasher.Compare(ctx, newHash, password))
+
+		require.Error(t, hasher.Compare(ctx, []byte("$argon2id$invalid"), password))
+		require.Error(t, hasher.Compare(ctx, []byte("$argon2id$v=19$m=1024,t=0,p=0$c2FsdA$a2V5"), password))
+	})
+
+	t.Run("legacy bcrypt hash", func(t *testing.T) {
+		hasher, err := console.NewPasswordHasher(console.PasswordHashArgon2id, console.TestPasswordCost, argon2Config)
+		require.NoError(t, err)
+
+		hash, err := bcrypt.GenerateFromPassword(password, bcrypt.MinCost)
+		require.NoError(t, err)
+
+		require.NoError(t, hasher.Compare(ctx, hash, password))
+		require.Error(t, hasher.Compare(ctx, hash, []byte("wrong password")))
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		hasher, err := console.NewPasswordHasher(console.PasswordHashArgon2id, console.TestPasswordCost, argon2Config)
+		require.NoError(t, err)
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = hasher.Hash(canceled, password)
+		require.True(t, console.ErrPasswordHash.Has(err))
+	})
+}
+
+func TestNewPasswordHasherValidation(t *testing.T) {
+	valid := console.Argon2Config{Time: 1, Memory: console.TestArgon2Memory, Threads: 1, Concurrency: 1}
+
+	for _, tt := range []struct {
+		name      string
+		algorithm string
+		cost      int
+		mutate    func(*console.Argon2Config)
+	}{
+		{name: "unknown algorithm", algorithm: "md5"},
+		{name: "bcrypt cost too high", algorithm: console.PasswordHashBcrypt, cost: bcrypt.MaxCost + 1},
+		{name: "zero time", mutate: func(c *console.Argon2Config) { c.Time = 0 }},
+		{name: "zero threads", mutate: func(c *console.Argon2Config) { c.Threads = 0 }},
+		{name: "too many threads", mutate: func(c *console.Argon2Config) { c.Threads = 256 }},
+		{name: "too little memory", mutate: func(c *console.Argon2Config) { c.Memory = memory.KiB }},
+		{name: "zero concurrency", mutate: func(c *console.Argon2Config) { c.Concurrency = 0 }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			if tt.mutate != nil {
+				tt.mutate(&config)
+			}
+			algorithm := tt.algorithm
+			if algorithm == "" {
+				algorithm = console.PasswordHashArgon2id
+			}
+			_, err := console.NewPasswordHasher(algorithm, tt.cost, config)
+			require.True(t, console.ErrPasswordHash.Has(err), err)
+		})
+	}
+
+	_, err := console.NewPasswordHasher(console.PasswordHashArgon2id, 0, valid)
+	require.NoError(t, err)
+}
@@ -5,6 +5,8 @@ package console
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"storj.io/common/uuid"
@@ -20,8 +22,56 @@ type ProjectMembers interface {
 	GetPagedByProjectID(ctx context.Context, projectID uuid.UUID, cursor ProjectMembersCursor) (*ProjectMembersPage, error)
 	// Insert is a method for inserting project member into the database.
 	Insert(ctx context.Context, memberID, projectID uuid.UUID) (*ProjectMember, error)
+	// InsertMany is a method for inserting multiple project members into the database.
+	// It returns the IDs of the users which weren't members of the project already.
+	InsertMany(ctx context.Context, memberIDs []uuid.UUID, projectID uuid.UUID) ([]uuid.UUID, error)
 	// Delete is a method for deleting project member by memberID and projectID from the database.
 	Delete(ctx context.Context, memberID, projectID uuid.UUID) error
+	// GetRole is a method for querying the role of a project member. Members without an explicitly
+	// assigned role have the RoleMember role.
+	GetRole(ctx context.Context, memberID, projectID uuid.UUID) (ProjectMemberRole, error)
+	// SetRole is a method for assigning a role to a project member.
+	SetRole(ctx context.Context, memberID, projectID uuid.UUID, role ProjectMemberRole) error
+}
+
+// ProjectMemberRole is the role of a member within a project.
+type ProjectMemberRole int
+
+const (
+	// RoleMember is the default role of project members.
+	RoleMember ProjectMemberRole = 0
+	// RoleAdmin is the role of members who are allowed to manage other members.
+	RoleAdmin ProjectMemberRole = 1
+	// RoleReadOnly is the role of members who are not allowed to modify the project.
+	RoleReadOnly ProjectMemberRole = 2
+	// RoleOwner is the role of the project owner. It is derived from the project and can't be assigned.
+	RoleOwner ProjectMemberRole = 3
+)
+
+// String returns the string representation of the role.
+func (role ProjectMemberRole) String() string {
+	switch role {
+	case RoleMember:
+		return "member"
+	case RoleAdmin:
+		return "admin"
+	case RoleReadOnly:
+		return "read-only"
+	case RoleOwner:
+		return "owner"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(role))
+	}
+}
+
+// ParseProjectMemberRole parses the string representation of a role.
+func ParseProjectMemberRole(s string) (ProjectMemberRole, error) {
+	for _, role := range []ProjectMemberRole{RoleMember, RoleAdmin, RoleReadOnly, RoleOwner} {
+		if role.String() == s {
+			return role, nil
+		}
+	}
+	return 0, ErrValidation.New("unknown project member role %q", s)
 }
 
 // ProjectMember is a database object that describes ProjectMember entity.
@@ -69,3 +119,39 @@ const (
 	// Created indicates that we should order by created date.
 	Created ProjectMemberOrder = 3
 )
+
+// AddProjectMemberStatus is the outcome of adding a single user to a project.
+type AddProjectMemberStatus int
+
+const (
+	// AddProjectMemberSubmitted indicates that the user with the email, if there
+	// is one, was added to the project. It's also the status of emails which
+	// don't belong to any user, so that the result doesn't reveal which emails
+	// have an account.
+	AddProjectMemberSubmitted AddProjectMemberStatus = 0
+	// AddProjectMemberAlreadyMember indicates that the user already was a member of the project.
+	AddProjectMemberAlreadyMember AddProjectMemberStatus = 1
+)
+
+// String returns the string representation of the status.
+func (status AddProjectMemberStatus) String() string {
+	switch status {
+	case AddProjectMemberSubmitted:
+		return "submitted"
+	case AddProjectMemberAlreadyMember:
+		return "already-member"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(status))
+	}
+}
+
+// MarshalJSON marshals the status as its string representation.
+func (status AddProjectMemberStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(status.String())
+}
+
+// AddProjectMemberResult is the result of adding the user with the email to a project.
+type AddProjectMemberResult struct {
+	Email  string                 `json:"email"`
+	Status AddProjectMemberStatus `json:"status"`
+}
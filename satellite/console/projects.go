@@ -36,6 +36,8 @@ type Projects interface {
 	List(ctx context.Context, offset int64, limit int, before time.Time) (ProjectsPage, error)
 	// ListByOwnerID is a method for querying all projects from the database by ownerID. It also includes the number of members for each project.
 	ListByOwnerID(ctx context.Context, userID uuid.UUID, cursor ProjectsCursor) (ProjectsPage, error)
+	// ListByUserID is a method for querying a page of projects where user is a project member.
+	ListByUserID(ctx context.Context, userID uuid.UUID, cursor ProjectsCursor) (ProjectsPage, error)
 
 	// UpdateRateLimit is a method for updating projects rate limit.
 	UpdateRateLimit(ctx context.Context, id uuid.UUID, newLimit int) error
@@ -43,6 +45,11 @@ type Projects interface {
 	// UpdateBurstLimit is a method for updating projects burst limit.
 	UpdateBurstLimit(ctx context.Context, id uuid.UUID, newLimit int) error
 
+	// UpdateEgressRateLimit is a method for updating projects egress rate limit in bytes per second.
+	UpdateEgressRateLimit(ctx context.Context, id uuid.UUID, newLimit memory.Size) error
+	// UpdateAdminEgressRateLimit is a method for updating projects egress rate limit set by an admin in bytes per second.
+	UpdateAdminEgressRateLimit(ctx context.Context, id uuid.UUID, newLimit memory.Size) error
+
 	// GetMaxBuckets is a method to get the maximum number of buckets allowed for the project
 	GetMaxBuckets(ctx context.Context, id uuid.UUID) (*int, error)
 	// UpdateBucketLimit is a method for updating projects bucket limit.
@@ -85,19 +92,21 @@ type ProjectLimitConfig struct {
 type Project struct {
 	ID uuid.UUID `json:"id"`
 
-	Name           string       `json:"name"`
-	Description    string       `json:"description"`
-	PartnerID      uuid.UUID    `json:"partnerId"`
-	UserAgent      []byte       `json:"userAgent"`
-	OwnerID        uuid.UUID    `json:"ownerId"`
-	RateLimit      *int         `json:"rateLimit"`
-	BurstLimit     *int         `json:"burstLimit"`
-	MaxBuckets     *int         `json:"maxBuckets"`
-	CreatedAt      time.Time    `json:"createdAt"`
-	MemberCount    int          `json:"memberCount"`
-	StorageLimit   *memory.Size `json:"storageLimit"`
-	BandwidthLimit *memory.Size `json:"bandwidthLimit"`
-	SegmentLimit   *int64       `json:"segmentLimit"`
+	Name                 string       `json:"name"`
+	Description          string       `json:"description"`
+	PartnerID            uuid.UUID    `json:"partnerId"`
+	UserAgent            []byte       `json:"userAgent"`
+	OwnerID              uuid.UUID    `json:"ownerId"`
+	RateLimit            *int         `json:"rateLimit"`
+	BurstLimit           *int         `json:"burstLimit"`
+	MaxBuckets           *int         `json:"maxBuckets"`
+	CreatedAt            time.Time    `json:"createdAt"`
+	MemberCount          int          `json:"memberCount"`
+	StorageLimit         *memory.Size `json:"storageLimit"`
+	BandwidthLimit       *memory.Size `json:"bandwidthLimit"`
+	SegmentLimit         *int64       `json:"segmentLimit"`
+	EgressRateLimit      *memory.Size `json:"egressRateLimit"`
+	AdminEgressRateLimit *memory.Size `json:"adminEgressRateLimit"`
 }
 
 // ProjectInfo holds data needed to create/update Project.
@@ -109,6 +118,11 @@ type ProjectInfo struct {
 	CreatedAt      time.Time   `json:"createdAt"`
 }
 
+// Validate validates name and description of the project.
+func (info ProjectInfo) Validate() error {
+	return ValidateNameAndDescription(info.Name, info.Description)
+}
+
 // ProjectsCursor holds info for project
 // cursor pagination.
 type ProjectsCursor struct {
@@ -120,16 +134,16 @@ type ProjectsCursor struct {
 // providing next offset if there are more projects
 // to retrieve.
 type ProjectsPage struct {
-	Projects   []Project
-	Next       bool
-	NextOffset int64
+	Projects   []Project `json:"projects"`
+	Next       bool      `json:"next"`
+	NextOffset int64     `json:"nextOffset"`
 
-	Limit  int
-	Offset int64
+	Limit  int   `json:"limit"`
+	Offset int64 `json:"offset"`
 
-	PageCount   int
-	CurrentPage int
-	TotalCount  int64
+	PageCount   int   `json:"pageCount"`
+	CurrentPage int   `json:"currentPage"`
+	TotalCount  int64 `json:"totalCount"`
 }
 
 // ValidateNameAndDescription validates project name and description strings.
@@ -0,0 +1,77 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+)
+
+// SecurityEvents exposes methods to manage account security events.
+//
+// architecture: Database
+type SecurityEvents interface {
+	// Insert stores a new security event.
+	Insert(ctx context.Context, event SecurityEvent) (*SecurityEvent, error)
+	// GetLatest returns the user's newest security event of the given type.
+	GetLatest(ctx context.Context, userID uuid.UUID, eventType SecurityEventType) (*SecurityEvent, error)
+	// ListByUserID returns a page of the user's security events, newest first.
+	ListByUserID(ctx context.Context, userID uuid.UUID, cursor SecurityEventsCursor) (SecurityEventsPage, error)
+	// MarkReviewed marks the user's security events with the given IDs as reviewed.
+	MarkReviewed(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, reviewedAt time.Time) (int64, error)
+}
+
+// SecurityEventType describes the kind of a security event.
+type SecurityEventType string
+
+const (
+	// SecurityEventLogin is recorded when a user logs in.
+	SecurityEventLogin SecurityEventType = "login"
+	// SecurityEventLockout is recorded when a user is locked out after too many failed logins.
+	SecurityEventLockout SecurityEventType = "lockout"
+	// SecurityEventPasswordChange is recorded when a user changes or resets their password.
+	SecurityEventPasswordChange SecurityEventType = "password-change"
+	// SecurityEventEmailChangeRequest is recorded when a user requests to change their email.
+	SecurityEventEmailChangeRequest SecurityEventType = "email-change-request"
+	// SecurityEventEmailChange is recorded when a user changes their email.
+	SecurityEventEmailChange SecurityEventType = "email-change"
+	// SecurityEventMFAEnabled is recorded when a user enables MFA.
+	SecurityEventMFAEnabled SecurityEventType = "mfa-enabled"
+	// SecurityEventMFADisabled is recorded when a user disables MFA.
+	SecurityEventMFADisabled SecurityEventType = "mfa-disabled"
+)
+
+// SecurityEvent is a security-relevant event of a user's account.
+type SecurityEvent struct {
+	ID         uuid.UUID         `json:"id"`
+	UserID     uuid.UUID         `json:"-"`
+	Type       SecurityEventType `json:"type"`
+	IPAddress  string            `json:"ipAddress"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	ReviewedAt *time.Time        `json:"reviewedAt"`
+}
+
+// SecurityEventsCursor holds info for security events cursor pagination.
+type SecurityEventsCursor struct {
+	Limit int
+	Page  int
+}
+
+// SecurityEventsPage returns paginated security events,
+// providing next offset if there are more events
+// to retrieve.
+type SecurityEventsPage struct {
+	Events     []SecurityEvent `json:"events"`
+	Next       bool            `json:"next"`
+	NextOffset int64           `json:"nextOffset"`
+
+	Limit  int   `json:"limit"`
+	Offset int64 `json:"offset"`
+
+	PageCount   int   `json:"pageCount"`
+	CurrentPage int   `json:"currentPage"`
+	TotalCount  int64 `json:"totalCount"`
+}
@@ -5,14 +5,18 @@ package console
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"net/http"
 	"net/mail"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/spf13/pflag"
 	"github.com/stripe/stripe-go/v72"
@@ -41,8 +45,15 @@ const (
 	// maxLimit specifies the limit for all paged queries.
 	maxLimit = 50
 
+	// maxBulkProjectMembers is the maximum number of emails which can be
+	// added to a project at once.
+	maxBulkProjectMembers = 100
+
 	// TestPasswordCost is the hashing complexity to use for testing.
 	TestPasswordCost = bcrypt.MinCost
+
+	// TestArgon2Memory is the argon2id hashing memory to use for testing.
+	TestArgon2Memory = memory.MiB
 )
 
 // Error messages.
@@ -60,9 +71,11 @@ const (
 	apiKeyWithNameDoesntExistErrMsg      = "An API Key with this name doesn't exist in this project."
 	teamMemberDoesNotExistErrMsg         = `There is no account on this Satellite for the user(s) you have entered.
 									     Please add team members with active accounts`
-	activationTokenExpiredErrMsg = "This activation token has expired, please request another one"
-	usedRegTokenErrMsg           = "This registration token has already been used"
-	projLimitErrMsg              = "Sorry, project creation is limited for your account. Please contact support!"
+	activationTokenExpiredErrMsg  = "This activation token has expired, please request another one"
+	emailChangeTokenExpiredErrMsg = "This email verification link has expired, please change your email again"
+	usedRegTokenErrMsg            = "This registration token has already been used"
+	projLimitErrMsg               = "Sorry, project creation is limited for your account. Please contact support!"
+	projNameUsedErrMsg            = "A project with this name already exists, please use a different name"
 )
 
 var (
@@ -78,9 +91,15 @@ var (
 	// ErrTokenExpiration is error type of token reached expiration time.
 	ErrTokenExpiration = errs.Class("token expiration")
 
+	// ErrTokenInvalid occurs when a token can't be used for the requested operation.
+	ErrTokenInvalid = errs.Class("invalid token")
+
 	// ErrProjLimit is error type of project limit.
 	ErrProjLimit = errs.Class("project limit")
 
+	// ErrProjName is error type of project name already used by the owner.
+	ErrProjName = errs.Class("project name")
+
 	// ErrUsage is error type of project usage.
 	ErrUsage = errs.Class("project usage")
 
@@ -102,6 +121,9 @@ var (
 	// ErrNoAPIKey is error type that occurs when there is no api key found.
 	ErrNoAPIKey = errs.Class("no api key found")
 
+	// ErrAPIKeyNameExists occurs when an api key with the same name already exists in the project.
+	ErrAPIKeyNameExists = errs.Class("api key name exists")
+
 	// ErrRegToken describes registration token errors.
 	ErrRegToken = errs.Class("registration token")
 
@@ -110,6 +132,9 @@ var (
 
 	// ErrRecoveryToken describes account recovery token errors.
 	ErrRecoveryToken = errs.Class("recovery token")
+
+	// ErrRateLimited occurs when a user makes too many requests.
+	ErrRateLimited = errs.Class("rate limited")
 )
 
 // Service is handling accounts related logic.
@@ -128,6 +153,8 @@ type Service struct {
 	captchaHandler    CaptchaHandler
 	analytics         *analytics.Service
 	tokens            *consoleauth.Service
+	userLimiter       *userRateLimiter
+	passwords         *PasswordHasher
 
 	config Config
 }
@@ -135,25 +162,35 @@ type Service struct {
 func init() {
 	var c Config
 	cfgstruct.Bind(pflag.NewFlagSet("", pflag.PanicOnError), &c, cfgstruct.UseTestDefaults())
-	if c.PasswordCost != TestPasswordCost {
+	if c.PasswordCost != TestPasswordCost || c.Argon2.Memory != TestArgon2Memory {
 		panic("invalid test constant defined in struct tag")
 	}
 	cfgstruct.Bind(pflag.NewFlagSet("", pflag.PanicOnError), &c, cfgstruct.UseReleaseDefaults())
 	if c.PasswordCost != 0 {
 		panic("invalid release constant defined in struct tag. should be 0 (=automatic)")
 	}
+	if c.Argon2.Memory == TestArgon2Memory {
+		panic("invalid release constant defined in struct tag. should not be the test argon2 memory")
+	}
 }
 
 // Config keeps track of core console service configuration parameters.
 type Config struct {
 	PasswordCost                int           `help:"password hashing cost (0=automatic)" testDefault:"4" default:"0"`
+	PasswordHashAlgorithm       string        `help:"algorithm used for hashing new passwords (bcrypt|argon2id)" default:"bcrypt"`
 	OpenRegistrationEnabled     bool          `help:"enable open registration" default:"false" testDefault:"true"`
 	DefaultProjectLimit         int           `help:"default project limits for users" default:"1" testDefault:"5"`
 	AsOfSystemTimeDuration      time.Duration `help:"default duration for AS OF SYSTEM TIME" devDefault:"-5m" releaseDefault:"-5m" testDefault:"0"`
 	LoginAttemptsWithoutPenalty int           `help:"number of times user can try to login without penalty" default:"3"`
 	FailedLoginPenalty          float64       `help:"incremental duration of penalty for failed login attempts in minutes" default:"2.0"`
 	SessionDuration             time.Duration `help:"duration a session is valid for" default:"168h"`
+	SessionInactivityTimeout    time.Duration `help:"duration of inactivity after which a session expires, regardless of the session duration (0=disabled)" default:"0s"`
+	LogoutOnPasswordChange      bool          `help:"whether to revoke all sessions of a user except the current one when the user changes their password" default:"false"`
+	UniqueProjectNames          bool          `help:"require names of projects owned by a user to be unique, ignoring case" default:"false"`
+	UserRequestsPerMinute       int           `help:"number of audited requests a user can make per minute (0=unlimited)" default:"0"`
+	Argon2                      Argon2Config
 	UsageLimits                 UsageLimitsConfig
+	ShareLinks                  ShareLinkConfig
 	Recaptcha                   RecaptchaConfig
 	Hcaptcha                    HcaptchaConfig
 }
@@ -185,8 +222,9 @@ func NewService(log *zap.Logger, store DB, restKeys RESTKeys, projectAccounting
 	if log == nil {
 		return nil, errs.New("log can't be nil")
 	}
-	if config.PasswordCost == 0 {
-		config.PasswordCost = bcrypt.DefaultCost
+	passwords, err := NewPasswordHasher(config.PasswordHashAlgorithm, config.PasswordCost, config.Argon2)
+	if err != nil {
+		return nil, err
 	}
 
 	var captchaHandler CaptchaHandler
@@ -196,6 +234,11 @@ func NewService(log *zap.Logger, store DB, restKeys RESTKeys, projectAccounting
 		captchaHandler = NewDefaultCaptcha(Hcaptcha, config.Hcaptcha.SecretKey)
 	}
 
+	var userLimiter *userRateLimiter
+	if config.UserRequestsPerMinute > 0 {
+		userLimiter = newUserRateLimiter(config.UserRequestsPerMinute)
+	}
+
 	return &Service{
 		log:               log,
 		auditLogger:       log.Named("auditlog"),
@@ -210,6 +253,8 @@ func NewService(log *zap.Logger, store DB, restKeys RESTKeys, projectAccounting
 		captchaHandler:    captchaHandler,
 		analytics:         analytics,
 		tokens:            tokens,
+		userLimiter:       userLimiter,
+		passwords:         passwords,
 		config:            config,
 	}, nil
 }
@@ -239,6 +284,23 @@ func (s *Service) auditLog(ctx context.Context, operation string, userID *uuid.U
 	s.auditLogger.Info("console activity", fields...)
 }
 
+// recordSecurityEvent persists a security-relevant event of the user's account.
+// Failures are only logged, so that they don't prevent the operation itself.
+func (s *Service) recordSecurityEvent(ctx context.Context, userID uuid.UUID, eventType SecurityEventType) {
+	sourceIP, _ := getRequestingIP(ctx)
+	_, err := s.store.SecurityEvents().Insert(ctx, SecurityEvent{
+		UserID:    userID,
+		Type:      eventType,
+		IPAddress: sourceIP,
+	})
+	if err != nil {
+		s.log.Error("failed to record security event",
+			zap.Stringer("userID", userID),
+			zap.String("type", string(eventType)),
+			zap.Error(err))
+	}
+}
+
 func (s *Service) getUserAndAuditLog(ctx context.Context, operation string, extra ...zap.Field) (*User, error) {
 	user, err := GetUser(ctx)
 	if err != nil {
@@ -254,9 +316,21 @@ func (s *Service) getUserAndAuditLog(ctx context.Context, operation string, extr
 		return nil, err
 	}
 	s.auditLog(ctx, operation, &user.ID, user.Email, extra...)
+	if !s.allowUserRequest(user.ID) {
+		return nil, ErrRateLimited.New("too many requests")
+	}
 	return user, nil
 }
 
+// allowUserRequest reports whether the user is allowed to make another request.
+// Each user has a token bucket refilled with config.UserRequestsPerMinute tokens per minute.
+func (s *Service) allowUserRequest(userID uuid.UUID) bool {
+	if s.userLimiter == nil {
+		return true
+	}
+	return s.userLimiter.Allow(userID)
+}
+
 // Payments separates all payment related functionality.
 func (s *Service) Payments() Payments {
 	return Payments{service: s}
@@ -364,6 +438,31 @@ func (payment Payments) ProjectsCharges(ctx context.Context, since, before time.
 	return payment.service.accounts.ProjectCharges(ctx, user.ID, since, before)
 }
 
+// EstimateStorageCost returns how much money in cents the current user would be charged
+// for storing the specified amount of bytes for the duration.
+func (payment Payments) EstimateStorageCost(ctx context.Context, bytes int64, duration time.Duration) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := payment.service.getUserAndAuditLog(ctx, "estimate storage cost")
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	if bytes < 0 {
+		return 0, ErrValidation.New("amount of bytes can't be negative")
+	}
+	if duration <= 0 {
+		return 0, ErrValidation.New("duration must be positive")
+	}
+
+	cost, err := payment.service.accounts.EstimateStorageCost(ctx, user.ID, bytes, duration)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	return cost, nil
+}
+
 // ListCreditCards returns a list of credit cards for a given payment account.
 func (payment Payments) ListCreditCards(ctx context.Context) (_ []payments.CreditCard, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -570,13 +669,16 @@ func (payment Payments) checkProjectUsageStatus(ctx context.Context, projectID u
 func (payment Payments) ApplyCouponCode(ctx context.Context, couponCode string) (coupon *payments.Coupon, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := payment.service.getUserAndAuditLog(ctx, "apply coupon code")
+	user, err := payment.service.getUserAndAuditLog(ctx, "apply coupon code", zap.String("coupon code", couponCode))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
 	coupon, err = payment.service.accounts.Coupons().ApplyCouponCode(ctx, user.ID, couponCode)
 	if err != nil {
+		if payments.ErrInvalidCouponCode.Has(err) || payments.ErrCouponCodeAlreadyApplied.Has(err) {
+			return nil, ErrValidation.Wrap(err)
+		}
 		return nil, Error.Wrap(err)
 	}
 
@@ -600,6 +702,53 @@ func (payment Payments) GetCoupon(ctx context.Context) (coupon *payments.Coupon,
 	return coupon, nil
 }
 
+// CreditBalance contains the remaining coupon and credit balance of the user's account.
+type CreditBalance struct {
+	// Credits is the credit balance of the account in cents.
+	Credits int64 `json:"credits"`
+	// CouponAmountOff is the amount in cents taken off by the coupon applied to the account.
+	CouponAmountOff int64 `json:"couponAmountOff"`
+	// CouponPercentOff is the percentage taken off by the coupon applied to the account.
+	CouponPercentOff float64 `json:"couponPercentOff"`
+	// ExpiresAt is when the coupon expires, nil when there's no coupon or it doesn't expire.
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// GetCreditBalance returns the remaining coupon and credit balance of the user's account.
+func (payment Payments) GetCreditBalance(ctx context.Context) (_ CreditBalance, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := payment.service.getUserAndAuditLog(ctx, "get credit balance")
+	if err != nil {
+		return CreditBalance{}, Error.Wrap(err)
+	}
+
+	balance, err := payment.service.accounts.Balance(ctx, user.ID)
+	if err != nil {
+		return CreditBalance{}, Error.Wrap(err)
+	}
+
+	coupon, err := payment.service.accounts.Coupons().GetByUserID(ctx, user.ID)
+	if err != nil {
+		return CreditBalance{}, Error.Wrap(err)
+	}
+
+	creditBalance := CreditBalance{
+		Credits: balance.FreeCredits + balance.Coins,
+	}
+	if coupon != nil {
+		creditBalance.CouponAmountOff = coupon.AmountOff
+		creditBalance.CouponPercentOff = coupon.PercentOff
+		// a coupon without an end is converted to the unix epoch.
+		if coupon.ExpiresAt.Unix() > 0 {
+			expiresAt := coupon.ExpiresAt
+			creditBalance.ExpiresAt = &expiresAt
+		}
+	}
+
+	return creditBalance, nil
+}
+
 // checkRegistrationSecret returns a RegistrationToken if applicable (nil if not), and an error
 // if and only if the registration shouldn't proceed.
 func (s *Service) checkRegistrationSecret(ctx context.Context, tokenSecret RegistrationSecret) (*RegistrationToken, error) {
@@ -663,7 +812,7 @@ func (s *Service) CreateUser(ctx context.Context, user CreateUser, tokenSecret R
 		return nil, ErrEmailUsed.New(emailUsedErrMsg)
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), s.config.PasswordCost)
+	hash, err := s.passwords.Hash(ctx, []byte(user.Password))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -788,6 +937,7 @@ func (s *Service) GenerateSessionToken(ctx context.Context, userID uuid.UUID, em
 	token.Signature = signature
 
 	s.auditLog(ctx, "login", &userID, email)
+	s.recordSecurityEvent(ctx, userID, SecurityEventLogin)
 
 	s.analytics.TrackSignedIn(userID, email)
 
@@ -798,22 +948,12 @@ func (s *Service) GenerateSessionToken(ctx context.Context, userID uuid.UUID, em
 func (s *Service) ActivateAccount(ctx context.Context, activationToken string) (user *User, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	parsedActivationToken, err := consoleauth.FromBase64URLString(activationToken)
-	if err != nil {
-		return nil, Error.Wrap(err)
-	}
-
-	valid, err := s.tokens.ValidateToken(parsedActivationToken)
+	claims, err := s.parseSignedToken(activationToken)
 	if err != nil {
-		return nil, Error.Wrap(err)
-	}
-	if !valid {
-		return nil, Error.New("incorrect signature")
+		return nil, err
 	}
-
-	claims, err := consoleauth.FromJSON(parsedActivationToken.Payload)
-	if err != nil {
-		return nil, Error.Wrap(err)
+	if claims.Purpose != consoleauth.PurposeActivation {
+		return nil, ErrTokenInvalid.New("not an activation token")
 	}
 
 	if time.Now().After(claims.Expiration) {
@@ -844,6 +984,29 @@ func (s *Service) ActivateAccount(ctx context.Context, activationToken string) (
 	return user, nil
 }
 
+// parseSignedToken parses the token and returns its claims if the token's signature is valid.
+func (s *Service) parseSignedToken(token string) (*consoleauth.Claims, error) {
+	parsedToken, err := consoleauth.FromBase64URLString(token)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	valid, err := s.tokens.ValidateToken(parsedToken)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !valid {
+		return nil, Error.New("incorrect signature")
+	}
+
+	claims, err := consoleauth.FromJSON(parsedToken.Payload)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return claims, nil
+}
+
 // ResetPassword - is a method for resetting user password.
 func (s *Service) ResetPassword(ctx context.Context, resetPasswordToken, password string, passcode string, recoveryCode string, t time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -895,7 +1058,7 @@ func (s *Service) ResetPassword(ctx context.Context, resetPasswordToken, passwor
 		return ErrRecoveryToken.Wrap(ErrTokenExpiration.New(passwordRecoveryTokenIsExpiredErrMsg))
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.config.PasswordCost)
+	hash, err := s.passwords.Hash(ctx, []byte(password))
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -917,6 +1080,7 @@ func (s *Service) ResetPassword(ctx context.Context, resetPasswordToken, passwor
 	}
 
 	s.auditLog(ctx, "password reset", &user.ID, user.Email)
+	s.recordSecurityEvent(ctx, user.ID, SecurityEventPasswordChange)
 
 	if err = s.store.ResetPasswordTokens().Delete(ctx, token.Secret); err != nil {
 		return Error.Wrap(err)
@@ -972,6 +1136,7 @@ func (s *Service) Token(ctx context.Context, request AuthUser) (token consoleaut
 
 		if user.FailedLoginCount == s.config.LoginAttemptsWithoutPenalty {
 			mon.Counter("login_lockout_initiated").Inc(1) //mon:locked
+			s.recordSecurityEvent(ctx, user.ID, SecurityEventLockout)
 			return ErrLockedAccount.New(lockedAccountErrMsg)
 		}
 
@@ -983,8 +1148,12 @@ func (s *Service) Token(ctx context.Context, request AuthUser) (token consoleaut
 		return nil
 	}
 
-	err = bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(request.Password))
+	err = s.passwords.Compare(ctx, user.PasswordHash, []byte(request.Password))
 	if err != nil {
+		// a canceled request is not a failed login attempt.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return consoleauth.Token{}, Error.Wrap(ctxErr)
+		}
 		err = handleLockAccount()
 		if err != nil {
 			return consoleauth.Token{}, err
@@ -1188,34 +1357,108 @@ func (s *Service) UpdateAccount(ctx context.Context, fullName string, shortName
 	return nil
 }
 
-// ChangeEmail updates email for a given user.
-func (s *Service) ChangeEmail(ctx context.Context, newEmail string) (err error) {
+// ChangeEmail starts changing the email of a given user. It returns a token,
+// which has to be sent to the new email, the email is only changed once the
+// token is confirmed with ConfirmEmailChange.
+func (s *Service) ChangeEmail(ctx context.Context, newEmail string) (token string, err error) {
 	defer mon.Task()(&ctx)(&err)
-	user, err := s.getUserAndAuditLog(ctx, "change email")
+	user, err := s.getUserAndAuditLog(ctx, "change email", zap.String("newEmail", newEmail))
 	if err != nil {
-		return Error.Wrap(err)
+		return "", Error.Wrap(err)
 	}
 
 	if _, err := mail.ParseAddress(newEmail); err != nil {
-		return ErrValidation.Wrap(err)
+		return "", ErrValidation.Wrap(err)
 	}
 
-	verified, unverified, err := s.store.Users().GetByEmailWithUnverified(ctx, newEmail)
+	if err := s.checkEmailUnused(ctx, newEmail); err != nil {
+		return "", err
+	}
+
+	// the token is tied to the latest request, so that issuing a new token
+	// invalidates all the earlier ones.
+	sourceIP, _ := getRequestingIP(ctx)
+	request, err := s.store.SecurityEvents().Insert(ctx, SecurityEvent{
+		UserID:    user.ID,
+		Type:      SecurityEventEmailChangeRequest,
+		IPAddress: sourceIP,
+	})
 	if err != nil {
-		return Error.Wrap(err)
+		return "", Error.Wrap(err)
 	}
-	if verified != nil || len(unverified) != 0 {
-		return ErrEmailUsed.New(emailUsedErrMsg)
+
+	token, err = s.tokens.CreateEmailChangeToken(ctx, user.ID, newEmail, request.ID.String())
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	return token, nil
+}
+
+// ConfirmEmailChange changes the email of the user to the email the token was
+// created for by ChangeEmail.
+func (s *Service) ConfirmEmailChange(ctx context.Context, token string) (user *User, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	claims, err := s.parseSignedToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != consoleauth.PurposeEmailChange {
+		return nil, ErrTokenInvalid.New("not an email change token")
+	}
+
+	if time.Now().After(claims.Expiration) {
+		return nil, ErrTokenExpiration.New(emailChangeTokenExpiredErrMsg)
+	}
+
+	request, err := s.store.SecurityEvents().GetLatest(ctx, claims.ID, SecurityEventEmailChangeRequest)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTokenInvalid.New("no email change was requested")
+		}
+		return nil, Error.Wrap(err)
+	}
+	if request.ID.String() != claims.Nonce {
+		return nil, ErrTokenInvalid.New("email change token was superseded by a newer one")
+	}
+
+	if err := s.checkEmailUnused(ctx, claims.Email); err != nil {
+		return nil, err
 	}
 
-	user.Email = newEmail
+	user, err = s.store.Users().Get(ctx, claims.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	oldEmail := user.Email
+	user.Email = claims.Email
 	err = s.store.Users().Update(ctx, user.ID, UpdateUserRequest{
 		Email: &user.Email,
 	})
 	if err != nil {
-		return Error.Wrap(err)
+		return nil, Error.Wrap(err)
 	}
 
+	s.auditLog(ctx, "confirm email change", &user.ID, user.Email, zap.String("oldEmail", oldEmail))
+	s.recordSecurityEvent(ctx, user.ID, SecurityEventEmailChange)
+
+	return user, nil
+}
+
+// checkEmailUnused returns ErrEmailUsed when there is a verified or
+// unverified user with the email.
+func (s *Service) checkEmailUnused(ctx context.Context, email string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	verified, unverified, err := s.store.Users().GetByEmailWithUnverified(ctx, email)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if verified != nil || len(unverified) != 0 {
+		return ErrEmailUsed.New(emailUsedErrMsg)
+	}
 	return nil
 }
 
@@ -1227,7 +1470,7 @@ func (s *Service) ChangePassword(ctx context.Context, pass, newPass string) (err
 		return Error.Wrap(err)
 	}
 
-	err = bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(pass))
+	err = s.passwords.Compare(ctx, user.PasswordHash, []byte(pass))
 	if err != nil {
 		return ErrUnauthorized.New(credentialsErrMsg)
 	}
@@ -1236,7 +1479,7 @@ func (s *Service) ChangePassword(ctx context.Context, pass, newPass string) (err
 		return ErrValidation.Wrap(err)
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(newPass), s.config.PasswordCost)
+	hash, err := s.passwords.Hash(ctx, []byte(newPass))
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -1249,6 +1492,42 @@ func (s *Service) ChangePassword(ctx context.Context, pass, newPass string) (err
 		return Error.Wrap(err)
 	}
 
+	s.recordSecurityEvent(ctx, user.ID, SecurityEventPasswordChange)
+
+	if s.config.LogoutOnPasswordChange {
+		if err := s.revokeOtherSessions(ctx, user.ID); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// revokeOtherSessions deletes all webapp sessions of the user except the one
+// the request was authenticated with.
+func (s *Service) revokeOtherSessions(ctx context.Context, userID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	currentID, hasCurrent := consoleauth.GetSessionID(ctx)
+	if !hasCurrent {
+		_, err = s.store.WebappSessions().DeleteAllByUserID(ctx, userID)
+		return err
+	}
+
+	sessions, err := s.store.WebappSessions().GetAllByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.ID == currentID {
+			continue
+		}
+		if err := s.store.WebappSessions().DeleteBySessionID(ctx, session.ID); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1260,7 +1539,7 @@ func (s *Service) DeleteAccount(ctx context.Context, password string) (err error
 		return Error.Wrap(err)
 	}
 
-	err = bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password))
+	err = s.passwords.Compare(ctx, user.PasswordHash, []byte(password))
 	if err != nil {
 		return ErrUnauthorized.New(credentialsErrMsg)
 	}
@@ -1278,6 +1557,53 @@ func (s *Service) DeleteAccount(ctx context.Context, password string) (err error
 	return nil
 }
 
+// RequestDataErasure schedules the deletion of all objects in the projects
+// owned by the user. The returned job can be used to check whether the
+// erasure has completed. While a job of the user is pending, it is returned
+// instead of scheduling another one.
+func (s *Service) RequestDataErasure(ctx context.Context) (_ *DataErasureJob, err error) {
+	defer mon.Task()(&ctx)(&err)
+	user, err := s.getUserAndAuditLog(ctx, "request data erasure")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	job, err := s.store.DataErasureJobs().Insert(ctx, DataErasureJob{
+		UserID: user.ID,
+		Status: DataErasurePending,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	s.auditLog(ctx, "data erasure scheduled", &user.ID, user.Email, zap.String("jobID", job.ID.String()))
+
+	return job, nil
+}
+
+// GetDataErasureJob returns the user's data erasure job with the given ID.
+func (s *Service) GetDataErasureJob(ctx context.Context, id uuid.UUID) (_ *DataErasureJob, err error) {
+	defer mon.Task()(&ctx)(&err)
+	user, err := s.getUserAndAuditLog(ctx, "get data erasure job", zap.String("jobID", id.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	job, err := s.store.DataErasureJobs().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDataErasureJobNotFound.New("%s", id)
+		}
+		return nil, Error.Wrap(err)
+	}
+	// jobs of other users are reported as missing, so that their IDs can't be probed.
+	if job.UserID != user.ID {
+		return nil, ErrDataErasureJobNotFound.New("%s", id)
+	}
+
+	return job, nil
+}
+
 // GetProject is a method for querying project by id.
 func (s *Service) GetProject(ctx context.Context, projectID uuid.UUID) (p *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1314,8 +1640,9 @@ func (s *Service) GetUsersProjects(ctx context.Context) (ps []Project, err error
 	return
 }
 
-// GenGetUsersProjects is a method for querying all projects for generated api.
-func (s *Service) GenGetUsersProjects(ctx context.Context) (ps []Project, httpErr api.HTTPError) {
+// GenGetUsersProjects is a method for querying a page of projects for generated api.
+// Limit defaults to the maximum page size and page defaults to the first page when they are zero.
+func (s *Service) GenGetUsersProjects(ctx context.Context, limit, page uint) (p *ProjectsPage, httpErr api.HTTPError) {
 	var err error
 	defer mon.Task()(&ctx)(&err)
 
@@ -1327,7 +1654,18 @@ func (s *Service) GenGetUsersProjects(ctx context.Context) (ps []Project, httpEr
 		}
 	}
 
-	ps, err = s.store.Projects().GetByUserID(ctx, user.ID)
+	cursor := ProjectsCursor{
+		Limit: int(limit),
+		Page:  int(page),
+	}
+	if cursor.Limit == 0 || cursor.Limit > maxLimit {
+		cursor.Limit = maxLimit
+	}
+	if cursor.Page == 0 {
+		cursor.Page = 1
+	}
+
+	projects, err := s.store.Projects().ListByUserID(ctx, user.ID, cursor)
 	if err != nil {
 		return nil, api.HTTPError{
 			Status: http.StatusInternalServerError,
@@ -1335,7 +1673,7 @@ func (s *Service) GenGetUsersProjects(ctx context.Context) (ps []Project, httpEr
 		}
 	}
 
-	return
+	return &projects, api.HTTPError{}
 }
 
 // GetUsersOwnedProjectsPage is a method for querying paged projects.
@@ -1354,6 +1692,56 @@ func (s *Service) GetUsersOwnedProjectsPage(ctx context.Context, cursor Projects
 	return projects, nil
 }
 
+// ProjectCreationReason describes why a user is not able to create a new project.
+type ProjectCreationReason string
+
+const (
+	// ProjectCreationAtLimit indicates that the user has reached his project limit.
+	ProjectCreationAtLimit ProjectCreationReason = "at-limit"
+	// ProjectCreationUnverifiedEmail indicates that the user has not verified his email.
+	ProjectCreationUnverifiedEmail ProjectCreationReason = "unverified-email"
+	// ProjectCreationAccountSuspended indicates that the user's account is suspended or locked.
+	ProjectCreationAccountSuspended ProjectCreationReason = "account-suspended"
+)
+
+// ProjectCreationEligibility holds whether a user is able to create a new project and why not.
+type ProjectCreationEligibility struct {
+	Eligible bool                  `json:"eligible"`
+	Reason   ProjectCreationReason `json:"reason,omitempty"`
+}
+
+// CanCreateProject returns whether the authorized user is able to create a new project.
+func (s *Service) CanCreateProject(ctx context.Context) (eligibility ProjectCreationEligibility, err error) {
+	defer mon.Task()(&ctx)(&err)
+	user, err := s.getUserAndAuditLog(ctx, "can create project")
+	if err != nil {
+		return ProjectCreationEligibility{}, Error.Wrap(err)
+	}
+
+	// the user in the context may be stale, so use the current status.
+	user, err = s.store.Users().Get(ctx, user.ID)
+	if err != nil {
+		return ProjectCreationEligibility{}, Error.Wrap(err)
+	}
+
+	switch {
+	case user.Status == Inactive:
+		return ProjectCreationEligibility{Reason: ProjectCreationUnverifiedEmail}, nil
+	case user.Status == Suspended, user.LoginLockoutExpiration.After(time.Now()):
+		return ProjectCreationEligibility{Reason: ProjectCreationAccountSuspended}, nil
+	}
+
+	_, _, err = s.checkProjectLimit(ctx, user.ID)
+	if err != nil {
+		if ErrProjLimit.Has(err) {
+			return ProjectCreationEligibility{Reason: ProjectCreationAtLimit}, nil
+		}
+		return ProjectCreationEligibility{}, Error.Wrap(err)
+	}
+
+	return ProjectCreationEligibility{Eligible: true}, nil
+}
+
 // CreateProject is a method for creating new project.
 func (s *Service) CreateProject(ctx context.Context, projectInfo ProjectInfo) (p *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1362,12 +1750,17 @@ func (s *Service) CreateProject(ctx context.Context, projectInfo ProjectInfo) (p
 		return nil, Error.Wrap(err)
 	}
 
-	currentProjectCount, err := s.checkProjectLimit(ctx, user.ID)
+	currentProjectCount, limit, err := s.checkProjectLimit(ctx, user.ID)
 	if err != nil {
-		s.analytics.TrackProjectLimitError(user.ID, user.Email)
+		s.analytics.TrackProjectLimitError(user.ID, user.Email, limit)
 		return nil, ErrProjLimit.Wrap(err)
 	}
 
+	err = s.checkProjectName(ctx, user.ID, uuid.UUID{}, projectInfo.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	newProjectLimits, err := s.getUserProjectLimits(ctx, user.ID)
 	if err != nil {
 		return nil, ErrProjLimit.Wrap(err)
@@ -1423,14 +1816,22 @@ func (s *Service) GenCreateProject(ctx context.Context, projectInfo ProjectInfo)
 		}
 	}
 
-	currentProjectCount, err := s.checkProjectLimit(ctx, user.ID)
+	currentProjectCount, limit, err := s.checkProjectLimit(ctx, user.ID)
 	if err != nil {
+		if ErrProjLimit.Has(err) {
+			s.analytics.TrackProjectLimitError(user.ID, user.Email, limit)
+		}
 		return nil, api.HTTPError{
 			Status: http.StatusInternalServerError,
 			Err:    ErrProjLimit.Wrap(err),
 		}
 	}
 
+	err = s.checkProjectName(ctx, user.ID, uuid.UUID{}, projectInfo.Name)
+	if err != nil {
+		return nil, projectNameHTTPError(err)
+	}
+
 	newProjectLimits, err := s.getUserProjectLimits(ctx, user.ID)
 	if err != nil {
 		return nil, api.HTTPError{
@@ -1564,7 +1965,16 @@ func (s *Service) UpdateProject(ctx context.Context, projectID uuid.UUID, projec
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return nil, err
+	}
 	project := isMember.project
+
+	err = s.checkProjectName(ctx, project.OwnerID, project.ID, projectInfo.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	project.Name = projectInfo.Name
 	project.Description = projectInfo.Description
 
@@ -1617,6 +2027,35 @@ func (s *Service) UpdateProject(ctx context.Context, projectID uuid.UUID, projec
 	return project, nil
 }
 
+// SetProjectEgressRateLimit sets the maximum egress rate of the project in bytes per second.
+// Downloads exceeding the rate are rejected. Zero removes the limit.
+// Only the project owner is allowed to set the limit. A limit set by an admin through
+// the admin API is stored separately and still applies when it's lower.
+func (s *Service) SetProjectEgressRateLimit(ctx context.Context, projectID uuid.UUID, bytesPerSec memory.Size) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "set project egress rate limit",
+		zap.String("projectID", projectID.String()), zap.Int64("bytesPerSec", bytesPerSec.Int64()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if bytesPerSec < 0 {
+		return ErrValidation.New("egress rate limit can't be negative")
+	}
+
+	if _, err = s.isProjectOwner(ctx, user.ID, projectID); err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = s.store.Projects().UpdateEgressRateLimit(ctx, projectID, bytesPerSec)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
 // GenUpdateProject is a method for updating project name and description by id for generated api.
 func (s *Service) GenUpdateProject(ctx context.Context, projectID uuid.UUID, projectInfo ProjectInfo) (p *Project, httpError api.HTTPError) {
 	var err error
@@ -1645,9 +2084,21 @@ func (s *Service) GenUpdateProject(ctx context.Context, projectID uuid.UUID, pro
 			Err:    Error.Wrap(err),
 		}
 	}
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return nil, api.HTTPError{
+			Status: http.StatusUnauthorized,
+			Err:    err,
+		}
+	}
 	project := isMember.project
-	project.Name = projectInfo.Name
-	project.Description = projectInfo.Description
+
+	err = s.checkProjectName(ctx, project.OwnerID, project.ID, projectInfo.Name)
+	if err != nil {
+		return nil, projectNameHTTPError(err)
+	}
+
+	project.Name = projectInfo.Name
+	project.Description = projectInfo.Description
 
 	if user.PaidTier {
 		if project.BandwidthLimit != nil && *project.BandwidthLimit == 0 {
@@ -1736,10 +2187,15 @@ func (s *Service) AddProjectMembers(ctx context.Context, projectID uuid.UUID, em
 		return nil, Error.Wrap(err)
 	}
 
-	if _, err = s.isProjectMember(ctx, user.ID, projectID); err != nil {
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return nil, err
+	}
+
 	var userErr errs.Group
 
 	// collect user querying errors
@@ -1773,6 +2229,71 @@ func (s *Service) AddProjectMembers(ctx context.Context, projectID uuid.UUID, em
 	return users, nil
 }
 
+// AddProjectMembersBulk adds users by email to given project. Unlike AddProjectMembers
+// it doesn't fail when some of the emails don't belong to a user or the user is
+// already a member, instead it returns the outcome for every email. Emails which
+// don't belong to a user have the same outcome as those of added users.
+func (s *Service) AddProjectMembersBulk(ctx context.Context, projectID uuid.UUID, emails []string) (results []AddProjectMemberResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+	user, err := s.getUserAndAuditLog(ctx, "add project members bulk", zap.String("projectID", projectID.String()), zap.Strings("emails", emails))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if len(emails) > maxBulkProjectMembers {
+		return nil, ErrValidation.New("at most %d members can be added at once", maxBulkProjectMembers)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.store.Users().GetIDsByEmails(ctx, emails)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	memberIDs := make([]uuid.UUID, 0, len(userIDs))
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	for _, id := range userIDs {
+		if !seen[id] {
+			seen[id] = true
+			memberIDs = append(memberIDs, id)
+		}
+	}
+
+	inserted, err := s.store.ProjectMembers().InsertMany(ctx, memberIDs, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	added := make(map[uuid.UUID]bool, len(inserted))
+	for _, id := range inserted {
+		added[id] = true
+	}
+
+	results = make([]AddProjectMemberResult, len(emails))
+	for i, email := range emails {
+		results[i].Email = email
+
+		id, ok := userIDs[email]
+		if ok && !added[id] {
+			results[i].Status = AddProjectMemberAlreadyMember
+			continue
+		}
+		results[i].Status = AddProjectMemberSubmitted
+		// duplicates within the batch are already members.
+		delete(added, id)
+	}
+
+	return results, nil
+}
+
 // DeleteProjectMembers removes users by email from given project.
 func (s *Service) DeleteProjectMembers(ctx context.Context, projectID uuid.UUID, emails []string) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1781,10 +2302,15 @@ func (s *Service) DeleteProjectMembers(ctx context.Context, projectID uuid.UUID,
 		return Error.Wrap(err)
 	}
 
-	if _, err = s.isProjectMember(ctx, user.ID, projectID); err != nil {
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
 		return Error.Wrap(err)
 	}
 
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return err
+	}
+
 	var userIDs []uuid.UUID
 	var userErr errs.Group
 
@@ -1854,16 +2380,40 @@ func (s *Service) GetProjectMembers(ctx context.Context, projectID uuid.UUID, cu
 func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name string) (_ *APIKeyInfo, _ *macaroon.APIKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return s.createAPIKey(ctx, projectID, name, nil)
+}
+
+// CreateAPIKeyWithExpiration creates new api key, which is restricted by
+// a caveat to expire at the given time.
+func (s *Service) CreateAPIKeyWithExpiration(ctx context.Context, projectID uuid.UUID, name string, expiresAt time.Time) (_ *APIKeyInfo, _ *macaroon.APIKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !expiresAt.After(time.Now()) {
+		return nil, nil, ErrValidation.New("api key expiration must be in the future")
+	}
+
+	expiresAt = expiresAt.UTC()
+	return s.createAPIKey(ctx, projectID, name, &expiresAt)
+}
+
+// createAPIKey creates new api key. When expiresAt is set, the key expires at that time.
+func (s *Service) createAPIKey(ctx context.Context, projectID uuid.UUID, name string, expiresAt *time.Time) (_ *APIKeyInfo, _ *macaroon.APIKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	user, err := s.getUserAndAuditLog(ctx, "create api key", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, nil, Error.Wrap(err)
 	}
 
-	_, err = s.isProjectMember(ctx, user.ID, projectID)
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
 	if err != nil {
 		return nil, nil, Error.Wrap(err)
 	}
 
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return nil, nil, err
+	}
+
 	_, err = s.store.APIKeys().GetByNameAndProjectID(ctx, name, projectID)
 	if err == nil {
 		return nil, nil, ErrValidation.New(apiKeyWithNameExistsErrMsg)
@@ -1879,12 +2429,21 @@ func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name st
 		return nil, nil, Error.Wrap(err)
 	}
 
+	if expiresAt != nil {
+		// restricting the key doesn't change its head, which identifies the key.
+		key, err = key.Restrict(macaroon.WithNonce(macaroon.Caveat{NotAfter: expiresAt}))
+		if err != nil {
+			return nil, nil, Error.Wrap(err)
+		}
+	}
+
 	apikey := APIKeyInfo{
 		Name:      name,
 		ProjectID: projectID,
 		Secret:    secret,
 		PartnerID: user.PartnerID,
 		UserAgent: user.UserAgent,
+		ExpiresAt: expiresAt,
 	}
 
 	info, err := s.store.APIKeys().Create(ctx, key.Head(), apikey)
@@ -1970,6 +2529,88 @@ func (s *Service) GenCreateAPIKey(ctx context.Context, requestInfo CreateAPIKeyR
 	}, api.HTTPError{}
 }
 
+// RenameAPIKey changes the name of the api key. The key's secret is left unchanged.
+func (s *Service) RenameAPIKey(ctx context.Context, id uuid.UUID, name string) (_ *APIKeyInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "rename api key", zap.String("apiKeyID", id.String()))
+	if err != nil {
+		if ErrRateLimited.Has(err) {
+			return nil, err
+		}
+		return nil, ErrUnauthorized.Wrap(err)
+	}
+
+	if err = (RenameAPIKeyRequest{Name: name}).Validate(); err != nil {
+		return nil, err
+	}
+
+	key, err := s.store.APIKeys().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAPIKey.Wrap(err)
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, key.ProjectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return nil, err
+	}
+
+	if key.Name == name {
+		return key, nil
+	}
+
+	_, err = s.store.APIKeys().GetByNameAndProjectID(ctx, name, key.ProjectID)
+	switch {
+	case err == nil:
+		return nil, ErrAPIKeyNameExists.New(apiKeyWithNameExistsErrMsg)
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, Error.Wrap(err)
+	}
+
+	key.Name = name
+	if err = s.store.APIKeys().Update(ctx, *key); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return key, nil
+}
+
+// GenRenameAPIKey changes the name of the api key for generated api.
+func (s *Service) GenRenameAPIKey(ctx context.Context, id uuid.UUID, requestInfo RenameAPIKeyRequest) (*APIKeyInfo, api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	key, err := s.RenameAPIKey(ctx, id, requestInfo.Name)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case ErrRateLimited.Has(err):
+			status = http.StatusTooManyRequests
+		case ErrUnauthorized.Has(err), ErrNoMembership.Has(err):
+			status = http.StatusUnauthorized
+		case ErrValidation.Has(err):
+			status = http.StatusBadRequest
+		case ErrNoAPIKey.Has(err):
+			status = http.StatusNotFound
+		case ErrAPIKeyNameExists.Has(err):
+			status = http.StatusConflict
+		}
+		return nil, api.HTTPError{
+			Status: status,
+			Err:    err,
+		}
+	}
+
+	return key, api.HTTPError{}
+}
+
 // GetAPIKeyInfoByName retrieves an api key by its name and project id.
 func (s *Service) GetAPIKeyInfoByName(ctx context.Context, projectID uuid.UUID, name string) (_ *APIKeyInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -2040,11 +2681,16 @@ func (s *Service) DeleteAPIKeys(ctx context.Context, ids []uuid.UUID) (err error
 			continue
 		}
 
-		_, err = s.isProjectMember(ctx, user.ID, key.ProjectID)
+		isMember, err := s.isProjectMember(ctx, user.ID, key.ProjectID)
 		if err != nil {
 			keysErr.Add(ErrUnauthorized.Wrap(err))
 			continue
 		}
+
+		if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+			keysErr.Add(err)
+			continue
+		}
 	}
 
 	if err = keysErr.Err(); err != nil {
@@ -2073,11 +2719,15 @@ func (s *Service) DeleteAPIKeyByNameAndProjectID(ctx context.Context, name strin
 		return Error.Wrap(err)
 	}
 
-	_, err = s.isProjectMember(ctx, user.ID, projectID)
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return err
+	}
+
 	key, err := s.store.APIKeys().GetByNameAndProjectID(ctx, name, projectID)
 	if err != nil {
 		return ErrNoAPIKey.New(apiKeyWithNameDoesntExistErrMsg)
@@ -2117,6 +2767,34 @@ func (s *Service) GetAPIKeys(ctx context.Context, projectID uuid.UUID, cursor AP
 	return
 }
 
+// ListExpiringAPIKeys returns the api keys of the project, which expire within the given duration,
+// so they can be rotated before they stop working. Already expired keys aren't returned.
+func (s *Service) ListExpiringAPIKeys(ctx context.Context, projectID uuid.UUID, within time.Duration) (_ []APIKeyInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "list expiring api keys", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	_, err = s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if within <= 0 {
+		return nil, ErrValidation.New("expiration window must be positive")
+	}
+
+	now := time.Now()
+	keys, err := s.store.APIKeys().GetExpiringByProjectID(ctx, projectID, now, now.Add(within))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return keys, nil
+}
+
 // CreateRESTKey creates a satellite rest key.
 func (s *Service) CreateRESTKey(ctx context.Context, expiration time.Duration) (apiKey string, expiresAt time.Time, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -2193,6 +2871,79 @@ func (s *Service) GetBucketTotals(ctx context.Context, projectID uuid.UUID, curs
 	return usage, nil
 }
 
+// GetProjectActivity retrieves paged upload and download activity of the project, newest first.
+func (s *Service) GetProjectActivity(ctx context.Context, projectID uuid.UUID, cursor accounting.ProjectActivityCursor) (_ *accounting.ProjectActivityPage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get project activity", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	_, err = s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	activity, err := s.projectAccounting.GetProjectActivity(ctx, projectID, cursor)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return activity, nil
+}
+
+// GetBucketVersioning returns whether new writes to the bucket create new object
+// versions instead of overwriting the existing object.
+func (s *Service) GetBucketVersioning(ctx context.Context, projectID uuid.UUID, bucket string) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get bucket versioning", zap.String("projectID", projectID.String()), zap.String("bucket", bucket))
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	_, err = s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	enabled, err := s.buckets.GetBucketVersioning(ctx, []byte(bucket), projectID)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	return enabled, nil
+}
+
+// SetBucketVersioning sets whether new writes to the bucket create new object
+// versions instead of overwriting the existing object. Existing versions are
+// kept when versioning is disabled.
+func (s *Service) SetBucketVersioning(ctx context.Context, projectID uuid.UUID, bucket string, enabled bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "set bucket versioning", zap.String("projectID", projectID.String()), zap.String("bucket", bucket), zap.Bool("enabled", enabled))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return err
+	}
+
+	err = s.buckets.SetBucketVersioning(ctx, []byte(bucket), projectID, enabled)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
 // GetAllBucketNames retrieves all bucket names of a specific project.
 func (s *Service) GetAllBucketNames(ctx context.Context, projectID uuid.UUID) (_ []string, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -2242,7 +2993,7 @@ func (s *Service) GetBucketUsageRollups(ctx context.Context, projectID uuid.UUID
 		return nil, Error.Wrap(err)
 	}
 
-	result, err := s.projectAccounting.GetBucketUsageRollups(ctx, projectID, since, before)
+	result, err := s.projectAccounting.GetBucketUsageRollupsBatched(ctx, projectID, since, before)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -2271,7 +3022,7 @@ func (s *Service) GenGetBucketUsageRollups(ctx context.Context, projectID uuid.U
 		}
 	}
 
-	rollups, err = s.projectAccounting.GetBucketUsageRollups(ctx, projectID, since, before)
+	rollups, err = s.projectAccounting.GetBucketUsageRollupsBatched(ctx, projectID, since, before)
 	if err != nil {
 		return nil, api.HTTPError{
 			Status: http.StatusInternalServerError,
@@ -2314,6 +3065,51 @@ func (s *Service) GenGetSingleBucketUsageRollup(ctx context.Context, projectID u
 	return
 }
 
+// GenGetBucketUsageSeries retrieves usage rollups of a single bucket of particular project for every interval
+// of a given period for generated api. The interval is a duration, e.g. "1h" or "24h".
+func (s *Service) GenGetBucketUsageSeries(ctx context.Context, projectID uuid.UUID, bucket string, since, before time.Time, interval string) (series []accounting.BucketUsageRollup, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get bucket usage series", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, api.HTTPError{
+			Status: http.StatusUnauthorized,
+			Err:    Error.Wrap(err),
+		}
+	}
+
+	_, err = s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, api.HTTPError{
+			Status: http.StatusUnauthorized,
+			Err:    Error.Wrap(err),
+		}
+	}
+
+	duration, err := time.ParseDuration(interval)
+	if err != nil {
+		return nil, api.HTTPError{
+			Status: http.StatusBadRequest,
+			Err:    ErrValidation.Wrap(err),
+		}
+	}
+
+	series, err = s.projectAccounting.GetBucketUsageSeries(ctx, projectID, bucket, since, before, duration)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if accounting.ErrInvalidArgument.Has(err) {
+			status = http.StatusBadRequest
+		}
+		return nil, api.HTTPError{
+			Status: status,
+			Err:    Error.Wrap(err),
+		}
+	}
+
+	return
+}
+
 // GetDailyProjectUsage returns daily usage by project ID.
 func (s *Service) GetDailyProjectUsage(ctx context.Context, projectID uuid.UUID, from, to time.Time) (_ *accounting.ProjectDailyUsage, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -2463,7 +3259,17 @@ func (s *Service) TokenAuth(ctx context.Context, token consoleauth.Token, authTi
 		return nil, Error.Wrap(err)
 	}
 
-	ctx, err = s.authorize(ctx, session.UserID, session.ExpiresAt, authTime)
+	// with an inactivity timeout, the session expires when it isn't used for
+	// the duration of the timeout, but not later than its absolute expiration.
+	expiresAt := session.ExpiresAt
+	if s.config.SessionInactivityTimeout > 0 && session.LastUsedAt != nil {
+		inactiveAt := session.LastUsedAt.Add(s.config.SessionInactivityTimeout)
+		if inactiveAt.Before(expiresAt) {
+			expiresAt = inactiveAt
+		}
+	}
+
+	ctx, err = s.authorize(ctx, session.UserID, expiresAt, authTime)
 	if err != nil {
 		err := errs.Combine(err, s.store.WebappSessions().DeleteBySessionID(ctx, sessionID))
 		if err != nil {
@@ -2472,7 +3278,15 @@ func (s *Service) TokenAuth(ctx context.Context, token consoleauth.Token, authTi
 		return nil, err
 	}
 
-	return ctx, nil
+	// the last use is recorded with a granularity of a minute, so that not
+	// every request results in a write.
+	if s.config.SessionInactivityTimeout > 0 && (session.LastUsedAt == nil || authTime.Sub(*session.LastUsedAt) >= time.Minute) {
+		if err := s.store.WebappSessions().UpdateLastUsedAt(ctx, sessionID, authTime); err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	return consoleauth.WithSessionID(ctx, sessionID), nil
 }
 
 // KeyAuth returns an authenticated context by api key.
@@ -2531,24 +3345,70 @@ func (s *Service) checkProjectCanBeDeleted(ctx context.Context, user *User, proj
 }
 
 // checkProjectLimit is used to check if user is able to create a new project.
-func (s *Service) checkProjectLimit(ctx context.Context, userID uuid.UUID) (currentProjects int, err error) {
+func (s *Service) checkProjectLimit(ctx context.Context, userID uuid.UUID) (currentProjects, limit int, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	limit, err := s.store.Users().GetProjectLimit(ctx, userID)
+	limit, err = s.store.Users().GetProjectLimit(ctx, userID)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return 0, 0, Error.Wrap(err)
 	}
 
 	projects, err := s.GetUsersProjects(ctx)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return 0, limit, Error.Wrap(err)
 	}
 
 	if len(projects) >= limit {
-		return 0, ErrProjLimit.New(projLimitErrMsg)
+		return 0, limit, ErrProjLimit.New(projLimitErrMsg)
+	}
+
+	return len(projects), limit, nil
+}
+
+// checkProjectName is used to check that none of the other projects owned by
+// the user uses the name, when unique project names are enforced. Names are
+// compared ignoring case and surrounding whitespace.
+//
+// The check is best-effort: there is no database constraint backing it, since
+// projects created before enforcing it may share names, so concurrent requests
+// may still create projects with the same name.
+func (s *Service) checkProjectName(ctx context.Context, ownerID, projectID uuid.UUID, name string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !s.config.UniqueProjectNames {
+		return nil
+	}
+
+	projects, err := s.store.Projects().GetOwn(ctx, ownerID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	normalized := normalizeProjectName(name)
+	for _, project := range projects {
+		if project.ID != projectID && normalizeProjectName(project.Name) == normalized {
+			return ErrProjName.New(projNameUsedErrMsg)
+		}
 	}
 
-	return len(projects), nil
+	return nil
+}
+
+// normalizeProjectName returns the form of the name used to compare project names.
+func normalizeProjectName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// projectNameHTTPError converts an error returned by checkProjectName to an api.HTTPError.
+func projectNameHTTPError(err error) api.HTTPError {
+	status := http.StatusInternalServerError
+	if ErrProjName.Has(err) {
+		status = http.StatusConflict
+	}
+	return api.HTTPError{
+		Status: status,
+		Err:    err,
+	}
 }
 
 // getUserProjectLimits is a method to get the users storage and bandwidth limits for new projects.
@@ -2641,6 +3501,105 @@ func (s *Service) isProjectMember(ctx context.Context, userID uuid.UUID, project
 	return isProjectMember{}, ErrNoMembership.New(unauthorizedErrMsg)
 }
 
+// memberRole returns the role of the user within the project.
+func (s *Service) memberRole(ctx context.Context, project *Project, userID uuid.UUID) (_ ProjectMemberRole, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if project.OwnerID == userID {
+		return RoleOwner, nil
+	}
+
+	return s.store.ProjectMembers().GetRole(ctx, userID, project.ID)
+}
+
+// checkMemberRole checks whether the member has one of the allowed roles within the project.
+func (s *Service) checkMemberRole(ctx context.Context, isMember isProjectMember, allowed ...ProjectMemberRole) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	role, err := s.memberRole(ctx, isMember.project, isMember.membership.MemberID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, allowedRole := range allowed {
+		if role == allowedRole {
+			return nil
+		}
+	}
+
+	return ErrUnauthorized.New(unauthorizedErrMsg)
+}
+
+// GetMemberRole returns the role of the user within the project.
+func (s *Service) GetMemberRole(ctx context.Context, projectID, userID uuid.UUID) (role ProjectMemberRole, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get member role", zap.String("projectID", projectID.String()), zap.String("memberID", userID.String()))
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectMember(ctx, userID, projectID); err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	role, err = s.memberRole(ctx, isMember.project, userID)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	return role, nil
+}
+
+// SetMemberRole changes the role of the user within the project.
+// Only project owners and admins are allowed to change roles and the owner role can't be assigned or changed.
+func (s *Service) SetMemberRole(ctx context.Context, projectID, userID uuid.UUID, role ProjectMemberRole) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "set member role",
+		zap.String("projectID", projectID.String()),
+		zap.String("memberID", userID.String()),
+		zap.Stringer("role", role))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	switch role {
+	case RoleMember, RoleAdmin, RoleReadOnly:
+	default:
+		return ErrValidation.New("role %s can not be assigned", role)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin); err != nil {
+		return err
+	}
+
+	if _, err = s.isProjectMember(ctx, userID, projectID); err != nil {
+		return Error.Wrap(err)
+	}
+
+	if isMember.project.OwnerID == userID {
+		return ErrValidation.New("the role of the project owner can not be changed")
+	}
+
+	err = s.store.ProjectMembers().SetRole(ctx, userID, projectID, role)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
 // WalletInfo contains all the information about a destination wallet assigned to a user.
 type WalletInfo struct {
 	Address blockchain.Address `json:"address"`
@@ -2702,6 +3661,43 @@ func (payment Payments) GetWallet(ctx context.Context) (_ WalletInfo, err error)
 	}, nil
 }
 
+// WalletTransactions returns up to limit deposits to the user's wallet, ordered from the oldest.
+// Cursor is the number of deposits to skip.
+func (payment Payments) WalletTransactions(ctx context.Context, cursor int64, limit int) (_ []payments.WalletTransaction, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if cursor < 0 || limit <= 0 {
+		return nil, ErrValidation.New("invalid cursor %d or limit %d", cursor, limit)
+	}
+
+	user, err := payment.service.getUserAndAuditLog(ctx, "get wallet transactions", zap.Int64("cursor", cursor), zap.Int("limit", limit))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	transactions, err := payment.service.depositWallets.Transactions(ctx, user.ID, cursor, limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return transactions, nil
+}
+
+// TokenAmountInUSD converts a STORJ token amount to US dollars, where tokenPrice
+// is the value of one STORJ token in dollars.
+func (payment Payments) TokenAmountInUSD(ctx context.Context, amount monetary.Amount, tokenPrice decimal.Decimal) (_ monetary.Amount, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, err = GetUser(ctx); err != nil {
+		return monetary.Amount{}, Error.Wrap(err)
+	}
+
+	usd, err := monetary.Convert(amount, monetary.StorjToken, monetary.USDollars, tokenPrice)
+	if err != nil {
+		return monetary.Amount{}, ErrValidation.Wrap(err)
+	}
+	return usd, nil
+}
+
 // Transactions returns with all the native blockchain transactions.
 func (payment Payments) Transactions(ctx context.Context) (TokenTransactions, error) {
 	panic("Not yet implemented")
@@ -2716,6 +3712,48 @@ func findMembershipByProjectID(memberships []ProjectMember, projectID uuid.UUID)
 	return ProjectMember{}, false
 }
 
+// GetSecurityEvents returns a page of the user's security events, newest first.
+// A missing limit or page defaults to the first page of maxLimit events.
+func (s *Service) GetSecurityEvents(ctx context.Context, cursor SecurityEventsCursor) (page SecurityEventsPage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get security events")
+	if err != nil {
+		return SecurityEventsPage{}, Error.Wrap(err)
+	}
+
+	if cursor.Limit <= 0 || cursor.Limit > maxLimit {
+		cursor.Limit = maxLimit
+	}
+	if cursor.Page <= 0 {
+		cursor.Page = 1
+	}
+
+	page, err = s.store.SecurityEvents().ListByUserID(ctx, user.ID, cursor)
+	if err != nil {
+		return SecurityEventsPage{}, Error.Wrap(err)
+	}
+
+	return page, nil
+}
+
+// MarkSecurityEventsReviewed marks the user's security events with the given IDs as reviewed.
+func (s *Service) MarkSecurityEventsReviewed(ctx context.Context, ids []uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "mark security events reviewed", zap.Int("count", len(ids)))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	_, err = s.store.SecurityEvents().MarkReviewed(ctx, user.ID, ids, time.Now())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
 // DeleteSessionByToken removes the session corresponding to the given token from the database.
 func (s *Service) DeleteSessionByToken(ctx context.Context, token consoleauth.Token) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -4,19 +4,31 @@
 package console_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"math"
 	"math/big"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v72"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/crypto/bcrypt"
+	segment "gopkg.in/segmentio/analytics-go.v3"
 
 	"storj.io/common/macaroon"
 	"storj.io/common/memory"
+	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
@@ -24,7 +36,11 @@ import (
 	"storj.io/storj/private/blockchain"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/payments"
+	"storj.io/storj/satellite/payments/monetary"
 )
 
 func TestService(t *testing.T) {
@@ -233,14 +249,17 @@ func TestService(t *testing.T) {
 			t.Run("TestChangeEmail", func(t *testing.T) {
 				const newEmail = "newEmail@example.com"
 
-				err = service.ChangeEmail(userCtx2, newEmail)
+				token, err := service.ChangeEmail(userCtx2, newEmail)
+				require.NoError(t, err)
+
+				_, err = service.ConfirmEmailChange(ctx, token)
 				require.NoError(t, err)
 
 				user, _, err := service.GetUserByEmailWithUnverified(userCtx2, newEmail)
 				require.NoError(t, err)
 				require.Equal(t, newEmail, user.Email)
 
-				err = service.ChangeEmail(userCtx2, newEmail)
+				_, err = service.ChangeEmail(userCtx2, newEmail)
 				require.Error(t, err)
 			})
 
@@ -852,6 +871,225 @@ func TestWalletJsonMarshall(t *testing.T) {
 
 }
 
+func TestWalletTransactions(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		other, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		wallets := &depositWalletsMock{
+			transactions: map[uuid.UUID][]payments.WalletTransaction{},
+		}
+		for i := 0; i < 5; i++ {
+			status := payments.TransactionStatusPaid
+			if i >= 3 {
+				status = payments.TransactionStatusPending
+			}
+			wallets.transactions[user.ID] = append(wallets.transactions[user.ID], payments.WalletTransaction{
+				TxHash:    blockchain.Hash{byte(i + 1)},
+				Amount:    monetary.AmountFromBaseUnits(int64(i+1)*100000000, monetary.StorjToken),
+				Status:    status,
+				Timestamp: time.Now().Add(time.Duration(i) * time.Minute),
+			})
+		}
+
+		service, err := console.NewService(zaptest.NewLogger(t), sat.DB.Console(),
+			nil, nil, nil, nil, nil, nil, wallets, nil, nil, console.Config{Argon2: console.Argon2Config{Time: 1, Memory: console.TestArgon2Memory, Threads: 1, Concurrency: 1}})
+		require.NoError(t, err)
+
+		_, err = service.Payments().WalletTransactions(ctx, 0, 2)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		_, err = service.Payments().WalletTransactions(userCtx, 0, 0)
+		require.True(t, console.ErrValidation.Has(err))
+
+		var listed []payments.WalletTransaction
+		var cursor int64
+		for {
+			page, err := service.Payments().WalletTransactions(userCtx, cursor, 2)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page), 2)
+			if len(page) == 0 {
+				break
+			}
+			listed = append(listed, page...)
+			cursor += int64(len(page))
+		}
+		require.Equal(t, wallets.transactions[user.ID], listed)
+
+		otherCtx, err := sat.UserContext(ctx, other.ID)
+		require.NoError(t, err)
+
+		transactions, err := service.Payments().WalletTransactions(otherCtx, 0, 2)
+		require.NoError(t, err)
+		require.Empty(t, transactions)
+	})
+}
+
+func TestTokenAmountInUSD(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		tokens := monetary.AmountFromBaseUnits(250000000, monetary.StorjToken)
+
+		usd, err := service.Payments().TokenAmountInUSD(userCtx, tokens, decimal.RequireFromString("0.505"))
+		require.NoError(t, err)
+		require.Equal(t, monetary.AmountFromBaseUnits(126, monetary.USDollars), usd)
+
+		_, err = service.Payments().TokenAmountInUSD(userCtx, monetary.AmountFromBaseUnits(1, monetary.USDollars), decimal.NewFromInt(1))
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.Payments().TokenAmountInUSD(ctx, tokens, decimal.NewFromInt(1))
+		require.Error(t, err)
+	})
+}
+
+// depositWalletsMock serves a fixed list of wallet transactions for each user.
+type depositWalletsMock struct {
+	transactions map[uuid.UUID][]payments.WalletTransaction
+}
+
+func (wallets *depositWalletsMock) Claim(ctx context.Context, userID uuid.UUID) (blockchain.Address, error) {
+	return blockchain.Address{}, nil
+}
+
+func (wallets *depositWalletsMock) Get(ctx context.Context, userID uuid.UUID) (blockchain.Address, error) {
+	return blockchain.Address{}, nil
+}
+
+func (wallets *depositWalletsMock) Transactions(ctx context.Context, userID uuid.UUID, cursor int64, limit int) ([]payments.WalletTransaction, error) {
+	transactions := wallets.transactions[userID]
+	if cursor >= int64(len(transactions)) {
+		return nil, nil
+	}
+	transactions = transactions[cursor:]
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+	return transactions, nil
+}
+
+func TestApplyCouponCode(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		validCoupon := payments.Coupon{
+			ID:        "c1",
+			PromoCode: "promo1",
+			Name:      "Test Promo Code 1",
+			AmountOff: 500,
+			Duration:  payments.CouponOnce,
+		}
+		accounts := &accountsMock{
+			coupons: &couponsMock{
+				valid:   map[string]payments.Coupon{validCoupon.PromoCode: validCoupon},
+				applied: map[uuid.UUID]string{},
+			},
+		}
+
+		service, err := console.NewService(zaptest.NewLogger(t), sat.DB.Console(),
+			nil, nil, nil, nil, nil, accounts, nil, nil, nil, console.Config{Argon2: console.Argon2Config{Time: 1, Memory: console.TestArgon2Memory, Threads: 1, Concurrency: 1}})
+		require.NoError(t, err)
+
+		_, err = service.Payments().ApplyCouponCode(ctx, validCoupon.PromoCode)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		t.Run("valid code", func(t *testing.T) {
+			coupon, err := service.Payments().ApplyCouponCode(userCtx, validCoupon.PromoCode)
+			require.NoError(t, err)
+			require.Equal(t, validCoupon, *coupon)
+		})
+
+		t.Run("invalid code", func(t *testing.T) {
+			_, err := service.Payments().ApplyCouponCode(userCtx, "invalid")
+			require.True(t, console.ErrValidation.Has(err))
+			require.True(t, payments.ErrInvalidCouponCode.Has(err))
+		})
+
+		t.Run("already applied code", func(t *testing.T) {
+			_, err := service.Payments().ApplyCouponCode(userCtx, validCoupon.PromoCode)
+			require.True(t, console.ErrValidation.Has(err))
+			require.True(t, payments.ErrCouponCodeAlreadyApplied.Has(err))
+		})
+	})
+}
+
+// accountsMock is a payments.Accounts stub which only supports coupons.
+type accountsMock struct {
+	payments.Accounts
+
+	coupons *couponsMock
+}
+
+func (accounts *accountsMock) Coupons() payments.Coupons {
+	return accounts.coupons
+}
+
+// couponsMock applies coupons from a fixed list of valid codes.
+type couponsMock struct {
+	valid   map[string]payments.Coupon
+	applied map[uuid.UUID]string
+}
+
+func (coupons *couponsMock) GetByUserID(ctx context.Context, userID uuid.UUID) (*payments.Coupon, error) {
+	code, ok := coupons.applied[userID]
+	if !ok {
+		return nil, nil
+	}
+	coupon := coupons.valid[code]
+	return &coupon, nil
+}
+
+func (coupons *couponsMock) ApplyCouponCode(ctx context.Context, userID uuid.UUID, couponCode string) (*payments.Coupon, error) {
+	coupon, ok := coupons.valid[couponCode]
+	if !ok {
+		return nil, payments.ErrInvalidCouponCode.New("%q does not exist", couponCode)
+	}
+	if coupons.applied[userID] == couponCode {
+		return nil, payments.ErrCouponCodeAlreadyApplied.New("%q", couponCode)
+	}
+	coupons.applied[userID] = couponCode
+	return &coupon, nil
+}
+
 func TestSessionExpiration(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
@@ -891,3 +1129,1480 @@ func TestSessionExpiration(t *testing.T) {
 		require.ErrorIs(t, sql.ErrNoRows, err)
 	})
 }
+
+func TestChangeEmailVerification(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		other, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		// emails of other users can't be used
+		_, err = service.ChangeEmail(userCtx, other.Email)
+		require.True(t, console.ErrEmailUsed.Has(err))
+
+		const newEmail = "new@mail.test"
+
+		token, err := service.ChangeEmail(userCtx, newEmail)
+		require.NoError(t, err)
+
+		// the email isn't changed until the token is confirmed
+		current, err := sat.DB.Console().Users().Get(ctx, user.ID)
+		require.NoError(t, err)
+		require.Equal(t, user.Email, current.Email)
+
+		// expired tokens are rejected
+		claims := consoleauth.Claims{
+			ID:         user.ID,
+			Email:      newEmail,
+			Expiration: time.Now().Add(-time.Minute),
+			Purpose:    consoleauth.PurposeEmailChange,
+		}
+		payload, err := claims.JSON()
+		require.NoError(t, err)
+		expired := consoleauth.Token{Payload: payload}
+		expired.Signature, err = sat.API.Console.AuthTokens.SignToken(expired)
+		require.NoError(t, err)
+
+		_, err = service.ConfirmEmailChange(ctx, expired.String())
+		require.True(t, console.ErrTokenExpiration.Has(err))
+
+		// tampered tokens are rejected
+		tampered := consoleauth.Token{Payload: payload, Signature: []byte("invalid")}
+		_, err = service.ConfirmEmailChange(ctx, tampered.String())
+		require.Error(t, err)
+
+		// email change tokens can't be used to activate the account and vice versa
+		_, err = service.ActivateAccount(ctx, token)
+		require.True(t, console.ErrTokenInvalid.Has(err))
+
+		activationToken, err := service.GenerateActivationToken(ctx, user.ID, newEmail)
+		require.NoError(t, err)
+		_, err = service.ConfirmEmailChange(ctx, activationToken)
+		require.True(t, console.ErrTokenInvalid.Has(err))
+
+		// requesting another email change invalidates the earlier token
+		superseded := token
+		token, err = service.ChangeEmail(userCtx, newEmail)
+		require.NoError(t, err)
+		_, err = service.ConfirmEmailChange(ctx, superseded)
+		require.True(t, console.ErrTokenInvalid.Has(err))
+
+		changed, err := service.ConfirmEmailChange(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, changed.ID)
+		require.Equal(t, newEmail, changed.Email)
+
+		current, err = sat.DB.Console().Users().GetByEmail(ctx, newEmail)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, current.ID)
+
+		// the token can't be used once the email is taken
+		_, err = service.ConfirmEmailChange(ctx, token)
+		require.True(t, console.ErrEmailUsed.Has(err))
+	})
+}
+
+func TestSessionInactivityTimeout(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.SessionDuration = time.Hour
+				config.Console.SessionInactivityTimeout = 30 * time.Minute
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		login := func(t *testing.T) (consoleauth.Token, uuid.UUID) {
+			token, err := service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName})
+			require.NoError(t, err)
+
+			sessionID, err := uuid.FromBytes(token.Payload)
+			require.NoError(t, err)
+
+			return token, sessionID
+		}
+
+		t.Run("activity keeps session alive", func(t *testing.T) {
+			token, sessionID := login(t)
+			now := time.Now()
+
+			for _, elapsed := range []time.Duration{20 * time.Minute, 45 * time.Minute} {
+				_, err = service.TokenAuth(ctx, token, now.Add(elapsed))
+				require.NoError(t, err)
+			}
+
+			session, err := sat.DB.Console().WebappSessions().GetBySessionID(ctx, sessionID)
+			require.NoError(t, err)
+			require.NotNil(t, session.LastUsedAt)
+			require.WithinDuration(t, now.Add(45*time.Minute), *session.LastUsedAt, time.Second)
+
+			// requests within a minute of the last recorded use don't update it
+			_, err = service.TokenAuth(ctx, token, now.Add(45*time.Minute+30*time.Second))
+			require.NoError(t, err)
+
+			session, err = sat.DB.Console().WebappSessions().GetBySessionID(ctx, sessionID)
+			require.NoError(t, err)
+			require.WithinDuration(t, now.Add(45*time.Minute), *session.LastUsedAt, time.Second)
+		})
+
+		t.Run("inactivity expires session", func(t *testing.T) {
+			token, sessionID := login(t)
+			now := time.Now()
+
+			_, err = service.TokenAuth(ctx, token, now.Add(10*time.Minute))
+			require.NoError(t, err)
+
+			_, err = service.TokenAuth(ctx, token, now.Add(41*time.Minute))
+			require.True(t, console.ErrTokenExpiration.Has(err))
+
+			_, err = sat.DB.Console().WebappSessions().GetBySessionID(ctx, sessionID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+		})
+
+		t.Run("absolute expiration is enforced", func(t *testing.T) {
+			token, sessionID := login(t)
+			now := time.Now()
+
+			for _, elapsed := range []time.Duration{20 * time.Minute, 40 * time.Minute, 55 * time.Minute} {
+				_, err = service.TokenAuth(ctx, token, now.Add(elapsed))
+				require.NoError(t, err)
+			}
+
+			_, err = service.TokenAuth(ctx, token, now.Add(65*time.Minute))
+			require.True(t, console.ErrTokenExpiration.Has(err))
+
+			_, err = sat.DB.Console().WebappSessions().GetBySessionID(ctx, sessionID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+		})
+	})
+}
+
+func TestCanCreateProject(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		eligibility, err := service.CanCreateProject(userCtx)
+		require.NoError(t, err)
+		require.Equal(t, console.ProjectCreationEligibility{Eligible: true}, eligibility)
+
+		_, err = service.CreateProject(userCtx, console.ProjectInfo{Name: "Project 1"})
+		require.NoError(t, err)
+
+		eligibility, err = service.CanCreateProject(userCtx)
+		require.NoError(t, err)
+		require.Equal(t, console.ProjectCreationEligibility{Reason: console.ProjectCreationAtLimit}, eligibility)
+
+		updateStatus := func(status console.UserStatus) {
+			err := sat.DB.Console().Users().Update(ctx, user.ID, console.UpdateUserRequest{Status: &status})
+			require.NoError(t, err)
+		}
+
+		updateStatus(console.Inactive)
+		eligibility, err = service.CanCreateProject(userCtx)
+		require.NoError(t, err)
+		require.Equal(t, console.ProjectCreationEligibility{Reason: console.ProjectCreationUnverifiedEmail}, eligibility)
+
+		updateStatus(console.Suspended)
+		eligibility, err = service.CanCreateProject(userCtx)
+		require.NoError(t, err)
+		require.Equal(t, console.ProjectCreationEligibility{Reason: console.ProjectCreationAccountSuspended}, eligibility)
+
+		updateStatus(console.Active)
+		lockoutExpiration := time.Now().Add(time.Hour)
+		lockoutExpirationPtr := &lockoutExpiration
+		err = sat.DB.Console().Users().Update(ctx, user.ID, console.UpdateUserRequest{
+			LoginLockoutExpiration: &lockoutExpirationPtr,
+		})
+		require.NoError(t, err)
+
+		eligibility, err = service.CanCreateProject(userCtx)
+		require.NoError(t, err)
+		require.Equal(t, console.ProjectCreationEligibility{Reason: console.ProjectCreationAccountSuspended}, eligibility)
+	})
+}
+
+func TestEstimateStorageCost(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Payments.StorageTBPrice = "10"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		const month = 30 * 24 * time.Hour
+
+		for _, tt := range []struct {
+			bytes    memory.Size
+			duration time.Duration
+			cents    int64
+		}{
+			{bytes: memory.TB, duration: month, cents: 1000},
+			{bytes: 500 * memory.GB, duration: 2 * month, cents: 1000},
+			{bytes: 3 * memory.TB, duration: month / 2, cents: 1500},
+			{bytes: 0, duration: month, cents: 0},
+		} {
+			cost, err := service.Payments().EstimateStorageCost(userCtx, tt.bytes.Int64(), tt.duration)
+			require.NoError(t, err)
+			require.Equal(t, tt.cents, cost, "%s for %s", tt.bytes, tt.duration)
+		}
+
+		_, err = service.Payments().EstimateStorageCost(userCtx, -1, month)
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.Payments().EstimateStorageCost(userCtx, memory.TB.Int64(), 0)
+		require.True(t, console.ErrValidation.Has(err))
+	})
+}
+
+func TestUniqueProjectNames(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.UniqueProjectNames = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 3)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		project, err := service.CreateProject(userCtx, console.ProjectInfo{Name: "Project 1"})
+		require.NoError(t, err)
+
+		// a duplicate name is rejected with an error distinct from the project limit
+		_, err = service.CreateProject(userCtx, console.ProjectInfo{Name: "Project 1"})
+		require.True(t, console.ErrProjName.Has(err))
+		require.False(t, console.ErrProjLimit.Has(err))
+
+		// names differing only in case or surrounding whitespace are duplicates too
+		_, err = service.CreateProject(userCtx, console.ProjectInfo{Name: " project 1 "})
+		require.True(t, console.ErrProjName.Has(err))
+
+		_, err = service.CreateProject(userCtx, console.ProjectInfo{Name: "Project 2"})
+		require.NoError(t, err)
+
+		// renaming to a name of another project is rejected as well
+		_, err = service.UpdateProject(userCtx, project.ID, console.ProjectInfo{Name: "Project 2"})
+		require.True(t, console.ErrProjName.Has(err))
+
+		// keeping the name of the project itself is allowed
+		_, err = service.UpdateProject(userCtx, project.ID, console.ProjectInfo{Name: "Project 1", Description: "updated"})
+		require.NoError(t, err)
+
+		// names only have to be unique per user
+		otherUser, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		otherUserCtx, err := sat.UserContext(ctx, otherUser.ID)
+		require.NoError(t, err)
+
+		_, err = service.CreateProject(otherUserCtx, console.ProjectInfo{Name: "Project 1"})
+		require.NoError(t, err)
+	})
+}
+
+func TestGenGetUsersProjects(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 3)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		for _, name := range []string{"Project C", "Project A", "Project B"} {
+			_, err = service.CreateProject(userCtx, console.ProjectInfo{Name: name})
+			require.NoError(t, err)
+		}
+
+		// without limit and page all the projects fit into the first page
+		page, httpErr := service.GenGetUsersProjects(userCtx, 0, 0)
+		require.NoError(t, httpErr.Err)
+		require.Len(t, page.Projects, 3)
+		require.False(t, page.Next)
+		require.EqualValues(t, 3, page.TotalCount)
+		require.Equal(t, 1, page.CurrentPage)
+
+		page, httpErr = service.GenGetUsersProjects(userCtx, 2, 1)
+		require.NoError(t, httpErr.Err)
+		require.Len(t, page.Projects, 2)
+		require.Equal(t, "Project A", page.Projects[0].Name)
+		require.Equal(t, "Project B", page.Projects[1].Name)
+		require.True(t, page.Next)
+		require.EqualValues(t, 2, page.NextOffset)
+		require.Equal(t, 2, page.PageCount)
+		require.EqualValues(t, 3, page.TotalCount)
+
+		page, httpErr = service.GenGetUsersProjects(userCtx, 2, 2)
+		require.NoError(t, httpErr.Err)
+		require.Len(t, page.Projects, 1)
+		require.Equal(t, "Project C", page.Projects[0].Name)
+		require.False(t, page.Next)
+		require.Equal(t, 2, page.CurrentPage)
+
+		// pages after the last one are empty
+		page, httpErr = service.GenGetUsersProjects(userCtx, 2, 3)
+		require.NoError(t, httpErr.Err)
+		require.Empty(t, page.Projects)
+		require.EqualValues(t, 3, page.TotalCount)
+	})
+}
+
+func TestSetProjectEgressRateLimit(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+		uplink := planet.Uplinks[0]
+		projectID := uplink.Projects[0].ID
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		ownerCtx, err := sat.UserContext(ctx, uplink.Projects[0].Owner.ID)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		// only the owner is allowed to set the limit
+		err = service.SetProjectEgressRateLimit(userCtx, projectID, memory.KiB)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		err = service.SetProjectEgressRateLimit(ownerCtx, projectID, -memory.KiB)
+		require.True(t, console.ErrValidation.Has(err))
+
+		// the limit set by an admin is lower, so it applies.
+		err = sat.DB.Console().Projects().UpdateAdminEgressRateLimit(ctx, projectID, memory.KiB)
+		require.NoError(t, err)
+
+		err = service.SetProjectEgressRateLimit(ownerCtx, projectID, memory.MiB)
+		require.NoError(t, err)
+
+		project, err := sat.DB.Console().Projects().Get(ctx, projectID)
+		require.NoError(t, err)
+		require.NotNil(t, project.EgressRateLimit)
+		require.Equal(t, memory.MiB, *project.EgressRateLimit)
+		require.NotNil(t, project.AdminEgressRateLimit)
+		require.Equal(t, memory.KiB, *project.AdminEgressRateLimit)
+
+		data := testrand.Bytes(memory.KiB)
+		require.NoError(t, uplink.Upload(ctx, sat, "testbucket", "object", data))
+
+		downloaded, err := uplink.Download(ctx, sat, "testbucket", "object")
+		require.NoError(t, err)
+		require.Equal(t, data, downloaded)
+
+		// the first download used the whole limit of the second
+		_, err = uplink.Download(ctx, sat, "testbucket", "object")
+		require.Error(t, err)
+
+		// zero removes the limit of the owner, but not the one of the admin
+		err = service.SetProjectEgressRateLimit(ownerCtx, projectID, 0)
+		require.NoError(t, err)
+
+		project, err = sat.DB.Console().Projects().Get(ctx, projectID)
+		require.NoError(t, err)
+		require.Nil(t, project.EgressRateLimit)
+		require.NotNil(t, project.AdminEgressRateLimit)
+		require.Equal(t, memory.KiB, *project.AdminEgressRateLimit)
+	})
+}
+
+func TestSecurityEvents(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		_, err = service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName})
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, service.ChangePassword(userCtx, user.FullName, "newPassword123"))
+
+		cursor := console.SecurityEventsCursor{Limit: 10, Page: 1}
+		page, err := service.GetSecurityEvents(userCtx, cursor)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, page.TotalCount)
+		require.Len(t, page.Events, 2)
+		require.False(t, page.Next)
+
+		// events are listed newest first
+		require.Equal(t, console.SecurityEventPasswordChange, page.Events[0].Type)
+		require.Equal(t, console.SecurityEventLogin, page.Events[1].Type)
+
+		// a missing limit and page default to the first page
+		defaultPage, err := service.GetSecurityEvents(userCtx, console.SecurityEventsCursor{})
+		require.NoError(t, err)
+		require.Equal(t, 1, defaultPage.CurrentPage)
+		require.Equal(t, page.Events, defaultPage.Events)
+
+		firstPage, err := service.GetSecurityEvents(userCtx, console.SecurityEventsCursor{Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, 2, firstPage.PageCount)
+		require.True(t, firstPage.Next)
+		require.Equal(t, page.Events[:1], firstPage.Events)
+
+		var ids []uuid.UUID
+		for _, event := range page.Events {
+			require.Equal(t, user.ID, event.UserID)
+			require.Nil(t, event.ReviewedAt)
+			ids = append(ids, event.ID)
+		}
+
+		require.NoError(t, service.MarkSecurityEventsReviewed(userCtx, ids))
+
+		page, err = service.GetSecurityEvents(userCtx, cursor)
+		require.NoError(t, err)
+		require.Len(t, page.Events, 2)
+		for _, event := range page.Events {
+			require.NotNil(t, event.ReviewedAt)
+		}
+	})
+}
+
+func TestDataErasure(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 2,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+		sat.DataErasure.Chore.Loop.Pause()
+
+		owner := planet.Uplinks[0]
+		other := planet.Uplinks[1]
+
+		for _, bucket := range []string{"bucket1", "bucket2"} {
+			for _, key := range []string{"object1", "object2"} {
+				require.NoError(t, owner.Upload(ctx, sat, bucket, key, testrand.Bytes(memory.KiB)))
+			}
+		}
+		require.NoError(t, other.Upload(ctx, sat, "bucket1", "object1", testrand.Bytes(memory.KiB)))
+
+		ownerCtx, err := sat.UserContext(ctx, owner.Projects[0].Owner.ID)
+		require.NoError(t, err)
+
+		otherCtx, err := sat.UserContext(ctx, other.Projects[0].Owner.ID)
+		require.NoError(t, err)
+
+		job, err := service.RequestDataErasure(ownerCtx)
+		require.NoError(t, err)
+		require.Equal(t, console.DataErasurePending, job.Status)
+		require.Nil(t, job.CompletedAt)
+
+		// requesting again while the job is pending returns the same job.
+		again, err := service.RequestDataErasure(ownerCtx)
+		require.NoError(t, err)
+		require.Equal(t, job.ID, again.ID)
+
+		// jobs are only visible to the user who requested them
+		_, err = service.GetDataErasureJob(otherCtx, job.ID)
+		require.True(t, console.ErrDataErasureJobNotFound.Has(err))
+
+		_, err = service.GetDataErasureJob(ownerCtx, testrand.UUID())
+		require.True(t, console.ErrDataErasureJobNotFound.Has(err))
+
+		job, err = service.GetDataErasureJob(ownerCtx, job.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.DataErasurePending, job.Status)
+
+		// objects deleted by a previous, failed attempt are still counted.
+		require.NoError(t, sat.DB.Console().DataErasureJobs().AddDeletedObjects(ctx, job.ID, 2))
+
+		sat.DataErasure.Chore.Loop.TriggerWait()
+
+		job, err = service.GetDataErasureJob(ownerCtx, job.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.DataErasureCompleted, job.Status)
+		require.EqualValues(t, 6, job.DeletedObjects)
+		require.NotNil(t, job.CompletedAt)
+
+		objects, err := sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, other.Projects[0].ID, objects[0].ProjectID)
+	})
+}
+
+func TestUserRateLimit(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.UserRequestsPerMinute = 3
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		// AddUser sets up the payment account, which uses one of the requests.
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		other, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		otherCtx, err := sat.UserContext(ctx, other.ID)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = service.GetUsersProjects(userCtx)
+			require.NoError(t, err)
+		}
+
+		_, err = service.Payments().SetupAccount(userCtx)
+		require.True(t, console.ErrRateLimited.Has(err))
+
+		_, err = service.GetUsersProjects(userCtx)
+		require.True(t, console.ErrRateLimited.Has(err))
+
+		// other users have their own limit
+		_, err = service.GetUsersProjects(otherCtx)
+		require.NoError(t, err)
+	})
+}
+
+func TestArgon2idPasswords(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.PasswordHashAlgorithm = console.PasswordHashArgon2id
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+		users := sat.DB.Console().Users()
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		user, err = users.Get(ctx, user.ID)
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(user.PasswordHash, []byte("$argon2id$")))
+
+		_, err = service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName})
+		require.NoError(t, err)
+
+		// users with a password hashed before switching the algorithm can still log in
+		legacy, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Legacy User",
+			Email:    "legacy@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		legacyHash, err := bcrypt.GenerateFromPassword([]byte(legacy.FullName), console.TestPasswordCost)
+		require.NoError(t, err)
+		require.NoError(t, users.Update(ctx, legacy.ID, console.UpdateUserRequest{PasswordHash: legacyHash}))
+
+		_, err = service.Token(ctx, console.AuthUser{Email: legacy.Email, Password: legacy.FullName})
+		require.NoError(t, err)
+
+		// changing the password rehashes it with the configured algorithm
+		legacyCtx, err := sat.UserContext(ctx, legacy.ID)
+		require.NoError(t, err)
+		require.NoError(t, service.ChangePassword(legacyCtx, legacy.FullName, "newPassword123"))
+
+		legacy, err = users.Get(ctx, legacy.ID)
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(legacy.PasswordHash, []byte("$argon2id$")))
+
+		_, err = service.Token(ctx, console.AuthUser{Email: legacy.Email, Password: "newPassword123"})
+		require.NoError(t, err)
+	})
+}
+
+func TestChangePasswordLogoutOtherSessions(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.LogoutOnPasswordChange = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		// log in from two different places
+		currentToken, err := service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName})
+		require.NoError(t, err)
+		otherToken, err := service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName})
+		require.NoError(t, err)
+
+		userCtx, err := service.TokenAuth(ctx, currentToken, time.Now())
+		require.NoError(t, err)
+
+		require.NoError(t, service.ChangePassword(userCtx, user.FullName, "newPassword123"))
+
+		// the session which changed the password persists, the other one is revoked
+		_, err = service.TokenAuth(ctx, currentToken, time.Now())
+		require.NoError(t, err)
+
+		_, err = service.TokenAuth(ctx, otherToken, time.Now())
+		require.Error(t, err)
+
+		otherSessionID, err := uuid.FromBytes(otherToken.Payload)
+		require.NoError(t, err)
+		_, err = sat.DB.Console().WebappSessions().GetBySessionID(ctx, otherSessionID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestProjectMemberRoles(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		owner, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Owner User",
+			Email:    "owner@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		admin, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Admin User",
+			Email:    "admin@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		member, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Member User",
+			Email:    "member@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, owner.ID, "test project")
+		require.NoError(t, err)
+
+		ownerCtx, err := sat.UserContext(ctx, owner.ID)
+		require.NoError(t, err)
+		adminCtx, err := sat.UserContext(ctx, admin.ID)
+		require.NoError(t, err)
+		memberCtx, err := sat.UserContext(ctx, member.ID)
+		require.NoError(t, err)
+
+		_, err = service.AddProjectMembers(ownerCtx, project.ID, []string{admin.Email, member.Email})
+		require.NoError(t, err)
+
+		role, err := service.GetMemberRole(ownerCtx, project.ID, owner.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.RoleOwner, role)
+
+		role, err = service.GetMemberRole(ownerCtx, project.ID, member.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.RoleMember, role)
+
+		// the owner can promote members to admins
+		require.NoError(t, service.SetMemberRole(ownerCtx, project.ID, admin.ID, console.RoleAdmin))
+
+		role, err = service.GetMemberRole(memberCtx, project.ID, admin.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.RoleAdmin, role)
+
+		// members without permission can't change roles
+		err = service.SetMemberRole(memberCtx, project.ID, member.ID, console.RoleAdmin)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		// the owner role can't be assigned or taken away
+		err = service.SetMemberRole(adminCtx, project.ID, member.ID, console.RoleOwner)
+		require.True(t, console.ErrValidation.Has(err))
+		err = service.SetMemberRole(adminCtx, project.ID, owner.ID, console.RoleReadOnly)
+		require.True(t, console.ErrValidation.Has(err))
+
+		// admins can restrict members to read-only access
+		require.NoError(t, service.SetMemberRole(adminCtx, project.ID, member.ID, console.RoleReadOnly))
+
+		role, err = service.GetMemberRole(adminCtx, project.ID, member.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.RoleReadOnly, role)
+
+		// read-only members can't modify the project
+		_, _, err = service.CreateAPIKey(memberCtx, project.ID, "test key")
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		err = service.DeleteProjectMembers(memberCtx, project.ID, []string{admin.Email})
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		_, err = service.UpdateProject(memberCtx, project.ID, console.ProjectInfo{Name: "renamed project"})
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		err = service.SetBucketVersioning(memberCtx, project.ID, "testbucket", true)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		// the role is removed together with the membership
+		require.NoError(t, service.DeleteProjectMembers(ownerCtx, project.ID, []string{member.Email}))
+		_, err = service.AddProjectMembers(ownerCtx, project.ID, []string{member.Email})
+		require.NoError(t, err)
+
+		role, err = service.GetMemberRole(ownerCtx, project.ID, member.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.RoleMember, role)
+	})
+}
+
+func TestAddProjectMembersBulk(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		owner, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Owner User",
+			Email:    "owner@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		existing, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Existing Member",
+			Email:    "existing@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		invited, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Invited User",
+			Email:    "invited@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, owner.ID, "test project")
+		require.NoError(t, err)
+
+		ownerCtx, err := sat.UserContext(ctx, owner.ID)
+		require.NoError(t, err)
+		invitedCtx, err := sat.UserContext(ctx, invited.ID)
+		require.NoError(t, err)
+
+		_, err = service.AddProjectMembers(ownerCtx, project.ID, []string{existing.Email})
+		require.NoError(t, err)
+
+		// non-members can't invite anyone
+		_, err = service.AddProjectMembersBulk(invitedCtx, project.ID, []string{invited.Email})
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		// the single add fails the whole batch when any email is unknown
+		_, err = service.AddProjectMembers(ownerCtx, project.ID, []string{invited.Email, "unknown@mail.test"})
+		require.True(t, console.ErrValidation.Has(err))
+
+		emails := []string{invited.Email, "unknown@mail.test", existing.Email, invited.Email}
+		results, err := service.AddProjectMembersBulk(ownerCtx, project.ID, emails)
+		require.NoError(t, err)
+		require.Len(t, results, len(emails))
+
+		for i, email := range emails {
+			require.Equal(t, email, results[i].Email)
+		}
+		require.Equal(t, console.AddProjectMemberSubmitted, results[0].Status)
+
+		// unknown emails can't be told apart from added users
+		require.Equal(t, console.AddProjectMemberSubmitted, results[1].Status)
+
+		require.Equal(t, console.AddProjectMemberAlreadyMember, results[2].Status)
+
+		// duplicates within the batch are added once
+		require.Equal(t, console.AddProjectMemberAlreadyMember, results[3].Status)
+
+		page, err := service.GetProjectMembers(ownerCtx, project.ID, console.ProjectMembersCursor{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.ProjectMembers, 3)
+
+		// the invited user is now a member of the project
+		_, err = service.GetProject(invitedCtx, project.ID)
+		require.NoError(t, err)
+
+		// the number of emails added at once is limited
+		tooMany := make([]string, 101)
+		for i := range tooMany {
+			tooMany[i] = fmt.Sprintf("user%d@mail.test", i)
+		}
+		_, err = service.AddProjectMembersBulk(ownerCtx, project.ID, tooMany)
+		require.True(t, console.ErrValidation.Has(err))
+	})
+}
+
+func TestExportProjectUsageCSV(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		owner, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Project Owner",
+			Email:    "owner@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		other, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, owner.ID, "project")
+		require.NoError(t, err)
+
+		ownerCtx, err := sat.UserContext(ctx, owner.ID)
+		require.NoError(t, err)
+		otherCtx, err := sat.UserContext(ctx, other.ID)
+		require.NoError(t, err)
+
+		since := time.Now().UTC().Truncate(24 * time.Hour).Add(-3 * 24 * time.Hour)
+		before := since.Add(3 * 24 * time.Hour)
+
+		// egress in the middle of the second day
+		err = sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("bucket"), pb.PieceAction_GET, 1000, 0, since.Add(36*time.Hour))
+		require.NoError(t, err)
+
+		_, err = service.ExportProjectUsageCSV(otherCtx, project.ID, since, before, 24*time.Hour)
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		for _, invalid := range []struct {
+			since, before time.Time
+			granularity   time.Duration
+		}{
+			{before, since, 24 * time.Hour},
+			{since, since, 24 * time.Hour},
+			{since, before, time.Minute},
+			{since.Add(-2000 * time.Hour), before, time.Hour},
+		} {
+			_, err = service.ExportProjectUsageCSV(ownerCtx, project.ID, invalid.since, invalid.before, invalid.granularity)
+			require.True(t, console.ErrValidation.Has(err))
+		}
+
+		data, err := service.ExportProjectUsageCSV(ownerCtx, project.ID, since, before, 24*time.Hour)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		require.Equal(t, []string{"Since", "Before", "Storage (byte-hours)", "Egress (bytes)", "Segments (segment-hours)"}, records[0])
+
+		for i, record := range records[1:] {
+			require.Equal(t, since.Add(time.Duration(i)*24*time.Hour).Format(time.RFC3339), record[0])
+			require.Equal(t, since.Add(time.Duration(i+1)*24*time.Hour).Format(time.RFC3339), record[1])
+		}
+		require.Equal(t, "0", records[1][3])
+		require.Equal(t, "1000", records[2][3])
+		require.Equal(t, "0", records[3][3])
+	})
+}
+
+func TestGetProjectActivity(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		owner, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Project Owner",
+			Email:    "owner@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		other, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, owner.ID, "project")
+		require.NoError(t, err)
+
+		ownerCtx, err := sat.UserContext(ctx, owner.ID)
+		require.NoError(t, err)
+		otherCtx, err := sat.UserContext(ctx, other.ID)
+		require.NoError(t, err)
+
+		now := time.Now().UTC().Truncate(time.Hour)
+
+		// uploads and downloads over the last three hours, audit traffic isn't user activity
+		err = sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("alpha"), pb.PieceAction_PUT, 100, 0, now.Add(-3*time.Hour))
+		require.NoError(t, err)
+		err = sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("alpha"), pb.PieceAction_GET, 200, 0, now.Add(-2*time.Hour))
+		require.NoError(t, err)
+		err = sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("beta"), pb.PieceAction_PUT, 300, 0, now.Add(-time.Hour))
+		require.NoError(t, err)
+		err = sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("beta"), pb.PieceAction_GET_AUDIT, 400, 0, now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		_, err = service.GetProjectActivity(otherCtx, project.ID, accounting.ProjectActivityCursor{Limit: 2, Page: 1})
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		page, err := service.GetProjectActivity(ownerCtx, project.ID, accounting.ProjectActivityCursor{Limit: 2, Page: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, page.TotalCount)
+		require.EqualValues(t, 2, page.PageCount)
+		require.EqualValues(t, 1, page.CurrentPage)
+		require.Len(t, page.Activities, 2)
+
+		require.Equal(t, "beta", page.Activities[0].BucketName)
+		require.Equal(t, pb.PieceAction_PUT, page.Activities[0].Action)
+		require.EqualValues(t, 300, page.Activities[0].Settled)
+		require.Equal(t, now.Add(-time.Hour), page.Activities[0].IntervalStart.UTC())
+
+		require.Equal(t, "alpha", page.Activities[1].BucketName)
+		require.Equal(t, pb.PieceAction_GET, page.Activities[1].Action)
+		require.EqualValues(t, 200, page.Activities[1].Settled)
+
+		page, err = service.GetProjectActivity(ownerCtx, project.ID, accounting.ProjectActivityCursor{Limit: 2, Page: 2})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, page.CurrentPage)
+		require.Len(t, page.Activities, 1)
+		require.Equal(t, "alpha", page.Activities[0].BucketName)
+		require.Equal(t, pb.PieceAction_PUT, page.Activities[0].Action)
+		require.Equal(t, now.Add(-3*time.Hour), page.Activities[0].IntervalStart.UTC())
+
+		_, err = service.GetProjectActivity(ownerCtx, project.ID, accounting.ProjectActivityCursor{Limit: 2, Page: 3})
+		require.Error(t, err)
+	})
+}
+
+func TestListExpiringAPIKeys(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		owner, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Project Owner",
+			Email:    "owner@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		other, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, owner.ID, "project")
+		require.NoError(t, err)
+
+		ownerCtx, err := sat.UserContext(ctx, owner.ID)
+		require.NoError(t, err)
+		otherCtx, err := sat.UserContext(ctx, other.ID)
+		require.NoError(t, err)
+
+		now := time.Now()
+
+		_, _, err = service.CreateAPIKeyWithExpiration(ownerCtx, project.ID, "expired", now.Add(-time.Hour))
+		require.True(t, console.ErrValidation.Has(err))
+
+		soon, key, err := service.CreateAPIKeyWithExpiration(ownerCtx, project.ID, "soon", now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, soon.ExpiresAt)
+
+		// the expiration is enforced by the caveat of the key.
+		err = key.Check(ctx, soon.Secret, macaroon.Action{Op: macaroon.ActionRead, Time: now}, nil)
+		require.NoError(t, err)
+		err = key.Check(ctx, soon.Secret, macaroon.Action{Op: macaroon.ActionRead, Time: now.Add(2 * time.Hour)}, nil)
+		require.True(t, macaroon.ErrUnauthorized.Has(err))
+
+		tomorrow, _, err := service.CreateAPIKeyWithExpiration(ownerCtx, project.ID, "tomorrow", now.Add(20*time.Hour))
+		require.NoError(t, err)
+
+		_, _, err = service.CreateAPIKeyWithExpiration(ownerCtx, project.ID, "next month", now.Add(30*24*time.Hour))
+		require.NoError(t, err)
+
+		_, _, err = service.CreateAPIKey(ownerCtx, project.ID, "forever")
+		require.NoError(t, err)
+
+		_, err = service.ListExpiringAPIKeys(otherCtx, project.ID, 24*time.Hour)
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		_, err = service.ListExpiringAPIKeys(ownerCtx, project.ID, 0)
+		require.True(t, console.ErrValidation.Has(err))
+
+		expiring, err := service.ListExpiringAPIKeys(ownerCtx, project.ID, 24*time.Hour)
+		require.NoError(t, err)
+		require.Len(t, expiring, 2)
+		require.Equal(t, soon.ID, expiring[0].ID)
+		require.Equal(t, tomorrow.ID, expiring[1].ID)
+		require.WithinDuration(t, *soon.ExpiresAt, *expiring[0].ExpiresAt, time.Second)
+
+		expiring, err = service.ListExpiringAPIKeys(ownerCtx, project.ID, 30*time.Minute)
+		require.NoError(t, err)
+		require.Empty(t, expiring)
+	})
+}
+
+func TestRenameAPIKey(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		owner, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Project Owner",
+			Email:    "owner@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		other, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, owner.ID, "project")
+		require.NoError(t, err)
+
+		ownerCtx, err := sat.UserContext(ctx, owner.ID)
+		require.NoError(t, err)
+		otherCtx, err := sat.UserContext(ctx, other.ID)
+		require.NoError(t, err)
+
+		info, key, err := service.CreateAPIKey(ownerCtx, project.ID, "old name")
+		require.NoError(t, err)
+		_, _, err = service.CreateAPIKey(ownerCtx, project.ID, "taken")
+		require.NoError(t, err)
+
+		t.Run("success", func(t *testing.T) {
+			renamed, err := service.RenameAPIKey(ownerCtx, info.ID, "new name")
+			require.NoError(t, err)
+			require.Equal(t, "new name", renamed.Name)
+
+			stored, err := sat.DB.Console().APIKeys().Get(ctx, info.ID)
+			require.NoError(t, err)
+			require.Equal(t, "new name", stored.Name)
+
+			// renaming doesn't rotate the secret.
+			require.Equal(t, info.Secret, stored.Secret)
+			byHead, err := sat.DB.Console().APIKeys().GetByHead(ctx, key.Head())
+			require.NoError(t, err)
+			require.Equal(t, info.ID, byHead.ID)
+		})
+
+		t.Run("duplicate name", func(t *testing.T) {
+			_, err := service.RenameAPIKey(ownerCtx, info.ID, "taken")
+			require.True(t, console.ErrAPIKeyNameExists.Has(err))
+
+			_, httpErr := service.GenRenameAPIKey(ownerCtx, info.ID, console.RenameAPIKeyRequest{Name: "taken"})
+			require.Equal(t, http.StatusConflict, httpErr.Status)
+
+			stored, err := sat.DB.Console().APIKeys().Get(ctx, info.ID)
+			require.NoError(t, err)
+			require.Equal(t, "new name", stored.Name)
+		})
+
+		t.Run("not found", func(t *testing.T) {
+			_, err := service.RenameAPIKey(ownerCtx, testrand.UUID(), "missing")
+			require.True(t, console.ErrNoAPIKey.Has(err))
+
+			_, httpErr := service.GenRenameAPIKey(ownerCtx, testrand.UUID(), console.RenameAPIKeyRequest{Name: "missing"})
+			require.Equal(t, http.StatusNotFound, httpErr.Status)
+		})
+
+		t.Run("not a member", func(t *testing.T) {
+			_, err := service.RenameAPIKey(otherCtx, info.ID, "other name")
+			require.True(t, console.ErrNoMembership.Has(err))
+		})
+	})
+}
+
+func TestGetCreditBalance(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		_, err = service.Payments().GetCreditBalance(ctx)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		customerID, err := sat.API.DB.StripeCoinPayments().Customers().GetCustomerID(ctx, user.ID)
+		require.NoError(t, err)
+		customer, err := sat.API.Payments.StripeClient.Customers().Get(customerID, nil)
+		require.NoError(t, err)
+
+		expiresAt := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+		// negative customer balance is a credit.
+		customer.Balance = -1500
+		customer.Discount = &stripe.Discount{
+			Coupon: &stripe.Coupon{
+				AmountOff: 500,
+			},
+			End: expiresAt.Unix(),
+		}
+
+		balance, err := service.Payments().GetCreditBalance(userCtx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1500, balance.Credits)
+		require.EqualValues(t, 500, balance.CouponAmountOff)
+		require.NotNil(t, balance.ExpiresAt)
+		require.True(t, expiresAt.Equal(*balance.ExpiresAt))
+
+		// a coupon without an end doesn't expire.
+		customer.Discount.End = 0
+
+		balance, err = service.Payments().GetCreditBalance(userCtx)
+		require.NoError(t, err)
+		require.Nil(t, balance.ExpiresAt)
+	})
+}
+
+func TestCreateShareLink(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 2,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.ShareLinks.BaseURL = "https://link.test/s"
+				config.Console.ShareLinks.MaxExpiration = 24 * time.Hour
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+		uplink := planet.Uplinks[0]
+		project := uplink.Projects[0]
+
+		require.NoError(t, uplink.CreateBucket(ctx, sat, "testbucket"))
+
+		userCtx, err := sat.UserContext(ctx, project.Owner.ID)
+		require.NoError(t, err)
+
+		otherCtx, err := sat.UserContext(ctx, planet.Uplinks[1].Projects[0].Owner.ID)
+		require.NoError(t, err)
+
+		now := time.Now()
+		expiresAt := now.Add(time.Hour)
+		readOnly := console.SharePermissions{Download: true}
+
+		// keys are encrypted by the client, the test uses the plain keys.
+		encode := func(key string) string {
+			return base64.StdEncoding.EncodeToString([]byte(key))
+		}
+
+		_, err = service.CreateShareLink(otherCtx, project.ID, "testbucket", encode("shared/file"), expiresAt, readOnly)
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		_, err = service.CreateShareLink(userCtx, project.ID, "testbucket", encode("shared/file"), now.Add(48*time.Hour), readOnly)
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.CreateShareLink(userCtx, project.ID, "testbucket", encode("shared/file"), now.Add(-time.Hour), readOnly)
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.CreateShareLink(userCtx, project.ID, "missing", encode("shared/file"), expiresAt, readOnly)
+		require.True(t, storj.ErrBucketNotFound.Has(err))
+
+		_, err = service.CreateShareLink(userCtx, project.ID, "testbucket", encode(""), expiresAt, readOnly)
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.CreateShareLink(userCtx, project.ID, "testbucket", "not base64!", expiresAt, readOnly)
+		require.True(t, console.ErrValidation.Has(err))
+
+		// only prefix links can allow listing
+		_, err = service.CreateShareLink(userCtx, project.ID, "testbucket", encode("shared/file"), expiresAt, console.SharePermissions{List: true})
+		require.True(t, console.ErrValidation.Has(err))
+
+		// read-only members can't create links
+		viewer, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Viewer User",
+			Email:    "viewer@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		viewerCtx, err := sat.UserContext(ctx, viewer.ID)
+		require.NoError(t, err)
+
+		_, err = service.AddProjectMembers(userCtx, project.ID, []string{viewer.Email})
+		require.NoError(t, err)
+		require.NoError(t, service.SetMemberRole(userCtx, project.ID, viewer.ID, console.RoleReadOnly))
+
+		_, err = service.CreateShareLink(viewerCtx, project.ID, "testbucket", encode("shared/file"), expiresAt, readOnly)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		link, err := service.CreateShareLink(userCtx, project.ID, "testbucket", encode("shared/file"), expiresAt, readOnly)
+		require.NoError(t, err)
+		require.Equal(t, "https://link.test/s", link.BaseURL)
+		require.WithinDuration(t, expiresAt, link.ExpiresAt, time.Second)
+
+		apiKey, err := macaroon.ParseAPIKey(link.APIKey)
+		require.NoError(t, err)
+
+		keyInfo, err := sat.DB.Console().APIKeys().GetByHead(ctx, apiKey.Head())
+		require.NoError(t, err)
+		require.Equal(t, project.ID, keyInfo.ProjectID)
+
+		check := func(op macaroon.ActionType, key string, at time.Time) error {
+			return apiKey.Check(ctx, keyInfo.Secret, macaroon.Action{
+				Op:            op,
+				Bucket:        []byte("testbucket"),
+				EncryptedPath: []byte(key),
+				Time:          at,
+			}, nil)
+		}
+
+		// the link only allows downloading the shared object.
+		require.NoError(t, check(macaroon.ActionRead, "shared/file", now))
+		require.Error(t, check(macaroon.ActionWrite, "shared/file", now))
+		require.Error(t, check(macaroon.ActionDelete, "shared/file", now))
+		require.Error(t, check(macaroon.ActionList, "shared/file", now))
+		require.Error(t, check(macaroon.ActionRead, "other/file", now))
+
+		// the link expires at the set time.
+		require.NoError(t, check(macaroon.ActionRead, "shared/file", link.ExpiresAt.Add(-time.Second)))
+		require.Error(t, check(macaroon.ActionRead, "shared/file", link.ExpiresAt.Add(time.Second)))
+
+		// prefix links don't match keys which only share the prefix bytes.
+		prefixLink, err := service.CreateShareLink(userCtx, project.ID, "testbucket", encode("shared/"), expiresAt, console.SharePermissions{Download: true, List: true})
+		require.NoError(t, err)
+
+		prefixKey, err := macaroon.ParseAPIKey(prefixLink.APIKey)
+		require.NoError(t, err)
+		prefixInfo, err := sat.DB.Console().APIKeys().GetByHead(ctx, prefixKey.Head())
+		require.NoError(t, err)
+
+		checkPrefix := func(op macaroon.ActionType, key string) error {
+			return prefixKey.Check(ctx, prefixInfo.Secret, macaroon.Action{
+				Op:            op,
+				Bucket:        []byte("testbucket"),
+				EncryptedPath: []byte(key),
+				Time:          now,
+			}, nil)
+		}
+		require.NoError(t, checkPrefix(macaroon.ActionList, "shared/"))
+		require.NoError(t, checkPrefix(macaroon.ActionRead, "shared/file"))
+		require.Error(t, checkPrefix(macaroon.ActionRead, "shared-other/file"))
+	})
+}
+
+func TestBucketVersioning(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 2,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+		uplink := planet.Uplinks[0]
+		project := uplink.Projects[0]
+
+		require.NoError(t, uplink.CreateBucket(ctx, sat, "testbucket"))
+
+		userCtx, err := sat.UserContext(ctx, project.Owner.ID)
+		require.NoError(t, err)
+
+		otherCtx, err := sat.UserContext(ctx, planet.Uplinks[1].Projects[0].Owner.ID)
+		require.NoError(t, err)
+
+		// only project members can view or toggle versioning
+		_, err = service.GetBucketVersioning(otherCtx, project.ID, "testbucket")
+		require.True(t, console.ErrNoMembership.Has(err))
+		err = service.SetBucketVersioning(otherCtx, project.ID, "testbucket", true)
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		err = service.SetBucketVersioning(userCtx, project.ID, "missing", true)
+		require.True(t, storj.ErrBucketNotFound.Has(err))
+
+		enabled, err := service.GetBucketVersioning(userCtx, project.ID, "testbucket")
+		require.NoError(t, err)
+		require.False(t, enabled)
+
+		uploadAndCheck := func(expectedVersions int) {
+			data := testrand.Bytes(memory.KiB)
+			require.NoError(t, uplink.Upload(ctx, sat, "testbucket", "object", data))
+
+			downloaded, err := uplink.Download(ctx, sat, "testbucket", "object")
+			require.NoError(t, err)
+			require.Equal(t, data, downloaded)
+
+			objects, err := sat.Metabase.DB.TestingAllCommittedObjects(ctx, project.ID, "testbucket")
+			require.NoError(t, err)
+			require.Len(t, objects, expectedVersions)
+		}
+
+		// writes overwrite the object without versioning
+		uploadAndCheck(1)
+		uploadAndCheck(1)
+
+		require.NoError(t, service.SetBucketVersioning(userCtx, project.ID, "testbucket", true))
+		enabled, err = service.GetBucketVersioning(userCtx, project.ID, "testbucket")
+		require.NoError(t, err)
+		require.True(t, enabled)
+
+		// writes create new versions with versioning enabled
+		uploadAndCheck(2)
+		uploadAndCheck(3)
+
+		require.NoError(t, service.SetBucketVersioning(userCtx, project.ID, "testbucket", false))
+		enabled, err = service.GetBucketVersioning(userCtx, project.ID, "testbucket")
+		require.NoError(t, err)
+		require.False(t, enabled)
+
+		// writes overwrite the latest version again, existing versions are kept
+		uploadAndCheck(3)
+	})
+}
+
+func TestProjectLimitErrorAnalytics(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		client := &fakeSegmentClient{}
+		sat.API.Analytics.Service.TestingSetSegmentClient(client)
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Project Limit",
+			Email:    "projectlimit@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		limitErrors := func() (events []segment.Track) {
+			for _, message := range client.messages {
+				if track, ok := message.(segment.Track); ok && strings.HasSuffix(track.Event, "Project Limit Error") {
+					events = append(events, track)
+				}
+			}
+			return events
+		}
+
+		_, err = service.CreateProject(userCtx, console.ProjectInfo{Name: "first"})
+		require.NoError(t, err)
+		require.Empty(t, limitErrors())
+
+		for i := 1; i <= 2; i++ {
+			_, err = service.CreateProject(userCtx, console.ProjectInfo{Name: fmt.Sprintf("blocked-%d", i)})
+			require.True(t, console.ErrProjLimit.Has(err))
+
+			events := limitErrors()
+			require.Len(t, events, i)
+			require.Equal(t, user.ID.String(), events[i-1].UserId)
+			require.Equal(t, 1, events[i-1].Properties["limit"])
+		}
+	})
+}
+
+// fakeSegmentClient records the messages enqueued by the analytics service.
+type fakeSegmentClient struct {
+	messages []segment.Message
+}
+
+func (client *fakeSegmentClient) Enqueue(message segment.Message) error {
+	client.messages = append(client.messages, message)
+	return nil
+}
+
+func (client *fakeSegmentClient) Close() error { return nil }
@@ -0,0 +1,152 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/uuid"
+)
+
+// ShareLinkConfig contains configurations for object share links.
+type ShareLinkConfig struct {
+	BaseURL       string        `help:"base url of object share links, the client appends the access grant, the bucket and the object key to it" default:""`
+	MaxExpiration time.Duration `help:"maximum duration an object share link can be valid for" default:"720h"`
+}
+
+// SharePermissions are the permissions granted by a share link. Share links
+// never allow writing or deleting objects.
+type SharePermissions struct {
+	// Download allows downloading the object, or the objects under the prefix.
+	Download bool `json:"download"`
+	// List allows listing the objects under the prefix. Only prefix links,
+	// whose key ends with a slash, can allow listing.
+	List bool `json:"list"`
+}
+
+// ShareLink is a time-limited, read-only link to an object or a prefix.
+//
+// The satellite doesn't know the encryption keys of the project, so the
+// client encrypts the object key to create the link, derives the access
+// grant from APIKey and appends it, together with the bucket and the
+// unencrypted object key, to BaseURL.
+type ShareLink struct {
+	BaseURL   string      `json:"baseURL"`
+	APIKey    string      `json:"apiKey"`
+	KeyInfo   *APIKeyInfo `json:"keyInfo"`
+	ExpiresAt time.Time   `json:"expiresAt"`
+}
+
+// CreateShareLink creates a share link for the object with the key in the
+// bucket, or for the objects under the key when it ends with a slash, which
+// is valid until expiresAt.
+//
+// The key is the encrypted object key, base64 encoded. Object keys are
+// encrypted per path component, so the prefix of an object link doesn't
+// match sibling objects. The link is backed by a new api key of the project
+// restricted to the permissions and the key, so the link is revoked by
+// deleting the api key.
+func (s *Service) CreateShareLink(ctx context.Context, projectID uuid.UUID, bucket, key string, expiresAt time.Time, permissions SharePermissions) (_ *ShareLink, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "create share link",
+		zap.String("projectID", projectID.String()),
+		zap.String("bucket", bucket),
+		zap.Time("expiresAt", expiresAt))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err = s.checkMemberRole(ctx, isMember, RoleOwner, RoleAdmin, RoleMember); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, ErrValidation.New("key must be base64 encoded")
+	}
+	prefix := bytes.HasSuffix(encryptedKey, []byte("/"))
+
+	now := time.Now()
+	switch {
+	case bucket == "":
+		return nil, ErrValidation.New("bucket can't be empty")
+	case len(encryptedKey) == 0 || string(encryptedKey) == "/":
+		return nil, ErrValidation.New("key can't be empty")
+	case permissions.List && !prefix:
+		return nil, ErrValidation.New("only links to a prefix ending with a slash can allow listing")
+	case !permissions.Download && !permissions.List:
+		return nil, ErrValidation.New("share link must allow downloading or listing")
+	case !expiresAt.After(now):
+		return nil, ErrValidation.New("share link expiration must be in the future")
+	case expiresAt.Sub(now) > s.config.ShareLinks.MaxExpiration:
+		return nil, ErrValidation.New("share link can be valid for at most %s", s.config.ShareLinks.MaxExpiration)
+	}
+
+	if _, err := s.buckets.GetBucket(ctx, []byte(bucket), projectID); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	secret, err := macaroon.NewSecret()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	apiKey, err := macaroon.NewAPIKey(secret)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	expiresAt = expiresAt.UTC()
+	restricted, err := apiKey.Restrict(macaroon.WithNonce(macaroon.Caveat{
+		DisallowReads:   !permissions.Download,
+		DisallowLists:   !permissions.List,
+		DisallowWrites:  true,
+		DisallowDeletes: true,
+		AllowedPaths: []*macaroon.Caveat_Path{{
+			Bucket:              []byte(bucket),
+			EncryptedPathPrefix: encryptedKey,
+		}},
+		NotAfter: &expiresAt,
+	}))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	linkID, err := uuid.New()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	info, err := s.store.APIKeys().Create(ctx, apiKey.Head(), APIKeyInfo{
+		Name:      "share link " + linkID.String(),
+		ProjectID: projectID,
+		Secret:    secret,
+		PartnerID: user.PartnerID,
+		UserAgent: user.UserAgent,
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &ShareLink{
+		BaseURL:   strings.TrimSuffix(s.config.ShareLinks.BaseURL, "/"),
+		APIKey:    restricted.Serialize(),
+		KeyInfo:   info,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+)
+
+const (
+	// minUsageCSVGranularity is the smallest supported interval of a usage csv,
+	// usage is rolled up hourly.
+	minUsageCSVGranularity = time.Hour
+	// maxUsageCSVIntervals is the maximum number of rows of a usage csv.
+	maxUsageCSVIntervals = 1000
+)
+
+// usageCSVHeader is the header of the project usage csv.
+var usageCSVHeader = []string{
+	"Since", "Before", "Storage (byte-hours)", "Egress (bytes)", "Segments (segment-hours)",
+}
+
+// ExportProjectUsageCSV returns project usage between since and before as csv
+// with one row for every interval of the specified granularity.
+func (s *Service) ExportProjectUsageCSV(ctx context.Context, projectID uuid.UUID, since, before time.Time, granularity time.Duration) (_ []byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "export project usage csv", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	_, err = s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	switch {
+	case !since.Before(before):
+		return nil, ErrValidation.New("the start of the range must be before its end")
+	case granularity < minUsageCSVGranularity:
+		return nil, ErrValidation.New("granularity must be at least %s", minUsageCSVGranularity)
+	case (before.Sub(since)+granularity-1)/granularity > maxUsageCSVIntervals:
+		return nil, ErrValidation.New("the range may contain at most %d intervals", maxUsageCSVIntervals)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(usageCSVHeader); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	usages, err := s.projectAccounting.GetProjectTotalByInterval(ctx, projectID, since, before, granularity)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	for i, usage := range usages {
+		// usage.Since is truncated to the hour, the rows show the requested interval.
+		from := since.Add(time.Duration(i) * granularity)
+
+		err = w.Write([]string{
+			from.UTC().Format(time.RFC3339),
+			usage.Before.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(usage.Storage, 'f', -1, 64),
+			strconv.FormatInt(usage.Egress, 10),
+			strconv.FormatFloat(usage.SegmentCount, 'f', -1, 64),
+		})
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return buf.Bytes(), nil
+}
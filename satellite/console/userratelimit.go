@@ -0,0 +1,76 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"storj.io/common/uuid"
+)
+
+// userRateLimiter limits the number of requests each user can make per minute.
+//
+// A limiter is only dropped after it hasn't been used for long enough to
+// refill completely, at which point it's indistinguishable from a new one.
+// Dropping limiters therefore never resets the limit of an active user, and
+// the memory used is bounded by the number of users active within that
+// period.
+type userRateLimiter struct {
+	limit rate.Limit
+	burst int
+	// refill is how long it takes for an unused limiter to refill completely.
+	refill time.Duration
+
+	nowFn func() time.Time
+
+	mu          sync.Mutex
+	limiters    map[uuid.UUID]*userLimiter
+	lastCleanup time.Time
+}
+
+// userLimiter is the limiter of a single user.
+type userLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// newUserRateLimiter returns a limiter allowing requestsPerMinute requests
+// per minute with bursts of the same size.
+func newUserRateLimiter(requestsPerMinute int) *userRateLimiter {
+	return &userRateLimiter{
+		limit:    rate.Limit(requestsPerMinute) / 60,
+		burst:    requestsPerMinute,
+		refill:   time.Minute,
+		nowFn:    time.Now,
+		limiters: make(map[uuid.UUID]*userLimiter),
+	}
+}
+
+// Allow reports whether the user is allowed to make another request.
+func (rl *userRateLimiter) Allow(userID uuid.UUID) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.nowFn()
+	if now.Sub(rl.lastCleanup) >= rl.refill {
+		for id, user := range rl.limiters {
+			if now.Sub(user.lastSeen) >= rl.refill {
+				delete(rl.limiters, id)
+			}
+		}
+		rl.lastCleanup = now
+	}
+
+	user, ok := rl.limiters[userID]
+	if !ok {
+		user = &userLimiter{limiter: rate.NewLimiter(rl.limit, rl.burst)}
+		rl.limiters[userID] = user
+	}
+	user.lastSeen = now
+
+	return user.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+)
+
+func TestUserRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newUserRateLimiter(60)
+	limiter.nowFn = func() time.Time { return now }
+
+	user, other := testrand.UUID(), testrand.UUID()
+
+	for i := 0; i < 60; i++ {
+		require.True(t, limiter.Allow(user))
+	}
+	require.False(t, limiter.Allow(user))
+	require.True(t, limiter.Allow(other))
+
+	// a throttled user keeps its limiter, regardless of when it was created.
+	now = now.Add(59 * time.Second)
+	for i := 0; i < 59; i++ {
+		require.True(t, limiter.Allow(user))
+	}
+	require.False(t, limiter.Allow(user))
+
+	// limiters are only dropped after they have refilled completely.
+	now = now.Add(time.Minute)
+	require.True(t, limiter.Allow(user))
+	require.Len(t, limiter.limiters, 1)
+}
@@ -28,6 +28,9 @@ type Users interface {
 	GetByEmailWithUnverified(ctx context.Context, email string) (*User, []User, error)
 	// GetByEmail is a method for querying user by verified email from the database.
 	GetByEmail(ctx context.Context, email string) (*User, error)
+	// GetIDsByEmails is a method for querying the IDs of verified users by emails from the database.
+	// The IDs are keyed by the emails as given, emails which don't belong to a user are left out.
+	GetIDsByEmails(ctx context.Context, emails []string) (map[string]uuid.UUID, error)
 	// Insert is a method for inserting user into the database.
 	Insert(ctx context.Context, user *User) (*User, error)
 	// Delete is a method for deleting user by Id from the database.
@@ -131,6 +134,8 @@ const (
 	Active UserStatus = 1
 	// Deleted is a user status that he receives after deleting account.
 	Deleted UserStatus = 2
+	// Suspended is a user status that he receives when his account is suspended.
+	Suspended UserStatus = 3
 )
 
 // User is a database object that describes User entity.
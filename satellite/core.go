@@ -33,6 +33,7 @@ import (
 	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/console/dataerasure"
 	"storj.io/storj/satellite/console/emailreminders"
 	"storj.io/storj/satellite/gracefulexit"
 	"storj.io/storj/satellite/mailservice"
@@ -102,7 +103,8 @@ type Core struct {
 	}
 
 	Repair struct {
-		Checker *checker.Checker
+		Checker     *checker.Checker
+		HealthChore *checker.HealthChore
 	}
 
 	Audit struct {
@@ -121,6 +123,10 @@ type Core struct {
 		Chore *zombiedeletion.Chore
 	}
 
+	DataErasure struct {
+		Chore *dataerasure.Chore
+	}
+
 	Accounting struct {
 		Tally                 *tally.Service
 		NodeTally             *nodetally.Service
@@ -333,6 +339,21 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		})
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Repair Checker", peer.Repair.Checker.Loop))
+
+		peer.Repair.HealthChore = checker.NewHealthChore(
+			peer.Log.Named("repair:health-chore"),
+			peer.Metainfo.Metabase,
+			peer.Overlay.Service,
+			config.Checker)
+		if config.Checker.HealthInterval > 0 {
+			peer.Services.Add(lifecycle.Item{
+				Name:  "repair:health-chore",
+				Run:   peer.Repair.HealthChore.Run,
+				Close: peer.Repair.HealthChore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Repair Segment Health", peer.Repair.HealthChore.Loop))
+		}
 	}
 
 	{ // setup reputation
@@ -439,6 +460,23 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			debug.Cycle("Zombie Objects Chore", peer.ZombieDeletion.Chore.Loop))
 	}
 
+	{ // setup data erasure
+		peer.DataErasure.Chore = dataerasure.NewChore(
+			peer.Log.Named("core-data-erasure"),
+			config.DataErasure,
+			peer.DB.Console(),
+			peer.DB.Buckets(),
+			peer.Metainfo.Metabase,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "dataerasure:chore",
+			Run:   peer.DataErasure.Chore.Run,
+			Close: peer.DataErasure.Chore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Data Erasure Chore", peer.DataErasure.Chore.Loop))
+	}
+
 	{ // setup accounting
 		peer.Accounting.Tally = tally.New(peer.Log.Named("accounting:tally"), peer.DB.StoragenodeAccounting(), peer.DB.ProjectAccounting(), peer.LiveAccounting.Cache, peer.Metainfo.Metabase, config.Tally)
 		peer.Services.Add(lifecycle.Item{
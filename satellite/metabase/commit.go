@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	pgxerrcode "github.com/jackc/pgerrcode"
@@ -43,6 +44,13 @@ type BeginObjectNextVersion struct {
 	EncryptedMetadataEncryptedKey []byte // optional
 
 	Encryption storj.EncryptionParameters
+
+	// FailIfCommittedExists fails the request with ErrObjectAlreadyExists
+	// when any committed version of the object key exists.
+	FailIfCommittedExists bool
+
+	// WriteOnce prevents the object from being overwritten once it's committed.
+	WriteOnce bool
 }
 
 // Verify verifies get object request fields.
@@ -76,13 +84,16 @@ func (db *DB) BeginObjectNextVersion(ctx context.Context, opts BeginObjectNextVe
 		opts.ZombieDeletionDeadline = &deadline
 	}
 
+	// the check for committed versions is part of the insert, so it's
+	// done in the same transaction which allocates the next version.
 	row := db.db.QueryRowContext(ctx, `
 		INSERT INTO objects (
 			project_id, bucket_name, object_key, version, stream_id,
 			expires_at, encryption,
 			zombie_deletion_deadline,
-			encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key
-		) VALUES (
+			encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+			write_once
+		) SELECT
 			$1, $2, $3,
 				coalesce((
 					SELECT version + 1
@@ -93,16 +104,30 @@ func (db *DB) BeginObjectNextVersion(ctx context.Context, opts BeginObjectNextVe
 				), 1),
 			$4, $5, $6,
 			$7,
-			$8, $9, $10)
+			$8, $9, $10,
+			$12
+		WHERE NOT $11::BOOL OR NOT EXISTS (
+			SELECT 1
+			FROM objects
+			WHERE
+				project_id  = $1 AND
+				bucket_name = $2 AND
+				object_key  = $3 AND
+				status      = `+committedStatus+`
+		)
 		RETURNING version
 	`, opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.StreamID,
 		opts.ExpiresAt, encryptionParameters{&opts.Encryption},
 		opts.ZombieDeletionDeadline,
 		opts.EncryptedMetadata, opts.EncryptedMetadataNonce, opts.EncryptedMetadataEncryptedKey,
+		opts.FailIfCommittedExists, opts.WriteOnce,
 	)
 
 	var v int64
 	if err := row.Scan(&v); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, Error.Wrap(ErrObjectAlreadyExists.New(""))
+		}
 		return -1, Error.New("unable to insert object: %w", err)
 	}
 
@@ -123,6 +148,9 @@ type BeginObjectExactVersion struct {
 	EncryptedMetadataEncryptedKey []byte // optional
 
 	Encryption storj.EncryptionParameters
+
+	// WriteOnce prevents the object from being overwritten once it's committed.
+	WriteOnce bool
 }
 
 // Verify verifies get object reqest fields.
@@ -167,6 +195,7 @@ func (db *DB) BeginObjectExactVersion(ctx context.Context, opts BeginObjectExact
 		ExpiresAt:              opts.ExpiresAt,
 		Encryption:             opts.Encryption,
 		ZombieDeletionDeadline: opts.ZombieDeletionDeadline,
+		WriteOnce:              opts.WriteOnce,
 	}
 
 	err = db.db.QueryRowContext(ctx, `
@@ -174,24 +203,30 @@ func (db *DB) BeginObjectExactVersion(ctx context.Context, opts BeginObjectExact
 			project_id, bucket_name, object_key, version, stream_id,
 			expires_at, encryption,
 			zombie_deletion_deadline,
-			encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key
+			encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+			write_once
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7,
 			$8,
-			$9, $10, $11
+			$9, $10, $11,
+			$12
 		)
 		RETURNING status, created_at
 	`, opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version, opts.StreamID,
 		opts.ExpiresAt, encryptionParameters{&opts.Encryption},
 		opts.ZombieDeletionDeadline,
 		opts.EncryptedMetadata, opts.EncryptedMetadataNonce, opts.EncryptedMetadataEncryptedKey,
+		opts.WriteOnce,
 	).
 		Scan(
 			&object.Status, &object.CreatedAt,
 		)
 	if err != nil {
 		if code := pgerrcode.FromError(err); code == pgxerrcode.UniqueViolation {
+			if err := db.checkWriteOnce(ctx, opts.Location(), opts.Version); err != nil {
+				return Object{}, err
+			}
 			return Object{}, Error.Wrap(ErrObjectAlreadyExists.New(""))
 		}
 		return Object{}, Error.New("unable to insert object: %w", err)
@@ -202,6 +237,36 @@ func (db *DB) BeginObjectExactVersion(ctx context.Context, opts BeginObjectExact
 	return object, nil
 }
 
+// checkWriteOnce returns ErrObjectWriteOnce when there is a committed
+// write-once object at the specified version.
+func (db *DB) checkWriteOnce(ctx context.Context, location ObjectLocation, version Version) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var writeOnce bool
+	err = db.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM objects
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = $3 AND
+				version      = $4 AND
+				status       = `+committedStatus+` AND
+				write_once
+		)`,
+		location.ProjectID, []byte(location.BucketName), location.ObjectKey, version).
+		Scan(&writeOnce)
+	if err != nil {
+		return Error.New("unable to query object: %w", err)
+	}
+	if writeOnce {
+		return Error.Wrap(ErrObjectWriteOnce.New(""))
+	}
+
+	return nil
+}
+
 // BeginSegment contains options to verify, whether a new segment upload can be started.
 type BeginSegment struct {
 	ObjectStream
@@ -214,10 +279,8 @@ type BeginSegment struct {
 	Pieces Pieces
 }
 
-// BeginSegment verifies, whether a new segment upload can be started.
-func (db *DB) BeginSegment(ctx context.Context, opts BeginSegment) (err error) {
-	defer mon.Task()(&ctx)(&err)
-
+// Verify verifies request fields.
+func (opts *BeginSegment) Verify() error {
 	if err := opts.ObjectStream.Verify(); err != nil {
 		return err
 	}
@@ -229,11 +292,81 @@ func (db *DB) BeginSegment(ctx context.Context, opts BeginSegment) (err error) {
 	if opts.RootPieceID.IsZero() {
 		return ErrInvalidRequest.New("RootPieceID missing")
 	}
+	return nil
+}
+
+// BeginSegment verifies, whether a new segment upload can be started.
+func (db *DB) BeginSegment(ctx context.Context, opts BeginSegment) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
 
 	// NOTE: this isn't strictly necessary, since we can also fail this in CommitSegment.
 	//       however, we should prevent creating segements for non-partial objects.
+	if err := db.verifyPendingObject(ctx, opts.ObjectStream); err != nil {
+		return err
+	}
+
+	mon.Meter("segment_begin").Mark(1)
+
+	return nil
+}
+
+// BeginSegments verifies, whether new segment uploads of the same pending object
+// can be started. It returns an error for every segment in the order of opts,
+// a nil error means that the segment upload can be started. Every position may
+// appear only once in a batch, later occurrences of a position are rejected.
+//
+// Errors which affect all segments, e.g. the pending object missing, are
+// returned as err. Beginning segments doesn't write anything, the segments are
+// inserted by CommitSegment, so the whole batch is checked against a single
+// read of the pending object and either all valid segments begin or none.
+func (db *DB) BeginSegments(ctx context.Context, opts []BeginSegment) (results []error, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(opts) == 0 {
+		return nil, ErrInvalidRequest.New("no segments")
+	}
+
+	obj := opts[0].ObjectStream
+	if err := obj.Verify(); err != nil {
+		return nil, err
+	}
+
+	results = make([]error, len(opts))
+	positions := make(map[SegmentPosition]struct{}, len(opts))
+	started := 0
+	for i := range opts {
+		if opts[i].ObjectStream != obj {
+			return nil, ErrInvalidRequest.New("segments belong to different objects")
+		}
+		if _, ok := positions[opts[i].Position]; ok {
+			results[i] = ErrInvalidRequest.New("duplicated segment position %d/%d", opts[i].Position.Part, opts[i].Position.Index)
+			continue
+		}
+		positions[opts[i].Position] = struct{}{}
+
+		results[i] = opts[i].Verify()
+		if results[i] == nil {
+			started++
+		}
+	}
+
+	if err := db.verifyPendingObject(ctx, obj); err != nil {
+		return nil, err
+	}
+
+	mon.Meter("segment_begin").Mark(started)
+
+	return results, nil
+}
+
+// verifyPendingObject verifies that the object exists and is partial.
+func (db *DB) verifyPendingObject(ctx context.Context, obj ObjectStream) (err error) {
+	defer mon.Task()(&ctx)(&err)
 
-	// Verify that object exists and is partial.
 	var value int
 	err = db.db.QueryRowContext(ctx, `
 			SELECT 1
@@ -244,16 +377,13 @@ func (db *DB) BeginSegment(ctx context.Context, opts BeginSegment) (err error) {
 				version      = $4 AND
 				stream_id    = $5 AND
 				status       = `+pendingStatus,
-		opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version, opts.StreamID).Scan(&value)
+		obj.ProjectID, []byte(obj.BucketName), obj.ObjectKey, obj.Version, obj.StreamID).Scan(&value)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrPendingObjectMissing.New("")
 		}
 		return Error.New("unable to query object status: %w", err)
 	}
-
-	mon.Meter("segment_begin").Mark(1)
-
 	return nil
 }
 
@@ -275,6 +405,11 @@ type CommitSegment struct {
 
 	EncryptedETag []byte
 
+	// ExpectedETag makes the commit fail with ErrSegmentETagMismatch when a segment
+	// already exists at the position with a different EncryptedETag. It allows
+	// retrying a commit without overwriting a differently uploaded segment.
+	ExpectedETag []byte
+
 	Redundancy storj.RedundancyScheme
 
 	Pieces Pieces
@@ -321,7 +456,7 @@ func (db *DB) CommitSegment(ctx context.Context, opts CommitSegment) (err error)
 	}
 
 	// Verify that object exists and is partial.
-	_, err = db.db.ExecContext(ctx, `
+	result, err := db.db.ExecContext(ctx, `
 		INSERT INTO segments (
 			stream_id, position, expires_at,
 			root_piece_id, encrypted_key_nonce, encrypted_key,
@@ -353,13 +488,14 @@ func (db *DB) CommitSegment(ctx context.Context, opts CommitSegment) (err error)
 			redundancy = $10,
 			remote_alias_pieces = $11,
 			placement = $17
+		WHERE $18::BYTEA IS NULL OR segments.encrypted_etag = $18
 		`, opts.Position, opts.ExpiresAt,
 		opts.RootPieceID, opts.EncryptedKeyNonce, opts.EncryptedKey,
 		opts.EncryptedSize, opts.PlainOffset, opts.PlainSize, opts.EncryptedETag,
 		redundancyScheme{&opts.Redundancy},
 		aliasPieces,
 		opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version, opts.StreamID,
-		opts.Placement,
+		opts.Placement, opts.ExpectedETag,
 	)
 	if err != nil {
 		if code := pgerrcode.FromError(err); code == pgxerrcode.NotNullViolation {
@@ -368,6 +504,14 @@ func (db *DB) CommitSegment(ctx context.Context, opts CommitSegment) (err error)
 		return Error.New("unable to insert segment: %w", err)
 	}
 
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.New("unable to insert segment: %w", err)
+	}
+	if affected == 0 {
+		return ErrSegmentETagMismatch.New("segment %d/%d", opts.Position.Part, opts.Position.Index)
+	}
+
 	mon.Meter("segment_commit").Mark(1)
 	mon.IntVal("segment_commit_encrypted_size").Observe(int64(opts.EncryptedSize))
 
@@ -474,6 +618,16 @@ type CommitObject struct {
 	EncryptedMetadata             []byte // optional
 	EncryptedMetadataNonce        []byte // optional
 	EncryptedMetadataEncryptedKey []byte // optional
+
+	// AllowGaps allows committing an object whose segment indexes aren't
+	// contiguous within a part.
+	AllowGaps bool
+
+	// MinPartSize and MaxNumberOfParts override the limits of the DB config
+	// for this object, e.g. for projects with custom limits. Zero values
+	// fall back to the DB config.
+	MinPartSize      memory.Size
+	MaxNumberOfParts int
 }
 
 // Verify verifies reqest fields.
@@ -486,6 +640,14 @@ func (c *CommitObject) Verify() error {
 		return ErrInvalidRequest.New("Encryption.BlockSize is negative or zero")
 	}
 
+	if c.MinPartSize < 0 {
+		return ErrInvalidRequest.New("MinPartSize is negative")
+	}
+
+	if c.MaxNumberOfParts < 0 {
+		return ErrInvalidRequest.New("MaxNumberOfParts is negative")
+	}
+
 	if c.OverrideEncryptedMetadata {
 		if c.EncryptedMetadata == nil && (c.EncryptedMetadataNonce != nil || c.EncryptedMetadataEncryptedKey != nil) {
 			return ErrInvalidRequest.New("EncryptedMetadataNonce and EncryptedMetadataEncryptedKey must be not set if EncryptedMetadata is not set")
@@ -510,10 +672,16 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 			return Error.New("failed to fetch segments: %w", err)
 		}
 
-		if err = db.validateParts(segments); err != nil {
+		if err = db.validateParts(segments, opts.MinPartSize, opts.MaxNumberOfParts); err != nil {
 			return err
 		}
 
+		if !opts.AllowGaps {
+			if err = validateContiguousSegments(segments); err != nil {
+				return err
+			}
+		}
+
 		finalSegments := convertToFinalSegments(segments)
 		err = updateSegmentOffsets(ctx, tx, opts.StreamID, finalSegments)
 		if err != nil {
@@ -592,11 +760,11 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 			RETURNING
 				created_at, expires_at,
 				encrypted_metadata, encrypted_metadata_encrypted_key, encrypted_metadata_nonce,
-				encryption;
+				encryption, write_once;
 		`, args...).Scan(
 			&object.CreatedAt, &object.ExpiresAt,
 			&object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey, &object.EncryptedMetadataNonce,
-			encryptionParameters{&object.Encryption},
+			encryptionParameters{&object.Encryption}, &object.WriteOnce,
 		)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -631,7 +799,45 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 	return object, nil
 }
 
-func (db *DB) validateParts(segments []segmentInfoForCommit) error {
+// validateContiguousSegments checks that the segment indexes of every part
+// start at 0 and don't have gaps. The segments must be sorted by position.
+func validateContiguousSegments(segments []segmentInfoForCommit) error {
+	var expected SegmentPosition
+	for _, segment := range segments {
+		if segment.Position.Part != expected.Part {
+			expected = SegmentPosition{Part: segment.Position.Part}
+		}
+		if segment.Position != expected {
+			return ErrInvalidRequest.New("missing segment at part %d, index %d", expected.Part, expected.Index)
+		}
+		expected.Index++
+	}
+	return nil
+}
+
+// ErrPartSize is returned when a part, other than the last one, is smaller
+// than the minimum part size.
+type ErrPartSize struct {
+	PartNumber uint32
+	Got        memory.Size
+	Min        memory.Size
+}
+
+// Error implements the error interface.
+func (err *ErrPartSize) Error() string {
+	return fmt.Sprintf("size of part number %d is below minimum threshold, got: %s, min: %s", err.PartNumber, err.Got, err.Min)
+}
+
+// validateParts validates the number and sizes of the parts. Zero minPartSize
+// and maxNumberOfParts fall back to the DB config.
+func (db *DB) validateParts(segments []segmentInfoForCommit, minPartSize memory.Size, maxNumberOfParts int) error {
+	if minPartSize == 0 {
+		minPartSize = db.config.MinPartSize
+	}
+	if maxNumberOfParts == 0 {
+		maxNumberOfParts = db.config.MaxNumberOfParts
+	}
+
 	partSize := make(map[uint32]memory.Size)
 
 	var lastPart uint32
@@ -642,8 +848,8 @@ func (db *DB) validateParts(segments []segmentInfoForCommit) error {
 		}
 	}
 
-	if len(partSize) > db.config.MaxNumberOfParts {
-		return Error.New("exceeded maximum number of parts: %d", db.config.MaxNumberOfParts)
+	if len(partSize) > maxNumberOfParts {
+		return Error.New("exceeded maximum number of parts: %d", maxNumberOfParts)
 	}
 
 	for part, size := range partSize {
@@ -652,8 +858,8 @@ func (db *DB) validateParts(segments []segmentInfoForCommit) error {
 			continue
 		}
 
-		if size < db.config.MinPartSize {
-			return Error.New("size of part number %d is below minimum threshold, got: %s, min: %s", part, size, db.config.MinPartSize)
+		if size < minPartSize {
+			return Error.Wrap(&ErrPartSize{PartNumber: part, Got: size, Min: minPartSize})
 		}
 	}
 
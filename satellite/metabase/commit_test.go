@@ -4,10 +4,13 @@
 package metabase_test
 
 import (
+	"errors"
 	"math"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
 	"storj.io/common/memory"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
@@ -312,6 +315,87 @@ func TestBeginObjectNextVersion(t *testing.T) {
 			}.Check(ctx, t, db)
 		})
 
+		t.Run("fail if committed exists", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			now1 := time.Now()
+			zombieDeadline := now1.Add(24 * time.Hour)
+			objectStream.Version = metabase.NextVersion
+
+			// only a pending version exists
+			metabasetest.BeginObjectNextVersion{
+				Opts: metabase.BeginObjectNextVersion{
+					ObjectStream:          objectStream,
+					Encryption:            metabasetest.DefaultEncryption,
+					FailIfCommittedExists: true,
+				},
+				Version: 1,
+			}.Check(ctx, t, db)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: metabase.ObjectStream{
+						ProjectID:  obj.ProjectID,
+						BucketName: obj.BucketName,
+						ObjectKey:  obj.ObjectKey,
+						Version:    1,
+						StreamID:   obj.StreamID,
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.BeginObjectNextVersion{
+				Opts: metabase.BeginObjectNextVersion{
+					ObjectStream:          objectStream,
+					Encryption:            metabasetest.DefaultEncryption,
+					FailIfCommittedExists: true,
+				},
+				Version:  -1,
+				ErrClass: &metabase.ErrObjectAlreadyExists,
+			}.Check(ctx, t, db)
+
+			now2 := time.Now()
+			metabasetest.BeginObjectNextVersion{
+				Opts: metabase.BeginObjectNextVersion{
+					ObjectStream: objectStream,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: 2,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					{
+						ObjectStream: metabase.ObjectStream{
+							ProjectID:  obj.ProjectID,
+							BucketName: obj.BucketName,
+							ObjectKey:  obj.ObjectKey,
+							Version:    1,
+							StreamID:   obj.StreamID,
+						},
+						CreatedAt: now1,
+						Status:    metabase.Committed,
+
+						Encryption: metabasetest.DefaultEncryption,
+					},
+					{
+						ObjectStream: metabase.ObjectStream{
+							ProjectID:  obj.ProjectID,
+							BucketName: obj.BucketName,
+							ObjectKey:  obj.ObjectKey,
+							Version:    2,
+							StreamID:   obj.StreamID,
+						},
+						CreatedAt: now2,
+						Status:    metabase.Pending,
+
+						Encryption:             metabasetest.DefaultEncryption,
+						ZombieDeletionDeadline: &zombieDeadline,
+					},
+				},
+			}.Check(ctx, t, db)
+		})
+
 		t.Run("begin object next version with metadata", func(t *testing.T) {
 			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
 
@@ -753,6 +837,81 @@ func TestBeginObjectExactVersion(t *testing.T) {
 				},
 			}.Check(ctx, t, db)
 		})
+
+		t.Run("write-once object cannot be overwritten", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			now := time.Now()
+
+			objectStream.Version = 1
+
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: objectStream,
+					Encryption:   metabasetest.DefaultEncryption,
+					WriteOnce:    true,
+				},
+				Version: 1,
+			}.Check(ctx, t, db)
+
+			object := metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: objectStream,
+				},
+			}.Check(ctx, t, db)
+			require.True(t, object.WriteOnce)
+
+			overwriteStream := objectStream
+			overwriteStream.StreamID = testrand.UUID()
+
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: overwriteStream,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version:  1,
+				ErrClass: &metabase.ErrObjectWriteOnce,
+			}.Check(ctx, t, db)
+
+			sourceStream := metabasetest.RandObjectStream()
+			sourceObject := metabasetest.CreateObject(ctx, t, db, sourceStream, 0)
+
+			metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          sourceStream,
+					NewBucket:             objectStream.BucketName,
+					NewEncryptedObjectKey: objectStream.ObjectKey,
+					NewStreamID:           testrand.UUID(),
+				},
+				ErrClass: &metabase.ErrObjectWriteOnce,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					{
+						ObjectStream: objectStream,
+						CreatedAt:    now,
+						Status:       metabase.Committed,
+
+						Encryption: metabasetest.DefaultEncryption,
+						WriteOnce:  true,
+					},
+					metabase.RawObject(sourceObject),
+				},
+			}.Check(ctx, t, db)
+
+			// write-once only prevents overwrites, the object can still be deleted.
+			result, err := db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+				ObjectLocation: objectStream.Location(),
+				Version:        objectStream.Version,
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Objects, 1)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(sourceObject)},
+			}.Check(ctx, t, db)
+		})
 	})
 }
 
@@ -1006,6 +1165,96 @@ func TestBeginSegment(t *testing.T) {
 				},
 			}.Check(ctx, t, db)
 		})
+
+		t.Run("multiple begin segments in one call", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+			now := time.Now()
+			zombieDeadline := now.Add(24 * time.Hour)
+
+			segment := func(index uint32) metabase.BeginSegment {
+				return metabase.BeginSegment{
+					ObjectStream: obj,
+					Position:     metabase.SegmentPosition{Index: index},
+					RootPieceID:  storj.PieceID{1},
+					Pieces: []metabase.Piece{{
+						Number:      1,
+						StorageNode: testrand.NodeID(),
+					}},
+				}
+			}
+
+			var opts []metabase.BeginSegment
+			for i := 0; i < 5; i++ {
+				opts = append(opts, segment(uint32(i)))
+			}
+
+			metabasetest.BeginSegments{
+				Opts:     opts,
+				ErrClass: &metabase.ErrPendingObjectMissing,
+			}.Check(ctx, t, db)
+
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: 1,
+			}.Check(ctx, t, db)
+
+			metabasetest.BeginSegments{
+				Opts:     nil,
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "no segments",
+			}.Check(ctx, t, db)
+
+			otherObj := obj
+			otherObj.StreamID = testrand.UUID()
+			otherSegment := segment(5)
+			otherSegment.ObjectStream = otherObj
+			metabasetest.BeginSegments{
+				Opts:     append([]metabase.BeginSegment{segment(0)}, otherSegment),
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "segments belong to different objects",
+			}.Check(ctx, t, db)
+
+			metabasetest.BeginSegments{
+				Opts: opts,
+			}.Check(ctx, t, db)
+
+			invalid := []metabase.BeginSegment{segment(0), segment(1), segment(2)}
+			invalid[1].RootPieceID = storj.PieceID{}
+			invalid[2].Pieces = append(invalid[2].Pieces, invalid[2].Pieces[0])
+			metabasetest.BeginSegments{
+				Opts: invalid,
+				Results: []metabasetest.ExpectedError{
+					{},
+					{ErrClass: &metabase.ErrInvalidRequest, ErrText: "RootPieceID missing"},
+					{ErrClass: &metabase.ErrInvalidRequest, ErrText: "duplicated piece number 1"},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.BeginSegments{
+				Opts: []metabase.BeginSegment{segment(0), segment(1), segment(0)},
+				Results: []metabasetest.ExpectedError{
+					{},
+					{},
+					{ErrClass: &metabase.ErrInvalidRequest, ErrText: "duplicated segment position 0/0"},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					{
+						ObjectStream: obj,
+						CreatedAt:    now,
+						Status:       metabase.Pending,
+
+						Encryption:             metabasetest.DefaultEncryption,
+						ZombieDeletionDeadline: &zombieDeadline,
+					},
+				},
+			}.Check(ctx, t, db)
+		})
 	})
 }
 
@@ -1452,6 +1701,105 @@ func TestCommitSegment(t *testing.T) {
 			}.Check(ctx, t, db)
 		})
 
+		t.Run("overwrite with expected etag", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			now1 := time.Now()
+			zombieDeadline := now1.Add(24 * time.Hour)
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: 1,
+			}.Check(ctx, t, db)
+
+			rootPieceID1 := testrand.PieceID()
+			rootPieceID2 := testrand.PieceID()
+			pieces1 := metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}}
+			pieces2 := metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}}
+			encryptedKey := testrand.Bytes(32)
+			encryptedKeyNonce := testrand.Bytes(32)
+			etag1 := testrand.Bytes(32)
+			etag2 := testrand.Bytes(32)
+
+			commit := func(rootPieceID storj.PieceID, pieces metabase.Pieces, etag, expectedETag []byte) metabase.CommitSegment {
+				return metabase.CommitSegment{
+					ObjectStream: obj,
+					Position:     metabase.SegmentPosition{Part: 0, Index: 0},
+					RootPieceID:  rootPieceID,
+					Pieces:       pieces,
+
+					EncryptedKey:      encryptedKey,
+					EncryptedKeyNonce: encryptedKeyNonce,
+
+					EncryptedSize: 1024,
+					PlainSize:     512,
+					PlainOffset:   0,
+					EncryptedETag: etag,
+					ExpectedETag:  expectedETag,
+					Redundancy:    metabasetest.DefaultRedundancy,
+				}
+			}
+
+			segment := func(rootPieceID storj.PieceID, pieces metabase.Pieces, etag []byte) metabase.RawSegment {
+				return metabase.RawSegment{
+					StreamID:  obj.StreamID,
+					Position:  metabase.SegmentPosition{Part: 0, Index: 0},
+					CreatedAt: now,
+
+					RootPieceID:       rootPieceID,
+					EncryptedKey:      encryptedKey,
+					EncryptedKeyNonce: encryptedKeyNonce,
+
+					EncryptedSize: 1024,
+					PlainOffset:   0,
+					PlainSize:     512,
+					EncryptedETag: etag,
+
+					Redundancy: metabasetest.DefaultRedundancy,
+
+					Pieces: pieces,
+				}
+			}
+
+			object := metabase.RawObject{
+				ObjectStream: obj,
+				CreatedAt:    now1,
+				Status:       metabase.Pending,
+
+				Encryption:             metabasetest.DefaultEncryption,
+				ZombieDeletionDeadline: &zombieDeadline,
+			}
+
+			// without a prior segment the expected etag doesn't matter
+			metabasetest.CommitSegment{
+				Opts: commit(rootPieceID1, pieces1, etag1, etag2),
+			}.Check(ctx, t, db)
+
+			// the existing segment has a different etag
+			metabasetest.CommitSegment{
+				Opts:     commit(rootPieceID2, pieces2, etag2, etag2),
+				ErrClass: &metabase.ErrSegmentETagMismatch,
+				ErrText:  "segment 0/0",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects:  []metabase.RawObject{object},
+				Segments: []metabase.RawSegment{segment(rootPieceID1, pieces1, etag1)},
+			}.Check(ctx, t, db)
+
+			// the existing segment has the expected etag
+			metabasetest.CommitSegment{
+				Opts: commit(rootPieceID2, pieces2, etag2, etag1),
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects:  []metabase.RawObject{object},
+				Segments: []metabase.RawSegment{segment(rootPieceID2, pieces2, etag2)},
+			}.Check(ctx, t, db)
+		})
+
 		t.Run("commit segment of missing object", func(t *testing.T) {
 			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
 
@@ -2427,6 +2775,111 @@ func TestCommitObject(t *testing.T) {
 			}.Check(ctx, t, db)
 		})
 
+		t.Run("non-contiguous segment positions", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: 1,
+			}.Check(ctx, t, db)
+			now := time.Now()
+			zombieDeadline := now.Add(24 * time.Hour)
+
+			rootPieceID := testrand.PieceID()
+			pieces := metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}}
+			encryptedKey := testrand.Bytes(32)
+			encryptedKeyNonce := testrand.Bytes(32)
+
+			var segments []metabase.RawSegment
+			for _, position := range []metabase.SegmentPosition{{Index: 0}, {Index: 2}} {
+				metabasetest.CommitSegment{
+					Opts: metabase.CommitSegment{
+						ObjectStream: obj,
+						Position:     position,
+						RootPieceID:  rootPieceID,
+						Pieces:       pieces,
+
+						EncryptedKey:      encryptedKey,
+						EncryptedKeyNonce: encryptedKeyNonce,
+
+						EncryptedSize: 1024,
+						PlainSize:     512,
+
+						Redundancy: metabasetest.DefaultRedundancy,
+					},
+				}.Check(ctx, t, db)
+
+				segments = append(segments, metabase.RawSegment{
+					StreamID:  obj.StreamID,
+					Position:  position,
+					CreatedAt: now,
+
+					RootPieceID:       rootPieceID,
+					EncryptedKey:      encryptedKey,
+					EncryptedKeyNonce: encryptedKeyNonce,
+
+					EncryptedSize: 1024,
+					PlainSize:     512,
+
+					Redundancy: metabasetest.DefaultRedundancy,
+
+					Pieces: pieces,
+				})
+			}
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "missing segment at part 0, index 1",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Segments: segments,
+				Objects: []metabase.RawObject{
+					{
+						ObjectStream: obj,
+						CreatedAt:    now,
+						Status:       metabase.Pending,
+
+						Encryption:             metabasetest.DefaultEncryption,
+						ZombieDeletionDeadline: &zombieDeadline,
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+					AllowGaps:    true,
+				},
+			}.Check(ctx, t, db)
+
+			segments[1].PlainOffset = 512
+
+			metabasetest.Verify{
+				Segments: segments,
+				Objects: []metabase.RawObject{
+					{
+						ObjectStream: obj,
+						CreatedAt:    now,
+						Status:       metabase.Committed,
+
+						SegmentCount:       2,
+						FixedSegmentSize:   -1,
+						TotalPlainSize:     2 * 512,
+						TotalEncryptedSize: 2 * 1024,
+
+						Encryption: metabasetest.DefaultEncryption,
+					},
+				},
+			}.Check(ctx, t, db)
+		})
+
 		t.Run("large object over 2 GB", func(t *testing.T) {
 			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
 
@@ -3051,6 +3504,15 @@ func TestCommitObjectWithIncorrectPartSize(t *testing.T) {
 				ErrText:  "size of part number 2 is below minimum threshold, got: 1.0 MiB, min: 5.0 MiB",
 			}.Check(ctx, t, db)
 
+			_, err := db.CommitObject(ctx, metabase.CommitObject{
+				ObjectStream: obj,
+			})
+			var partSizeErr *metabase.ErrPartSize
+			require.True(t, errors.As(err, &partSizeErr))
+			require.Equal(t, uint32(2), partSizeErr.PartNumber)
+			require.Equal(t, 1*memory.MiB, partSizeErr.Got)
+			require.Equal(t, 5*memory.MiB, partSizeErr.Min)
+
 			metabasetest.Verify{
 				Objects: []metabase.RawObject{
 					{
@@ -3117,6 +3579,154 @@ func TestCommitObjectWithIncorrectPartSize(t *testing.T) {
 	})
 }
 
+func TestCommitObjectPartLimitsOverride(t *testing.T) {
+	metabasetest.RunWithConfig(t, metabase.Config{
+		ApplicationName:  "satellite-test",
+		MinPartSize:      5 * memory.MiB,
+		MaxNumberOfParts: 3,
+	}, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		// createParts begins the object and commits parts with a single segment of the given size.
+		createParts := func(t *testing.T, parts int, size memory.Size) {
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: 1,
+			}.Check(ctx, t, db)
+
+			encryptedKeyNonce := testrand.Nonce()
+			for i := 0; i < parts; i++ {
+				metabasetest.CommitSegment{
+					Opts: metabase.CommitSegment{
+						ObjectStream: obj,
+						Position:     metabase.SegmentPosition{Part: uint32(i), Index: 0},
+						RootPieceID:  testrand.PieceID(),
+						Pieces:       metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}},
+
+						EncryptedKey:      testrand.Bytes(32),
+						EncryptedKeyNonce: encryptedKeyNonce[:],
+
+						EncryptedSize: size.Int32(),
+						PlainSize:     size.Int32(),
+						Redundancy:    metabasetest.DefaultRedundancy,
+					},
+				}.Check(ctx, t, db)
+			}
+		}
+
+		t.Run("negative overrides", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+					MinPartSize:  -1,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "MinPartSize is negative",
+			}.Check(ctx, t, db)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream:     obj,
+					MaxNumberOfParts: -1,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "MaxNumberOfParts is negative",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("stricter min part size", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			createParts(t, 2, 6*memory.MiB)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+					MinPartSize:  10 * memory.MiB,
+				},
+				ErrClass: &metabase.Error,
+				ErrText:  "size of part number 0 is below minimum threshold, got: 6.0 MiB, min: 10.0 MiB",
+			}.Check(ctx, t, db)
+
+			// the global limit is satisfied.
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("looser min part size", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			createParts(t, 2, 2*memory.MiB)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+				},
+				ErrClass: &metabase.Error,
+				ErrText:  "size of part number 0 is below minimum threshold, got: 2.0 MiB, min: 5.0 MiB",
+			}.Check(ctx, t, db)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+					MinPartSize:  memory.MiB,
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("stricter number of parts", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			createParts(t, 3, 6*memory.MiB)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream:     obj,
+					MaxNumberOfParts: 2,
+				},
+				ErrClass: &metabase.Error,
+				ErrText:  "exceeded maximum number of parts: 2",
+			}.Check(ctx, t, db)
+
+			// the global limit is satisfied.
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("looser number of parts", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			createParts(t, 5, 6*memory.MiB)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+				},
+				ErrClass: &metabase.Error,
+				ErrText:  "exceeded maximum number of parts: 3",
+			}.Check(ctx, t, db)
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream:     obj,
+					MaxNumberOfParts: 10,
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
 func TestCommitObjectWithIncorrectAmountOfParts(t *testing.T) {
 	metabasetest.RunWithConfig(t, metabase.Config{
 		ApplicationName:  "satellite-test",
@@ -23,6 +23,10 @@ var (
 	ErrObjectAlreadyExists = errs.Class("object already exists")
 	// ErrPendingObjectMissing is used to indicate a pending object is no longer accessible.
 	ErrPendingObjectMissing = errs.Class("pending object missing")
+	// ErrObjectWriteOnce is used to indicate that a write-once object cannot be overwritten.
+	ErrObjectWriteOnce = errs.Class("object is write-once")
+	// ErrSegmentETagMismatch is used to indicate that an existing segment has a different ETag than expected.
+	ErrSegmentETagMismatch = errs.Class("segment etag mismatch")
 )
 
 // Common constants for segment keys.
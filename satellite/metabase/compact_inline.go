@@ -0,0 +1,226 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/private/dbutil/txutil"
+	"storj.io/private/tagsql"
+)
+
+// CompactedInlineSegmentMaxSize is the maximum size of the inline data of a segment
+// merged by CompactInlineSegments, it matches the default maximum inline segment size.
+const CompactedInlineSegmentMaxSize = 4 * memory.KiB
+
+// compactedSegment contains the segment fields needed for compacting inline segments.
+type compactedSegment struct {
+	Position      SegmentPosition
+	PlainSize     int32
+	EncryptedETag []byte
+	InlineData    []byte
+	Inline        bool
+}
+
+// CompactInlineSegments merges runs of contiguous inline segments of a committed
+// object into single segments, to reduce the number of rows of objects which
+// accumulated many tiny inline segments. It returns the number of removed segments.
+//
+// The data of an encrypted segment is encrypted with a key of the segment, so every
+// segment is an encryption boundary and only segments of objects without encryption
+// are merged. Segments are merged only within a part and up to CompactedInlineSegmentMaxSize.
+// A merged segment keeps the plain offset and key of the first segment of the run. The
+// following segments of the part are moved to close the gaps, so the indexes of every
+// part stay contiguous and match the segment count of the object. Everything happens
+// in a single transaction.
+func (db *DB) CompactInlineSegments(ctx context.Context, streamID uuid.UUID) (removed int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if streamID.IsZero() {
+		return 0, ErrInvalidRequest.New("StreamID missing")
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		removed = 0
+
+		var encryption storj.EncryptionParameters
+		err := tx.QueryRowContext(ctx, `
+			SELECT encryption
+			FROM objects
+			WHERE stream_id = $1 AND status = `+committedStatus+`
+		`, streamID).Scan(encryptionParameters{&encryption})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return storj.ErrObjectNotFound.Wrap(Error.New("object with specified stream id is missing"))
+			}
+			return Error.New("unable to query object: %w", err)
+		}
+
+		if encryption.CipherSuite != storj.EncNull {
+			return nil
+		}
+
+		var segments []compactedSegment
+		err = withRows(tx.QueryContext(ctx, `
+			SELECT position, plain_size, encrypted_etag, inline_data, redundancy, remote_alias_pieces
+			FROM segments
+			WHERE stream_id = $1
+			ORDER BY position
+		`, streamID))(func(rows tagsql.Rows) error {
+			for rows.Next() {
+				var segment compactedSegment
+				var redundancy storj.RedundancyScheme
+				var aliasPieces AliasPieces
+				err := rows.Scan(&segment.Position, &segment.PlainSize, &segment.EncryptedETag, &segment.InlineData,
+					redundancyScheme{&redundancy}, &aliasPieces)
+				if err != nil {
+					return Error.New("failed to scan segments: %w", err)
+				}
+				segment.Inline = redundancy.IsZero() && len(aliasPieces) == 0
+
+				segments = append(segments, segment)
+			}
+			return nil
+		})
+		if err != nil {
+			return Error.New("unable to fetch segments: %w", err)
+		}
+
+		mergedSegments := map[SegmentPosition]bool{}
+		for _, run := range inlineSegmentRuns(segments) {
+			merged := compactedSegment{Position: run[0].Position}
+			var removedPositions []int64
+			for i, segment := range run {
+				merged.PlainSize += segment.PlainSize
+				merged.InlineData = append(merged.InlineData, segment.InlineData...)
+				if len(segment.EncryptedETag) > 0 {
+					merged.EncryptedETag = segment.EncryptedETag
+				}
+				if i > 0 {
+					removedPositions = append(removedPositions, int64(segment.Position.Encode()))
+					mergedSegments[segment.Position] = true
+				}
+			}
+
+			_, err := tx.ExecContext(ctx, `
+				UPDATE segments SET
+					plain_size = $3,
+					encrypted_size = $4,
+					encrypted_etag = $5,
+					inline_data = $6
+				WHERE stream_id = $1 AND position = $2
+			`, streamID, merged.Position, merged.PlainSize, len(merged.InlineData), merged.EncryptedETag, merged.InlineData)
+			if err != nil {
+				return Error.New("unable to update segment: %w", err)
+			}
+
+			_, err = tx.ExecContext(ctx, `
+				DELETE FROM segments
+				WHERE stream_id = $1 AND position = ANY($2::INT8[])
+			`, streamID, pgutil.Int8Array(removedPositions))
+			if err != nil {
+				return Error.New("unable to delete segments: %w", err)
+			}
+
+			removed += len(removedPositions)
+		}
+
+		if removed == 0 {
+			return nil
+		}
+
+		if err := renumberSegments(ctx, tx, streamID, segments, mergedSegments); err != nil {
+			return err
+		}
+
+		// segments don't have the same size anymore.
+		_, err = tx.ExecContext(ctx, `
+			UPDATE objects SET
+				segment_count = segment_count - $2,
+				fixed_segment_size = -1
+			WHERE stream_id = $1 AND status = `+committedStatus+`
+		`, streamID, removed)
+		if err != nil {
+			return Error.New("unable to update object: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	mon.Meter("inline_segments_compacted").Mark(removed)
+
+	return removed, nil
+}
+
+// renumberSegments moves the remaining segments of every part to contiguous indexes,
+// starting at the first index of the part. The segments must be sorted by position.
+// Segments only move to lower indexes, which are free at the time of the update,
+// because the segments are moved in ascending order.
+func renumberSegments(ctx context.Context, tx tagsql.Tx, streamID uuid.UUID, segments []compactedSegment, removed map[SegmentPosition]bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var next SegmentPosition
+	for i, segment := range segments {
+		if i == 0 || segment.Position.Part != next.Part {
+			next = segment.Position
+		}
+		if removed[segment.Position] {
+			continue
+		}
+
+		if segment.Position != next {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE segments SET position = $3
+				WHERE stream_id = $1 AND position = $2
+			`, streamID, segment.Position, next)
+			if err != nil {
+				return Error.New("unable to move segment: %w", err)
+			}
+		}
+		next.Index++
+	}
+	return nil
+}
+
+// inlineSegmentRuns returns the runs of at least two inline segments, which can be merged.
+// The segments must be sorted by position.
+func inlineSegmentRuns(segments []compactedSegment) (runs [][]compactedSegment) {
+	var run []compactedSegment
+	var runSize int
+	flush := func() {
+		if len(run) > 1 {
+			runs = append(runs, run)
+		}
+		run, runSize = nil, 0
+	}
+
+	for _, segment := range segments {
+		if !segment.Inline {
+			flush()
+			continue
+		}
+
+		if len(run) > 0 {
+			last := run[len(run)-1].Position
+			contiguous := segment.Position.Part == last.Part && segment.Position.Index == last.Index+1
+			if !contiguous || runSize+len(segment.InlineData) > CompactedInlineSegmentMaxSize.Int() {
+				flush()
+			}
+		}
+
+		run = append(run, segment)
+		runSize += len(segment.InlineData)
+	}
+	flush()
+
+	return runs
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestCompactInlineSegments(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		unencrypted := storj.EncryptionParameters{
+			CipherSuite: storj.EncNull,
+			BlockSize:   29 * 256,
+		}
+
+		// createObject creates an object with inline segments containing the data,
+		// a nil data creates a remote segment.
+		createObject := func(t *testing.T, obj metabase.ObjectStream, encryption storj.EncryptionParameters, data [][]byte) {
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+					Encryption:   encryption,
+				},
+				Version: obj.Version,
+			}.Check(ctx, t, db)
+
+			for i, inlineData := range data {
+				position := metabase.SegmentPosition{Index: uint32(i)}
+				if inlineData == nil {
+					metabasetest.BeginSegment{
+						Opts: metabase.BeginSegment{
+							ObjectStream: obj,
+							Position:     position,
+							RootPieceID:  testrand.PieceID(),
+							Pieces:       metabase.Pieces{{Number: 1, StorageNode: testrand.NodeID()}},
+						},
+					}.Check(ctx, t, db)
+
+					metabasetest.CommitSegment{
+						Opts: metabase.CommitSegment{
+							ObjectStream: obj,
+							Position:     position,
+							RootPieceID:  testrand.PieceID(),
+							Pieces:       metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}},
+
+							EncryptedKey:      []byte{3},
+							EncryptedKeyNonce: []byte{4},
+
+							EncryptedSize: 1024,
+							PlainSize:     512,
+							Redundancy:    metabasetest.DefaultRedundancy,
+						},
+					}.Check(ctx, t, db)
+					continue
+				}
+
+				metabasetest.CommitInlineSegment{
+					Opts: metabase.CommitInlineSegment{
+						ObjectStream: obj,
+						Position:     position,
+
+						EncryptedKey:      []byte{3},
+						EncryptedKeyNonce: []byte{4},
+
+						InlineData: inlineData,
+						PlainSize:  int32(len(inlineData)),
+					},
+				}.Check(ctx, t, db)
+			}
+
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+				},
+			}.Check(ctx, t, db)
+		}
+
+		// reconstruct returns the inline data of the object in the order of plain offsets.
+		reconstruct := func(t *testing.T, obj metabase.ObjectStream) ([]byte, []metabase.Segment) {
+			result, err := db.ListSegments(ctx, metabase.ListSegments{StreamID: obj.StreamID})
+			require.NoError(t, err)
+
+			var data []byte
+			var plainOffset int64
+			for _, segment := range result.Segments {
+				require.Equal(t, plainOffset, segment.PlainOffset)
+				plainOffset += int64(segment.PlainSize)
+
+				if segment.Inline() {
+					require.Equal(t, int(segment.PlainSize), len(segment.InlineData))
+					data = append(data, segment.InlineData...)
+				}
+			}
+			return data, result.Segments
+		}
+
+		t.Run("StreamID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.CompactInlineSegments(ctx, uuid.UUID{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.CompactInlineSegments(ctx, testrand.UUID())
+			require.True(t, storj.ErrObjectNotFound.Has(err))
+		})
+
+		t.Run("encrypted segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			createObject(t, obj, metabasetest.DefaultEncryption, [][]byte{
+				testrand.Bytes(10), testrand.Bytes(10), testrand.Bytes(10),
+			})
+
+			original, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+
+			removed, err := db.CompactInlineSegments(ctx, obj.StreamID)
+			require.NoError(t, err)
+			require.Zero(t, removed)
+
+			metabasetest.Verify(*original).Check(ctx, t, db)
+		})
+
+		t.Run("compact", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			maxSize := metabase.CompactedInlineSegmentMaxSize
+
+			obj := metabasetest.RandObjectStream()
+			createObject(t, obj, unencrypted, [][]byte{
+				testrand.Bytes(10), testrand.Bytes(20), testrand.Bytes(30),
+				nil,
+				testrand.Bytes(40), testrand.Bytes(50),
+				nil,
+				testrand.Bytes(60),
+				testrand.Bytes(maxSize - 60),
+				testrand.Bytes(70),
+			})
+
+			dataBefore, segmentsBefore := reconstruct(t, obj)
+			require.Len(t, segmentsBefore, 10)
+
+			removed, err := db.CompactInlineSegments(ctx, obj.StreamID)
+			require.NoError(t, err)
+			require.Equal(t, 4, removed)
+
+			dataAfter, segmentsAfter := reconstruct(t, obj)
+			require.Len(t, segmentsAfter, 6)
+			require.Equal(t, dataBefore, dataAfter)
+
+			var positions []metabase.SegmentPosition
+			for _, segment := range segmentsAfter {
+				positions = append(positions, segment.Position)
+			}
+			require.Equal(t, []metabase.SegmentPosition{
+				{Index: 0}, {Index: 1}, {Index: 2}, {Index: 3}, {Index: 4}, {Index: 5},
+			}, positions)
+
+			// moved segments only change their position.
+			moved := func(segment metabase.Segment, index uint32) metabase.Segment {
+				segment.Position.Index = index
+				return segment
+			}
+			require.EqualValues(t, 60, segmentsAfter[0].PlainSize)
+			require.Equal(t, moved(segmentsBefore[3], 1), segmentsAfter[1])
+			require.EqualValues(t, 90, segmentsAfter[2].PlainSize)
+			require.Equal(t, moved(segmentsBefore[6], 3), segmentsAfter[3])
+			require.EqualValues(t, maxSize.Int(), segmentsAfter[4].PlainSize)
+			require.Equal(t, segmentsBefore[9].InlineData, segmentsAfter[5].InlineData)
+
+			objects, err := db.TestingAllObjects(ctx)
+			require.NoError(t, err)
+			require.Len(t, objects, 1)
+			require.EqualValues(t, 6, objects[0].SegmentCount)
+			require.EqualValues(t, -1, objects[0].FixedSegmentSize)
+
+			// compacting again doesn't change anything.
+			removed, err = db.CompactInlineSegments(ctx, obj.StreamID)
+			require.NoError(t, err)
+			require.Zero(t, removed)
+		})
+	})
+}
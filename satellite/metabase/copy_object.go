@@ -329,6 +329,7 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 					total_encrypted_size = $13,
 					fixed_segment_size = $14,
 					zombie_deletion_deadline = NULL
+				WHERE NOT objects.write_once
 			RETURNING
 				created_at,
 				(SELECT stream_id FROM existing_object LIMIT 1),
@@ -347,6 +348,10 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 
 		err = row.Scan(&copyObject.CreatedAt, &existingObjStreamID, &newAncestorStreamID, &oldSegmentCount)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// the destination object exists and is write-once, so it was not updated
+				return ErrObjectWriteOnce.New("")
+			}
 			return Error.New("unable to copy object: %w", err)
 		}
 
@@ -197,6 +197,10 @@ func (db *DB) TestMigrateToLatest(ctx context.Context) error {
 
 						zombie_deletion_deadline TIMESTAMPTZ default now() + '1 day',
 
+						write_once BOOLEAN NOT NULL default false,
+
+						plaintext_tags JSONB default NULL,
+
 						PRIMARY KEY (project_id, bucket_name, object_key, version)
 					);
 					CREATE TABLE segments (
@@ -240,7 +244,18 @@ func (db *DB) TestMigrateToLatest(ctx context.Context) error {
 
 						CONSTRAINT not_self_ancestor CHECK (stream_id != ancestor_stream_id)
 					);
-					CREATE INDEX ON segment_copies (ancestor_stream_id);`,
+					CREATE INDEX ON segment_copies (ancestor_stream_id);
+
+					CREATE TABLE segment_health (
+						stream_id BYTEA NOT NULL,
+						position  INT8  NOT NULL,
+
+						healthy_pieces      INT4        NOT NULL,
+						placement_compliant BOOLEAN     NOT NULL,
+						checked_at          TIMESTAMPTZ NOT NULL,
+
+						PRIMARY KEY (stream_id, position)
+					);`,
 				},
 			},
 		},
@@ -470,6 +485,39 @@ func (db *DB) PostgresMigration() *migrate.Migration {
 					`CREATE INDEX ON segment_copies (ancestor_stream_id)`,
 				},
 			},
+			{
+				DB:          &db.db,
+				Description: "add write_once to the objects table",
+				Version:     16,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN write_once BOOLEAN NOT NULL DEFAULT false`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add plaintext_tags to the objects table",
+				Version:     17,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN plaintext_tags JSONB`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add table for segment health summaries",
+				Version:     18,
+				Action: migrate.SQL{
+					`CREATE TABLE segment_health (
+						stream_id BYTEA NOT NULL,
+						position  INT8  NOT NULL,
+
+						healthy_pieces      INT4        NOT NULL,
+						placement_compliant BOOLEAN     NOT NULL,
+						checked_at          TIMESTAMPTZ NOT NULL,
+
+						PRIMARY KEY (stream_id, position)
+					)`,
+				},
+			},
 		},
 	}
 }
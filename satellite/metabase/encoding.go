@@ -6,6 +6,7 @@ package metabase
 import (
 	"database/sql/driver"
 	"encoding/binary"
+	"encoding/json"
 
 	"github.com/jackc/pgtype"
 
@@ -44,6 +45,37 @@ func (params encryptionParameters) Scan(value interface{}) error {
 	}
 }
 
+type plaintextTags struct {
+	Tags *map[string]string
+}
+
+// Value implements sql/driver.Valuer interface.
+func (params plaintextTags) Value() (driver.Value, error) {
+	if params.Tags == nil || *params.Tags == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(*params.Tags)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner interface.
+func (params plaintextTags) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*params.Tags = nil
+		return nil
+	case []byte:
+		return Error.Wrap(json.Unmarshal(value, params.Tags))
+	case string:
+		return Error.Wrap(json.Unmarshal([]byte(value), params.Tags))
+	default:
+		return Error.New("unable to scan %T into plaintext tags", value)
+	}
+}
+
 // Value implements sql/driver.Valuer interface.
 func (params SegmentPosition) Value() (driver.Value, error) {
 	return int64(params.Encode()), nil
@@ -0,0 +1,203 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/private/tagsql"
+)
+
+const fixObjectTotalsBatchSizeLimit = intLimitRange(1000)
+
+// FixObjectTotals contains arguments necessary for fixing object totals.
+type FixObjectTotals struct {
+	ProjectID uuid.UUID
+	BatchSize int
+}
+
+// FixObjectTotalsResult contains the result of fixing object totals.
+type FixObjectTotalsResult struct {
+	// Checked is the number of committed objects that were verified.
+	Checked int64
+	// Fixed is the number of objects whose totals were corrected.
+	Fixed int64
+}
+
+// objectTotals contains the segment count and sizes of an object.
+type objectTotals struct {
+	SegmentCount       int32
+	TotalPlainSize     int64
+	TotalEncryptedSize int64
+}
+
+// objectTotalsEntry contains the object totals stored in the objects table.
+type objectTotalsEntry struct {
+	BucketName string
+	ObjectKey  ObjectKey
+	Version    Version
+	StreamID   uuid.UUID
+
+	objectTotals
+}
+
+// FixObjectTotals iterates over all committed objects of a project, recomputes
+// segment count and total sizes from the segments table and updates objects whose
+// stored values do not match.
+func (db *DB) FixObjectTotals(ctx context.Context, opts FixObjectTotals) (result FixObjectTotalsResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.ProjectID.IsZero() {
+		return FixObjectTotalsResult{}, ErrInvalidRequest.New("ProjectID missing")
+	}
+
+	fixObjectTotalsBatchSizeLimit.Ensure(&opts.BatchSize)
+
+	var cursor objectTotalsEntry
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		entries, err := db.listObjectTotals(ctx, opts.ProjectID, cursor, opts.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(entries) == 0 {
+			return result, nil
+		}
+
+		fixed, err := db.fixObjectTotalsBatch(ctx, opts.ProjectID, entries)
+		if err != nil {
+			return result, err
+		}
+
+		result.Checked += int64(len(entries))
+		result.Fixed += fixed
+
+		if len(entries) < opts.BatchSize {
+			return result, nil
+		}
+		cursor = entries[len(entries)-1]
+	}
+}
+
+// listObjectTotals lists committed objects after the cursor together with their stored totals.
+func (db *DB) listObjectTotals(ctx context.Context, projectID uuid.UUID, cursor objectTotalsEntry, limit int) (entries []objectTotalsEntry, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			bucket_name, object_key, version, stream_id,
+			segment_count, total_plain_size, total_encrypted_size
+		FROM objects
+		WHERE
+			project_id = $1 AND
+			(bucket_name, object_key, version) > ($2, $3, $4) AND
+			status = `+committedStatus+`
+		ORDER BY project_id, bucket_name, object_key, version
+		LIMIT $5
+	`, projectID, []byte(cursor.BucketName), []byte(cursor.ObjectKey), cursor.Version, limit))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var entry objectTotalsEntry
+			err := rows.Scan(
+				&entry.BucketName, &entry.ObjectKey, &entry.Version, &entry.StreamID,
+				&entry.SegmentCount, &entry.TotalPlainSize, &entry.TotalEncryptedSize,
+			)
+			if err != nil {
+				return Error.New("failed to scan objects: %w", err)
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list objects: %w", err)
+	}
+	return entries, nil
+}
+
+// fixObjectTotalsBatch recomputes totals of the specified objects and updates the mismatching ones.
+func (db *DB) fixObjectTotalsBatch(ctx context.Context, projectID uuid.UUID, entries []objectTotalsEntry) (fixed int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	streamIDs := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		streamIDs = append(streamIDs, entry.StreamID)
+	}
+
+	actual := make(map[uuid.UUID]objectTotals, len(entries))
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			stream_id, count(*),
+			coalesce(sum(plain_size), 0), coalesce(sum(encrypted_size), 0)
+		FROM segments
+		WHERE stream_id = ANY($1::BYTEA[])
+		GROUP BY stream_id
+	`, pgutil.UUIDArray(streamIDs)))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var streamID uuid.UUID
+			var totals objectTotals
+			err := rows.Scan(&streamID, &totals.SegmentCount, &totals.TotalPlainSize, &totals.TotalEncryptedSize)
+			if err != nil {
+				return Error.New("failed to scan segments: %w", err)
+			}
+			actual[streamID] = totals
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, Error.New("unable to compute object totals: %w", err)
+	}
+
+	var batch struct {
+		StreamIDs           []uuid.UUID
+		SegmentCounts       []int32
+		TotalPlainSizes     []int64
+		TotalEncryptedSizes []int64
+	}
+	for _, entry := range entries {
+		totals := actual[entry.StreamID]
+		if totals == entry.objectTotals {
+			continue
+		}
+		batch.StreamIDs = append(batch.StreamIDs, entry.StreamID)
+		batch.SegmentCounts = append(batch.SegmentCounts, totals.SegmentCount)
+		batch.TotalPlainSizes = append(batch.TotalPlainSizes, totals.TotalPlainSize)
+		batch.TotalEncryptedSizes = append(batch.TotalEncryptedSizes, totals.TotalEncryptedSize)
+	}
+	if len(batch.StreamIDs) == 0 {
+		return 0, nil
+	}
+
+	updateResult, err := db.db.ExecContext(ctx, `
+		UPDATE objects
+		SET
+			segment_count        = F.segment_count,
+			total_plain_size     = F.total_plain_size,
+			total_encrypted_size = F.total_encrypted_size
+		FROM (
+			SELECT unnest($2::BYTEA[]), unnest($3::INT4[]), unnest($4::INT8[]), unnest($5::INT8[])
+		) AS F(stream_id, segment_count, total_plain_size, total_encrypted_size)
+		WHERE
+			objects.project_id = $1 AND
+			objects.stream_id  = F.stream_id AND
+			objects.status     = `+committedStatus+`
+	`, projectID, pgutil.UUIDArray(batch.StreamIDs), pgutil.Int4Array(batch.SegmentCounts),
+		pgutil.Int8Array(batch.TotalPlainSizes), pgutil.Int8Array(batch.TotalEncryptedSizes))
+	if err != nil {
+		return 0, Error.New("unable to update object totals: %w", err)
+	}
+
+	fixed, err = updateResult.RowsAffected()
+	if err != nil {
+		return 0, Error.New("unable to get number of fixed objects: %w", err)
+	}
+
+	mon.Meter("object_totals_fixed").Mark64(fixed)
+
+	return fixed, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestFixObjectTotals(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("ProjectID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.FixObjectTotals{
+				Opts:     metabase.FixObjectTotals{},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "ProjectID missing",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("no objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.FixObjectTotals{
+				Opts: metabase.FixObjectTotals{
+					ProjectID: testrand.UUID(),
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("doctored objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			projectID := testrand.UUID()
+
+			objects := make([]metabase.ObjectStream, 5)
+			for i := range objects {
+				objects[i] = metabasetest.RandObjectStream()
+				objects[i].ProjectID = projectID
+				metabasetest.CreateObject(ctx, t, db, objects[i], byte(i+1))
+			}
+
+			// an object from another project should not be touched
+			other := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, other, 2)
+
+			expected, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+
+			for _, obj := range append(objects[:3:3], other) {
+				_, err := db.UnderlyingTagSQL().ExecContext(ctx, `
+					UPDATE objects SET
+						segment_count = 100,
+						total_plain_size = 1,
+						total_encrypted_size = 2
+					WHERE stream_id = $1
+				`, obj.StreamID)
+				require.NoError(t, err)
+			}
+
+			metabasetest.FixObjectTotals{
+				Opts: metabase.FixObjectTotals{
+					ProjectID: projectID,
+					BatchSize: 2,
+				},
+				Result: metabase.FixObjectTotalsResult{
+					Checked: 5,
+					Fixed:   3,
+				},
+			}.Check(ctx, t, db)
+
+			// other project is still broken
+			for i, object := range expected.Objects {
+				if object.StreamID == other.StreamID {
+					expected.Objects[i].SegmentCount = 100
+					expected.Objects[i].TotalPlainSize = 1
+					expected.Objects[i].TotalEncryptedSize = 2
+				}
+			}
+
+			metabasetest.Verify(*expected).Check(ctx, t, db)
+
+			// running again should not find anything to fix
+			metabasetest.FixObjectTotals{
+				Opts: metabase.FixObjectTotals{
+					ProjectID: projectID,
+				},
+				Result: metabase.FixObjectTotalsResult{
+					Checked: 5,
+					Fixed:   0,
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
@@ -13,6 +13,8 @@ import (
 
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/private/tagsql"
 )
 
 // ErrSegmentNotFound is an error class for non-existing segment.
@@ -84,7 +86,8 @@ func (db *DB) GetObjectExactVersion(ctx context.Context, opts GetObjectExactVers
 			segment_count,
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
-			encryption
+			encryption,
+			write_once
 		FROM objects
 		WHERE
 			project_id   = $1 AND
@@ -100,6 +103,7 @@ func (db *DB) GetObjectExactVersion(ctx context.Context, opts GetObjectExactVers
 			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
 			&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
 			encryptionParameters{&object.Encryption},
+			&object.WriteOnce,
 		)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -118,6 +122,114 @@ func (db *DB) GetObjectExactVersion(ctx context.Context, opts GetObjectExactVers
 	return object, nil
 }
 
+// GetObjectLatestVersion contains arguments necessary for fetching
+// an information about the latest committed object version.
+type GetObjectLatestVersion struct {
+	ObjectLocation
+}
+
+// GetObjectLatestVersion returns object information for the latest committed version.
+func (db *DB) GetObjectLatestVersion(ctx context.Context, opts GetObjectLatestVersion) (_ Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return Object{}, err
+	}
+
+	object := Object{}
+	err = db.db.QueryRowContext(ctx, `
+		SELECT
+			version, stream_id,
+			created_at, expires_at,
+			segment_count,
+			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption,
+			write_once
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			status       = `+committedStatus+`
+		ORDER BY version DESC
+		LIMIT 1
+	`, opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey).
+		Scan(
+			&object.Version, &object.StreamID,
+			&object.CreatedAt, &object.ExpiresAt,
+			&object.SegmentCount,
+			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+			&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+			encryptionParameters{&object.Encryption},
+			&object.WriteOnce,
+		)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Object{}, storj.ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return Object{}, Error.New("unable to query object status: %w", err)
+	}
+
+	object.ProjectID = opts.ProjectID
+	object.BucketName = opts.BucketName
+	object.ObjectKey = opts.ObjectKey
+
+	object.Status = Committed
+
+	return object, nil
+}
+
+// GetObjectByStreamID returns object information, including its location and
+// version, of the object with the specified stream id. It's intended for
+// tooling which only knows the stream id of a segment.
+//
+// The query isn't indexed and scans the whole objects table, so it shouldn't
+// be used by regular satellite operations.
+func (db *DB) GetObjectByStreamID(ctx context.Context, streamID uuid.UUID) (_ Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if streamID.IsZero() {
+		return Object{}, ErrInvalidRequest.New("StreamID missing")
+	}
+
+	object := Object{}
+	object.StreamID = streamID
+	err = db.db.QueryRowContext(ctx, `
+		SELECT
+			project_id, bucket_name, object_key, version,
+			status,
+			created_at, expires_at,
+			segment_count,
+			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption,
+			zombie_deletion_deadline,
+			write_once
+		FROM objects
+		WHERE stream_id = $1
+	`, streamID).
+		Scan(
+			&object.ProjectID, &object.BucketName, &object.ObjectKey, &object.Version,
+			&object.Status,
+			&object.CreatedAt, &object.ExpiresAt,
+			&object.SegmentCount,
+			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+			&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+			encryptionParameters{&object.Encryption},
+			&object.ZombieDeletionDeadline,
+			&object.WriteOnce,
+		)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Object{}, storj.ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return Object{}, Error.New("unable to query object: %w", err)
+	}
+
+	return object, nil
+}
+
 // GetSegmentByPosition contains arguments necessary for fetching a segment on specific position.
 type GetSegmentByPosition struct {
 	StreamID uuid.UUID
@@ -336,6 +448,83 @@ func (db *DB) BucketEmpty(ctx context.Context, opts BucketEmpty) (empty bool, er
 	return false, nil
 }
 
+// ListNonEmptyBuckets contains arguments necessary for listing non-empty buckets.
+type ListNonEmptyBuckets struct {
+	ProjectID uuid.UUID
+	// BucketNames are the names of the buckets to check, usually the buckets
+	// of the project listed from the buckets database.
+	BucketNames []string
+}
+
+// ListNonEmptyBuckets returns the names of the given buckets of the project, which
+// contain at least one committed object, sorted by name. Every bucket is checked
+// with a lookup of its first committed object, instead of scanning all objects.
+// This method doesn't check bucket existence.
+func (db *DB) ListNonEmptyBuckets(ctx context.Context, opts ListNonEmptyBuckets) (bucketNames []string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.ProjectID.IsZero() {
+		return nil, ErrInvalidRequest.New("ProjectID missing")
+	}
+	if len(opts.BucketNames) == 0 {
+		return nil, nil
+	}
+
+	names := make([][]byte, len(opts.BucketNames))
+	for i, name := range opts.BucketNames {
+		names[i] = []byte(name)
+	}
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT buckets.name
+		FROM UNNEST($2::BYTEA[]) AS buckets(name)
+		WHERE EXISTS (
+			SELECT 1
+			FROM objects
+			WHERE
+				project_id  = $1 AND
+				bucket_name = buckets.name AND
+				status      = `+committedStatus+`
+		)
+		ORDER BY buckets.name
+	`, opts.ProjectID, pgutil.ByteaArray(names)))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var bucketName []byte
+			if err := rows.Scan(&bucketName); err != nil {
+				return Error.New("failed to scan bucket name: %w", err)
+			}
+			bucketNames = append(bucketNames, string(bucketName))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list buckets: %w", err)
+	}
+
+	return bucketNames, nil
+}
+
+// CountSegments returns the number of committed segments of the stream,
+// without loading the segments.
+func (db *DB) CountSegments(ctx context.Context, streamID uuid.UUID) (count int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if streamID.IsZero() {
+		return 0, ErrInvalidRequest.New("StreamID missing")
+	}
+
+	err = db.db.QueryRowContext(ctx, `
+		SELECT count(*)
+		FROM segments
+		WHERE stream_id = $1
+	`, streamID).Scan(&count)
+	if err != nil {
+		return 0, Error.New("unable to count segments: %w", err)
+	}
+
+	return count, nil
+}
+
 // TestingAllCommittedObjects gets all objects from bucket.
 // Use only for testing purposes.
 func (db *DB) TestingAllCommittedObjects(ctx context.Context, projectID uuid.UUID, bucketName string) (objects []ObjectEntry, err error) {
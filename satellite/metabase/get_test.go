@@ -12,6 +12,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metabase/metabasetest"
 )
@@ -165,6 +166,161 @@ func TestGetObjectExactVersion(t *testing.T) {
 	})
 }
 
+func TestGetObjectLatestVersion(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		location := obj.Location()
+
+		now := time.Now()
+
+		for _, test := range metabasetest.InvalidObjectLocations(location) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+				metabasetest.GetObjectLatestVersion{
+					Opts: metabase.GetObjectLatestVersion{
+						ObjectLocation: test.ObjectLocation,
+					},
+					ErrClass: test.ErrClass,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		}
+
+		t.Run("Object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLatestVersion{
+				Opts: metabase.GetObjectLatestVersion{
+					ObjectLocation: location,
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: sql: no rows in result set",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("Get latest committed version", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			first := obj
+			first.Version = 1
+			metabasetest.CreateObject(ctx, t, db, first, 0)
+
+			second := obj
+			second.Version = 2
+			second.StreamID = testrand.UUID()
+			metabasetest.CreateObject(ctx, t, db, second, 0)
+
+			// pending versions are ignored.
+			pending := obj
+			pending.Version = 3
+			pending.StreamID = testrand.UUID()
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: pending,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: 3,
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLatestVersion{
+				Opts: metabase.GetObjectLatestVersion{
+					ObjectLocation: location,
+				},
+				Result: metabase.Object{
+					ObjectStream: second,
+					CreatedAt:    now,
+					Status:       metabase.Committed,
+
+					Encryption: metabasetest.DefaultEncryption,
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestGetObjectByStreamID(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		now := time.Now()
+		zombieDeadline := now.Add(24 * time.Hour)
+
+		t.Run("StreamID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.GetObjectByStreamID{
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "StreamID missing",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("Object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.GetObjectByStreamID{
+				StreamID: obj.StreamID,
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: sql: no rows in result set",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("Get pending object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+
+					Encryption: metabasetest.DefaultEncryption,
+				},
+				Version: obj.Version,
+			}.Check(ctx, t, db)
+
+			object := metabase.Object{
+				ObjectStream: obj,
+				CreatedAt:    now,
+				Status:       metabase.Pending,
+
+				Encryption:             metabasetest.DefaultEncryption,
+				ZombieDeletionDeadline: &zombieDeadline,
+			}
+
+			metabasetest.GetObjectByStreamID{
+				StreamID: obj.StreamID,
+				Result:   object,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Get committed object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			// an unrelated object must not be returned
+			metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 1)
+
+			metabasetest.GetObjectByStreamID{
+				StreamID: obj.StreamID,
+				Result:   object,
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
 func TestGetSegmentByPosition(t *testing.T) {
 	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
 		obj := metabasetest.RandObjectStream()
@@ -1365,3 +1521,112 @@ func TestBucketEmpty(t *testing.T) {
 		})
 	})
 }
+
+func TestCountSegments(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("StreamID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.CountSegments(ctx, uuid.UUID{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("unknown stream", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			count, err := db.CountSegments(ctx, testrand.UUID())
+			require.NoError(t, err)
+			require.Zero(t, count)
+		})
+
+		t.Run("multiple objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			objects := []metabase.Object{
+				metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 0),
+				metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 1),
+				metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 5),
+			}
+
+			segments, err := db.TestingAllSegments(ctx)
+			require.NoError(t, err)
+
+			for _, object := range objects {
+				expected := 0
+				for _, segment := range segments {
+					if segment.StreamID == object.StreamID {
+						expected++
+					}
+				}
+
+				count, err := db.CountSegments(ctx, object.StreamID)
+				require.NoError(t, err)
+				require.EqualValues(t, expected, count)
+				require.EqualValues(t, object.SegmentCount, count)
+			}
+		})
+	})
+}
+
+func TestListNonEmptyBuckets(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("ProjectID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.ListNonEmptyBuckets(ctx, metabase.ListNonEmptyBuckets{BucketNames: []string{"bucket"}})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("no objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			buckets, err := db.ListNonEmptyBuckets(ctx, metabase.ListNonEmptyBuckets{
+				ProjectID:   testrand.UUID(),
+				BucketNames: []string{"bucket"},
+			})
+			require.NoError(t, err)
+			require.Empty(t, buckets)
+		})
+
+		t.Run("empty and non-empty buckets", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			projectID := testrand.UUID()
+
+			newObject := func(bucketName string) metabase.ObjectStream {
+				obj := metabasetest.RandObjectStream()
+				obj.ProjectID = projectID
+				obj.BucketName = bucketName
+				return obj
+			}
+
+			metabasetest.CreateObject(ctx, t, db, newObject("b-bucket"), 2)
+			metabasetest.CreateObject(ctx, t, db, newObject("b-bucket"), 0)
+			metabasetest.CreateObject(ctx, t, db, newObject("a-bucket"), 1)
+
+			// a bucket with only a pending object isn't listed.
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: newObject("pending-bucket"),
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: 1,
+			}.Check(ctx, t, db)
+
+			// buckets of other projects aren't listed.
+			otherProject := metabasetest.RandObjectStream()
+			otherProject.BucketName = "other-bucket"
+			metabasetest.CreateObject(ctx, t, db, otherProject, 1)
+
+			// buckets which weren't asked for aren't listed.
+			metabasetest.CreateObject(ctx, t, db, newObject("c-bucket"), 1)
+
+			buckets, err := db.ListNonEmptyBuckets(ctx, metabase.ListNonEmptyBuckets{
+				ProjectID:   projectID,
+				BucketNames: []string{"b-bucket", "pending-bucket", "other-bucket", "a-bucket", "empty-bucket"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{"a-bucket", "b-bucket"}, buckets)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"time"
+
+	"storj.io/private/tagsql"
+)
+
+// ObjectWithInconsistentSegmentExpiry contains information about an object
+// whose segments have a different expiration time than the object.
+type ObjectWithInconsistentSegmentExpiry struct {
+	ObjectStream
+
+	ExpiresAt *time.Time
+	// InconsistentSegments is the number of segments with a different expiration time.
+	InconsistentSegments int64
+}
+
+// ListObjectsWithInconsistentSegmentExpiryResult contains the result of ListObjectsWithInconsistentSegmentExpiry.
+type ListObjectsWithInconsistentSegmentExpiryResult struct {
+	Objects []ObjectWithInconsistentSegmentExpiry
+	// Cursor can be used to continue the listing when More is true.
+	Cursor StreamIDCursor
+	More   bool
+}
+
+// ListObjectsWithInconsistentSegmentExpiry lists objects after the cursor which have
+// segments with a different expiration time than the object. The expiration time is
+// set for both the object and its segments when they are committed, so a difference
+// means that one of them was changed without the other.
+//
+// The query scans the whole objects and segments tables and shouldn't be used by
+// regular satellite operations.
+func (db *DB) ListObjectsWithInconsistentSegmentExpiry(ctx context.Context, limit int, cursor StreamIDCursor) (result ListObjectsWithInconsistentSegmentExpiryResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit < 0 {
+		return ListObjectsWithInconsistentSegmentExpiryResult{}, ErrInvalidRequest.New("Invalid limit: %d", limit)
+	}
+	ListLimit.Ensure(&limit)
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			objects.project_id, objects.bucket_name, objects.object_key, objects.version, objects.stream_id,
+			objects.expires_at,
+			count(*)
+		FROM objects
+		JOIN segments ON segments.stream_id = objects.stream_id
+		WHERE
+			objects.stream_id > $1 AND
+			segments.expires_at IS DISTINCT FROM objects.expires_at
+		GROUP BY
+			objects.project_id, objects.bucket_name, objects.object_key, objects.version, objects.stream_id,
+			objects.expires_at
+		ORDER BY objects.stream_id
+		LIMIT $2
+	`, cursor.StreamID, limit+1))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var object ObjectWithInconsistentSegmentExpiry
+			err := rows.Scan(
+				&object.ProjectID, &object.BucketName, &object.ObjectKey, &object.Version, &object.StreamID,
+				&object.ExpiresAt,
+				&object.InconsistentSegments,
+			)
+			if err != nil {
+				return Error.New("failed to scan objects: %w", err)
+			}
+			result.Objects = append(result.Objects, object)
+		}
+		return nil
+	})
+	if err != nil {
+		return ListObjectsWithInconsistentSegmentExpiryResult{}, Error.New("unable to list objects with inconsistent segment expiry: %w", err)
+	}
+
+	if len(result.Objects) > limit {
+		result.More = true
+		result.Objects = result.Objects[:limit]
+	}
+
+	if len(result.Objects) > 0 {
+		result.Cursor = StreamIDCursor{StreamID: result.Objects[len(result.Objects)-1].StreamID}
+	} else {
+		result.Cursor = cursor
+	}
+
+	return result, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestListObjectsWithInconsistentSegmentExpiry(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("invalid limit", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.ListObjectsWithInconsistentSegmentExpiry(ctx, -1, metabase.StreamIDCursor{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("no objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			result, err := db.ListObjectsWithInconsistentSegmentExpiry(ctx, 10, metabase.StreamIDCursor{})
+			require.NoError(t, err)
+			require.Empty(t, result.Objects)
+			require.False(t, result.More)
+		})
+
+		t.Run("reports objects with diverged segment expiry", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			expiresAt := time.Now().Add(time.Hour)
+
+			metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 2)
+			metabasetest.CreateExpiredObject(ctx, t, db, metabasetest.RandObjectStream(), 2, expiresAt)
+
+			var broken []uuid.UUID
+
+			// segment expires while the object doesn't
+			for i := 0; i < 2; i++ {
+				obj := metabasetest.RandObjectStream()
+				metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+				_, err := db.UnderlyingTagSQL().ExecContext(ctx, `
+					UPDATE segments SET expires_at = $3
+					WHERE stream_id = $1 AND position = $2
+				`, obj.StreamID, metabase.SegmentPosition{Index: 1}, expiresAt)
+				require.NoError(t, err)
+
+				broken = append(broken, obj.StreamID)
+			}
+
+			// object expires while the segments don't
+			for i := 0; i < 2; i++ {
+				obj := metabasetest.RandObjectStream()
+				metabasetest.CreateExpiredObject(ctx, t, db, obj, 2, expiresAt)
+
+				_, err := db.UnderlyingTagSQL().ExecContext(ctx, `
+					UPDATE segments SET expires_at = NULL
+					WHERE stream_id = $1
+				`, obj.StreamID)
+				require.NoError(t, err)
+
+				broken = append(broken, obj.StreamID)
+			}
+			sort.Slice(broken, func(i, j int) bool {
+				return broken[i].Less(broken[j])
+			})
+
+			var listed []uuid.UUID
+			cursor := metabase.StreamIDCursor{}
+			for {
+				result, err := db.ListObjectsWithInconsistentSegmentExpiry(ctx, 3, cursor)
+				require.NoError(t, err)
+
+				for _, object := range result.Objects {
+					if object.ExpiresAt == nil {
+						require.EqualValues(t, 1, object.InconsistentSegments)
+					} else {
+						require.EqualValues(t, 2, object.InconsistentSegments)
+					}
+					listed = append(listed, object.StreamID)
+				}
+
+				if !result.More {
+					break
+				}
+				cursor = result.Cursor
+			}
+
+			require.Equal(t, broken, listed)
+		})
+	})
+}
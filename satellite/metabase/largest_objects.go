@@ -0,0 +1,73 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"storj.io/common/uuid"
+	"storj.io/private/tagsql"
+)
+
+// ListLargestObjects returns up to topN committed objects of the project with
+// the greatest total encrypted size, ordered by the size descending. It's
+// intended for guiding users which objects to clean up.
+//
+// The query isn't indexed by the size and scans all objects of the project.
+func (db *DB) ListLargestObjects(ctx context.Context, projectID uuid.UUID, topN int) (objects []Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if projectID.IsZero() {
+		return nil, ErrInvalidRequest.New("ProjectID missing")
+	}
+	if topN <= 0 {
+		return nil, ErrInvalidRequest.New("Invalid topN: %d", topN)
+	}
+	ListLimit.Ensure(&topN)
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			bucket_name, object_key, version, stream_id,
+			status,
+			created_at, expires_at,
+			segment_count,
+			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption,
+			zombie_deletion_deadline,
+			write_once
+		FROM objects
+		WHERE
+			project_id = $1 AND
+			status = `+committedStatus+`
+		ORDER BY total_encrypted_size DESC, bucket_name, object_key, version
+		LIMIT $2
+	`, projectID, topN))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			object := Object{}
+			object.ProjectID = projectID
+			err := rows.Scan(
+				&object.BucketName, &object.ObjectKey, &object.Version, &object.StreamID,
+				&object.Status,
+				&object.CreatedAt, &object.ExpiresAt,
+				&object.SegmentCount,
+				&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+				&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+				encryptionParameters{&object.Encryption},
+				&object.ZombieDeletionDeadline,
+				&object.WriteOnce,
+			)
+			if err != nil {
+				return Error.New("failed to scan objects: %w", err)
+			}
+			objects = append(objects, object)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list largest objects: %w", err)
+	}
+
+	return objects, nil
+}
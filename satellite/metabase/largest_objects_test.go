@@ -0,0 +1,73 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestListLargestObjects(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.ListLargestObjects(ctx, uuid.UUID{}, 1)
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+			_, err = db.ListLargestObjects(ctx, testrand.UUID(), 0)
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("no objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			objects, err := db.ListLargestObjects(ctx, testrand.UUID(), 3)
+			require.NoError(t, err)
+			require.Empty(t, objects)
+		})
+
+		t.Run("top N", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			projectID := testrand.UUID()
+
+			// objects with more segments have a greater total encrypted size.
+			bySize := make([]metabase.Object, 5)
+			for _, numberOfSegments := range []byte{3, 1, 5, 2, 4} {
+				obj := metabasetest.RandObjectStream()
+				obj.ProjectID = projectID
+				bySize[5-numberOfSegments] = metabasetest.CreateObject(ctx, t, db, obj, numberOfSegments)
+			}
+
+			// pending objects and objects of other projects are ignored.
+			pending := metabasetest.RandObjectStream()
+			pending.ProjectID = projectID
+			metabasetest.CreatePendingObject(ctx, t, db, pending, 10)
+			metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 10)
+
+			for _, topN := range []int{1, 3, 5, 10} {
+				objects, err := db.ListLargestObjects(ctx, projectID, topN)
+				require.NoError(t, err)
+
+				expected := bySize
+				if topN < len(expected) {
+					expected = expected[:topN]
+				}
+				require.Len(t, objects, len(expected))
+				for i := range expected {
+					require.Equal(t, expected[i].ObjectStream, objects[i].ObjectStream)
+					require.Equal(t, expected[i].TotalEncryptedSize, objects[i].TotalEncryptedSize)
+				}
+			}
+		})
+	})
+}
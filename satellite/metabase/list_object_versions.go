@@ -0,0 +1,74 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"storj.io/private/tagsql"
+)
+
+// ListObjectVersions contains arguments necessary for listing all versions of an object.
+type ListObjectVersions struct {
+	ObjectLocation
+}
+
+// ListObjectVersions returns all pending and committed versions of the object,
+// ordered by version.
+func (db *DB) ListObjectVersions(ctx context.Context, opts ListObjectVersions) (objects []Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return nil, err
+	}
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			version, stream_id,
+			status,
+			created_at, expires_at,
+			segment_count,
+			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption,
+			zombie_deletion_deadline,
+			write_once
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3
+		ORDER BY version
+	`, opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			object := Object{}
+			object.ProjectID = opts.ProjectID
+			object.BucketName = opts.BucketName
+			object.ObjectKey = opts.ObjectKey
+
+			err := rows.Scan(
+				&object.Version, &object.StreamID,
+				&object.Status,
+				&object.CreatedAt, &object.ExpiresAt,
+				&object.SegmentCount,
+				&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+				&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+				encryptionParameters{&object.Encryption},
+				&object.ZombieDeletionDeadline,
+				&object.WriteOnce,
+			)
+			if err != nil {
+				return Error.New("failed to scan objects: %w", err)
+			}
+
+			objects = append(objects, object)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list object versions: %w", err)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestListObjectVersions(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		location := obj.Location()
+
+		// expectedVersions returns the versions of the object from the raw state.
+		expectedVersions := func(t *testing.T) []metabase.Object {
+			objects, err := db.TestingAllObjects(ctx)
+			require.NoError(t, err)
+
+			var versions []metabase.Object
+			for _, object := range objects {
+				if object.Location() == location {
+					versions = append(versions, object)
+				}
+			}
+			sort.Slice(versions, func(i, k int) bool {
+				return versions[i].Version < versions[k].Version
+			})
+			return versions
+		}
+
+		beginNextVersion := func(t *testing.T, version metabase.Version) {
+			metabasetest.BeginObjectNextVersion{
+				Opts: metabase.BeginObjectNextVersion{
+					ObjectStream: metabase.ObjectStream{
+						ProjectID:  obj.ProjectID,
+						BucketName: obj.BucketName,
+						ObjectKey:  obj.ObjectKey,
+						Version:    metabase.NextVersion,
+						StreamID:   obj.StreamID,
+					},
+					Encryption: metabasetest.DefaultEncryption,
+				},
+				Version: version,
+			}.Check(ctx, t, db)
+		}
+
+		commit := func(t *testing.T, version metabase.Version) {
+			stream := obj
+			stream.Version = version
+			metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: stream,
+				},
+			}.Check(ctx, t, db)
+		}
+
+		t.Run("invalid location", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			for _, test := range metabasetest.InvalidObjectLocations(location) {
+				test := test
+				t.Run(test.Name, func(t *testing.T) {
+					_, err := db.ListObjectVersions(ctx, metabase.ListObjectVersions{
+						ObjectLocation: test.ObjectLocation,
+					})
+					require.True(t, metabase.ErrInvalidRequest.Has(err))
+				})
+			}
+		})
+
+		t.Run("no versions", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			versions, err := db.ListObjectVersions(ctx, metabase.ListObjectVersions{
+				ObjectLocation: location,
+			})
+			require.NoError(t, err)
+			require.Empty(t, versions)
+		})
+
+		t.Run("older committed version exists", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			beginNextVersion(t, 1)
+			commit(t, 1)
+			beginNextVersion(t, 2)
+			commit(t, 2)
+
+			// pending versions are listed too.
+			beginNextVersion(t, 3)
+
+			// other keys aren't listed.
+			metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 1)
+
+			versions, err := db.ListObjectVersions(ctx, metabase.ListObjectVersions{
+				ObjectLocation: location,
+			})
+			require.NoError(t, err)
+			require.Len(t, versions, 3)
+			require.Equal(t, expectedVersions(t), versions)
+
+			require.Equal(t, metabase.Committed, versions[0].Status)
+			require.Equal(t, metabase.Committed, versions[1].Status)
+			require.Equal(t, metabase.Pending, versions[2].Status)
+		})
+
+		t.Run("newer committed version exists", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			beginNextVersion(t, 1)
+			beginNextVersion(t, 2)
+			commit(t, 2)
+			commit(t, 1)
+
+			versions, err := db.ListObjectVersions(ctx, metabase.ListObjectVersions{
+				ObjectLocation: location,
+			})
+			require.NoError(t, err)
+			require.Len(t, versions, 2)
+			require.Equal(t, expectedVersions(t), versions)
+			require.EqualValues(t, 1, versions[0].Version)
+			require.EqualValues(t, 2, versions[1].Version)
+		})
+
+		t.Run("sizes", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			versions, err := db.ListObjectVersions(ctx, metabase.ListObjectVersions{
+				ObjectLocation: location,
+			})
+			require.NoError(t, err)
+			require.Equal(t, expectedVersions(t), versions)
+			require.NotZero(t, versions[0].TotalPlainSize)
+			require.EqualValues(t, 2, versions[0].SegmentCount)
+		})
+	})
+}
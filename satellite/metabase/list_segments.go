@@ -262,3 +262,35 @@ func (db *DB) ListStreamPositions(ctx context.Context, opts ListStreamPositions)
 
 	return result, nil
 }
+
+// IterateObjectSegments iterates over all segments of the specified stream in position order.
+// Segments include root piece ID and pieces.
+func (db *DB) IterateObjectSegments(ctx context.Context, streamID uuid.UUID, fn func(Segment) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if streamID.IsZero() {
+		return ErrInvalidRequest.New("StreamID missing")
+	}
+
+	opts := ListSegments{
+		StreamID: streamID,
+		Limit:    ListLimit.Max(),
+	}
+	for {
+		result, err := db.ListSegments(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, segment := range result.Segments {
+			if err := fn(segment); err != nil {
+				return err
+			}
+		}
+
+		if !result.More || len(result.Segments) == 0 {
+			return nil
+		}
+		opts.Cursor = result.Segments[len(result.Segments)-1].Position
+	}
+}
@@ -4,12 +4,17 @@
 package metabase_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metabase/metabasetest"
 )
@@ -718,3 +723,89 @@ func TestListStreamPositions(t *testing.T) {
 		})
 	})
 }
+
+func TestIterateObjectSegments(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		now := time.Now()
+
+		t.Run("StreamID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			err := db.IterateObjectSegments(ctx, uuid.UUID{}, func(metabase.Segment) error {
+				return nil
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+			require.Contains(t, err.Error(), "StreamID missing")
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("no segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			visited := 0
+			err := db.IterateObjectSegments(ctx, obj.StreamID, func(metabase.Segment) error {
+				visited++
+				return nil
+			})
+			require.NoError(t, err)
+			require.Zero(t, visited)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObject(ctx, t, db, obj, 10)
+
+			// segments of another object should not be visited
+			metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 3)
+
+			expectedSegment := metabase.Segment{
+				StreamID: obj.StreamID,
+				Position: metabase.SegmentPosition{
+					Index: 0,
+				},
+				CreatedAt:         now,
+				RootPieceID:       storj.PieceID{1},
+				EncryptedKey:      []byte{3},
+				EncryptedKeyNonce: []byte{4},
+				EncryptedETag:     []byte{5},
+				EncryptedSize:     1024,
+				PlainSize:         512,
+				Pieces:            metabase.Pieces{{Number: 0, StorageNode: storj.NodeID{2}}},
+				Redundancy:        metabasetest.DefaultRedundancy,
+			}
+
+			expectedSegments := make([]metabase.Segment, 10)
+			for i := range expectedSegments {
+				expectedSegment.Position.Index = uint32(i)
+				expectedSegments[i] = expectedSegment
+				expectedSegment.PlainOffset += int64(expectedSegment.PlainSize)
+			}
+
+			var segments []metabase.Segment
+			err := db.IterateObjectSegments(ctx, obj.StreamID, func(segment metabase.Segment) error {
+				segments = append(segments, segment)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Zero(t, cmp.Diff(expectedSegments, segments, metabasetest.DefaultTimeDiff()))
+
+			// iteration should stop on callback error
+			errStop := errors.New("stop")
+			visited := 0
+			err = db.IterateObjectSegments(ctx, obj.StreamID, func(metabase.Segment) error {
+				visited++
+				if visited == 3 {
+					return errStop
+				}
+				return nil
+			})
+			require.ErrorIs(t, err, errStop)
+			require.Equal(t, 3, visited)
+		})
+	})
+}
@@ -200,6 +200,8 @@ func CreateFullObjectsWithKeys(ctx *testcontext.Context, t *testing.T, db *metab
 type CreateTestObject struct {
 	BeginObjectExactVersion *metabase.BeginObjectExactVersion
 	CommitObject            *metabase.CommitObject
+	// Redundancy is used for the committed segments instead of DefaultRedundancy.
+	Redundancy *storj.RedundancyScheme
 	// TODO add BeginSegment, CommitSegment
 }
 
@@ -249,6 +251,9 @@ func (co CreateTestObject) Run(ctx *testcontext.Context, t testing.TB, db *metab
 			PlainOffset:   int64(i) * 512,
 			Redundancy:    DefaultRedundancy,
 		}
+		if co.Redundancy != nil {
+			commitSegmentOpts.Redundancy = *co.Redundancy
+		}
 
 		CommitSegment{
 			Opts: commitSegmentOpts,
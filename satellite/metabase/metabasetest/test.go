@@ -17,6 +17,7 @@ import (
 
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase"
 )
 
@@ -61,6 +62,7 @@ func (step BeginObjectExactVersion) Check(ctx *testcontext.Context, t testing.TB
 			require.WithinDuration(t, *optsDeadline, *gotDeadline, 5*time.Second)
 		}
 		require.Equal(t, step.Opts.Encryption, got.Encryption)
+		require.Equal(t, step.Opts.WriteOnce, got.WriteOnce)
 	}
 }
 
@@ -113,6 +115,40 @@ func (step BeginSegment) Check(ctx *testcontext.Context, t testing.TB, db *metab
 	checkError(t, err, step.ErrClass, step.ErrText)
 }
 
+// BeginSegments is for testing metabase.BeginSegments.
+type BeginSegments struct {
+	Opts []metabase.BeginSegment
+	// Results contains the expected errors of the segments in the order of Opts,
+	// missing entries are expected to succeed.
+	Results  []ExpectedError
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// ExpectedError is an expected error of a single item in a batch.
+type ExpectedError struct {
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step BeginSegments) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	results, err := db.BeginSegments(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	if err != nil {
+		return
+	}
+
+	require.Len(t, results, len(step.Opts))
+	for i, result := range results {
+		var expected ExpectedError
+		if i < len(step.Results) {
+			expected = step.Results[i]
+		}
+		checkError(t, result, expected.ErrClass, expected.ErrText)
+	}
+}
+
 // CommitSegment is for testing metabase.CommitSegment.
 type CommitSegment struct {
 	Opts     metabase.CommitSegment
@@ -197,6 +233,40 @@ func (step GetObjectExactVersion) Check(ctx *testcontext.Context, t testing.TB,
 	require.Zero(t, diff)
 }
 
+// GetObjectLatestVersion is for testing metabase.GetObjectLatestVersion.
+type GetObjectLatestVersion struct {
+	Opts     metabase.GetObjectLatestVersion
+	Result   metabase.Object
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectLatestVersion) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectLatestVersion(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, DefaultTimeDiff())
+	require.Zero(t, diff)
+}
+
+// GetObjectByStreamID is for testing metabase.GetObjectByStreamID.
+type GetObjectByStreamID struct {
+	StreamID uuid.UUID
+	Result   metabase.Object
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectByStreamID) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectByStreamID(ctx, step.StreamID)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, DefaultTimeDiff())
+	require.Zero(t, diff)
+}
+
 // GetSegmentByPosition is for testing metabase.GetSegmentByPosition.
 type GetSegmentByPosition struct {
 	Opts     metabase.GetSegmentByPosition
@@ -302,6 +372,23 @@ func (step GetStreamPieceCountByNodeID) Check(ctx *testcontext.Context, t testin
 	require.Zero(t, diff)
 }
 
+// EstimateObjectRepairCost is for testing metabase.EstimateObjectRepairCost.
+type EstimateObjectRepairCost struct {
+	Opts     metabase.EstimateObjectRepairCost
+	Result   metabase.ObjectRepairCost
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step EstimateObjectRepairCost) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.EstimateObjectRepairCost(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result)
+	require.Zero(t, diff)
+}
+
 // IterateLoopSegments is for testing metabase.IterateLoopSegments.
 type IterateLoopSegments struct {
 	Opts     metabase.IterateLoopSegments
@@ -652,3 +739,20 @@ func (step FinishCopyObject) Check(ctx *testcontext.Context, t testing.TB, db *m
 	require.Zero(t, diff)
 	return result
 }
+
+// FixObjectTotals is for testing metabase.FixObjectTotals.
+type FixObjectTotals struct {
+	Opts     metabase.FixObjectTotals
+	Result   metabase.FixObjectTotalsResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step FixObjectTotals) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.FixObjectTotals(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result)
+	require.Zero(t, diff)
+}
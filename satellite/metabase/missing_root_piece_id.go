@@ -0,0 +1,96 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/tagsql"
+)
+
+// SegmentsCursor is a cursor used when iterating over segments of all streams.
+// Segments are ordered by stream id and position.
+type SegmentsCursor struct {
+	StreamID uuid.UUID
+	Position SegmentPosition
+}
+
+// SegmentMissingRootPieceID contains information about a remote segment
+// without a root piece id.
+type SegmentMissingRootPieceID struct {
+	StreamID uuid.UUID
+	Position SegmentPosition
+
+	CreatedAt     time.Time
+	EncryptedSize int32
+}
+
+// ListSegmentsMissingRootPieceIDResult contains the result of ListSegmentsMissingRootPieceID.
+type ListSegmentsMissingRootPieceIDResult struct {
+	Segments []SegmentMissingRootPieceID
+	// Cursor can be used to continue the listing when More is true.
+	Cursor SegmentsCursor
+	More   bool
+}
+
+// ListSegmentsMissingRootPieceID lists remote segments after the cursor which
+// don't have a root piece id. Such segments can't be downloaded, audited or
+// repaired and should be handled by verification or cleanup tooling.
+//
+// The query scans the whole segments table and shouldn't be used by
+// regular satellite operations.
+func (db *DB) ListSegmentsMissingRootPieceID(ctx context.Context, limit int, cursor SegmentsCursor) (result ListSegmentsMissingRootPieceIDResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit < 0 {
+		return ListSegmentsMissingRootPieceIDResult{}, ErrInvalidRequest.New("Invalid limit: %d", limit)
+	}
+	ListLimit.Ensure(&limit)
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			stream_id, position,
+			created_at, encrypted_size
+		FROM segments
+		WHERE
+			(stream_id, position) > ($1, $2) AND
+			redundancy <> 0 AND
+			(length(root_piece_id) = 0 OR root_piece_id = $3)
+		ORDER BY stream_id, position
+		LIMIT $4
+	`, cursor.StreamID, cursor.Position, storj.PieceID{}, limit+1))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var segment SegmentMissingRootPieceID
+			err := rows.Scan(
+				&segment.StreamID, &segment.Position,
+				&segment.CreatedAt, &segment.EncryptedSize,
+			)
+			if err != nil {
+				return Error.New("failed to scan segments: %w", err)
+			}
+			result.Segments = append(result.Segments, segment)
+		}
+		return nil
+	})
+	if err != nil {
+		return ListSegmentsMissingRootPieceIDResult{}, Error.New("unable to list segments missing root piece id: %w", err)
+	}
+
+	if len(result.Segments) > limit {
+		result.More = true
+		result.Segments = result.Segments[:limit]
+	}
+
+	if len(result.Segments) > 0 {
+		last := result.Segments[len(result.Segments)-1]
+		result.Cursor = SegmentsCursor{StreamID: last.StreamID, Position: last.Position}
+	} else {
+		result.Cursor = cursor
+	}
+
+	return result, nil
+}
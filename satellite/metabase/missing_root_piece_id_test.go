@@ -0,0 +1,105 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestListSegmentsMissingRootPieceID(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("invalid limit", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.ListSegmentsMissingRootPieceID(ctx, -1, metabase.SegmentsCursor{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("no segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			result, err := db.ListSegmentsMissingRootPieceID(ctx, 10, metabase.SegmentsCursor{})
+			require.NoError(t, err)
+			require.Empty(t, result.Segments)
+			require.False(t, result.More)
+		})
+
+		t.Run("reports remote segments without root piece id", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			healthy := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, healthy, 2)
+
+			var broken []metabase.SegmentsCursor
+			for i := 0; i < 3; i++ {
+				obj := metabasetest.RandObjectStream()
+				metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+				position := metabase.SegmentPosition{Index: 1}
+				_, err := db.UnderlyingTagSQL().ExecContext(ctx, `
+					UPDATE segments SET root_piece_id = $3
+					WHERE stream_id = $1 AND position = $2
+				`, obj.StreamID, position, storj.PieceID{})
+				require.NoError(t, err)
+
+				broken = append(broken, metabase.SegmentsCursor{StreamID: obj.StreamID, Position: position})
+			}
+			sort.Slice(broken, func(i, j int) bool {
+				return broken[i].StreamID.Less(broken[j].StreamID)
+			})
+
+			// inline segments legitimately don't have a root piece id
+			inline := metabasetest.RandObjectStream()
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: inline,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: inline.Version,
+			}.Check(ctx, t, db)
+			metabasetest.CommitInlineSegment{
+				Opts: metabase.CommitInlineSegment{
+					ObjectStream: inline,
+					Position:     metabase.SegmentPosition{Part: 0, Index: 0},
+					InlineData:   []byte{1, 2, 3},
+
+					EncryptedKey:      testrand.Bytes(32),
+					EncryptedKeyNonce: testrand.Bytes(32),
+
+					PlainSize:   512,
+					PlainOffset: 0,
+				},
+			}.Check(ctx, t, db)
+
+			var listed []metabase.SegmentsCursor
+			cursor := metabase.SegmentsCursor{}
+			for {
+				result, err := db.ListSegmentsMissingRootPieceID(ctx, 2, cursor)
+				require.NoError(t, err)
+
+				for _, segment := range result.Segments {
+					require.NotZero(t, segment.EncryptedSize)
+					require.False(t, segment.CreatedAt.IsZero())
+					listed = append(listed, metabase.SegmentsCursor{StreamID: segment.StreamID, Position: segment.Position})
+				}
+
+				if !result.More {
+					break
+				}
+				cursor = result.Cursor
+			}
+
+			require.Equal(t, broken, listed)
+		})
+	})
+}
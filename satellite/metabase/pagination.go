@@ -0,0 +1,115 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+
+	"storj.io/common/uuid"
+)
+
+// paginationTokenVersion is the encoding version of PaginationToken.
+const paginationTokenVersion = 1
+
+// PaginationToken is an opaque token which allows resuming an object listing
+// from the position where a previous listing stopped.
+type PaginationToken struct {
+	ProjectID  uuid.UUID
+	BucketName string
+	Cursor     IterateCursor
+}
+
+// NewPaginationToken returns a token for resuming the listing after the
+// specified entry.
+func NewPaginationToken(projectID uuid.UUID, bucketName string, last ObjectEntry) PaginationToken {
+	return PaginationToken{
+		ProjectID:  projectID,
+		BucketName: bucketName,
+		Cursor: IterateCursor{
+			Key:     last.ObjectKey,
+			Version: last.Version,
+		},
+	}
+}
+
+// Encode encodes the token into an URL safe string.
+func (token PaginationToken) Encode() string {
+	data := make([]byte, 0, 1+len(token.ProjectID)+2*binary.MaxVarintLen64+len(token.BucketName)+len(token.Cursor.Key)+binary.MaxVarintLen64)
+
+	data = append(data, paginationTokenVersion)
+	data = append(data, token.ProjectID[:]...)
+	data = appendBytes(data, []byte(token.BucketName))
+	data = appendBytes(data, []byte(token.Cursor.Key))
+	data = appendVarint(data, int64(token.Cursor.Version))
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePaginationToken decodes a token created by PaginationToken.Encode.
+func DecodePaginationToken(encoded string) (token PaginationToken, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return PaginationToken{}, ErrInvalidRequest.New("invalid pagination token: %v", err)
+	}
+
+	if len(data) < 1 || data[0] != paginationTokenVersion {
+		return PaginationToken{}, ErrInvalidRequest.New("invalid pagination token: unsupported version")
+	}
+	data = data[1:]
+
+	if len(data) < len(token.ProjectID) {
+		return PaginationToken{}, ErrInvalidRequest.New("invalid pagination token: project id missing")
+	}
+	copy(token.ProjectID[:], data)
+	data = data[len(token.ProjectID):]
+
+	bucketName, data, ok := readBytes(data)
+	if !ok {
+		return PaginationToken{}, ErrInvalidRequest.New("invalid pagination token: bucket name missing")
+	}
+	token.BucketName = string(bucketName)
+
+	key, data, ok := readBytes(data)
+	if !ok {
+		return PaginationToken{}, ErrInvalidRequest.New("invalid pagination token: object key missing")
+	}
+	token.Cursor.Key = ObjectKey(key)
+
+	version, n := binary.Varint(data)
+	if n <= 0 || n != len(data) {
+		return PaginationToken{}, ErrInvalidRequest.New("invalid pagination token: version missing")
+	}
+	token.Cursor.Version = Version(version)
+
+	return token, nil
+}
+
+// Verify checks whether the token was issued for the specified listing.
+func (token PaginationToken) Verify(projectID uuid.UUID, bucketName string) error {
+	if token.ProjectID != projectID || token.BucketName != bucketName {
+		return ErrInvalidRequest.New("pagination token does not match the listed bucket")
+	}
+	return nil
+}
+
+func appendVarint(data []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	return append(data, buf[:n]...)
+}
+
+func appendBytes(data, v []byte) []byte {
+	data = appendVarint(data, int64(len(v)))
+	return append(data, v...)
+}
+
+func readBytes(data []byte) (v, rest []byte, ok bool) {
+	length, n := binary.Varint(data)
+	if n <= 0 || length < 0 || int64(len(data)-n) < length {
+		return nil, data, false
+	}
+	data = data[n:]
+	return data[:length], data[length:], true
+}
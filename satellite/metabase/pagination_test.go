@@ -0,0 +1,76 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestPaginationToken(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		token := metabase.PaginationToken{
+			ProjectID:  uuid.UUID{1, 2, 3},
+			BucketName: "bucket",
+			Cursor: metabase.IterateCursor{
+				Key:     "a/b/c\x00\xff",
+				Version: 12345,
+			},
+		}
+
+		decoded, err := metabase.DecodePaginationToken(token.Encode())
+		require.NoError(t, err)
+		require.Equal(t, token, decoded)
+
+		require.NoError(t, decoded.Verify(token.ProjectID, token.BucketName))
+		require.True(t, metabase.ErrInvalidRequest.Has(decoded.Verify(token.ProjectID, "other")))
+		require.True(t, metabase.ErrInvalidRequest.Has(decoded.Verify(uuid.UUID{4}, token.BucketName)))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		valid := metabase.PaginationToken{BucketName: "bucket", Cursor: metabase.IterateCursor{Key: "key", Version: 1}}.Encode()
+
+		for _, encoded := range []string{"", "!!!", "AA", valid[:len(valid)-2], valid + "AA"} {
+			_, err := metabase.DecodePaginationToken(encoded)
+			require.True(t, metabase.ErrInvalidRequest.Has(err), encoded)
+		}
+	})
+}
+
+func TestPaginationTokenResume(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		projectID, bucketName := uuid.UUID{1}, "bucket"
+		createObjects(ctx, t, db, 5, projectID, bucketName)
+
+		opts := metabase.IterateObjectsWithStatus{
+			ProjectID:  projectID,
+			BucketName: bucketName,
+			Recursive:  true,
+			Status:     metabase.Committed,
+		}
+
+		var all metabasetest.IterateCollector
+		require.NoError(t, db.IterateObjectsAllVersionsWithStatus(ctx, opts, all.Add))
+		require.Len(t, all, 5)
+
+		// pretend the first listing stopped after the second entry
+		encoded := metabase.NewPaginationToken(projectID, bucketName, all[1]).Encode()
+
+		token, err := metabase.DecodePaginationToken(encoded)
+		require.NoError(t, err)
+		require.NoError(t, token.Verify(projectID, bucketName))
+
+		opts.Cursor = token.Cursor
+
+		var resumed metabasetest.IterateCollector
+		require.NoError(t, db.IterateObjectsAllVersionsWithStatus(ctx, opts, resumed.Add))
+		require.Equal(t, all[2:], resumed)
+	})
+}
@@ -39,6 +39,12 @@ type RawObject struct {
 	// This is as a safeguard against objects that failed to upload and the client has not indicated
 	// whether they want to continue uploading or delete the already uploaded data.
 	ZombieDeletionDeadline *time.Time
+
+	// WriteOnce indicates that the object cannot be overwritten once committed.
+	WriteOnce bool
+
+	// PlaintextTags are unencrypted tags, e.g. used by lifecycle operations.
+	PlaintextTags map[string]string
 }
 
 // RawSegment defines the full segment that is stored in the database. It should be rarely used directly.
@@ -110,6 +116,7 @@ func (db *DB) TestingDeleteAll(ctx context.Context) (err error) {
 		DELETE FROM objects;
 		DELETE FROM segments;
 		DELETE FROM segment_copies;
+		DELETE FROM segment_health;
 		DELETE FROM node_aliases;
 		SELECT setval('node_alias_seq', 1, false);
 	`)
@@ -129,7 +136,9 @@ func (db *DB) testingGetAllObjects(ctx context.Context) (_ []RawObject, err erro
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
 			encryption,
-			zombie_deletion_deadline
+			zombie_deletion_deadline,
+			write_once,
+			plaintext_tags
 		FROM objects
 		ORDER BY project_id ASC, bucket_name ASC, object_key ASC, version ASC
 	`)
@@ -162,6 +171,8 @@ func (db *DB) testingGetAllObjects(ctx context.Context) (_ []RawObject, err erro
 
 			encryptionParameters{&obj.Encryption},
 			&obj.ZombieDeletionDeadline,
+			&obj.WriteOnce,
+			plaintextTags{&obj.PlaintextTags},
 		)
 		if err != nil {
 			return nil, Error.New("testingGetAllObjects scan failed: %w", err)
@@ -0,0 +1,115 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/tagsql"
+)
+
+// EstimateObjectRepairCost contains arguments for EstimateObjectRepairCost.
+type EstimateObjectRepairCost struct {
+	StreamID uuid.UUID
+}
+
+// SegmentRepairCost is the estimated cost of repairing a single segment.
+type SegmentRepairCost struct {
+	Position SegmentPosition
+
+	// HealthyPieces is the number of pieces considered healthy.
+	HealthyPieces int
+	// MissingPieces is the number of pieces which need to be uploaded
+	// to bring the segment back to the optimal number of pieces.
+	MissingPieces int
+	// RepairBytes is the number of bytes which need to be uploaded.
+	RepairBytes int64
+}
+
+// ObjectRepairCost is the estimated cost of repairing all segments of an object.
+type ObjectRepairCost struct {
+	Segments []SegmentRepairCost
+
+	MissingPieces int
+	RepairBytes   int64
+}
+
+// EstimateObjectRepairCost estimates how many pieces of the object's remote
+// segments need to be re-uploaded to reach the optimal number of pieces.
+//
+// The healthy piece count is taken from the stored segment health summary
+// when there is one, otherwise all pieces of the segment are considered
+// healthy. Inline segments and segments of copies, which don't have pieces of
+// their own, aren't included.
+func (db *DB) EstimateObjectRepairCost(ctx context.Context, opts EstimateObjectRepairCost) (result ObjectRepairCost, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.StreamID.IsZero() {
+		return ObjectRepairCost{}, ErrInvalidRequest.New("StreamID missing")
+	}
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			segments.position,
+			segments.encrypted_size,
+			segments.redundancy,
+			segments.remote_alias_pieces,
+			segment_health.healthy_pieces
+		FROM segments
+		LEFT JOIN segment_health
+			ON segment_health.stream_id = segments.stream_id AND segment_health.position = segments.position
+		WHERE
+			segments.stream_id = $1 AND
+			segments.remote_alias_pieces IS NOT NULL
+		ORDER BY segments.position ASC
+	`, opts.StreamID))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var position SegmentPosition
+			var encryptedSize int32
+			var redundancy storj.RedundancyScheme
+			var aliasPieces AliasPieces
+			var storedHealthy sql.NullInt32
+			err = rows.Scan(
+				&position,
+				&encryptedSize,
+				redundancyScheme{&redundancy},
+				&aliasPieces,
+				&storedHealthy,
+			)
+			if err != nil {
+				return Error.New("failed to scan segments: %w", err)
+			}
+
+			segment := SegmentRepairCost{
+				Position:      position,
+				HealthyPieces: len(aliasPieces),
+			}
+			if storedHealthy.Valid && int(storedHealthy.Int32) < segment.HealthyPieces {
+				segment.HealthyPieces = int(storedHealthy.Int32)
+			}
+			if missing := int(redundancy.OptimalShares) - segment.HealthyPieces; missing > 0 {
+				segment.MissingPieces = missing
+				pieceSize := int64(redundancy.StripeCount(encryptedSize)) * int64(redundancy.ShareSize)
+				segment.RepairBytes = int64(missing) * pieceSize
+			}
+
+			result.Segments = append(result.Segments, segment)
+			result.MissingPieces += segment.MissingPieces
+			result.RepairBytes += segment.RepairBytes
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ObjectRepairCost{}, nil
+		}
+		return ObjectRepairCost{}, Error.New("unable to fetch object segments: %w", err)
+	}
+
+	return result, nil
+}
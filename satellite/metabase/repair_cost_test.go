@@ -0,0 +1,142 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestEstimateObjectRepairCost(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		t.Run("StreamID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.EstimateObjectRepairCost{
+				Opts:     metabase.EstimateObjectRepairCost{},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "StreamID missing",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("no segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.EstimateObjectRepairCost{
+				Opts: metabase.EstimateObjectRepairCost{
+					StreamID: obj.StreamID,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("partially damaged object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			redundancy := storj.RedundancyScheme{
+				Algorithm:      storj.ReedSolomon,
+				ShareSize:      256,
+				RequiredShares: 2,
+				RepairShares:   3,
+				OptimalShares:  4,
+				TotalShares:    5,
+			}
+
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: 1,
+			}.Check(ctx, t, db)
+
+			pieces := make(metabase.Pieces, redundancy.OptimalShares)
+			for i := range pieces {
+				pieces[i] = metabase.Piece{Number: uint16(i), StorageNode: testrand.NodeID()}
+			}
+
+			for index := uint32(0); index < 3; index++ {
+				metabasetest.CommitSegment{
+					Opts: metabase.CommitSegment{
+						ObjectStream: obj,
+						Position:     metabase.SegmentPosition{Index: index},
+						RootPieceID:  testrand.PieceID(),
+						Pieces:       pieces,
+
+						EncryptedKey:      testrand.Bytes(32),
+						EncryptedKeyNonce: testrand.Bytes(32),
+
+						// a single stripe, so that the piece size is the share size.
+						EncryptedSize: 512,
+						PlainSize:     500,
+						Redundancy:    redundancy,
+					},
+				}.Check(ctx, t, db)
+			}
+
+			// the first segment lost a piece.
+			metabasetest.UpdateSegmentPieces{
+				Opts: metabase.UpdateSegmentPieces{
+					StreamID:      obj.StreamID,
+					Position:      metabase.SegmentPosition{Index: 0},
+					OldPieces:     pieces,
+					NewRedundancy: redundancy,
+					NewPieces:     pieces[:3],
+				},
+			}.Check(ctx, t, db)
+
+			// two pieces of the second segment are on unreliable nodes.
+			err := db.UpsertSegmentHealth(ctx, []metabase.SegmentHealth{{
+				StreamID:           obj.StreamID,
+				Position:           metabase.SegmentPosition{Index: 1},
+				HealthyPieces:      2,
+				PlacementCompliant: true,
+				CheckedAt:          time.Now(),
+			}})
+			require.NoError(t, err)
+
+			shareSize := int64(redundancy.ShareSize)
+			metabasetest.EstimateObjectRepairCost{
+				Opts: metabase.EstimateObjectRepairCost{
+					StreamID: obj.StreamID,
+				},
+				Result: metabase.ObjectRepairCost{
+					Segments: []metabase.SegmentRepairCost{
+						{
+							Position:      metabase.SegmentPosition{Index: 0},
+							HealthyPieces: 3,
+							MissingPieces: 1,
+							RepairBytes:   shareSize,
+						},
+						{
+							Position:      metabase.SegmentPosition{Index: 1},
+							HealthyPieces: 2,
+							MissingPieces: 2,
+							RepairBytes:   2 * shareSize,
+						},
+						{
+							Position:      metabase.SegmentPosition{Index: 2},
+							HealthyPieces: 4,
+						},
+					},
+					MissingPieces: 3,
+					RepairBytes:   3 * shareSize,
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
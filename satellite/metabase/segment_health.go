@@ -0,0 +1,134 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgtype"
+
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+)
+
+// SegmentHealth is a compact health summary of a segment, which is
+// periodically recomputed so that it doesn't need to be computed on demand.
+type SegmentHealth struct {
+	StreamID uuid.UUID
+	Position SegmentPosition
+
+	// HealthyPieces is the number of pieces stored on reliable nodes.
+	HealthyPieces int32
+	// PlacementCompliant is false when some of the pieces are stored on
+	// nodes not allowed by the placement of the segment.
+	PlacementCompliant bool
+	CheckedAt          time.Time
+}
+
+// UpsertSegmentHealth stores the health summaries of segments, replacing the
+// existing summaries of the same segments.
+func (db *DB) UpsertSegmentHealth(ctx context.Context, health []SegmentHealth) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(health) == 0 {
+		return nil
+	}
+
+	var batch struct {
+		StreamIDs          []uuid.UUID
+		Positions          []int64
+		HealthyPieces      []int32
+		PlacementCompliant []bool
+		CheckedAt          []time.Time
+	}
+	for _, h := range health {
+		batch.StreamIDs = append(batch.StreamIDs, h.StreamID)
+		batch.Positions = append(batch.Positions, int64(h.Position.Encode()))
+		batch.HealthyPieces = append(batch.HealthyPieces, h.HealthyPieces)
+		batch.PlacementCompliant = append(batch.PlacementCompliant, h.PlacementCompliant)
+		batch.CheckedAt = append(batch.CheckedAt, h.CheckedAt)
+	}
+
+	_, err = db.db.ExecContext(ctx, `
+		INSERT INTO segment_health (
+			stream_id, position,
+			healthy_pieces, placement_compliant, checked_at
+		)
+		SELECT
+			unnest($1::BYTEA[]), unnest($2::INT8[]),
+			unnest($3::INT4[]), unnest($4::BOOLEAN[]), unnest($5::TIMESTAMPTZ[])
+		ON CONFLICT (stream_id, position)
+		DO UPDATE SET
+			healthy_pieces      = EXCLUDED.healthy_pieces,
+			placement_compliant = EXCLUDED.placement_compliant,
+			checked_at          = EXCLUDED.checked_at
+	`, pgutil.UUIDArray(batch.StreamIDs), pgutil.Int8Array(batch.Positions),
+		pgutil.Int4Array(batch.HealthyPieces), boolArray(batch.PlacementCompliant),
+		pgutil.TimestampTZArray(batch.CheckedAt))
+	if err != nil {
+		return Error.New("unable to store segment health: %w", err)
+	}
+	return nil
+}
+
+// GetSegmentHealth returns the stored health summary of a segment.
+func (db *DB) GetSegmentHealth(ctx context.Context, streamID uuid.UUID, position SegmentPosition) (health SegmentHealth, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if streamID.IsZero() {
+		return SegmentHealth{}, ErrInvalidRequest.New("StreamID missing")
+	}
+
+	health = SegmentHealth{StreamID: streamID, Position: position}
+	err = db.db.QueryRowContext(ctx, `
+		SELECT healthy_pieces, placement_compliant, checked_at
+		FROM segment_health
+		WHERE stream_id = $1 AND position = $2
+	`, streamID, position).Scan(&health.HealthyPieces, &health.PlacementCompliant, &health.CheckedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return SegmentHealth{}, ErrSegmentNotFound.New("segment health missing")
+	}
+	if err != nil {
+		return SegmentHealth{}, Error.New("unable to query segment health: %w", err)
+	}
+	return health, nil
+}
+
+// DeleteSegmentHealthCheckedBefore deletes health summaries which weren't
+// updated since the specified time, e.g. of segments which were deleted.
+func (db *DB) DeleteSegmentHealthCheckedBefore(ctx context.Context, before time.Time) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := db.db.ExecContext(ctx, `
+		DELETE FROM segment_health
+		WHERE checked_at < $1
+	`, before)
+	if err != nil {
+		return 0, Error.New("unable to delete segment health: %w", err)
+	}
+
+	deleted, err = result.RowsAffected()
+	if err != nil {
+		return 0, Error.New("unable to delete segment health: %w", err)
+	}
+	return deleted, nil
+}
+
+// boolArray returns an object usable by pg drivers for passing a []bool slice
+// into a database as type BOOLEAN[].
+func boolArray(bools []bool) *pgtype.BoolArray {
+	pgtypeBoolArray := make([]pgtype.Bool, len(bools))
+	for i, b := range bools {
+		pgtypeBoolArray[i].Bool = b
+		pgtypeBoolArray[i].Status = pgtype.Present
+	}
+	return &pgtype.BoolArray{
+		Elements:   pgtypeBoolArray,
+		Dimensions: []pgtype.ArrayDimension{{Length: int32(len(bools)), LowerBound: 1}},
+		Status:     pgtype.Present,
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/txutil"
+	"storj.io/private/tagsql"
+	"storj.io/storj/storage"
+)
+
+// SegmentPiecesSnapshot contains piece lists of all segments of a stream
+// at the time of the snapshot.
+type SegmentPiecesSnapshot struct {
+	StreamID uuid.UUID
+	Segments []SnapshotSegment
+}
+
+// SnapshotSegment contains the pieces of a single segment.
+type SnapshotSegment struct {
+	Position   SegmentPosition
+	Redundancy storj.RedundancyScheme
+	Pieces     Pieces
+}
+
+// SnapshotSegmentPieces returns piece lists of all segments of the stream.
+func (db *DB) SnapshotSegmentPieces(ctx context.Context, streamID uuid.UUID) (_ SegmentPiecesSnapshot, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if streamID.IsZero() {
+		return SegmentPiecesSnapshot{}, ErrInvalidRequest.New("StreamID missing")
+	}
+
+	snapshot := SegmentPiecesSnapshot{StreamID: streamID}
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT position, redundancy, remote_alias_pieces
+		FROM segments
+		WHERE stream_id = $1
+		ORDER BY position
+	`, streamID))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var segment SnapshotSegment
+			var aliasPieces AliasPieces
+			err := rows.Scan(&segment.Position, redundancyScheme{&segment.Redundancy}, &aliasPieces)
+			if err != nil {
+				return Error.New("failed to scan segments: %w", err)
+			}
+
+			segment.Pieces, err = db.aliasCache.ConvertAliasesToPieces(ctx, aliasPieces)
+			if err != nil {
+				return Error.New("failed to convert aliases to pieces: %w", err)
+			}
+
+			snapshot.Segments = append(snapshot.Segments, segment)
+		}
+		return nil
+	})
+	if err != nil {
+		return SegmentPiecesSnapshot{}, Error.New("unable to fetch segment pieces: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// RestoreSegmentPieces contains arguments necessary for restoring segment pieces.
+type RestoreSegmentPieces struct {
+	// Current is a snapshot of the present state of the segments. It's used
+	// as a concurrency token, restoring fails when any segment was changed since.
+	Current SegmentPiecesSnapshot
+	// Snapshot is the state to restore.
+	Snapshot SegmentPiecesSnapshot
+}
+
+// Verify verifies request fields.
+func (opts *RestoreSegmentPieces) Verify() error {
+	switch {
+	case opts.Snapshot.StreamID.IsZero():
+		return ErrInvalidRequest.New("StreamID missing")
+	case opts.Current.StreamID != opts.Snapshot.StreamID:
+		return ErrInvalidRequest.New("Current and Snapshot StreamID don't match")
+	case len(opts.Current.Segments) != len(opts.Snapshot.Segments):
+		return ErrInvalidRequest.New("Current and Snapshot segments don't match")
+	}
+
+	for i, segment := range opts.Snapshot.Segments {
+		if opts.Current.Segments[i].Position != segment.Position {
+			return ErrInvalidRequest.New("Current and Snapshot segments don't match")
+		}
+		if len(segment.Pieces) == 0 {
+			// inline segments don't have pieces.
+			continue
+		}
+		if err := segment.Pieces.Verify(); err != nil {
+			if ErrInvalidRequest.Has(err) {
+				return ErrInvalidRequest.New("Snapshot segment %d/%d: %v", segment.Position.Part, segment.Position.Index, errs.Unwrap(err))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreSegmentPieces restores piece lists of the segments from a snapshot.
+// If any segment doesn't match the current state given in the request, nothing
+// is restored.
+func (db *DB) RestoreSegmentPieces(ctx context.Context, opts RestoreSegmentPieces) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		for i, segment := range opts.Snapshot.Segments {
+			current := opts.Current.Segments[i]
+			if len(current.Pieces) == 0 && len(segment.Pieces) == 0 {
+				continue
+			}
+
+			currentPieces, err := db.aliasCache.ConvertPiecesToAliases(ctx, current.Pieces)
+			if err != nil {
+				return Error.New("unable to convert pieces to aliases: %w", err)
+			}
+
+			restoredPieces, err := db.aliasCache.ConvertPiecesToAliases(ctx, segment.Pieces)
+			if err != nil {
+				return Error.New("unable to convert pieces to aliases: %w", err)
+			}
+
+			result, err := tx.ExecContext(ctx, `
+				UPDATE segments SET
+					remote_alias_pieces = $4,
+					redundancy = $5
+				WHERE
+					stream_id = $1 AND
+					position = $2 AND
+					remote_alias_pieces = $3
+			`, opts.Snapshot.StreamID, segment.Position, currentPieces, restoredPieces, redundancyScheme{&segment.Redundancy})
+			if err != nil {
+				return Error.New("unable to restore segment pieces: %w", err)
+			}
+
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return Error.New("unable to restore segment pieces: %w", err)
+			}
+			if affected != 1 {
+				return storage.ErrValueChanged.New("segment %d/%d remote_alias_pieces field was changed", segment.Position.Part, segment.Position.Index)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	mon.Meter("segment_pieces_restore").Mark(1)
+
+	return nil
+}
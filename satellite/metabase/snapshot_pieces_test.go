@@ -0,0 +1,102 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+	"storj.io/storj/storage"
+)
+
+func TestSnapshotRestoreSegmentPieces(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("StreamID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.SnapshotSegmentPieces(ctx, uuid.UUID{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+			err = db.RestoreSegmentPieces(ctx, metabase.RestoreSegmentPieces{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("mismatched segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			snapshot, err := db.SnapshotSegmentPieces(ctx, obj.StreamID)
+			require.NoError(t, err)
+
+			current := snapshot
+			current.Segments = current.Segments[:1]
+
+			err = db.RestoreSegmentPieces(ctx, metabase.RestoreSegmentPieces{
+				Current:  current,
+				Snapshot: snapshot,
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("restore", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, obj, 3)
+
+			original, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+
+			snapshot, err := db.SnapshotSegmentPieces(ctx, obj.StreamID)
+			require.NoError(t, err)
+			require.Len(t, snapshot.Segments, 3)
+
+			// a bad bulk update replaces the pieces of all segments
+			for _, segment := range snapshot.Segments {
+				err := db.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+					StreamID:      obj.StreamID,
+					Position:      segment.Position,
+					OldPieces:     segment.Pieces,
+					NewRedundancy: segment.Redundancy,
+					NewPieces: metabase.Pieces{{
+						Number:      segment.Pieces[0].Number,
+						StorageNode: testrand.NodeID(),
+					}},
+				})
+				require.NoError(t, err)
+			}
+
+			current, err := db.SnapshotSegmentPieces(ctx, obj.StreamID)
+			require.NoError(t, err)
+			require.NotEqual(t, snapshot, current)
+
+			// the stale snapshot can't be used as the concurrency token
+			err = db.RestoreSegmentPieces(ctx, metabase.RestoreSegmentPieces{
+				Current:  snapshot,
+				Snapshot: snapshot,
+			})
+			require.True(t, storage.ErrValueChanged.Has(err))
+
+			restored, err := db.SnapshotSegmentPieces(ctx, obj.StreamID)
+			require.NoError(t, err)
+			require.Equal(t, current, restored)
+
+			err = db.RestoreSegmentPieces(ctx, metabase.RestoreSegmentPieces{
+				Current:  current,
+				Snapshot: snapshot,
+			})
+			require.NoError(t, err)
+
+			metabasetest.Verify(*original).Check(ctx, t, db)
+		})
+	})
+}
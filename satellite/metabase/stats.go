@@ -10,6 +10,8 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/errs2"
+	"storj.io/common/storj"
+	"storj.io/private/tagsql"
 )
 
 // GetTableStats contains arguments necessary for getting table statistics.
@@ -39,3 +41,40 @@ func (db *DB) GetTableStats(ctx context.Context, opts GetTableStats) (result Tab
 	err = errs.Combine(group.Wait()...)
 	return result, err
 }
+
+// SegmentRedundancyCount contains the number of segments using a redundancy scheme.
+type SegmentRedundancyCount struct {
+	Redundancy storj.RedundancyScheme
+	Count      int64
+}
+
+// CountSegmentsByRedundancy returns the number of segments for every distinct
+// redundancy scheme. Inline segments are counted under the zero redundancy scheme.
+//
+// The query scans the whole segments table and shouldn't be used by regular
+// satellite operations.
+func (db *DB) CountSegmentsByRedundancy(ctx context.Context) (counts []SegmentRedundancyCount, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT redundancy, count(*)
+		FROM segments
+		GROUP BY redundancy
+		ORDER BY redundancy
+	`))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var count SegmentRedundancyCount
+			err := rows.Scan(redundancyScheme{&count.Redundancy}, &count.Count)
+			if err != nil {
+				return Error.New("failed to scan segment counts: %w", err)
+			}
+			counts = append(counts, count)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to count segments by redundancy: %w", err)
+	}
+
+	return counts, nil
+}
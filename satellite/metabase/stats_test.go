@@ -7,6 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/private/dbutil"
 	"storj.io/storj/satellite/metabase"
@@ -91,3 +94,41 @@ func TestGetTableStats(t *testing.T) {
 		}
 	})
 }
+
+func TestCountSegmentsByRedundancy(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("no segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			counts, err := db.CountSegmentsByRedundancy(ctx)
+			require.NoError(t, err)
+			require.Empty(t, counts)
+		})
+
+		t.Run("multiple schemes", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			otherRedundancy := storj.RedundancyScheme{
+				Algorithm:      storj.ReedSolomon,
+				ShareSize:      256,
+				RequiredShares: 29,
+				RepairShares:   35,
+				OptimalShares:  80,
+				TotalShares:    110,
+			}
+
+			metabasetest.CreateTestObject{}.Run(ctx, t, db, metabasetest.RandObjectStream(), 4)
+			metabasetest.CreateTestObject{}.Run(ctx, t, db, metabasetest.RandObjectStream(), 1)
+			metabasetest.CreateTestObject{
+				Redundancy: &otherRedundancy,
+			}.Run(ctx, t, db, metabasetest.RandObjectStream(), 3)
+
+			counts, err := db.CountSegmentsByRedundancy(ctx)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []metabase.SegmentRedundancyCount{
+				{Redundancy: metabasetest.DefaultRedundancy, Count: 5},
+				{Redundancy: otherRedundancy, Count: 3},
+			}, counts)
+		})
+	})
+}
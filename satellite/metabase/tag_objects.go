@@ -0,0 +1,116 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"storj.io/common/uuid"
+	"storj.io/private/tagsql"
+)
+
+// tagObjectsBatchSize is the number of objects tagged by a single query.
+const tagObjectsBatchSize = 1000
+
+// TagObjectsByPrefix adds plaintext tags to all committed objects in the bucket
+// whose key starts with prefix. Existing tags with the same name are overwritten.
+// Objects are updated in batches and the number of tagged objects is returned.
+func (db *DB) TagObjectsByPrefix(ctx context.Context, projectID uuid.UUID, bucketName string, prefix ObjectKey, tags map[string]string) (tagged int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	switch {
+	case projectID.IsZero():
+		return 0, ErrInvalidRequest.New("ProjectID missing")
+	case bucketName == "":
+		return 0, ErrInvalidRequest.New("BucketName missing")
+	case len(tags) == 0:
+		return 0, ErrInvalidRequest.New("Tags missing")
+	}
+
+	for name := range tags {
+		if name == "" {
+			return 0, ErrInvalidRequest.New("tag name missing")
+		}
+	}
+
+	// the first batch starts from the prefix itself, which is a valid key.
+	cursor := iterateCursor{Key: prefix, Version: -1}
+	limit := prefixLimit(prefix)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return tagged, err
+		}
+
+		count, last, err := db.tagObjectsBatch(ctx, projectID, bucketName, cursor, limit, tags)
+		if err != nil {
+			return tagged, err
+		}
+
+		tagged += count
+		if count < tagObjectsBatchSize {
+			mon.Meter("objects_tagged").Mark64(tagged)
+			return tagged, nil
+		}
+		cursor = last
+	}
+}
+
+// tagObjectsBatch tags the next batch of objects after the cursor and returns
+// the number of tagged objects together with the last tagged object.
+func (db *DB) tagObjectsBatch(ctx context.Context, projectID uuid.UUID, bucketName string, cursor iterateCursor, limit ObjectKey, tags map[string]string) (count int64, last iterateCursor, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limitCondition := ""
+	args := []interface{}{
+		projectID, []byte(bucketName),
+		[]byte(cursor.Key), cursor.Version,
+		tagObjectsBatchSize,
+		plaintextTags{&tags},
+	}
+	if limit != "" {
+		limitCondition = "AND object_key < $7"
+		args = append(args, []byte(limit))
+	}
+
+	err = withRows(db.db.QueryContext(ctx, `
+		WITH batch AS (
+			SELECT object_key, version
+			FROM objects
+			WHERE
+				project_id = $1 AND bucket_name = $2 AND
+				(object_key, version) > ($3, $4)
+				`+limitCondition+` AND
+				status = `+committedStatus+`
+			ORDER BY project_id, bucket_name, object_key, version
+			LIMIT $5
+		)
+		UPDATE objects
+		SET plaintext_tags = coalesce(objects.plaintext_tags, '{}'::JSONB) || $6::JSONB
+		FROM batch
+		WHERE
+			objects.project_id  = $1 AND
+			objects.bucket_name = $2 AND
+			objects.object_key  = batch.object_key AND
+			objects.version     = batch.version
+		RETURNING objects.object_key, objects.version
+	`, args...))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var entry iterateCursor
+			if err := rows.Scan(&entry.Key, &entry.Version); err != nil {
+				return Error.New("failed to scan objects: %w", err)
+			}
+			// returned rows are not ordered.
+			if count == 0 || lessKey(last.Key, entry.Key) || (last.Key == entry.Key && last.Version < entry.Version) {
+				last = entry
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, iterateCursor{}, Error.New("unable to tag objects: %w", err)
+	}
+	return count, last, nil
+}
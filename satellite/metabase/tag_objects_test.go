@@ -0,0 +1,108 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestTagObjectsByPrefix(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		projectID, bucketName := testrand.UUID(), "bucket"
+
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.TagObjectsByPrefix(ctx, uuid.UUID{}, bucketName, "", map[string]string{"a": "b"})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+			_, err = db.TagObjectsByPrefix(ctx, projectID, "", "", map[string]string{"a": "b"})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+			_, err = db.TagObjectsByPrefix(ctx, projectID, bucketName, "", nil)
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+			_, err = db.TagObjectsByPrefix(ctx, projectID, bucketName, "", map[string]string{"": "b"})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("only objects under prefix", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			createObjectsWithKeys(ctx, t, db, projectID, bucketName, []metabase.ObjectKey{
+				"logs", "logs/a", "logs/b/c", "logs0", "other/logs/a",
+			})
+			createObjectsWithKeys(ctx, t, db, projectID, "other-bucket", []metabase.ObjectKey{"logs/a"})
+
+			pending := metabasetest.RandObjectStream()
+			pending.ProjectID, pending.BucketName, pending.ObjectKey = projectID, bucketName, "logs/pending"
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: pending,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: pending.Version,
+			}.Check(ctx, t, db)
+
+			tagged, err := db.TagObjectsByPrefix(ctx, projectID, bucketName, "logs/", map[string]string{"lifecycle": "expire"})
+			require.NoError(t, err)
+			require.EqualValues(t, 2, tagged)
+
+			// existing tags are kept and overwritten by name.
+			tagged, err = db.TagObjectsByPrefix(ctx, projectID, bucketName, "logs/b/", map[string]string{"lifecycle": "archive", "tier": "cold"})
+			require.NoError(t, err)
+			require.EqualValues(t, 1, tagged)
+
+			state, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+
+			tags := map[string]map[string]string{}
+			for _, obj := range state.Objects {
+				if obj.ProjectID == projectID && obj.BucketName == bucketName {
+					tags[string(obj.ObjectKey)] = obj.PlaintextTags
+				}
+			}
+
+			require.Equal(t, map[string]map[string]string{
+				"logs":         nil,
+				"logs/a":       {"lifecycle": "expire"},
+				"logs/b/c":     {"lifecycle": "archive", "tier": "cold"},
+				"logs/pending": nil,
+				"logs0":        nil,
+				"other/logs/a": nil,
+			}, tags)
+
+			for _, obj := range state.Objects {
+				if obj.BucketName == "other-bucket" {
+					require.Nil(t, obj.PlaintextTags)
+				}
+			}
+		})
+
+		t.Run("many batches", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			objects := createObjects(ctx, t, db, 1100, projectID, bucketName)
+
+			tagged, err := db.TagObjectsByPrefix(ctx, projectID, bucketName, "", map[string]string{"a": "b"})
+			require.NoError(t, err)
+			require.EqualValues(t, len(objects), tagged)
+
+			state, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+			require.Len(t, state.Objects, len(objects))
+			for _, obj := range state.Objects {
+				require.Equal(t, map[string]string{"a": "b"}, obj.PlaintextTags)
+			}
+		})
+	})
+}
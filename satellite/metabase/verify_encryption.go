@@ -0,0 +1,125 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/private/tagsql"
+)
+
+// ErrObjectEncryptionInconsistent is used when the encryption of an object
+// doesn't match the encryption of its segments.
+var ErrObjectEncryptionInconsistent = errs.Class("object encryption inconsistent")
+
+// VerifyObjectEncryptionConsistency verifies that the object has encryption
+// parameters and that all of its segments are encrypted compatibly with them,
+// i.e. all segments have encrypted keys and nonces of the same length.
+//
+// All found inconsistencies are reported in a single ErrObjectEncryptionInconsistent error.
+func (db *DB) VerifyObjectEncryptionConsistency(ctx context.Context, opts ObjectStream) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	var encryption storj.EncryptionParameters
+	err = db.db.QueryRowContext(ctx, `
+		SELECT encryption
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      = $4 AND
+			stream_id    = $5
+	`, opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version, opts.StreamID).
+		Scan(encryptionParameters{&encryption})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storj.ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return Error.New("unable to query object: %w", err)
+	}
+
+	if encryption.IsZero() {
+		return ErrObjectEncryptionInconsistent.New("Encryption is missing")
+	}
+
+	var inconsistencies []string
+	report := func(position SegmentPosition, format string, args ...interface{}) {
+		inconsistencies = append(inconsistencies,
+			fmt.Sprintf("segment %d/%d: ", position.Part, position.Index)+fmt.Sprintf(format, args...))
+	}
+
+	encrypted := encryption.CipherSuite != storj.EncNull
+	keyLength, nonceLength := -1, -1
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			position,
+			encrypted_key_nonce, encrypted_key,
+			encrypted_size, plain_size
+		FROM segments
+		WHERE stream_id = $1
+		ORDER BY position
+	`, opts.StreamID))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var position SegmentPosition
+			var encryptedKeyNonce, encryptedKey []byte
+			var encryptedSize, plainSize int32
+			err := rows.Scan(
+				&position,
+				&encryptedKeyNonce, &encryptedKey,
+				&encryptedSize, &plainSize,
+			)
+			if err != nil {
+				return Error.New("failed to scan segments: %w", err)
+			}
+
+			if !encrypted {
+				continue
+			}
+
+			switch {
+			case len(encryptedKey) == 0:
+				report(position, "encrypted key is missing")
+			case keyLength < 0:
+				keyLength = len(encryptedKey)
+			case keyLength != len(encryptedKey):
+				report(position, "encrypted key length %d doesn't match %d", len(encryptedKey), keyLength)
+			}
+
+			switch {
+			case len(encryptedKeyNonce) == 0:
+				report(position, "encrypted key nonce is missing")
+			case nonceLength < 0:
+				nonceLength = len(encryptedKeyNonce)
+			case nonceLength != len(encryptedKeyNonce):
+				report(position, "encrypted key nonce length %d doesn't match %d", len(encryptedKeyNonce), nonceLength)
+			}
+
+			if encryptedSize < plainSize {
+				report(position, "encrypted size %d is smaller than plain size %d", encryptedSize, plainSize)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Error.New("unable to fetch object segments: %w", err)
+	}
+
+	if len(inconsistencies) > 0 {
+		return ErrObjectEncryptionInconsistent.New("%s", strings.Join(inconsistencies, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestVerifyObjectEncryptionConsistency(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			err := db.VerifyObjectEncryptionConsistency(ctx, metabase.ObjectStream{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("missing object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			err := db.VerifyObjectEncryptionConsistency(ctx, metabasetest.RandObjectStream())
+			require.True(t, storj.ErrObjectNotFound.Has(err))
+		})
+
+		t.Run("consistent object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, obj, 3)
+
+			require.NoError(t, db.VerifyObjectEncryptionConsistency(ctx, obj))
+		})
+
+		t.Run("missing encryption", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+				},
+				Version: obj.Version,
+			}.Check(ctx, t, db)
+
+			err := db.VerifyObjectEncryptionConsistency(ctx, obj)
+			require.True(t, metabase.ErrObjectEncryptionInconsistent.Has(err))
+			require.EqualError(t, err, metabase.ErrObjectEncryptionInconsistent.New("Encryption is missing").Error())
+		})
+
+		t.Run("mixed encryption", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, obj, 3)
+
+			_, err := db.UnderlyingTagSQL().ExecContext(ctx, `
+				UPDATE segments SET encrypted_key = $3, encrypted_key_nonce = $4
+				WHERE stream_id = $1 AND position = $2
+			`, obj.StreamID, metabase.SegmentPosition{Index: 1}, []byte{3, 3}, []byte{})
+			require.NoError(t, err)
+
+			err = db.VerifyObjectEncryptionConsistency(ctx, obj)
+			require.True(t, metabase.ErrObjectEncryptionInconsistent.Has(err))
+			require.EqualError(t, err, metabase.ErrObjectEncryptionInconsistent.New(
+				"segment 0/1: encrypted key length 2 doesn't match 1; "+
+					"segment 0/1: encrypted key nonce is missing").Error())
+		})
+	})
+}
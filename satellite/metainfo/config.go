@@ -138,4 +138,9 @@ type Config struct {
 	PieceDeletion               piecedeletion.Config `help:"piece deletion configuration"`
 	// TODO remove this flag when server-side copy implementation will be finished
 	ServerSideCopy bool `help:"enable code for server-side copy" default:"true"`
+	// WriteOnceObjects prevents committed objects from being overwritten.
+	WriteOnceObjects bool `help:"prevent committed objects from being overwritten" default:"false"`
+	// IdempotentSegmentCommit makes the etag of a committed segment the expected etag of
+	// the segment it replaces, so that a retried commit doesn't clobber a different part.
+	IdempotentSegmentCommit bool `help:"reject committing a segment with an etag over an existing segment with a different etag" default:"false"`
 }
@@ -76,6 +76,7 @@ type Endpoint struct {
 	apiKeys              APIKeys
 	satellite            signing.Signer
 	limiterCache         *lrucache.ExpiringLRU
+	egressLimiterCache   *lrucache.ExpiringLRU
 	encInlineSegmentSize int64 // max inline segment size + encryption overhead
 	revocations          revocation.DB
 	defaultRS            *pb.RedundancyScheme
@@ -126,6 +127,10 @@ func NewEndpoint(log *zap.Logger, buckets *buckets.Service, metabaseDB *metabase
 			Capacity:   config.RateLimiter.CacheCapacity,
 			Expiration: config.RateLimiter.CacheExpiration,
 		}),
+		egressLimiterCache: lrucache.New(lrucache.Options{
+			Capacity:   config.RateLimiter.CacheCapacity,
+			Expiration: config.RateLimiter.CacheExpiration,
+		}),
 		encInlineSegmentSize: encInlineSegmentSize,
 		revocations:          revocations,
 		defaultRS:            defaultRSScheme,
@@ -274,8 +279,12 @@ func (endpoint *Endpoint) convertMetabaseErr(err error) error {
 		return rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
 	case metabase.ErrObjectAlreadyExists.Has(err):
 		return rpcstatus.Error(rpcstatus.AlreadyExists, err.Error())
+	case metabase.ErrObjectWriteOnce.Has(err):
+		return rpcstatus.Error(rpcstatus.FailedPrecondition, err.Error())
 	case metabase.ErrPendingObjectMissing.Has(err):
 		return rpcstatus.Error(rpcstatus.NotFound, err.Error())
+	case metabase.ErrSegmentETagMismatch.Has(err):
+		return rpcstatus.Error(rpcstatus.FailedPrecondition, err.Error())
 	default:
 		endpoint.log.Error("internal", zap.Error(err))
 		return rpcstatus.Error(rpcstatus.Internal, err.Error())
@@ -80,7 +80,7 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 	}
 
 	// TODO this needs to be optimized to avoid DB call on each request
-	placement, err := endpoint.buckets.GetBucketPlacement(ctx, req.Bucket, keyInfo.ProjectID)
+	placement, versioning, err := endpoint.buckets.GetBucketPlacementAndVersioning(ctx, req.Bucket, keyInfo.ProjectID)
 	if err != nil {
 		if storj.ErrBucketNotFound.Has(err) {
 			return nil, rpcstatus.Errorf(rpcstatus.NotFound, "bucket not found: %s", req.Bucket)
@@ -89,26 +89,37 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
 
-	if canDelete {
-		_, err = endpoint.DeleteObjectAnyStatus(ctx, metabase.ObjectLocation{
-			ProjectID:  keyInfo.ProjectID,
-			BucketName: string(req.Bucket),
-			ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
+	}
+
+	// without versioning the latest version is overwritten, which is
+	// the default version unless versioning was enabled before.
+	version := metabase.DefaultVersion
+	if !versioning {
+		latest, err := endpoint.metabase.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
+			ObjectLocation: location,
 		})
-		if err != nil && !storj.ErrObjectNotFound.Has(err) {
-			return nil, err
+		switch {
+		case err == nil:
+			if !canDelete {
+				return nil, rpcstatus.Error(rpcstatus.PermissionDenied, "Unauthorized API credentials")
+			}
+			if latest.WriteOnce {
+				return nil, endpoint.convertMetabaseErr(metabase.ErrObjectWriteOnce.New(""))
+			}
+			version = latest.Version
+		case !storj.ErrObjectNotFound.Has(err):
+			return nil, endpoint.convertMetabaseErr(err)
 		}
-	} else {
-		_, err = endpoint.metabase.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
-			ObjectLocation: metabase.ObjectLocation{
-				ProjectID:  keyInfo.ProjectID,
-				BucketName: string(req.Bucket),
-				ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
-			},
-			Version: metabase.DefaultVersion,
-		})
-		if err == nil {
-			return nil, rpcstatus.Error(rpcstatus.PermissionDenied, "Unauthorized API credentials")
+
+		if canDelete {
+			_, err = endpoint.deleteObjectAnyStatus(ctx, location, version)
+			if err != nil && !storj.ErrObjectNotFound.Has(err) {
+				return nil, err
+			}
 		}
 	}
 
@@ -141,23 +152,49 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 		nonce = req.EncryptedMetadataNonce[:]
 	}
 
-	object, err := endpoint.metabase.BeginObjectExactVersion(ctx, metabase.BeginObjectExactVersion{
-		ObjectStream: metabase.ObjectStream{
-			ProjectID:  keyInfo.ProjectID,
-			BucketName: string(req.Bucket),
-			ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
-			StreamID:   streamID,
-			Version:    metabase.DefaultVersion,
-		},
-		ExpiresAt:  expiresAt,
-		Encryption: encryptionParameters,
+	objectStream := metabase.ObjectStream{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
+		StreamID:   streamID,
+		Version:    version,
+	}
 
-		EncryptedMetadata:             req.EncryptedMetadata,
-		EncryptedMetadataEncryptedKey: req.EncryptedMetadataEncryptedKey,
-		EncryptedMetadataNonce:        nonce,
-	})
-	if err != nil {
-		return nil, endpoint.convertMetabaseErr(err)
+	var object metabase.Object
+	if versioning {
+		objectStream.Version = metabase.NextVersion
+		objectStream.Version, err = endpoint.metabase.BeginObjectNextVersion(ctx, metabase.BeginObjectNextVersion{
+			ObjectStream: objectStream,
+			ExpiresAt:    expiresAt,
+			Encryption:   encryptionParameters,
+			WriteOnce:    endpoint.config.WriteOnceObjects,
+
+			EncryptedMetadata:             req.EncryptedMetadata,
+			EncryptedMetadataEncryptedKey: req.EncryptedMetadataEncryptedKey,
+			EncryptedMetadataNonce:        nonce,
+		})
+		if err != nil {
+			return nil, endpoint.convertMetabaseErr(err)
+		}
+		object = metabase.Object{
+			ObjectStream: objectStream,
+			CreatedAt:    now,
+			Status:       metabase.Pending,
+		}
+	} else {
+		object, err = endpoint.metabase.BeginObjectExactVersion(ctx, metabase.BeginObjectExactVersion{
+			ObjectStream: objectStream,
+			ExpiresAt:    expiresAt,
+			Encryption:   encryptionParameters,
+			WriteOnce:    endpoint.config.WriteOnceObjects,
+
+			EncryptedMetadata:             req.EncryptedMetadata,
+			EncryptedMetadataEncryptedKey: req.EncryptedMetadataEncryptedKey,
+			EncryptedMetadataNonce:        nonce,
+		})
+		if err != nil {
+			return nil, endpoint.convertMetabaseErr(err)
+		}
 	}
 
 	satStreamID, err := endpoint.packStreamID(ctx, &internalpb.StreamID{
@@ -235,7 +272,7 @@ func (endpoint *Endpoint) CommitObject(ctx context.Context, req *pb.ObjectCommit
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedObjectKey),
 			StreamID:   id,
-			Version:    metabase.DefaultVersion,
+			Version:    metabase.Version(streamID.Version),
 		},
 		Encryption: encryption,
 	}
@@ -286,13 +323,12 @@ func (endpoint *Endpoint) GetObject(ctx context.Context, req *pb.ObjectGetReques
 		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
 	}
 
-	mbObject, err := endpoint.metabase.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
+	mbObject, err := endpoint.metabase.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
 		ObjectLocation: metabase.ObjectLocation{
 			ProjectID:  keyInfo.ProjectID,
 			BucketName: string(req.Bucket),
 			ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
 		},
-		Version: metabase.DefaultVersion,
 	})
 	if err != nil {
 		return nil, endpoint.convertMetabaseErr(err)
@@ -384,13 +420,12 @@ func (endpoint *Endpoint) DownloadObject(ctx context.Context, req *pb.ObjectDown
 
 	// get the object information
 
-	object, err := endpoint.metabase.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
+	object, err := endpoint.metabase.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
 		ObjectLocation: metabase.ObjectLocation{
 			ProjectID:  keyInfo.ProjectID,
 			BucketName: string(req.Bucket),
 			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
 		},
-		Version: metabase.DefaultVersion,
 	})
 	if err != nil {
 		return nil, endpoint.convertMetabaseErr(err)
@@ -431,6 +466,10 @@ func (endpoint *Endpoint) DownloadObject(ctx context.Context, req *pb.ObjectDown
 
 		downloadSizes := endpoint.calculateDownloadSizes(streamRange, segment, object.Encryption)
 
+		if err := endpoint.checkEgressRate(ctx, keyInfo.ProjectID, downloadSizes.encryptedSize); err != nil {
+			return nil, err
+		}
+
 		// Update the current bandwidth cache value incrementing the SegmentSize.
 		err = endpoint.projectUsage.UpdateProjectBandwidthUsage(ctx, keyInfo.ProjectID, downloadSizes.encryptedSize)
 		if err != nil {
@@ -1007,13 +1046,12 @@ func (endpoint *Endpoint) GetObjectIPs(ctx context.Context, req *pb.ObjectGetIPs
 	}
 
 	// TODO we may need custom metabase request to avoid two DB calls
-	object, err := endpoint.metabase.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
+	object, err := endpoint.metabase.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
 		ObjectLocation: metabase.ObjectLocation{
 			ProjectID:  keyInfo.ProjectID,
 			BucketName: string(req.Bucket),
 			ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
 		},
-		Version: metabase.DefaultVersion,
 	})
 	if err != nil {
 		return nil, endpoint.convertMetabaseErr(err)
@@ -1305,15 +1343,17 @@ func (endpoint *Endpoint) DeleteCommittedObject(
 		ObjectKey:  object,
 	}
 
-	var result metabase.DeleteObjectResult
-	if endpoint.config.ServerSideCopy {
-		result, err = endpoint.metabase.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
-			ObjectLocation: req,
-			Version:        metabase.DefaultVersion,
-		})
-	} else {
-		result, err = endpoint.metabase.DeleteObjectsAllVersions(ctx, metabase.DeleteObjectsAllVersions{Locations: []metabase.ObjectLocation{req}})
+	version, err := endpoint.resolveObjectVersion(ctx, req)
+	if err != nil {
+		return nil, Error.Wrap(err)
 	}
+
+	// only the latest version is deleted, the same one which reads return,
+	// so older versions are kept.
+	result, err := endpoint.metabase.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+		ObjectLocation: req,
+		Version:        version,
+	})
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1338,20 +1378,24 @@ func (endpoint *Endpoint) DeleteCommittedObject(
 // NOTE: this method is exported for being able to individually test it without
 // having import cycles.
 func (endpoint *Endpoint) DeleteObjectAnyStatus(ctx context.Context, location metabase.ObjectLocation,
+) (deletedObjects []*pb.Object, err error) {
+	version, err := endpoint.resolveObjectVersion(ctx, location)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return endpoint.deleteObjectAnyStatus(ctx, location, version)
+}
+
+// deleteObjectAnyStatus deletes the specified object version. Other versions
+// of the object are kept.
+func (endpoint *Endpoint) deleteObjectAnyStatus(ctx context.Context, location metabase.ObjectLocation, version metabase.Version,
 ) (deletedObjects []*pb.Object, err error) {
 	defer mon.Task()(&ctx, location.ProjectID.String(), location.BucketName, location.ObjectKey)(&err)
 
-	var result metabase.DeleteObjectResult
-	if endpoint.config.ServerSideCopy {
-		result, err = endpoint.metabase.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
-			ObjectLocation: location,
-			Version:        metabase.DefaultVersion,
-		})
-	} else {
-		result, err = endpoint.metabase.DeleteObjectAnyStatusAllVersions(ctx, metabase.DeleteObjectAnyStatusAllVersions{
-			ObjectLocation: location,
-		})
-	}
+	result, err := endpoint.metabase.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+		ObjectLocation: location,
+		Version:        version,
+	})
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1370,6 +1414,25 @@ func (endpoint *Endpoint) DeleteObjectAnyStatus(ctx context.Context, location me
 	return deletedObjects, nil
 }
 
+// resolveObjectVersion returns the latest committed version of the object,
+// which is the version reads return, or the default version when there is
+// no committed version.
+func (endpoint *Endpoint) resolveObjectVersion(ctx context.Context, location metabase.ObjectLocation) (_ metabase.Version, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	latest, err := endpoint.metabase.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
+		ObjectLocation: location,
+	})
+	if err != nil {
+		if storj.ErrObjectNotFound.Has(err) {
+			return metabase.DefaultVersion, nil
+		}
+		return 0, err
+	}
+
+	return latest.Version, nil
+}
+
 // DeletePendingObject deletes all the pieces of the storage nodes that belongs
 // to the specified pending object.
 //
@@ -1514,13 +1577,20 @@ func (endpoint *Endpoint) BeginMoveObject(ctx context.Context, req *pb.ObjectBeg
 		}
 	}
 
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+
+	version, err := endpoint.resolveObjectVersion(ctx, location)
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
 	result, err := endpoint.metabase.BeginMoveObject(ctx, metabase.BeginMoveObject{
-		ObjectLocation: metabase.ObjectLocation{
-			ProjectID:  keyInfo.ProjectID,
-			BucketName: string(req.Bucket),
-			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
-		},
-		Version: metabase.DefaultVersion,
+		ObjectLocation: location,
+		Version:        version,
 	})
 	if err != nil {
 		return nil, endpoint.convertMetabaseErr(err)
@@ -1535,7 +1605,7 @@ func (endpoint *Endpoint) BeginMoveObject(ctx context.Context, req *pb.ObjectBeg
 	satStreamID, err := endpoint.packStreamID(ctx, &internalpb.StreamID{
 		Bucket:             req.Bucket,
 		EncryptedObjectKey: req.EncryptedObjectKey,
-		Version:            int32(metabase.DefaultVersion),
+		Version:            int32(version),
 		StreamId:           result.StreamID[:],
 		EncryptionParameters: &pb.EncryptionParameters{
 			CipherSuite: pb.CipherSuite(result.EncryptionParameters.CipherSuite),
@@ -1650,7 +1720,7 @@ func (endpoint *Endpoint) FinishMoveObject(ctx context.Context, req *pb.ObjectFi
 			ProjectID:  keyInfo.ProjectID,
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedObjectKey),
-			Version:    metabase.DefaultVersion,
+			Version:    metabase.Version(streamID.Version),
 			StreamID:   streamUUID,
 		},
 		NewSegmentKeys:               protobufkeysToMetabase(req.NewSegmentKeys),
@@ -1740,13 +1810,20 @@ func (endpoint *Endpoint) BeginCopyObject(ctx context.Context, req *pb.ObjectBeg
 		}
 	}
 
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+
+	version, err := endpoint.resolveObjectVersion(ctx, location)
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
 	result, err := endpoint.metabase.BeginCopyObject(ctx, metabase.BeginCopyObject{
-		ObjectLocation: metabase.ObjectLocation{
-			ProjectID:  keyInfo.ProjectID,
-			BucketName: string(req.Bucket),
-			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
-		},
-		Version: metabase.DefaultVersion,
+		ObjectLocation: location,
+		Version:        version,
 	})
 	if err != nil {
 		return nil, endpoint.convertMetabaseErr(err)
@@ -1761,7 +1838,7 @@ func (endpoint *Endpoint) BeginCopyObject(ctx context.Context, req *pb.ObjectBeg
 	satStreamID, err := endpoint.packStreamID(ctx, &internalpb.StreamID{
 		Bucket:             req.Bucket,
 		EncryptedObjectKey: req.EncryptedObjectKey,
-		Version:            int32(metabase.DefaultVersion),
+		Version:            int32(version),
 		StreamId:           result.StreamID[:],
 		EncryptionParameters: &pb.EncryptionParameters{
 			CipherSuite: pb.CipherSuite(result.EncryptionParameters.CipherSuite),
@@ -1885,7 +1962,7 @@ func (endpoint *Endpoint) FinishCopyObject(ctx context.Context, req *pb.ObjectFi
 			ProjectID:  keyInfo.ProjectID,
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedObjectKey),
-			Version:    metabase.DefaultVersion,
+			Version:    metabase.Version(streamID.Version),
 			StreamID:   streamUUID,
 		},
 		NewStreamID:                  newStreamID,
@@ -983,6 +983,71 @@ func TestEndpoint_DeletePendingObject(t *testing.T) {
 	testDeleteObject(t, createObject, deleteAllObjects)
 }
 
+func TestEndpoint_ObjectVersioning(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		projectID := planet.Uplinks[0].Projects[0].ID
+		buckets := satellite.API.Buckets.Service
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "testbucket"))
+		require.NoError(t, buckets.SetBucketVersioning(ctx, []byte("testbucket"), projectID, true))
+
+		first := testrand.Bytes(100)
+		second := testrand.Bytes(100)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "object", first))
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "object", second))
+
+		objects, err := satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		data, err := planet.Uplinks[0].Download(ctx, satellite, "testbucket", "object")
+		require.NoError(t, err)
+		require.Equal(t, second, data)
+
+		// without versioning only the latest version is overwritten
+		require.NoError(t, buckets.SetBucketVersioning(ctx, []byte("testbucket"), projectID, false))
+
+		third := testrand.Bytes(100)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "object", third))
+
+		objects, err = satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		data, err = planet.Uplinks[0].Download(ctx, satellite, "testbucket", "object")
+		require.NoError(t, err)
+		require.Equal(t, third, data)
+
+		// move and delete act on the latest version as well
+		project, err := planet.Uplinks[0].OpenProject(ctx, satellite)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		require.NoError(t, project.MoveObject(ctx, "testbucket", "object", "testbucket", "moved", nil))
+
+		data, err = planet.Uplinks[0].Download(ctx, satellite, "testbucket", "moved")
+		require.NoError(t, err)
+		require.Equal(t, third, data)
+
+		data, err = planet.Uplinks[0].Download(ctx, satellite, "testbucket", "object")
+		require.NoError(t, err)
+		require.Equal(t, first, data)
+
+		_, err = project.DeleteObject(ctx, "testbucket", "object")
+		require.NoError(t, err)
+
+		_, err = planet.Uplinks[0].Download(ctx, satellite, "testbucket", "object")
+		require.True(t, errs.Is(err, uplink.ErrObjectNotFound), err)
+
+		objects, err = satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+	})
+}
+
 func TestEndpoint_DeleteObjectAnyStatus(t *testing.T) {
 	bucketName := "a-bucket"
 	createCommittedObject := func(ctx context.Context, t *testing.T, planet *testplanet.Planet, data []byte) {
@@ -102,7 +102,7 @@ func (endpoint *Endpoint) BeginSegment(ctx context.Context, req *pb.SegmentBegin
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedObjectKey),
 			StreamID:   id,
-			Version:    1,
+			Version:    metabase.Version(streamID.Version),
 		},
 		Position: metabase.SegmentPosition{
 			Part:  uint32(req.Position.PartNumber),
@@ -248,7 +248,7 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedObjectKey),
 			StreamID:   id,
-			Version:    1,
+			Version:    metabase.Version(streamID.Version),
 		},
 		ExpiresAt:         expiresAt,
 		EncryptedKey:      req.EncryptedKey,
@@ -268,6 +268,9 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 		Pieces:      pieces,
 		Placement:   storj.PlacementConstraint(streamID.Placement),
 	}
+	if endpoint.config.IdempotentSegmentCommit {
+		mbCommitSegment.ExpectedETag = req.EncryptedETag
+	}
 
 	err = endpoint.validateRemoteSegment(ctx, mbCommitSegment, originalLimits)
 	if err != nil {
@@ -364,7 +367,7 @@ func (endpoint *Endpoint) MakeInlineSegment(ctx context.Context, req *pb.Segment
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedObjectKey),
 			StreamID:   id,
-			Version:    1,
+			Version:    metabase.Version(streamID.Version),
 		},
 		ExpiresAt:         expiresAt,
 		EncryptedKey:      req.EncryptedKey,
@@ -561,6 +564,10 @@ func (endpoint *Endpoint) DownloadSegment(ctx context.Context, req *pb.SegmentDo
 		return nil, endpoint.convertMetabaseErr(err)
 	}
 
+	if err := endpoint.checkEgressRate(ctx, keyInfo.ProjectID, int64(segment.EncryptedSize)); err != nil {
+		return nil, err
+	}
+
 	// Update the current bandwidth cache value incrementing the SegmentSize.
 	err = endpoint.projectUsage.UpdateProjectBandwidthUsage(ctx, keyInfo.ProjectID, int64(segment.EncryptedSize))
 	if err != nil {
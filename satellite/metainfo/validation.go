@@ -18,6 +18,7 @@ import (
 	"storj.io/common/encryption"
 	"storj.io/common/errs2"
 	"storj.io/common/macaroon"
+	"storj.io/common/memory"
 	"storj.io/common/pb"
 	"storj.io/common/rpc/rpcstatus"
 	"storj.io/common/storj"
@@ -200,6 +201,61 @@ func (endpoint *Endpoint) checkRate(ctx context.Context, projectID uuid.UUID) (e
 	return nil
 }
 
+// checkEgressRate checks whether downloading size bytes exceeds the egress rate limit of the project,
+// the lower of the limits set by the owner and by an admin. Downloads of projects without an egress
+// rate limit are not limited.
+//
+// The limiters are local to each API instance, so the egress rate of a project served by several
+// instances can reach the limit multiplied by the number of instances.
+func (endpoint *Endpoint) checkEgressRate(ctx context.Context, projectID uuid.UUID, size int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cached, err := endpoint.egressLimiterCache.Get(projectID.String(), func() (interface{}, error) {
+		project, err := endpoint.projects.Get(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		var limit memory.Size
+		for _, projectLimit := range []*memory.Size{project.EgressRateLimit, project.AdminEgressRateLimit} {
+			if projectLimit != nil && *projectLimit > 0 && (limit == 0 || *projectLimit < limit) {
+				limit = *projectLimit
+			}
+		}
+		if limit == 0 {
+			return (*rate.Limiter)(nil), nil
+		}
+
+		return rate.NewLimiter(rate.Limit(limit.Int()), limit.Int()), nil
+	})
+	if err != nil {
+		return rpcstatus.Error(rpcstatus.Unavailable, err.Error())
+	}
+
+	limiter := cached.(*rate.Limiter)
+	if limiter == nil {
+		return nil
+	}
+
+	// segments larger than the limit consume the whole burst, otherwise
+	// they could never be downloaded.
+	if size > int64(limiter.Burst()) {
+		size = int64(limiter.Burst())
+	}
+
+	if !limiter.AllowN(time.Now(), int(size)) {
+		endpoint.log.Warn("egress rate limit exceeded for project",
+			zap.Stringer("projectID", projectID),
+			zap.Float64("egress rate limit", float64(limiter.Limit())))
+
+		mon.Event("metainfo_egress_rate_limit_exceeded")
+
+		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Egress Rate Limit")
+	}
+
+	return nil
+}
+
 func (endpoint *Endpoint) validateBucket(ctx context.Context, bucket []byte) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
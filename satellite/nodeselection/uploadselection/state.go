@@ -88,6 +88,7 @@ type Request struct {
 	NewFraction          float64
 	Distinct             bool
 	ExcludedIDs          []storj.NodeID
+	ExcludedNetworks     []string // subnets which are excluded in addition to the subnets of ExcludedIDs, only used with Distinct
 	Placement            storj.PlacementConstraint
 	ExcludedCountryCodes []string
 }
@@ -126,6 +127,9 @@ func (state *State) Select(ctx context.Context, request Request) (_ []*Node, err
 				criteria.AutoExcludeSubnets[net] = struct{}{}
 			}
 		}
+		for _, net := range request.ExcludedNetworks {
+			criteria.AutoExcludeSubnets[net] = struct{}{}
+		}
 		reputableNodes = state.distinct.Reputable
 		newNodes = state.distinct.New
 	} else {
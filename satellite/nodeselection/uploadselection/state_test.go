@@ -73,6 +73,19 @@ func TestState_Select(t *testing.T) {
 		require.Len(t, selected, 2)
 	}
 
+	{ // select 2 distinct subnet reputable nodes, when one of the subnets is excluded
+		const selectCount = 2
+		selected, err := state.Select(ctx, uploadselection.Request{
+			Count:            selectCount,
+			NewFraction:      0,
+			Distinct:         true,
+			ExcludedNetworks: []string{"1.0.1"},
+		})
+		require.Error(t, err)
+		require.Len(t, selected, 1)
+		require.Equal(t, "1.0.2", selected[0].LastNet)
+	}
+
 	{ // select 6 non-distinct subnet reputable and new nodes (50%)
 		const selectCount = 6
 		const newFraction = 0.5
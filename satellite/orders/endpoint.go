@@ -36,6 +36,8 @@ type DB interface {
 	UpdateBucketBandwidthSettle(ctx context.Context, projectID uuid.UUID, bucketName []byte, action pb.PieceAction, settledAmount, deadAmount int64, intervalStart time.Time) error
 	// UpdateBucketBandwidthInline updates 'inline' bandwidth for given bucket
 	UpdateBucketBandwidthInline(ctx context.Context, projectID uuid.UUID, bucketName []byte, action pb.PieceAction, amount int64, intervalStart time.Time) error
+	// UpdateRepairBandwidthAllocation updates 'allocated' repair bandwidth for given placement
+	UpdateRepairBandwidthAllocation(ctx context.Context, placement storj.PlacementConstraint, action pb.PieceAction, amount int64, intervalStart time.Time) error
 	// UpdateBandwidthBatch updates bucket and project bandwidth rollups in the database
 	UpdateBandwidthBatch(ctx context.Context, rollups []BucketBandwidthRollup) error
 
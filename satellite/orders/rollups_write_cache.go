@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/pb"
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/common/uuid"
 )
@@ -35,6 +36,16 @@ type CacheKey struct {
 // RollupData contains the pending rollups waiting to be flushed to the db.
 type RollupData map[CacheKey]CacheData
 
+// RepairCacheKey is the key information for the cached repair bandwidth allocations.
+type RepairCacheKey struct {
+	Placement     storj.PlacementConstraint
+	Action        pb.PieceAction
+	IntervalStart int64
+}
+
+// RepairRollupData contains the pending repair bandwidth allocations waiting to be flushed to the db.
+type RepairRollupData map[RepairCacheKey]int64
+
 // RollupsWriteCache stores information needed to update bucket bandwidth rollups.
 type RollupsWriteCache struct {
 	DB
@@ -42,10 +53,11 @@ type RollupsWriteCache struct {
 	wg        sync.WaitGroup
 	log       *zap.Logger
 
-	mu             sync.Mutex
-	pendingRollups RollupData
-	stopped        bool
-	flushing       bool
+	mu                   sync.Mutex
+	pendingRollups       RollupData
+	pendingRepairRollups RepairRollupData
+	stopped              bool
+	flushing             bool
 
 	nextFlushCompletion *sync2.Fence
 }
@@ -53,11 +65,12 @@ type RollupsWriteCache struct {
 // NewRollupsWriteCache creates an RollupsWriteCache.
 func NewRollupsWriteCache(log *zap.Logger, db DB, batchSize int) *RollupsWriteCache {
 	return &RollupsWriteCache{
-		DB:                  db,
-		batchSize:           batchSize,
-		log:                 log,
-		pendingRollups:      make(RollupData),
-		nextFlushCompletion: new(sync2.Fence),
+		DB:                   db,
+		batchSize:            batchSize,
+		log:                  log,
+		pendingRollups:       make(RollupData),
+		pendingRepairRollups: make(RepairRollupData),
+		nextFlushCompletion:  new(sync2.Fence),
 	}
 }
 
@@ -76,13 +89,44 @@ func (cache *RollupsWriteCache) UpdateBucketBandwidthSettle(ctx context.Context,
 	return cache.updateCacheValue(ctx, projectID, bucketName, action, 0, 0, settledAmount, deadAmount, intervalStart.UTC())
 }
 
+// UpdateRepairBandwidthAllocation updates the rollups cache adding allocated repair bandwidth for a placement.
+// The allocations are written to the db together with the bucket bandwidth rollups.
+func (cache *RollupsWriteCache) UpdateRepairBandwidthAllocation(ctx context.Context, placement storj.PlacementConstraint, action pb.PieceAction, amount int64, intervalStart time.Time) error {
+	defer mon.Task()(&ctx)(nil)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.stopped {
+		return Error.New("RollupsWriteCache is stopped")
+	}
+
+	intervalStart = intervalStart.UTC()
+	key := RepairCacheKey{
+		Placement:     placement,
+		Action:        action,
+		IntervalStart: time.Date(intervalStart.Year(), intervalStart.Month(), intervalStart.Day(), intervalStart.Hour(), 0, 0, 0, intervalStart.Location()).Unix(),
+	}
+
+	if _, ok := cache.pendingRepairRollups[key]; !ok && len(cache.pendingRepairRollups) >= cache.batchSize {
+		mon.Event("rollups_write_cache_repair_update_lost")
+		return nil
+	}
+	cache.pendingRepairRollups[key] += amount
+
+	return nil
+}
+
 // resetCache should only be called after you have acquired the cache lock. It
-// will reset the various cache values and return the pendingRollups.
-func (cache *RollupsWriteCache) resetCache() RollupData {
+// will reset the various cache values and return the pending rollups.
+func (cache *RollupsWriteCache) resetCache() (RollupData, RepairRollupData) {
 	pendingRollups := cache.pendingRollups
 	cache.pendingRollups = make(RollupData)
 
-	return pendingRollups
+	pendingRepairRollups := cache.pendingRepairRollups
+	cache.pendingRepairRollups = make(RepairRollupData)
+
+	return pendingRollups, pendingRepairRollups
 }
 
 // Flush resets cache then flushes the everything in the rollups write cache to the database.
@@ -106,11 +150,11 @@ func (cache *RollupsWriteCache) Flush(ctx context.Context) {
 	}
 
 	cache.flushing = true
-	pendingRollups := cache.resetCache()
+	pendingRollups, pendingRepairRollups := cache.resetCache()
 
 	cache.mu.Unlock()
 
-	cache.flush(ctx, pendingRollups)
+	cache.flush(ctx, pendingRollups, pendingRepairRollups)
 }
 
 // CloseAndFlush flushes anything in the cache and marks the cache as stopped.
@@ -126,7 +170,7 @@ func (cache *RollupsWriteCache) CloseAndFlush(ctx context.Context) error {
 }
 
 // flush flushes the everything in the rollups write cache to the database.
-func (cache *RollupsWriteCache) flush(ctx context.Context, pendingRollups RollupData) {
+func (cache *RollupsWriteCache) flush(ctx context.Context, pendingRollups RollupData, pendingRepairRollups RepairRollupData) {
 	defer mon.Task()(&ctx)(nil)
 
 	if len(pendingRollups) > 0 {
@@ -151,6 +195,14 @@ func (cache *RollupsWriteCache) flush(ctx context.Context, pendingRollups Rollup
 		}
 	}
 
+	for cacheKey, allocated := range pendingRepairRollups {
+		err := cache.DB.UpdateRepairBandwidthAllocation(ctx, cacheKey.Placement, cacheKey.Action, allocated, time.Unix(cacheKey.IntervalStart, 0))
+		if err != nil {
+			mon.Event("rollups_write_cache_repair_flush_lost")
+			cache.log.Warn("repair bandwidth rollup flush failed", zap.Error(err))
+		}
+	}
+
 	cache.mu.Lock()
 	defer cache.mu.Unlock()
 
@@ -197,12 +249,12 @@ func (cache *RollupsWriteCache) updateCacheValue(ctx context.Context, projectID
 
 	if !cache.flushing {
 		cache.flushing = true
-		pendingRollups := cache.resetCache()
+		pendingRollups, pendingRepairRollups := cache.resetCache()
 
 		cache.wg.Add(1)
 		go func() {
 			defer cache.wg.Done()
-			cache.flush(ctx, pendingRollups)
+			cache.flush(ctx, pendingRollups, pendingRepairRollups)
 		}()
 	}
 
@@ -15,6 +15,7 @@ import (
 
 	"storj.io/common/memory"
 	"storj.io/common/pb"
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/common/uuid"
@@ -212,6 +213,44 @@ func TestUpdateBucketBandwidth(t *testing.T) {
 	)
 }
 
+func TestUpdateRepairBandwidth(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1,
+	},
+		func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+			satellite := planet.Satellites[0]
+			// don't let the loop flush our cache while we're checking it
+			satellite.Orders.Chore.Loop.Pause()
+
+			cache, ok := satellite.Orders.DB.(*orders.RollupsWriteCache)
+			require.True(t, ok)
+
+			amount := (memory.MB * 10).Int64()
+			intervalStart := time.Now()
+			for i := 0; i < 2; i++ {
+				err := cache.UpdateRepairBandwidthAllocation(ctx, storj.EU, pb.PieceAction_GET_REPAIR, amount, intervalStart)
+				require.NoError(t, err)
+			}
+
+			// test: nothing is written to the db before the flush
+			from, to := intervalStart.Add(-time.Hour), intervalStart.Add(time.Hour)
+			rollups, err := satellite.DB.StoragenodeAccounting().GetRepairBandwidthByPlacement(ctx, from, to)
+			require.NoError(t, err)
+			require.Empty(t, rollups)
+
+			cache.Flush(ctx)
+
+			// test: the allocations for the same key are summed up
+			rollups, err = satellite.DB.StoragenodeAccounting().GetRepairBandwidthByPlacement(ctx, from, to)
+			require.NoError(t, err)
+			require.Len(t, rollups, 1)
+			require.Equal(t, storj.EU, rollups[0].Placement)
+			require.Equal(t, pb.PieceAction_GET_REPAIR, rollups[0].Action)
+			require.Equal(t, 2*amount, rollups[0].Allocated)
+		},
+	)
+}
+
 func TestSortRollups(t *testing.T) {
 	rollups := []orders.BucketBandwidthRollup{
 		{
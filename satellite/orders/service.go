@@ -124,6 +124,33 @@ func (service *Service) updateBandwidth(ctx context.Context, bucket metabase.Buc
 	return nil
 }
 
+// updateRepairBandwidth updates the repair bandwidth allocated, not settled, for segments of the placement.
+// The accounting is only informational, so failures are logged and don't fail the repair.
+func (service *Service) updateRepairBandwidth(ctx context.Context, placement storj.PlacementConstraint, addressedOrderLimits ...*pb.AddressedOrderLimit) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var action pb.PieceAction
+	var allocation int64
+	for _, addressedOrderLimit := range addressedOrderLimits {
+		if addressedOrderLimit != nil && addressedOrderLimit.Limit != nil {
+			action = addressedOrderLimit.Limit.Action
+			allocation += addressedOrderLimit.Limit.Limit
+		}
+	}
+	if allocation == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	intervalStart := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
+
+	err = service.orders.UpdateRepairBandwidthAllocation(ctx, placement, action, allocation, intervalStart)
+	if err != nil {
+		service.log.Warn("failed to update repair bandwidth", zap.Uint16("Placement", uint16(placement)), zap.Error(err))
+	}
+}
+
 // CreateGetOrderLimits creates the order limits for downloading the pieces of a segment.
 func (service *Service) CreateGetOrderLimits(ctx context.Context, bucket metabase.BucketLocation, segment metabase.Segment, overrideLimit int64) (_ []*pb.AddressedOrderLimit, privateKey storj.PiecePrivateKey, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -430,6 +457,8 @@ func (service *Service) CreateGetRepairOrderLimits(ctx context.Context, bucket m
 		return nil, storj.PiecePrivateKey{}, nil, Error.Wrap(err)
 	}
 
+	service.updateRepairBandwidth(ctx, segment.Placement, limits...)
+
 	return limits, signer.PrivateKey, cachedNodesInfo, nil
 }
 
@@ -503,6 +532,8 @@ func (service *Service) CreatePutRepairOrderLimits(ctx context.Context, bucket m
 		return nil, storj.PiecePrivateKey{}, Error.Wrap(err)
 	}
 
+	service.updateRepairBandwidth(ctx, segment.Placement, limits...)
+
 	return limits, signer.PrivateKey, nil
 }
 
@@ -42,6 +42,7 @@ type NodeSelectionConfig struct {
 	OnlineWindow     time.Duration `help:"the amount of time without seeing a node before its considered offline" default:"4h" testDefault:"1m"`
 	DistinctIP       bool          `help:"require distinct IPs when choosing nodes for upload" releaseDefault:"true" devDefault:"false"`
 	MinimumDiskSpace memory.Size   `help:"how much disk space a node at minimum must have to be selected for upload" default:"500.00MB" testDefault:"100.00MB"`
+	FlappingWindow   time.Duration `help:"the length of the window in which up and down transitions of nodes are counted to detect flapping" default:"24h"`
 
 	AsOfSystemTime AsOfSystemTimeConfig
 
@@ -238,3 +238,147 @@ func TestDBDisqualifyNode(t *testing.T) {
 		}
 	})
 }
+
+func TestDBDisqualifyNodes(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		overlayDB := db.OverlayCache()
+		now := time.Now().Truncate(time.Second).UTC()
+
+		var nodeIDs storj.NodeIDList
+		for i := 0; i < 4; i++ {
+			nodeID := testrand.NodeID()
+			checkIn := overlay.NodeCheckInInfo{
+				NodeID: nodeID,
+				Address: &pb.NodeAddress{
+					Transport: 1,
+					Address:   "127.0.0.1:0",
+				},
+				IsUp: true,
+				Version: &pb.NodeVersion{
+					Version:    "v0.0.0",
+					CommitHash: "",
+					Timestamp:  now,
+				},
+			}
+			err := overlayDB.UpdateCheckIn(ctx, checkIn, now, overlay.NodeSelectionConfig{})
+			require.NoError(t, err)
+
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+
+		earlier := now.Add(-time.Hour)
+		err := overlayDB.DisqualifyNode(ctx, nodeIDs[0], earlier, overlay.DisqualificationReasonAuditFailure)
+		require.NoError(t, err)
+
+		unknown := testrand.NodeID()
+
+		disqualified, alreadyDisqualified, err := overlayDB.DisqualifyNodes(ctx, append(nodeIDs[:3:3], unknown), now, overlay.DisqualificationReasonUnknown)
+		require.NoError(t, err)
+		require.ElementsMatch(t, storj.NodeIDList{nodeIDs[1], nodeIDs[2]}, disqualified)
+		require.ElementsMatch(t, storj.NodeIDList{nodeIDs[0]}, alreadyDisqualified)
+
+		// already disqualified nodes are left unchanged
+		info, err := overlayDB.Get(ctx, nodeIDs[0])
+		require.NoError(t, err)
+		require.NotNil(t, info.Disqualified)
+		assert.Equal(t, earlier, info.Disqualified.UTC())
+		assert.Equal(t, overlay.DisqualificationReasonAuditFailure, *info.DisqualificationReason)
+
+		for _, nodeID := range nodeIDs[1:3] {
+			info, err := overlayDB.Get(ctx, nodeID)
+			require.NoError(t, err)
+			require.NotNil(t, info.Disqualified)
+			assert.Equal(t, now, info.Disqualified.UTC())
+			assert.Equal(t, overlay.DisqualificationReasonUnknown, *info.DisqualificationReason)
+		}
+
+		info, err = overlayDB.Get(ctx, nodeIDs[3])
+		require.NoError(t, err)
+		require.Nil(t, info.Disqualified)
+
+		// disqualifying the same nodes again is a no-op
+		disqualified, alreadyDisqualified, err = overlayDB.DisqualifyNodes(ctx, nodeIDs, now.Add(time.Hour), overlay.DisqualificationReasonNodeOffline)
+		require.NoError(t, err)
+		require.ElementsMatch(t, storj.NodeIDList{nodeIDs[3]}, disqualified)
+		require.ElementsMatch(t, storj.NodeIDList{nodeIDs[0], nodeIDs[1], nodeIDs[2]}, alreadyDisqualified)
+
+		info, err = overlayDB.Get(ctx, nodeIDs[1])
+		require.NoError(t, err)
+		assert.Equal(t, now, info.Disqualified.UTC())
+
+		disqualified, alreadyDisqualified, err = overlayDB.DisqualifyNodes(ctx, nil, now, overlay.DisqualificationReasonUnknown)
+		require.NoError(t, err)
+		require.Empty(t, disqualified)
+		require.Empty(t, alreadyDisqualified)
+	})
+}
+
+func TestDBNodeStability(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		overlayDB := db.OverlayCache()
+		now := time.Now().Truncate(time.Second).UTC()
+		config := overlay.NodeSelectionConfig{FlappingWindow: time.Hour}
+
+		nodeID := testrand.NodeID()
+		checkIn := func(isUp bool, timestamp time.Time) {
+			err := overlayDB.UpdateCheckIn(ctx, overlay.NodeCheckInInfo{
+				NodeID: nodeID,
+				Address: &pb.NodeAddress{
+					Transport: 1,
+					Address:   "127.0.0.1:0",
+				},
+				IsUp: isUp,
+				Version: &pb.NodeVersion{
+					Version:    "v0.0.0",
+					CommitHash: "",
+					Timestamp:  now,
+				},
+			}, timestamp, config)
+			require.NoError(t, err)
+		}
+
+		checkIn(true, now)
+
+		stability, err := overlayDB.GetNodeStability(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Zero(t, stability.UpTransitions)
+		assert.Zero(t, stability.DownTransitions)
+		assert.Nil(t, stability.Since)
+
+		// down, up, down, down (no transition), up
+		checkIn(false, now.Add(1*time.Minute))
+		checkIn(true, now.Add(2*time.Minute))
+		checkIn(false, now.Add(3*time.Minute))
+		checkIn(false, now.Add(4*time.Minute))
+		checkIn(true, now.Add(5*time.Minute))
+
+		stability, err = overlayDB.GetNodeStability(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stability.UpTransitions)
+		assert.Equal(t, 2, stability.DownTransitions)
+		require.NotNil(t, stability.Since)
+		assert.Equal(t, now.Add(1*time.Minute), stability.Since.UTC())
+
+		// the counters are reset by the first check-in after the window has passed
+		nextWindow := now.Add(1*time.Minute + config.FlappingWindow + time.Minute)
+		checkIn(true, nextWindow)
+
+		stability, err = overlayDB.GetNodeStability(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Zero(t, stability.UpTransitions)
+		assert.Zero(t, stability.DownTransitions)
+		require.NotNil(t, stability.Since)
+		assert.Equal(t, nextWindow, stability.Since.UTC())
+
+		checkIn(false, nextWindow.Add(time.Minute))
+
+		stability, err = overlayDB.GetNodeStability(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Zero(t, stability.UpTransitions)
+		assert.Equal(t, 1, stability.DownTransitions)
+		assert.Equal(t, nextWindow, stability.Since.UTC())
+
+		_, err = overlayDB.GetNodeStability(ctx, testrand.NodeID())
+		require.True(t, overlay.ErrNodeNotFound.Has(err))
+	})
+}
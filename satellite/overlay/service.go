@@ -89,12 +89,19 @@ type DB interface {
 	// GetExitStatus returns a node's graceful exit status.
 	GetExitStatus(ctx context.Context, nodeID storj.NodeID) (exitStatus *ExitStatus, err error)
 
+	// GetNodeStability returns the number of up and down transitions of a node within the current flapping window.
+	GetNodeStability(ctx context.Context, nodeID storj.NodeID) (stability NodeStability, err error)
+
 	// GetNodesNetwork returns the /24 subnet for each storage node, order is not guaranteed.
 	GetNodesNetwork(ctx context.Context, nodeIDs []storj.NodeID) (nodeNets []string, err error)
 
 	// DisqualifyNode disqualifies a storage node.
 	DisqualifyNode(ctx context.Context, nodeID storj.NodeID, disqualifiedAt time.Time, reason DisqualificationReason) (err error)
 
+	// DisqualifyNodes disqualifies storage nodes with a single statement. It returns the nodes which were
+	// disqualified by the call and the nodes which already were disqualified.
+	DisqualifyNodes(ctx context.Context, nodeIDs storj.NodeIDList, disqualifiedAt time.Time, reason DisqualificationReason) (disqualified, alreadyDisqualified storj.NodeIDList, err error)
+
 	// DQNodesLastSeenBefore disqualifies a limited number of nodes where last_contact_success < cutoff except those already disqualified
 	// or gracefully exited or where last_contact_success = '0001-01-01 00:00:00+00'.
 	DQNodesLastSeenBefore(ctx context.Context, cutoff time.Time, limit int) (count int, err error)
@@ -160,6 +167,7 @@ type InfoResponse struct {
 type FindStorageNodesRequest struct {
 	RequestedCount     int
 	ExcludedIDs        []storj.NodeID
+	ExcludedNetworks   []string      // the /24 subnet IPv4 or /64 subnet IPv6 of nodes, only used when distinct IPs are required
 	MinimumVersion     string        // semver or empty
 	AsOfSystemInterval time.Duration // only used for CRDB queries
 	Placement          storj.PlacementConstraint
@@ -204,6 +212,16 @@ type ExitStatus struct {
 	ExitSuccess         bool
 }
 
+// NodeStability contains the number of times the node went up and down
+// since the start of the current flapping window.
+type NodeStability struct {
+	UpTransitions   int
+	DownTransitions int
+	// Since is the start of the window; it's nil when the node hasn't
+	// checked in since the transitions are counted.
+	Since *time.Time
+}
+
 // ExitStatusRequest is used to update a node's graceful exit status.
 type ExitStatusRequest struct {
 	NodeID              storj.NodeID
@@ -268,6 +286,9 @@ type NodeReputation struct {
 	LastNet    string
 	LastIPPort string
 	Reputation ReputationStatus
+	// Latency90 is the 90th percentile of the latency observed for the node in
+	// milliseconds, 0 when unknown.
+	Latency90 int64
 }
 
 // Clone returns a deep clone of the selected node.
@@ -390,6 +411,17 @@ func (service *Service) FindStorageNodesForUpload(ctx context.Context, req FindS
 		return service.FindStorageNodesWithPreferences(ctx, req, &service.config.Node)
 	}
 
+	// the cache only knows the networks of the nodes it contains, hence
+	// the networks of excluded nodes which aren't selectable (e.g. nodes
+	// holding pieces of a repaired segment which are full or suspended)
+	// have to be looked up to keep every piece on a distinct network.
+	if service.config.Node.DistinctIP && len(req.ExcludedIDs) > 0 && req.ExcludedNetworks == nil {
+		req.ExcludedNetworks, err = service.db.GetNodesNetwork(ctx, req.ExcludedIDs)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
 	selectedNodes, err := service.UploadSelectionCache.GetNodes(ctx, req)
 	if err != nil {
 		return selectedNodes, err
@@ -430,6 +462,7 @@ func (service *Service) FindStorageNodesWithPreferences(ctx context.Context, req
 		if err != nil {
 			return nil, Error.Wrap(err)
 		}
+		excludedNetworks = append(excludedNetworks, req.ExcludedNetworks...)
 	}
 
 	newNodeCount := 0
@@ -479,10 +512,16 @@ func (service *Service) KnownUnreliableOrOffline(ctx context.Context, nodeIds st
 // KnownReliableInExcludedCountries filters healthy nodes that are in excluded countries.
 func (service *Service) KnownReliableInExcludedCountries(ctx context.Context, nodeIds storj.NodeIDList) (reliableInExcluded storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
+	return service.KnownReliableInCountries(ctx, nodeIds, service.config.RepairExcludedCountryCodes)
+}
+
+// KnownReliableInCountries filters healthy nodes that are in the given countries.
+func (service *Service) KnownReliableInCountries(ctx context.Context, nodeIds storj.NodeIDList, countryCodes []string) (reliableInCountries storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
 
 	criteria := &NodeCriteria{
 		OnlineWindow:      service.config.Node.OnlineWindow,
-		ExcludedCountries: service.config.RepairExcludedCountryCodes,
+		ExcludedCountries: countryCodes,
 	}
 	return service.db.KnownReliableInExcludedCountries(ctx, criteria, nodeIds)
 }
@@ -496,11 +535,18 @@ func (service *Service) KnownReliable(ctx context.Context, nodeIDs storj.NodeIDL
 // Reliable filters a set of nodes that are reliable, independent of new.
 func (service *Service) Reliable(ctx context.Context) (nodes storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
+	return service.ReliableOutsideCountries(ctx, service.config.RepairExcludedCountryCodes)
+}
+
+// ReliableOutsideCountries filters a set of nodes that are reliable, independent of new,
+// and not located in any of the given countries.
+func (service *Service) ReliableOutsideCountries(ctx context.Context, countryCodes []string) (nodes storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
 
 	criteria := &NodeCriteria{
-		OnlineWindow: service.config.Node.OnlineWindow,
+		OnlineWindow:      service.config.Node.OnlineWindow,
+		ExcludedCountries: countryCodes,
 	}
-	criteria.ExcludedCountries = service.config.RepairExcludedCountryCodes
 	return service.db.Reliable(ctx, criteria)
 }
 
@@ -621,12 +667,18 @@ func (service *Service) GetMissingPieces(ctx context.Context, pieces metabase.Pi
 
 // GetReliablePiecesInExcludedCountries returns the list of pieces held by nodes located in excluded countries.
 func (service *Service) GetReliablePiecesInExcludedCountries(ctx context.Context, pieces metabase.Pieces) (piecesInExcluded []uint16, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.GetReliablePiecesInCountries(ctx, pieces, service.config.RepairExcludedCountryCodes)
+}
+
+// GetReliablePiecesInCountries returns the list of pieces held by reliable nodes located in the given countries.
+func (service *Service) GetReliablePiecesInCountries(ctx context.Context, pieces metabase.Pieces, countryCodes []string) (piecesInExcluded []uint16, err error) {
 	defer mon.Task()(&ctx)(&err)
 	var nodeIDs storj.NodeIDList
 	for _, p := range pieces {
 		nodeIDs = append(nodeIDs, p.StorageNode)
 	}
-	inExcluded, err := service.KnownReliableInExcludedCountries(ctx, nodeIDs)
+	inExcluded, err := service.KnownReliableInCountries(ctx, nodeIDs, countryCodes)
 	if err != nil {
 		return nil, Error.New("error getting nodes %s", err)
 	}
@@ -647,6 +699,13 @@ func (service *Service) DisqualifyNode(ctx context.Context, nodeID storj.NodeID,
 	return service.db.DisqualifyNode(ctx, nodeID, time.Now().UTC(), reason)
 }
 
+// DisqualifyNodes disqualifies storage nodes. It returns the nodes which were
+// disqualified by the call and the nodes which already were disqualified.
+func (service *Service) DisqualifyNodes(ctx context.Context, nodeIDs storj.NodeIDList, reason DisqualificationReason) (disqualified, alreadyDisqualified storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.db.DisqualifyNodes(ctx, nodeIDs, time.Now().UTC(), reason)
+}
+
 // ResolveIPAndNetwork resolves the target address and determines its IP and /24 subnet IPv4 or /64 subnet IPv6.
 func ResolveIPAndNetwork(ctx context.Context, target string) (ip net.IP, port, network string, err error) {
 	defer mon.Task()(&ctx)(&err)
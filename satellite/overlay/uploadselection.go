@@ -110,6 +110,7 @@ func (cache *UploadSelectionCache) GetNodes(ctx context.Context, req FindStorage
 		NewFraction:          cache.selectionConfig.NewNodeFraction,
 		Distinct:             cache.selectionConfig.DistinctIP,
 		ExcludedIDs:          req.ExcludedIDs,
+		ExcludedNetworks:     req.ExcludedNetworks,
 		Placement:            req.Placement,
 		ExcludedCountryCodes: cache.selectionConfig.UploadExcludedCountryCodes,
 	})
@@ -29,6 +29,10 @@ type Accounts interface {
 	// ProjectCharges returns how much money current user will be charged for each project.
 	ProjectCharges(ctx context.Context, userID uuid.UUID, since, before time.Time) ([]ProjectCharge, error)
 
+	// EstimateStorageCost returns how much money in cents the user would be charged for storing
+	// the specified amount of bytes for the duration.
+	EstimateStorageCost(ctx context.Context, userID uuid.UUID, bytes int64, duration time.Duration) (int64, error)
+
 	// CheckProjectInvoicingStatus returns error if for the given project there are outstanding project records and/or usage
 	// which have not been applied/invoiced yet (meaning sent over to stripe).
 	CheckProjectInvoicingStatus(ctx context.Context, projectID uuid.UUID) error
@@ -7,9 +7,18 @@ import (
 	"context"
 	"time"
 
+	"github.com/zeebo/errs"
+
 	"storj.io/common/uuid"
 )
 
+var (
+	// ErrInvalidCouponCode is an error class for coupon codes that don't exist or have expired.
+	ErrInvalidCouponCode = errs.Class("invalid coupon code")
+	// ErrCouponCodeAlreadyApplied is an error class for coupon codes already applied to the user.
+	ErrCouponCodeAlreadyApplied = errs.Class("coupon code already applied")
+)
+
 // Coupons exposes all needed functionality to manage coupons.
 //
 // architecture: Service
@@ -18,6 +27,8 @@ type Coupons interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID) (*Coupon, error)
 
 	// ApplyCouponCode attempts to apply a coupon code to the user.
+	// It returns ErrInvalidCouponCode when the code doesn't exist or has expired
+	// and ErrCouponCodeAlreadyApplied when the user already has the coupon.
 	ApplyCouponCode(ctx context.Context, userID uuid.UUID, couponCode string) (*Coupon, error)
 }
 
@@ -0,0 +1,46 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monetary
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// conversionPair identifies a supported direction of currency conversion.
+type conversionPair struct {
+	from, to *Currency
+}
+
+// supportedConversions lists the currency pairs which Convert accepts.
+var supportedConversions = map[conversionPair]bool{
+	{StorjToken, USDollars}: true,
+	{USDollars, StorjToken}: true,
+	{Bitcoin, USDollars}:    true,
+	{USDollars, Bitcoin}:    true,
+}
+
+// Convert converts amount from the from currency to the to currency, where
+// rate is the value of one unit of from expressed in units of to.
+//
+// The result is rounded to the precision of the target currency using
+// banker's rounding (round half to even), so converting the same amount at
+// the same rate always produces the same result. Converting between the same
+// currency returns amount unchanged.
+func Convert(amount Amount, from, to *Currency, rate decimal.Decimal) (Amount, error) {
+	if amount.currency != from {
+		return Amount{}, Error.New("amount is in %s, not %s", amount.currency.symbol, from.symbol)
+	}
+	if from == to {
+		return amount, nil
+	}
+	if !supportedConversions[conversionPair{from: from, to: to}] {
+		return Amount{}, Error.New("unsupported conversion from %s to %s", from.symbol, to.symbol)
+	}
+	if !rate.IsPositive() {
+		return Amount{}, Error.New("invalid conversion rate %s", rate)
+	}
+
+	converted := amount.AsDecimal().Mul(rate).Shift(to.decimalPlaces).RoundBank(0)
+	return AmountFromBaseUnits(converted.IntPart(), to), nil
+}
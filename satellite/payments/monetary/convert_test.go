@@ -0,0 +1,64 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monetary
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    Amount
+		from      *Currency
+		to        *Currency
+		rate      string
+		baseUnits int64
+	}{
+		{"zero", AmountFromBaseUnits(0, StorjToken), StorjToken, USDollars, "0.5", 0},
+		{"whole tokens", AmountFromBaseUnits(10_00000000, StorjToken), StorjToken, USDollars, "0.5", 500},
+		{"round half down to even", AmountFromBaseUnits(12500000, StorjToken), StorjToken, USDollars, "1", 12},
+		{"round half up to even", AmountFromBaseUnits(13500000, StorjToken), StorjToken, USDollars, "1", 14},
+		{"round below half", AmountFromBaseUnits(12499999, StorjToken), StorjToken, USDollars, "1", 12},
+		{"round above half", AmountFromBaseUnits(12500001, StorjToken), StorjToken, USDollars, "1", 13},
+		{"negative half to even", AmountFromBaseUnits(-12500000, StorjToken), StorjToken, USDollars, "1", -12},
+		{"usd to storj", AmountFromBaseUnits(100, USDollars), USDollars, StorjToken, "3", 3_00000000},
+		{"usd to storj repeating", AmountFromBaseUnits(100, USDollars), USDollars, StorjToken, "0.333333333333", 33333333},
+		{"btc to usd", AmountFromBaseUnits(1_00000000, Bitcoin), Bitcoin, USDollars, "29123.455", 2912346},
+		{"same currency", AmountFromBaseUnits(12345, USDollars), USDollars, USDollars, "2", 12345},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := Convert(tt.amount, tt.from, tt.to, decimal.RequireFromString(tt.rate))
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, converted.Currency())
+			assert.Equal(t, tt.baseUnits, converted.BaseUnits())
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	one := decimal.NewFromInt(1)
+
+	_, err := Convert(AmountFromBaseUnits(1, LiveGoats), LiveGoats, USDollars, one)
+	require.Error(t, err)
+	require.True(t, Error.Has(err))
+
+	_, err = Convert(AmountFromBaseUnits(1, StorjToken), StorjToken, Bitcoin, one)
+	require.Error(t, err)
+
+	_, err = Convert(AmountFromBaseUnits(1, StorjToken), USDollars, StorjToken, one)
+	require.Error(t, err)
+
+	_, err = Convert(AmountFromBaseUnits(1, StorjToken), StorjToken, USDollars, decimal.Zero)
+	require.Error(t, err)
+
+	_, err = Convert(AmountFromBaseUnits(1, StorjToken), StorjToken, USDollars, decimal.NewFromInt(-1))
+	require.Error(t, err)
+}
@@ -26,13 +26,15 @@ var _ payments.DepositWallets = (*Service)(nil)
 // architecture: Service
 type Service struct {
 	walletsDB       WalletsDB
+	paymentsDB      PaymentsDB
 	storjscanClient *Client
 }
 
 // NewService creates a Service instance.
-func NewService(db DB, storjscanClient *Client) (*Service, error) {
+func NewService(db DB, paymentsDB PaymentsDB, storjscanClient *Client) (*Service, error) {
 	return &Service{
 		walletsDB:       db.Wallets(),
+		paymentsDB:      paymentsDB,
 		storjscanClient: storjscanClient,
 	}, nil
 }
@@ -60,3 +62,38 @@ func (service *Service) Get(ctx context.Context, userID uuid.UUID) (_ blockchain
 	address, err := service.walletsDB.Get(ctx, userID)
 	return address, Error.Wrap(err)
 }
+
+// Transactions returns up to limit deposits to the wallet of the given user, ordered from the oldest.
+// Cursor is the number of deposits to skip.
+func (service *Service) Transactions(ctx context.Context, userID uuid.UUID, cursor int64, limit int) (_ []payments.WalletTransaction, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if cursor < 0 || limit <= 0 {
+		return nil, Error.New("invalid cursor %d or limit %d", cursor, limit)
+	}
+
+	address, err := service.walletsDB.Get(ctx, userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	cachedPayments, err := service.paymentsDB.ListWallet(ctx, address, limit, cursor)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	transactions := make([]payments.WalletTransaction, 0, len(cachedPayments))
+	for _, payment := range cachedPayments {
+		status := payments.TransactionStatusPending
+		if payment.Status == PaymentStatusConfirmed {
+			status = payments.TransactionStatusPaid
+		}
+		transactions = append(transactions, payments.WalletTransaction{
+			TxHash:    payment.Transaction,
+			Amount:    payment.TokenValue,
+			Status:    status,
+			Timestamp: payment.Timestamp,
+		})
+	}
+	return transactions, nil
+}
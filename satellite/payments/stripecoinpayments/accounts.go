@@ -147,6 +147,19 @@ func (accounts *accounts) ProjectCharges(ctx context.Context, userID uuid.UUID,
 	return charges, nil
 }
 
+// EstimateStorageCost returns how much money in cents the user would be charged for storing
+// the specified amount of bytes for the duration.
+func (accounts *accounts) EstimateStorageCost(ctx context.Context, userID uuid.UUID, bytes int64, duration time.Duration) (_ int64, err error) {
+	defer mon.Task()(&ctx, userID, bytes, duration)(&err)
+
+	// storage is charged the same way as the actual usage of a project, which
+	// is tracked in byte-hours.
+	byteHours := float64(bytes) * duration.Hours()
+	price := accounts.service.calculateProjectUsagePrice(0, byteHours, 0)
+
+	return price.Storage.IntPart(), nil
+}
+
 // CheckProjectInvoicingStatus returns error if for the given project there are outstanding project records and/or usage
 // which have not been applied/invoiced yet (meaning sent over to stripe).
 func (accounts *accounts) CheckProjectInvoicingStatus(ctx context.Context, projectID uuid.UUID) (err error) {
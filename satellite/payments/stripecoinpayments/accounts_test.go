@@ -89,7 +89,7 @@ func TestSignupCouponCodes(t *testing.T) {
 			consoleauth.NewService(consoleauth.Config{
 				TokenExpirationTime: 24 * time.Hour,
 			}, &consoleauth.Hmac{Secret: []byte("my-suppa-secret-key")}),
-			console.Config{PasswordCost: console.TestPasswordCost, DefaultProjectLimit: 5},
+			console.Config{PasswordCost: console.TestPasswordCost, DefaultProjectLimit: 5, Argon2: console.Argon2Config{Time: 1, Memory: console.TestArgon2Memory, Threads: 1, Concurrency: 1}},
 		)
 
 		require.NoError(t, err)
@@ -31,15 +31,27 @@ func (coupons *coupons) ApplyCouponCode(ctx context.Context, userID uuid.UUID, c
 		Code: stripe.String(couponCode),
 	})
 	if !promoCodeIter.Next() {
-		return nil, Error.New("Invalid coupon code")
+		return nil, payments.ErrInvalidCouponCode.New("%q does not exist", couponCode)
 	}
 	promoCode := promoCodeIter.PromotionCode()
+	if promoCode.ExpiresAt != 0 && time.Unix(promoCode.ExpiresAt, 0).Before(time.Now()) {
+		return nil, payments.ErrInvalidCouponCode.New("%q has expired", couponCode)
+	}
 
 	customerID, err := coupons.service.db.Customers().GetCustomerID(ctx, userID)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
+	current, err := coupons.service.stripeClient.Customers().Get(customerID, nil)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if current.Discount != nil && current.Discount.Coupon != nil && promoCode.Coupon != nil &&
+		current.Discount.Coupon.ID == promoCode.Coupon.ID {
+		return nil, payments.ErrCouponCodeAlreadyApplied.New("%q", couponCode)
+	}
+
 	params := &stripe.CustomerParams{
 		PromotionCode: stripe.String(promoCode.ID),
 	}
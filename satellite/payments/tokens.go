@@ -34,6 +34,18 @@ type DepositWallets interface {
 	Claim(ctx context.Context, userID uuid.UUID) (blockchain.Address, error)
 	// Get returns the crypto wallet address associated with the given user.
 	Get(ctx context.Context, userID uuid.UUID) (blockchain.Address, error)
+	// Transactions returns up to limit deposits to the wallet of the given user, ordered from the oldest.
+	// Cursor is the number of deposits to skip.
+	Transactions(ctx context.Context, userID uuid.UUID, cursor int64, limit int) ([]WalletTransaction, error)
+}
+
+// WalletTransaction holds the data of an on-chain deposit to a user's wallet.
+// Status is TransactionStatusPaid once the deposit has the required number of confirmations.
+type WalletTransaction struct {
+	TxHash    blockchain.Hash
+	Amount    monetary.Amount
+	Status    TransactionStatus
+	Timestamp time.Time
 }
 
 // TransactionStatus defines allowed statuses
@@ -34,6 +34,7 @@ import (
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/consoleweb"
+	"storj.io/storj/satellite/console/dataerasure"
 	"storj.io/storj/satellite/console/emailreminders"
 	"storj.io/storj/satellite/console/restkeys"
 	"storj.io/storj/satellite/contact"
@@ -96,6 +97,8 @@ type DB interface {
 	ProjectAccounting() accounting.ProjectAccounting
 	// RepairQueue returns queue for segments that need repairing
 	RepairQueue() queue.RepairQueue
+	// RepairCheckpoints returns the checkpoints of interrupted repairs
+	RepairCheckpoints() queue.RepairCheckpoints
 	// Console returns database for satellite console
 	Console() console.DB
 	// OIDC returns the database for OIDC resources.
@@ -152,6 +155,7 @@ type Config struct {
 
 	ExpiredDeletion expireddeletion.Config
 	ZombieDeletion  zombiedeletion.Config
+	DataErasure     dataerasure.Config
 
 	Tally            tally.Config
 	Rollup           rollup.Config
@@ -6,6 +6,7 @@ package checker
 import (
 	"bytes"
 	"context"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +32,11 @@ var (
 	mon   = monkit.Package()
 )
 
+// repairQueueHealthBuckets are the upper bounds of the segment health buckets
+// of the repair queue stats. Segment health roughly corresponds to the number
+// of days a segment is expected to survive.
+var repairQueueHealthBuckets = [...]float64{1, 7, 30, 365}
+
 // Checker contains the information needed to do checks for missing pieces.
 //
 // architecture: Chore
@@ -42,6 +48,7 @@ type Checker struct {
 	nodestate            *ReliabilityCache
 	statsCollector       *statsCollector
 	repairOverrides      RepairOverridesMap
+	successOverrides     PlacementSuccessOverridesMap
 	nodeFailureRate      float64
 	repairQueueBatchSize int
 	Loop                 *sync2.Cycle
@@ -55,9 +62,10 @@ func NewChecker(logger *zap.Logger, repairQueue queue.RepairQueue, metabase *met
 		repairQueue:          repairQueue,
 		metabase:             metabase,
 		segmentLoop:          segmentLoop,
-		nodestate:            NewReliabilityCache(overlay, config.ReliabilityCacheStaleness),
+		nodestate:            NewReliabilityCache(overlay, config.ReliabilityCacheStaleness, config.PlacementExcludedCountries),
 		statsCollector:       newStatsCollector(),
 		repairOverrides:      config.RepairOverrides.GetMap(),
+		successOverrides:     config.PlacementSuccessOverrides.GetMap(),
 		nodeFailureRate:      config.NodeFailureRate,
 		repairQueueBatchSize: config.RepairQueueInsertBatchSize,
 
@@ -121,6 +129,7 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 		statsCollector:   checker.statsCollector,
 		monStats:         aggregateStats{},
 		repairOverrides:  checker.repairOverrides,
+		successOverrides: checker.successOverrides,
 		nodeFailureRate:  checker.nodeFailureRate,
 		getNodesEstimate: checker.getNodesEstimate,
 		log:              checker.logger,
@@ -165,9 +174,37 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 	allHealthy := allChecked - allUnhealthy
 	mon.FloatVal("remote_segments_healthy_percentage").Observe(100 * float64(allHealthy) / float64(allChecked)) //mon:locked
 
+	// after cleaning, the queue contains only the segments which were seen as
+	// unhealthy by this iteration, so its statistics are taken from the observer.
+	var oldestAge time.Duration
+	if oldestInsertedAt := observer.repairQueue.OldestInsertedAt(); !oldestInsertedAt.IsZero() {
+		oldestAge = time.Since(oldestInsertedAt)
+	}
+	mon.IntVal("repair_queue_size").Observe(observer.monStats.remoteSegmentsNeedingRepair)
+	mon.FloatVal("repair_queue_oldest_age_seconds").Observe(oldestAge.Seconds())
+	mon.FloatVal("repair_queue_insertion_rate").Observe(float64(observer.monStats.newRemoteSegmentsNeedingRepair) / time.Since(startTime).Seconds())
+	for i, count := range observer.monStats.remoteSegmentsNeedingRepairByHealth {
+		bucket := "above"
+		if i < len(repairQueueHealthBuckets) {
+			bucket = strconv.FormatFloat(repairQueueHealthBuckets[i], 'f', -1, 64)
+		}
+		mon.IntVal("repair_queue_segments_by_health", monkit.NewSeriesTag("health_below", bucket)).Observe(count)
+	}
+
 	return nil
 }
 
+// repairQueueHealthBucket returns the index of the first health bucket whose
+// upper bound is above the segment health.
+func repairQueueHealthBucket(segmentHealth float64) int {
+	for i, upperBound := range repairQueueHealthBuckets {
+		if segmentHealth < upperBound {
+			return i
+		}
+	}
+	return len(repairQueueHealthBuckets)
+}
+
 var remoteSegmentFunc = mon.Func()
 
 var _ segmentloop.Observer = (*checkerObserver)(nil)
@@ -181,6 +218,7 @@ type checkerObserver struct {
 	statsCollector   *statsCollector
 	monStats         aggregateStats // TODO(cam): once we verify statsCollector reports data correctly, remove this
 	repairOverrides  RepairOverridesMap
+	successOverrides PlacementSuccessOverridesMap
 	nodeFailureRate  float64
 	getNodesEstimate func(ctx context.Context) (int, error)
 	log              *zap.Logger
@@ -264,7 +302,7 @@ func (obs *checkerObserver) RemoteSegment(ctx context.Context, segment *segmentl
 	if segment.RepairedAt != nil {
 		repairedAt = *segment.RepairedAt
 	}
-	missingPieces, err := obs.nodestate.MissingPieces(ctx, segment.CreatedAt, segment.Pieces)
+	missingPieces, err := obs.nodestate.MissingPieces(ctx, segment.CreatedAt, segment.Placement, segment.Pieces)
 	if err != nil {
 		obs.monStats.remoteSegmentsFailedToCheck++
 		stats.iterationAggregates.remoteSegmentsFailedToCheck++
@@ -281,7 +319,8 @@ func (obs *checkerObserver) RemoteSegment(ctx context.Context, segment *segmentl
 	mon.IntVal("checker_segment_age").Observe(int64(segmentAge.Seconds())) //mon:locked
 	stats.segmentAge.Observe(int64(segmentAge.Seconds()))
 
-	required, repairThreshold, successThreshold, _ := obs.loadRedundancy(segment.Redundancy)
+	required, repairThreshold, _, _ := obs.loadRedundancy(segment.Redundancy)
+	successThreshold := obs.successOverrides.GetSuccessThreshold(segment.Placement, segment.Redundancy)
 
 	segmentHealth := repair.SegmentHealth(numHealthy, required, totalNumNodes, obs.nodeFailureRate)
 	mon.FloatVal("checker_segment_health").Observe(segmentHealth) //mon:locked
@@ -294,12 +333,14 @@ func (obs *checkerObserver) RemoteSegment(ctx context.Context, segment *segmentl
 		mon.FloatVal("checker_injured_segment_health").Observe(segmentHealth) //mon:locked
 		stats.injuredSegmentHealth.Observe(segmentHealth)
 		obs.monStats.remoteSegmentsNeedingRepair++
+		obs.monStats.remoteSegmentsNeedingRepairByHealth[repairQueueHealthBucket(segmentHealth)]++
 		stats.iterationAggregates.remoteSegmentsNeedingRepair++
 		err := obs.repairQueue.Insert(ctx, &queue.InjuredSegment{
 			StreamID:      segment.StreamID,
 			Position:      segment.Position,
 			UpdatedAt:     time.Now().UTC(),
 			SegmentHealth: segmentHealth,
+			Placement:     segment.Placement,
 		}, func() {
 			// Counters are increased after the queue has determined
 			// that the segment wasn't already queued for repair.
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"storj.io/common/memory"
 	"storj.io/common/storj"
@@ -17,6 +18,7 @@ import (
 	"storj.io/common/testrand"
 	"storj.io/common/uuid"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/metabase"
 )
 
@@ -313,6 +315,88 @@ func TestIgnoringCopiedSegments(t *testing.T) {
 	})
 }
 
+func TestPlacementExcludedCountries(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				require.NoError(t, config.Checker.PlacementExcludedCountries.Set(
+					fmt.Sprintf("%d:DE,%d:US;CA", storj.EU, storj.US)))
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		checker := satellite.Repair.Checker
+		repairQueue := satellite.DB.RepairQueue()
+		metabaseDB := satellite.Metabase.DB
+
+		checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		rs := storj.RedundancyScheme{
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    5,
+			ShareSize:      256,
+		}
+
+		location := metabase.SegmentLocation{
+			ProjectID:  planet.Uplinks[0].Projects[0].ID,
+			BucketName: "test-bucket",
+		}
+
+		insertWithPlacement := func(key string, placement storj.PlacementConstraint) uuid.UUID {
+			location.ObjectKey = metabase.ObjectKey(key)
+			streamID := insertSegment(ctx, t, planet, rs, location, createPieces(planet, rs), nil)
+			_, err := metabaseDB.UnderlyingTagSQL().ExecContext(ctx,
+				`UPDATE segments SET placement = $2 WHERE stream_id = $1`, streamID, placement)
+			require.NoError(t, err)
+			return streamID
+		}
+
+		insertWithPlacement("default", storj.EveryCountry)
+		eu := insertWithPlacement("eu", storj.EU)
+		us := insertWithPlacement("us", storj.US)
+
+		// a single node is in a country excluded only for the EU placement.
+		for i, node := range planet.StorageNodes {
+			countryCode := "NL"
+			if i == 0 {
+				countryCode = "DE"
+			}
+			require.NoError(t, satellite.Overlay.Service.TestNodeCountryCode(ctx, node.ID(), countryCode))
+		}
+		require.NoError(t, checker.RefreshReliabilityCache(ctx))
+
+		checker.Loop.TriggerWait()
+
+		count, err := repairQueue.Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		injuredSegment, err := repairQueue.Select(ctx)
+		require.NoError(t, err)
+		require.Equal(t, eu, injuredSegment.StreamID)
+		require.NoError(t, repairQueue.Delete(ctx, injuredSegment))
+
+		// moving the node to a country excluded only for the US placement
+		// makes only the US segment unhealthy.
+		require.NoError(t, satellite.Overlay.Service.TestNodeCountryCode(ctx, planet.StorageNodes[0].ID(), "CA"))
+		require.NoError(t, checker.RefreshReliabilityCache(ctx))
+
+		checker.Loop.TriggerWait()
+
+		count, err = repairQueue.Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		injuredSegment, err = repairQueue.Select(ctx)
+		require.NoError(t, err)
+		require.Equal(t, us, injuredSegment.StreamID)
+	})
+}
+
 func createPieces(planet *testplanet.Planet, rs storj.RedundancyScheme) metabase.Pieces {
 	pieces := make(metabase.Pieces, rs.OptimalShares)
 	for i := range pieces {
@@ -88,6 +88,10 @@ type aggregateStats struct {
 
 	// remoteSegmentsOverThreshold[0]=# of healthy=rt+1, remoteSegmentsOverThreshold[1]=# of healthy=rt+2, etc...
 	remoteSegmentsOverThreshold [5]int64
+
+	// remoteSegmentsNeedingRepairByHealth[i]=# of segments needing repair with health below
+	// repairQueueHealthBuckets[i], the last element counts segments above all the buckets.
+	remoteSegmentsNeedingRepairByHealth [len(repairQueueHealthBuckets) + 1]int64
 }
 
 func newStats(rs string) *stats {
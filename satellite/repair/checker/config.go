@@ -17,12 +17,18 @@ import (
 type Config struct {
 	Interval time.Duration `help:"how frequently checker should check for bad segments" releaseDefault:"30s" devDefault:"0h0m10s" testDefault:"$TESTINTERVAL"`
 
-	ReliabilityCacheStaleness time.Duration   `help:"how stale reliable node cache can be" releaseDefault:"5m" devDefault:"5m" testDefault:"1m"`
-	RepairOverrides           RepairOverrides `help:"comma-separated override values for repair threshold in the format k/o/n-override (min/optimal/total-override)" releaseDefault:"29/80/110-52,29/80/95-52,29/80/130-52" devDefault:""`
+	ReliabilityCacheStaleness time.Duration             `help:"how stale reliable node cache can be" releaseDefault:"5m" devDefault:"5m" testDefault:"1m"`
+	RepairOverrides           RepairOverrides           `help:"comma-separated override values for repair threshold in the format k/o/n-override (min/optimal/total-override)" releaseDefault:"29/80/110-52,29/80/95-52,29/80/130-52" devDefault:""`
+	PlacementSuccessOverrides PlacementSuccessOverrides `help:"comma-separated override values for the success threshold of segments in a placement in the format placement:success" default:""`
 	// Node failure rate is an estimation based on a 6 hour checker run interval (4 checker iterations per day), a network of about 9200 nodes, and about 2 nodes churning per day.
 	// This results in `2/9200/4 = 0.00005435` being the probability of any single node going down in the interval of one checker iteration.
 	NodeFailureRate            float64 `help:"the probability of a single node going down within the next checker iteration" default:"0.00005435" `
 	RepairQueueInsertBatchSize int     `help:"Number of damaged segments to buffer in-memory before flushing to the repair queue" default:"100" `
+
+	HealthInterval  time.Duration `help:"how frequently the health summary of every segment should be recomputed, 0 disables it" default:"0s"`
+	HealthBatchSize int           `help:"number of segments processed in a single batch when recomputing segment health" default:"1000"`
+
+	PlacementExcludedCountries PlacementExcludedCountries `help:"comma-separated lists of country codes whose nodes are not counted as healthy for segments in a placement, in the format placement:CC;CC" default:""`
 }
 
 // RepairOverride is a configuration struct that contains an override repair
@@ -171,3 +177,237 @@ func (rom *RepairOverridesMap) GetOverrideValue(rs storj.RedundancyScheme) int32
 func getRepairOverrideKey(min, success, total int) string {
 	return fmt.Sprintf("%d/%d/%d", min, success, total)
 }
+
+// PlacementSuccessOverride is a configuration struct that contains an override
+// success threshold for segments of a given placement.
+//
+// Can be used as a flag.
+type PlacementSuccessOverride struct {
+	Placement storj.PlacementConstraint
+	Success   int
+}
+
+// Type implements pflag.Value.
+func (PlacementSuccessOverride) Type() string { return "checker.PlacementSuccessOverride" }
+
+// String is required for pflag.Value.
+func (pso *PlacementSuccessOverride) String() string {
+	return fmt.Sprintf("%d:%d", pso.Placement, pso.Success)
+}
+
+// Set sets the value from a string in the format placement:success.
+func (pso *PlacementSuccessOverride) Set(s string) error {
+	info := strings.Split(s, ":")
+	if len(info) != 2 {
+		return Error.New("Invalid placement success override config (expect format placement:success, got %s)", s)
+	}
+
+	placement, err := strconv.ParseUint(info[0], 10, 16)
+	if err != nil {
+		return Error.New("Invalid placement value (should be valid integer): %s, %w", info[0], err)
+	}
+
+	success, err := strconv.Atoi(info[1])
+	if err != nil {
+		return Error.New("Invalid success value (should be valid integer): %s, %w", info[1], err)
+	}
+	if success <= 0 {
+		return Error.New("Invalid success value (should be positive): %d", success)
+	}
+
+	pso.Placement = storj.PlacementConstraint(placement)
+	pso.Success = success
+	return nil
+}
+
+// PlacementSuccessOverrides is a configuration struct that contains a list of
+// override success thresholds for various placements.
+//
+// Can be used as a flag.
+type PlacementSuccessOverrides struct {
+	List []PlacementSuccessOverride
+}
+
+// Type implements pflag.Value.
+func (PlacementSuccessOverrides) Type() string { return "checker.PlacementSuccessOverrides" }
+
+// String is required for pflag.Value. It is a comma separated list of PlacementSuccessOverride configs.
+func (psos *PlacementSuccessOverrides) String() string {
+	var s strings.Builder
+	for i, pso := range psos.List {
+		if i > 0 {
+			s.WriteString(",")
+		}
+		s.WriteString(pso.String())
+	}
+	return s.String()
+}
+
+// Set sets the value from a string in the format "placement:success,placement:success,...".
+func (psos *PlacementSuccessOverrides) Set(s string) error {
+	psos.List = nil
+	psoStrings := strings.Split(s, ",")
+	for _, psoString := range psoStrings {
+		psoString = strings.TrimSpace(psoString)
+		if psoString == "" {
+			continue
+		}
+		newPso := PlacementSuccessOverride{}
+		err := newPso.Set(psoString)
+		if err != nil {
+			return err
+		}
+		psos.List = append(psos.List, newPso)
+	}
+	return nil
+}
+
+// GetMap creates a PlacementSuccessOverridesMap from the config.
+func (psos *PlacementSuccessOverrides) GetMap() PlacementSuccessOverridesMap {
+	newMap := PlacementSuccessOverridesMap{
+		overrideMap: make(map[storj.PlacementConstraint]int),
+	}
+	for _, pso := range psos.List {
+		newMap.overrideMap[pso.Placement] = pso.Success
+	}
+	return newMap
+}
+
+// PlacementSuccessOverridesMap is derived from the PlacementSuccessOverrides config, and is used
+// for quickly retrieving the success threshold of a placement.
+type PlacementSuccessOverridesMap struct {
+	// map of placement -> success threshold
+	overrideMap map[storj.PlacementConstraint]int
+}
+
+// GetSuccessThreshold returns the success threshold for a segment with the given
+// placement and RS scheme. When there is no override for the placement, the
+// optimal shares of the RS scheme are returned. Overrides are capped at the
+// total shares and never lower the optimal shares.
+func (psom *PlacementSuccessOverridesMap) GetSuccessThreshold(placement storj.PlacementConstraint, rs storj.RedundancyScheme) int {
+	success := int(rs.OptimalShares)
+	if override, ok := psom.overrideMap[placement]; ok && override > success {
+		success = override
+	}
+	if success > int(rs.TotalShares) {
+		success = int(rs.TotalShares)
+	}
+	return success
+}
+
+// PlacementExcludedCountry is a configuration struct that contains the country
+// codes excluded from the health of segments of a given placement.
+//
+// Can be used as a flag.
+type PlacementExcludedCountry struct {
+	Placement    storj.PlacementConstraint
+	CountryCodes []string
+}
+
+// Type implements pflag.Value.
+func (PlacementExcludedCountry) Type() string { return "checker.PlacementExcludedCountry" }
+
+// String is required for pflag.Value.
+func (pec *PlacementExcludedCountry) String() string {
+	return fmt.Sprintf("%d:%s", pec.Placement, strings.Join(pec.CountryCodes, ";"))
+}
+
+// Set sets the value from a string in the format placement:CC;CC.
+func (pec *PlacementExcludedCountry) Set(s string) error {
+	info := strings.Split(s, ":")
+	if len(info) != 2 {
+		return Error.New("Invalid placement excluded countries config (expect format placement:CC;CC, got %s)", s)
+	}
+
+	placement, err := strconv.ParseUint(info[0], 10, 16)
+	if err != nil {
+		return Error.New("Invalid placement value (should be valid integer): %s, %w", info[0], err)
+	}
+
+	var countryCodes []string
+	for _, code := range strings.Split(info[1], ";") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		if len(code) != 2 {
+			return Error.New("Invalid country code (expect two letters): %s", code)
+		}
+		countryCodes = append(countryCodes, code)
+	}
+	if len(countryCodes) == 0 {
+		return Error.New("Invalid placement excluded countries config (no country codes): %s", s)
+	}
+
+	pec.Placement = storj.PlacementConstraint(placement)
+	pec.CountryCodes = countryCodes
+	return nil
+}
+
+// PlacementExcludedCountries is a configuration struct that contains a list of
+// excluded country codes for various placements.
+//
+// Can be used as a flag.
+type PlacementExcludedCountries struct {
+	List []PlacementExcludedCountry
+}
+
+// Type implements pflag.Value.
+func (PlacementExcludedCountries) Type() string { return "checker.PlacementExcludedCountries" }
+
+// String is required for pflag.Value. It is a comma separated list of PlacementExcludedCountry configs.
+func (pecs *PlacementExcludedCountries) String() string {
+	var s strings.Builder
+	for i, pec := range pecs.List {
+		if i > 0 {
+			s.WriteString(",")
+		}
+		s.WriteString(pec.String())
+	}
+	return s.String()
+}
+
+// Set sets the value from a string in the format "placement:CC;CC,placement:CC,...".
+func (pecs *PlacementExcludedCountries) Set(s string) error {
+	pecs.List = nil
+	pecStrings := strings.Split(s, ",")
+	for _, pecString := range pecStrings {
+		pecString = strings.TrimSpace(pecString)
+		if pecString == "" {
+			continue
+		}
+		newPec := PlacementExcludedCountry{}
+		err := newPec.Set(pecString)
+		if err != nil {
+			return err
+		}
+		pecs.List = append(pecs.List, newPec)
+	}
+	return nil
+}
+
+// GetMap creates a PlacementExcludedCountriesMap from the config.
+func (pecs *PlacementExcludedCountries) GetMap() PlacementExcludedCountriesMap {
+	newMap := PlacementExcludedCountriesMap{
+		countriesMap: make(map[storj.PlacementConstraint][]string),
+	}
+	for _, pec := range pecs.List {
+		newMap.countriesMap[pec.Placement] = pec.CountryCodes
+	}
+	return newMap
+}
+
+// PlacementExcludedCountriesMap is derived from the PlacementExcludedCountries config, and is used
+// for quickly retrieving the excluded countries of a placement.
+type PlacementExcludedCountriesMap struct {
+	// map of placement -> excluded country codes
+	countriesMap map[storj.PlacementConstraint][]string
+}
+
+// GetExcludedCountries returns the country codes excluded for segments with the given
+// placement. When the placement has no entry, ok is false and the globally excluded
+// countries apply.
+func (pecm *PlacementExcludedCountriesMap) GetExcludedCountries(placement storj.PlacementConstraint) (countryCodes []string, ok bool) {
+	countryCodes, ok = pecm.countriesMap[placement]
+	return countryCodes, ok
+}
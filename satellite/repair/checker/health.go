@@ -0,0 +1,166 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/storj/location"
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/overlay"
+)
+
+// HealthChore periodically recomputes a compact health summary of every
+// remote segment and stores it in the segment health table, so that
+// dashboards don't need to compute segment health from scratch.
+//
+// architecture: Chore
+type HealthChore struct {
+	log       *zap.Logger
+	metabase  *metabase.DB
+	overlay   *overlay.Service
+	nodestate *ReliabilityCache
+	batchSize int
+
+	Loop *sync2.Cycle
+}
+
+// NewHealthChore creates a new segment health chore.
+func NewHealthChore(log *zap.Logger, metabase *metabase.DB, overlay *overlay.Service, config Config) *HealthChore {
+	return &HealthChore{
+		log:       log,
+		metabase:  metabase,
+		overlay:   overlay,
+		nodestate: NewReliabilityCache(overlay, config.ReliabilityCacheStaleness, config.PlacementExcludedCountries),
+		batchSize: config.HealthBatchSize,
+
+		Loop: sync2.NewCycle(config.HealthInterval),
+	}
+}
+
+// Run runs the segment health chore.
+func (chore *HealthChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return chore.Loop.Run(ctx, chore.RecomputeHealth)
+}
+
+// Close halts the segment health chore.
+func (chore *HealthChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// RecomputeHealth computes the health summary of all remote segments, stores
+// it and removes the summaries of segments which don't exist anymore.
+func (chore *HealthChore) RecomputeHealth(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// all summaries of a single run share the timestamp, so that the stale
+	// summaries can be removed at the end of the run.
+	checkedAt := time.Now()
+	countries := nodeCountries{overlay: chore.overlay, countries: map[storj.NodeID]*location.CountryCode{}}
+
+	var checked, nonCompliant int64
+	batch := make([]metabase.SegmentHealth, 0, chore.batchSize)
+
+	err = chore.metabase.IterateLoopSegments(ctx, metabase.IterateLoopSegments{
+		BatchSize: chore.batchSize,
+	}, func(ctx context.Context, it metabase.LoopSegmentsIterator) error {
+		var segment metabase.LoopSegmentEntry
+		for it.Next(ctx, &segment) {
+			if segment.Inline() || (segment.ExpiresAt != nil && segment.ExpiresAt.Before(checkedAt)) {
+				continue
+			}
+
+			missingPieces, err := chore.nodestate.MissingPieces(ctx, segment.CreatedAt, segment.Placement, segment.Pieces)
+			if err != nil {
+				return Error.New("error getting missing pieces: %w", err)
+			}
+
+			compliant, err := countries.compliant(ctx, segment.Placement, segment.Pieces)
+			if err != nil {
+				return err
+			}
+			if !compliant {
+				nonCompliant++
+			}
+
+			batch = append(batch, metabase.SegmentHealth{
+				StreamID:           segment.StreamID,
+				Position:           segment.Position,
+				HealthyPieces:      int32(len(segment.Pieces) - len(missingPieces)),
+				PlacementCompliant: compliant,
+				CheckedAt:          checkedAt,
+			})
+			checked++
+
+			if len(batch) >= chore.batchSize {
+				if err := chore.metabase.UpsertSegmentHealth(ctx, batch); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err := chore.metabase.UpsertSegmentHealth(ctx, batch); err != nil {
+		return Error.Wrap(err)
+	}
+
+	deleted, err := chore.metabase.DeleteSegmentHealthCheckedBefore(ctx, checkedAt)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	mon.IntVal("segment_health_checked").Observe(checked)
+	mon.IntVal("segment_health_placement_non_compliant").Observe(nonCompliant)
+	mon.IntVal("segment_health_stale_deleted").Observe(deleted)
+
+	return nil
+}
+
+// nodeCountries caches the country codes of nodes during a single health run.
+type nodeCountries struct {
+	overlay *overlay.Service
+	// countries contains nil for nodes which don't exist anymore.
+	countries map[storj.NodeID]*location.CountryCode
+}
+
+// compliant returns whether all pieces are stored on nodes allowed by the placement.
+func (nc *nodeCountries) compliant(ctx context.Context, placement storj.PlacementConstraint, pieces metabase.Pieces) (_ bool, err error) {
+	if placement == storj.EveryCountry {
+		return true, nil
+	}
+
+	for _, piece := range pieces {
+		country, ok := nc.countries[piece.StorageNode]
+		if !ok {
+			node, err := nc.overlay.Get(ctx, piece.StorageNode)
+			switch {
+			case overlay.ErrNodeNotFound.Has(err):
+			case err != nil:
+				return false, Error.New("error getting node %s: %w", piece.StorageNode, err)
+			default:
+				country = &node.CountryCode
+			}
+			nc.countries[piece.StorageNode] = country
+		}
+
+		// pieces on unknown nodes can't be verified to be compliant.
+		if country == nil || !placement.AllowedCountry(*country) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
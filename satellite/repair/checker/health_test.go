@@ -0,0 +1,108 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestHealthChore(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		chore := satellite.Repair.HealthChore
+		metabaseDB := satellite.Metabase.DB
+
+		rs := storj.RedundancyScheme{
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    5,
+			ShareSize:      256,
+		}
+
+		location := metabase.SegmentLocation{
+			ProjectID:  planet.Uplinks[0].Projects[0].ID,
+			BucketName: "test-bucket",
+		}
+
+		location.ObjectKey = "healthy"
+		healthy := insertSegment(ctx, t, planet, rs, location, createPieces(planet, rs), nil)
+
+		location.ObjectKey = "lost"
+		lost := insertSegment(ctx, t, planet, rs, location, createLostPieces(planet, rs), nil)
+
+		location.ObjectKey = "placement"
+		placement := insertSegment(ctx, t, planet, rs, location, createPieces(planet, rs), nil)
+		_, err := metabaseDB.UnderlyingTagSQL().ExecContext(ctx,
+			`UPDATE segments SET placement = $2 WHERE stream_id = $1`, placement, storj.EU)
+		require.NoError(t, err)
+
+		for _, node := range planet.StorageNodes {
+			require.NoError(t, satellite.Overlay.Service.TestNodeCountryCode(ctx, node.ID(), "DE"))
+		}
+
+		require.NoError(t, chore.RecomputeHealth(ctx))
+
+		expected := map[string]struct {
+			healthyPieces int32
+			compliant     bool
+		}{
+			healthy.String():   {healthyPieces: 4, compliant: true},
+			lost.String():      {healthyPieces: int32(rs.RequiredShares), compliant: true},
+			placement.String(): {healthyPieces: 4, compliant: true},
+		}
+
+		checkHealth := func() {
+			segments, err := metabaseDB.TestingAllSegments(ctx)
+			require.NoError(t, err)
+			require.Len(t, segments, len(expected))
+
+			for _, segment := range segments {
+				health, err := metabaseDB.GetSegmentHealth(ctx, segment.StreamID, segment.Position)
+				require.NoError(t, err)
+
+				// the stored summary has to match the actual state of the segment
+				exp := expected[segment.StreamID.String()]
+				require.Equal(t, exp.healthyPieces, health.HealthyPieces, segment.StreamID)
+				require.Equal(t, exp.compliant, health.PlacementCompliant, segment.StreamID)
+				require.False(t, health.CheckedAt.IsZero())
+			}
+		}
+		checkHealth()
+
+		// a piece outside of the placement makes the segment non-compliant,
+		// other segments don't care about the country.
+		require.NoError(t, satellite.Overlay.Service.TestNodeCountryCode(ctx, planet.StorageNodes[0].ID(), "US"))
+		require.NoError(t, chore.RecomputeHealth(ctx))
+
+		expected[placement.String()] = struct {
+			healthyPieces int32
+			compliant     bool
+		}{healthyPieces: 4, compliant: false}
+		checkHealth()
+
+		// summaries of removed segments are deleted
+		_, err = metabaseDB.UnderlyingTagSQL().ExecContext(ctx, `DELETE FROM segments WHERE stream_id = $1`, lost)
+		require.NoError(t, err)
+		require.NoError(t, chore.RecomputeHealth(ctx))
+
+		_, err = metabaseDB.GetSegmentHealth(ctx, lost, metabase.SegmentPosition{})
+		require.True(t, metabase.ErrSegmentNotFound.Has(err))
+
+		delete(expected, lost.String())
+		checkHealth()
+	})
+}
@@ -19,23 +19,28 @@ import (
 //
 // architecture: Service
 type ReliabilityCache struct {
-	overlay   *overlay.Service
-	staleness time.Duration
-	mu        sync.Mutex
-	state     atomic.Value // contains immutable *reliabilityState
+	overlay           *overlay.Service
+	staleness         time.Duration
+	excludedCountries PlacementExcludedCountriesMap
+	mu                sync.Mutex
+	state             atomic.Value // contains immutable *reliabilityState
 }
 
 // reliabilityState.
 type reliabilityState struct {
 	reliable map[storj.NodeID]struct{}
-	created  time.Time
+	// placementReliable contains the reliable nodes of placements which
+	// have their own excluded countries.
+	placementReliable map[storj.PlacementConstraint]map[storj.NodeID]struct{}
+	created           time.Time
 }
 
 // NewReliabilityCache creates a new reliability checking cache.
-func NewReliabilityCache(overlay *overlay.Service, staleness time.Duration) *ReliabilityCache {
+func NewReliabilityCache(overlay *overlay.Service, staleness time.Duration, excludedCountries PlacementExcludedCountries) *ReliabilityCache {
 	return &ReliabilityCache{
-		overlay:   overlay,
-		staleness: staleness,
+		overlay:           overlay,
+		staleness:         staleness,
+		excludedCountries: excludedCountries.GetMap(),
 	}
 }
 
@@ -63,16 +68,23 @@ func (cache *ReliabilityCache) NumNodes(ctx context.Context) (numNodes int, err
 }
 
 // MissingPieces returns piece indices that are unreliable with the given staleness period.
-func (cache *ReliabilityCache) MissingPieces(ctx context.Context, created time.Time, pieces metabase.Pieces) (_ []metabase.Piece, err error) {
+// Pieces on nodes in the countries excluded for the placement are unreliable as well.
+func (cache *ReliabilityCache) MissingPieces(ctx context.Context, created time.Time, placement storj.PlacementConstraint, pieces metabase.Pieces) (_ []metabase.Piece, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	state, err := cache.loadFast(ctx, created)
 	if err != nil {
 		return nil, err
 	}
+
+	reliable := state.reliable
+	if placementReliable, ok := state.placementReliable[placement]; ok {
+		reliable = placementReliable
+	}
+
 	var unreliable []metabase.Piece
 	for _, p := range pieces {
-		if _, ok := state.reliable[p.StorageNode]; !ok {
+		if _, ok := reliable[p.StorageNode]; !ok {
 			unreliable = append(unreliable, p)
 		}
 	}
@@ -133,6 +145,22 @@ func (cache *ReliabilityCache) refreshLocked(ctx context.Context) (_ *reliabilit
 		state.reliable[id] = struct{}{}
 	}
 
+	if len(cache.excludedCountries.countriesMap) > 0 {
+		state.placementReliable = make(map[storj.PlacementConstraint]map[storj.NodeID]struct{}, len(cache.excludedCountries.countriesMap))
+		for placement, countryCodes := range cache.excludedCountries.countriesMap {
+			nodes, err := cache.overlay.ReliableOutsideCountries(ctx, countryCodes)
+			if err != nil {
+				return nil, Error.Wrap(err)
+			}
+
+			reliable := make(map[storj.NodeID]struct{}, len(nodes))
+			for _, id := range nodes {
+				reliable[id] = struct{}{}
+			}
+			state.placementReliable[placement] = reliable
+		}
+	}
+
 	cache.state.Store(state)
 	return state, nil
 }
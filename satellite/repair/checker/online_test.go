@@ -24,13 +24,13 @@ func TestReliabilityCache_Concurrent(t *testing.T) {
 
 	ocache, err := overlay.NewService(zap.NewNop(), fakeOverlayDB{}, overlay.Config{})
 	require.NoError(t, err)
-	rcache := NewReliabilityCache(ocache, time.Millisecond)
+	rcache := NewReliabilityCache(ocache, time.Millisecond, PlacementExcludedCountries{})
 
 	for i := 0; i < 10; i++ {
 		ctx.Go(func() error {
 			for i := 0; i < 10000; i++ {
 				pieces := []metabase.Piece{{StorageNode: testrand.NodeID()}}
-				_, err := rcache.MissingPieces(ctx, time.Now(), pieces)
+				_, err := rcache.MissingPieces(ctx, time.Now(), storj.EveryCountry, pieces)
 				if err != nil {
 					return err
 				}
@@ -157,3 +157,78 @@ func TestRepairOverride(t *testing.T) {
 	require.EqualValues(t, 0, ro.GetOverrideValue(storjSchemes[3]))
 	require.EqualValues(t, 0, ro.GetOverrideValuePB(pbSchemes[3]))
 }
+
+func TestPlacementSuccessOverrideConfigValidation(t *testing.T) {
+	tests := []struct {
+		description    string
+		overrideConfig string
+		expectError    bool
+		size           int
+	}{
+		{
+			description:    "valid multi placement success override config",
+			overrideConfig: "1:60,2:80",
+			size:           2,
+		},
+		{
+			description:    "valid single placement success override config",
+			overrideConfig: "1:60",
+			size:           1,
+		},
+		{
+			description:    "valid empty placement success override config",
+			overrideConfig: "",
+			size:           0,
+		},
+		{
+			description:    "invalid placement success override config - wrong format",
+			overrideConfig: "1-60",
+			expectError:    true,
+		},
+		{
+			description:    "invalid placement success override config - strings",
+			overrideConfig: "a:60",
+			expectError:    true,
+		},
+		{
+			description:    "invalid placement success override config - zero success",
+			overrideConfig: "1:0",
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Log(tt.description)
+
+		newOverrides := checker.PlacementSuccessOverrides{}
+		err := newOverrides.Set(tt.overrideConfig)
+		if tt.expectError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+			require.Len(t, newOverrides.List, tt.size)
+		}
+	}
+}
+
+func TestPlacementSuccessOverrideThreshold(t *testing.T) {
+	newOverrides := checker.PlacementSuccessOverrides{}
+	require.NoError(t, newOverrides.Set("1:60,2:20,3:200"))
+	overridesMap := newOverrides.GetMap()
+
+	rs := storj.RedundancyScheme{
+		RequiredShares: 29,
+		RepairShares:   35,
+		OptimalShares:  50,
+		TotalShares:    110,
+	}
+
+	// no override for the placement
+	require.Equal(t, 50, overridesMap.GetSuccessThreshold(storj.EveryCountry, rs))
+	// override above optimal shares
+	require.Equal(t, 60, overridesMap.GetSuccessThreshold(1, rs))
+	// override doesn't lower optimal shares
+	require.Equal(t, 50, overridesMap.GetSuccessThreshold(2, rs))
+	// override is capped at total shares
+	require.Equal(t, 110, overridesMap.GetSuccessThreshold(3, rs))
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package queue
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+)
+
+// RepairCheckpoints stores the pieces which were uploaded to new nodes while
+// repairing a segment, so that an interrupted repair can be resumed without
+// uploading those pieces again. Downloaded data isn't checkpointed, a resumed
+// repair downloads the pieces again.
+//
+// Storage nodes garbage collect pieces which aren't referenced by a segment,
+// so recorded pieces are only reused while they are younger than the
+// garbage collection interval.
+// Implementation can be found at satellite/satellitedb/repaircheckpoints.go.
+//
+// architecture: Database
+type RepairCheckpoints interface {
+	// AddPiece records a piece uploaded while repairing the segment.
+	AddPiece(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, piece metabase.Piece) error
+	// Get returns the pieces recorded for the segment which were uploaded after uploadedAfter.
+	Get(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, uploadedAfter time.Time) (metabase.Pieces, error)
+	// Delete removes the checkpoint of the segment.
+	Delete(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition) error
+	// DeleteUploadedBefore removes the recorded pieces which were uploaded before the given time.
+	DeleteUploadedBefore(ctx context.Context, before time.Time) (deleted int64, err error)
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestRepairCheckpoints(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		checkpoints := db.RepairCheckpoints()
+
+		streamID := testrand.UUID()
+		position := metabase.SegmentPosition{Index: 1}
+		piece := metabase.Piece{Number: 3, StorageNode: testrand.NodeID()}
+
+		require.NoError(t, checkpoints.AddPiece(ctx, streamID, position, piece))
+
+		pieces, err := checkpoints.Get(ctx, streamID, position, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, metabase.Pieces{piece}, pieces)
+
+		// pieces uploaded before the cutoff aren't returned
+		pieces, err = checkpoints.Get(ctx, streamID, position, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, pieces)
+
+		deleted, err := checkpoints.DeleteUploadedBefore(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+
+		deleted, err = checkpoints.DeleteUploadedBefore(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		pieces, err = checkpoints.Get(ctx, streamID, position, time.Time{})
+		require.NoError(t, err)
+		require.Empty(t, pieces)
+	})
+}
@@ -5,6 +5,7 @@ package queue
 
 import (
 	"context"
+	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 )
@@ -22,6 +23,10 @@ type InsertBuffer struct {
 	// is flushed to the queue and it is determined that it wasn't already queued for repair.
 	// This is made to collect metrics.
 	newInsertCallbacks map[*InjuredSegment]func()
+
+	// oldestInsertedAt is the earliest time one of the flushed segments was
+	// first queued for repair.
+	oldestInsertedAt time.Time
 }
 
 // NewInsertBuffer wraps a RepairQueue with buffer logic.
@@ -67,6 +72,15 @@ func (r *InsertBuffer) Flush(ctx context.Context) (err error) {
 		return err
 	}
 
+	for _, segment := range r.batch {
+		if segment.InsertedAt.IsZero() {
+			continue
+		}
+		if r.oldestInsertedAt.IsZero() || segment.InsertedAt.Before(r.oldestInsertedAt) {
+			r.oldestInsertedAt = segment.InsertedAt
+		}
+	}
+
 	for _, segment := range newlyInsertedSegments {
 		callback := r.newInsertCallbacks[segment]
 		if callback != nil {
@@ -79,6 +93,12 @@ func (r *InsertBuffer) Flush(ctx context.Context) (err error) {
 	return nil
 }
 
+// OldestInsertedAt returns the earliest time one of the flushed segments was
+// first queued for repair. It's zero when no segment was flushed.
+func (r *InsertBuffer) OldestInsertedAt() time.Time {
+	return r.oldestInsertedAt
+}
+
 func (r *InsertBuffer) clearInternals() {
 	// make room for the next batch
 	r.batch = r.batch[:0]
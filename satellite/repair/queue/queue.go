@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase"
 )
@@ -21,6 +22,17 @@ type InjuredSegment struct {
 	AttemptedAt   *time.Time
 	UpdatedAt     time.Time
 	InsertedAt    time.Time
+
+	// Held is set for segments of objects under legal hold or retention.
+	// They are repaired ahead of other segments with the same health.
+	Held bool
+
+	// Attempts is the number of times the segment was selected for repair.
+	Attempts int
+
+	// Placement is the placement of the segment, which is used to partition
+	// the repair work between repairers.
+	Placement storj.PlacementConstraint
 }
 
 // RepairQueue implements queueing for segments that need repairing.
@@ -30,19 +42,31 @@ type InjuredSegment struct {
 type RepairQueue interface {
 	// Insert adds an injured segment.
 	Insert(ctx context.Context, s *InjuredSegment) (alreadyInserted bool, err error)
-	// InsertBatch adds multiple injured segments
+	// InsertBatch adds multiple injured segments. InsertedAt of every given
+	// segment is set to the time the segment was first queued.
 	InsertBatch(ctx context.Context, segments []*InjuredSegment) (newlyInsertedSegments []*InjuredSegment, err error)
-	// Select gets an injured segment.
-	Select(ctx context.Context) (*InjuredSegment, error)
+	// Select gets an injured segment. Segments with the lowest health are
+	// selected first, held segments before others with the same health.
+	// When placements are specified, only segments of those placements are selected.
+	Select(ctx context.Context, includedPlacements ...storj.PlacementConstraint) (*InjuredSegment, error)
 	// Delete removes an injured segment.
 	Delete(ctx context.Context, s *InjuredSegment) error
 	// Clean removes all segments last updated before a certain time
 	Clean(ctx context.Context, before time.Time) (deleted int64, err error)
 	// SelectN lists limit amount of injured segments.
 	SelectN(ctx context.Context, limit int) ([]InjuredSegment, error)
-	// Count counts the number of segments in the repair queue.
+	// Count counts the number of segments in the repair queue, excluding
+	// segments which exhausted their repair attempts.
 	Count(ctx context.Context) (count int, err error)
+	// MarkExhausted moves a segment which exhausted its repair attempts out of
+	// the active queue, so that it isn't selected for repair until the checker
+	// reports a different segment health.
+	MarkExhausted(ctx context.Context, s *InjuredSegment) error
+	// CountExhausted counts the number of segments which exhausted their repair attempts.
+	CountExhausted(ctx context.Context) (count int, err error)
 
 	// TestingSetAttemptedTime sets attempted time for a segment.
 	TestingSetAttemptedTime(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, t time.Time) (rowsAffected int64, err error)
+	// TestingSetInsertedTime sets inserted time for a segment.
+	TestingSetInsertedTime(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, t time.Time) (rowsAffected int64, err error)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/common/uuid"
@@ -261,6 +262,165 @@ func TestOrderOverwrite(t *testing.T) {
 	})
 }
 
+// TestOrderHeld ensures that held segments are selected before other segments with the same health.
+func TestOrderHeld(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		unheld := uuid.UUID{1}
+		held := uuid.UUID{2}
+		heldHealthier := uuid.UUID{3}
+		lessHealthy := uuid.UUID{4}
+
+		// the unheld segment is inserted first, so it would be selected
+		// first if held segments weren't prioritized.
+		for _, item := range []queue.InjuredSegment{
+			{StreamID: unheld, SegmentHealth: 9},
+			{StreamID: held, SegmentHealth: 9, Held: true},
+			{StreamID: lessHealthy, SegmentHealth: 8},
+		} {
+			item := item
+			alreadyInserted, err := repairQueue.Insert(ctx, &item)
+			require.NoError(t, err)
+			require.False(t, alreadyInserted)
+		}
+
+		inserted, err := repairQueue.InsertBatch(ctx, []*queue.InjuredSegment{
+			{StreamID: heldHealthier, SegmentHealth: 10, Held: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, inserted, 1)
+
+		// being held doesn't outweigh the health of the segment.
+		for _, next := range []struct {
+			streamID uuid.UUID
+			held     bool
+		}{
+			{lessHealthy, false},
+			{held, true},
+			{unheld, false},
+			{heldHealthier, true},
+		} {
+			injuredSeg, err := repairQueue.Select(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, next.streamID, injuredSeg.StreamID)
+			assert.Equal(t, next.held, injuredSeg.Held)
+		}
+
+		// queue should be considered "empty" now
+		injuredSeg, err := repairQueue.Select(ctx)
+		assert.True(t, storage.ErrEmptyQueue.Has(err))
+		assert.Nil(t, injuredSeg)
+	})
+}
+
+func TestSelectIncludedPlacements(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		everyCountry := uuid.UUID{1}
+		eu := uuid.UUID{2}
+		euBatch := uuid.UUID{3}
+
+		for _, item := range []queue.InjuredSegment{
+			{StreamID: everyCountry, SegmentHealth: 1, Placement: storj.EveryCountry},
+			{StreamID: eu, SegmentHealth: 2, Placement: storj.EU},
+		} {
+			item := item
+			alreadyInserted, err := repairQueue.Insert(ctx, &item)
+			require.NoError(t, err)
+			require.False(t, alreadyInserted)
+		}
+
+		inserted, err := repairQueue.InsertBatch(ctx, []*queue.InjuredSegment{
+			{StreamID: euBatch, SegmentHealth: 3, Placement: storj.EU},
+		})
+		require.NoError(t, err)
+		require.Len(t, inserted, 1)
+
+		// the unhealthiest segment of other placements is ignored.
+		for _, expected := range []uuid.UUID{eu, euBatch} {
+			injuredSeg, err := repairQueue.Select(ctx, storj.EU)
+			require.NoError(t, err)
+			assert.Equal(t, expected, injuredSeg.StreamID)
+			assert.Equal(t, storj.EU, injuredSeg.Placement)
+		}
+
+		_, err = repairQueue.Select(ctx, storj.EU)
+		assert.True(t, storage.ErrEmptyQueue.Has(err))
+
+		injuredSeg, err := repairQueue.Select(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, everyCountry, injuredSeg.StreamID)
+		assert.Equal(t, storj.EveryCountry, injuredSeg.Placement)
+	})
+}
+
+func TestAttemptsAndExhausted(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		injuredSeg := &queue.InjuredSegment{StreamID: testrand.UUID()}
+		_, err := repairQueue.Insert(ctx, injuredSeg)
+		require.NoError(t, err)
+
+		// every selection of the segment counts as an attempt
+		for attempt := 1; attempt <= 2; attempt++ {
+			selected, err := repairQueue.Select(ctx)
+			require.NoError(t, err)
+			require.Equal(t, attempt, selected.Attempts)
+
+			_, err = repairQueue.TestingSetAttemptedTime(ctx, selected.StreamID, selected.Position, time.Now().Add(-7*time.Hour))
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, repairQueue.MarkExhausted(ctx, injuredSeg))
+
+		// the exhausted segment isn't part of the active queue anymore
+		_, err = repairQueue.Select(ctx)
+		require.True(t, storage.ErrEmptyQueue.Has(err))
+
+		count, err := repairQueue.Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		count, err = repairQueue.CountExhausted(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		// updates from the checker with the same health don't bring the segment back
+		_, err = repairQueue.Insert(ctx, injuredSeg)
+		require.NoError(t, err)
+
+		_, err = repairQueue.Select(ctx)
+		require.True(t, storage.ErrEmptyQueue.Has(err))
+
+		// a changed health resets the attempts and brings the segment back
+		injuredSeg.SegmentHealth = 1
+		_, err = repairQueue.Insert(ctx, injuredSeg)
+		require.NoError(t, err)
+
+		count, err = repairQueue.CountExhausted(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		selected, err := repairQueue.Select(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, selected.Attempts)
+
+		// the same applies to batch inserts
+		require.NoError(t, repairQueue.MarkExhausted(ctx, injuredSeg))
+
+		injuredSeg.SegmentHealth = 2
+		_, err = repairQueue.InsertBatch(ctx, []*queue.InjuredSegment{injuredSeg})
+		require.NoError(t, err)
+
+		count, err = repairQueue.CountExhausted(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
+
 func TestCount(t *testing.T) {
 	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
 		repairQueue := db.RepairQueue()
@@ -282,3 +442,44 @@ func TestCount(t *testing.T) {
 	})
 
 }
+
+func TestInsertBatchInsertedAt(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		now := time.Now()
+		oldSegment := &queue.InjuredSegment{
+			StreamID:      testrand.UUID(),
+			SegmentHealth: 3,
+		}
+		_, err := repairQueue.InsertBatch(ctx, []*queue.InjuredSegment{oldSegment})
+		require.NoError(t, err)
+		require.WithinDuration(t, now, oldSegment.InsertedAt, time.Minute)
+
+		_, err = repairQueue.TestingSetInsertedTime(ctx, oldSegment.StreamID, oldSegment.Position, now.Add(-48*time.Hour))
+		require.NoError(t, err)
+
+		// segments already in the queue keep the time they were first queued
+		updatedSegment := &queue.InjuredSegment{
+			StreamID:      oldSegment.StreamID,
+			SegmentHealth: 2,
+		}
+		newSegment := &queue.InjuredSegment{
+			StreamID:      testrand.UUID(),
+			SegmentHealth: 5,
+		}
+		inserted, err := repairQueue.InsertBatch(ctx, []*queue.InjuredSegment{updatedSegment, newSegment})
+		require.NoError(t, err)
+		require.Equal(t, []*queue.InjuredSegment{newSegment}, inserted)
+		require.WithinDuration(t, now.Add(-48*time.Hour), updatedSegment.InsertedAt, time.Second)
+		require.WithinDuration(t, now, newSegment.InsertedAt, time.Minute)
+
+		insertBuffer := queue.NewInsertBuffer(repairQueue, 10)
+		require.True(t, insertBuffer.OldestInsertedAt().IsZero())
+
+		require.NoError(t, insertBuffer.Insert(ctx, &queue.InjuredSegment{StreamID: newSegment.StreamID, SegmentHealth: 5}, nil))
+		require.NoError(t, insertBuffer.Insert(ctx, &queue.InjuredSegment{StreamID: oldSegment.StreamID, SegmentHealth: 2}, nil))
+		require.NoError(t, insertBuffer.Flush(ctx))
+		require.WithinDuration(t, now.Add(-48*time.Hour), insertBuffer.OldestInsertedAt(), time.Second)
+	})
+}
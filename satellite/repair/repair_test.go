@@ -10,6 +10,7 @@ import (
 	"io"
 	"math"
 	"net"
+	"runtime"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
+	"storj.io/common/identity"
 	"storj.io/common/memory"
 	"storj.io/common/pb"
 	"storj.io/common/rpc"
@@ -34,6 +36,7 @@ import (
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/repair/checker"
+	"storj.io/storj/satellite/repair/queue"
 	"storj.io/storj/satellite/repair/repairer"
 	"storj.io/storj/satellite/reputation"
 	"storj.io/storj/storage"
@@ -50,13 +53,16 @@ import (
 //	 threshold
 // - Downloads the data from those left nodes and check that it's the same than the uploaded one.
 func TestDataRepairInMemory(t *testing.T) {
-	testDataRepair(t, true)
+	testDataRepair(t, true, false)
 }
 func TestDataRepairToDisk(t *testing.T) {
-	testDataRepair(t, false)
+	testDataRepair(t, false, false)
+}
+func TestDataRepairStreaming(t *testing.T) {
+	testDataRepair(t, false, true)
 }
 
-func testDataRepair(t *testing.T, inMemoryRepair bool) {
+func testDataRepair(t *testing.T, inMemoryRepair, streamingRepair bool) {
 	const (
 		RepairMaxExcessRateOptimalThreshold = 0.05
 		minThreshold                        = 3
@@ -72,6 +78,7 @@ func testDataRepair(t *testing.T, inMemoryRepair bool) {
 				func(log *zap.Logger, index int, config *satellite.Config) {
 					config.Repairer.MaxExcessRateOptimalThreshold = RepairMaxExcessRateOptimalThreshold
 					config.Repairer.InMemoryRepair = inMemoryRepair
+					config.Repairer.StreamingRepair = streamingRepair
 				},
 				testplanet.ReconfigureRS(minThreshold, 5, successThreshold, 9),
 			),
@@ -444,6 +451,84 @@ func TestMinRequiredDataRepair(t *testing.T) {
 	})
 }
 
+// TestRepairSourceStats checks that the bytes downloaded from each node
+// for repair are accounted to the node.
+func TestRepairSourceStats(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 15,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.InMemoryRepair = true
+				},
+				testplanet.ReconfigureRS(4, 4, 9, 9),
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
+
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Equal(t, 9, len(segment.Pieces))
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+		pieceSize := eestream.CalcPieceSize(int64(segment.EncryptedSize), redundancy)
+
+		// keep exactly the minimum number of pieces, so every remaining node is a repair source
+		var sourceNodes, killedNodes storj.NodeIDList
+		for i, piece := range segment.Pieces {
+			if i >= 5 {
+				sourceNodes = append(sourceNodes, piece.StorageNode)
+				continue
+			}
+
+			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+			require.NoError(t, err)
+			killedNodes = append(killedNodes, piece.StorageNode)
+		}
+
+		sourceStats := satellite.Repairer.EcRepairer.SourceStats()
+		before := sourceStats.TakeWindow(time.Now())
+		require.Empty(t, before.Downloaded)
+
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
+
+		for _, nodeID := range sourceNodes {
+			require.Equal(t, pieceSize, sourceStats.Downloaded(nodeID))
+		}
+		for _, nodeID := range killedNodes {
+			require.Zero(t, sourceStats.Downloaded(nodeID))
+		}
+
+		window := sourceStats.TakeWindow(time.Now())
+		require.Equal(t, before.To, window.From)
+		require.Len(t, window.Downloaded, len(sourceNodes))
+		for _, nodeID := range sourceNodes {
+			require.Equal(t, pieceSize, window.Downloaded[nodeID])
+		}
+
+		// a new window starts empty
+		require.Zero(t, sourceStats.Downloaded(sourceNodes[0]))
+	})
+}
+
 // TestFailedDataRepair does the following:
 // - Uploads test data
 // - Kills some nodes carrying the uploaded segment but keep it above minimum requirement
@@ -1262,6 +1347,81 @@ func TestCorruptDataRepair_Failed(t *testing.T) {
 	})
 }
 
+// TestCorruptDataRepair_Streaming checks that a piece failing the verification
+// during a streaming repair is reported as a failed audit and removed from
+// the segment, even though it's only detected after the download started.
+func TestCorruptDataRepair_Streaming(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 15,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.StreamingRepair = true
+					config.Repairer.StreamingSparePieces = 0
+				},
+				testplanet.ReconfigureRS(4, 4, 9, 9),
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
+
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Equal(t, 9, len(segment.Pieces))
+
+		// kill nodes so that exactly the required number of pieces is left
+		var availablePieces metabase.Pieces
+		for i, piece := range segment.Pieces {
+			if i >= 5 {
+				availablePieces = append(availablePieces, piece)
+				continue
+			}
+			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+			require.NoError(t, err)
+		}
+
+		corruptedPiece := availablePieces[0]
+		corruptedNode := planet.FindNode(corruptedPiece.StorageNode)
+		require.NotNil(t, corruptedNode)
+		corruptedPieceID := segment.RootPieceID.Derive(corruptedPiece.StorageNode, int32(corruptedPiece.Number))
+		corruptPieceData(ctx, t, planet, corruptedNode, corruptedPieceID)
+
+		reputationService := satellite.Reputation.Service
+		infoBefore, err := reputationService.Get(ctx, corruptedPiece.StorageNode)
+		require.NoError(t, err)
+
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
+
+		infoAfter, err := reputationService.Get(ctx, corruptedPiece.StorageNode)
+		require.NoError(t, err)
+		require.Equal(t, infoBefore.TotalAuditCount+1, infoAfter.TotalAuditCount)
+		require.Equal(t, infoBefore.AuditSuccessCount, infoAfter.AuditSuccessCount)
+
+		// the corrupted piece is removed, no new pieces are added
+		segmentAfter, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Len(t, segmentAfter.Pieces, len(segment.Pieces)-1)
+		for _, piece := range segmentAfter.Pieces {
+			require.NotEqual(t, corruptedPiece.Number, piece.Number)
+		}
+	})
+}
+
 // TestRepairExpiredSegment
 // - Upload tests data to 7 nodes
 // - Kill nodes so that repair threshold > online nodes > minimum threshold
@@ -1693,23 +1853,28 @@ func TestIrreparableSegmentAccordingToOverlay(t *testing.T) {
 	})
 }
 
-// TestIrreparableSegmentNodesOffline
+// TestRepairAttemptsExhausted
 // - Upload tests data to 7 nodes
-// - Disqualify nodes so that repair threshold > online nodes > minimum threshold
+// - Disqualify nodes so that the segment is irreparable
 // - Call checker to add segment to the repair queue
-// - Kill (as opposed to disqualifying) nodes so that online nodes < minimum threshold
-// - Run the repairer
-// - Verify segment is still in the repair queue.
-func TestIrreparableSegmentNodesOffline(t *testing.T) {
+// - Run the repairer until the repair attempts are exhausted
+// - Verify segment left the active repair queue and isn't selected anymore.
+func TestRepairAttemptsExhausted(t *testing.T) {
+	const maxRepairAttempts = 2
+
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
 		StorageNodeCount: 10,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
-			Satellite: testplanet.ReconfigureRS(3, 5, 7, 7),
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.MaxRepairAttempts = maxRepairAttempts
+				},
+				testplanet.ReconfigureRS(3, 5, 7, 7),
+			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
-		// first, upload some remote data
 		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
 		// stop audit to prevent possible interactions i.e. repair timeout problems
@@ -1718,470 +1883,454 @@ func TestIrreparableSegmentNodesOffline(t *testing.T) {
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
-		testData := testrand.Bytes(8 * memory.KiB)
-
-		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
 		require.NoError(t, err)
 
 		segment, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
 
-		// kill 3 nodes and mark them as offline so that pointer has 4 left from overlay
-		// perspective (less than repair threshold)
-		toMarkOffline := 3
+		// dq 3 nodes so that pointer has 4 left (less than repair threshold)
 		remotePieces := segment.Pieces
+		for i := 0; i < 3; i++ {
+			err := satellite.DB.OverlayCache().DisqualifyNode(ctx, remotePieces[i].StorageNode, time.Now(), overlay.DisqualificationReasonUnknown)
+			require.NoError(t, err)
+		}
 
-		for _, piece := range remotePieces[:toMarkOffline] {
-			node := planet.FindNode(piece.StorageNode)
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
 
-			err := planet.StopNodeAndUpdate(ctx, node)
+		// dq the rest of the nodes, so that every repair attempt fails
+		for _, piece := range remotePieces {
+			err := satellite.DB.OverlayCache().DisqualifyNode(ctx, piece.StorageNode, time.Now(), overlay.DisqualificationReasonUnknown)
 			require.NoError(t, err)
+		}
 
-			err = updateNodeCheckIn(ctx, satellite.DB.OverlayCache(), node, false, time.Now().Add(-24*time.Hour))
+		repairQueue := satellite.DB.RepairQueue()
+		runRepairer := func() {
+			satellite.Repair.Repairer.Loop.Restart()
+			satellite.Repair.Repairer.Loop.TriggerWait()
+			satellite.Repair.Repairer.Loop.Pause()
+			satellite.Repair.Repairer.WaitForPendingRepairs()
+
+			// make the segment available for the next attempt right away
+			_, err := repairQueue.TestingSetAttemptedTime(ctx, segment.StreamID, segment.Position, time.Now().Add(-7*time.Hour))
 			require.NoError(t, err)
 		}
 
-		// trigger checker to add segment to repair queue
-		satellite.Repair.Checker.Loop.Restart()
-		satellite.Repair.Checker.Loop.TriggerWait()
-		satellite.Repair.Checker.Loop.Pause()
+		for attempt := 1; attempt < maxRepairAttempts; attempt++ {
+			runRepairer()
 
-		// Verify that the segment is on the repair queue
-		count, err := satellite.DB.RepairQueue().Count(ctx)
+			count, err := repairQueue.Count(ctx)
+			require.NoError(t, err)
+			require.Equal(t, 1, count, "segment should stay in the queue after attempt %d", attempt)
+		}
+
+		// the last allowed attempt exhausts the segment, the one after doesn't select it
+		runRepairer()
+		runRepairer()
+
+		count, err := repairQueue.Count(ctx)
 		require.NoError(t, err)
-		require.Equal(t, count, 1)
+		require.Zero(t, count)
 
-		// Kill 2 extra nodes so that the number of available pieces is less than the minimum
-		for _, piece := range remotePieces[toMarkOffline : toMarkOffline+2] {
-			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+		count, err = repairQueue.CountExhausted(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		segments, err := repairQueue.SelectN(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		require.Equal(t, maxRepairAttempts, segments[0].Attempts)
+	})
+}
+
+// TestRepairBandwidthByPlacement does the following:
+// - Uploads two objects and moves the segment of one of them to the EU placement
+// - Disqualifies nodes so that both segments need repair
+// - Runs the checker and the repairer
+// - Verifies that the repair bandwidth is accounted separately for each placement.
+func TestRepairBandwidthByPlacement(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 10,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(2, 3, 4, 4),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		for _, bucket := range []string{"bucket-a", "bucket-b"} {
+			err := planet.Uplinks[0].Upload(ctx, satellite, bucket, "test/path", testrand.Bytes(8*memory.KiB))
 			require.NoError(t, err)
 		}
 
-		// Mark nodes as online again so that online nodes > minimum threshold
-		// This will make the repair worker attempt to download the pieces
-		for _, piece := range remotePieces[:toMarkOffline] {
-			node := planet.FindNode(piece.StorageNode)
-			err := updateNodeCheckIn(ctx, satellite.DB.OverlayCache(), node, true, time.Now())
-			require.NoError(t, err)
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 2)
+
+		_, err = satellite.Metabase.DB.UnderlyingTagSQL().ExecContext(ctx,
+			`UPDATE segments SET placement = $2 WHERE stream_id = $1`, segments[1].StreamID, storj.EU)
+		require.NoError(t, err)
+
+		// leave at most the repair threshold of healthy pieces in each segment.
+		disqualified := make(map[storj.NodeID]bool)
+		for _, segment := range segments {
+			healthy := 0
+			for _, piece := range segment.Pieces {
+				if !disqualified[piece.StorageNode] {
+					healthy++
+				}
+			}
+			for _, piece := range segment.Pieces {
+				if healthy <= int(segment.Redundancy.RequiredShares) {
+					break
+				}
+				if disqualified[piece.StorageNode] {
+					continue
+				}
+				err := satellite.DB.OverlayCache().DisqualifyNode(ctx, piece.StorageNode, time.Now(), overlay.DisqualificationReasonUnknown)
+				require.NoError(t, err)
+				disqualified[piece.StorageNode] = true
+				healthy--
+			}
 		}
 
-		// Run the repairer
+		require.NoError(t, satellite.Repair.Checker.RefreshReliabilityCache(ctx))
+		satellite.Repair.Checker.Loop.TriggerWait()
+
+		count, err := satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
 		satellite.Repair.Repairer.Loop.Restart()
 		satellite.Repair.Repairer.Loop.TriggerWait()
 		satellite.Repair.Repairer.Loop.Pause()
 		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		// Verify that the irreparable segment is still in repair queue
 		count, err = satellite.DB.RepairQueue().Count(ctx)
 		require.NoError(t, err)
-		require.Equal(t, 1, count)
-	})
-}
+		require.Zero(t, count)
 
-func updateNodeCheckIn(ctx context.Context, overlayDB overlay.DB, node *testplanet.StorageNode, isUp bool, timestamp time.Time) error {
-	local := node.Contact.Service.Local()
-	checkInInfo := overlay.NodeCheckInInfo{
-		NodeID: node.ID(),
-		Address: &pb.NodeAddress{
-			Address: local.Address,
-		},
-		LastIPPort: local.Address,
-		IsUp:       isUp,
-		Operator:   &local.Operator,
-		Capacity:   &local.Capacity,
-		Version:    &local.Version,
-	}
-	return overlayDB.UpdateCheckIn(ctx, checkInInfo, time.Now().Add(-24*time.Hour), overlay.NodeSelectionConfig{})
+		// the repair bandwidth is written when the orders write cache is flushed.
+		rollups, err := satellite.DB.StoragenodeAccounting().GetRepairBandwidthByPlacement(ctx, time.Now().Add(-2*time.Hour), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, rollups)
+
+		satellite.Repairer.Orders.Chore.Loop.TriggerWait()
+
+		now := time.Now()
+		rollups, err = satellite.DB.StoragenodeAccounting().GetRepairBandwidthByPlacement(ctx, now.Add(-2*time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+
+		allocated := make(map[storj.PlacementConstraint]map[pb.PieceAction]int64)
+		for _, rollup := range rollups {
+			if allocated[rollup.Placement] == nil {
+				allocated[rollup.Placement] = make(map[pb.PieceAction]int64)
+			}
+			allocated[rollup.Placement][rollup.Action] += rollup.Allocated
+		}
+
+		require.Len(t, allocated, 2)
+		for _, placement := range []storj.PlacementConstraint{storj.EveryCountry, storj.EU} {
+			require.Positive(t, allocated[placement][pb.PieceAction_GET_REPAIR], placement)
+			require.Positive(t, allocated[placement][pb.PieceAction_PUT_REPAIR], placement)
+		}
+	})
 }
 
-// TestRepairMultipleDisqualifiedAndSuspended does the following:
-// - Uploads test data to 7 nodes
-// - Disqualifies 2 nodes and suspends 1 node
-// - Triggers data repair, which repairs the data from the remaining 4 nodes to additional 3 new nodes
-// - Shuts down the 4 nodes from which the data was repaired
-// - Now we have just the 3 new nodes to which the data was repaired
-// - Downloads the data from these 3 nodes (succeeds because 3 nodes are enough for download)
-// - Expect newly repaired pointer does not contain the disqualified or suspended nodes.
-func TestRepairMultipleDisqualifiedAndSuspended(t *testing.T) {
+// TestRepairDryRun
+// - Upload tests data to 7 nodes
+// - Disqualify nodes so that the segment is below the repair threshold
+// - Run the checker and the repairer in dry-run mode
+// - Verify that the segment pieces are unchanged and the segment stays in the repair queue.
+func TestRepairDryRun(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 12,
+		StorageNodeCount: 10,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
 			Satellite: testplanet.Combine(
 				func(log *zap.Logger, index int, config *satellite.Config) {
-					config.Repairer.InMemoryRepair = true
+					config.Repairer.DryRun = true
 				},
 				testplanet.ReconfigureRS(3, 5, 7, 7),
 			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
-		// first, upload some remote data
 		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Stop()
 
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
 		testData := testrand.Bytes(8 * memory.KiB)
-
 		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
 
-		// get a remote segment from metainfo
-		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
-		require.NoError(t, err)
-		require.Len(t, segments, 1)
-		require.False(t, segments[0].Inline())
-
-		// calculate how many storagenodes to disqualify
-		numStorageNodes := len(planet.StorageNodes)
-		remotePieces := segments[0].Pieces
-		numPieces := len(remotePieces)
-		// sanity check
-		require.EqualValues(t, numPieces, 7)
-		toDisqualify := 2
-		toSuspend := 1
-		// we should have enough storage nodes to repair on
-		require.True(t, (numStorageNodes-toDisqualify-toSuspend) >= numPieces)
-
-		// disqualify nodes and track lost pieces
-		nodesToDisqualify := make(map[storj.NodeID]bool)
-		nodesToSuspend := make(map[storj.NodeID]bool)
-		nodesToKeepAlive := make(map[storj.NodeID]bool)
+		segment, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
 
-		// disqualify and suspend nodes
-		for i := 0; i < toDisqualify; i++ {
-			nodesToDisqualify[remotePieces[i].StorageNode] = true
-			err := satellite.DB.OverlayCache().DisqualifyNode(ctx, remotePieces[i].StorageNode, time.Now(), overlay.DisqualificationReasonUnknown)
-			require.NoError(t, err)
-		}
-		for i := toDisqualify; i < toDisqualify+toSuspend; i++ {
-			nodesToSuspend[remotePieces[i].StorageNode] = true
-			err := satellite.DB.OverlayCache().TestSuspendNodeUnknownAudit(ctx, remotePieces[i].StorageNode, time.Now())
+		// dq 3 nodes so that pointer has 4 left (less than repair threshold)
+		for i := 0; i < 3; i++ {
+			err := satellite.DB.OverlayCache().DisqualifyNode(ctx, segment.Pieces[i].StorageNode, time.Now(), overlay.DisqualificationReasonUnknown)
 			require.NoError(t, err)
 		}
-		for i := toDisqualify + toSuspend; i < len(remotePieces); i++ {
-			nodesToKeepAlive[remotePieces[i].StorageNode] = true
-		}
 
-		err = satellite.Repair.Checker.RefreshReliabilityCache(ctx)
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+
+		count, err := satellite.DB.RepairQueue().Count(ctx)
 		require.NoError(t, err)
+		require.Equal(t, 1, count)
 
-		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Restart()
 		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
 		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		// kill nodes kept alive to ensure repair worked
-		for _, node := range planet.StorageNodes {
-			if nodesToKeepAlive[node.ID()] {
-				err := planet.StopNodeAndUpdate(ctx, node)
-				require.NoError(t, err)
-			}
-		}
-
-		// we should be able to download data without any of the original nodes
-		newData, err := uplinkPeer.Download(ctx, satellite, "testbucket", "test/path")
-		require.NoError(t, err)
-		require.Equal(t, newData, testData)
+		segmentAfter, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
+		require.Equal(t, segment.Pieces, segmentAfter.Pieces)
+		require.Equal(t, segment.RepairedAt, segmentAfter.RepairedAt)
 
-		segments, err = satellite.Metabase.DB.TestingAllSegments(ctx)
+		count, err = satellite.DB.RepairQueue().Count(ctx)
 		require.NoError(t, err)
-		require.Len(t, segments, 1)
-
-		remotePieces = segments[0].Pieces
-		for _, piece := range remotePieces {
-			require.False(t, nodesToDisqualify[piece.StorageNode])
-			require.False(t, nodesToSuspend[piece.StorageNode])
-		}
+		require.Equal(t, 1, count)
 	})
 }
 
-// TestDataRepairOverride_HigherLimit does the following:
-//   - Uploads test data
-//   - Kills nodes to fall to the Repair Override Value of the checker but stays above the original Repair Threshold
-//   - Triggers data repair, which attempts to repair the data from the remaining nodes to
-//	   the numbers of nodes determined by the upload repair max threshold
-func TestDataRepairOverride_HigherLimit(t *testing.T) {
-	const repairOverride = 6
-
+// TestIrreparableSegmentNodesOffline
+// - Upload tests data to 7 nodes
+// - Disqualify nodes so that repair threshold > online nodes > minimum threshold
+// - Call checker to add segment to the repair queue
+// - Kill (as opposed to disqualifying) nodes so that online nodes < minimum threshold
+// - Run the repairer
+// - Verify segment is still in the repair queue.
+func TestIrreparableSegmentNodesOffline(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 14,
+		StorageNodeCount: 10,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
-			Satellite: testplanet.Combine(
-				func(log *zap.Logger, index int, config *satellite.Config) {
-					config.Repairer.InMemoryRepair = true
-					config.Checker.RepairOverrides = checker.RepairOverrides{
-						List: []checker.RepairOverride{
-							{Min: 3, Success: 9, Total: 9, Override: repairOverride},
-						},
-					}
-				},
-				testplanet.ReconfigureRS(3, 4, 9, 9),
-			),
+			Satellite: testplanet.ReconfigureRS(3, 5, 7, 7),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		// first, upload some remote data
 		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
 		// stop audit to prevent possible interactions i.e. repair timeout problems
-		satellite.Audit.Worker.Loop.Pause()
+		satellite.Audit.Worker.Loop.Stop()
 
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
-		var testData = testrand.Bytes(8 * memory.KiB)
-		// first, upload some remote data
+		testData := testrand.Bytes(8 * memory.KiB)
+
 		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
 
 		segment, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
 
-		// calculate how many storagenodes to kill
-		// kill one nodes less than repair threshold to ensure we dont hit it.
+		// kill 3 nodes and mark them as offline so that pointer has 4 left from overlay
+		// perspective (less than repair threshold)
+		toMarkOffline := 3
 		remotePieces := segment.Pieces
-		numPieces := len(remotePieces)
-		toKill := numPieces - repairOverride
-		require.True(t, toKill >= 1)
 
-		// kill nodes and track lost pieces
-		nodesToKill := make(map[storj.NodeID]bool)
-		originalNodes := make(map[storj.NodeID]bool)
+		for _, piece := range remotePieces[:toMarkOffline] {
+			node := planet.FindNode(piece.StorageNode)
 
-		for i, piece := range remotePieces {
-			originalNodes[piece.StorageNode] = true
-			if i >= toKill {
-				// this means the node will be kept alive for repair
-				continue
-			}
-			nodesToKill[piece.StorageNode] = true
-		}
+			err := planet.StopNodeAndUpdate(ctx, node)
+			require.NoError(t, err)
 
-		for _, node := range planet.StorageNodes {
-			if nodesToKill[node.ID()] {
-				err := planet.StopNodeAndUpdate(ctx, node)
-				require.NoError(t, err)
-			}
+			err = updateNodeCheckIn(ctx, satellite.DB.OverlayCache(), node, false, time.Now().Add(-24*time.Hour))
+			require.NoError(t, err)
 		}
 
+		// trigger checker to add segment to repair queue
 		satellite.Repair.Checker.Loop.Restart()
 		satellite.Repair.Checker.Loop.TriggerWait()
 		satellite.Repair.Checker.Loop.Pause()
+
+		// Verify that the segment is on the repair queue
+		count, err := satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, count, 1)
+
+		// Kill 2 extra nodes so that the number of available pieces is less than the minimum
+		for _, piece := range remotePieces[toMarkOffline : toMarkOffline+2] {
+			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+			require.NoError(t, err)
+		}
+
+		// Mark nodes as online again so that online nodes > minimum threshold
+		// This will make the repair worker attempt to download the pieces
+		for _, piece := range remotePieces[:toMarkOffline] {
+			node := planet.FindNode(piece.StorageNode)
+			err := updateNodeCheckIn(ctx, satellite.DB.OverlayCache(), node, true, time.Now())
+			require.NoError(t, err)
+		}
+
+		// Run the repairer
 		satellite.Repair.Repairer.Loop.Restart()
 		satellite.Repair.Repairer.Loop.TriggerWait()
 		satellite.Repair.Repairer.Loop.Pause()
 		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		// repair should have been done, due to the override
-		segment, _ = getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
-
-		// pointer should have the success count of pieces
-		remotePieces = segment.Pieces
-		require.Equal(t, int(segment.Redundancy.OptimalShares), len(remotePieces))
+		// Verify that the irreparable segment is still in repair queue
+		count, err = satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
 	})
 }
 
-// TestDataRepairOverride_LowerLimit does the following:
-//   - Uploads test data
-//   - Kills nodes to fall to the Repair Threshold of the checker that should not trigger repair any longer
-//   - Starts Checker and Repairer and ensures this is the case.
-//   - Kills more nodes to fall to the Override Value to trigger repair
-//   - Triggers data repair, which attempts to repair the data from the remaining nodes to
-//	   the numbers of nodes determined by the upload repair max threshold
-func TestDataRepairOverride_LowerLimit(t *testing.T) {
-	const repairOverride = 4
-
+// TestRepairSkippedHealthy
+// - Upload tests data to 7 nodes
+// - Mark nodes as offline so that the segment is below the repair threshold
+// - Call checker to add segment to the repair queue
+// - Mark nodes as online again, so the segment recovers on its own
+// - Run the repairer
+// - Verify that the segment was skipped as healthy and removed from the queue.
+func TestRepairSkippedHealthy(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 14,
+		StorageNodeCount: 10,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
-			Satellite: testplanet.Combine(
-				func(log *zap.Logger, index int, config *satellite.Config) {
-					config.Repairer.InMemoryRepair = true
-					config.Checker.RepairOverrides = checker.RepairOverrides{
-						List: []checker.RepairOverride{
-							{Min: 3, Success: 9, Total: 9, Override: repairOverride},
-						},
-					}
-				},
-				testplanet.ReconfigureRS(3, 6, 9, 9),
-			),
+			Satellite: testplanet.ReconfigureRS(3, 5, 7, 7),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
 		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
 		// stop audit to prevent possible interactions i.e. repair timeout problems
-		satellite.Audit.Worker.Loop.Pause()
+		satellite.Audit.Worker.Loop.Stop()
 
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
-		var testData = testrand.Bytes(8 * memory.KiB)
-		// first, upload some remote data
-		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
 		require.NoError(t, err)
 
 		segment, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
 
-		// calculate how many storagenodes to kill
-		// to hit the repair threshold
-		remotePieces := segment.Pieces
-		repairThreshold := int(segment.Redundancy.RepairShares)
-		numPieces := len(remotePieces)
-		toKill := numPieces - repairThreshold
-		require.True(t, toKill >= 1)
-
-		// kill nodes and track lost pieces
-		nodesToKill := make(map[storj.NodeID]bool)
-		originalNodes := make(map[storj.NodeID]bool)
-
-		for i, piece := range remotePieces {
-			originalNodes[piece.StorageNode] = true
-			if i >= toKill {
-				// this means the node will be kept alive for repair
-				continue
-			}
-			nodesToKill[piece.StorageNode] = true
-		}
-
-		for _, node := range planet.StorageNodes {
-			if nodesToKill[node.ID()] {
-				err := planet.StopNodeAndUpdate(ctx, node)
-				require.NoError(t, err)
-			}
+		// mark 3 nodes as offline, by their last successful contact being too
+		// long ago, so that pointer has 4 left (less than repair threshold)
+		offlinePieces := segment.Pieces[:3]
+		for _, piece := range offlinePieces {
+			err := updateNodeCheckIn(ctx, satellite.DB.OverlayCache(), planet.FindNode(piece.StorageNode), true, time.Now().Add(-24*time.Hour))
+			require.NoError(t, err)
 		}
 
 		satellite.Repair.Checker.Loop.Restart()
 		satellite.Repair.Checker.Loop.TriggerWait()
 		satellite.Repair.Checker.Loop.Pause()
-		satellite.Repair.Repairer.Loop.Restart()
-		satellite.Repair.Repairer.Loop.TriggerWait()
-		satellite.Repair.Repairer.Loop.Pause()
-		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		// Increase offline count by the difference to trigger repair
-		toKill += repairThreshold - repairOverride
+		count, err := satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
 
-		for i, piece := range remotePieces {
-			originalNodes[piece.StorageNode] = true
-			if i >= toKill {
-				// this means the node will be kept alive for repair
-				continue
-			}
-			nodesToKill[piece.StorageNode] = true
+		// the nodes come back online before the repairer gets to the segment
+		for _, piece := range offlinePieces {
+			err := updateNodeCheckIn(ctx, satellite.DB.OverlayCache(), planet.FindNode(piece.StorageNode), true, time.Now())
+			require.NoError(t, err)
 		}
 
-		for _, node := range planet.StorageNodes {
-			if nodesToKill[node.ID()] {
-				err = planet.StopNodeAndUpdate(ctx, node)
-				require.NoError(t, err)
-			}
-		}
+		skippedBefore := satellite.Repairer.SegmentRepairer.SkippedHealthy()
 
-		satellite.Repair.Checker.Loop.Restart()
-		satellite.Repair.Checker.Loop.TriggerWait()
-		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Restart()
 		satellite.Repair.Repairer.Loop.TriggerWait()
 		satellite.Repair.Repairer.Loop.Pause()
 		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		// repair should have been done, due to the override
-		segment, _ = getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
+		require.Equal(t, skippedBefore+1, satellite.Repairer.SegmentRepairer.SkippedHealthy())
 
-		// pointer should have the success count of pieces
-		remotePieces = segment.Pieces
-		require.Equal(t, int(segment.Redundancy.OptimalShares), len(remotePieces))
-	})
-}
+		segmentAfter, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
+		require.Equal(t, segment.Pieces, segmentAfter.Pieces)
 
-// TestDataRepairUploadLimits does the following:
-//   - Uploads test data to nodes
-//   - Get one segment of that data to check in which nodes its pieces are stored
-//   - Kills as many nodes as needed which store such segment pieces
-//   - Triggers data repair
-//   - Verify that the number of pieces which repaired has uploaded don't overpass
-//     the established limit (success threshold + % of excess)
-func TestDataRepairUploadLimit(t *testing.T) {
+		count, err = satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
+
+func updateNodeCheckIn(ctx context.Context, overlayDB overlay.DB, node *testplanet.StorageNode, isUp bool, timestamp time.Time) error {
+	local := node.Contact.Service.Local()
+	checkInInfo := overlay.NodeCheckInInfo{
+		NodeID: node.ID(),
+		Address: &pb.NodeAddress{
+			Address: local.Address,
+		},
+		LastIPPort: local.Address,
+		IsUp:       isUp,
+		Operator:   &local.Operator,
+		Capacity:   &local.Capacity,
+		Version:    &local.Version,
+	}
+	return overlayDB.UpdateCheckIn(ctx, checkInInfo, timestamp, overlay.NodeSelectionConfig{})
+}
+
+// TestDegradedRepair does the following:
+//   - Uploads test data to 6 of 8 nodes
+//   - Kills nodes so that the segment is at the repair threshold
+//   - Triggers data repair, which can only find 2 new nodes instead of the 3 needed
+//     for the success threshold
+//   - Verifies the segment was repaired above the repair threshold and flagged as
+//     degraded-repaired
+func TestDegradedRepair(t *testing.T) {
 	const (
-		RepairMaxExcessRateOptimalThreshold = 0.05
-		repairThreshold                     = 5
-		successThreshold                    = 7
-		maxThreshold                        = 9
+		repairThreshold  = 3
+		successThreshold = 6
 	)
 
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 13,
+		StorageNodeCount: 8,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
 			Satellite: testplanet.Combine(
 				func(log *zap.Logger, index int, config *satellite.Config) {
-					config.Repairer.MaxExcessRateOptimalThreshold = RepairMaxExcessRateOptimalThreshold
 					config.Repairer.InMemoryRepair = true
+					config.Repairer.MaxExcessRateOptimalThreshold = 0
+					config.Repairer.AllowDegradedRepair = true
 				},
-				testplanet.ReconfigureRS(3, repairThreshold, successThreshold, maxThreshold),
+				testplanet.ReconfigureRS(2, repairThreshold, successThreshold, successThreshold),
 			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
 		// stop audit to prevent possible interactions i.e. repair timeout problems
 		satellite.Audit.Worker.Loop.Pause()
+
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
-		var (
-			maxRepairUploadThreshold = int(
-				math.Ceil(
-					float64(successThreshold) * (1 + RepairMaxExcessRateOptimalThreshold),
-				),
-			)
-			ul       = planet.Uplinks[0]
-			testData = testrand.Bytes(8 * memory.KiB)
-		)
-
-		err := ul.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
 		require.NoError(t, err)
 
-		segment, _ := getRemoteSegment(ctx, t, satellite, ul.Projects[0].ID, "testbucket")
-
-		originalPieces := segment.Pieces
-		require.True(t, len(originalPieces) <= maxThreshold)
+		segment, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
+		require.Len(t, segment.Pieces, successThreshold)
 
-		{ // Check that there is enough nodes in the network which don't contain
-			// pieces of the segment for being able to repair the lost pieces
-			availableNumNodes := len(planet.StorageNodes) - len(originalPieces)
-			neededNodesForRepair := maxRepairUploadThreshold - repairThreshold
-			require.Truef(t,
-				availableNumNodes >= neededNodesForRepair,
-				"Not enough remaining nodes in the network for repairing the pieces: have= %d, need= %d",
-				availableNumNodes, neededNodesForRepair,
-			)
+		// kill nodes so that exactly the repair threshold of pieces stays healthy,
+		// which leaves only 2 nodes without a piece for the repair.
+		nodesToKill := make(map[storj.NodeID]bool)
+		for _, piece := range segment.Pieces[repairThreshold:] {
+			nodesToKill[piece.StorageNode] = true
 		}
-
-		originalStorageNodes := make(map[storj.NodeID]struct{})
-		for _, p := range originalPieces {
-			originalStorageNodes[p.StorageNode] = struct{}{}
+		for _, node := range planet.StorageNodes {
+			if nodesToKill[node.ID()] {
+				require.NoError(t, planet.StopNodeAndUpdate(ctx, node))
+			}
 		}
 
-		killedNodes := make(map[storj.NodeID]struct{})
-		{ // Register nodes of the network which don't have pieces for the segment
-			// to be injured and ill nodes which have pieces of the segment in order
-			// to injure it
-			numNodesToKill := len(originalPieces) - repairThreshold
-			for _, node := range planet.StorageNodes {
-				if _, ok := originalStorageNodes[node.ID()]; !ok {
-					continue
-				}
-
-				if len(killedNodes) < numNodesToKill {
-					err := planet.StopNodeAndUpdate(ctx, node)
-					require.NoError(t, err)
-
-					killedNodes[node.ID()] = struct{}{}
-				}
-			}
+		var degradedHealthy int
+		satellite.Repairer.SegmentRepairer.OnTestingDegradedRepairHook = func(degraded metabase.Segment, healthyAfterRepair int) {
+			require.Equal(t, segment.StreamID, degraded.StreamID)
+			degradedHealthy = healthyAfterRepair
 		}
 
 		satellite.Repair.Checker.Loop.Restart()
@@ -2192,41 +2341,31 @@ func TestDataRepairUploadLimit(t *testing.T) {
 		satellite.Repair.Repairer.Loop.Pause()
 		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		// Get the pointer after repair to check the nodes where the pieces are
-		// stored
-		segment, _ = getRemoteSegment(ctx, t, satellite, ul.Projects[0].ID, "testbucket")
-
-		// Check that repair has uploaded missed pieces to an expected number of
-		// nodes
-		afterRepairPieces := segment.Pieces
-		require.Falsef(t,
-			len(afterRepairPieces) > maxRepairUploadThreshold,
-			"Repaired pieces cannot be over max repair upload threshold. maxRepairUploadThreshold= %d, have= %d",
-			maxRepairUploadThreshold, len(afterRepairPieces),
-		)
-		require.Falsef(t,
-			len(afterRepairPieces) < successThreshold,
-			"Repaired pieces shouldn't be under success threshold. successThreshold= %d, have= %d",
-			successThreshold, len(afterRepairPieces),
-		)
+		// the segment was repaired to the best achievable count and flagged
+		require.Equal(t, repairThreshold+2, degradedHealthy)
 
-		// Check that after repair, the segment doesn't have more pieces on the
-		// killed nodes
-		for _, p := range afterRepairPieces {
-			require.NotContains(t, killedNodes, p.StorageNode, "there shouldn't be pieces in killed nodes")
+		segment, _ = getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
+		var healthy int
+		for _, piece := range segment.Pieces {
+			if !nodesToKill[piece.StorageNode] {
+				healthy++
+			}
 		}
+		require.Equal(t, repairThreshold+2, healthy)
+		require.Greater(t, healthy, repairThreshold)
+		require.Less(t, healthy, successThreshold)
 	})
 }
 
-// TestRepairGracefullyExited does the following:
+// TestRepairMultipleDisqualifiedAndSuspended does the following:
 // - Uploads test data to 7 nodes
-// - Set 3 nodes as gracefully exited
+// - Disqualifies 2 nodes and suspends 1 node
 // - Triggers data repair, which repairs the data from the remaining 4 nodes to additional 3 new nodes
 // - Shuts down the 4 nodes from which the data was repaired
 // - Now we have just the 3 new nodes to which the data was repaired
 // - Downloads the data from these 3 nodes (succeeds because 3 nodes are enough for download)
-// - Expect newly repaired pointer does not contain the gracefully exited nodes.
-func TestRepairGracefullyExited(t *testing.T) {
+// - Expect newly repaired pointer does not contain the disqualified or suspended nodes.
+func TestRepairMultipleDisqualifiedAndSuspended(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
 		StorageNodeCount: 12,
@@ -2252,34 +2391,40 @@ func TestRepairGracefullyExited(t *testing.T) {
 		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
 
-		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		// get a remote segment from metainfo
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		require.False(t, segments[0].Inline())
 
+		// calculate how many storagenodes to disqualify
 		numStorageNodes := len(planet.StorageNodes)
-		remotePieces := segment.Pieces
+		remotePieces := segments[0].Pieces
 		numPieces := len(remotePieces)
 		// sanity check
 		require.EqualValues(t, numPieces, 7)
-		toExit := 3
+		toDisqualify := 2
+		toSuspend := 1
 		// we should have enough storage nodes to repair on
-		require.True(t, (numStorageNodes-toExit) >= numPieces)
+		require.True(t, (numStorageNodes-toDisqualify-toSuspend) >= numPieces)
 
-		// gracefully exit nodes and track lost pieces
-		nodesToExit := make(map[storj.NodeID]bool)
+		// disqualify nodes and track lost pieces
+		nodesToDisqualify := make(map[storj.NodeID]bool)
+		nodesToSuspend := make(map[storj.NodeID]bool)
 		nodesToKeepAlive := make(map[storj.NodeID]bool)
 
-		// exit nodes
-		for i := 0; i < toExit; i++ {
-			nodesToExit[remotePieces[i].StorageNode] = true
-			req := &overlay.ExitStatusRequest{
-				NodeID:              remotePieces[i].StorageNode,
-				ExitInitiatedAt:     time.Now(),
-				ExitLoopCompletedAt: time.Now(),
-				ExitFinishedAt:      time.Now(),
-			}
-			_, err := satellite.DB.OverlayCache().UpdateExitStatus(ctx, req)
+		// disqualify and suspend nodes
+		for i := 0; i < toDisqualify; i++ {
+			nodesToDisqualify[remotePieces[i].StorageNode] = true
+			err := satellite.DB.OverlayCache().DisqualifyNode(ctx, remotePieces[i].StorageNode, time.Now(), overlay.DisqualificationReasonUnknown)
 			require.NoError(t, err)
 		}
-		for i := toExit; i < len(remotePieces); i++ {
+		for i := toDisqualify; i < toDisqualify+toSuspend; i++ {
+			nodesToSuspend[remotePieces[i].StorageNode] = true
+			err := satellite.DB.OverlayCache().TestSuspendNodeUnknownAudit(ctx, remotePieces[i].StorageNode, time.Now())
+			require.NoError(t, err)
+		}
+		for i := toDisqualify + toSuspend; i < len(remotePieces); i++ {
 			nodesToKeepAlive[remotePieces[i].StorageNode] = true
 		}
 
@@ -2293,7 +2438,8 @@ func TestRepairGracefullyExited(t *testing.T) {
 		// kill nodes kept alive to ensure repair worked
 		for _, node := range planet.StorageNodes {
 			if nodesToKeepAlive[node.ID()] {
-				require.NoError(t, planet.StopNodeAndUpdate(ctx, node))
+				err := planet.StopNodeAndUpdate(ctx, node)
+				require.NoError(t, err)
 			}
 		}
 
@@ -2302,177 +2448,167 @@ func TestRepairGracefullyExited(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, newData, testData)
 
-		// updated pointer should not contain any of the gracefully exited nodes
-		segmentAfter, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		segments, err = satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
 
-		remotePieces = segmentAfter.Pieces
+		remotePieces = segments[0].Pieces
 		for _, piece := range remotePieces {
-			require.False(t, nodesToExit[piece.StorageNode])
+			require.False(t, nodesToDisqualify[piece.StorageNode])
+			require.False(t, nodesToSuspend[piece.StorageNode])
 		}
 	})
 }
 
-// getRemoteSegment returns a remote pointer its path from satellite.
-// nolint:golint
-func getRemoteSegment(
-	ctx context.Context, t *testing.T, satellite *testplanet.Satellite, projectID uuid.UUID, bucketName string,
-) (_ metabase.Segment, key metabase.SegmentKey) {
-	t.Helper()
+// TestRepairDistinctNetworks does the following:
+// - Uploads test data to 5 nodes, which are on all the available distinct networks
+// - Disqualifies 1 node and fills up the node on the shared network, so it isn't selectable anymore
+// - Triggers data repair, which should only repair to the node on the remaining distinct network
+// - Expects no two pieces of the repaired segment to share a network.
+func TestRepairDistinctNetworks(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("Test does not work with macOS")
+	}
 
-	objects, err := satellite.Metabase.DB.TestingAllObjects(ctx)
-	require.NoError(t, err)
-	require.Len(t, objects, 1)
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 9,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			// nodes 0-3 share a network; nodes 4-8 each have a distinct network
+			UniqueIPCount: 5,
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Overlay.Node.DistinctIP = true
+					config.Repairer.InMemoryRepair = true
+					config.Repairer.MaxExcessRateOptimalThreshold = 0
+				},
+				testplanet.ReconfigureRS(2, 4, 5, 5),
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
 
-	segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
-	require.NoError(t, err)
-	require.Len(t, segments, 1)
-	require.False(t, segments[0].Inline())
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+		for _, node := range planet.StorageNodes {
+			node.Contact.Chore.Pause(ctx)
+		}
 
-	return segments[0], metabase.SegmentLocation{
-		ProjectID:  projectID,
-		BucketName: bucketName,
-		ObjectKey:  objects[0].ObjectKey,
-		Position:   segments[0].Position,
-	}.Encode()
-}
+		lastNet := func(nodeID storj.NodeID) string {
+			dossier, err := satellite.Overlay.Service.Get(ctx, nodeID)
+			require.NoError(t, err)
+			return dossier.LastNet
+		}
+		requireDistinctNetworks := func(pieces metabase.Pieces) {
+			networks := make(map[string]bool)
+			for _, piece := range pieces {
+				network := lastNet(piece.StorageNode)
+				require.False(t, networks[network], "network %q holds more than one piece", network)
+				networks[network] = true
+			}
+		}
 
-// corruptPieceData manipulates piece data on a storage node.
-func corruptPieceData(ctx context.Context, t *testing.T, planet *testplanet.Planet, corruptedNode *testplanet.StorageNode, corruptedPieceID storj.PieceID) {
-	t.Helper()
+		// keep the last distinct network out of the upload, so it's the only one left for repair
+		spareNode := planet.StorageNodes[len(planet.StorageNodes)-1]
+		err := satellite.DB.OverlayCache().TestSuspendNodeUnknownAudit(ctx, spareNode.ID(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, satellite.Overlay.Service.UploadSelectionCache.Refresh(ctx))
 
-	blobRef := storage.BlobRef{
-		Namespace: planet.Satellites[0].ID().Bytes(),
-		Key:       corruptedPieceID.Bytes(),
-	}
+		testData := testrand.Bytes(8 * memory.KiB)
+		err = uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
 
-	// get currently stored piece data from storagenode
-	reader, err := corruptedNode.Storage2.BlobsCache.Open(ctx, blobRef)
-	require.NoError(t, err)
-	pieceSize, err := reader.Size()
-	require.NoError(t, err)
-	require.True(t, pieceSize > 0)
-	pieceData := make([]byte, pieceSize)
-	n, err := io.ReadFull(reader, pieceData)
-	require.NoError(t, err)
-	require.EqualValues(t, n, pieceSize)
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		require.False(t, segments[0].Inline())
 
-	// delete piece data
-	err = corruptedNode.Storage2.BlobsCache.Delete(ctx, blobRef)
-	require.NoError(t, err)
+		remotePieces := segments[0].Pieces
+		require.Len(t, remotePieces, 5)
+		requireDistinctNetworks(remotePieces)
 
-	// corrupt piece data (not PieceHeader) and write back to storagenode
-	// this means repair downloading should fail during piece hash verification
-	pieceData[pieceSize-1]++ // if we don't do this, this test should fail
-	writer, err := corruptedNode.Storage2.BlobsCache.Create(ctx, blobRef, pieceSize)
-	require.NoError(t, err)
+		sharedNetwork := lastNet(planet.StorageNodes[0].ID())
+		var fullNode, disqualifiedNode storj.NodeID
+		for _, piece := range remotePieces {
+			if lastNet(piece.StorageNode) == sharedNetwork {
+				fullNode = piece.StorageNode
+			} else {
+				disqualifiedNode = piece.StorageNode
+			}
+		}
+		require.False(t, fullNode.IsZero())
+		require.False(t, disqualifiedNode.IsZero())
 
-	n, err = writer.Write(pieceData)
-	require.NoError(t, err)
-	require.EqualValues(t, n, pieceSize)
+		err = satellite.DB.OverlayCache().DisqualifyNode(ctx, disqualifiedNode, time.Now(), overlay.DisqualificationReasonUnknown)
+		require.NoError(t, err)
 
-	err = writer.Commit(ctx)
-	require.NoError(t, err)
-}
-
-type mockConnector struct {
-	realConnector   rpc.Connector
-	addressesDialed []string
-	dialInstead     map[string]string
-}
-
-func (m *mockConnector) DialContext(ctx context.Context, tlsConfig *tls.Config, address string) (rpc.ConnectorConn, error) {
-	m.addressesDialed = append(m.addressesDialed, address)
-	replacement := m.dialInstead[address]
-	if replacement == "" {
-		// allow numeric ip addresses through, return errors for unexpected dns lookups
-		host, _, err := net.SplitHostPort(address)
-		if err != nil {
-			return nil, err
-		}
-		if net.ParseIP(host) == nil {
-			return nil, &net.DNSError{
-				Err:        "unexpected lookup",
-				Name:       address,
-				Server:     "a.totally.real.dns.server.i.promise",
-				IsNotFound: true,
-			}
-		}
-		replacement = address
-	}
-	return m.realConnector.DialContext(ctx, tlsConfig, replacement)
-}
-
-func ecRepairerWithMockConnector(t testing.TB, sat *testplanet.Satellite, mock *mockConnector) *repairer.ECRepairer {
-	tlsOptions := sat.Dialer.TLSOptions
-	newDialer := rpc.NewDefaultDialer(tlsOptions)
-	mock.realConnector = newDialer.Connector
-	newDialer.Connector = mock
-
-	ec := repairer.NewECRepairer(
-		zaptest.NewLogger(t).Named("a-special-repairer"),
-		newDialer,
-		signing.SigneeFromPeerIdentity(sat.Identity.PeerIdentity()),
-		sat.Config.Repairer.DownloadTimeout,
-		sat.Config.Repairer.InMemoryRepair,
-	)
-	return ec
-}
-
-func TestECRepairerGet(t *testing.T) {
-	testplanet.Run(t, testplanet.Config{
-		SatelliteCount:   1,
-		StorageNodeCount: 6,
-		UplinkCount:      1,
-		Reconfigure: testplanet.Reconfigure{
-			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
-		},
-	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
-		uplinkPeer := planet.Uplinks[0]
-		satellite := planet.Satellites[0]
-
-		// stop audit to prevent possible interactions i.e. repair timeout problems
-		satellite.Audit.Worker.Loop.Pause()
-		satellite.Repair.Checker.Loop.Pause()
-		satellite.Repair.Repairer.Loop.Pause()
+		// the full node still holds a healthy piece, but isn't known to the upload selection cache anymore
+		_, err = satellite.Overlay.Service.UpdateNodeInfo(ctx, fullNode, &overlay.InfoResponse{
+			Capacity: &pb.NodeCapacity{FreeDisk: 0},
+		})
+		require.NoError(t, err)
 
-		var testData = testrand.Bytes(8 * memory.KiB)
-		// first, upload some remote data
-		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		err = satellite.DB.OverlayCache().TestUnsuspendNodeUnknownAudit(ctx, spareNode.ID())
 		require.NoError(t, err)
 
-		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.NoError(t, satellite.Overlay.Service.UploadSelectionCache.Refresh(ctx))
+		require.NoError(t, satellite.Repair.Checker.RefreshReliabilityCache(ctx))
 
-		ecRepairer := satellite.Repairer.EcRepairer
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
-		require.NoError(t, err)
-		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		segments, err = satellite.Metabase.DB.TestingAllSegments(ctx)
 		require.NoError(t, err)
+		require.Len(t, segments, 1)
 
-		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
-		require.NoError(t, err)
-		require.Equal(t, 0, len(piecesReport.Offline))
-		require.Equal(t, 0, len(piecesReport.Failed))
-		require.Equal(t, 0, len(piecesReport.Contained))
-		require.Equal(t, 0, len(piecesReport.Unknown))
-		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
+		remotePieces = segments[0].Pieces
+		require.Len(t, remotePieces, 5)
+		requireDistinctNetworks(remotePieces)
+
+		nodesWithPieces := make(map[storj.NodeID]bool)
+		for _, piece := range remotePieces {
+			nodesWithPieces[piece.StorageNode] = true
+		}
+		require.False(t, nodesWithPieces[disqualifiedNode])
+		require.True(t, nodesWithPieces[fullNode])
+		require.True(t, nodesWithPieces[spareNode.ID()])
 	})
 }
 
-func TestECRepairerGetCorrupted(t *testing.T) {
+// TestDataRepairOverride_HigherLimit does the following:
+//   - Uploads test data
+//   - Kills nodes to fall to the Repair Override Value of the checker but stays above the original Repair Threshold
+//   - Triggers data repair, which attempts to repair the data from the remaining nodes to
+//	   the numbers of nodes determined by the upload repair max threshold
+func TestDataRepairOverride_HigherLimit(t *testing.T) {
+	const repairOverride = 6
+
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 6,
+		StorageNodeCount: 14,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
-			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.InMemoryRepair = true
+					config.Checker.RepairOverrides = checker.RepairOverrides{
+						List: []checker.RepairOverride{
+							{Min: 3, Success: 9, Total: 9, Override: repairOverride},
+						},
+					}
+				},
+				testplanet.ReconfigureRS(3, 4, 9, 9),
+			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
 		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
-
 		// stop audit to prevent possible interactions i.e. repair timeout problems
 		satellite.Audit.Worker.Loop.Pause()
+
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
@@ -2481,66 +2617,85 @@ func TestECRepairerGetCorrupted(t *testing.T) {
 		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
 
-		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
-		require.Equal(t, 6, len(segment.Pieces))
-		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
-		toKill := 2
+		segment, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
+
+		// calculate how many storagenodes to kill
+		// kill one nodes less than repair threshold to ensure we dont hit it.
+		remotePieces := segment.Pieces
+		numPieces := len(remotePieces)
+		toKill := numPieces - repairOverride
+		require.True(t, toKill >= 1)
 
 		// kill nodes and track lost pieces
-		var corruptedPiece metabase.Piece
-		for i, piece := range segment.Pieces {
+		nodesToKill := make(map[storj.NodeID]bool)
+		originalNodes := make(map[storj.NodeID]bool)
+
+		for i, piece := range remotePieces {
+			originalNodes[piece.StorageNode] = true
 			if i >= toKill {
 				// this means the node will be kept alive for repair
-				// choose piece to corrupt
-				if corruptedPiece.StorageNode.IsZero() {
-					corruptedPiece = piece
-				}
 				continue
 			}
-
-			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
-			require.NoError(t, err)
+			nodesToKill[piece.StorageNode] = true
 		}
-		require.False(t, corruptedPiece.StorageNode.IsZero())
 
-		// corrupted node
-		corruptedNode := planet.FindNode(corruptedPiece.StorageNode)
-		require.NotNil(t, corruptedNode)
-		pieceID := segment.RootPieceID.Derive(corruptedPiece.StorageNode, int32(corruptedPiece.Number))
-		corruptPieceData(ctx, t, planet, corruptedNode, pieceID)
+		for _, node := range planet.StorageNodes {
+			if nodesToKill[node.ID()] {
+				err := planet.StopNodeAndUpdate(ctx, node)
+				require.NoError(t, err)
+			}
+		}
 
-		ecRepairer := satellite.Repairer.EcRepairer
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
-		require.NoError(t, err)
-		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
-		require.NoError(t, err)
+		// repair should have been done, due to the override
+		segment, _ = getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
 
-		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
-		require.NoError(t, err)
-		require.Equal(t, 0, len(piecesReport.Offline))
-		require.Equal(t, 1, len(piecesReport.Failed))
-		require.Equal(t, 0, len(piecesReport.Contained))
-		require.Equal(t, 0, len(piecesReport.Unknown))
-		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
-		require.Equal(t, corruptedPiece, piecesReport.Failed[0])
+		// pointer should have the success count of pieces
+		remotePieces = segment.Pieces
+		require.Equal(t, int(segment.Redundancy.OptimalShares), len(remotePieces))
 	})
 }
 
-func TestECRepairerGetMissingPiece(t *testing.T) {
+// TestDataRepairOverride_LowerLimit does the following:
+//   - Uploads test data
+//   - Kills nodes to fall to the Repair Threshold of the checker that should not trigger repair any longer
+//   - Starts Checker and Repairer and ensures this is the case.
+//   - Kills more nodes to fall to the Override Value to trigger repair
+//   - Triggers data repair, which attempts to repair the data from the remaining nodes to
+//	   the numbers of nodes determined by the upload repair max threshold
+func TestDataRepairOverride_LowerLimit(t *testing.T) {
+	const repairOverride = 4
+
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 6,
+		StorageNodeCount: 14,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
-			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.InMemoryRepair = true
+					config.Checker.RepairOverrides = checker.RepairOverrides{
+						List: []checker.RepairOverride{
+							{Min: 3, Success: 9, Total: 9, Override: repairOverride},
+						},
+					}
+				},
+				testplanet.ReconfigureRS(3, 6, 9, 9),
+			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
 		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
-
 		// stop audit to prevent possible interactions i.e. repair timeout problems
 		satellite.Audit.Worker.Loop.Pause()
+
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
@@ -2549,174 +2704,429 @@ func TestECRepairerGetMissingPiece(t *testing.T) {
 		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
 
-		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
-		require.Equal(t, 6, len(segment.Pieces))
-		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
-		toKill := 2
+		segment, _ := getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
+
+		// calculate how many storagenodes to kill
+		// to hit the repair threshold
+		remotePieces := segment.Pieces
+		repairThreshold := int(segment.Redundancy.RepairShares)
+		numPieces := len(remotePieces)
+		toKill := numPieces - repairThreshold
+		require.True(t, toKill >= 1)
 
 		// kill nodes and track lost pieces
-		var missingPiece metabase.Piece
-		for i, piece := range segment.Pieces {
+		nodesToKill := make(map[storj.NodeID]bool)
+		originalNodes := make(map[storj.NodeID]bool)
+
+		for i, piece := range remotePieces {
+			originalNodes[piece.StorageNode] = true
 			if i >= toKill {
 				// this means the node will be kept alive for repair
-				// choose a piece for deletion
-				if missingPiece.StorageNode.IsZero() {
-					missingPiece = piece
-				}
 				continue
 			}
+			nodesToKill[piece.StorageNode] = true
+		}
 
-			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
-			require.NoError(t, err)
+		for _, node := range planet.StorageNodes {
+			if nodesToKill[node.ID()] {
+				err := planet.StopNodeAndUpdate(ctx, node)
+				require.NoError(t, err)
+			}
 		}
-		require.False(t, missingPiece.StorageNode.IsZero())
 
-		// delete piece
-		node := planet.FindNode(missingPiece.StorageNode)
-		require.NotNil(t, node)
-		pieceID := segment.RootPieceID.Derive(missingPiece.StorageNode, int32(missingPiece.Number))
-		err = node.Storage2.Store.Delete(ctx, satellite.ID(), pieceID)
-		require.NoError(t, err)
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		ecRepairer := satellite.Repairer.EcRepairer
+		// Increase offline count by the difference to trigger repair
+		toKill += repairThreshold - repairOverride
 
-		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
-		require.NoError(t, err)
-		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
-		require.NoError(t, err)
+		for i, piece := range remotePieces {
+			originalNodes[piece.StorageNode] = true
+			if i >= toKill {
+				// this means the node will be kept alive for repair
+				continue
+			}
+			nodesToKill[piece.StorageNode] = true
+		}
 
-		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
-		require.NoError(t, err)
-		require.Equal(t, 0, len(piecesReport.Offline))
-		require.Equal(t, 1, len(piecesReport.Failed))
-		require.Equal(t, 0, len(piecesReport.Contained))
-		require.Equal(t, 0, len(piecesReport.Unknown))
-		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
-		require.Equal(t, missingPiece, piecesReport.Failed[0])
+		for _, node := range planet.StorageNodes {
+			if nodesToKill[node.ID()] {
+				err = planet.StopNodeAndUpdate(ctx, node)
+				require.NoError(t, err)
+			}
+		}
+
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
+
+		// repair should have been done, due to the override
+		segment, _ = getRemoteSegment(ctx, t, satellite, uplinkPeer.Projects[0].ID, "testbucket")
+
+		// pointer should have the success count of pieces
+		remotePieces = segment.Pieces
+		require.Equal(t, int(segment.Redundancy.OptimalShares), len(remotePieces))
 	})
 }
 
-func TestECRepairerGetOffline(t *testing.T) {
+// TestDataRepairUploadLimits does the following:
+//   - Uploads test data to nodes
+//   - Get one segment of that data to check in which nodes its pieces are stored
+//   - Kills as many nodes as needed which store such segment pieces
+//   - Triggers data repair
+//   - Verify that the number of pieces which repaired has uploaded don't overpass
+//     the established limit (success threshold + % of excess)
+func TestDataRepairUploadLimit(t *testing.T) {
+	const (
+		RepairMaxExcessRateOptimalThreshold = 0.05
+		repairThreshold                     = 5
+		successThreshold                    = 7
+		maxThreshold                        = 9
+	)
+
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 6,
+		StorageNodeCount: 13,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
-			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.MaxExcessRateOptimalThreshold = RepairMaxExcessRateOptimalThreshold
+					config.Repairer.InMemoryRepair = true
+				},
+				testplanet.ReconfigureRS(3, repairThreshold, successThreshold, maxThreshold),
+			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
-		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
-
 		// stop audit to prevent possible interactions i.e. repair timeout problems
 		satellite.Audit.Worker.Loop.Pause()
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
-		var testData = testrand.Bytes(8 * memory.KiB)
-		// first, upload some remote data
-		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		var (
+			maxRepairUploadThreshold = int(
+				math.Ceil(
+					float64(successThreshold) * (1 + RepairMaxExcessRateOptimalThreshold),
+				),
+			)
+			ul       = planet.Uplinks[0]
+			testData = testrand.Bytes(8 * memory.KiB)
+		)
+
+		err := ul.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
 
-		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
-		require.Equal(t, 6, len(segment.Pieces))
-		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
-		toKill := 2
+		segment, _ := getRemoteSegment(ctx, t, satellite, ul.Projects[0].ID, "testbucket")
 
-		// kill nodes and track lost pieces
-		var offlinePiece metabase.Piece
-		for i, piece := range segment.Pieces {
-			if i >= toKill {
-				// choose a node and pieceID to shutdown
-				if offlinePiece.StorageNode.IsZero() {
-					offlinePiece = piece
+		originalPieces := segment.Pieces
+		require.True(t, len(originalPieces) <= maxThreshold)
+
+		{ // Check that there is enough nodes in the network which don't contain
+			// pieces of the segment for being able to repair the lost pieces
+			availableNumNodes := len(planet.StorageNodes) - len(originalPieces)
+			neededNodesForRepair := maxRepairUploadThreshold - repairThreshold
+			require.Truef(t,
+				availableNumNodes >= neededNodesForRepair,
+				"Not enough remaining nodes in the network for repairing the pieces: have= %d, need= %d",
+				availableNumNodes, neededNodesForRepair,
+			)
+		}
+
+		originalStorageNodes := make(map[storj.NodeID]struct{})
+		for _, p := range originalPieces {
+			originalStorageNodes[p.StorageNode] = struct{}{}
+		}
+
+		killedNodes := make(map[storj.NodeID]struct{})
+		{ // Register nodes of the network which don't have pieces for the segment
+			// to be injured and ill nodes which have pieces of the segment in order
+			// to injure it
+			numNodesToKill := len(originalPieces) - repairThreshold
+			for _, node := range planet.StorageNodes {
+				if _, ok := originalStorageNodes[node.ID()]; !ok {
+					continue
 				}
-				continue
-			}
 
-			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
-			require.NoError(t, err)
+				if len(killedNodes) < numNodesToKill {
+					err := planet.StopNodeAndUpdate(ctx, node)
+					require.NoError(t, err)
+
+					killedNodes[node.ID()] = struct{}{}
+				}
+			}
 		}
-		require.False(t, offlinePiece.StorageNode.IsZero())
 
-		// shutdown node
-		offlineNode := planet.FindNode(offlinePiece.StorageNode)
-		require.NotNil(t, offlineNode)
-		require.NoError(t, planet.StopPeer(offlineNode))
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		ecRepairer := satellite.Repairer.EcRepairer
+		// Get the pointer after repair to check the nodes where the pieces are
+		// stored
+		segment, _ = getRemoteSegment(ctx, t, satellite, ul.Projects[0].ID, "testbucket")
 
-		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
-		require.NoError(t, err)
-		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
-		require.NoError(t, err)
+		// Check that repair has uploaded missed pieces to an expected number of
+		// nodes
+		afterRepairPieces := segment.Pieces
+		require.Falsef(t,
+			len(afterRepairPieces) > maxRepairUploadThreshold,
+			"Repaired pieces cannot be over max repair upload threshold. maxRepairUploadThreshold= %d, have= %d",
+			maxRepairUploadThreshold, len(afterRepairPieces),
+		)
+		require.Falsef(t,
+			len(afterRepairPieces) < successThreshold,
+			"Repaired pieces shouldn't be under success threshold. successThreshold= %d, have= %d",
+			successThreshold, len(afterRepairPieces),
+		)
 
-		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
-		require.NoError(t, err)
-		require.Equal(t, 1, len(piecesReport.Offline))
-		require.Equal(t, 0, len(piecesReport.Failed))
-		require.Equal(t, 0, len(piecesReport.Contained))
-		require.Equal(t, 0, len(piecesReport.Unknown))
-		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
-		require.Equal(t, offlinePiece, piecesReport.Offline[0])
+		// Check that after repair, the segment doesn't have more pieces on the
+		// killed nodes
+		for _, p := range afterRepairPieces {
+			require.NotContains(t, killedNodes, p.StorageNode, "there shouldn't be pieces in killed nodes")
+		}
 	})
 }
 
-func TestECRepairerGetUnknown(t *testing.T) {
+// TestRepairGracefullyExited does the following:
+// - Uploads test data to 7 nodes
+// - Set 3 nodes as gracefully exited
+// - Triggers data repair, which repairs the data from the remaining 4 nodes to additional 3 new nodes
+// - Shuts down the 4 nodes from which the data was repaired
+// - Now we have just the 3 new nodes to which the data was repaired
+// - Downloads the data from these 3 nodes (succeeds because 3 nodes are enough for download)
+// - Expect newly repaired pointer does not contain the gracefully exited nodes.
+func TestRepairGracefullyExited(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 6,
+		StorageNodeCount: 12,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
-			StorageNodeDB: func(index int, db storagenode.DB, log *zap.Logger) (storagenode.DB, error) {
-				return testblobs.NewBadDB(log.Named("baddb"), db), nil
-			},
-			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.InMemoryRepair = true
+				},
+				testplanet.ReconfigureRS(3, 5, 7, 7),
+			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		// first, upload some remote data
 		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
 
-		// stop audit to prevent possible interactions i.e. repair timeout problems
-		satellite.Audit.Worker.Loop.Pause()
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
-		var testData = testrand.Bytes(8 * memory.KiB)
-		// first, upload some remote data
+		testData := testrand.Bytes(8 * memory.KiB)
+
 		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
 
 		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
-		require.Equal(t, 6, len(segment.Pieces))
-		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
-		toKill := 2
 
-		// kill nodes and track lost pieces
-		var unknownPiece metabase.Piece
-		for i, piece := range segment.Pieces {
-			if i >= toKill {
-				// choose a node to return unknown error
-				if unknownPiece.StorageNode.IsZero() {
-					unknownPiece = piece
-				}
-				continue
-			}
+		numStorageNodes := len(planet.StorageNodes)
+		remotePieces := segment.Pieces
+		numPieces := len(remotePieces)
+		// sanity check
+		require.EqualValues(t, numPieces, 7)
+		toExit := 3
+		// we should have enough storage nodes to repair on
+		require.True(t, (numStorageNodes-toExit) >= numPieces)
 
-			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+		// gracefully exit nodes and track lost pieces
+		nodesToExit := make(map[storj.NodeID]bool)
+		nodesToKeepAlive := make(map[storj.NodeID]bool)
+
+		// exit nodes
+		for i := 0; i < toExit; i++ {
+			nodesToExit[remotePieces[i].StorageNode] = true
+			req := &overlay.ExitStatusRequest{
+				NodeID:              remotePieces[i].StorageNode,
+				ExitInitiatedAt:     time.Now(),
+				ExitLoopCompletedAt: time.Now(),
+				ExitFinishedAt:      time.Now(),
+			}
+			_, err := satellite.DB.OverlayCache().UpdateExitStatus(ctx, req)
 			require.NoError(t, err)
 		}
-		require.False(t, unknownPiece.StorageNode.IsZero())
-
-		// set unknown error for download from bad node
-		badNode := planet.FindNode(unknownPiece.StorageNode)
-		require.NotNil(t, badNode)
-		badNodeDB := badNode.DB.(*testblobs.BadDB)
-		badNodeDB.SetError(errs.New("unknown error"))
-
-		ecRepairer := satellite.Repairer.EcRepairer
+		for i := toExit; i < len(remotePieces); i++ {
+			nodesToKeepAlive[remotePieces[i].StorageNode] = true
+		}
 
-		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		err = satellite.Repair.Checker.RefreshReliabilityCache(ctx)
+		require.NoError(t, err)
+
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
+
+		// kill nodes kept alive to ensure repair worked
+		for _, node := range planet.StorageNodes {
+			if nodesToKeepAlive[node.ID()] {
+				require.NoError(t, planet.StopNodeAndUpdate(ctx, node))
+			}
+		}
+
+		// we should be able to download data without any of the original nodes
+		newData, err := uplinkPeer.Download(ctx, satellite, "testbucket", "test/path")
+		require.NoError(t, err)
+		require.Equal(t, newData, testData)
+
+		// updated pointer should not contain any of the gracefully exited nodes
+		segmentAfter, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+
+		remotePieces = segmentAfter.Pieces
+		for _, piece := range remotePieces {
+			require.False(t, nodesToExit[piece.StorageNode])
+		}
+	})
+}
+
+// getRemoteSegment returns a remote pointer its path from satellite.
+// nolint:golint
+func getRemoteSegment(
+	ctx context.Context, t *testing.T, satellite *testplanet.Satellite, projectID uuid.UUID, bucketName string,
+) (_ metabase.Segment, key metabase.SegmentKey) {
+	t.Helper()
+
+	objects, err := satellite.Metabase.DB.TestingAllObjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+
+	segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	require.False(t, segments[0].Inline())
+
+	return segments[0], metabase.SegmentLocation{
+		ProjectID:  projectID,
+		BucketName: bucketName,
+		ObjectKey:  objects[0].ObjectKey,
+		Position:   segments[0].Position,
+	}.Encode()
+}
+
+// corruptPieceData manipulates piece data on a storage node.
+func corruptPieceData(ctx context.Context, t *testing.T, planet *testplanet.Planet, corruptedNode *testplanet.StorageNode, corruptedPieceID storj.PieceID) {
+	t.Helper()
+
+	blobRef := storage.BlobRef{
+		Namespace: planet.Satellites[0].ID().Bytes(),
+		Key:       corruptedPieceID.Bytes(),
+	}
+
+	// get currently stored piece data from storagenode
+	reader, err := corruptedNode.Storage2.BlobsCache.Open(ctx, blobRef)
+	require.NoError(t, err)
+	pieceSize, err := reader.Size()
+	require.NoError(t, err)
+	require.True(t, pieceSize > 0)
+	pieceData := make([]byte, pieceSize)
+	n, err := io.ReadFull(reader, pieceData)
+	require.NoError(t, err)
+	require.EqualValues(t, n, pieceSize)
+
+	// delete piece data
+	err = corruptedNode.Storage2.BlobsCache.Delete(ctx, blobRef)
+	require.NoError(t, err)
+
+	// corrupt piece data (not PieceHeader) and write back to storagenode
+	// this means repair downloading should fail during piece hash verification
+	pieceData[pieceSize-1]++ // if we don't do this, this test should fail
+	writer, err := corruptedNode.Storage2.BlobsCache.Create(ctx, blobRef, pieceSize)
+	require.NoError(t, err)
+
+	n, err = writer.Write(pieceData)
+	require.NoError(t, err)
+	require.EqualValues(t, n, pieceSize)
+
+	err = writer.Commit(ctx)
+	require.NoError(t, err)
+}
+
+type mockConnector struct {
+	realConnector   rpc.Connector
+	addressesDialed []string
+	dialInstead     map[string]string
+}
+
+func (m *mockConnector) DialContext(ctx context.Context, tlsConfig *tls.Config, address string) (rpc.ConnectorConn, error) {
+	m.addressesDialed = append(m.addressesDialed, address)
+	replacement := m.dialInstead[address]
+	if replacement == "" {
+		// allow numeric ip addresses through, return errors for unexpected dns lookups
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) == nil {
+			return nil, &net.DNSError{
+				Err:        "unexpected lookup",
+				Name:       address,
+				Server:     "a.totally.real.dns.server.i.promise",
+				IsNotFound: true,
+			}
+		}
+		replacement = address
+	}
+	return m.realConnector.DialContext(ctx, tlsConfig, replacement)
+}
+
+func ecRepairerWithMockConnector(t testing.TB, sat *testplanet.Satellite, mock *mockConnector) *repairer.ECRepairer {
+	tlsOptions := sat.Dialer.TLSOptions
+	newDialer := rpc.NewDefaultDialer(tlsOptions)
+	mock.realConnector = newDialer.Connector
+	newDialer.Connector = mock
+
+	ec := repairer.NewECRepairer(
+		zaptest.NewLogger(t).Named("a-special-repairer"),
+		newDialer,
+		signing.SigneeFromPeerIdentity(sat.Identity.PeerIdentity()),
+		sat.Reputation.Service,
+		sat.Config.Repairer,
+	)
+	return ec
+}
+
+func TestECRepairerGet(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 6,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		var testData = testrand.Bytes(8 * memory.KiB)
+		// first, upload some remote data
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+
+		ecRepairer := satellite.Repairer.EcRepairer
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
 		require.NoError(t, err)
 		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
 		require.NoError(t, err)
@@ -2726,21 +3136,19 @@ func TestECRepairerGetUnknown(t *testing.T) {
 		require.Equal(t, 0, len(piecesReport.Offline))
 		require.Equal(t, 0, len(piecesReport.Failed))
 		require.Equal(t, 0, len(piecesReport.Contained))
-		require.Equal(t, 1, len(piecesReport.Unknown))
+		require.Equal(t, 0, len(piecesReport.Unknown))
 		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
-		require.Equal(t, unknownPiece, piecesReport.Unknown[0])
+		require.True(t, piecesReport.Reconstructable)
+		require.Equal(t, int(segment.Redundancy.RequiredShares), piecesReport.MinRequired)
 	})
 }
 
-func TestECRepairerGetFailure(t *testing.T) {
+func TestECRepairerGetCorrupted(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
 		StorageNodeCount: 6,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
-			StorageNodeDB: func(index int, db storagenode.DB, log *zap.Logger) (storagenode.DB, error) {
-				return testblobs.NewBadDB(log.Named("baddb"), db), nil
-			},
 			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
@@ -2760,44 +3168,30 @@ func TestECRepairerGetFailure(t *testing.T) {
 		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
 		require.Equal(t, 6, len(segment.Pieces))
 		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
-
-		// calculate how many storagenodes to kill
 		toKill := 2
 
-		var onlinePieces metabase.Pieces
+		// kill nodes and track lost pieces
+		var corruptedPiece metabase.Piece
 		for i, piece := range segment.Pieces {
 			if i >= toKill {
-				onlinePieces = append(onlinePieces, piece)
+				// this means the node will be kept alive for repair
+				// choose piece to corrupt
+				if corruptedPiece.StorageNode.IsZero() {
+					corruptedPiece = piece
+				}
 				continue
 			}
 
 			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
 			require.NoError(t, err)
 		}
-		require.Equal(t, 4, len(onlinePieces))
-
-		successfulPiece := onlinePieces[0]
-		offlinePiece := onlinePieces[1]
-		unknownPiece := onlinePieces[2]
-		corruptedPiece := onlinePieces[3]
-
-		// stop offline node
-		offlineNode := planet.FindNode(offlinePiece.StorageNode)
-		require.NotNil(t, offlineNode)
-		require.NoError(t, planet.StopPeer(offlineNode))
-
-		// set unknown error for download from bad node
-		badNode := planet.FindNode(unknownPiece.StorageNode)
-		require.NotNil(t, badNode)
-		badNodeDB := badNode.DB.(*testblobs.BadDB)
-		badNodeDB.SetError(errs.New("unknown error"))
+		require.False(t, corruptedPiece.StorageNode.IsZero())
 
-		// corrupt data for corrupted node
+		// corrupted node
 		corruptedNode := planet.FindNode(corruptedPiece.StorageNode)
 		require.NotNil(t, corruptedNode)
-		corruptedPieceID := segment.RootPieceID.Derive(corruptedPiece.StorageNode, int32(corruptedPiece.Number))
-		require.NotNil(t, corruptedPieceID)
-		corruptPieceData(ctx, t, planet, corruptedNode, corruptedPieceID)
+		pieceID := segment.RootPieceID.Derive(corruptedPiece.StorageNode, int32(corruptedPiece.Number))
+		corruptPieceData(ctx, t, planet, corruptedNode, pieceID)
 
 		ecRepairer := satellite.Repairer.EcRepairer
 
@@ -2807,189 +3201,1049 @@ func TestECRepairerGetFailure(t *testing.T) {
 		require.NoError(t, err)
 
 		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
-		require.Error(t, err)
-		require.Equal(t, 1, len(piecesReport.Offline))
+		require.NoError(t, err)
+		require.Equal(t, 0, len(piecesReport.Offline))
 		require.Equal(t, 1, len(piecesReport.Failed))
 		require.Equal(t, 0, len(piecesReport.Contained))
-		require.Equal(t, 1, len(piecesReport.Unknown))
-		require.Equal(t, 1, len(piecesReport.Successful))
-		require.Equal(t, offlinePiece, piecesReport.Offline[0])
+		require.Equal(t, 0, len(piecesReport.Unknown))
+		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
 		require.Equal(t, corruptedPiece, piecesReport.Failed[0])
-		require.Equal(t, unknownPiece, piecesReport.Unknown[0])
-		require.Equal(t, successfulPiece, piecesReport.Successful[0])
 	})
 }
 
-func TestECRepairerGetDoesNameLookupIfNecessary(t *testing.T) {
+func TestECRepairerGetMissingPiece(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
-		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		SatelliteCount:   1,
+		StorageNodeCount: 6,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
 
-		testSatellite := planet.Satellites[0]
-		audits := testSatellite.Audit
-
-		audits.Worker.Loop.Pause()
-		audits.Chore.Loop.Pause()
-
-		ul := planet.Uplinks[0]
-		testData := testrand.Bytes(8 * memory.KiB)
-
-		err := ul.Upload(ctx, testSatellite, "test.bucket", "some//path", testData)
-		require.NoError(t, err)
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
 
-		audits.Chore.Loop.TriggerWait()
-		queue := audits.Queues.Fetch()
-		queueSegment, err := queue.Next()
+		var testData = testrand.Bytes(8 * memory.KiB)
+		// first, upload some remote data
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
 
-		segment, err := testSatellite.Metabase.DB.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
-			StreamID: queueSegment.StreamID,
-			Position: queueSegment.Position,
-		})
-		require.NoError(t, err)
-		require.True(t, len(segment.Pieces) > 1)
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Equal(t, 6, len(segment.Pieces))
+		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
+		toKill := 2
+
+		// kill nodes and track lost pieces
+		var missingPiece metabase.Piece
+		for i, piece := range segment.Pieces {
+			if i >= toKill {
+				// this means the node will be kept alive for repair
+				// choose a piece for deletion
+				if missingPiece.StorageNode.IsZero() {
+					missingPiece = piece
+				}
+				continue
+			}
+
+			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+			require.NoError(t, err)
+		}
+		require.False(t, missingPiece.StorageNode.IsZero())
+
+		// delete piece
+		node := planet.FindNode(missingPiece.StorageNode)
+		require.NotNil(t, node)
+		pieceID := segment.RootPieceID.Derive(missingPiece.StorageNode, int32(missingPiece.Number))
+		err = node.Storage2.Store.Delete(ctx, satellite.ID(), pieceID)
+		require.NoError(t, err)
+
+		ecRepairer := satellite.Repairer.EcRepairer
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		require.NoError(t, err)
+
+		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
+		require.NoError(t, err)
+		require.Equal(t, 0, len(piecesReport.Offline))
+		require.Equal(t, 1, len(piecesReport.Failed))
+		require.Equal(t, 0, len(piecesReport.Contained))
+		require.Equal(t, 0, len(piecesReport.Unknown))
+		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
+		require.Equal(t, missingPiece, piecesReport.Failed[0])
+	})
+}
+
+func TestECRepairerGetOffline(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 6,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		var testData = testrand.Bytes(8 * memory.KiB)
+		// first, upload some remote data
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Equal(t, 6, len(segment.Pieces))
+		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
+		toKill := 2
+
+		// kill nodes and track lost pieces
+		var offlinePiece metabase.Piece
+		for i, piece := range segment.Pieces {
+			if i >= toKill {
+				// choose a node and pieceID to shutdown
+				if offlinePiece.StorageNode.IsZero() {
+					offlinePiece = piece
+				}
+				continue
+			}
+
+			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+			require.NoError(t, err)
+		}
+		require.False(t, offlinePiece.StorageNode.IsZero())
+
+		// shutdown node
+		offlineNode := planet.FindNode(offlinePiece.StorageNode)
+		require.NotNil(t, offlineNode)
+		require.NoError(t, planet.StopPeer(offlineNode))
+
+		ecRepairer := satellite.Repairer.EcRepairer
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		require.NoError(t, err)
+
+		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(piecesReport.Offline))
+		require.Equal(t, 0, len(piecesReport.Failed))
+		require.Equal(t, 0, len(piecesReport.Contained))
+		require.Equal(t, 0, len(piecesReport.Unknown))
+		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
+		require.Equal(t, offlinePiece, piecesReport.Offline[0])
+	})
+}
+
+func TestECRepairerGetUnknown(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 6,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			StorageNodeDB: func(index int, db storagenode.DB, log *zap.Logger) (storagenode.DB, error) {
+				return testblobs.NewBadDB(log.Named("baddb"), db), nil
+			},
+			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		var testData = testrand.Bytes(8 * memory.KiB)
+		// first, upload some remote data
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Equal(t, 6, len(segment.Pieces))
+		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
+		toKill := 2
+
+		// kill nodes and track lost pieces
+		var unknownPiece metabase.Piece
+		for i, piece := range segment.Pieces {
+			if i >= toKill {
+				// choose a node to return unknown error
+				if unknownPiece.StorageNode.IsZero() {
+					unknownPiece = piece
+				}
+				continue
+			}
+
+			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+			require.NoError(t, err)
+		}
+		require.False(t, unknownPiece.StorageNode.IsZero())
+
+		// set unknown error for download from bad node
+		badNode := planet.FindNode(unknownPiece.StorageNode)
+		require.NotNil(t, badNode)
+		badNodeDB := badNode.DB.(*testblobs.BadDB)
+		badNodeDB.SetError(errs.New("unknown error"))
+
+		ecRepairer := satellite.Repairer.EcRepairer
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		require.NoError(t, err)
+
+		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
+		require.NoError(t, err)
+		require.Equal(t, 0, len(piecesReport.Offline))
+		require.Equal(t, 0, len(piecesReport.Failed))
+		require.Equal(t, 0, len(piecesReport.Contained))
+		require.Equal(t, 1, len(piecesReport.Unknown))
+		require.Equal(t, int(segment.Redundancy.RequiredShares), len(piecesReport.Successful))
+		require.Equal(t, unknownPiece, piecesReport.Unknown[0])
+	})
+}
+
+func TestECRepairerGetFailure(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 6,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			StorageNodeDB: func(index int, db storagenode.DB, log *zap.Logger) (storagenode.DB, error) {
+				return testblobs.NewBadDB(log.Named("baddb"), db), nil
+			},
+			Satellite: testplanet.ReconfigureRS(3, 3, 6, 6),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		var testData = testrand.Bytes(8 * memory.KiB)
+		// first, upload some remote data
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Equal(t, 6, len(segment.Pieces))
+		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
+
+		// calculate how many storagenodes to kill
+		toKill := 2
+
+		var onlinePieces metabase.Pieces
+		for i, piece := range segment.Pieces {
+			if i >= toKill {
+				onlinePieces = append(onlinePieces, piece)
+				continue
+			}
+
+			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
+			require.NoError(t, err)
+		}
+		require.Equal(t, 4, len(onlinePieces))
+
+		successfulPiece := onlinePieces[0]
+		offlinePiece := onlinePieces[1]
+		unknownPiece := onlinePieces[2]
+		corruptedPiece := onlinePieces[3]
+
+		// stop offline node
+		offlineNode := planet.FindNode(offlinePiece.StorageNode)
+		require.NotNil(t, offlineNode)
+		require.NoError(t, planet.StopPeer(offlineNode))
+
+		// set unknown error for download from bad node
+		badNode := planet.FindNode(unknownPiece.StorageNode)
+		require.NotNil(t, badNode)
+		badNodeDB := badNode.DB.(*testblobs.BadDB)
+		badNodeDB.SetError(errs.New("unknown error"))
+
+		// corrupt data for corrupted node
+		corruptedNode := planet.FindNode(corruptedPiece.StorageNode)
+		require.NotNil(t, corruptedNode)
+		corruptedPieceID := segment.RootPieceID.Derive(corruptedPiece.StorageNode, int32(corruptedPiece.Number))
+		require.NotNil(t, corruptedPieceID)
+		corruptPieceData(ctx, t, planet, corruptedNode, corruptedPieceID)
+
+		ecRepairer := satellite.Repairer.EcRepairer
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+		getOrderLimits, getPrivateKey, cachedIPsAndPorts, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		require.NoError(t, err)
+
+		_, piecesReport, err := ecRepairer.Get(ctx, getOrderLimits, cachedIPsAndPorts, getPrivateKey, redundancy, int64(segment.EncryptedSize))
+		require.Error(t, err)
+		require.Equal(t, 1, len(piecesReport.Offline))
+		require.Equal(t, 1, len(piecesReport.Failed))
+		require.Equal(t, 0, len(piecesReport.Contained))
+		require.Equal(t, 1, len(piecesReport.Unknown))
+		require.Equal(t, 1, len(piecesReport.Successful))
+		require.Equal(t, offlinePiece, piecesReport.Offline[0])
+		require.Equal(t, corruptedPiece, piecesReport.Failed[0])
+		require.Equal(t, unknownPiece, piecesReport.Unknown[0])
+		require.Equal(t, successfulPiece, piecesReport.Successful[0])
+		// reconstruction needed 3 pieces, but only 1 was downloaded
+		require.False(t, piecesReport.Reconstructable)
+		require.Equal(t, 3, piecesReport.MinRequired)
+	})
+}
+
+func TestECRepairerGetDoesNameLookupIfNecessary(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+
+		testSatellite := planet.Satellites[0]
+		audits := testSatellite.Audit
+
+		audits.Worker.Loop.Pause()
+		audits.Chore.Loop.Pause()
+
+		ul := planet.Uplinks[0]
+		testData := testrand.Bytes(8 * memory.KiB)
+
+		err := ul.Upload(ctx, testSatellite, "test.bucket", "some//path", testData)
+		require.NoError(t, err)
+
+		audits.Chore.Loop.TriggerWait()
+		queue := audits.Queues.Fetch()
+		queueSegment, err := queue.Next()
+		require.NoError(t, err)
+
+		segment, err := testSatellite.Metabase.DB.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+			StreamID: queueSegment.StreamID,
+			Position: queueSegment.Position,
+		})
+		require.NoError(t, err)
+		require.True(t, len(segment.Pieces) > 1)
+
+		limits, privateKey, cachedNodesInfo, err := testSatellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		require.NoError(t, err)
+
+		for i, l := range limits {
+			if l == nil {
+				continue
+			}
+			info := cachedNodesInfo[l.Limit.StorageNodeId]
+			info.LastIPPort = fmt.Sprintf("garbageXXX#:%d", i)
+			cachedNodesInfo[l.Limit.StorageNodeId] = info
+		}
+
+		mock := &mockConnector{}
+		ec := ecRepairerWithMockConnector(t, testSatellite, mock)
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+
+		readCloser, pieces, err := ec.Get(ctx, limits, cachedNodesInfo, privateKey, redundancy, int64(segment.EncryptedSize))
+		require.NoError(t, err)
+		require.Len(t, pieces.Failed, 0)
+		require.NotNil(t, readCloser)
+
+		// repair will only download minimum required
+		minReq := redundancy.RequiredCount()
+		var numDialed int
+		for _, info := range cachedNodesInfo {
+			for _, dialed := range mock.addressesDialed {
+				if dialed == info.LastIPPort {
+					numDialed++
+					if numDialed == minReq {
+						break
+					}
+				}
+			}
+			if numDialed == minReq {
+				break
+			}
+		}
+		require.True(t, numDialed == minReq)
+	})
+}
+
+func TestECRepairerGetPrefersCachedIPPort(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+
+		testSatellite := planet.Satellites[0]
+		audits := testSatellite.Audit
+
+		audits.Worker.Loop.Pause()
+		audits.Chore.Loop.Pause()
+
+		ul := planet.Uplinks[0]
+		testData := testrand.Bytes(8 * memory.KiB)
+
+		err := ul.Upload(ctx, testSatellite, "test.bucket", "some//path", testData)
+		require.NoError(t, err)
+
+		audits.Chore.Loop.TriggerWait()
+		queue := audits.Queues.Fetch()
+		queueSegment, err := queue.Next()
+		require.NoError(t, err)
+
+		segment, err := testSatellite.Metabase.DB.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+			StreamID: queueSegment.StreamID,
+			Position: queueSegment.Position,
+		})
+		require.NoError(t, err)
+		require.True(t, len(segment.Pieces) > 1)
+
+		limits, privateKey, cachedNodesInfo, err := testSatellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		require.NoError(t, err)
+
+		// make it so that when the cached IP is dialed, we dial the "right" address,
+		// but when the "right" address is dialed (meaning it came from the OrderLimit,
+		// we dial something else!
+		mock := &mockConnector{
+			dialInstead: make(map[string]string),
+		}
+		var realAddresses []string
+		for i, l := range limits {
+			if l == nil {
+				continue
+			}
+
+			info := cachedNodesInfo[l.Limit.StorageNodeId]
+			info.LastIPPort = fmt.Sprintf("garbageXXX#:%d", i)
+			cachedNodesInfo[l.Limit.StorageNodeId] = info
+
+			address := l.StorageNodeAddress.Address
+			mock.dialInstead[info.LastIPPort] = address
+			mock.dialInstead[address] = "utter.failure?!*"
+
+			realAddresses = append(realAddresses, address)
+		}
+
+		ec := ecRepairerWithMockConnector(t, testSatellite, mock)
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+
+		readCloser, pieces, err := ec.Get(ctx, limits, cachedNodesInfo, privateKey, redundancy, int64(segment.EncryptedSize))
+		require.NoError(t, err)
+		require.Len(t, pieces.Failed, 0)
+		require.NotNil(t, readCloser)
+		// repair will only download minimum required.
+		minReq := redundancy.RequiredCount()
+		var numDialed int
+		for _, info := range cachedNodesInfo {
+			for _, dialed := range mock.addressesDialed {
+				if dialed == info.LastIPPort {
+					numDialed++
+					if numDialed == minReq {
+						break
+					}
+				}
+			}
+			if numDialed == minReq {
+				break
+			}
+		}
+		require.True(t, numDialed == minReq)
+		// and that the right address was never dialed directly
+		require.NotContains(t, mock.addressesDialed, realAddresses)
+	})
+}
+
+func TestECRepairerGetPrefersLowLatency(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.DownloadPreferLowLatency = true
+				},
+				testplanet.ReconfigureRS(2, 3, 4, 4),
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		testSatellite := planet.Satellites[0]
+		testSatellite.Audit.Worker.Loop.Pause()
+		testSatellite.Repair.Checker.Loop.Pause()
+		testSatellite.Repair.Repairer.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, testSatellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, testSatellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+
+		limits, privateKey, cachedNodesInfo, err := testSatellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		require.NoError(t, err)
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+		minReq := redundancy.RequiredCount()
+
+		// the later pieces come from the faster nodes and one node has an unknown latency.
+		mock := &mockConnector{
+			dialInstead: make(map[string]string),
+		}
+		var byLatency []string
+		for i, l := range limits {
+			if l == nil {
+				continue
+			}
+
+			info := cachedNodesInfo[l.Limit.StorageNodeId]
+			info.LastIPPort = fmt.Sprintf("latency#:%d", i)
+			info.Latency90 = int64(100 * (len(limits) - i))
+			if i == 0 {
+				info.Latency90 = 0
+			}
+			cachedNodesInfo[l.Limit.StorageNodeId] = info
+
+			mock.dialInstead[info.LastIPPort] = l.StorageNodeAddress.Address
+			// faster nodes are prepended, the node with unknown latency stays last.
+			byLatency = append([]string{info.LastIPPort}, byLatency...)
+		}
+
+		ec := ecRepairerWithMockConnector(t, testSatellite, mock)
+
+		readCloser, pieces, err := ec.Get(ctx, limits, cachedNodesInfo, privateKey, redundancy, int64(segment.EncryptedSize))
+		require.NoError(t, err)
+		require.NotNil(t, readCloser)
+		defer ctx.Check(readCloser.Close)
+		require.Len(t, pieces.Failed, 0)
+
+		// only the minimum required pieces are downloaded, from the fastest nodes.
+		require.Len(t, mock.addressesDialed, minReq)
+		require.ElementsMatch(t, byLatency[:minReq], mock.addressesDialed)
+	})
+}
+
+func TestECRepairerGetDeprioritizesLowReputation(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.DownloadMinAuditReputation = 0.5
+				},
+				testplanet.ReconfigureRS(2, 3, 4, 4),
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		testSatellite := planet.Satellites[0]
+		testSatellite.Audit.Worker.Loop.Pause()
+		testSatellite.Repair.Checker.Loop.Pause()
+		testSatellite.Repair.Repairer.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, testSatellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, testSatellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+
+		limits, privateKey, cachedNodesInfo, err := testSatellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		require.NoError(t, err)
+
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+		minReq := redundancy.RequiredCount()
+
+		mock := &mockConnector{
+			dialInstead: make(map[string]string),
+		}
+		var addresses []string
+		nodeIDs := make(map[string]storj.NodeID)
+		for i, l := range limits {
+			if l == nil {
+				continue
+			}
+
+			info := cachedNodesInfo[l.Limit.StorageNodeId]
+			info.LastIPPort = fmt.Sprintf("reputation#:%d", i)
+			cachedNodesInfo[l.Limit.StorageNodeId] = info
+
+			mock.dialInstead[info.LastIPPort] = l.StorageNodeAddress.Address
+			addresses = append(addresses, info.LastIPPort)
+			nodeIDs[info.LastIPPort] = l.Limit.StorageNodeId
+		}
+		require.Len(t, addresses, 4)
+
+		// nodes without audits have a perfect reputation.
+		scores, err := testSatellite.Reputation.Service.AuditScores(ctx, []storj.NodeID{nodeIDs[addresses[0]]})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, scores[nodeIDs[addresses[0]]])
+
+		lowerReputation := func(addresses ...string) {
+			for _, address := range addresses {
+				nodeID := nodeIDs[address]
+				for i := 0; i < 3; i++ {
+					err := testSatellite.Reputation.Service.ApplyAudit(ctx, nodeID, overlay.ReputationStatus{}, reputation.AuditFailure)
+					require.NoError(t, err)
+				}
+
+				scores, err := testSatellite.Reputation.Service.AuditScores(ctx, []storj.NodeID{nodeID})
+				require.NoError(t, err)
+				require.Less(t, scores[nodeID], 0.5)
+			}
+		}
+
+		get := func() {
+			mock.addressesDialed = nil
+
+			ec := ecRepairerWithMockConnector(t, testSatellite, mock)
+			readCloser, pieces, err := ec.Get(ctx, limits, cachedNodesInfo, privateKey, redundancy, int64(segment.EncryptedSize))
+			require.NoError(t, err)
+			require.NotNil(t, readCloser)
+			require.NoError(t, readCloser.Close())
+			require.Len(t, pieces.Failed, 0)
+			require.Len(t, mock.addressesDialed, minReq)
+		}
+
+		// the nodes which would be used first have a low reputation, so the others are used.
+		lowerReputation(addresses[0], addresses[1])
+		get()
+		require.ElementsMatch(t, addresses[2:], mock.addressesDialed)
+
+		// with only one node with a good reputation, a node with a low reputation is used as well.
+		lowerReputation(addresses[2])
+		get()
+		require.ElementsMatch(t, []string{addresses[3], addresses[0]}, mock.addressesDialed)
+	})
+}
+
+func TestECRepairerGetDialBackoff(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.DialBackoff = time.Hour
+				},
+				testplanet.ReconfigureRS(2, 3, 4, 4),
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		testSatellite := planet.Satellites[0]
+		testSatellite.Audit.Worker.Loop.Pause()
+		testSatellite.Repair.Checker.Loop.Pause()
+		testSatellite.Repair.Repairer.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, testSatellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, testSatellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
 
 		limits, privateKey, cachedNodesInfo, err := testSatellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
 		require.NoError(t, err)
 
-		for i, l := range limits {
-			if l == nil {
-				continue
-			}
-			info := cachedNodesInfo[l.Limit.StorageNodeId]
-			info.LastIPPort = fmt.Sprintf("garbageXXX#:%d", i)
-			cachedNodesInfo[l.Limit.StorageNodeId] = info
-		}
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(t, err)
+
+		mock := &mockConnector{
+			dialInstead: make(map[string]string),
+		}
+
+		// the node of the first piece, which is dialed first, is unreachable.
+		var failingNode storj.NodeID
+		var failingAddress string
+		for i, l := range limits {
+			if l == nil {
+				continue
+			}
+
+			info := cachedNodesInfo[l.Limit.StorageNodeId]
+			if failingNode.IsZero() {
+				failingNode = l.Limit.StorageNodeId
+				failingAddress = l.StorageNodeAddress.Address
+				info.LastIPPort = ""
+				mock.dialInstead[failingAddress] = "127.0.0.1:1"
+			} else {
+				info.LastIPPort = fmt.Sprintf("backoff#:%d", i)
+				mock.dialInstead[info.LastIPPort] = l.StorageNodeAddress.Address
+			}
+			cachedNodesInfo[l.Limit.StorageNodeId] = info
+		}
+		require.False(t, failingNode.IsZero())
+
+		ec := ecRepairerWithMockConnector(t, testSatellite, mock)
+
+		now := time.Now()
+		ec.DialBackoff().SetNow(func() time.Time { return now })
+
+		get := func() audit.Pieces {
+			mock.addressesDialed = nil
+
+			readCloser, pieces, err := ec.Get(ctx, limits, cachedNodesInfo, privateKey, redundancy, int64(segment.EncryptedSize))
+			require.NoError(t, err)
+			require.NotNil(t, readCloser)
+			require.NoError(t, readCloser.Close())
+			return pieces
+		}
+
+		// failedPieces returns the pieces which couldn't be downloaded because of dial errors.
+		failedPieces := func(pieces audit.Pieces) metabase.Pieces {
+			var failed metabase.Pieces
+			failed = append(failed, pieces.Offline...)
+			failed = append(failed, pieces.Unknown...)
+			return failed
+		}
+
+		pieces := get()
+		require.Contains(t, mock.addressesDialed, failingAddress)
+		failed := failedPieces(pieces)
+		require.Len(t, failed, 1)
+		require.Equal(t, failingNode, failed[0].StorageNode)
+		require.Equal(t, storj.NodeIDList{failingNode}, ec.DialBackoff().Nodes())
+
+		// the node is reachable again, but it isn't dialed in the next cycle.
+		delete(mock.dialInstead, failingAddress)
+
+		now = now.Add(30 * time.Minute)
+		pieces = get()
+		require.NotContains(t, mock.addressesDialed, failingAddress)
+		require.Empty(t, failedPieces(pieces))
+		require.Equal(t, storj.NodeIDList{failingNode}, ec.DialBackoff().Nodes())
+
+		// once the interval elapses, the node is dialed again.
+		now = now.Add(time.Hour)
+		require.Empty(t, ec.DialBackoff().Nodes())
+
+		pieces = get()
+		require.Contains(t, mock.addressesDialed, failingAddress)
+		require.Empty(t, failedPieces(pieces))
+
+		// without enough nodes out of the backoff, none of them is dialed.
+		for _, l := range limits {
+			if l != nil {
+				ec.DialBackoff().Failed(l.Limit.StorageNodeId)
+			}
+		}
+		mock.addressesDialed = nil
+
+		_, _, err = ec.Get(ctx, limits, cachedNodesInfo, privateKey, redundancy, int64(segment.EncryptedSize))
+		require.Error(t, err)
+		require.Empty(t, mock.addressesDialed)
+	})
+}
+
+func TestSegmentInExcludedCountriesRepair(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 7,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.InMemoryRepair = true
+				},
+				testplanet.ReconfigureRS(2, 3, 4, 5),
+				testplanet.RepairExcludedCountryCodes([]string{"FR", "BE"}),
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
+
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		var testData = testrand.Bytes(8 * memory.KiB)
+		// first, upload some remote data
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
 
-		mock := &mockConnector{}
-		ec := ecRepairerWithMockConnector(t, testSatellite, mock)
+		remotePieces := segment.Pieces
+		require.GreaterOrEqual(t, len(segment.Pieces), int(segment.Redundancy.RequiredShares))
 
-		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		err = planet.Satellites[0].Overlay.Service.TestNodeCountryCode(ctx, remotePieces[1].StorageNode, "FR")
+		require.NoError(t, err)
+		nodeInExcluded := remotePieces[1].StorageNode
+		// make one piece after optimal bad
+		err = planet.StopNodeAndUpdate(ctx, planet.FindNode(remotePieces[2].StorageNode))
 		require.NoError(t, err)
 
-		readCloser, pieces, err := ec.Get(ctx, limits, cachedNodesInfo, privateKey, redundancy, int64(segment.EncryptedSize))
+		// trigger checker to add segment to repair queue
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+
+		count, err := satellite.DB.RepairQueue().Count(ctx)
 		require.NoError(t, err)
-		require.Len(t, pieces.Failed, 0)
-		require.NotNil(t, readCloser)
+		require.Equal(t, 1, count)
 
-		// repair will only download minimum required
-		minReq := redundancy.RequiredCount()
-		var numDialed int
-		for _, info := range cachedNodesInfo {
-			for _, dialed := range mock.addressesDialed {
-				if dialed == info.LastIPPort {
-					numDialed++
-					if numDialed == minReq {
-						break
-					}
-				}
-			}
-			if numDialed == minReq {
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
+
+		// Verify that the segment was removed
+		count, err = satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		// Verify the segment has been repaired
+		segmentAfterRepair, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.NotEqual(t, segment.Pieces, segmentAfterRepair.Pieces)
+		require.GreaterOrEqual(t, len(segmentAfterRepair.Pieces), int(segmentAfterRepair.Redundancy.OptimalShares))
+
+		// check excluded area node still exists
+		var found bool
+		for _, p := range segmentAfterRepair.Pieces {
+			if p.StorageNode == nodeInExcluded {
+				found = true
 				break
 			}
 		}
-		require.True(t, numDialed == minReq)
+		require.True(t, found, fmt.Sprintf("node %s not in segment, but should be\n", segmentAfterRepair.Pieces[1].StorageNode.String()))
+		nodesInPointer := make(map[storj.NodeID]bool)
+		for _, n := range segmentAfterRepair.Pieces {
+			// check for duplicates
+			_, ok := nodesInPointer[n.StorageNode]
+			require.False(t, ok)
+			nodesInPointer[n.StorageNode] = true
+		}
 	})
 }
 
-func TestECRepairerGetPrefersCachedIPPort(t *testing.T) {
+// - 7 storage nodes
+// - pieces uploaded to 4 or 5 nodes
+// - mark one node holding a piece in excluded area
+// - put one other node holding a piece offline
+// - run the checker and check the segment is in the repair queue
+// - run the repairer
+// - check the segment has been repaired and that:
+//		- piece in excluded is still there
+//		- piece held by offline node is not
+//		- there are no duplicate
+func TestSegmentInExcludedCountriesRepairIrreparable(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
-		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		SatelliteCount:   1,
+		StorageNodeCount: 7,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.InMemoryRepair = true
+				},
+				testplanet.ReconfigureRS(2, 3, 4, 5),
+				testplanet.RepairExcludedCountryCodes([]string{"FR", "BE"}),
+			),
+		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
 
-		testSatellite := planet.Satellites[0]
-		audits := testSatellite.Audit
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
 
-		audits.Worker.Loop.Pause()
-		audits.Chore.Loop.Pause()
+		var testData = testrand.Bytes(8 * memory.KiB)
+		// first, upload some remote data
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
 
-		ul := planet.Uplinks[0]
-		testData := testrand.Bytes(8 * memory.KiB)
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
 
-		err := ul.Upload(ctx, testSatellite, "test.bucket", "some//path", testData)
-		require.NoError(t, err)
+		remotePieces := segment.Pieces
+		require.GreaterOrEqual(t, len(remotePieces), int(segment.Redundancy.OptimalShares))
 
-		audits.Chore.Loop.TriggerWait()
-		queue := audits.Queues.Fetch()
-		queueSegment, err := queue.Next()
+		err = planet.Satellites[0].Overlay.Service.TestNodeCountryCode(ctx, remotePieces[1].StorageNode, "FR")
 		require.NoError(t, err)
-
-		segment, err := testSatellite.Metabase.DB.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
-			StreamID: queueSegment.StreamID,
-			Position: queueSegment.Position,
-		})
+		nodeInExcluded := remotePieces[0].StorageNode
+		offlineNode := remotePieces[2].StorageNode
+		// make  one unhealthy
+		err = planet.StopNodeAndUpdate(ctx, planet.FindNode(offlineNode))
 		require.NoError(t, err)
-		require.True(t, len(segment.Pieces) > 1)
 
-		limits, privateKey, cachedNodesInfo, err := testSatellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, segment.Pieces)
+		// trigger checker to add segment to repair queue
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+
+		count, err := satellite.DB.RepairQueue().Count(ctx)
 		require.NoError(t, err)
+		require.Equal(t, 1, count)
 
-		// make it so that when the cached IP is dialed, we dial the "right" address,
-		// but when the "right" address is dialed (meaning it came from the OrderLimit,
-		// we dial something else!
-		mock := &mockConnector{
-			dialInstead: make(map[string]string),
-		}
-		var realAddresses []string
-		for i, l := range limits {
-			if l == nil {
-				continue
-			}
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-			info := cachedNodesInfo[l.Limit.StorageNodeId]
-			info.LastIPPort = fmt.Sprintf("garbageXXX#:%d", i)
-			cachedNodesInfo[l.Limit.StorageNodeId] = info
+		// Verify that the segment was removed
+		count, err = satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
 
-			address := l.StorageNodeAddress.Address
-			mock.dialInstead[info.LastIPPort] = address
-			mock.dialInstead[address] = "utter.failure?!*"
+		// Verify the segment has been repaired
+		segmentAfterRepair, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.NotEqual(t, segment.Pieces, segmentAfterRepair.Pieces)
+		require.GreaterOrEqual(t, len(segmentAfterRepair.Pieces), int(segment.Redundancy.OptimalShares))
 
-			realAddresses = append(realAddresses, address)
+		// check node in excluded area still exists
+		var nodeInExcludedAreaFound bool
+		var offlineNodeFound bool
+		for _, p := range segmentAfterRepair.Pieces {
+			if p.StorageNode == nodeInExcluded {
+				nodeInExcludedAreaFound = true
+			}
+			if p.StorageNode == offlineNode {
+				offlineNodeFound = true
+			}
 		}
+		require.True(t, nodeInExcludedAreaFound, fmt.Sprintf("node %s not in segment, but should be\n", nodeInExcluded.String()))
+		require.False(t, offlineNodeFound, fmt.Sprintf("node %s in segment, but should not be\n", offlineNode.String()))
 
-		ec := ecRepairerWithMockConnector(t, testSatellite, mock)
+		nodesInPointer := make(map[storj.NodeID]bool)
+		for _, n := range segmentAfterRepair.Pieces {
+			// check for duplicates
+			_, ok := nodesInPointer[n.StorageNode]
+			require.False(t, ok)
+			nodesInPointer[n.StorageNode] = true
+		}
+	})
+}
 
-		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
-		require.NoError(t, err)
+// TestRepairTrimExcessPieces
+// - Upload test data to 9 nodes
+// - Add pieces with numbers outside of the redundancy scheme so that the
+//   segment has more pieces than the total shares
+// - Put the segment into the repair queue and run the repairer
+// - Verify the segment was trimmed down to the optimal shares.
+func TestRepairTrimExcessPieces(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 14,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Repairer.InMemoryRepair = true
+					config.Repairer.TrimExcessPieces = true
+				},
+				testplanet.ReconfigureRS(3, 5, 7, 9),
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+		// stop audit to prevent possible interactions i.e. repair timeout problems
+		satellite.Audit.Worker.Loop.Pause()
 
-		readCloser, pieces, err := ec.Get(ctx, limits, cachedNodesInfo, privateKey, redundancy, int64(segment.EncryptedSize))
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		testData := testrand.Bytes(8 * memory.KiB)
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
 		require.NoError(t, err)
-		require.Len(t, pieces.Failed, 0)
-		require.NotNil(t, readCloser)
-		// repair will only download minimum required.
-		minReq := redundancy.RequiredCount()
-		var numDialed int
-		for _, info := range cachedNodesInfo {
-			for _, dialed := range mock.addressesDialed {
-				if dialed == info.LastIPPort {
-					numDialed++
-					if numDialed == minReq {
-						break
-					}
-				}
-			}
-			if numDialed == minReq {
+
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+
+		nodesInSegment := make(map[storj.NodeID]bool)
+		for _, piece := range segment.Pieces {
+			nodesInSegment[piece.StorageNode] = true
+		}
+
+		// add pieces which can't belong to the redundancy scheme
+		overPieced := append(metabase.Pieces{}, segment.Pieces...)
+		number := uint16(segment.Redundancy.TotalShares)
+		for _, node := range planet.StorageNodes {
+			if len(overPieced) > int(segment.Redundancy.TotalShares)+1 {
 				break
 			}
+			if nodesInSegment[node.ID()] {
+				continue
+			}
+			overPieced = append(overPieced, metabase.Piece{Number: number, StorageNode: node.ID()})
+			number++
+		}
+		require.Greater(t, len(overPieced), int(segment.Redundancy.TotalShares))
+
+		err = satellite.Metabase.DB.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+			StreamID:      segment.StreamID,
+			Position:      segment.Position,
+			OldPieces:     segment.Pieces,
+			NewRedundancy: segment.Redundancy,
+			NewPieces:     overPieced,
+		})
+		require.NoError(t, err)
+
+		_, err = satellite.DB.RepairQueue().Insert(ctx, &queue.InjuredSegment{
+			StreamID: segment.StreamID,
+			Position: segment.Position,
+		})
+		require.NoError(t, err)
+
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
+
+		count, err := satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		segmentAfterRepair, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Len(t, segmentAfterRepair.Pieces, int(segment.Redundancy.OptimalShares))
+		for _, piece := range segmentAfterRepair.Pieces {
+			require.Less(t, piece.Number, uint16(segment.Redundancy.TotalShares))
+			require.True(t, nodesInSegment[piece.StorageNode])
 		}
-		require.True(t, numDialed == minReq)
-		// and that the right address was never dialed directly
-		require.NotContains(t, mock.addressesDialed, realAddresses)
+
+		// the data should still be downloadable
+		data, err := uplinkPeer.Download(ctx, satellite, "testbucket", "test/path")
+		require.NoError(t, err)
+		require.Equal(t, testData, data)
 	})
 }
 
-func TestSegmentInExcludedCountriesRepair(t *testing.T) {
+// TestRepairPlacementSuccessOverride does the following:
+//   - Uploads test data into two buckets
+//   - Moves the segment of the second bucket into a placement with a higher success threshold
+//   - Kills nodes so both segments fall to the repair threshold
+//   - Triggers data repair and checks that the segment of the stricter placement
+//     was repaired to more pieces than the other one
+func TestRepairPlacementSuccessOverride(t *testing.T) {
+	const (
+		repairThreshold   = 4
+		placementSuccess  = 8
+		strictPlacement   = storj.PlacementConstraint(1)
+		defaultBucketName = "default-bucket"
+		strictBucketName  = "strict-bucket"
+	)
+
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 7,
+		StorageNodeCount: 24,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
 			Satellite: testplanet.Combine(
 				func(log *zap.Logger, index int, config *satellite.Config) {
 					config.Repairer.InMemoryRepair = true
+					config.Repairer.MaxExcessRateOptimalThreshold = 0
+					config.Checker.PlacementSuccessOverrides = checker.PlacementSuccessOverrides{
+						List: []checker.PlacementSuccessOverride{
+							{Placement: strictPlacement, Success: placementSuccess},
+						},
+					}
 				},
-				testplanet.ReconfigureRS(2, 3, 4, 5),
-				testplanet.RepairExcludedCountryCodes([]string{"FR", "BE"}),
+				testplanet.ReconfigureRS(3, repairThreshold, 5, 9),
 			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
@@ -3001,161 +4255,452 @@ func TestSegmentInExcludedCountriesRepair(t *testing.T) {
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
-		var testData = testrand.Bytes(8 * memory.KiB)
-		// first, upload some remote data
-		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
-		require.NoError(t, err)
+		for _, bucket := range []string{defaultBucketName, strictBucketName} {
+			err := uplinkPeer.Upload(ctx, satellite, bucket, "test/path", testrand.Bytes(8*memory.KiB))
+			require.NoError(t, err)
+		}
 
-		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		objects, err := satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
 
-		remotePieces := segment.Pieces
-		require.GreaterOrEqual(t, len(segment.Pieces), int(segment.Redundancy.RequiredShares))
+		streamIDs := make(map[string]uuid.UUID)
+		for _, object := range objects {
+			streamIDs[object.BucketName] = object.StreamID
+		}
 
-		err = planet.Satellites[0].Overlay.Service.TestNodeCountryCode(ctx, remotePieces[1].StorageNode, "FR")
-		require.NoError(t, err)
-		nodeInExcluded := remotePieces[1].StorageNode
-		// make one piece after optimal bad
-		err = planet.StopNodeAndUpdate(ctx, planet.FindNode(remotePieces[2].StorageNode))
+		_, err = satellite.Metabase.DB.UnderlyingTagSQL().ExecContext(ctx,
+			`UPDATE segments SET placement = $1 WHERE stream_id = $2`,
+			strictPlacement, streamIDs[strictBucketName])
 		require.NoError(t, err)
 
-		// trigger checker to add segment to repair queue
+		getSegment := func(bucket string) metabase.Segment {
+			segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+			require.NoError(t, err)
+			for _, segment := range segments {
+				if segment.StreamID == streamIDs[bucket] {
+					return segment
+				}
+			}
+			require.FailNow(t, "segment not found", bucket)
+			return metabase.Segment{}
+		}
+
+		defaultSegment := getSegment(defaultBucketName)
+		strictSegment := getSegment(strictBucketName)
+		require.Equal(t, strictPlacement, strictSegment.Placement)
+
+		// select nodes to keep alive, so that exactly the repair threshold
+		// of pieces stays healthy for both of the segments
+		defaultNodes := make(map[storj.NodeID]bool)
+		for _, piece := range defaultSegment.Pieces {
+			defaultNodes[piece.StorageNode] = true
+		}
+		strictNodes := make(map[storj.NodeID]bool)
+		for _, piece := range strictSegment.Pieces {
+			strictNodes[piece.StorageNode] = true
+		}
+
+		var shared, onlyDefault, onlyStrict []storj.NodeID
+		for nodeID := range defaultNodes {
+			if strictNodes[nodeID] {
+				shared = append(shared, nodeID)
+			} else {
+				onlyDefault = append(onlyDefault, nodeID)
+			}
+		}
+		for nodeID := range strictNodes {
+			if !defaultNodes[nodeID] {
+				onlyStrict = append(onlyStrict, nodeID)
+			}
+		}
+
+		if len(shared) > repairThreshold {
+			shared = shared[:repairThreshold]
+		}
+		keepAlive := make(map[storj.NodeID]bool)
+		for _, nodeID := range shared {
+			keepAlive[nodeID] = true
+		}
+		for _, nodeID := range onlyDefault[:repairThreshold-len(shared)] {
+			keepAlive[nodeID] = true
+		}
+		for _, nodeID := range onlyStrict[:repairThreshold-len(shared)] {
+			keepAlive[nodeID] = true
+		}
+
+		for _, node := range planet.StorageNodes {
+			if (defaultNodes[node.ID()] || strictNodes[node.ID()]) && !keepAlive[node.ID()] {
+				require.NoError(t, planet.StopNodeAndUpdate(ctx, node))
+			}
+		}
+
 		satellite.Repair.Checker.Loop.Restart()
 		satellite.Repair.Checker.Loop.TriggerWait()
 		satellite.Repair.Checker.Loop.Pause()
-
-		count, err := satellite.DB.RepairQueue().Count(ctx)
-		require.NoError(t, err)
-		require.Equal(t, 1, count)
-
 		satellite.Repair.Repairer.Loop.Restart()
 		satellite.Repair.Repairer.Loop.TriggerWait()
 		satellite.Repair.Repairer.Loop.Pause()
 		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		// Verify that the segment was removed
-		count, err = satellite.DB.RepairQueue().Count(ctx)
-		require.NoError(t, err)
-		require.Zero(t, count)
-
-		// Verify the segment has been repaired
-		segmentAfterRepair, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
-		require.NotEqual(t, segment.Pieces, segmentAfterRepair.Pieces)
-		require.GreaterOrEqual(t, len(segmentAfterRepair.Pieces), int(segmentAfterRepair.Redundancy.OptimalShares))
+		defaultSegment = getSegment(defaultBucketName)
+		strictSegment = getSegment(strictBucketName)
 
-		// check excluded area node still exists
-		var found bool
-		for _, p := range segmentAfterRepair.Pieces {
-			if p.StorageNode == nodeInExcluded {
-				found = true
-				break
-			}
-		}
-		require.True(t, found, fmt.Sprintf("node %s not in segment, but should be\n", segmentAfterRepair.Pieces[1].StorageNode.String()))
-		nodesInPointer := make(map[storj.NodeID]bool)
-		for _, n := range segmentAfterRepair.Pieces {
-			// check for duplicates
-			_, ok := nodesInPointer[n.StorageNode]
-			require.False(t, ok)
-			nodesInPointer[n.StorageNode] = true
-		}
+		require.Len(t, defaultSegment.Pieces, int(defaultSegment.Redundancy.OptimalShares))
+		require.Len(t, strictSegment.Pieces, placementSuccess)
+		require.Greater(t, len(strictSegment.Pieces), len(defaultSegment.Pieces))
 	})
 }
 
-// - 7 storage nodes
-// - pieces uploaded to 4 or 5 nodes
-// - mark one node holding a piece in excluded area
-// - put one other node holding a piece offline
-// - run the checker and check the segment is in the repair queue
-// - run the repairer
-// - check the segment has been repaired and that:
-//		- piece in excluded is still there
-//		- piece held by offline node is not
-//		- there are no duplicate
-func TestSegmentInExcludedCountriesRepairIrreparable(t *testing.T) {
+// TestRepairResumesFromCheckpoint checks that a repair, which was interrupted
+// after uploading some pieces, is resumed by another repairer without
+// uploading those pieces again.
+func TestRepairResumesFromCheckpoint(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
-		StorageNodeCount: 7,
+		StorageNodeCount: 15,
 		UplinkCount:      1,
 		Reconfigure: testplanet.Reconfigure{
 			Satellite: testplanet.Combine(
 				func(log *zap.Logger, index int, config *satellite.Config) {
-					config.Repairer.InMemoryRepair = true
+					config.Repairer.InMemoryRepair = false
+					config.Repairer.Checkpointing = true
 				},
-				testplanet.ReconfigureRS(2, 3, 4, 5),
-				testplanet.RepairExcludedCountryCodes([]string{"FR", "BE"}),
+				testplanet.ReconfigureRS(3, 5, 7, 9),
 			),
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
-		uplinkPeer := planet.Uplinks[0]
 		satellite := planet.Satellites[0]
-		// stop audit to prevent possible interactions i.e. repair timeout problems
 		satellite.Audit.Worker.Loop.Pause()
-
 		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
 
-		var testData = testrand.Bytes(8 * memory.KiB)
-		// first, upload some remote data
-		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
 		require.NoError(t, err)
 
 		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
 
-		remotePieces := segment.Pieces
-		require.GreaterOrEqual(t, len(remotePieces), int(segment.Redundancy.OptimalShares))
+		// kill nodes to get the segment down to the repair threshold
+		toKill := len(segment.Pieces) - int(segment.Redundancy.RepairShares)
+		for _, piece := range segment.Pieces[:toKill] {
+			require.NoError(t, planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode)))
+		}
 
-		err = planet.Satellites[0].Overlay.Service.TestNodeCountryCode(ctx, remotePieces[1].StorageNode, "FR")
+		injured := &queue.InjuredSegment{
+			StreamID: segment.StreamID,
+			Position: segment.Position,
+		}
+
+		// interrupt the repair as soon as the first piece is uploaded
+		repairCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		satellite.Repairer.SegmentRepairer.OnTestingPieceUploadedHook = func(piece metabase.Piece) {
+			cancel()
+		}
+		_, err = satellite.Repairer.SegmentRepairer.Repair(repairCtx, injured)
+		require.Error(t, err)
+
+		interrupted, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Equal(t, segment.Pieces, interrupted.Pieces)
+
+		checkpointed, err := satellite.DB.RepairCheckpoints().Get(ctx, segment.StreamID, segment.Position, time.Time{})
 		require.NoError(t, err)
-		nodeInExcluded := remotePieces[0].StorageNode
-		offlineNode := remotePieces[2].StorageNode
-		// make  one unhealthy
-		err = planet.StopNodeAndUpdate(ctx, planet.FindNode(offlineNode))
+		require.NotEmpty(t, checkpointed)
+
+		// pieces older than the checkpoint ttl aren't returned
+		stale, err := satellite.DB.RepairCheckpoints().Get(ctx, segment.StreamID, segment.Position, time.Now().Add(time.Hour))
 		require.NoError(t, err)
+		require.Empty(t, stale)
 
-		// trigger checker to add segment to repair queue
-		satellite.Repair.Checker.Loop.Restart()
-		satellite.Repair.Checker.Loop.TriggerWait()
+		checkpointedNodes := make(map[storj.NodeID]bool)
+		for _, piece := range checkpointed {
+			checkpointedNodes[piece.StorageNode] = true
+		}
+
+		// a new repairer, like after a restart of the process, resumes the repair
+		config := satellite.Config
+		resumingRepairer := repairer.NewSegmentRepairer(
+			zaptest.NewLogger(t),
+			satellite.Metabase.DB,
+			satellite.Orders.Service,
+			satellite.Overlay.Service,
+			satellite.Repairer.Audit.Reporter,
+			satellite.Repairer.EcRepairer,
+			satellite.DB.RepairCheckpoints(),
+			config.Checker.RepairOverrides,
+			config.Checker.PlacementSuccessOverrides,
+			config.Checker.PlacementExcludedCountries,
+			&config.Repairer,
+		)
+
+		var uploaded []metabase.Piece
+		resumingRepairer.OnTestingPieceUploadedHook = func(piece metabase.Piece) {
+			uploaded = append(uploaded, piece)
+		}
+
+		shouldDelete, err := resumingRepairer.Repair(ctx, injured)
+		require.NoError(t, err)
+		require.True(t, shouldDelete)
+
+		for _, piece := range uploaded {
+			require.NotContains(t, checkpointedNodes, piece.StorageNode, "piece was uploaded again to a node which already has it")
+		}
+
+		repaired, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.Len(t, repaired.Pieces, int(segment.Redundancy.RepairShares)+len(checkpointed)+len(uploaded))
+		for _, piece := range checkpointed {
+			require.Contains(t, repaired.Pieces, piece)
+		}
+
+		// the checkpoint is cleared after the segment was updated
+		checkpointed, err = satellite.DB.RepairCheckpoints().Get(ctx, segment.StreamID, segment.Position, time.Time{})
+		require.NoError(t, err)
+		require.Empty(t, checkpointed)
+	})
+}
+
+func TestRepairTrace(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 15,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(3, 5, 7, 9),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Worker.Loop.Pause()
 		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
 
-		count, err := satellite.DB.RepairQueue().Count(ctx)
+		for _, bucket := range []string{"traced", "untraced"} {
+			err := planet.Uplinks[0].Upload(ctx, satellite, bucket, "test/path", testrand.Bytes(8*memory.KiB))
+			require.NoError(t, err)
+		}
+
+		traced, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "traced")
+		untraced, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "untraced")
+
+		// kill nodes to get the traced segment down to the repair threshold
+		toKill := len(traced.Pieces) - int(traced.Redundancy.RepairShares)
+		killed := make(map[storj.NodeID]bool)
+		for _, piece := range traced.Pieces[:toKill] {
+			require.NoError(t, planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode)))
+			killed[piece.StorageNode] = true
+		}
+
+		config := satellite.Config
+		config.Repairer.TraceStreamIDs = traced.StreamID.String()
+		tracingRepairer := repairer.NewSegmentRepairer(
+			zaptest.NewLogger(t),
+			satellite.Metabase.DB,
+			satellite.Orders.Service,
+			satellite.Overlay.Service,
+			satellite.Repairer.Audit.Reporter,
+			satellite.Repairer.EcRepairer,
+			satellite.DB.RepairCheckpoints(),
+			config.Checker.RepairOverrides,
+			config.Checker.PlacementSuccessOverrides,
+			config.Checker.PlacementExcludedCountries,
+			&config.Repairer,
+		)
+
+		var traces []*repairer.RepairTrace
+		tracingRepairer.OnTestingRepairTraceHook = func(trace *repairer.RepairTrace) {
+			traces = append(traces, trace)
+		}
+
+		_, err := tracingRepairer.Repair(ctx, &queue.InjuredSegment{
+			StreamID: untraced.StreamID,
+			Position: untraced.Position,
+		})
 		require.NoError(t, err)
-		require.Equal(t, 1, count)
+		require.Empty(t, traces)
 
-		satellite.Repair.Repairer.Loop.Restart()
-		satellite.Repair.Repairer.Loop.TriggerWait()
+		shouldDelete, err := tracingRepairer.Repair(ctx, &queue.InjuredSegment{
+			StreamID: traced.StreamID,
+			Position: traced.Position,
+		})
+		require.NoError(t, err)
+		require.True(t, shouldDelete)
+		require.Len(t, traces, 1)
+
+		trace := traces[0]
+		require.Equal(t, traced.StreamID, trace.StreamID)
+		require.Equal(t, traced.Position, trace.Position)
+		require.NoError(t, trace.Err)
+
+		successfulDownloads := 0
+		for _, download := range trace.Downloads {
+			require.Contains(t, traced.Pieces, download.Piece)
+			require.False(t, killed[download.StorageNode])
+			if download.Result == repairer.TraceSuccess {
+				successfulDownloads++
+			}
+		}
+		require.GreaterOrEqual(t, successfulDownloads, int(traced.Redundancy.RequiredShares))
+		require.Positive(t, trace.DownloadDuration)
+
+		repaired, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "traced")
+		require.NotEmpty(t, trace.Uploads)
+		for _, upload := range trace.Uploads {
+			if upload.Result == repairer.TraceSuccess {
+				require.Contains(t, repaired.Pieces, upload.Piece)
+			}
+		}
+		require.Positive(t, trace.RepairDuration)
+		require.GreaterOrEqual(t, trace.Duration, trace.DownloadDuration+trace.RepairDuration)
+	})
+}
+
+func TestRepairFederated(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 15,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(3, 5, 7, 9),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
 		satellite.Repair.Repairer.Loop.Pause()
-		satellite.Repair.Repairer.WaitForPendingRepairs()
 
-		// Verify that the segment was removed
-		count, err = satellite.DB.RepairQueue().Count(ctx)
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
 		require.NoError(t, err)
-		require.Zero(t, count)
 
-		// Verify the segment has been repaired
-		segmentAfterRepair, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
-		require.NotEqual(t, segment.Pieces, segmentAfterRepair.Pieces)
-		require.GreaterOrEqual(t, len(segmentAfterRepair.Pieces), int(segment.Redundancy.OptimalShares))
+		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
 
-		// check node in excluded area still exists
-		var nodeInExcludedAreaFound bool
-		var offlineNodeFound bool
-		for _, p := range segmentAfterRepair.Pieces {
-			if p.StorageNode == nodeInExcluded {
-				nodeInExcludedAreaFound = true
-			}
-			if p.StorageNode == offlineNode {
-				offlineNodeFound = true
+		// disqualify nodes, so the segment can't be repaired with the local pieces,
+		// but keep them running, so the peer's pieces can still be downloaded.
+		toDisqualify := len(segment.Pieces) - int(segment.Redundancy.RequiredShares) + 1
+		disqualified := make(map[storj.NodeID]bool)
+		for _, piece := range segment.Pieces[:toDisqualify] {
+			err := satellite.DB.OverlayCache().DisqualifyNode(ctx, piece.StorageNode, time.Now(), overlay.DisqualificationReasonUnknown)
+			require.NoError(t, err)
+			disqualified[piece.StorageNode] = true
+		}
+
+		newRepairer := func(peers string) *repairer.SegmentRepairer {
+			config := satellite.Config
+			config.Repairer.FederatedRepair = true
+			config.Repairer.FederatedPeers = peers
+			return repairer.NewSegmentRepairer(
+				zaptest.NewLogger(t),
+				satellite.Metabase.DB,
+				satellite.Orders.Service,
+				satellite.Overlay.Service,
+				satellite.Repairer.Audit.Reporter,
+				satellite.Repairer.EcRepairer,
+				satellite.DB.RepairCheckpoints(),
+				config.Checker.RepairOverrides,
+				config.Checker.PlacementSuccessOverrides,
+				config.Checker.PlacementExcludedCountries,
+				&config.Repairer,
+			)
+		}
+
+		// the storage nodes only trust the satellite, so it stands in for the peer.
+		source := &federatedSource{planet: planet, peer: satellite.Identity}
+
+		t.Run("untrusted peer", func(t *testing.T) {
+			untrustedRepairer := newRepairer(testrand.NodeID().String())
+			untrustedRepairer.AddFederatedSource(source)
+
+			shouldDelete, err := untrustedRepairer.Repair(ctx, &queue.InjuredSegment{
+				StreamID: segment.StreamID,
+				Position: segment.Position,
+			})
+			require.NoError(t, err)
+			require.False(t, shouldDelete)
+			require.Zero(t, source.calls)
+		})
+
+		federatedRepairer := newRepairer(satellite.ID().String())
+		federatedRepairer.AddFederatedSource(source)
+
+		var report audit.Pieces
+		federatedRepairer.OnTestingPiecesReportHook = func(pieces audit.Pieces) {
+			report = pieces
+		}
+
+		shouldDelete, err := federatedRepairer.Repair(ctx, &queue.InjuredSegment{
+			StreamID: segment.StreamID,
+			Position: segment.Position,
+		})
+		require.NoError(t, err)
+		require.True(t, shouldDelete)
+		require.Equal(t, 1, source.calls)
+
+		fromDisqualified := 0
+		for _, piece := range report.Successful {
+			if disqualified[piece.StorageNode] {
+				fromDisqualified++
 			}
 		}
-		require.True(t, nodeInExcludedAreaFound, fmt.Sprintf("node %s not in segment, but should be\n", nodeInExcluded.String()))
-		require.False(t, offlineNodeFound, fmt.Sprintf("node %s in segment, but should not be\n", offlineNode.String()))
+		require.Positive(t, fromDisqualified)
 
-		nodesInPointer := make(map[storj.NodeID]bool)
-		for _, n := range segmentAfterRepair.Pieces {
-			// check for duplicates
-			_, ok := nodesInPointer[n.StorageNode]
-			require.False(t, ok)
-			nodesInPointer[n.StorageNode] = true
+		repaired, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		require.GreaterOrEqual(t, len(repaired.Pieces), int(repaired.Redundancy.OptimalShares))
+		for _, piece := range repaired.Pieces {
+			require.False(t, disqualified[piece.StorageNode])
 		}
+
+		data, err := planet.Uplinks[0].Download(ctx, satellite, "testbucket", "test/path")
+		require.NoError(t, err)
+		require.Len(t, data, 8*memory.KiB.Int())
 	})
 }
+
+// federatedSource signs GET_REPAIR order limits for all pieces of a segment
+// with the identity of the peer.
+type federatedSource struct {
+	planet *testplanet.Planet
+	peer   *identity.FullIdentity
+	calls  int
+}
+
+func (source *federatedSource) PeerID() storj.NodeID { return source.peer.ID }
+
+func (source *federatedSource) CreateGetRepairOrderLimits(ctx context.Context, segment metabase.Segment) ([]*pb.AddressedOrderLimit, storj.PiecePrivateKey, error) {
+	source.calls++
+
+	redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+	if err != nil {
+		return nil, storj.PiecePrivateKey{}, err
+	}
+	pieceSize := eestream.CalcPieceSize(int64(segment.EncryptedSize), redundancy)
+
+	publicKey, privateKey, err := storj.NewPieceKey()
+	if err != nil {
+		return nil, storj.PiecePrivateKey{}, err
+	}
+
+	now := time.Now()
+	limits := make([]*pb.AddressedOrderLimit, segment.Redundancy.TotalShares)
+	for _, piece := range segment.Pieces {
+		limit, err := signing.SignOrderLimit(ctx, signing.SignerFromFullIdentity(source.peer), &pb.OrderLimit{
+			SerialNumber:    testrand.SerialNumber(),
+			SatelliteId:     source.peer.ID,
+			UplinkPublicKey: publicKey,
+			StorageNodeId:   piece.StorageNode,
+			PieceId:         segment.RootPieceID.Derive(piece.StorageNode, int32(piece.Number)),
+			Limit:           pieceSize,
+			Action:          pb.PieceAction_GET_REPAIR,
+			OrderCreation:   now,
+			OrderExpiration: now.Add(time.Hour),
+		})
+		if err != nil {
+			return nil, storj.PiecePrivateKey{}, err
+		}
+		limits[piece.Number] = &pb.AddressedOrderLimit{
+			Limit: limit,
+			StorageNodeAddress: &pb.NodeAddress{
+				Address: source.planet.FindNode(piece.StorageNode).Addr(),
+			},
+		}
+	}
+	return limits, privateKey, nil
+}
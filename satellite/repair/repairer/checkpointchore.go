@@ -0,0 +1,64 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/repair/queue"
+)
+
+// CheckpointChore deletes the recorded pieces of interrupted repairs which are
+// too old to be reused, including the ones of segments which were never
+// repaired again.
+//
+// architecture: Chore
+type CheckpointChore struct {
+	log         *zap.Logger
+	checkpoints queue.RepairCheckpoints
+	ttl         time.Duration
+
+	Loop *sync2.Cycle
+
+	nowFn func() time.Time
+}
+
+// NewCheckpointChore creates a new chore which deletes old repair checkpoints.
+func NewCheckpointChore(log *zap.Logger, checkpoints queue.RepairCheckpoints, config *Config) *CheckpointChore {
+	return &CheckpointChore{
+		log:         log,
+		checkpoints: checkpoints,
+		ttl:         config.CheckpointTTL,
+
+		Loop: sync2.NewCycle(config.CheckpointCleanupInterval),
+
+		nowFn: time.Now,
+	}
+}
+
+// Run runs the chore.
+func (chore *CheckpointChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		deleted, err := chore.checkpoints.DeleteUploadedBefore(ctx, chore.nowFn().Add(-chore.ttl))
+		if err != nil {
+			chore.log.Error("failed to delete old repair checkpoints", zap.Error(err))
+			return nil
+		}
+
+		mon.IntVal("repair_checkpoints_deleted").Observe(deleted)
+		return nil
+	})
+}
+
+// Close stops the chore.
+func (chore *CheckpointChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
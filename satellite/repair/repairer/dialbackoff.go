@@ -0,0 +1,91 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"sync"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// DialBackoff tracks the storage nodes which failed during repair, so that
+// they aren't dialed again, neither as a source nor as a target, until the
+// backoff interval passes. The failures are kept across repair cycles.
+type DialBackoff struct {
+	interval time.Duration
+
+	mu     sync.Mutex
+	nowFn  func() time.Time
+	failed map[storj.NodeID]time.Time
+}
+
+// NewDialBackoff creates a new dial backoff, an interval of 0 disables it.
+func NewDialBackoff(interval time.Duration) *DialBackoff {
+	return &DialBackoff{
+		interval: interval,
+		nowFn:    time.Now,
+		failed:   map[storj.NodeID]time.Time{},
+	}
+}
+
+// Failed records a failure of the node.
+func (backoff *DialBackoff) Failed(nodeID storj.NodeID) {
+	if backoff.interval <= 0 {
+		return
+	}
+
+	backoff.mu.Lock()
+	defer backoff.mu.Unlock()
+
+	backoff.failed[nodeID] = backoff.nowFn()
+}
+
+// Active returns whether the node failed within the backoff interval.
+func (backoff *DialBackoff) Active(nodeID storj.NodeID) bool {
+	if backoff.interval <= 0 {
+		return false
+	}
+
+	backoff.mu.Lock()
+	defer backoff.mu.Unlock()
+
+	failedAt, ok := backoff.failed[nodeID]
+	if !ok {
+		return false
+	}
+	if backoff.nowFn().Sub(failedAt) >= backoff.interval {
+		delete(backoff.failed, nodeID)
+		return false
+	}
+	return true
+}
+
+// Nodes returns the nodes which failed within the backoff interval.
+func (backoff *DialBackoff) Nodes() (nodeIDs storj.NodeIDList) {
+	if backoff.interval <= 0 {
+		return nil
+	}
+
+	backoff.mu.Lock()
+	defer backoff.mu.Unlock()
+
+	now := backoff.nowFn()
+	for nodeID, failedAt := range backoff.failed {
+		if now.Sub(failedAt) >= backoff.interval {
+			delete(backoff.failed, nodeID)
+			continue
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	return nodeIDs
+}
+
+// SetNow allows tests to have the backoff act as if the current time is whatever they want.
+func (backoff *DialBackoff) SetNow(nowFn func() time.Time) {
+	backoff.mu.Lock()
+	defer backoff.mu.Unlock()
+
+	backoff.nowFn = nowFn
+}
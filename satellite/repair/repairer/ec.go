@@ -8,8 +8,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
+	"math"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -21,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/errs2"
+	"storj.io/common/memory"
 	"storj.io/common/pb"
 	"storj.io/common/pkcrypto"
 	"storj.io/common/rpc"
@@ -30,6 +33,7 @@ import (
 	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/reputation"
 	"storj.io/uplink/private/eestream"
 	"storj.io/uplink/private/piecestore"
 )
@@ -44,19 +48,61 @@ type ECRepairer struct {
 	satelliteSignee signing.Signee
 	downloadTimeout time.Duration
 	inmemory        bool
+	sourceStats     *SourceStats
+
+	// preferLowLatency indicates whether pieces should be downloaded from
+	// the nodes with the lowest observed latency first.
+	preferLowLatency bool
+
+	// minSourceAuditReputation is the audit reputation score below which nodes
+	// are used as sources only when not enough other nodes are available.
+	// The scores are queried from reputation only when it's enabled.
+	minSourceAuditReputation float64
+	reputation               *reputation.Service
+
+	// streaming indicates whether the segment should be reconstructed while
+	// the pieces are downloaded, using at most maxBufferMem for buffering.
+	// sparePieces more pieces than required are downloaded, so that the
+	// reconstruction doesn't fail when some of them fail.
+	streaming    bool
+	sparePieces  int
+	maxBufferMem memory.Size
+
+	// dialBackoff tracks the nodes which failed recently, which aren't
+	// dialed as sources until their backoff expires.
+	dialBackoff *DialBackoff
 }
 
 // NewECRepairer creates a new repairer for interfacing with storagenodes.
-func NewECRepairer(log *zap.Logger, dialer rpc.Dialer, satelliteSignee signing.Signee, downloadTimeout time.Duration, inmemory bool) *ECRepairer {
+func NewECRepairer(log *zap.Logger, dialer rpc.Dialer, satelliteSignee signing.Signee, reputationService *reputation.Service, config Config) *ECRepairer {
 	return &ECRepairer{
 		log:             log,
 		dialer:          dialer,
 		satelliteSignee: satelliteSignee,
-		downloadTimeout: downloadTimeout,
-		inmemory:        inmemory,
+		downloadTimeout: config.DownloadTimeout,
+		inmemory:        config.InMemoryRepair,
+		sourceStats:     NewSourceStats(),
+
+		preferLowLatency:         config.DownloadPreferLowLatency,
+		minSourceAuditReputation: config.DownloadMinAuditReputation,
+		reputation:               reputationService,
+		streaming:                config.StreamingRepair,
+		sparePieces:              config.StreamingSparePieces,
+		maxBufferMem:             config.MaxBufferMem,
+		dialBackoff:              NewDialBackoff(config.DialBackoff),
 	}
 }
 
+// DialBackoff returns the nodes which failed recently during repair.
+func (ec *ECRepairer) DialBackoff() *DialBackoff {
+	return ec.dialBackoff
+}
+
+// SourceStats returns the accounting of bytes downloaded from each node for repair.
+func (ec *ECRepairer) SourceStats() *SourceStats {
+	return ec.sourceStats
+}
+
 func (ec *ECRepairer) dialPiecestore(ctx context.Context, n storj.NodeURL) (*piecestore.Client, error) {
 	return piecestore.Dial(ctx, ec.dialer, n, piecestore.DefaultConfig)
 }
@@ -76,13 +122,28 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 	nonNilLimits := nonNilCount(limits)
 
 	if nonNilLimits < es.RequiredCount() {
-		return nil, audit.Pieces{}, Error.New("number of non-nil limits (%d) is less than required count (%d) of erasure scheme", nonNilCount(limits), es.RequiredCount())
+		return nil, audit.Pieces{MinRequired: es.RequiredCount()}, Error.New("number of non-nil limits (%d) is less than required count (%d) of erasure scheme", nonNilCount(limits), es.RequiredCount())
+	}
+
+	order := ec.downloadOrder(ctx, limits, cachedNodesInfo)
+	if len(order) < es.RequiredCount() {
+		// the segment is retried once enough of the nodes are out of the backoff.
+		mon.Meter("download_failed_dial_backoff_repair").Mark(1)
+		return nil, audit.Pieces{MinRequired: es.RequiredCount()}, &irreparableError{
+			piecesAvailable: int32(len(order)),
+			piecesRequired:  int32(es.RequiredCount()),
+			errlist:         []error{Error.New("%d of the nodes are in the dial backoff", nonNilLimits-len(order))},
+		}
 	}
 
 	pieceSize := eestream.CalcPieceSize(dataSize, es)
 
+	if ec.streaming {
+		return ec.getStreaming(ctx, limits, order, cachedNodesInfo, privateKey, es, pieceSize)
+	}
+
 	var successfulPieces, inProgress int
-	unusedLimits := nonNilLimits
+	unusedLimits := len(order)
 	pieceReaders := make(map[int]io.ReadCloser)
 	var pieces audit.Pieces
 
@@ -92,12 +153,8 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 	var errlist errs.Group
 	var mu sync.Mutex
 
-	for currentLimitIndex, limit := range limits {
-		if limit == nil {
-			continue
-		}
-
-		currentLimitIndex, limit := currentLimitIndex, limit
+	for _, currentLimitIndex := range order {
+		currentLimitIndex, limit := currentLimitIndex, limits[currentLimitIndex]
 		limiter.Go(ctx, func() {
 			cond.L.Lock()
 			defer cond.Signal()
@@ -178,6 +235,7 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 							zap.Stringer("Piece ID", limit.Limit.PieceId),
 							zap.Error(err))
 						pieces.Offline = append(pieces.Offline, piece)
+						ec.dialBackoff.Failed(piece.StorageNode)
 
 					case audit.PieceAuditContained:
 						ec.log.Info("Failed to download pieces for repair: download timeout (contained)",
@@ -192,6 +250,7 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 							zap.Stringer("Piece ID", limit.Limit.PieceId),
 							zap.Error(err))
 						pieces.Unknown = append(pieces.Unknown, piece)
+						ec.dialBackoff.Failed(piece.StorageNode)
 					}
 
 					mu.Lock()
@@ -210,7 +269,10 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 
 	limiter.Wait()
 
-	if successfulPieces < es.RequiredCount() {
+	pieces.MinRequired = es.RequiredCount()
+	pieces.Reconstructable = successfulPieces >= es.RequiredCount()
+
+	if !pieces.Reconstructable {
 		mon.Meter("download_failed_not_enough_pieces_repair").Mark(1) //mon:locked
 		return nil, pieces, &irreparableError{
 			piecesAvailable: int32(successfulPieces),
@@ -233,6 +295,263 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 	return decodeReader, pieces, nil
 }
 
+// getStreaming opens the downloads of the minimum required number of pieces,
+// plus the configured number of spare pieces, and returns a reader which
+// reconstructs the segment while the pieces are downloaded, without
+// buffering whole pieces.
+//
+// The pieces are verified once they are read completely, and with spare
+// pieces every stripe is also checked against the erasure code. A piece
+// failing the verification makes the reader fail, so nothing is committed
+// from the reconstructed data. The failed pieces are available from the
+// reader through FailedPieces once it has failed, so that they can be
+// reported as failed audits.
+func (ec *ECRepairer) getStreaming(ctx context.Context, limits []*pb.AddressedOrderLimit, order []int, cachedNodesInfo map[storj.NodeID]overlay.NodeReputation, privateKey storj.PiecePrivateKey, es eestream.ErasureScheme, pieceSize int64) (_ io.ReadCloser, _ audit.Pieces, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieces := audit.Pieces{MinRequired: es.RequiredCount()}
+	pieceReaders := make(map[int]io.ReadCloser)
+	defer func() {
+		if err != nil {
+			for _, pieceReader := range pieceReaders {
+				_ = pieceReader.Close()
+			}
+		}
+	}()
+
+	segment := &streamedSegment{}
+
+	var errlist errs.Group
+	for _, currentLimitIndex := range order {
+		if len(pieceReaders) >= es.RequiredCount()+ec.sparePieces {
+			break
+		}
+
+		limit := limits[currentLimitIndex]
+		piece := metabase.Piece{
+			Number:      uint16(currentLimitIndex),
+			StorageNode: limit.GetLimit().StorageNodeId,
+		}
+
+		info := cachedNodesInfo[limit.GetLimit().StorageNodeId]
+		address := limit.GetStorageNodeAddress().GetAddress()
+		var triedLastIPPort bool
+		if info.LastIPPort != "" && info.LastIPPort != address {
+			address = info.LastIPPort
+			triedLastIPPort = true
+		}
+
+		pieceReader, err := ec.openPieceStream(ctx, segment, piece, limit, address, privateKey, pieceSize)
+		// if piecestore dial with last ip:port failed try again with node address
+		if triedLastIPPort && piecestore.Error.Has(err) && !piecestore.CloseError.Has(err) {
+			pieceReader, err = ec.openPieceStream(ctx, segment, piece, limit, limit.GetStorageNodeAddress().GetAddress(), privateKey, pieceSize)
+		}
+		if err != nil {
+			switch audit.PieceAuditFromErr(err) {
+			case audit.PieceAuditFailure:
+				pieces.Failed = append(pieces.Failed, piece)
+			case audit.PieceAuditOffline:
+				pieces.Offline = append(pieces.Offline, piece)
+				ec.dialBackoff.Failed(piece.StorageNode)
+			case audit.PieceAuditContained:
+				pieces.Contained = append(pieces.Contained, piece)
+			case audit.PieceAuditUnknown:
+				pieces.Unknown = append(pieces.Unknown, piece)
+				ec.dialBackoff.Failed(piece.StorageNode)
+			}
+			ec.log.Debug("Failed to open piece stream for repair",
+				zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
+				zap.Stringer("Piece ID", limit.Limit.PieceId),
+				zap.Error(err))
+			errlist.Add(fmt.Errorf("node id: %s, error: %w", limit.GetLimit().StorageNodeId.String(), err))
+			continue
+		}
+
+		pieceReaders[currentLimitIndex] = pieceReader
+	}
+
+	pieces.Reconstructable = len(pieceReaders) >= es.RequiredCount()
+	if !pieces.Reconstructable {
+		mon.Meter("download_failed_not_enough_pieces_repair").Mark(1) //mon:locked
+		return nil, pieces, &irreparableError{
+			piecesAvailable: int32(len(pieceReaders)),
+			piecesRequired:  int32(es.RequiredCount()),
+			errlist:         errlist,
+		}
+	}
+
+	fec, err := infectious.NewFEC(es.RequiredCount(), es.TotalCount())
+	if err != nil {
+		return nil, pieces, Error.Wrap(err)
+	}
+
+	// the data of a piece is only verified against its hash once it's read
+	// completely, so the stripes are checked against the erasure code when
+	// there are enough pieces to do so.
+	forceErrorDetection := len(pieceReaders) > es.RequiredCount()
+	esScheme := eestream.NewRSScheme(fec, es.ErasureShareSize())
+	expectedSize := pieceSize * int64(es.RequiredCount())
+
+	mon.Meter("repair_streamed").Mark(1)
+
+	ctx, cancel := context.WithCancel(ctx)
+	segment.ReadCloser = eestream.DecodeReaders2(ctx, cancel, pieceReaders, esScheme, expectedSize, ec.maxBufferMem.Int(), forceErrorDetection)
+
+	return segment, pieces, nil
+}
+
+// streamedSegment is a segment reconstructed while its pieces are
+// downloaded, which fails when any of the pieces fails the verification.
+type streamedSegment struct {
+	io.ReadCloser
+
+	mu     sync.Mutex
+	failed metabase.Pieces
+}
+
+// Read reads the reconstructed segment.
+func (segment *streamedSegment) Read(p []byte) (n int, err error) {
+	n, err = segment.ReadCloser.Read(p)
+	if failed := segment.FailedPieces(); len(failed) > 0 {
+		return 0, ErrPieceHashVerifyFailed.New("%d pieces failed the verification", len(failed))
+	}
+	return n, err
+}
+
+// pieceFailed records a piece which failed the verification.
+func (segment *streamedSegment) pieceFailed(piece metabase.Piece) {
+	segment.mu.Lock()
+	defer segment.mu.Unlock()
+	segment.failed = append(segment.failed, piece)
+}
+
+// FailedPieces returns the pieces which failed the verification so far.
+func (segment *streamedSegment) FailedPieces() metabase.Pieces {
+	segment.mu.Lock()
+	defer segment.mu.Unlock()
+	return append(metabase.Pieces(nil), segment.failed...)
+}
+
+// pieceDownload is a download of a piece from a storagenode.
+type pieceDownload interface {
+	io.ReadCloser
+	GetHashAndLimit() (*pb.PieceHash, *pb.OrderLimit)
+}
+
+// openPieceStream starts downloading a piece from a storagenode and returns
+// a reader which verifies the piece once it's read completely. Pieces failing
+// the hash verification are recorded in the segment.
+func (ec *ECRepairer) openPieceStream(ctx context.Context, segment *streamedSegment, piece metabase.Piece, limit *pb.AddressedOrderLimit, address string, privateKey storj.PiecePrivateKey, pieceSize int64) (_ io.ReadCloser, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dialCtx, dialCancel := context.WithTimeout(ctx, ec.downloadTimeout)
+	defer dialCancel()
+
+	ps, err := ec.dialPiecestore(dialCtx, storj.NodeURL{
+		ID:      limit.GetLimit().StorageNodeId,
+		Address: address,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the download lives as long as the returned reader, so it can't use the
+	// dial timeout.
+	downloadCtx, downloadCancel := context.WithCancel(ctx)
+	downloader, err := ps.Download(downloadCtx, limit.GetLimit(), privateKey, 0, pieceSize)
+	if err != nil {
+		downloadCancel()
+		return nil, errs.Combine(err, ps.Close())
+	}
+
+	return &streamedPiece{
+		ec:         ec,
+		ctx:        ctx,
+		segment:    segment,
+		piece:      piece,
+		limit:      limit,
+		size:       pieceSize,
+		ps:         ps,
+		downloader: downloader,
+		cancel:     downloadCancel,
+		hash:       pkcrypto.NewHash(),
+	}, nil
+}
+
+// streamedPiece is a piece which is verified when it's read completely.
+type streamedPiece struct {
+	ec         *ECRepairer
+	ctx        context.Context
+	segment    *streamedSegment
+	piece      metabase.Piece
+	limit      *pb.AddressedOrderLimit
+	size       int64
+	ps         *piecestore.Client
+	downloader pieceDownload
+	cancel     func()
+
+	hash     hash.Hash
+	read     int64
+	verified bool
+}
+
+// Read reads the piece and returns an error instead of the last part of the
+// piece when the verification fails.
+func (piece *streamedPiece) Read(p []byte) (n int, err error) {
+	n, err = piece.downloader.Read(p)
+	_, _ = piece.hash.Write(p[:n])
+	piece.read += int64(n)
+
+	if !piece.verified && (piece.read >= piece.size || errors.Is(err, io.EOF)) {
+		piece.verified = true
+		if verifyErr := piece.verify(); verifyErr != nil {
+			return n, verifyErr
+		}
+	}
+	return n, err
+}
+
+// verify verifies the size, the hash and the original order limit of the piece.
+func (piece *streamedPiece) verify() (err error) {
+	ctx := piece.ctx
+	defer mon.Task()(&ctx)(&err)
+
+	nodeID := piece.limit.GetLimit().StorageNodeId
+	mon.Meter("repair_bytes_downloaded").Mark64(piece.read) //mon:locked
+	piece.ec.sourceStats.Add(nodeID, piece.read)
+
+	if piece.read != piece.size {
+		return Error.New("didn't download the correct amount of data from %s, want %d, got %d", nodeID, piece.size, piece.read)
+	}
+
+	hash, originalLimit := piece.downloader.GetHashAndLimit()
+	if hash == nil {
+		return Error.New("hash was not sent from storagenode %s", nodeID)
+	}
+	if originalLimit == nil {
+		return Error.New("original order limit was not sent from storagenode %s", nodeID)
+	}
+
+	if err := verifyOrderLimitSignature(ctx, piece.ec.satelliteSignee, originalLimit); err != nil {
+		return err
+	}
+	if err := verifyPieceHash(ctx, originalLimit, hash, piece.hash.Sum(nil)); err != nil {
+		piece.ec.log.Info("audit failed",
+			zap.Stringer("node ID", nodeID),
+			zap.Stringer("Piece ID", piece.limit.Limit.PieceId),
+			zap.String("reason", err.Error()))
+		piece.segment.pieceFailed(piece.piece)
+		return ErrPieceHashVerifyFailed.Wrap(err)
+	}
+	return nil
+}
+
+// Close closes the download and the connection to the storagenode.
+func (piece *streamedPiece) Close() error {
+	defer piece.cancel()
+	return errs.Combine(piece.downloader.Close(), piece.ps.Close())
+}
+
 // downloadAndVerifyPiece downloads a piece from a storagenode,
 // expects the original order limit to have the correct piece public key,
 // and expects the hash of the data to match the signed hash provided by the storagenode.
@@ -292,6 +611,7 @@ func (ec *ECRepairer) downloadAndVerifyPiece(ctx context.Context, limit *pb.Addr
 	}
 
 	mon.Meter("repair_bytes_downloaded").Mark64(downloadedPieceSize) //mon:locked
+	ec.sourceStats.Add(limit.GetLimit().StorageNodeId, downloadedPieceSize)
 
 	if downloadedPieceSize != pieceSize {
 		return pieceReadCloser, nil, nil, Error.New("didn't download the correct amount of data, want %d, got %d", pieceSize, downloadedPieceSize)
@@ -351,7 +671,8 @@ func verifyOrderLimitSignature(ctx context.Context, satellite signing.Signee, li
 
 // Repair takes a provided segment, encodes it with the provided redundancy strategy,
 // and uploads the pieces in need of repair to new nodes provided by order limits.
-func (ec *ECRepairer) Repair(ctx context.Context, limits []*pb.AddressedOrderLimit, privateKey storj.PiecePrivateKey, rs eestream.RedundancyStrategy, data io.Reader, timeout time.Duration, successfulNeeded int) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
+// uploaded, when not nil, is called for every piece as soon as it's uploaded.
+func (ec *ECRepairer) Repair(ctx context.Context, limits []*pb.AddressedOrderLimit, privateKey storj.PiecePrivateKey, rs eestream.RedundancyStrategy, data io.Reader, timeout time.Duration, successfulNeeded int, uploaded func(pieceNum int, nodeID storj.NodeID)) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	pieceCount := len(limits)
@@ -415,6 +736,7 @@ func (ec *ECRepairer) Repair(ctx context.Context, limits []*pb.AddressedOrderLim
 		if info.err != nil {
 			if !errs2.IsCanceled(info.err) {
 				failureCount++
+				ec.dialBackoff.Failed(limits[info.i].GetLimit().StorageNodeId)
 				ec.log.Warn("Repair to a storage node failed",
 					zap.Stringer("Node ID", limits[info.i].GetLimit().StorageNodeId),
 					zap.Error(info.err),
@@ -436,6 +758,10 @@ func (ec *ECRepairer) Repair(ctx context.Context, limits []*pb.AddressedOrderLim
 		successfulHashes[info.i] = info.hash
 		successfulCount++
 
+		if uploaded != nil {
+			uploaded(info.i, limits[info.i].GetLimit().StorageNodeId)
+		}
+
 		if successfulCount >= int32(successfulNeeded) {
 			ec.log.Debug("Number of successful uploads met. Canceling the long tail...",
 				zap.Int32("Successfully repaired", atomic.LoadInt32(&successfulCount)),
@@ -537,6 +863,56 @@ func (ec *ECRepairer) putPiece(ctx, parent context.Context, limit *pb.AddressedO
 	return hash, err
 }
 
+// downloadOrder returns the indexes of the non-nil limits in the order in which
+// the pieces should be downloaded. Nodes in the dial backoff are left out. When preferring low latency, the nodes with
+// the lowest observed latency come first and nodes with unknown latency last.
+// Nodes with an audit reputation below minSourceAuditReputation always come
+// after all other nodes, so that their pieces, which are more likely to be
+// corrupted, are downloaded only when the other nodes don't have enough pieces.
+func (ec *ECRepairer) downloadOrder(ctx context.Context, limits []*pb.AddressedOrderLimit, cachedNodesInfo map[storj.NodeID]overlay.NodeReputation) []int {
+	order := make([]int, 0, len(limits))
+	for i, limit := range limits {
+		if limit != nil && !ec.dialBackoff.Active(limit.GetLimit().StorageNodeId) {
+			order = append(order, i)
+		}
+	}
+
+	if ec.preferLowLatency {
+		latency := func(i int) int64 {
+			latency := cachedNodesInfo[limits[i].GetLimit().StorageNodeId].Latency90
+			if latency <= 0 {
+				return math.MaxInt64
+			}
+			return latency
+		}
+		sort.SliceStable(order, func(i, k int) bool {
+			return latency(order[i]) < latency(order[k])
+		})
+	}
+
+	if ec.minSourceAuditReputation > 0 && ec.reputation != nil {
+		nodeIDs := make([]storj.NodeID, 0, len(order))
+		for _, i := range order {
+			nodeIDs = append(nodeIDs, limits[i].GetLimit().StorageNodeId)
+		}
+
+		scores, err := ec.reputation.AuditScores(ctx, nodeIDs)
+		if err != nil {
+			// the order is only an optimization, so the download goes on without it.
+			ec.log.Warn("failed to get audit scores of repair sources", zap.Error(err))
+		} else {
+			lowReputation := func(i int) bool {
+				return scores[limits[i].GetLimit().StorageNodeId] < ec.minSourceAuditReputation
+			}
+			sort.SliceStable(order, func(i, k int) bool {
+				return !lowReputation(order[i]) && lowReputation(order[k])
+			})
+		}
+	}
+
+	return order
+}
+
 func nonNilCount(limits []*pb.AddressedOrderLimit) int {
 	total := 0
 	for _, limit := range limits {
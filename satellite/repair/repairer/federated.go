@@ -0,0 +1,103 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metabase"
+)
+
+// FederatedSource provides order limits signed by a peer satellite for
+// downloading the pieces of a segment which the peer stores as well.
+//
+// Federated repair is experimental. It's only correct when the peer's copy
+// of the segment was erasure encoded from the same encrypted data with the
+// same redundancy scheme, e.g. when the data is replicated between
+// satellites sharing storage nodes.
+type FederatedSource interface {
+	// PeerID returns the ID of the peer satellite signing the order limits.
+	PeerID() storj.NodeID
+	// CreateGetRepairOrderLimits returns the GET_REPAIR order limits, indexed by
+	// piece number, and the private key for downloading the peer's pieces of the segment.
+	CreateGetRepairOrderLimits(ctx context.Context, segment metabase.Segment) ([]*pb.AddressedOrderLimit, storj.PiecePrivateKey, error)
+}
+
+// AddFederatedSource adds a source of peer signed order limits, which is used
+// when a segment doesn't have enough healthy pieces to be repaired. Sources of
+// peers which aren't trusted by the config are ignored.
+func (repairer *SegmentRepairer) AddFederatedSource(source FederatedSource) {
+	if _, ok := repairer.federatedPeers[source.PeerID()]; !ok {
+		repairer.log.Warn("ignoring federated repair source of untrusted peer", zap.Stringer("Peer ID", source.PeerID()))
+		return
+	}
+	repairer.federatedSources = append(repairer.federatedSources, source)
+}
+
+// parseFederatedPeers parses the comma separated list of trusted peer satellite ids.
+func parseFederatedPeers(log *zap.Logger, list string) map[storj.NodeID]struct{} {
+	peers := make(map[storj.NodeID]struct{})
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		id, err := storj.NodeIDFromString(value)
+		if err != nil {
+			log.Warn("invalid federated repair peer id", zap.String("Peer ID", value), zap.Error(err))
+			continue
+		}
+		peers[id] = struct{}{}
+	}
+	return peers
+}
+
+// federatedOrderLimits returns the order limits of the first peer which can
+// provide enough pieces to reconstruct the segment. Order limits which aren't
+// GET_REPAIR limits signed for the peer are dropped.
+func (repairer *SegmentRepairer) federatedOrderLimits(ctx context.Context, segment metabase.Segment) (_ []*pb.AddressedOrderLimit, _ storj.PiecePrivateKey, peerID storj.NodeID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var errlist errs.Group
+	for _, source := range repairer.federatedSources {
+		limits, privateKey, err := source.CreateGetRepairOrderLimits(ctx, segment)
+		if err != nil {
+			errlist.Add(err)
+			continue
+		}
+		if len(limits) != int(segment.Redundancy.TotalShares) {
+			errlist.Add(errs.New("peer %s returned %d order limits, expected %d", source.PeerID(), len(limits), segment.Redundancy.TotalShares))
+			continue
+		}
+
+		var count int
+		for i, limit := range limits {
+			if limit == nil {
+				continue
+			}
+			if limit.GetLimit() == nil ||
+				limit.GetLimit().SatelliteId != source.PeerID() ||
+				limit.GetLimit().Action != pb.PieceAction_GET_REPAIR {
+				limits[i] = nil
+				continue
+			}
+			count++
+		}
+
+		if count < int(segment.Redundancy.RequiredShares) {
+			errlist.Add(errs.New("peer %s has %d pieces, required %d", source.PeerID(), count, segment.Redundancy.RequiredShares))
+			continue
+		}
+
+		return limits, privateKey, source.PeerID(), nil
+	}
+
+	return nil, storj.PiecePrivateKey{}, storj.NodeID{}, errs.Combine(errs.New("no peer can provide enough pieces"), errlist.Err())
+}
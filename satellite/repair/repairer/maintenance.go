@@ -0,0 +1,122 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// MaintenanceWindow is a daily time window, in UTC, during which the repairer
+// doesn't pull segments from the repair queue. Windows crossing midnight are
+// supported, e.g. "22:00-02:00".
+//
+// Can be used as a flag.
+type MaintenanceWindow struct {
+	// Start and End are offsets from midnight.
+	Start time.Duration
+	End   time.Duration
+}
+
+// Type implements pflag.Value.
+func (MaintenanceWindow) Type() string { return "repairer.MaintenanceWindow" }
+
+// String is required for pflag.Value.
+func (mw *MaintenanceWindow) String() string {
+	return fmt.Sprintf("%s-%s", formatTimeOfDay(mw.Start), formatTimeOfDay(mw.End))
+}
+
+// Set sets the value from a string in the format "hh:mm-hh:mm".
+func (mw *MaintenanceWindow) Set(s string) (err error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return errs.New("invalid maintenance window %q, expected format hh:mm-hh:mm", s)
+	}
+	mw.Start, err = parseTimeOfDay(parts[0])
+	if err != nil {
+		return err
+	}
+	mw.End, err = parseTimeOfDay(parts[1])
+	if err != nil {
+		return err
+	}
+	if mw.Start == mw.End {
+		return errs.New("invalid maintenance window %q, start and end must differ", s)
+	}
+	return nil
+}
+
+// Contains returns whether t is inside of the window.
+func (mw *MaintenanceWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	offset := t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+	if mw.Start < mw.End {
+		return mw.Start <= offset && offset < mw.End
+	}
+	return mw.Start <= offset || offset < mw.End
+}
+
+// MaintenanceWindows is a configuration struct that contains a list of maintenance windows.
+//
+// Can be used as a flag.
+type MaintenanceWindows struct {
+	List []MaintenanceWindow
+}
+
+// Type implements pflag.Value.
+func (MaintenanceWindows) Type() string { return "repairer.MaintenanceWindows" }
+
+// String is required for pflag.Value. It is a comma separated list of MaintenanceWindow configs.
+func (mws *MaintenanceWindows) String() string {
+	var s strings.Builder
+	for i, mw := range mws.List {
+		if i > 0 {
+			s.WriteString(",")
+		}
+		s.WriteString(mw.String())
+	}
+	return s.String()
+}
+
+// Set sets the value from a string in the format "hh:mm-hh:mm,hh:mm-hh:mm,...".
+func (mws *MaintenanceWindows) Set(s string) error {
+	mws.List = nil
+	for _, mwString := range strings.Split(s, ",") {
+		mwString = strings.TrimSpace(mwString)
+		if mwString == "" {
+			continue
+		}
+		var mw MaintenanceWindow
+		if err := mw.Set(mwString); err != nil {
+			return err
+		}
+		mws.List = append(mws.List, mw)
+	}
+	return nil
+}
+
+// Contains returns whether t is inside of any of the windows.
+func (mws *MaintenanceWindows) Contains(t time.Time) bool {
+	for i := range mws.List {
+		if mws.List[i].Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, errs.New("invalid time of day %q, expected format hh:mm", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
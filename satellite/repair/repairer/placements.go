@@ -0,0 +1,52 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// Placements is a configuration struct that contains a list of placements.
+//
+// Can be used as a flag.
+type Placements struct {
+	List []storj.PlacementConstraint
+}
+
+// Type implements pflag.Value.
+func (Placements) Type() string { return "repairer.Placements" }
+
+// String is required for pflag.Value. It is a comma separated list of placements.
+func (placements *Placements) String() string {
+	var s strings.Builder
+	for i, placement := range placements.List {
+		if i > 0 {
+			s.WriteString(",")
+		}
+		s.WriteString(strconv.FormatUint(uint64(placement), 10))
+	}
+	return s.String()
+}
+
+// Set sets the value from a string in the format "1,2,...".
+func (placements *Placements) Set(s string) error {
+	placements.List = nil
+	for _, value := range strings.Split(s, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		placement, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return errs.New("invalid placement %q", value)
+		}
+		placements.List = append(placements.List, storj.PlacementConstraint(placement))
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package repairer
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -13,6 +14,7 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"storj.io/common/memory"
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/storj/satellite/repair/queue"
 	"storj.io/storj/storage"
@@ -26,14 +28,33 @@ var (
 
 // Config contains configurable values for repairer.
 type Config struct {
-	MaxRepair                     int           `help:"maximum segments that can be repaired concurrently" releaseDefault:"5" devDefault:"1" testDefault:"10"`
-	Interval                      time.Duration `help:"how frequently repairer should try and repair more data" releaseDefault:"5m0s" devDefault:"1m0s" testDefault:"$TESTINTERVAL"`
-	Timeout                       time.Duration `help:"time limit for uploading repaired pieces to new storage nodes" default:"5m0s" testDefault:"1m"`
-	DownloadTimeout               time.Duration `help:"time limit for downloading pieces from a node for repair" default:"5m0s" testDefault:"1m"`
-	TotalTimeout                  time.Duration `help:"time limit for an entire repair job, from queue pop to upload completion" default:"45m" testDefault:"10m"`
-	MaxBufferMem                  memory.Size   `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"4.0 MiB"`
-	MaxExcessRateOptimalThreshold float64       `help:"ratio applied to the optimal threshold to calculate the excess of the maximum number of repaired pieces to upload" default:"0.05"`
-	InMemoryRepair                bool          `help:"whether to download pieces for repair in memory (true) or download to disk (false)" default:"false"`
+	MaxRepair                     int                `help:"maximum segments that can be repaired concurrently" releaseDefault:"5" devDefault:"1" testDefault:"10"`
+	Interval                      time.Duration      `help:"how frequently repairer should try and repair more data" releaseDefault:"5m0s" devDefault:"1m0s" testDefault:"$TESTINTERVAL"`
+	IntervalJitter                time.Duration      `help:"maximum random delay added to the start of each repairer cycle to avoid synchronizing with other repairers" default:"0s"`
+	Timeout                       time.Duration      `help:"time limit for uploading repaired pieces to new storage nodes" default:"5m0s" testDefault:"1m"`
+	DownloadTimeout               time.Duration      `help:"time limit for downloading pieces from a node for repair" default:"5m0s" testDefault:"1m"`
+	TotalTimeout                  time.Duration      `help:"time limit for an entire repair job, from queue pop to upload completion" default:"45m" testDefault:"10m"`
+	MaxBufferMem                  memory.Size        `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"4.0 MiB"`
+	MaxExcessRateOptimalThreshold float64            `help:"ratio applied to the optimal threshold to calculate the excess of the maximum number of repaired pieces to upload" default:"0.05"`
+	InMemoryRepair                bool               `help:"whether to download pieces for repair in memory (true) or download to disk (false)" default:"false"`
+	DownloadPreferLowLatency      bool               `help:"whether to download pieces for repair from the nodes with the lowest observed latency first" default:"false"`
+	DownloadMinAuditReputation    float64            `help:"audit reputation score below which nodes are used as sources for repair only when not enough other nodes are available, 0 disables it" default:"0"`
+	DialBackoff                   time.Duration      `help:"minimum time before a node which failed during repair is dialed again as a source or a target, 0 disables it" default:"0s"`
+	StreamingRepair               bool               `help:"whether to reconstruct and upload repaired pieces while they are downloaded, with buffers bounded by max-buffer-mem, instead of downloading whole pieces first" default:"false"`
+	StreamingSparePieces          int                `help:"number of pieces downloaded in addition to the required ones during streaming repair, so that the repair doesn't fail when some of them fail" default:"2"`
+	Checkpointing                 bool               `help:"whether to record the pieces uploaded during repair, so that an interrupted repair is resumed without uploading them again" default:"false"`
+	CheckpointTTL                 time.Duration      `help:"maximum age of a recorded piece to be reused by a resumed repair, it must be shorter than the garbage collection interval" default:"24h"`
+	CheckpointCleanupInterval     time.Duration      `help:"how often recorded pieces older than the checkpoint ttl are deleted" default:"1h"`
+	TrimExcessPieces              bool               `help:"whether to trim pieces of segments which have more pieces than the redundancy total shares down to the success threshold of their placement" default:"false"`
+	MaxRepairAttempts             int                `help:"maximum number of failed repair attempts of a segment before it's moved out of the active repair queue, 0 means unlimited" default:"0"`
+	AllowDegradedRepair           bool               `help:"whether to repair segments to fewer pieces than the success threshold when not enough nodes are available, as long as they end up above the repair threshold" default:"false"`
+	DryRun                        bool               `help:"whether to only log the planned repairs without uploading pieces, updating segments or removing them from the repair queue" default:"false"`
+	MaintenanceWindows            MaintenanceWindows `help:"comma-separated list of daily UTC time windows in the format hh:mm-hh:mm during which the repairer doesn't pull segments from the queue" default:""`
+	TraceStreamIDs                string             `help:"comma-separated list of stream ids whose repairs are traced in detail, for debugging specific segments" default:""`
+	FederatedRepair               bool               `help:"experimental: whether to repair segments without enough healthy pieces using pieces obtained with order limits of trusted peer satellites" default:"false"`
+	FederatedPeers                string             `help:"comma-separated list of peer satellite ids whose order limits are trusted for federated repair" default:""`
+	IncludedPlacements            Placements         `help:"comma-separated list of placements whose segments the repairer is responsible for, segments of all placements are repaired when empty" default:""`
+	SourceStatsWindow             time.Duration      `help:"minimum length of the window over which the bytes downloaded from each node for repair are accounted before they're logged" default:"1h"`
 }
 
 // Service contains the information needed to run the repair service.
@@ -46,8 +67,18 @@ type Service struct {
 	JobLimiter *semaphore.Weighted
 	Loop       *sync2.Cycle
 	repairer   *SegmentRepairer
+	rand       *rand.Rand
 
-	nowFn func() time.Time
+	// includedPlacements are the placements whose segments are selected from
+	// the queue, segments of all placements are selected when it's empty.
+	includedPlacements []storj.PlacementConstraint
+
+	// reportedSkippedHealthy is the number of segments skipped because they
+	// were healthy, as of the last cycle summary.
+	reportedSkippedHealthy int64
+
+	nowFn   func() time.Time
+	sleepFn func(ctx context.Context, duration time.Duration) bool
 }
 
 // NewService creates repairing service.
@@ -59,8 +90,12 @@ func NewService(log *zap.Logger, queue queue.RepairQueue, config *Config, repair
 		JobLimiter: semaphore.NewWeighted(int64(config.MaxRepair)),
 		Loop:       sync2.NewCycle(config.Interval),
 		repairer:   repairer,
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
 
-		nowFn: time.Now,
+		includedPlacements: config.IncludedPlacements.List,
+
+		nowFn:   time.Now,
+		sleepFn: sync2.Sleep,
 	}
 }
 
@@ -88,16 +123,39 @@ func (service *Service) Run(ctx context.Context) (err error) {
 	// Wait for all repairs to complete
 	defer service.WaitForPendingRepairs()
 
-	return service.Loop.Run(ctx, service.processWhileQueueHasItems)
+	return service.Loop.Run(ctx, service.withJitter(service.processWhileQueueHasItems))
+}
+
+// withJitter delays every call of fn by a random duration up to the configured
+// interval jitter, so that multiple repairers don't run their cycles at the same time.
+func (service *Service) withJitter(fn func(ctx context.Context) error) func(ctx context.Context) error {
+	if service.config.IntervalJitter <= 0 {
+		return fn
+	}
+
+	return func(ctx context.Context) error {
+		jitter := time.Duration(service.rand.Int63n(int64(service.config.IntervalJitter)))
+		if !service.sleepFn(ctx, jitter) {
+			return ctx.Err()
+		}
+		return fn(ctx)
+	}
 }
 
 // processWhileQueueHasItems keeps calling process() until the queue is empty or something
 // else goes wrong in fetching from the queue.
 func (service *Service) processWhileQueueHasItems(ctx context.Context) error {
 	for {
+		if service.config.MaintenanceWindows.Contains(service.nowFn()) {
+			service.log.Debug("skipping repair during maintenance window")
+			mon.Counter("repair_maintenance_window_skipped").Inc(1)
+			return nil
+		}
+
 		err := service.process(ctx)
 		if err != nil {
 			if storage.ErrEmptyQueue.Has(err) {
+				service.reportCycle()
 				return nil
 			}
 			service.log.Error("process", zap.Error(Error.Wrap(err)))
@@ -106,6 +164,53 @@ func (service *Service) processWhileQueueHasItems(ctx context.Context) error {
 	}
 }
 
+// reportCycle publishes the summary of a finished repair cycle. Repairs which
+// are still running are accounted in the next cycle.
+func (service *Service) reportCycle() {
+	if service.repairer == nil {
+		return
+	}
+
+	skippedHealthy := service.repairer.SkippedHealthy()
+	skipped := skippedHealthy - service.reportedSkippedHealthy
+	service.reportedSkippedHealthy = skippedHealthy
+
+	mon.IntVal("repair_cycle_skipped_healthy").Observe(skipped)
+	if skipped > 0 {
+		service.log.Info("repair cycle finished", zap.Int64("skippedHealthy", skipped))
+	}
+
+	service.reportSourceStats()
+}
+
+// reportSourceStats logs the bytes downloaded from each node for repair, once
+// the accounting window is at least the configured length.
+func (service *Service) reportSourceStats() {
+	sourceStats := service.repairer.ec.SourceStats()
+
+	now := service.nowFn()
+	if now.Sub(sourceStats.WindowFrom()) < service.config.SourceStatsWindow {
+		return
+	}
+
+	window := sourceStats.TakeWindow(now)
+	mon.IntVal("repair_source_window_bytes").Observe(window.Total())
+	mon.IntVal("repair_source_window_nodes").Observe(int64(len(window.Downloaded)))
+
+	for nodeID, bytes := range window.Downloaded {
+		service.log.Debug("repair source bytes",
+			zap.Stringer("Node ID", nodeID),
+			zap.Int64("bytes", bytes),
+			zap.Time("from", window.From),
+			zap.Time("to", window.To))
+	}
+	service.log.Info("repair source window",
+		zap.Int64("bytes", window.Total()),
+		zap.Int("nodes", len(window.Downloaded)),
+		zap.Time("from", window.From),
+		zap.Time("to", window.To))
+}
+
 // process picks items from repair queue and spawns a repair worker.
 func (service *Service) process(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -127,7 +232,7 @@ func (service *Service) process(ctx context.Context) (err error) {
 	// return from service.Run when queue fetch fails.
 	ctx, cancel := context.WithTimeout(ctx, service.config.TotalTimeout)
 
-	seg, err := service.queue.Select(ctx)
+	seg, err := service.queue.Select(ctx, service.includedPlacements...)
 	if err != nil {
 		service.JobLimiter.Release(1)
 		cancel()
@@ -157,6 +262,10 @@ func (service *Service) worker(ctx context.Context, seg *queue.InjuredSegment) (
 	service.log.Debug("Limiter running repair on segment")
 	// note that shouldDelete is used even in the case where err is not null
 	shouldDelete, err := service.repairer.Repair(ctx, seg)
+	if service.config.DryRun {
+		// in dry-run mode segments stay in the repair queue as they are.
+		return Error.Wrap(err)
+	}
 	if shouldDelete {
 		if err != nil {
 			service.log.Error("unexpected error repairing segment!", zap.Error(err))
@@ -170,6 +279,17 @@ func (service *Service) worker(ctx context.Context, seg *queue.InjuredSegment) (
 			}
 		}
 	}
+	if !shouldDelete && service.config.MaxRepairAttempts > 0 && seg.Attempts >= service.config.MaxRepairAttempts {
+		service.log.Warn("segment exhausted its repair attempts, removing from the active repair queue",
+			zap.Stringer("StreamID", seg.StreamID),
+			zap.Uint64("Position", seg.Position.Encode()),
+			zap.Int("attempts", seg.Attempts),
+		)
+		mon.Meter("repair_attempts_exhausted").Mark(1)
+		if exhaustErr := service.queue.MarkExhausted(ctx, seg); exhaustErr != nil {
+			err = errs.Combine(err, Error.New("failed to mark segment as exhausted: %v", exhaustErr))
+		}
+	}
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -0,0 +1,150 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/repair/queue"
+	"storj.io/storj/storage"
+)
+
+func TestIntervalJitter(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	const cycles = 5
+	config := &Config{
+		MaxRepair:      1,
+		Interval:       time.Hour,
+		IntervalJitter: time.Minute,
+	}
+
+	var slept []time.Duration
+	service := NewService(zaptest.NewLogger(t), nil, config, nil)
+	service.rand = rand.New(rand.NewSource(1))
+	service.sleepFn = func(ctx context.Context, duration time.Duration) bool {
+		slept = append(slept, duration)
+		return ctx.Err() == nil
+	}
+
+	// the same source is used to compute the jitter the service should apply
+	expected := rand.New(rand.NewSource(1))
+	jitters := make([]time.Duration, cycles)
+	for i := range jitters {
+		jitters[i] = time.Duration(expected.Int63n(int64(config.IntervalJitter)))
+		require.True(t, jitters[i] >= 0 && jitters[i] < config.IntervalJitter)
+	}
+	require.NotEqual(t, jitters[0], jitters[1], "successive jitters should vary")
+
+	var calls int
+	jittered := service.withJitter(func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	for i := 0; i < cycles; i++ {
+		require.NoError(t, jittered(ctx))
+	}
+	require.Equal(t, cycles, calls)
+	require.Equal(t, jitters, slept)
+
+	// a canceled sleep doesn't run the cycle.
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, jittered(canceledCtx), context.Canceled)
+	require.Equal(t, cycles, calls)
+
+	// without jitter the cycle runs immediately.
+	config.IntervalJitter = 0
+	slept = nil
+	require.NoError(t, service.withJitter(func(ctx context.Context) error { return nil })(ctx))
+	require.Empty(t, slept)
+}
+
+// selectCountingQueue is a repair queue which is always empty and counts how often it was selected from.
+type selectCountingQueue struct {
+	queue.RepairQueue
+	selects    int
+	placements []storj.PlacementConstraint
+}
+
+func (q *selectCountingQueue) Select(ctx context.Context, includedPlacements ...storj.PlacementConstraint) (*queue.InjuredSegment, error) {
+	q.selects++
+	q.placements = includedPlacements
+	return nil, storage.ErrEmptyQueue.New("")
+}
+
+func TestMaintenanceWindows(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	now := time.Date(2022, 6, 20, 12, 30, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		windows string
+		skipped bool
+	}{
+		{windows: "", skipped: false},
+		{windows: "12:00-13:00", skipped: true},
+		{windows: "01:00-02:00,12:30-12:31", skipped: true},
+		{windows: "22:00-13:00", skipped: true},
+		{windows: "13:00-14:00", skipped: false},
+		{windows: "22:00-12:30", skipped: false},
+	} {
+		config := &Config{MaxRepair: 1, Interval: time.Hour}
+		require.NoError(t, config.MaintenanceWindows.Set(tt.windows))
+		require.Equal(t, tt.windows, config.MaintenanceWindows.String())
+
+		repairQueue := &selectCountingQueue{}
+		service := NewService(zaptest.NewLogger(t), repairQueue, config, nil)
+		service.nowFn = func() time.Time { return now }
+
+		require.NoError(t, service.processWhileQueueHasItems(ctx))
+		if tt.skipped {
+			require.Zero(t, repairQueue.selects, tt.windows)
+		} else {
+			require.Equal(t, 1, repairQueue.selects, tt.windows)
+		}
+	}
+
+	var windows MaintenanceWindows
+	for _, invalid := range []string{"12:00", "12:00-12:00", "25:00-26:00", "noon-midnight"} {
+		require.Error(t, windows.Set(invalid), invalid)
+	}
+}
+
+func TestIncludedPlacements(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	for _, tt := range []struct {
+		placements string
+		expected   []storj.PlacementConstraint
+	}{
+		{placements: "", expected: nil},
+		{placements: "1", expected: []storj.PlacementConstraint{storj.EU}},
+		{placements: "1, 3", expected: []storj.PlacementConstraint{storj.EU, storj.US}},
+	} {
+		config := &Config{MaxRepair: 1, Interval: time.Hour}
+		require.NoError(t, config.IncludedPlacements.Set(tt.placements))
+
+		repairQueue := &selectCountingQueue{}
+		service := NewService(zaptest.NewLogger(t), repairQueue, config, nil)
+
+		require.NoError(t, service.processWhileQueueHasItems(ctx))
+		require.Equal(t, 1, repairQueue.selects, tt.placements)
+		require.Equal(t, tt.expected, repairQueue.placements, tt.placements)
+	}
+
+	var placements Placements
+	for _, invalid := range []string{"invalid", "1,invalid", "-1", "65536"} {
+		require.Error(t, placements.Set(invalid), invalid)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -17,6 +19,7 @@ import (
 	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/orders"
@@ -67,6 +70,12 @@ type SegmentRepairer struct {
 	timeout        time.Duration
 	reporter       audit.Reporter
 
+	// checkpoints records the pieces uploaded during repair, so that an
+	// interrupted repair can be resumed. It's nil when checkpointing is disabled.
+	checkpoints queue.RepairCheckpoints
+	// checkpointTTL is the maximum age of a checkpointed piece to be reused.
+	checkpointTTL time.Duration
+
 	// multiplierOptimalThreshold is the value that multiplied by the optimal
 	// threshold results in the maximum limit of number of nodes to upload
 	// repaired pieces
@@ -75,14 +84,47 @@ type SegmentRepairer struct {
 	// repairOverrides is the set of values configured by the checker to override the repair threshold for various RS schemes.
 	repairOverrides checker.RepairOverridesMap
 
+	// successOverrides is the set of values configured by the checker to override the success threshold for various placements.
+	successOverrides checker.PlacementSuccessOverridesMap
+
+	// excludedCountries is the set of countries configured by the checker to be excluded from the health of segments in various placements.
+	excludedCountries checker.PlacementExcludedCountriesMap
+
+	// trimExcessPieces indicates whether pieces of a segment in excess of
+	// the redundancy total shares should be trimmed down to the success threshold.
+	trimExcessPieces bool
+
+	// allowDegradedRepair indicates whether segments may be repaired to fewer
+	// pieces than the success threshold when there aren't enough nodes available.
+	allowDegradedRepair bool
+
+	// dryRun indicates whether repairs should only be planned and logged
+	// without uploading pieces or updating segments.
+	dryRun bool
+
+	// tracedStreamIDs is the set of streams whose repairs are traced in detail.
+	tracedStreamIDs map[uuid.UUID]struct{}
+
+	// federatedPeers is the set of peer satellites whose order limits are trusted
+	// for federated repair. It's empty when federated repair is disabled.
+	federatedPeers   map[storj.NodeID]struct{}
+	federatedSources []FederatedSource
+
+	// skippedHealthy counts segments which were skipped because they were
+	// healthy again by the time they were picked up for repair.
+	skippedHealthy int64
+
 	nowFn                            func() time.Time
 	OnTestingCheckSegmentAlteredHook func()
 	OnTestingPiecesReportHook        func(pieces audit.Pieces)
+	OnTestingDegradedRepairHook      func(segment metabase.Segment, healthyAfterRepair int)
+	OnTestingPieceUploadedHook       func(piece metabase.Piece)
+	OnTestingRepairTraceHook         func(trace *RepairTrace)
 }
 
 // NewSegmentRepairer creates a new instance of SegmentRepairer.
 //
-// excessPercentageOptimalThreshold is the percentage to apply over the optimal
+// config.MaxExcessRateOptimalThreshold is the percentage to apply over the optimal
 // threshould to determine the maximum limit of nodes to upload repaired pieces,
 // when negative, 0 is applied.
 func NewSegmentRepairer(
@@ -92,14 +134,27 @@ func NewSegmentRepairer(
 	overlay *overlay.Service,
 	reporter audit.Reporter,
 	ecRepairer *ECRepairer,
+	checkpoints queue.RepairCheckpoints,
 	repairOverrides checker.RepairOverrides,
-	timeout time.Duration, excessOptimalThreshold float64,
+	successOverrides checker.PlacementSuccessOverrides,
+	excludedCountries checker.PlacementExcludedCountries,
+	config *Config,
 ) *SegmentRepairer {
 
+	excessOptimalThreshold := config.MaxExcessRateOptimalThreshold
 	if excessOptimalThreshold < 0 {
 		excessOptimalThreshold = 0
 	}
 
+	if !config.Checkpointing {
+		checkpoints = nil
+	}
+
+	var federatedPeers map[storj.NodeID]struct{}
+	if config.FederatedRepair {
+		federatedPeers = parseFederatedPeers(log, config.FederatedPeers)
+	}
+
 	return &SegmentRepairer{
 		log:                        log,
 		statsCollector:             newStatsCollector(),
@@ -107,10 +162,19 @@ func NewSegmentRepairer(
 		orders:                     orders,
 		overlay:                    overlay,
 		ec:                         ecRepairer,
-		timeout:                    timeout,
+		timeout:                    config.Timeout,
 		multiplierOptimalThreshold: 1 + excessOptimalThreshold,
 		repairOverrides:            repairOverrides.GetMap(),
+		successOverrides:           successOverrides.GetMap(),
+		excludedCountries:          excludedCountries.GetMap(),
 		reporter:                   reporter,
+		checkpoints:                checkpoints,
+		checkpointTTL:              config.CheckpointTTL,
+		trimExcessPieces:           config.TrimExcessPieces,
+		allowDegradedRepair:        config.AllowDegradedRepair,
+		dryRun:                     config.DryRun,
+		tracedStreamIDs:            parseTracedStreamIDs(log, config.TraceStreamIDs),
+		federatedPeers:             federatedPeers,
 
 		nowFn: time.Now,
 	}
@@ -122,6 +186,21 @@ func NewSegmentRepairer(
 func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue.InjuredSegment) (shouldDelete bool, err error) {
 	defer mon.Task()(&ctx, queueSegment.StreamID.String(), queueSegment.Position.Encode())(&err)
 
+	var trace *RepairTrace
+	if _, ok := repairer.tracedStreamIDs[queueSegment.StreamID]; ok {
+		trace = &RepairTrace{
+			StreamID: queueSegment.StreamID,
+			Position: queueSegment.Position,
+			Started:  time.Now(),
+		}
+		defer func() {
+			trace.finish(repairer.log, err)
+			if repairer.OnTestingRepairTraceHook != nil {
+				repairer.OnTestingRepairTraceHook(trace)
+			}
+		}()
+	}
+
 	segment, err := repairer.metabase.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
 		StreamID: queueSegment.StreamID,
 		Position: queueSegment.Position,
@@ -131,6 +210,7 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 			mon.Meter("repair_unnecessary").Mark(1)            //mon:locked
 			mon.Meter("segment_deleted_before_repair").Mark(1) //mon:locked
 			repairer.log.Debug("segment was deleted")
+			repairer.deleteCheckpoint(ctx, queueSegment.StreamID, queueSegment.Position)
 			return true, nil
 		}
 		return false, metainfoGetError.Wrap(err)
@@ -151,6 +231,10 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		return true, invalidRepairError.New("invalid redundancy strategy: %w", err)
 	}
 
+	// successThreshold is the number of healthy pieces the segment should have
+	// after repair, it may be higher than the optimal shares for some placements.
+	successThreshold := repairer.successOverrides.GetSuccessThreshold(segment.Placement, segment.Redundancy)
+
 	stats := repairer.getStatsByRS(&pb.RedundancyScheme{
 		Type:             pb.RedundancyScheme_SchemeType(segment.Redundancy.Algorithm),
 		ErasureShareSize: segment.Redundancy.ShareSize,
@@ -172,9 +256,47 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		return false, overlayQueryError.New("error identifying missing pieces: %w", err)
 	}
 
+	if repairer.trimExcessPieces && !repairer.dryRun && len(pieces) > int(segment.Redundancy.TotalShares) {
+		trimmedPieces, err := repairer.trimPieces(ctx, segment, missingPieces, successThreshold)
+		if err != nil {
+			return false, err
+		}
+
+		err = repairer.metabase.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+			StreamID: segment.StreamID,
+			Position: segment.Position,
+
+			OldPieces:     segment.Pieces,
+			NewRedundancy: segment.Redundancy,
+			NewPieces:     trimmedPieces,
+		})
+		if err != nil {
+			return false, metainfoPutError.Wrap(err)
+		}
+
+		numTrimmed := len(segment.Pieces) - len(trimmedPieces)
+		mon.Meter("repair_excess_pieces_trimmed").Mark(numTrimmed)
+		stats.repairExcessPiecesTrimmed.Mark(numTrimmed)
+		repairer.log.Info("trimmed excess pieces of segment",
+			zap.Stringer("StreamID", segment.StreamID),
+			zap.Uint64("Position", segment.Position.Encode()),
+			zap.Int("piecesBefore", len(segment.Pieces)),
+			zap.Int("piecesTrimmed", numTrimmed),
+		)
+
+		segment.Pieces = trimmedPieces
+		pieces = trimmedPieces
+		missingPieces, err = repairer.overlay.GetMissingPieces(ctx, pieces)
+		if err != nil {
+			return false, overlayQueryError.New("error identifying missing pieces: %w", err)
+		}
+	}
+
 	numHealthy := len(pieces) - len(missingPieces)
+	// segments without enough healthy pieces can only be repaired with pieces of a peer satellite.
+	federated := numHealthy < int(segment.Redundancy.RequiredShares) && len(repairer.federatedSources) > 0
 	// irreparable piece
-	if numHealthy < int(segment.Redundancy.RequiredShares) {
+	if numHealthy < int(segment.Redundancy.RequiredShares) && !federated {
 		mon.Counter("repairer_segments_below_min_req").Inc(1) //mon:locked
 		stats.repairerSegmentsBelowMinReq.Inc(1)
 		mon.Meter("repair_nodes_unavailable").Mark(1) //mon:locked
@@ -189,9 +311,9 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		return false, nil
 	}
 
-	piecesInExcludedCountries, err := repairer.overlay.GetReliablePiecesInExcludedCountries(ctx, pieces)
+	piecesInExcludedCountries, err := repairer.getPiecesInExcludedCountries(ctx, segment, pieces)
 	if err != nil {
-		return false, overlayQueryError.New("error identifying pieces in excluded countries: %w", err)
+		return false, err
 	}
 
 	numHealthyInExcludedCountries := len(piecesInExcludedCountries)
@@ -217,6 +339,8 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 	if numHealthy-numHealthyInExcludedCountries > int(repairThreshold) {
 		mon.Meter("repair_unnecessary").Mark(1) //mon:locked
 		stats.repairUnnecessary.Mark(1)
+		// the segment recovered on its own since it was queued, e.g. nodes came back online.
+		atomic.AddInt64(&repairer.skippedHealthy, 1)
 		repairer.log.Debug("segment above repair threshold", zap.Int("numHealthy", numHealthy), zap.Int32("repairThreshold", repairThreshold))
 		return true, nil
 	}
@@ -241,62 +365,160 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		}
 	}
 
-	// Create the order limits for the GET_REPAIR action
-	getOrderLimits, getPrivateKey, cachedNodesInfo, err := repairer.orders.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, healthyPieces)
-	if err != nil {
-		if orders.ErrDownloadFailedNotEnoughPieces.Has(err) {
+	var getOrderLimits []*pb.AddressedOrderLimit
+	var getPrivateKey storj.PiecePrivateKey
+	var cachedNodesInfo map[storj.NodeID]overlay.NodeReputation
+	if federated {
+		var peerID storj.NodeID
+		getOrderLimits, getPrivateKey, peerID, err = repairer.federatedOrderLimits(ctx, segment)
+		if err != nil {
 			mon.Counter("repairer_segments_below_min_req").Inc(1) //mon:locked
 			stats.repairerSegmentsBelowMinReq.Inc(1)
-			mon.Meter("repair_nodes_unavailable").Mark(1) //mon:locked
-			stats.repairerNodesUnavailable.Mark(1)
 
 			repairer.log.Warn("irreparable segment",
 				zap.String("StreamID", queueSegment.StreamID.String()),
 				zap.Uint64("Position", queueSegment.Position.Encode()),
+				zap.Int("piecesAvailable", numHealthy),
+				zap.Int16("piecesRequired", segment.Redundancy.RequiredShares),
 				zap.Error(err),
 			)
+			return false, nil
+		}
+
+		mon.Meter("repair_federated").Mark(1)
+		repairer.log.Info("repairing segment with pieces of a peer satellite",
+			zap.Stringer("StreamID", segment.StreamID),
+			zap.Uint64("Position", segment.Position.Encode()),
+			zap.Stringer("Peer ID", peerID),
+		)
+	} else {
+		// Create the order limits for the GET_REPAIR action
+		getOrderLimits, getPrivateKey, cachedNodesInfo, err = repairer.orders.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, healthyPieces)
+		if err != nil {
+			if orders.ErrDownloadFailedNotEnoughPieces.Has(err) {
+				mon.Counter("repairer_segments_below_min_req").Inc(1) //mon:locked
+				stats.repairerSegmentsBelowMinReq.Inc(1)
+				mon.Meter("repair_nodes_unavailable").Mark(1) //mon:locked
+				stats.repairerNodesUnavailable.Mark(1)
+
+				repairer.log.Warn("irreparable segment",
+					zap.String("StreamID", queueSegment.StreamID.String()),
+					zap.Uint64("Position", queueSegment.Position.Encode()),
+					zap.Error(err),
+				)
+			}
+			return false, orderLimitFailureError.New("could not create GET_REPAIR order limits: %w", err)
 		}
-		return false, orderLimitFailureError.New("could not create GET_REPAIR order limits: %w", err)
+
+		// Double check for healthy pieces which became unhealthy inside CreateGetRepairOrderLimits
+		// Remove them from healthyPieces and add them to unhealthyPieces
+		var newHealthyPieces metabase.Pieces
+		for _, piece := range healthyPieces {
+			if getOrderLimits[piece.Number] == nil {
+				unhealthyPieces = append(unhealthyPieces, piece)
+			} else {
+				newHealthyPieces = append(newHealthyPieces, piece)
+			}
+		}
+		healthyPieces = newHealthyPieces
 	}
 
-	// Double check for healthy pieces which became unhealthy inside CreateGetRepairOrderLimits
-	// Remove them from healthyPieces and add them to unhealthyPieces
-	var newHealthyPieces metabase.Pieces
-	for _, piece := range healthyPieces {
-		if getOrderLimits[piece.Number] == nil {
-			unhealthyPieces = append(unhealthyPieces, piece)
-		} else {
-			newHealthyPieces = append(newHealthyPieces, piece)
+	// pieces uploaded by a previous repair of the segment, which was interrupted.
+	var resumedPieces metabase.Pieces
+	if repairer.checkpoints != nil {
+		resumedPieces, err = repairer.resumablePieces(ctx, segment, healthyPieces)
+		if err != nil {
+			// the repair can proceed without the checkpoint, it only uploads more pieces.
+			repairer.log.Warn("failed to load repair checkpoint",
+				zap.Stringer("StreamID", segment.StreamID),
+				zap.Uint64("Position", segment.Position.Encode()),
+				zap.Error(err),
+			)
+			resumedPieces = nil
+		}
+		for _, piece := range resumedPieces {
+			excludeNodeIDs = append(excludeNodeIDs, piece.StorageNode)
 		}
+		mon.Meter("repair_resumed_pieces").Mark(len(resumedPieces))
 	}
-	healthyPieces = newHealthyPieces
 
 	var requestCount int
 	var minSuccessfulNeeded int
 	{
-		totalNeeded := math.Ceil(float64(redundancy.OptimalThreshold()) * repairer.multiplierOptimalThreshold)
-		requestCount = int(totalNeeded) - len(healthyPieces) + numHealthyInExcludedCountries
-		minSuccessfulNeeded = redundancy.OptimalThreshold() - len(healthyPieces) + numHealthyInExcludedCountries
+		totalNeeded := math.Ceil(float64(successThreshold) * repairer.multiplierOptimalThreshold)
+		requestCount = int(totalNeeded) - len(healthyPieces) - len(resumedPieces) + numHealthyInExcludedCountries
+		minSuccessfulNeeded = successThreshold - len(healthyPieces) - len(resumedPieces) + numHealthyInExcludedCountries
 	}
 
+	if len(resumedPieces) > 0 && minSuccessfulNeeded <= 0 && !repairer.dryRun {
+		// all the needed pieces were uploaded before the repair was interrupted.
+		return repairer.finishResumedRepair(ctx, segment, stats, unhealthyPieces, resumedPieces)
+	}
+
+	// nodes which failed recently aren't used as targets.
+	excludeNodeIDs = append(excludeNodeIDs, repairer.ec.DialBackoff().Nodes()...)
+
 	// Request Overlay for n-h new storage nodes
 	request := overlay.FindStorageNodesRequest{
 		RequestedCount: requestCount,
 		ExcludedIDs:    excludeNodeIDs,
 	}
 	newNodes, err := repairer.overlay.FindStorageNodesForUpload(ctx, request)
+	degraded := false
 	if err != nil {
-		return false, overlayQueryError.Wrap(err)
+		// when degraded repair is allowed, the segment is repaired to the best achievable
+		// number of pieces, as long as it ends up above the repair threshold.
+		reachable := len(healthyPieces) - numHealthyInExcludedCountries + len(newNodes)
+		if !repairer.allowDegradedRepair || !overlay.ErrNotEnoughNodes.Has(err) || reachable <= int(repairThreshold) {
+			return false, overlayQueryError.Wrap(err)
+		}
+
+		repairer.log.Info("not enough nodes available, repairing segment below success threshold",
+			zap.Stringer("StreamID", segment.StreamID),
+			zap.Uint64("Position", segment.Position.Encode()),
+			zap.Int("requestedNodes", requestCount),
+			zap.Int("availableNodes", len(newNodes)),
+		)
+		degraded = true
+		if minSuccessfulNeeded > len(newNodes) {
+			minSuccessfulNeeded = len(newNodes)
+		}
 	}
 
 	// Create the order limits for the PUT_REPAIR action
-	putLimits, putPrivateKey, err := repairer.orders.CreatePutRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, getOrderLimits, newNodes, repairer.multiplierOptimalThreshold, numHealthyInExcludedCountries)
+	// order limits are created up to the success threshold instead of the optimal shares
+	targetSegment := segment
+	targetSegment.Redundancy.OptimalShares = int16(successThreshold)
+
+	// the piece numbers of the resumed pieces are reserved,
+	// so they aren't assigned to the new nodes.
+	reservedLimits := getOrderLimits
+	if federated {
+		// the peer's limits don't correspond to the local pieces,
+		// so only the numbers of the local healthy pieces are reserved.
+		reservedLimits = make([]*pb.AddressedOrderLimit, len(getOrderLimits))
+		for _, piece := range healthyPieces {
+			reservedLimits[piece.Number] = &pb.AddressedOrderLimit{}
+		}
+	} else if len(resumedPieces) > 0 {
+		reservedLimits = make([]*pb.AddressedOrderLimit, len(getOrderLimits))
+		copy(reservedLimits, getOrderLimits)
+	}
+	for _, piece := range resumedPieces {
+		reservedLimits[piece.Number] = &pb.AddressedOrderLimit{}
+	}
+
+	putLimits, putPrivateKey, err := repairer.orders.CreatePutRepairOrderLimits(ctx, metabase.BucketLocation{}, targetSegment, reservedLimits, newNodes, repairer.multiplierOptimalThreshold, numHealthyInExcludedCountries)
 	if err != nil {
 		return false, orderLimitFailureError.New("could not create PUT_REPAIR order limits: %w", err)
 	}
 
 	// Download the segment using just the healthy pieces
+	downloadStart := time.Now()
 	segmentReader, piecesReport, err := repairer.ec.Get(ctx, getOrderLimits, cachedNodesInfo, getPrivateKey, redundancy, int64(segment.EncryptedSize))
+	if trace != nil {
+		trace.recordDownloads(piecesReport, time.Since(downloadStart))
+	}
 
 	// ensure we get values, even if only zero values, so that redash can have an alert based on this
 	mon.Meter("repair_too_many_nodes_failed").Mark(0) //mon:locked
@@ -341,11 +563,20 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 			mon.Meter("repair_too_many_nodes_failed").Mark(1) //mon:locked
 			stats.repairTooManyNodesFailed.Mark(1)
 
+			// distinguish segments which were just short of being reconstructed
+			// from those which had no chance.
+			piecesShort := piecesReport.MinRequired - len(piecesReport.Successful)
+			mon.IntVal("repair_pieces_short").Observe(int64(piecesShort))
+			if piecesShort == 1 {
+				mon.Meter("repair_one_piece_short").Mark(1)
+			}
+
 			repairer.log.Warn("irreparable segment",
 				zap.String("StreamID", queueSegment.StreamID.String()),
 				zap.Uint64("Position", queueSegment.Position.Encode()),
 				zap.Int32("piecesAvailable", irreparableErr.piecesAvailable),
 				zap.Int32("piecesRequired", irreparableErr.piecesRequired),
+				zap.Int("piecesShort", piecesShort),
 				zap.Error(errs.Combine(irreparableErr.errlist...)),
 			)
 			return false, nil
@@ -355,6 +586,29 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 	}
 	defer func() { err = errs.Combine(err, segmentReader.Close()) }()
 
+	if repairer.dryRun {
+		// reconstruct the segment to verify it could be repaired, but don't
+		// report audits, upload pieces nor update the segment.
+		if _, err := io.Copy(io.Discard, segmentReader); err != nil {
+			return false, repairReconstructError.New("segment could not be reconstructed: %w", err)
+		}
+
+		plannedNodes := make([]string, 0, len(newNodes))
+		for _, node := range newNodes {
+			plannedNodes = append(plannedNodes, node.ID.String())
+		}
+
+		mon.Meter("repair_dry_run").Mark(1)
+		repairer.log.Info("dry run: segment would be repaired",
+			zap.Stringer("StreamID", segment.StreamID),
+			zap.Uint64("Position", segment.Position.Encode()),
+			zap.Int("healthyPieces", len(healthyPieces)),
+			zap.Int("minSuccessfulNeeded", minSuccessfulNeeded),
+			zap.Strings("plannedNodes", plannedNodes),
+		)
+		return false, nil
+	}
+
 	// only report audit result when segment can be successfully downloaded
 	cachedNodesReputation := make(map[storj.NodeID]overlay.ReputationStatus, len(cachedNodesInfo))
 	for id, info := range cachedNodesInfo {
@@ -377,15 +631,39 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 	for _, piece := range piecesReport.Unknown {
 		report.Unknown = append(report.Unknown, piece.StorageNode)
 	}
-	_, reportErr := repairer.reporter.RecordAudits(ctx, report)
-	if reportErr != nil {
-		// failed updates should not affect repair, therefore we will not return the error
-		repairer.log.Debug("failed to record audit", zap.Error(reportErr))
+	// the pieces downloaded with the peer's limits aren't audits of this satellite.
+	if !federated {
+		_, reportErr := repairer.reporter.RecordAudits(ctx, report)
+		if reportErr != nil {
+			// failed updates should not affect repair, therefore we will not return the error
+			repairer.log.Debug("failed to record audit", zap.Error(reportErr))
+		}
 	}
 
 	// Upload the repaired pieces
-	successfulNodes, _, err := repairer.ec.Repair(ctx, putLimits, putPrivateKey, redundancy, segmentReader, repairer.timeout, minSuccessfulNeeded)
+	uploaded := func(pieceNum int, nodeID storj.NodeID) {
+		piece := metabase.Piece{Number: uint16(pieceNum), StorageNode: nodeID}
+		if repairer.checkpoints != nil {
+			if err := repairer.checkpoints.AddPiece(ctx, segment.StreamID, segment.Position, piece); err != nil {
+				repairer.log.Warn("failed to checkpoint repaired piece",
+					zap.Stringer("StreamID", segment.StreamID),
+					zap.Uint64("Position", segment.Position.Encode()),
+					zap.Error(err),
+				)
+			}
+		}
+		if repairer.OnTestingPieceUploadedHook != nil {
+			repairer.OnTestingPieceUploadedHook(piece)
+		}
+	}
+
+	repairStart := time.Now()
+	successfulNodes, _, err := repairer.ec.Repair(ctx, putLimits, putPrivateKey, redundancy, segmentReader, repairer.timeout, minSuccessfulNeeded, uploaded)
+	if trace != nil {
+		trace.recordUploads(putLimits, successfulNodes, time.Since(repairStart))
+	}
 	if err != nil {
+		repairer.reportStreamedFailures(ctx, segment, segmentReader, cachedNodesReputation)
 		return false, repairPutError.Wrap(err)
 	}
 
@@ -407,6 +685,10 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		repairedPieces = append(repairedPieces, piece)
 		repairedMap[uint16(i)] = true
 	}
+	for _, piece := range resumedPieces {
+		repairedPieces = append(repairedPieces, piece)
+		repairedMap[piece.Number] = true
+	}
 
 	mon.Meter("repair_bytes_uploaded").Mark64(bytesRepaired) //mon:locked
 
@@ -420,7 +702,7 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		// not as healthy as we want it to be.
 		mon.Meter("repair_failed").Mark(1) //mon:locked
 		stats.repairFailed.Mark(1)
-	case healthyAfterRepair < int(segment.Redundancy.OptimalShares):
+	case healthyAfterRepair < successThreshold:
 		mon.Meter("repair_partial").Mark(1) //mon:locked
 		stats.repairPartial.Mark(1)
 	default:
@@ -428,6 +710,20 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		stats.repairSuccess.Mark(1)
 	}
 
+	if degraded && healthyAfterRepair < successThreshold {
+		mon.Meter("repair_degraded").Mark(1)
+		stats.repairDegraded.Mark(1)
+		repairer.log.Warn("segment degraded-repaired",
+			zap.Stringer("StreamID", segment.StreamID),
+			zap.Uint64("Position", segment.Position.Encode()),
+			zap.Int("healthyAfterRepair", healthyAfterRepair),
+			zap.Int("successThreshold", successThreshold),
+		)
+		if repairer.OnTestingDegradedRepairHook != nil {
+			repairer.OnTestingDegradedRepairHook(segment, healthyAfterRepair)
+		}
+	}
+
 	healthyRatioAfterRepair := 0.0
 	if segment.Redundancy.TotalShares != 0 {
 		healthyRatioAfterRepair = float64(healthyAfterRepair) / float64(segment.Redundancy.TotalShares)
@@ -437,7 +733,7 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 	stats.healthyRatioAfterRepair.Observe(healthyRatioAfterRepair)
 
 	var toRemove metabase.Pieces
-	if healthyAfterRepair >= int(segment.Redundancy.OptimalShares) {
+	if healthyAfterRepair >= successThreshold {
 		// if full repair, remove all unhealthy pieces
 		toRemove = unhealthyPieces
 	} else {
@@ -451,8 +747,11 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		}
 	}
 
-	// add pieces that failed piece hashes verification to the removal list
-	toRemove = append(toRemove, piecesReport.Failed...)
+	// add pieces that failed piece hashes verification to the removal list,
+	// pieces downloaded with the peer's limits aren't part of the segment.
+	if !federated {
+		toRemove = append(toRemove, piecesReport.Failed...)
+	}
 
 	newPieces, err := segment.Pieces.Update(repairedPieces, toRemove)
 	if err != nil {
@@ -473,6 +772,8 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		return false, metainfoPutError.Wrap(err)
 	}
 
+	repairer.deleteCheckpoint(ctx, segment.StreamID, segment.Position)
+
 	repairedAt := time.Time{}
 	if segment.RepairedAt != nil {
 		repairedAt = *segment.RepairedAt
@@ -497,6 +798,222 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 	return true, nil
 }
 
+// reportStreamedFailures reports the pieces of a streamed segment which failed
+// the verification while it was read as failed audits, and removes them from
+// the segment, so that they aren't used again when the repair is retried.
+func (repairer *SegmentRepairer) reportStreamedFailures(ctx context.Context, segment metabase.Segment, segmentReader io.Reader, nodesReputation map[storj.NodeID]overlay.ReputationStatus) {
+	defer mon.Task()(&ctx)(nil)
+
+	streamed, ok := segmentReader.(*streamedSegment)
+	if !ok {
+		return
+	}
+	failed := streamed.FailedPieces()
+	if len(failed) == 0 {
+		return
+	}
+
+	report := audit.Report{
+		NodesReputation: nodesReputation,
+	}
+	for _, piece := range failed {
+		report.Fails = append(report.Fails, piece.StorageNode)
+	}
+	if _, err := repairer.reporter.RecordAudits(ctx, report); err != nil {
+		repairer.log.Debug("failed to record audit", zap.Error(err))
+	}
+
+	newPieces, err := segment.Pieces.Update(nil, failed)
+	if err != nil {
+		repairer.log.Debug("failed to remove pieces failing verification", zap.Error(err))
+		return
+	}
+
+	err = repairer.metabase.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+		StreamID: segment.StreamID,
+		Position: segment.Position,
+
+		OldPieces:     segment.Pieces,
+		NewRedundancy: segment.Redundancy,
+		NewPieces:     newPieces,
+
+		NewRepairedAt: time.Now(),
+	})
+	if err != nil {
+		repairer.log.Debug("failed to remove pieces failing verification", zap.Error(err))
+	}
+}
+
+// resumablePieces returns the pieces recorded in the checkpoint of the segment,
+// which can be kept without uploading them again. Pieces older than the
+// checkpoint ttl may have been garbage collected and are skipped, so are pieces
+// on nodes which aren't reliable anymore or already hold a piece of the
+// segment, as well as pieces which numbers are used by the healthy pieces.
+func (repairer *SegmentRepairer) resumablePieces(ctx context.Context, segment metabase.Segment, healthyPieces metabase.Pieces) (_ metabase.Pieces, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	checkpointed, err := repairer.checkpoints.Get(ctx, segment.StreamID, segment.Position, repairer.nowFn().Add(-repairer.checkpointTTL))
+	if err != nil || len(checkpointed) == 0 {
+		return nil, err
+	}
+
+	missingPieces, err := repairer.overlay.GetMissingPieces(ctx, checkpointed)
+	if err != nil {
+		return nil, err
+	}
+	missingSet := sliceToSet(missingPieces)
+
+	usedNumbers := make(map[uint16]bool, len(healthyPieces))
+	for _, piece := range healthyPieces {
+		usedNumbers[piece.Number] = true
+	}
+	usedNodes := make(map[storj.NodeID]bool, len(segment.Pieces))
+	for _, piece := range segment.Pieces {
+		usedNodes[piece.StorageNode] = true
+	}
+
+	var resumable metabase.Pieces
+	for _, piece := range checkpointed {
+		if int(piece.Number) >= int(segment.Redundancy.TotalShares) ||
+			missingSet[piece.Number] || usedNumbers[piece.Number] || usedNodes[piece.StorageNode] {
+			continue
+		}
+		resumable = append(resumable, piece)
+	}
+	return resumable, nil
+}
+
+// finishResumedRepair completes the repair of a segment when the pieces uploaded
+// before the repair was interrupted are enough, without uploading any other piece.
+func (repairer *SegmentRepairer) finishResumedRepair(ctx context.Context, segment metabase.Segment, stats *stats, unhealthyPieces, resumedPieces metabase.Pieces) (shouldDelete bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	newPieces, err := segment.Pieces.Update(resumedPieces, unhealthyPieces)
+	if err != nil {
+		return false, repairPutError.Wrap(err)
+	}
+
+	err = repairer.metabase.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+		StreamID: segment.StreamID,
+		Position: segment.Position,
+
+		OldPieces:     segment.Pieces,
+		NewRedundancy: segment.Redundancy,
+		NewPieces:     newPieces,
+
+		NewRepairedAt: time.Now(),
+	})
+	if err != nil {
+		return false, metainfoPutError.Wrap(err)
+	}
+
+	repairer.deleteCheckpoint(ctx, segment.StreamID, segment.Position)
+
+	mon.Meter("repair_success").Mark(1) //mon:locked
+	stats.repairSuccess.Mark(1)
+	repairer.log.Debug("resumed repair didn't need to upload pieces",
+		zap.Stringer("StreamID", segment.StreamID),
+		zap.Uint64("Position", segment.Position.Encode()),
+		zap.Int("resumedPieces", len(resumedPieces)),
+	)
+	return true, nil
+}
+
+// deleteCheckpoint removes the repair checkpoint of the segment, if checkpointing is enabled.
+func (repairer *SegmentRepairer) deleteCheckpoint(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition) {
+	if repairer.checkpoints == nil {
+		return
+	}
+	if err := repairer.checkpoints.Delete(ctx, streamID, position); err != nil {
+		// a stale checkpoint is harmless, its pieces are verified before they are used.
+		repairer.log.Warn("failed to delete repair checkpoint",
+			zap.Stringer("StreamID", streamID),
+			zap.Uint64("Position", position.Encode()),
+			zap.Error(err),
+		)
+	}
+}
+
+// trimPieces selects up to keep pieces of a segment which has more pieces than the
+// redundancy total shares. Pieces with a number outside of the redundancy scheme are
+// always dropped. Pieces on unhealthy nodes are dropped first, followed by pieces in
+// excluded countries and then pieces sharing a subnet with another kept piece.
+func (repairer *SegmentRepairer) trimPieces(ctx context.Context, segment metabase.Segment, missingPieces []uint16, keep int) (_ metabase.Pieces, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeIDs := make([]storj.NodeID, len(segment.Pieces))
+	for i, piece := range segment.Pieces {
+		nodeIDs[i] = piece.StorageNode
+	}
+
+	nodes, err := repairer.overlay.GetOnlineNodesForAuditRepair(ctx, nodeIDs)
+	if err != nil {
+		return nil, overlayQueryError.New("error getting nodes for trimming pieces: %w", err)
+	}
+
+	piecesInExcludedCountries, err := repairer.getPiecesInExcludedCountries(ctx, segment, segment.Pieces)
+	if err != nil {
+		return nil, err
+	}
+
+	lostPiecesSet := sliceToSet(missingPieces)
+	excludedPiecesSet := sliceToSet(piecesInExcludedCountries)
+	usedNets := make(map[string]bool)
+
+	var distinct, healthy, excluded, unhealthy metabase.Pieces
+	for _, piece := range segment.Pieces {
+		if piece.Number >= uint16(segment.Redundancy.TotalShares) {
+			continue
+		}
+
+		node, ok := nodes[piece.StorageNode]
+		switch {
+		case !ok || lostPiecesSet[piece.Number]:
+			unhealthy = append(unhealthy, piece)
+		case excludedPiecesSet[piece.Number]:
+			excluded = append(excluded, piece)
+		case node.LastNet != "" && !usedNets[node.LastNet]:
+			usedNets[node.LastNet] = true
+			distinct = append(distinct, piece)
+		default:
+			healthy = append(healthy, piece)
+		}
+	}
+
+	trimmed := make(metabase.Pieces, 0, keep)
+	for _, candidates := range []metabase.Pieces{distinct, healthy, excluded, unhealthy} {
+		for _, piece := range candidates {
+			if len(trimmed) >= keep {
+				break
+			}
+			trimmed = append(trimmed, piece)
+		}
+	}
+
+	sort.Slice(trimmed, func(i, j int) bool {
+		return trimmed[i].Number < trimmed[j].Number
+	})
+
+	return trimmed, nil
+}
+
+// getPiecesInExcludedCountries returns the numbers of the pieces which are on reliable
+// nodes in the countries excluded for the placement of the segment.
+func (repairer *SegmentRepairer) getPiecesInExcludedCountries(ctx context.Context, segment metabase.Segment, pieces metabase.Pieces) (_ []uint16, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var piecesInExcludedCountries []uint16
+	if countryCodes, ok := repairer.excludedCountries.GetExcludedCountries(segment.Placement); ok {
+		piecesInExcludedCountries, err = repairer.overlay.GetReliablePiecesInCountries(ctx, pieces, countryCodes)
+	} else {
+		piecesInExcludedCountries, err = repairer.overlay.GetReliablePiecesInExcludedCountries(ctx, pieces)
+	}
+	if err != nil {
+		return nil, overlayQueryError.New("error identifying pieces in excluded countries: %w", err)
+	}
+	return piecesInExcludedCountries, nil
+}
+
 // checkIfSegmentAltered checks if oldSegment has been altered since it was selected for audit.
 func (repairer *SegmentRepairer) checkIfSegmentAltered(ctx context.Context, oldSegment metabase.Segment) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -536,6 +1053,12 @@ func (repairer *SegmentRepairer) loadRedundancy(redundancy *pb.RedundancyScheme)
 	return int(redundancy.MinReq), repair, int(redundancy.SuccessThreshold), int(redundancy.Total)
 }
 
+// SkippedHealthy returns the number of segments which were skipped because
+// they were healthy again by the time they were picked up for repair.
+func (repairer *SegmentRepairer) SkippedHealthy() int64 {
+	return atomic.LoadInt64(&repairer.skippedHealthy)
+}
+
 // SetNow allows tests to have the server act as if the current time is whatever they want.
 func (repairer *SegmentRepairer) SetNow(nowFn func() time.Time) {
 	repairer.nowFn = nowFn
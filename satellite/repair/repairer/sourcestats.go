@@ -0,0 +1,92 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"sync"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// SourceStats accounts the bytes downloaded from each storage node for repair,
+// i.e. the repair work which can be attributed to the node as a source.
+//
+// The accounting is done over a window, which starts when the stats are created
+// and is restarted by TakeWindow. The repair service takes and logs a window at
+// the end of a repair cycle once it's at least the configured length.
+type SourceStats struct {
+	mu         sync.Mutex
+	windowFrom time.Time
+	downloaded map[storj.NodeID]int64
+}
+
+// SourceStatsWindow contains the repair source bytes accounted during a window.
+type SourceStatsWindow struct {
+	From time.Time
+	To   time.Time
+
+	Downloaded map[storj.NodeID]int64
+}
+
+// Total returns the bytes downloaded from all nodes during the window.
+func (window SourceStatsWindow) Total() (total int64) {
+	for _, bytes := range window.Downloaded {
+		total += bytes
+	}
+	return total
+}
+
+// NewSourceStats creates empty repair source stats.
+func NewSourceStats() *SourceStats {
+	return &SourceStats{
+		windowFrom: time.Now(),
+		downloaded: map[storj.NodeID]int64{},
+	}
+}
+
+// Add accounts bytes downloaded from the node for repair.
+func (stats *SourceStats) Add(nodeID storj.NodeID, bytes int64) {
+	if bytes <= 0 {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.downloaded[nodeID] += bytes
+}
+
+// WindowFrom returns the start of the current window.
+func (stats *SourceStats) WindowFrom() time.Time {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	return stats.windowFrom
+}
+
+// Downloaded returns the bytes downloaded from the node for repair in the current window.
+func (stats *SourceStats) Downloaded(nodeID storj.NodeID) int64 {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	return stats.downloaded[nodeID]
+}
+
+// TakeWindow returns the accounting of the current window and starts a new one.
+func (stats *SourceStats) TakeWindow(now time.Time) SourceStatsWindow {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	window := SourceStatsWindow{
+		From:       stats.windowFrom,
+		To:         now,
+		Downloaded: stats.downloaded,
+	}
+
+	stats.windowFrom = now
+	stats.downloaded = map[storj.NodeID]int64{}
+
+	return window
+}
@@ -42,11 +42,13 @@ type stats struct {
 	repairerSegmentsBelowMinReq *monkit.Counter
 	repairerNodesUnavailable    *monkit.Meter
 	repairUnnecessary           *monkit.Meter
+	repairExcessPiecesTrimmed   *monkit.Meter
 	healthyRatioBeforeRepair    *monkit.FloatVal
 	repairTooManyNodesFailed    *monkit.Meter
 	repairFailed                *monkit.Meter
 	repairPartial               *monkit.Meter
 	repairSuccess               *monkit.Meter
+	repairDegraded              *monkit.Meter
 	healthyRatioAfterRepair     *monkit.FloatVal
 	segmentTimeUntilRepair      *monkit.IntVal
 	segmentRepairCount          *monkit.IntVal
@@ -59,11 +61,13 @@ func newStats(rs string) *stats {
 		repairerSegmentsBelowMinReq: monkit.NewCounter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repairer_segments_below_min_req").WithTag("rs_scheme", rs)),
 		repairerNodesUnavailable:    monkit.NewMeter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repairer_nodes_unavailable").WithTag("rs_scheme", rs)),
 		repairUnnecessary:           monkit.NewMeter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repair_unnecessary").WithTag("rs_scheme", rs)),
+		repairExcessPiecesTrimmed:   monkit.NewMeter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repair_excess_pieces_trimmed").WithTag("rs_scheme", rs)),
 		healthyRatioBeforeRepair:    monkit.NewFloatVal(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "healthy_ratio_before_repair").WithTag("rs_scheme", rs)),
 		repairTooManyNodesFailed:    monkit.NewMeter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repair_too_many_nodes_failed").WithTag("rs_scheme", rs)),
 		repairFailed:                monkit.NewMeter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repair_failed").WithTag("rs_scheme", rs)),
 		repairPartial:               monkit.NewMeter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repair_partial").WithTag("rs_scheme", rs)),
 		repairSuccess:               monkit.NewMeter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repair_success").WithTag("rs_scheme", rs)),
+		repairDegraded:              monkit.NewMeter(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "repair_degraded").WithTag("rs_scheme", rs)),
 		healthyRatioAfterRepair:     monkit.NewFloatVal(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "healthy_ratio_after_repair").WithTag("rs_scheme", rs)),
 		segmentTimeUntilRepair:      monkit.NewIntVal(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "segment_time_until_repair").WithTag("rs_scheme", rs)),
 		segmentRepairCount:          monkit.NewIntVal(monkit.NewSeriesKey("tagged_repair_stats").WithTag("name", "segment_repair_count").WithTag("rs_scheme", rs)),
@@ -77,11 +81,13 @@ func (stats *stats) Stats(cb func(key monkit.SeriesKey, field string, val float6
 	stats.repairerSegmentsBelowMinReq.Stats(cb)
 	stats.repairerNodesUnavailable.Stats(cb)
 	stats.repairUnnecessary.Stats(cb)
+	stats.repairExcessPiecesTrimmed.Stats(cb)
 	stats.healthyRatioBeforeRepair.Stats(cb)
 	stats.repairTooManyNodesFailed.Stats(cb)
 	stats.repairFailed.Stats(cb)
 	stats.repairPartial.Stats(cb)
 	stats.repairSuccess.Stats(cb)
+	stats.repairDegraded.Stats(cb)
 	stats.healthyRatioAfterRepair.Stats(cb)
 	stats.segmentTimeUntilRepair.Stats(cb)
 	stats.segmentRepairCount.Stats(cb)
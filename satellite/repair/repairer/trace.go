@@ -0,0 +1,139 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/metabase"
+)
+
+// Results of traced piece transfers.
+const (
+	TraceSuccess   = "success"
+	TraceFailed    = "failed"
+	TraceOffline   = "offline"
+	TraceContained = "contained"
+	TraceUnknown   = "unknown"
+)
+
+// RepairTrace contains detailed information about the repair of a single
+// segment, for debugging specific problem segments.
+type RepairTrace struct {
+	StreamID uuid.UUID
+	Position metabase.SegmentPosition
+	Started  time.Time
+
+	// Downloads contains the result of every source piece which was downloaded.
+	Downloads []TracePiece
+	// DownloadDuration is the time spent downloading the source pieces.
+	DownloadDuration time.Duration
+
+	// Uploads contains the result of every repaired piece which was uploaded.
+	Uploads []TracePiece
+	// RepairDuration is the time spent reconstructing and uploading the repaired pieces.
+	RepairDuration time.Duration
+
+	Duration time.Duration
+	Err      error
+}
+
+// TracePiece contains the transfer result of a single piece.
+type TracePiece struct {
+	metabase.Piece
+	Result string
+}
+
+// String returns the piece number, the node and the result.
+func (piece TracePiece) String() string {
+	return fmt.Sprintf("%d:%s:%s", piece.Number, piece.StorageNode, piece.Result)
+}
+
+// parseTracedStreamIDs parses the comma separated list of stream ids.
+func parseTracedStreamIDs(log *zap.Logger, list string) map[uuid.UUID]struct{} {
+	var streamIDs map[uuid.UUID]struct{}
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		streamID, err := uuid.FromString(value)
+		if err != nil {
+			log.Warn("invalid traced stream id", zap.String("StreamID", value), zap.Error(err))
+			continue
+		}
+		if streamIDs == nil {
+			streamIDs = make(map[uuid.UUID]struct{})
+		}
+		streamIDs[streamID] = struct{}{}
+	}
+	return streamIDs
+}
+
+// recordDownloads adds the download results of the pieces report to the trace.
+func (trace *RepairTrace) recordDownloads(report audit.Pieces, duration time.Duration) {
+	add := func(pieces metabase.Pieces, result string) {
+		for _, piece := range pieces {
+			trace.Downloads = append(trace.Downloads, TracePiece{Piece: piece, Result: result})
+		}
+	}
+	add(report.Successful, TraceSuccess)
+	add(report.Failed, TraceFailed)
+	add(report.Offline, TraceOffline)
+	add(report.Contained, TraceContained)
+	add(report.Unknown, TraceUnknown)
+
+	trace.DownloadDuration = duration
+}
+
+// recordUploads adds the upload results of the limits to the trace.
+func (trace *RepairTrace) recordUploads(limits []*pb.AddressedOrderLimit, successfulNodes []*pb.Node, duration time.Duration) {
+	for i, limit := range limits {
+		if limit == nil || limit.GetLimit() == nil {
+			continue
+		}
+		piece := TracePiece{
+			Piece:  metabase.Piece{Number: uint16(i), StorageNode: limit.GetLimit().StorageNodeId},
+			Result: TraceFailed,
+		}
+		if i < len(successfulNodes) && successfulNodes[i] != nil {
+			piece.Result = TraceSuccess
+		}
+		trace.Uploads = append(trace.Uploads, piece)
+	}
+
+	trace.RepairDuration = duration
+}
+
+// finish completes the trace and logs it.
+func (trace *RepairTrace) finish(log *zap.Logger, err error) {
+	trace.Duration = time.Since(trace.Started)
+	trace.Err = err
+
+	log.Info("repair trace",
+		zap.Stringer("StreamID", trace.StreamID),
+		zap.Uint64("Position", trace.Position.Encode()),
+		zap.Strings("downloads", tracePieceStrings(trace.Downloads)),
+		zap.Duration("downloadDuration", trace.DownloadDuration),
+		zap.Strings("uploads", tracePieceStrings(trace.Uploads)),
+		zap.Duration("repairDuration", trace.RepairDuration),
+		zap.Duration("duration", trace.Duration),
+		zap.Error(err),
+	)
+}
+
+func tracePieceStrings(pieces []TracePiece) []string {
+	strs := make([]string, 0, len(pieces))
+	for _, piece := range pieces {
+		strs = append(strs, piece.String())
+	}
+	return strs
+}
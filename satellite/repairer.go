@@ -71,6 +71,7 @@ type Repairer struct {
 	EcRepairer      *repairer.ECRepairer
 	SegmentRepairer *repairer.SegmentRepairer
 	Repairer        *repairer.Service
+	CheckpointChore *repairer.CheckpointChore
 
 	Buckets struct {
 		Service *buckets.Service
@@ -82,6 +83,7 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 	metabaseDB *metabase.DB,
 	revocationDB extensions.RevocationDB,
 	repairQueue queue.RepairQueue,
+	repairCheckpoints queue.RepairCheckpoints,
 	bucketsDB buckets.DB,
 	overlayCache overlay.DB,
 	reputationdb reputation.DB,
@@ -211,8 +213,8 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 			log.Named("ec-repair"),
 			peer.Dialer,
 			signing.SigneeFromPeerIdentity(peer.Identity.PeerIdentity()),
-			config.Repairer.DownloadTimeout,
-			config.Repairer.InMemoryRepair)
+			peer.Reputation,
+			config.Repairer)
 
 		peer.SegmentRepairer = repairer.NewSegmentRepairer(
 			log.Named("segment-repair"),
@@ -221,9 +223,11 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 			peer.Overlay,
 			peer.Audit.Reporter,
 			peer.EcRepairer,
+			repairCheckpoints,
 			config.Checker.RepairOverrides,
-			config.Repairer.Timeout,
-			config.Repairer.MaxExcessRateOptimalThreshold,
+			config.Checker.PlacementSuccessOverrides,
+			config.Checker.PlacementExcludedCountries,
+			&config.Repairer,
 		)
 		peer.Repairer = repairer.NewService(log.Named("repairer"), repairQueue, &config.Repairer, peer.SegmentRepairer)
 
@@ -234,6 +238,18 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 		})
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Repair Worker", peer.Repairer.Loop))
+
+		if config.Repairer.Checkpointing {
+			peer.CheckpointChore = repairer.NewCheckpointChore(log.Named("repair:checkpoints"), repairCheckpoints, &config.Repairer)
+
+			peer.Services.Add(lifecycle.Item{
+				Name:  "repair:checkpoints",
+				Run:   peer.CheckpointChore.Run,
+				Close: peer.CheckpointChore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Repair Checkpoint Cleanup", peer.CheckpointChore.Loop))
+		}
 	}
 
 	return peer, nil
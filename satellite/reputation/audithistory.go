@@ -76,22 +76,7 @@ func AddAuditToHistory(a *pb.AuditHistory, online bool, auditTime time.Time, con
 		return nil
 	}
 
-	if len(a.Windows) <= 1 {
-		a.Score = 1
-		return nil
-	}
-
-	totalWindowScores := 0.0
-	for i, window := range a.Windows {
-		// do not include last window in score
-		if i+1 == len(a.Windows) {
-			break
-		}
-		totalWindowScores += float64(window.OnlineCount) / float64(window.TotalCount)
-	}
-
-	// divide by number of windows-1 because last window is not included
-	a.Score = totalWindowScores / float64(len(a.Windows)-1)
+	RecalculateScore(a, config)
 	return nil
 }
 
@@ -141,7 +126,7 @@ func MergeAuditHistories(history *pb.AuditHistory, addHistory []*pb.AuditWindow,
 	}
 
 	history.Windows = windows
-	RecalculateScore(history)
+	RecalculateScore(history, config)
 
 	windowsPerTrackingPeriod := int(config.TrackingPeriod.Seconds() / config.WindowSize.Seconds())
 	trackingPeriodFull = len(history.Windows)-1 >= windowsPerTrackingPeriod
@@ -150,20 +135,32 @@ func MergeAuditHistories(history *pb.AuditHistory, addHistory []*pb.AuditWindow,
 }
 
 // RecalculateScore calculates and assigns the Score field in a pb.AuditHistory object.
-// The score is calculated by averaging the online percentage in each window
-// (not including the last).
-func RecalculateScore(history *pb.AuditHistory) {
+// The score is calculated by a weighted average of the online percentage in each window
+// (not including the last). The weight of every window is config.RecoveryRate greater
+// than the weight of the window before it, so that with a positive recovery rate a node
+// which returns to service after downtime regains its score faster.
+func RecalculateScore(history *pb.AuditHistory, config AuditHistoryConfig) {
 	if len(history.Windows) <= 1 {
 		history.Score = 1
 		return
 	}
+
+	growth := 1.0
+	if config.RecoveryRate > 0 {
+		growth += config.RecoveryRate
+	}
+
 	totalWindowScores := float64(0)
+	totalWeights := float64(0)
+	weight := float64(1)
 	for i, window := range history.Windows {
 		// do not include last window in score
 		if i+1 == len(history.Windows) {
 			break
 		}
-		totalWindowScores += float64(window.OnlineCount) / float64(window.TotalCount)
+		totalWindowScores += weight * float64(window.OnlineCount) / float64(window.TotalCount)
+		totalWeights += weight
+		weight *= growth
 	}
-	history.Score = totalWindowScores / float64(len(history.Windows)-1)
+	history.Score = totalWindowScores / totalWeights
 }
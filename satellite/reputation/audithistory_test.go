@@ -4,6 +4,8 @@
 package reputation_test
 
 import (
+	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -77,6 +79,67 @@ func TestAddAuditToHistory(t *testing.T) {
 	require.EqualValues(t, expectedScore, history.Score)
 }
 
+func TestAddAuditToHistoryRecoveryRate(t *testing.T) {
+	for _, recoveryRate := range []float64{0, 0.5, 1} {
+		recoveryRate := recoveryRate
+		t.Run(fmt.Sprint(recoveryRate), func(t *testing.T) {
+			config := reputation.AuditHistoryConfig{
+				WindowSize:     time.Hour,
+				TrackingPeriod: 24 * time.Hour,
+				RecoveryRate:   recoveryRate,
+			}
+
+			currentWindow := time.Now().Truncate(time.Hour)
+			history := &pb.AuditHistory{}
+
+			// the node is offline for a whole window
+			err := reputation.AddAuditToHistory(history, false, currentWindow, config)
+			require.NoError(t, err)
+
+			// and online for every window afterwards
+			previousScore := float64(-1)
+			for onlineWindows := 0; onlineWindows < 10; onlineWindows++ {
+				currentWindow = currentWindow.Add(time.Hour)
+				err = reputation.AddAuditToHistory(history, true, currentWindow, config)
+				require.NoError(t, err)
+
+				// the weights of the completed windows are 1, (1+rate), (1+rate)^2, ...
+				// of which only the first one belongs to the offline window.
+				totalWeights := float64(0)
+				for i := 0; i <= onlineWindows; i++ {
+					totalWeights += math.Pow(1+recoveryRate, float64(i))
+				}
+				expectedScore := 1 - 1/totalWeights
+
+				require.InDelta(t, expectedScore, history.Score, 1e-9)
+				require.Greater(t, history.Score, previousScore)
+				previousScore = history.Score
+			}
+		})
+	}
+
+	// a higher recovery rate recovers faster after the same downtime
+	scoreAfterRecovery := func(recoveryRate float64) float64 {
+		config := reputation.AuditHistoryConfig{
+			WindowSize:     time.Hour,
+			TrackingPeriod: 24 * time.Hour,
+			RecoveryRate:   recoveryRate,
+		}
+		history := makeHistory([]hist{
+			{false, time.Time{}},
+			{false, time.Time{}.Add(time.Hour)},
+			{true, time.Time{}.Add(2 * time.Hour)},
+			{true, time.Time{}.Add(3 * time.Hour)},
+			{true, time.Time{}.Add(4 * time.Hour)},
+		}, config)
+		return history.Score
+	}
+	require.EqualValues(t, 0.5, scoreAfterRecovery(0))
+	require.Less(t, scoreAfterRecovery(0), scoreAfterRecovery(0.5))
+	require.Less(t, scoreAfterRecovery(0.5), scoreAfterRecovery(1))
+	require.InDelta(t, float64(4+8)/float64(1+2+4+8), scoreAfterRecovery(1), 1e-9)
+}
+
 func TestMergeAuditHistoriesWithSingleAudit(t *testing.T) {
 	config := reputation.AuditHistoryConfig{
 		WindowSize:               time.Hour,
@@ -296,6 +359,6 @@ func makeHistory(histWindows []hist, config reputation.AuditHistoryConfig) *pb.A
 	baseHistory := &pb.AuditHistory{
 		Windows: windows,
 	}
-	reputation.RecalculateScore(baseHistory)
+	reputation.RecalculateScore(baseHistory, config)
 	return baseHistory
 }
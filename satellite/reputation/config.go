@@ -32,6 +32,8 @@ type Config struct {
 	SuspensionDQEnabled   bool          `help:"whether nodes will be disqualified if they have been suspended for longer than the suspended grace period" releaseDefault:"false" devDefault:"true"`
 	AuditCount            int64         `help:"the number of times a node has been audited to not be considered a New Node" releaseDefault:"100" devDefault:"0"`
 	AuditHistory          AuditHistoryConfig
+
+	ExportAsOfSystemInterval time.Duration `help:"as of system interval used when exporting the reputations of all nodes" releaseDefault:"-5m" devDefault:"-1us" testDefault:"-1us"`
 }
 
 // UpdateRequest is used to update a node's reputation status.
@@ -53,6 +55,7 @@ type AuditHistoryConfig struct {
 	OfflineThreshold         float64       `help:"The point below which a node is punished for offline audits. Determined by calculating the ratio of online/total audits within each window and finding the average across windows within the tracking period." default:"0.6"`
 	OfflineDQEnabled         bool          `help:"whether nodes will be disqualified if they have low online score after a review period" releaseDefault:"false" devDefault:"true"`
 	OfflineSuspensionEnabled bool          `help:"whether nodes will be suspended if they have low online score" releaseDefault:"true" devDefault:"true"`
+	RecoveryRate             float64       `help:"The rate by which the weight of each audit window grows compared to the previous one when calculating the online score. 0 weighs all windows equally; higher values let nodes returning after downtime recover their online score faster" default:"0"`
 }
 
 // AuditType is an enum representing the outcome of a particular audit.
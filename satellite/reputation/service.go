@@ -28,6 +28,10 @@ type DB interface {
 	DisqualifyNode(ctx context.Context, nodeID storj.NodeID, disqualifiedAt time.Time, reason overlay.DisqualificationReason) (err error)
 	// SuspendNodeUnknownAudit suspends a storage node for unknown audits.
 	SuspendNodeUnknownAudit(ctx context.Context, nodeID storj.NodeID, suspendedAt time.Time) (err error)
+	// ExportAll returns up to limit reputations of the nodes with an ID greater than cursor, ordered by node ID.
+	ExportAll(ctx context.Context, cursor storj.NodeID, limit int, asOfSystemInterval time.Duration) (_ []NodeInfo, err error)
+	// GetAuditScores returns the audit reputation scores of the nodes which have a reputation.
+	GetAuditScores(ctx context.Context, nodeIDs []storj.NodeID) (_ map[storj.NodeID]float64, err error)
 }
 
 // Info contains all reputation data to be stored in DB.
@@ -48,6 +52,12 @@ type Info struct {
 	UnknownAuditReputationBeta  float64
 }
 
+// NodeInfo contains the reputation data of a single node.
+type NodeInfo struct {
+	NodeID storj.NodeID
+	Info
+}
+
 // Mutations represents changes which should be made to a particular node's
 // reputation, in terms of counts and/or timestamps of events which have
 // occurred. A Mutations record can be applied to a reputations row without
@@ -141,6 +151,37 @@ func (service *Service) Get(ctx context.Context, nodeID storj.NodeID) (info *Inf
 	return info, nil
 }
 
+// AuditScores returns the audit reputation scores of the nodes, between 0 and 1.
+// Nodes without a reputation have a perfect score.
+func (service *Service) AuditScores(ctx context.Context, nodeIDs []storj.NodeID) (_ map[storj.NodeID]float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	scores, err := service.db.GetAuditScores(ctx, nodeIDs)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for _, nodeID := range nodeIDs {
+		if _, ok := scores[nodeID]; !ok {
+			scores[nodeID] = 1
+		}
+	}
+	return scores, nil
+}
+
+// ExportAll returns a page of the reputations of all nodes which have one, starting after
+// the node with ID cursor. The zero node ID starts from the beginning and the last node ID
+// of a page is the cursor for the next one; an empty page means all nodes have been exported.
+func (service *Service) ExportAll(ctx context.Context, cursor storj.NodeID, limit int) (_ []NodeInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 {
+		return nil, Error.New("limit must be positive, got %d", limit)
+	}
+
+	infos, err := service.db.ExportAll(ctx, cursor, limit, service.config.ExportAsOfSystemInterval)
+	return infos, Error.Wrap(err)
+}
+
 // TestSuspendNodeUnknownAudit suspends a storage node for unknown audits.
 func (service *Service) TestSuspendNodeUnknownAudit(ctx context.Context, nodeID storj.NodeID, suspendedAt time.Time) (err error) {
 	err = service.db.SuspendNodeUnknownAudit(ctx, nodeID, suspendedAt)
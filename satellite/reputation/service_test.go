@@ -4,20 +4,25 @@
 package reputation_test
 
 import (
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/memory"
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/reputation"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
 )
 
 func TestConcurrentAudit(t *testing.T) {
@@ -146,6 +151,110 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestExportAll(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		config := reputation.Config{
+			AuditLambda:              1,
+			AuditWeight:              1,
+			AuditHistory:             testAuditHistoryConfig(),
+			ExportAsOfSystemInterval: -time.Microsecond,
+		}
+		service := reputation.NewService(zaptest.NewLogger(t), db.OverlayCache(), db.Reputation(), config)
+
+		outcomes := []reputation.AuditType{
+			reputation.AuditSuccess,
+			reputation.AuditFailure,
+			reputation.AuditUnknown,
+			reputation.AuditOffline,
+			reputation.AuditSuccess,
+		}
+
+		var nodeIDs storj.NodeIDList
+		for _, outcome := range outcomes {
+			nodeID := testrand.NodeID()
+			_, err := db.Reputation().Update(ctx, reputation.UpdateRequest{
+				NodeID:       nodeID,
+				AuditOutcome: outcome,
+				Config:       config,
+			}, time.Now())
+			require.NoError(t, err)
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+		sort.Sort(nodeIDs)
+
+		var exported []reputation.NodeInfo
+		var cursor storj.NodeID
+		pages := 0
+		for {
+			page, err := service.ExportAll(ctx, cursor, 2)
+			require.NoError(t, err)
+			if len(page) == 0 {
+				break
+			}
+			require.LessOrEqual(t, len(page), 2)
+
+			exported = append(exported, page...)
+			cursor = page[len(page)-1].NodeID
+			pages++
+		}
+		require.Equal(t, 3, pages)
+		require.Len(t, exported, len(nodeIDs))
+
+		for i, nodeInfo := range exported {
+			require.Equal(t, nodeIDs[i], nodeInfo.NodeID)
+
+			info, err := service.Get(ctx, nodeInfo.NodeID)
+			require.NoError(t, err)
+			require.Equal(t, info.TotalAuditCount, nodeInfo.TotalAuditCount)
+			require.Equal(t, info.AuditReputationAlpha, nodeInfo.AuditReputationAlpha)
+			require.Equal(t, info.AuditReputationBeta, nodeInfo.AuditReputationBeta)
+			require.Equal(t, info.UnknownAuditReputationAlpha, nodeInfo.UnknownAuditReputationAlpha)
+			require.Equal(t, info.UnknownAuditReputationBeta, nodeInfo.UnknownAuditReputationBeta)
+			require.Equal(t, info.OnlineScore, nodeInfo.OnlineScore)
+			require.Equal(t, info.AuditHistory.GetWindows()[0].GetOnlineCount(), nodeInfo.AuditHistory.GetWindows()[0].GetOnlineCount())
+		}
+
+		_, err := service.ExportAll(ctx, storj.NodeID{}, 0)
+		require.Error(t, err)
+	})
+}
+
+func TestAuditScores(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		config := reputation.Config{
+			AuditLambda:  1,
+			AuditWeight:  1,
+			AuditHistory: testAuditHistoryConfig(),
+		}
+		service := reputation.NewService(zaptest.NewLogger(t), db.OverlayCache(), db.Reputation(), config)
+
+		succeeded, failed, unknown := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+		for nodeID, outcome := range map[storj.NodeID]reputation.AuditType{
+			succeeded: reputation.AuditSuccess,
+			failed:    reputation.AuditFailure,
+		} {
+			_, err := db.Reputation().Update(ctx, reputation.UpdateRequest{
+				NodeID:       nodeID,
+				AuditOutcome: outcome,
+				Config:       config,
+			}, time.Now())
+			require.NoError(t, err)
+		}
+
+		scores, err := service.AuditScores(ctx, []storj.NodeID{succeeded, failed, unknown})
+		require.NoError(t, err)
+		require.Len(t, scores, 3)
+		require.InDelta(t, 1, scores[succeeded], 1e-8)
+		require.InDelta(t, 0.5, scores[failed], 1e-8)
+		// nodes without a reputation have a perfect score.
+		require.InDelta(t, 1, scores[unknown], 1e-8)
+
+		scores, err = service.AuditScores(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, scores)
+	})
+}
+
 func TestDisqualificationAuditFailure(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
@@ -6,6 +6,7 @@ package satellitedb
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 
@@ -70,7 +71,7 @@ func (keys *apikeys) GetPagedByProjectID(ctx context.Context, projectID uuid.UUI
 	}
 
 	repoundQuery := keys.db.Rebind(`
-		SELECT ak.id, ak.project_id, ak.name, ak.partner_id, ak.user_agent, ak.created_at
+		SELECT ak.id, ak.project_id, ak.name, ak.partner_id, ak.user_agent, ak.created_at, ak.expires_at
 		FROM api_keys ak
 		WHERE ak.project_id = ?
 		AND lower(ak.name) LIKE ?
@@ -95,7 +96,7 @@ func (keys *apikeys) GetPagedByProjectID(ctx context.Context, projectID uuid.UUI
 		ak := console.APIKeyInfo{}
 		var partnerID uuid.NullUUID
 
-		err = rows.Scan(&ak.ID, &ak.ProjectID, &ak.Name, &partnerID, &ak.UserAgent, &ak.CreatedAt)
+		err = rows.Scan(&ak.ID, &ak.ProjectID, &ak.Name, &partnerID, &ak.UserAgent, &ak.CreatedAt, &ak.ExpiresAt)
 		if err != nil {
 			return nil, err
 		}
@@ -163,6 +164,40 @@ func (keys *apikeys) GetByNameAndProjectID(ctx context.Context, name string, pro
 	return fromDBXAPIKey(ctx, dbKey)
 }
 
+// GetExpiringByProjectID implements satellite.APIKeys.
+func (keys *apikeys) GetExpiringByProjectID(ctx context.Context, projectID uuid.UUID, after, before time.Time) (_ []console.APIKeyInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := keys.db.QueryContext(ctx, keys.db.Rebind(`
+		SELECT id, project_id, name, partner_id, user_agent, created_at, expires_at
+		FROM api_keys
+		WHERE project_id = ?
+			AND expires_at > ?
+			AND expires_at <= ?
+		ORDER BY expires_at, name
+	`), projectID[:], after, before)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var apiKeys []console.APIKeyInfo
+	for rows.Next() {
+		ak := console.APIKeyInfo{}
+		var partnerID uuid.NullUUID
+
+		err = rows.Scan(&ak.ID, &ak.ProjectID, &ak.Name, &partnerID, &ak.UserAgent, &ak.CreatedAt, &ak.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		ak.PartnerID = partnerID.UUID
+		apiKeys = append(apiKeys, ak)
+	}
+
+	return apiKeys, rows.Err()
+}
+
 // Create implements satellite.APIKeys.
 func (keys *apikeys) Create(ctx context.Context, head []byte, info console.APIKeyInfo) (_ *console.APIKeyInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -180,6 +215,10 @@ func (keys *apikeys) Create(ctx context.Context, head []byte, info console.APIKe
 		optional.UserAgent = dbx.ApiKey_UserAgent(info.UserAgent)
 	}
 
+	if info.ExpiresAt != nil {
+		optional.ExpiresAt = dbx.ApiKey_ExpiresAt(*info.ExpiresAt)
+	}
+
 	dbKey, err := keys.methods.Create_ApiKey(
 		ctx,
 		dbx.ApiKey_Id(id[:]),
@@ -234,6 +273,7 @@ func fromDBXAPIKey(ctx context.Context, key *dbx.ApiKey) (_ *console.APIKeyInfo,
 		ProjectID: projectID,
 		Name:      key.Name,
 		CreatedAt: key.CreatedAt,
+		ExpiresAt: key.ExpiresAt,
 		Head:      key.Head,
 		Secret:    key.Secret,
 	}
@@ -79,22 +79,66 @@ func (db *bucketsDB) GetBucket(ctx context.Context, bucketName []byte, projectID
 // GetBucketPlacement returns with the placement constraint identifier.
 func (db *bucketsDB) GetBucketPlacement(ctx context.Context, bucketName []byte, projectID uuid.UUID) (placement storj.PlacementConstraint, err error) {
 	defer mon.Task()(&ctx)(&err)
-	dbxPlacement, err := db.db.Get_BucketMetainfo_Placement_By_ProjectId_And_Name(ctx,
+	placement, _, err = db.GetBucketPlacementAndVersioning(ctx, bucketName, projectID)
+	return placement, err
+}
+
+// GetBucketPlacementAndVersioning returns with the placement constraint identifier
+// and whether new writes to the bucket create new object versions.
+func (db *bucketsDB) GetBucketPlacementAndVersioning(ctx context.Context, bucketName []byte, projectID uuid.UUID) (placement storj.PlacementConstraint, versioning bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxRow, err := db.db.Get_BucketMetainfo_Placement_BucketMetainfo_Versioning_By_ProjectId_And_Name(ctx,
 		dbx.BucketMetainfo_ProjectId(projectID[:]),
 		dbx.BucketMetainfo_Name(bucketName),
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return storj.EveryCountry, storj.ErrBucketNotFound.New("%s", bucketName)
+			return storj.EveryCountry, false, storj.ErrBucketNotFound.New("%s", bucketName)
 		}
-		return storj.EveryCountry, storj.ErrBucket.Wrap(err)
+		return storj.EveryCountry, false, storj.ErrBucket.Wrap(err)
 	}
 	placement = storj.EveryCountry
-	if dbxPlacement.Placement != nil {
-		placement = storj.PlacementConstraint(*dbxPlacement.Placement)
+	if dbxRow.Placement != nil {
+		placement = storj.PlacementConstraint(*dbxRow.Placement)
+	}
+
+	return placement, dbxRow.Versioning != nil && *dbxRow.Versioning, nil
+}
+
+// GetBucketVersioning returns whether new writes to the bucket create new object versions.
+func (db *bucketsDB) GetBucketVersioning(ctx context.Context, bucketName []byte, projectID uuid.UUID) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxBucket, err := db.db.Get_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, storj.ErrBucketNotFound.New("%s", bucketName)
+		}
+		return false, storj.ErrBucket.Wrap(err)
 	}
 
-	return placement, nil
+	return dbxBucket.Versioning != nil && *dbxBucket.Versioning, nil
+}
+
+// SetBucketVersioning sets whether new writes to the bucket create new object versions.
+func (db *bucketsDB) SetBucketVersioning(ctx context.Context, bucketName []byte, projectID uuid.UUID, enabled bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxBucket, err := db.db.Update_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+		dbx.BucketMetainfo_Update_Fields{
+			Versioning: dbx.BucketMetainfo_Versioning(enabled),
+		},
+	)
+	if err != nil {
+		return storj.ErrBucket.Wrap(err)
+	}
+	if dbxBucket == nil {
+		return storj.ErrBucketNotFound.New("%s", bucketName)
+	}
+	return nil
 }
 
 // GetMinimalBucket returns existing bucket with minimal number of fields.
@@ -43,7 +43,7 @@ func (db *ConsoleDB) Projects() console.Projects {
 
 // ProjectMembers is a getter for ProjectMembers repository.
 func (db *ConsoleDB) ProjectMembers() console.ProjectMembers {
-	return &projectMembers{db.methods, db.db}
+	return &projectMembers{methods: db.methods, db: db.db, tx: db.tx}
 }
 
 // APIKeys is a getter for APIKeys repository.
@@ -74,6 +74,16 @@ func (db *ConsoleDB) WebappSessions() consoleauth.WebappSessions {
 	return &webappSessions{db.methods}
 }
 
+// SecurityEvents is a getter for SecurityEvents repository.
+func (db *ConsoleDB) SecurityEvents() console.SecurityEvents {
+	return &securityEvents{methods: db.methods, db: db.db}
+}
+
+// DataErasureJobs is a getter for DataErasureJobs repository.
+func (db *ConsoleDB) DataErasureJobs() console.DataErasureJobs {
+	return &dataErasureJobs{db.db}
+}
+
 // WithTx is a method for executing and retrying transaction.
 func (db *ConsoleDB) WithTx(ctx context.Context, fn func(context.Context, console.DBTx) error) error {
 	if db.db == nil {
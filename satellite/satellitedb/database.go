@@ -199,6 +199,11 @@ func (dbc *satelliteDBCollection) RepairQueue() queue.RepairQueue {
 	return &repairQueue{db: dbc.getByName("repairqueue")}
 }
 
+// RepairCheckpoints is a getter for RepairCheckpoints repository.
+func (dbc *satelliteDBCollection) RepairCheckpoints() queue.RepairCheckpoints {
+	return &repairCheckpoints{db: dbc.getByName("repairqueue")}
+}
+
 // StoragenodeAccounting returns database for tracking storagenode usage.
 func (dbc *satelliteDBCollection) StoragenodeAccounting() accounting.StoragenodeAccounting {
 	return &StoragenodeAccounting{db: dbc.getByName("storagenodeaccounting")}
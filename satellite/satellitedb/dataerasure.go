@@ -0,0 +1,167 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that *dataErasureJobs implements console.DataErasureJobs.
+var _ console.DataErasureJobs = (*dataErasureJobs)(nil)
+
+// dataErasureJobs is an implementation of console.DataErasureJobs.
+type dataErasureJobs struct {
+	db *satelliteDB
+}
+
+// Insert stores a new data erasure job. When the job is pending and the user
+// already has a pending job, the existing job is returned instead.
+func (jobs *dataErasureJobs) Insert(ctx context.Context, job console.DataErasureJob) (_ *console.DataErasureJob, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if job.ID.IsZero() {
+		job.ID, err = uuid.New()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+
+	var existing *console.DataErasureJob
+	err = jobs.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		existing = nil
+
+		if job.Status == console.DataErasurePending {
+			// locking the user serializes concurrent requests of the same user.
+			var userID uuid.UUID
+			err := tx.Tx.QueryRowContext(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, job.UserID).Scan(&userID)
+			if err != nil {
+				return err
+			}
+
+			pending, err := scanDataErasureJob(tx.Tx.QueryRowContext(ctx, `
+				SELECT id, user_id, status, deleted_objects, created_at, completed_at
+				FROM data_erasure_jobs
+				WHERE user_id = $1 AND status = $2
+				LIMIT 1
+			`, job.UserID, int(console.DataErasurePending)))
+			switch {
+			case err == nil:
+				existing = pending
+				return nil
+			case !errors.Is(err, sql.ErrNoRows):
+				return err
+			}
+		}
+
+		_, err := tx.Tx.ExecContext(ctx, `
+			INSERT INTO data_erasure_jobs (id, user_id, status, deleted_objects, created_at, completed_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, job.ID, job.UserID, int(job.Status), job.DeletedObjects, job.CreatedAt, job.CompletedAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	return &job, nil
+}
+
+// Get returns the data erasure job with the given ID.
+func (jobs *dataErasureJobs) Get(ctx context.Context, id uuid.UUID) (_ *console.DataErasureJob, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return scanDataErasureJob(jobs.db.QueryRowContext(ctx, `
+		SELECT id, user_id, status, deleted_objects, created_at, completed_at
+		FROM data_erasure_jobs
+		WHERE id = $1
+	`, id))
+}
+
+// scanDataErasureJob scans a data erasure job from the row.
+func scanDataErasureJob(row *sql.Row) (*console.DataErasureJob, error) {
+	var job console.DataErasureJob
+	var status int
+	err := row.Scan(&job.ID, &job.UserID, &status, &job.DeletedObjects, &job.CreatedAt, &job.CompletedAt)
+	if err != nil {
+		return nil, err
+	}
+	job.Status = console.DataErasureJobStatus(status)
+
+	return &job, nil
+}
+
+// ListPending returns up to limit pending jobs, oldest first.
+func (jobs *dataErasureJobs) ListPending(ctx context.Context, limit int) (_ []console.DataErasureJob, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 {
+		return nil, errs.New("limit must be positive")
+	}
+
+	rows, err := jobs.db.QueryContext(ctx, `
+		SELECT id, user_id, status, deleted_objects, created_at, completed_at
+		FROM data_erasure_jobs
+		WHERE status = $1
+		ORDER BY created_at, id
+		LIMIT $2
+	`, int(console.DataErasurePending), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var pending []console.DataErasureJob
+	for rows.Next() {
+		var job console.DataErasureJob
+		var status int
+		err = rows.Scan(&job.ID, &job.UserID, &status, &job.DeletedObjects, &job.CreatedAt, &job.CompletedAt)
+		if err != nil {
+			return nil, err
+		}
+		job.Status = console.DataErasureJobStatus(status)
+
+		pending = append(pending, job)
+	}
+
+	return pending, rows.Err()
+}
+
+// AddDeletedObjects adds count to the number of objects deleted by the job.
+func (jobs *dataErasureJobs) AddDeletedObjects(ctx context.Context, id uuid.UUID, count int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = jobs.db.ExecContext(ctx, `
+		UPDATE data_erasure_jobs
+		SET deleted_objects = deleted_objects + $2
+		WHERE id = $1
+	`, id, count)
+	return err
+}
+
+// Complete marks the job as completed.
+func (jobs *dataErasureJobs) Complete(ctx context.Context, id uuid.UUID, completedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = jobs.db.ExecContext(ctx, `
+		UPDATE data_erasure_jobs
+		SET status = $2, completed_at = $3
+		WHERE id = $1
+	`, id, int(console.DataErasureCompleted), completedAt)
+	return err
+}
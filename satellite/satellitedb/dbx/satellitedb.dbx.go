@@ -426,6 +426,15 @@ CREATE TABLE coupon_usages (
 	period timestamp with time zone NOT NULL,
 	PRIMARY KEY ( coupon_id, period )
 );
+CREATE TABLE data_erasure_jobs (
+	id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	status integer NOT NULL,
+	deleted_objects bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	completed_at timestamp with time zone,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE graceful_exit_progress (
 	node_id bytea NOT NULL,
 	bytes_transferred bigint NOT NULL,
@@ -484,6 +493,9 @@ CREATE TABLE nodes (
 	exit_loop_completed_at timestamp with time zone,
 	exit_finished_at timestamp with time zone,
 	exit_success boolean NOT NULL DEFAULT false,
+	contact_up_transitions integer NOT NULL DEFAULT 0,
+	contact_down_transitions integer NOT NULL DEFAULT 0,
+	contact_transitions_since timestamp with time zone,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE node_api_versions (
@@ -557,6 +569,8 @@ CREATE TABLE projects (
 	rate_limit integer,
 	burst_limit integer,
 	max_buckets integer,
+	egress_rate_limit bigint,
+	admin_egress_rate_limit bigint,
 	partner_id bytea,
 	user_agent bytea,
 	owner_id bytea NOT NULL,
@@ -585,6 +599,21 @@ CREATE TABLE registration_tokens (
 	PRIMARY KEY ( secret ),
 	UNIQUE ( owner_id )
 );
+CREATE TABLE repair_bandwidth_rollups (
+	placement integer NOT NULL,
+	interval_start timestamp with time zone NOT NULL,
+	action integer NOT NULL,
+	allocated bigint NOT NULL,
+	PRIMARY KEY ( placement, interval_start, action )
+);
+CREATE TABLE repair_checkpoints (
+	stream_id bytea NOT NULL,
+	position bigint NOT NULL,
+	piece_num integer NOT NULL,
+	node_id bytea NOT NULL,
+	uploaded_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( stream_id, position, piece_num )
+);
 CREATE TABLE repair_queue (
 	stream_id bytea NOT NULL,
 	position bigint NOT NULL,
@@ -592,6 +621,10 @@ CREATE TABLE repair_queue (
 	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
+	held boolean NOT NULL DEFAULT false,
+	attempts integer NOT NULL DEFAULT 0,
+	exhausted_at timestamp with time zone,
+	placement integer,
 	PRIMARY KEY ( stream_id, position )
 );
 CREATE TABLE reputations (
@@ -626,6 +659,15 @@ CREATE TABLE revocations (
 	api_key_id bytea NOT NULL,
 	PRIMARY KEY ( revoked )
 );
+CREATE TABLE security_events (
+	id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	event_type text NOT NULL,
+	ip_address text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	reviewed_at timestamp with time zone,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE segment_pending_audits (
 	node_id bytea NOT NULL,
 	stream_id bytea NOT NULL,
@@ -800,6 +842,7 @@ CREATE TABLE webapp_sessions (
 	user_agent text NOT NULL,
 	status integer NOT NULL,
 	expires_at timestamp with time zone NOT NULL,
+	last_used_at timestamp with time zone,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE api_keys (
@@ -811,6 +854,7 @@ CREATE TABLE api_keys (
 	partner_id bytea,
 	user_agent bytea,
 	created_at timestamp with time zone NOT NULL,
+	expires_at timestamp with time zone,
 	PRIMARY KEY ( id ),
 	UNIQUE ( head ),
 	UNIQUE ( name, project_id )
@@ -833,9 +877,17 @@ CREATE TABLE bucket_metainfos (
 	default_redundancy_optimal_shares integer NOT NULL,
 	default_redundancy_total_shares integer NOT NULL,
 	placement integer,
+	versioning boolean,
 	PRIMARY KEY ( id ),
 	UNIQUE ( project_id, name )
 );
+CREATE TABLE project_member_roles (
+	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	role integer NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( member_id, project_id )
+);
 CREATE TABLE project_members (
 	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -867,6 +919,7 @@ CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket
 CREATE INDEX bucket_bandwidth_rollups_archive_project_id_action_interval_index ON bucket_bandwidth_rollup_archives ( project_id, action, interval_start ) ;
 CREATE INDEX bucket_bandwidth_rollups_archive_action_interval_project_id_index ON bucket_bandwidth_rollup_archives ( action, interval_start, project_id ) ;
 CREATE INDEX bucket_storage_tallies_project_id_interval_start_index ON bucket_storage_tallies ( project_id, interval_start ) ;
+CREATE INDEX data_erasure_jobs_status_created_at_index ON data_erasure_jobs ( status, created_at ) ;
 CREATE INDEX graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index ON graceful_exit_segment_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at ) ;
 CREATE INDEX node_last_ip ON nodes ( last_net ) ;
 CREATE INDEX nodes_dis_unk_off_exit_fin_last_success_index ON nodes ( disqualified, unknown_audit_suspended, offline_suspended, exit_finished_at, last_contact_success ) ;
@@ -879,6 +932,8 @@ CREATE INDEX oauth_tokens_user_id_index ON oauth_tokens ( user_id ) ;
 CREATE INDEX oauth_tokens_client_id_index ON oauth_tokens ( client_id ) ;
 CREATE INDEX repair_queue_updated_at_index ON repair_queue ( updated_at ) ;
 CREATE INDEX repair_queue_num_healthy_pieces_attempted_at_index ON repair_queue ( segment_health, attempted_at ) ;
+CREATE INDEX repair_queue_placement_segment_health_attempted_at_index ON repair_queue ( placement, segment_health, attempted_at ) ;
+CREATE INDEX security_events_user_id_created_at_index ON security_events ( user_id, created_at ) ;
 CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start ) ;
 CREATE INDEX storagenode_bandwidth_rollup_archives_interval_start_index ON storagenode_bandwidth_rollup_archives ( interval_start ) ;
 CREATE INDEX storagenode_payments_node_id_period_index ON storagenode_payments ( node_id, period ) ;
@@ -1092,6 +1147,15 @@ CREATE TABLE coupon_usages (
 	period timestamp with time zone NOT NULL,
 	PRIMARY KEY ( coupon_id, period )
 );
+CREATE TABLE data_erasure_jobs (
+	id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	status integer NOT NULL,
+	deleted_objects bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	completed_at timestamp with time zone,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE graceful_exit_progress (
 	node_id bytea NOT NULL,
 	bytes_transferred bigint NOT NULL,
@@ -1150,6 +1214,9 @@ CREATE TABLE nodes (
 	exit_loop_completed_at timestamp with time zone,
 	exit_finished_at timestamp with time zone,
 	exit_success boolean NOT NULL DEFAULT false,
+	contact_up_transitions integer NOT NULL DEFAULT 0,
+	contact_down_transitions integer NOT NULL DEFAULT 0,
+	contact_transitions_since timestamp with time zone,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE node_api_versions (
@@ -1223,6 +1290,8 @@ CREATE TABLE projects (
 	rate_limit integer,
 	burst_limit integer,
 	max_buckets integer,
+	egress_rate_limit bigint,
+	admin_egress_rate_limit bigint,
 	partner_id bytea,
 	user_agent bytea,
 	owner_id bytea NOT NULL,
@@ -1251,6 +1320,21 @@ CREATE TABLE registration_tokens (
 	PRIMARY KEY ( secret ),
 	UNIQUE ( owner_id )
 );
+CREATE TABLE repair_bandwidth_rollups (
+	placement integer NOT NULL,
+	interval_start timestamp with time zone NOT NULL,
+	action integer NOT NULL,
+	allocated bigint NOT NULL,
+	PRIMARY KEY ( placement, interval_start, action )
+);
+CREATE TABLE repair_checkpoints (
+	stream_id bytea NOT NULL,
+	position bigint NOT NULL,
+	piece_num integer NOT NULL,
+	node_id bytea NOT NULL,
+	uploaded_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( stream_id, position, piece_num )
+);
 CREATE TABLE repair_queue (
 	stream_id bytea NOT NULL,
 	position bigint NOT NULL,
@@ -1258,6 +1342,10 @@ CREATE TABLE repair_queue (
 	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
+	held boolean NOT NULL DEFAULT false,
+	attempts integer NOT NULL DEFAULT 0,
+	exhausted_at timestamp with time zone,
+	placement integer,
 	PRIMARY KEY ( stream_id, position )
 );
 CREATE TABLE reputations (
@@ -1292,6 +1380,15 @@ CREATE TABLE revocations (
 	api_key_id bytea NOT NULL,
 	PRIMARY KEY ( revoked )
 );
+CREATE TABLE security_events (
+	id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	event_type text NOT NULL,
+	ip_address text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	reviewed_at timestamp with time zone,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE segment_pending_audits (
 	node_id bytea NOT NULL,
 	stream_id bytea NOT NULL,
@@ -1466,6 +1563,7 @@ CREATE TABLE webapp_sessions (
 	user_agent text NOT NULL,
 	status integer NOT NULL,
 	expires_at timestamp with time zone NOT NULL,
+	last_used_at timestamp with time zone,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE api_keys (
@@ -1477,6 +1575,7 @@ CREATE TABLE api_keys (
 	partner_id bytea,
 	user_agent bytea,
 	created_at timestamp with time zone NOT NULL,
+	expires_at timestamp with time zone,
 	PRIMARY KEY ( id ),
 	UNIQUE ( head ),
 	UNIQUE ( name, project_id )
@@ -1499,9 +1598,17 @@ CREATE TABLE bucket_metainfos (
 	default_redundancy_optimal_shares integer NOT NULL,
 	default_redundancy_total_shares integer NOT NULL,
 	placement integer,
+	versioning boolean,
 	PRIMARY KEY ( id ),
 	UNIQUE ( project_id, name )
 );
+CREATE TABLE project_member_roles (
+	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	role integer NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( member_id, project_id )
+);
 CREATE TABLE project_members (
 	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -1533,6 +1640,7 @@ CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket
 CREATE INDEX bucket_bandwidth_rollups_archive_project_id_action_interval_index ON bucket_bandwidth_rollup_archives ( project_id, action, interval_start ) ;
 CREATE INDEX bucket_bandwidth_rollups_archive_action_interval_project_id_index ON bucket_bandwidth_rollup_archives ( action, interval_start, project_id ) ;
 CREATE INDEX bucket_storage_tallies_project_id_interval_start_index ON bucket_storage_tallies ( project_id, interval_start ) ;
+CREATE INDEX data_erasure_jobs_status_created_at_index ON data_erasure_jobs ( status, created_at ) ;
 CREATE INDEX graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index ON graceful_exit_segment_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at ) ;
 CREATE INDEX node_last_ip ON nodes ( last_net ) ;
 CREATE INDEX nodes_dis_unk_off_exit_fin_last_success_index ON nodes ( disqualified, unknown_audit_suspended, offline_suspended, exit_finished_at, last_contact_success ) ;
@@ -1545,6 +1653,8 @@ CREATE INDEX oauth_tokens_user_id_index ON oauth_tokens ( user_id ) ;
 CREATE INDEX oauth_tokens_client_id_index ON oauth_tokens ( client_id ) ;
 CREATE INDEX repair_queue_updated_at_index ON repair_queue ( updated_at ) ;
 CREATE INDEX repair_queue_num_healthy_pieces_attempted_at_index ON repair_queue ( segment_health, attempted_at ) ;
+CREATE INDEX repair_queue_placement_segment_health_attempted_at_index ON repair_queue ( placement, segment_health, attempted_at ) ;
+CREATE INDEX security_events_user_id_created_at_index ON security_events ( user_id, created_at ) ;
 CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start ) ;
 CREATE INDEX storagenode_bandwidth_rollup_archives_interval_start_index ON storagenode_bandwidth_rollup_archives ( interval_start ) ;
 CREATE INDEX storagenode_payments_node_id_period_index ON storagenode_payments ( node_id, period ) ;
@@ -3260,6 +3370,156 @@ func (f CouponUsage_Period_Field) value() interface{} {
 
 func (CouponUsage_Period_Field) _Column() string { return "period" }
 
+type DataErasureJob struct {
+	Id             []byte
+	UserId         []byte
+	Status         int
+	DeletedObjects int64
+	CreatedAt      time.Time
+	CompletedAt    *time.Time
+}
+
+func (DataErasureJob) _Table() string { return "data_erasure_jobs" }
+
+type DataErasureJob_Create_Fields struct {
+	CompletedAt DataErasureJob_CompletedAt_Field
+}
+
+type DataErasureJob_Update_Fields struct {
+	Status         DataErasureJob_Status_Field
+	DeletedObjects DataErasureJob_DeletedObjects_Field
+	CompletedAt    DataErasureJob_CompletedAt_Field
+}
+
+type DataErasureJob_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func DataErasureJob_Id(v []byte) DataErasureJob_Id_Field {
+	return DataErasureJob_Id_Field{_set: true, _value: v}
+}
+
+func (f DataErasureJob_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (DataErasureJob_Id_Field) _Column() string { return "id" }
+
+type DataErasureJob_UserId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func DataErasureJob_UserId(v []byte) DataErasureJob_UserId_Field {
+	return DataErasureJob_UserId_Field{_set: true, _value: v}
+}
+
+func (f DataErasureJob_UserId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (DataErasureJob_UserId_Field) _Column() string { return "user_id" }
+
+type DataErasureJob_Status_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func DataErasureJob_Status(v int) DataErasureJob_Status_Field {
+	return DataErasureJob_Status_Field{_set: true, _value: v}
+}
+
+func (f DataErasureJob_Status_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (DataErasureJob_Status_Field) _Column() string { return "status" }
+
+type DataErasureJob_DeletedObjects_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func DataErasureJob_DeletedObjects(v int64) DataErasureJob_DeletedObjects_Field {
+	return DataErasureJob_DeletedObjects_Field{_set: true, _value: v}
+}
+
+func (f DataErasureJob_DeletedObjects_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (DataErasureJob_DeletedObjects_Field) _Column() string { return "deleted_objects" }
+
+type DataErasureJob_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func DataErasureJob_CreatedAt(v time.Time) DataErasureJob_CreatedAt_Field {
+	return DataErasureJob_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f DataErasureJob_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (DataErasureJob_CreatedAt_Field) _Column() string { return "created_at" }
+
+type DataErasureJob_CompletedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func DataErasureJob_CompletedAt(v time.Time) DataErasureJob_CompletedAt_Field {
+	return DataErasureJob_CompletedAt_Field{_set: true, _value: &v}
+}
+
+func DataErasureJob_CompletedAt_Raw(v *time.Time) DataErasureJob_CompletedAt_Field {
+	if v == nil {
+		return DataErasureJob_CompletedAt_Null()
+	}
+	return DataErasureJob_CompletedAt(*v)
+}
+
+func DataErasureJob_CompletedAt_Null() DataErasureJob_CompletedAt_Field {
+	return DataErasureJob_CompletedAt_Field{_set: true, _null: true}
+}
+
+func (f DataErasureJob_CompletedAt_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f DataErasureJob_CompletedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (DataErasureJob_CompletedAt_Field) _Column() string { return "completed_at" }
+
 type GracefulExitProgress struct {
 	NodeId            []byte
 	BytesTransferred  int64
@@ -3749,103 +4009,112 @@ func (GracefulExitSegmentTransfer_OrderLimitSendCount_Field) _Column() string {
 }
 
 type Node struct {
-	Id                     []byte
-	Address                string
-	LastNet                string
-	LastIpPort             *string
-	CountryCode            *string
-	Protocol               int
-	Type                   int
-	Email                  string
-	Wallet                 string
-	WalletFeatures         string
-	FreeDisk               int64
-	PieceCount             int64
-	Major                  int64
-	Minor                  int64
-	Patch                  int64
-	Hash                   string
-	Timestamp              time.Time
-	Release                bool
-	Latency90              int64
-	VettedAt               *time.Time
-	CreatedAt              time.Time
-	UpdatedAt              time.Time
-	LastContactSuccess     time.Time
-	LastContactFailure     time.Time
-	Disqualified           *time.Time
-	DisqualificationReason *int
-	UnknownAuditSuspended  *time.Time
-	OfflineSuspended       *time.Time
-	UnderReview            *time.Time
-	ExitInitiatedAt        *time.Time
-	ExitLoopCompletedAt    *time.Time
-	ExitFinishedAt         *time.Time
-	ExitSuccess            bool
+	Id                      []byte
+	Address                 string
+	LastNet                 string
+	LastIpPort              *string
+	CountryCode             *string
+	Protocol                int
+	Type                    int
+	Email                   string
+	Wallet                  string
+	WalletFeatures          string
+	FreeDisk                int64
+	PieceCount              int64
+	Major                   int64
+	Minor                   int64
+	Patch                   int64
+	Hash                    string
+	Timestamp               time.Time
+	Release                 bool
+	Latency90               int64
+	VettedAt                *time.Time
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+	LastContactSuccess      time.Time
+	LastContactFailure      time.Time
+	Disqualified            *time.Time
+	DisqualificationReason  *int
+	UnknownAuditSuspended   *time.Time
+	OfflineSuspended        *time.Time
+	UnderReview             *time.Time
+	ExitInitiatedAt         *time.Time
+	ExitLoopCompletedAt     *time.Time
+	ExitFinishedAt          *time.Time
+	ExitSuccess             bool
+	ContactUpTransitions    int
+	ContactDownTransitions  int
+	ContactTransitionsSince *time.Time
 }
 
 func (Node) _Table() string { return "nodes" }
 
 type Node_Create_Fields struct {
-	Address                Node_Address_Field
-	LastIpPort             Node_LastIpPort_Field
-	CountryCode            Node_CountryCode_Field
-	Protocol               Node_Protocol_Field
-	Type                   Node_Type_Field
-	WalletFeatures         Node_WalletFeatures_Field
-	FreeDisk               Node_FreeDisk_Field
-	Major                  Node_Major_Field
-	Minor                  Node_Minor_Field
-	Patch                  Node_Patch_Field
-	Hash                   Node_Hash_Field
-	Timestamp              Node_Timestamp_Field
-	Release                Node_Release_Field
-	Latency90              Node_Latency90_Field
-	VettedAt               Node_VettedAt_Field
-	LastContactSuccess     Node_LastContactSuccess_Field
-	LastContactFailure     Node_LastContactFailure_Field
-	Disqualified           Node_Disqualified_Field
-	DisqualificationReason Node_DisqualificationReason_Field
-	UnknownAuditSuspended  Node_UnknownAuditSuspended_Field
-	OfflineSuspended       Node_OfflineSuspended_Field
-	UnderReview            Node_UnderReview_Field
-	ExitInitiatedAt        Node_ExitInitiatedAt_Field
-	ExitLoopCompletedAt    Node_ExitLoopCompletedAt_Field
-	ExitFinishedAt         Node_ExitFinishedAt_Field
-	ExitSuccess            Node_ExitSuccess_Field
+	Address                 Node_Address_Field
+	LastIpPort              Node_LastIpPort_Field
+	CountryCode             Node_CountryCode_Field
+	Protocol                Node_Protocol_Field
+	Type                    Node_Type_Field
+	WalletFeatures          Node_WalletFeatures_Field
+	FreeDisk                Node_FreeDisk_Field
+	Major                   Node_Major_Field
+	Minor                   Node_Minor_Field
+	Patch                   Node_Patch_Field
+	Hash                    Node_Hash_Field
+	Timestamp               Node_Timestamp_Field
+	Release                 Node_Release_Field
+	Latency90               Node_Latency90_Field
+	VettedAt                Node_VettedAt_Field
+	LastContactSuccess      Node_LastContactSuccess_Field
+	LastContactFailure      Node_LastContactFailure_Field
+	Disqualified            Node_Disqualified_Field
+	DisqualificationReason  Node_DisqualificationReason_Field
+	UnknownAuditSuspended   Node_UnknownAuditSuspended_Field
+	OfflineSuspended        Node_OfflineSuspended_Field
+	UnderReview             Node_UnderReview_Field
+	ExitInitiatedAt         Node_ExitInitiatedAt_Field
+	ExitLoopCompletedAt     Node_ExitLoopCompletedAt_Field
+	ExitFinishedAt          Node_ExitFinishedAt_Field
+	ExitSuccess             Node_ExitSuccess_Field
+	ContactUpTransitions    Node_ContactUpTransitions_Field
+	ContactDownTransitions  Node_ContactDownTransitions_Field
+	ContactTransitionsSince Node_ContactTransitionsSince_Field
 }
 
 type Node_Update_Fields struct {
-	Address                Node_Address_Field
-	LastNet                Node_LastNet_Field
-	LastIpPort             Node_LastIpPort_Field
-	CountryCode            Node_CountryCode_Field
-	Protocol               Node_Protocol_Field
-	Type                   Node_Type_Field
-	Email                  Node_Email_Field
-	Wallet                 Node_Wallet_Field
-	WalletFeatures         Node_WalletFeatures_Field
-	FreeDisk               Node_FreeDisk_Field
-	PieceCount             Node_PieceCount_Field
-	Major                  Node_Major_Field
-	Minor                  Node_Minor_Field
-	Patch                  Node_Patch_Field
-	Hash                   Node_Hash_Field
-	Timestamp              Node_Timestamp_Field
-	Release                Node_Release_Field
-	Latency90              Node_Latency90_Field
-	VettedAt               Node_VettedAt_Field
-	LastContactSuccess     Node_LastContactSuccess_Field
-	LastContactFailure     Node_LastContactFailure_Field
-	Disqualified           Node_Disqualified_Field
-	DisqualificationReason Node_DisqualificationReason_Field
-	UnknownAuditSuspended  Node_UnknownAuditSuspended_Field
-	OfflineSuspended       Node_OfflineSuspended_Field
-	UnderReview            Node_UnderReview_Field
-	ExitInitiatedAt        Node_ExitInitiatedAt_Field
-	ExitLoopCompletedAt    Node_ExitLoopCompletedAt_Field
-	ExitFinishedAt         Node_ExitFinishedAt_Field
-	ExitSuccess            Node_ExitSuccess_Field
+	Address                 Node_Address_Field
+	LastNet                 Node_LastNet_Field
+	LastIpPort              Node_LastIpPort_Field
+	CountryCode             Node_CountryCode_Field
+	Protocol                Node_Protocol_Field
+	Type                    Node_Type_Field
+	Email                   Node_Email_Field
+	Wallet                  Node_Wallet_Field
+	WalletFeatures          Node_WalletFeatures_Field
+	FreeDisk                Node_FreeDisk_Field
+	PieceCount              Node_PieceCount_Field
+	Major                   Node_Major_Field
+	Minor                   Node_Minor_Field
+	Patch                   Node_Patch_Field
+	Hash                    Node_Hash_Field
+	Timestamp               Node_Timestamp_Field
+	Release                 Node_Release_Field
+	Latency90               Node_Latency90_Field
+	VettedAt                Node_VettedAt_Field
+	LastContactSuccess      Node_LastContactSuccess_Field
+	LastContactFailure      Node_LastContactFailure_Field
+	Disqualified            Node_Disqualified_Field
+	DisqualificationReason  Node_DisqualificationReason_Field
+	UnknownAuditSuspended   Node_UnknownAuditSuspended_Field
+	OfflineSuspended        Node_OfflineSuspended_Field
+	UnderReview             Node_UnderReview_Field
+	ExitInitiatedAt         Node_ExitInitiatedAt_Field
+	ExitLoopCompletedAt     Node_ExitLoopCompletedAt_Field
+	ExitFinishedAt          Node_ExitFinishedAt_Field
+	ExitSuccess             Node_ExitSuccess_Field
+	ContactUpTransitions    Node_ContactUpTransitions_Field
+	ContactDownTransitions  Node_ContactDownTransitions_Field
+	ContactTransitionsSince Node_ContactTransitionsSince_Field
 }
 
 type Node_Id_Field struct {
@@ -4620,6 +4889,78 @@ func (f Node_ExitSuccess_Field) value() interface{} {
 
 func (Node_ExitSuccess_Field) _Column() string { return "exit_success" }
 
+type Node_ContactUpTransitions_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Node_ContactUpTransitions(v int) Node_ContactUpTransitions_Field {
+	return Node_ContactUpTransitions_Field{_set: true, _value: v}
+}
+
+func (f Node_ContactUpTransitions_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_ContactUpTransitions_Field) _Column() string { return "contact_up_transitions" }
+
+type Node_ContactDownTransitions_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Node_ContactDownTransitions(v int) Node_ContactDownTransitions_Field {
+	return Node_ContactDownTransitions_Field{_set: true, _value: v}
+}
+
+func (f Node_ContactDownTransitions_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_ContactDownTransitions_Field) _Column() string { return "contact_down_transitions" }
+
+type Node_ContactTransitionsSince_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func Node_ContactTransitionsSince(v time.Time) Node_ContactTransitionsSince_Field {
+	return Node_ContactTransitionsSince_Field{_set: true, _value: &v}
+}
+
+func Node_ContactTransitionsSince_Raw(v *time.Time) Node_ContactTransitionsSince_Field {
+	if v == nil {
+		return Node_ContactTransitionsSince_Null()
+	}
+	return Node_ContactTransitionsSince(*v)
+}
+
+func Node_ContactTransitionsSince_Null() Node_ContactTransitionsSince_Field {
+	return Node_ContactTransitionsSince_Field{_set: true, _null: true}
+}
+
+func (f Node_ContactTransitionsSince_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f Node_ContactTransitionsSince_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_ContactTransitionsSince_Field) _Column() string { return "contact_transitions_since" }
+
 type NodeApiVersion struct {
 	Id         []byte
 	ApiVersion int
@@ -5616,43 +5957,49 @@ func (f PeerIdentity_UpdatedAt_Field) value() interface{} {
 func (PeerIdentity_UpdatedAt_Field) _Column() string { return "updated_at" }
 
 type Project struct {
-	Id             []byte
-	Name           string
-	Description    string
-	UsageLimit     *int64
-	BandwidthLimit *int64
-	SegmentLimit   *int64
-	RateLimit      *int
-	BurstLimit     *int
-	MaxBuckets     *int
-	PartnerId      []byte
-	UserAgent      []byte
-	OwnerId        []byte
-	CreatedAt      time.Time
+	Id                   []byte
+	Name                 string
+	Description          string
+	UsageLimit           *int64
+	BandwidthLimit       *int64
+	SegmentLimit         *int64
+	RateLimit            *int
+	BurstLimit           *int
+	MaxBuckets           *int
+	EgressRateLimit      *int64
+	AdminEgressRateLimit *int64
+	PartnerId            []byte
+	UserAgent            []byte
+	OwnerId              []byte
+	CreatedAt            time.Time
 }
 
 func (Project) _Table() string { return "projects" }
 
 type Project_Create_Fields struct {
-	UsageLimit     Project_UsageLimit_Field
-	BandwidthLimit Project_BandwidthLimit_Field
-	SegmentLimit   Project_SegmentLimit_Field
-	RateLimit      Project_RateLimit_Field
-	BurstLimit     Project_BurstLimit_Field
-	MaxBuckets     Project_MaxBuckets_Field
-	PartnerId      Project_PartnerId_Field
-	UserAgent      Project_UserAgent_Field
+	UsageLimit           Project_UsageLimit_Field
+	BandwidthLimit       Project_BandwidthLimit_Field
+	SegmentLimit         Project_SegmentLimit_Field
+	RateLimit            Project_RateLimit_Field
+	BurstLimit           Project_BurstLimit_Field
+	MaxBuckets           Project_MaxBuckets_Field
+	EgressRateLimit      Project_EgressRateLimit_Field
+	AdminEgressRateLimit Project_AdminEgressRateLimit_Field
+	PartnerId            Project_PartnerId_Field
+	UserAgent            Project_UserAgent_Field
 }
 
 type Project_Update_Fields struct {
-	Name           Project_Name_Field
-	Description    Project_Description_Field
-	UsageLimit     Project_UsageLimit_Field
-	BandwidthLimit Project_BandwidthLimit_Field
-	SegmentLimit   Project_SegmentLimit_Field
-	RateLimit      Project_RateLimit_Field
-	BurstLimit     Project_BurstLimit_Field
-	MaxBuckets     Project_MaxBuckets_Field
+	Name                 Project_Name_Field
+	Description          Project_Description_Field
+	UsageLimit           Project_UsageLimit_Field
+	BandwidthLimit       Project_BandwidthLimit_Field
+	SegmentLimit         Project_SegmentLimit_Field
+	RateLimit            Project_RateLimit_Field
+	BurstLimit           Project_BurstLimit_Field
+	MaxBuckets           Project_MaxBuckets_Field
+	EgressRateLimit      Project_EgressRateLimit_Field
+	AdminEgressRateLimit Project_AdminEgressRateLimit_Field
 }
 
 type Project_Id_Field struct {
@@ -5904,85 +6251,151 @@ func (f Project_MaxBuckets_Field) value() interface{} {
 
 func (Project_MaxBuckets_Field) _Column() string { return "max_buckets" }
 
-type Project_PartnerId_Field struct {
+type Project_EgressRateLimit_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value *int64
 }
 
-func Project_PartnerId(v []byte) Project_PartnerId_Field {
-	return Project_PartnerId_Field{_set: true, _value: v}
+func Project_EgressRateLimit(v int64) Project_EgressRateLimit_Field {
+	return Project_EgressRateLimit_Field{_set: true, _value: &v}
 }
 
-func Project_PartnerId_Raw(v []byte) Project_PartnerId_Field {
+func Project_EgressRateLimit_Raw(v *int64) Project_EgressRateLimit_Field {
 	if v == nil {
-		return Project_PartnerId_Null()
+		return Project_EgressRateLimit_Null()
 	}
-	return Project_PartnerId(v)
+	return Project_EgressRateLimit(*v)
 }
 
-func Project_PartnerId_Null() Project_PartnerId_Field {
-	return Project_PartnerId_Field{_set: true, _null: true}
+func Project_EgressRateLimit_Null() Project_EgressRateLimit_Field {
+	return Project_EgressRateLimit_Field{_set: true, _null: true}
 }
 
-func (f Project_PartnerId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+func (f Project_EgressRateLimit_Field) isnull() bool { return !f._set || f._null || f._value == nil }
 
-func (f Project_PartnerId_Field) value() interface{} {
+func (f Project_EgressRateLimit_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_PartnerId_Field) _Column() string { return "partner_id" }
+func (Project_EgressRateLimit_Field) _Column() string { return "egress_rate_limit" }
 
-type Project_UserAgent_Field struct {
+type Project_AdminEgressRateLimit_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value *int64
 }
 
-func Project_UserAgent(v []byte) Project_UserAgent_Field {
-	return Project_UserAgent_Field{_set: true, _value: v}
+func Project_AdminEgressRateLimit(v int64) Project_AdminEgressRateLimit_Field {
+	return Project_AdminEgressRateLimit_Field{_set: true, _value: &v}
 }
 
-func Project_UserAgent_Raw(v []byte) Project_UserAgent_Field {
+func Project_AdminEgressRateLimit_Raw(v *int64) Project_AdminEgressRateLimit_Field {
 	if v == nil {
-		return Project_UserAgent_Null()
+		return Project_AdminEgressRateLimit_Null()
 	}
-	return Project_UserAgent(v)
+	return Project_AdminEgressRateLimit(*v)
 }
 
-func Project_UserAgent_Null() Project_UserAgent_Field {
-	return Project_UserAgent_Field{_set: true, _null: true}
+func Project_AdminEgressRateLimit_Null() Project_AdminEgressRateLimit_Field {
+	return Project_AdminEgressRateLimit_Field{_set: true, _null: true}
 }
 
-func (f Project_UserAgent_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+func (f Project_AdminEgressRateLimit_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
 
-func (f Project_UserAgent_Field) value() interface{} {
+func (f Project_AdminEgressRateLimit_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_UserAgent_Field) _Column() string { return "user_agent" }
+func (Project_AdminEgressRateLimit_Field) _Column() string { return "admin_egress_rate_limit" }
 
-type Project_OwnerId_Field struct {
+type Project_PartnerId_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func Project_OwnerId(v []byte) Project_OwnerId_Field {
-	return Project_OwnerId_Field{_set: true, _value: v}
+func Project_PartnerId(v []byte) Project_PartnerId_Field {
+	return Project_PartnerId_Field{_set: true, _value: v}
 }
 
-func (f Project_OwnerId_Field) value() interface{} {
-	if !f._set || f._null {
-		return nil
-	}
-	return f._value
+func Project_PartnerId_Raw(v []byte) Project_PartnerId_Field {
+	if v == nil {
+		return Project_PartnerId_Null()
+	}
+	return Project_PartnerId(v)
+}
+
+func Project_PartnerId_Null() Project_PartnerId_Field {
+	return Project_PartnerId_Field{_set: true, _null: true}
+}
+
+func (f Project_PartnerId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Project_PartnerId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_PartnerId_Field) _Column() string { return "partner_id" }
+
+type Project_UserAgent_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Project_UserAgent(v []byte) Project_UserAgent_Field {
+	return Project_UserAgent_Field{_set: true, _value: v}
+}
+
+func Project_UserAgent_Raw(v []byte) Project_UserAgent_Field {
+	if v == nil {
+		return Project_UserAgent_Null()
+	}
+	return Project_UserAgent(v)
+}
+
+func Project_UserAgent_Null() Project_UserAgent_Field {
+	return Project_UserAgent_Field{_set: true, _null: true}
+}
+
+func (f Project_UserAgent_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Project_UserAgent_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_UserAgent_Field) _Column() string { return "user_agent" }
+
+type Project_OwnerId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Project_OwnerId(v []byte) Project_OwnerId_Field {
+	return Project_OwnerId_Field{_set: true, _value: v}
+}
+
+func (f Project_OwnerId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
 }
 
 func (Project_OwnerId_Field) _Column() string { return "owner_id" }
@@ -6192,6 +6605,96 @@ func (f ProjectBandwidthRollup_EgressAllocated_Field) value() interface{} {
 
 func (ProjectBandwidthRollup_EgressAllocated_Field) _Column() string { return "egress_allocated" }
 
+type ProjectMemberRole struct {
+	MemberId  []byte
+	ProjectId []byte
+	Role      int
+	UpdatedAt time.Time
+}
+
+func (ProjectMemberRole) _Table() string { return "project_member_roles" }
+
+type ProjectMemberRole_Update_Fields struct {
+	Role      ProjectMemberRole_Role_Field
+	UpdatedAt ProjectMemberRole_UpdatedAt_Field
+}
+
+type ProjectMemberRole_MemberId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectMemberRole_MemberId(v []byte) ProjectMemberRole_MemberId_Field {
+	return ProjectMemberRole_MemberId_Field{_set: true, _value: v}
+}
+
+func (f ProjectMemberRole_MemberId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectMemberRole_MemberId_Field) _Column() string { return "member_id" }
+
+type ProjectMemberRole_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectMemberRole_ProjectId(v []byte) ProjectMemberRole_ProjectId_Field {
+	return ProjectMemberRole_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f ProjectMemberRole_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectMemberRole_ProjectId_Field) _Column() string { return "project_id" }
+
+type ProjectMemberRole_Role_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func ProjectMemberRole_Role(v int) ProjectMemberRole_Role_Field {
+	return ProjectMemberRole_Role_Field{_set: true, _value: v}
+}
+
+func (f ProjectMemberRole_Role_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectMemberRole_Role_Field) _Column() string { return "role" }
+
+type ProjectMemberRole_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectMemberRole_UpdatedAt(v time.Time) ProjectMemberRole_UpdatedAt_Field {
+	return ProjectMemberRole_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectMemberRole_UpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectMemberRole_UpdatedAt_Field) _Column() string { return "updated_at" }
+
 type RegistrationToken struct {
 	Secret       []byte
 	OwnerId      []byte
@@ -6298,6 +6801,203 @@ func (f RegistrationToken_CreatedAt_Field) value() interface{} {
 
 func (RegistrationToken_CreatedAt_Field) _Column() string { return "created_at" }
 
+type RepairBandwidthRollup struct {
+	Placement     int
+	IntervalStart time.Time
+	Action        uint
+	Allocated     uint64
+}
+
+func (RepairBandwidthRollup) _Table() string { return "repair_bandwidth_rollups" }
+
+type RepairBandwidthRollup_Update_Fields struct {
+	Allocated RepairBandwidthRollup_Allocated_Field
+}
+
+type RepairBandwidthRollup_Placement_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func RepairBandwidthRollup_Placement(v int) RepairBandwidthRollup_Placement_Field {
+	return RepairBandwidthRollup_Placement_Field{_set: true, _value: v}
+}
+
+func (f RepairBandwidthRollup_Placement_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairBandwidthRollup_Placement_Field) _Column() string { return "placement" }
+
+type RepairBandwidthRollup_IntervalStart_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func RepairBandwidthRollup_IntervalStart(v time.Time) RepairBandwidthRollup_IntervalStart_Field {
+	return RepairBandwidthRollup_IntervalStart_Field{_set: true, _value: v}
+}
+
+func (f RepairBandwidthRollup_IntervalStart_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairBandwidthRollup_IntervalStart_Field) _Column() string { return "interval_start" }
+
+type RepairBandwidthRollup_Action_Field struct {
+	_set   bool
+	_null  bool
+	_value uint
+}
+
+func RepairBandwidthRollup_Action(v uint) RepairBandwidthRollup_Action_Field {
+	return RepairBandwidthRollup_Action_Field{_set: true, _value: v}
+}
+
+func (f RepairBandwidthRollup_Action_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairBandwidthRollup_Action_Field) _Column() string { return "action" }
+
+type RepairBandwidthRollup_Allocated_Field struct {
+	_set   bool
+	_null  bool
+	_value uint64
+}
+
+func RepairBandwidthRollup_Allocated(v uint64) RepairBandwidthRollup_Allocated_Field {
+	return RepairBandwidthRollup_Allocated_Field{_set: true, _value: v}
+}
+
+func (f RepairBandwidthRollup_Allocated_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairBandwidthRollup_Allocated_Field) _Column() string { return "allocated" }
+
+type RepairCheckpoint struct {
+	StreamId   []byte
+	Position   uint64
+	PieceNum   int
+	NodeId     []byte
+	UploadedAt time.Time
+}
+
+func (RepairCheckpoint) _Table() string { return "repair_checkpoints" }
+
+type RepairCheckpoint_Update_Fields struct {
+}
+
+type RepairCheckpoint_StreamId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func RepairCheckpoint_StreamId(v []byte) RepairCheckpoint_StreamId_Field {
+	return RepairCheckpoint_StreamId_Field{_set: true, _value: v}
+}
+
+func (f RepairCheckpoint_StreamId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairCheckpoint_StreamId_Field) _Column() string { return "stream_id" }
+
+type RepairCheckpoint_Position_Field struct {
+	_set   bool
+	_null  bool
+	_value uint64
+}
+
+func RepairCheckpoint_Position(v uint64) RepairCheckpoint_Position_Field {
+	return RepairCheckpoint_Position_Field{_set: true, _value: v}
+}
+
+func (f RepairCheckpoint_Position_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairCheckpoint_Position_Field) _Column() string { return "position" }
+
+type RepairCheckpoint_PieceNum_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func RepairCheckpoint_PieceNum(v int) RepairCheckpoint_PieceNum_Field {
+	return RepairCheckpoint_PieceNum_Field{_set: true, _value: v}
+}
+
+func (f RepairCheckpoint_PieceNum_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairCheckpoint_PieceNum_Field) _Column() string { return "piece_num" }
+
+type RepairCheckpoint_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func RepairCheckpoint_NodeId(v []byte) RepairCheckpoint_NodeId_Field {
+	return RepairCheckpoint_NodeId_Field{_set: true, _value: v}
+}
+
+func (f RepairCheckpoint_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairCheckpoint_NodeId_Field) _Column() string { return "node_id" }
+
+type RepairCheckpoint_UploadedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func RepairCheckpoint_UploadedAt(v time.Time) RepairCheckpoint_UploadedAt_Field {
+	return RepairCheckpoint_UploadedAt_Field{_set: true, _value: v}
+}
+
+func (f RepairCheckpoint_UploadedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairCheckpoint_UploadedAt_Field) _Column() string { return "uploaded_at" }
+
 type RepairQueue struct {
 	StreamId      []byte
 	Position      uint64
@@ -6305,6 +7005,10 @@ type RepairQueue struct {
 	UpdatedAt     time.Time
 	InsertedAt    time.Time
 	SegmentHealth float64
+	Held          bool
+	Attempts      int
+	ExhaustedAt   *time.Time
+	Placement     *int
 }
 
 func (RepairQueue) _Table() string { return "repair_queue" }
@@ -6314,11 +7018,17 @@ type RepairQueue_Create_Fields struct {
 	UpdatedAt     RepairQueue_UpdatedAt_Field
 	InsertedAt    RepairQueue_InsertedAt_Field
 	SegmentHealth RepairQueue_SegmentHealth_Field
+	Held          RepairQueue_Held_Field
+	Attempts      RepairQueue_Attempts_Field
+	ExhaustedAt   RepairQueue_ExhaustedAt_Field
+	Placement     RepairQueue_Placement_Field
 }
 
 type RepairQueue_Update_Fields struct {
 	AttemptedAt RepairQueue_AttemptedAt_Field
 	UpdatedAt   RepairQueue_UpdatedAt_Field
+	Attempts    RepairQueue_Attempts_Field
+	ExhaustedAt RepairQueue_ExhaustedAt_Field
 }
 
 type RepairQueue_StreamId_Field struct {
@@ -6448,6 +7158,112 @@ func (f RepairQueue_SegmentHealth_Field) value() interface{} {
 
 func (RepairQueue_SegmentHealth_Field) _Column() string { return "segment_health" }
 
+type RepairQueue_Held_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func RepairQueue_Held(v bool) RepairQueue_Held_Field {
+	return RepairQueue_Held_Field{_set: true, _value: v}
+}
+
+func (f RepairQueue_Held_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairQueue_Held_Field) _Column() string { return "held" }
+
+type RepairQueue_Attempts_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func RepairQueue_Attempts(v int) RepairQueue_Attempts_Field {
+	return RepairQueue_Attempts_Field{_set: true, _value: v}
+}
+
+func (f RepairQueue_Attempts_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairQueue_Attempts_Field) _Column() string { return "attempts" }
+
+type RepairQueue_ExhaustedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func RepairQueue_ExhaustedAt(v time.Time) RepairQueue_ExhaustedAt_Field {
+	return RepairQueue_ExhaustedAt_Field{_set: true, _value: &v}
+}
+
+func RepairQueue_ExhaustedAt_Raw(v *time.Time) RepairQueue_ExhaustedAt_Field {
+	if v == nil {
+		return RepairQueue_ExhaustedAt_Null()
+	}
+	return RepairQueue_ExhaustedAt(*v)
+}
+
+func RepairQueue_ExhaustedAt_Null() RepairQueue_ExhaustedAt_Field {
+	return RepairQueue_ExhaustedAt_Field{_set: true, _null: true}
+}
+
+func (f RepairQueue_ExhaustedAt_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f RepairQueue_ExhaustedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairQueue_ExhaustedAt_Field) _Column() string { return "exhausted_at" }
+
+type RepairQueue_Placement_Field struct {
+	_set   bool
+	_null  bool
+	_value *int
+}
+
+func RepairQueue_Placement(v int) RepairQueue_Placement_Field {
+	return RepairQueue_Placement_Field{_set: true, _value: &v}
+}
+
+func RepairQueue_Placement_Raw(v *int) RepairQueue_Placement_Field {
+	if v == nil {
+		return RepairQueue_Placement_Null()
+	}
+	return RepairQueue_Placement(*v)
+}
+
+func RepairQueue_Placement_Null() RepairQueue_Placement_Field {
+	return RepairQueue_Placement_Field{_set: true, _null: true}
+}
+
+func (f RepairQueue_Placement_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f RepairQueue_Placement_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairQueue_Placement_Field) _Column() string { return "placement" }
+
 type Reputation struct {
 	Id                          []byte
 	AuditSuccessCount           int64
@@ -6910,126 +7726,274 @@ func (f Reputation_UnknownAuditReputationBeta_Field) value() interface{} {
 	return f._value
 }
 
-func (Reputation_UnknownAuditReputationBeta_Field) _Column() string {
-	return "unknown_audit_reputation_beta"
-}
+func (Reputation_UnknownAuditReputationBeta_Field) _Column() string {
+	return "unknown_audit_reputation_beta"
+}
+
+type ResetPasswordToken struct {
+	Secret    []byte
+	OwnerId   []byte
+	CreatedAt time.Time
+}
+
+func (ResetPasswordToken) _Table() string { return "reset_password_tokens" }
+
+type ResetPasswordToken_Update_Fields struct {
+	OwnerId ResetPasswordToken_OwnerId_Field
+}
+
+type ResetPasswordToken_Secret_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ResetPasswordToken_Secret(v []byte) ResetPasswordToken_Secret_Field {
+	return ResetPasswordToken_Secret_Field{_set: true, _value: v}
+}
+
+func (f ResetPasswordToken_Secret_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ResetPasswordToken_Secret_Field) _Column() string { return "secret" }
+
+type ResetPasswordToken_OwnerId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ResetPasswordToken_OwnerId(v []byte) ResetPasswordToken_OwnerId_Field {
+	return ResetPasswordToken_OwnerId_Field{_set: true, _value: v}
+}
+
+func (f ResetPasswordToken_OwnerId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ResetPasswordToken_OwnerId_Field) _Column() string { return "owner_id" }
+
+type ResetPasswordToken_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ResetPasswordToken_CreatedAt(v time.Time) ResetPasswordToken_CreatedAt_Field {
+	return ResetPasswordToken_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ResetPasswordToken_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ResetPasswordToken_CreatedAt_Field) _Column() string { return "created_at" }
+
+type Revocation struct {
+	Revoked  []byte
+	ApiKeyId []byte
+}
+
+func (Revocation) _Table() string { return "revocations" }
+
+type Revocation_Update_Fields struct {
+}
+
+type Revocation_Revoked_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Revocation_Revoked(v []byte) Revocation_Revoked_Field {
+	return Revocation_Revoked_Field{_set: true, _value: v}
+}
+
+func (f Revocation_Revoked_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Revocation_Revoked_Field) _Column() string { return "revoked" }
+
+type Revocation_ApiKeyId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Revocation_ApiKeyId(v []byte) Revocation_ApiKeyId_Field {
+	return Revocation_ApiKeyId_Field{_set: true, _value: v}
+}
+
+func (f Revocation_ApiKeyId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Revocation_ApiKeyId_Field) _Column() string { return "api_key_id" }
 
-type ResetPasswordToken struct {
-	Secret    []byte
-	OwnerId   []byte
-	CreatedAt time.Time
+type SecurityEvent struct {
+	Id         []byte
+	UserId     []byte
+	EventType  string
+	IpAddress  string
+	CreatedAt  time.Time
+	ReviewedAt *time.Time
 }
 
-func (ResetPasswordToken) _Table() string { return "reset_password_tokens" }
+func (SecurityEvent) _Table() string { return "security_events" }
 
-type ResetPasswordToken_Update_Fields struct {
-	OwnerId ResetPasswordToken_OwnerId_Field
+type SecurityEvent_Create_Fields struct {
+	ReviewedAt SecurityEvent_ReviewedAt_Field
 }
 
-type ResetPasswordToken_Secret_Field struct {
+type SecurityEvent_Update_Fields struct {
+	ReviewedAt SecurityEvent_ReviewedAt_Field
+}
+
+type SecurityEvent_Id_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func ResetPasswordToken_Secret(v []byte) ResetPasswordToken_Secret_Field {
-	return ResetPasswordToken_Secret_Field{_set: true, _value: v}
+func SecurityEvent_Id(v []byte) SecurityEvent_Id_Field {
+	return SecurityEvent_Id_Field{_set: true, _value: v}
 }
 
-func (f ResetPasswordToken_Secret_Field) value() interface{} {
+func (f SecurityEvent_Id_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ResetPasswordToken_Secret_Field) _Column() string { return "secret" }
+func (SecurityEvent_Id_Field) _Column() string { return "id" }
 
-type ResetPasswordToken_OwnerId_Field struct {
+type SecurityEvent_UserId_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func ResetPasswordToken_OwnerId(v []byte) ResetPasswordToken_OwnerId_Field {
-	return ResetPasswordToken_OwnerId_Field{_set: true, _value: v}
+func SecurityEvent_UserId(v []byte) SecurityEvent_UserId_Field {
+	return SecurityEvent_UserId_Field{_set: true, _value: v}
 }
 
-func (f ResetPasswordToken_OwnerId_Field) value() interface{} {
+func (f SecurityEvent_UserId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ResetPasswordToken_OwnerId_Field) _Column() string { return "owner_id" }
+func (SecurityEvent_UserId_Field) _Column() string { return "user_id" }
 
-type ResetPasswordToken_CreatedAt_Field struct {
+type SecurityEvent_EventType_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value string
 }
 
-func ResetPasswordToken_CreatedAt(v time.Time) ResetPasswordToken_CreatedAt_Field {
-	return ResetPasswordToken_CreatedAt_Field{_set: true, _value: v}
+func SecurityEvent_EventType(v string) SecurityEvent_EventType_Field {
+	return SecurityEvent_EventType_Field{_set: true, _value: v}
 }
 
-func (f ResetPasswordToken_CreatedAt_Field) value() interface{} {
+func (f SecurityEvent_EventType_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ResetPasswordToken_CreatedAt_Field) _Column() string { return "created_at" }
+func (SecurityEvent_EventType_Field) _Column() string { return "event_type" }
 
-type Revocation struct {
-	Revoked  []byte
-	ApiKeyId []byte
+type SecurityEvent_IpAddress_Field struct {
+	_set   bool
+	_null  bool
+	_value string
 }
 
-func (Revocation) _Table() string { return "revocations" }
+func SecurityEvent_IpAddress(v string) SecurityEvent_IpAddress_Field {
+	return SecurityEvent_IpAddress_Field{_set: true, _value: v}
+}
 
-type Revocation_Update_Fields struct {
+func (f SecurityEvent_IpAddress_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
 }
 
-type Revocation_Revoked_Field struct {
+func (SecurityEvent_IpAddress_Field) _Column() string { return "ip_address" }
+
+type SecurityEvent_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value time.Time
 }
 
-func Revocation_Revoked(v []byte) Revocation_Revoked_Field {
-	return Revocation_Revoked_Field{_set: true, _value: v}
+func SecurityEvent_CreatedAt(v time.Time) SecurityEvent_CreatedAt_Field {
+	return SecurityEvent_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f Revocation_Revoked_Field) value() interface{} {
+func (f SecurityEvent_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Revocation_Revoked_Field) _Column() string { return "revoked" }
+func (SecurityEvent_CreatedAt_Field) _Column() string { return "created_at" }
 
-type Revocation_ApiKeyId_Field struct {
+type SecurityEvent_ReviewedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value *time.Time
 }
 
-func Revocation_ApiKeyId(v []byte) Revocation_ApiKeyId_Field {
-	return Revocation_ApiKeyId_Field{_set: true, _value: v}
+func SecurityEvent_ReviewedAt(v time.Time) SecurityEvent_ReviewedAt_Field {
+	return SecurityEvent_ReviewedAt_Field{_set: true, _value: &v}
 }
 
-func (f Revocation_ApiKeyId_Field) value() interface{} {
+func SecurityEvent_ReviewedAt_Raw(v *time.Time) SecurityEvent_ReviewedAt_Field {
+	if v == nil {
+		return SecurityEvent_ReviewedAt_Null()
+	}
+	return SecurityEvent_ReviewedAt(*v)
+}
+
+func SecurityEvent_ReviewedAt_Null() SecurityEvent_ReviewedAt_Field {
+	return SecurityEvent_ReviewedAt_Field{_set: true, _null: true}
+}
+
+func (f SecurityEvent_ReviewedAt_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f SecurityEvent_ReviewedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Revocation_ApiKeyId_Field) _Column() string { return "api_key_id" }
+func (SecurityEvent_ReviewedAt_Field) _Column() string { return "reviewed_at" }
 
 type SegmentPendingAudits struct {
 	NodeId            []byte
@@ -10008,19 +10972,25 @@ func (f ValueAttribution_LastUpdated_Field) value() interface{} {
 func (ValueAttribution_LastUpdated_Field) _Column() string { return "last_updated" }
 
 type WebappSession struct {
-	Id        []byte
-	UserId    []byte
-	IpAddress string
-	UserAgent string
-	Status    int
-	ExpiresAt time.Time
+	Id         []byte
+	UserId     []byte
+	IpAddress  string
+	UserAgent  string
+	Status     int
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
 }
 
 func (WebappSession) _Table() string { return "webapp_sessions" }
 
+type WebappSession_Create_Fields struct {
+	LastUsedAt WebappSession_LastUsedAt_Field
+}
+
 type WebappSession_Update_Fields struct {
-	Status    WebappSession_Status_Field
-	ExpiresAt WebappSession_ExpiresAt_Field
+	Status     WebappSession_Status_Field
+	ExpiresAt  WebappSession_ExpiresAt_Field
+	LastUsedAt WebappSession_LastUsedAt_Field
 }
 
 type WebappSession_Id_Field struct {
@@ -10137,6 +11107,40 @@ func (f WebappSession_ExpiresAt_Field) value() interface{} {
 
 func (WebappSession_ExpiresAt_Field) _Column() string { return "expires_at" }
 
+type WebappSession_LastUsedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func WebappSession_LastUsedAt(v time.Time) WebappSession_LastUsedAt_Field {
+	return WebappSession_LastUsedAt_Field{_set: true, _value: &v}
+}
+
+func WebappSession_LastUsedAt_Raw(v *time.Time) WebappSession_LastUsedAt_Field {
+	if v == nil {
+		return WebappSession_LastUsedAt_Null()
+	}
+	return WebappSession_LastUsedAt(*v)
+}
+
+func WebappSession_LastUsedAt_Null() WebappSession_LastUsedAt_Field {
+	return WebappSession_LastUsedAt_Field{_set: true, _null: true}
+}
+
+func (f WebappSession_LastUsedAt_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f WebappSession_LastUsedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (WebappSession_LastUsedAt_Field) _Column() string { return "last_used_at" }
+
 type ApiKey struct {
 	Id        []byte
 	ProjectId []byte
@@ -10146,6 +11150,7 @@ type ApiKey struct {
 	PartnerId []byte
 	UserAgent []byte
 	CreatedAt time.Time
+	ExpiresAt *time.Time
 }
 
 func (ApiKey) _Table() string { return "api_keys" }
@@ -10153,6 +11158,7 @@ func (ApiKey) _Table() string { return "api_keys" }
 type ApiKey_Create_Fields struct {
 	PartnerId ApiKey_PartnerId_Field
 	UserAgent ApiKey_UserAgent_Field
+	ExpiresAt ApiKey_ExpiresAt_Field
 }
 
 type ApiKey_Update_Fields struct {
@@ -10337,6 +11343,38 @@ func (f ApiKey_CreatedAt_Field) value() interface{} {
 
 func (ApiKey_CreatedAt_Field) _Column() string { return "created_at" }
 
+type ApiKey_ExpiresAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func ApiKey_ExpiresAt(v time.Time) ApiKey_ExpiresAt_Field {
+	return ApiKey_ExpiresAt_Field{_set: true, _value: &v}
+}
+
+func ApiKey_ExpiresAt_Raw(v *time.Time) ApiKey_ExpiresAt_Field {
+	if v == nil {
+		return ApiKey_ExpiresAt_Null()
+	}
+	return ApiKey_ExpiresAt(*v)
+}
+
+func ApiKey_ExpiresAt_Null() ApiKey_ExpiresAt_Field {
+	return ApiKey_ExpiresAt_Field{_set: true, _null: true}
+}
+
+func (f ApiKey_ExpiresAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f ApiKey_ExpiresAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ApiKey_ExpiresAt_Field) _Column() string { return "expires_at" }
+
 type BucketMetainfo struct {
 	Id                              []byte
 	ProjectId                       []byte
@@ -10355,14 +11393,16 @@ type BucketMetainfo struct {
 	DefaultRedundancyOptimalShares  int
 	DefaultRedundancyTotalShares    int
 	Placement                       *int
+	Versioning                      *bool
 }
 
 func (BucketMetainfo) _Table() string { return "bucket_metainfos" }
 
 type BucketMetainfo_Create_Fields struct {
-	PartnerId BucketMetainfo_PartnerId_Field
-	UserAgent BucketMetainfo_UserAgent_Field
-	Placement BucketMetainfo_Placement_Field
+	PartnerId  BucketMetainfo_PartnerId_Field
+	UserAgent  BucketMetainfo_UserAgent_Field
+	Placement  BucketMetainfo_Placement_Field
+	Versioning BucketMetainfo_Versioning_Field
 }
 
 type BucketMetainfo_Update_Fields struct {
@@ -10378,6 +11418,7 @@ type BucketMetainfo_Update_Fields struct {
 	DefaultRedundancyOptimalShares  BucketMetainfo_DefaultRedundancyOptimalShares_Field
 	DefaultRedundancyTotalShares    BucketMetainfo_DefaultRedundancyTotalShares_Field
 	Placement                       BucketMetainfo_Placement_Field
+	Versioning                      BucketMetainfo_Versioning_Field
 }
 
 type BucketMetainfo_Id_Field struct {
@@ -10758,6 +11799,38 @@ func (f BucketMetainfo_Placement_Field) value() interface{} {
 
 func (BucketMetainfo_Placement_Field) _Column() string { return "placement" }
 
+type BucketMetainfo_Versioning_Field struct {
+	_set   bool
+	_null  bool
+	_value *bool
+}
+
+func BucketMetainfo_Versioning(v bool) BucketMetainfo_Versioning_Field {
+	return BucketMetainfo_Versioning_Field{_set: true, _value: &v}
+}
+
+func BucketMetainfo_Versioning_Raw(v *bool) BucketMetainfo_Versioning_Field {
+	if v == nil {
+		return BucketMetainfo_Versioning_Null()
+	}
+	return BucketMetainfo_Versioning(*v)
+}
+
+func BucketMetainfo_Versioning_Null() BucketMetainfo_Versioning_Field {
+	return BucketMetainfo_Versioning_Field{_set: true, _null: true}
+}
+
+func (f BucketMetainfo_Versioning_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f BucketMetainfo_Versioning_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketMetainfo_Versioning_Field) _Column() string { return "versioning" }
+
 type ProjectMember struct {
 	MemberId  []byte
 	ProjectId []byte
@@ -11616,8 +12689,9 @@ type PaidTier_Row struct {
 	PaidTier bool
 }
 
-type Placement_Row struct {
-	Placement *int
+type Placement_Versioning_Row struct {
+	Placement  *int
+	Versioning *bool
 }
 
 type ProjectLimit_Row struct {
@@ -11630,6 +12704,10 @@ type ProjectStorageLimit_ProjectBandwidthLimit_ProjectSegmentLimit_Row struct {
 	ProjectSegmentLimit   int64
 }
 
+type Role_Row struct {
+	Role int
+}
+
 type SegmentLimit_Row struct {
 	SegmentLimit *int64
 }
@@ -11919,7 +12997,8 @@ func (obj *pgxImpl) Create_WebappSession(ctx context.Context,
 	webapp_session_user_id WebappSession_UserId_Field,
 	webapp_session_ip_address WebappSession_IpAddress_Field,
 	webapp_session_user_agent WebappSession_UserAgent_Field,
-	webapp_session_expires_at WebappSession_ExpiresAt_Field) (
+	webapp_session_expires_at WebappSession_ExpiresAt_Field,
+	optional WebappSession_Create_Fields) (
 	webapp_session *WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
 	__id_val := webapp_session_id.value()
@@ -11928,17 +13007,18 @@ func (obj *pgxImpl) Create_WebappSession(ctx context.Context,
 	__user_agent_val := webapp_session_user_agent.value()
 	__status_val := int(0)
 	__expires_at_val := webapp_session_expires_at.value()
+	__last_used_at_val := optional.LastUsedAt.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO webapp_sessions ( id, user_id, ip_address, user_agent, status, expires_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO webapp_sessions ( id, user_id, ip_address, user_agent, status, expires_at, last_used_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? ) RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.last_used_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __user_id_val, __ip_address_val, __user_agent_val, __status_val, __expires_at_val)
+	__values = append(__values, __id_val, __user_id_val, __ip_address_val, __user_agent_val, __status_val, __expires_at_val, __last_used_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.LastUsedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -11946,6 +13026,40 @@ func (obj *pgxImpl) Create_WebappSession(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) Create_SecurityEvent(ctx context.Context,
+	security_event_id SecurityEvent_Id_Field,
+	security_event_user_id SecurityEvent_UserId_Field,
+	security_event_event_type SecurityEvent_EventType_Field,
+	security_event_ip_address SecurityEvent_IpAddress_Field,
+	optional SecurityEvent_Create_Fields) (
+	security_event *SecurityEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__id_val := security_event_id.value()
+	__user_id_val := security_event_user_id.value()
+	__event_type_val := security_event_event_type.value()
+	__ip_address_val := security_event_ip_address.value()
+	__created_at_val := __now
+	__reviewed_at_val := optional.ReviewedAt.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO security_events ( id, user_id, event_type, ip_address, created_at, reviewed_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING security_events.id, security_events.user_id, security_events.event_type, security_events.ip_address, security_events.created_at, security_events.reviewed_at")
+
+	var __values []interface{}
+	__values = append(__values, __id_val, __user_id_val, __event_type_val, __ip_address_val, __created_at_val, __reviewed_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	security_event = &SecurityEvent{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&security_event.Id, &security_event.UserId, &security_event.EventType, &security_event.IpAddress, &security_event.CreatedAt, &security_event.ReviewedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return security_event, nil
+
+}
+
 func (obj *pgxImpl) Create_Project(ctx context.Context,
 	project_id Project_Id_Field,
 	project_name Project_Name_Field,
@@ -11964,19 +13078,21 @@ func (obj *pgxImpl) Create_Project(ctx context.Context,
 	__rate_limit_val := optional.RateLimit.value()
 	__burst_limit_val := optional.BurstLimit.value()
 	__max_buckets_val := optional.MaxBuckets.value()
+	__egress_rate_limit_val := optional.EgressRateLimit.value()
+	__admin_egress_rate_limit_val := optional.AdminEgressRateLimit.value()
 	__partner_id_val := optional.PartnerId.value()
 	__user_agent_val := optional.UserAgent.value()
 	__owner_id_val := project_owner_id.value()
 	__created_at_val := __now
 
-	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, name, description, usage_limit, bandwidth_limit, rate_limit, burst_limit, max_buckets, partner_id, user_agent, owner_id, created_at")}
-	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
+	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, name, description, usage_limit, bandwidth_limit, rate_limit, burst_limit, max_buckets, egress_rate_limit, admin_egress_rate_limit, partner_id, user_agent, owner_id, created_at")}
+	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
 	var __clause = &__sqlbundle_Hole{SQL: __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("("), __columns, __sqlbundle_Literal(") VALUES ("), __placeholders, __sqlbundle_Literal(")")}}}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO projects "), __clause, __sqlbundle_Literal(" RETURNING projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO projects "), __clause, __sqlbundle_Literal(" RETURNING projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __name_val, __description_val, __usage_limit_val, __bandwidth_limit_val, __rate_limit_val, __burst_limit_val, __max_buckets_val, __partner_id_val, __user_agent_val, __owner_id_val, __created_at_val)
+	__values = append(__values, __id_val, __name_val, __description_val, __usage_limit_val, __bandwidth_limit_val, __rate_limit_val, __burst_limit_val, __max_buckets_val, __egress_rate_limit_val, __admin_egress_rate_limit_val, __partner_id_val, __user_agent_val, __owner_id_val, __created_at_val)
 
 	__optional_columns := __sqlbundle_Literals{Join: ", "}
 	__optional_placeholders := __sqlbundle_Literals{Join: ", "}
@@ -11999,7 +13115,7 @@ func (obj *pgxImpl) Create_Project(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -12035,6 +13151,34 @@ func (obj *pgxImpl) Create_ProjectMember(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) ReplaceNoReturn_ProjectMemberRole(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field,
+	project_member_role_role ProjectMemberRole_Role_Field,
+	project_member_role_updated_at ProjectMemberRole_UpdatedAt_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+	__member_id_val := project_member_role_member_id.value()
+	__project_id_val := project_member_role_project_id.value()
+	__role_val := project_member_role_role.value()
+	__updated_at_val := project_member_role_updated_at.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_member_roles ( member_id, project_id, role, updated_at ) VALUES ( ?, ?, ?, ? ) ON CONFLICT ( member_id, project_id ) DO UPDATE SET member_id = EXCLUDED.member_id, project_id = EXCLUDED.project_id, role = EXCLUDED.role, updated_at = EXCLUDED.updated_at")
+
+	var __values []interface{}
+	__values = append(__values, __member_id_val, __project_id_val, __role_val, __updated_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxImpl) Create_ApiKey(ctx context.Context,
 	api_key_id ApiKey_Id_Field,
 	api_key_project_id ApiKey_ProjectId_Field,
@@ -12054,17 +13198,18 @@ func (obj *pgxImpl) Create_ApiKey(ctx context.Context,
 	__partner_id_val := optional.PartnerId.value()
 	__user_agent_val := optional.UserAgent.value()
 	__created_at_val := __now
+	__expires_at_val := optional.ExpiresAt.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO api_keys ( id, project_id, head, name, secret, partner_id, user_agent, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO api_keys ( id, project_id, head, name, secret, partner_id, user_agent, created_at, expires_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at, api_keys.expires_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __project_id_val, __head_val, __name_val, __secret_val, __partner_id_val, __user_agent_val, __created_at_val)
+	__values = append(__values, __id_val, __project_id_val, __head_val, __name_val, __secret_val, __partner_id_val, __user_agent_val, __created_at_val, __expires_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt, &api_key.ExpiresAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -12369,17 +13514,18 @@ func (obj *pgxImpl) Create_BucketMetainfo(ctx context.Context,
 	__default_redundancy_optimal_shares_val := bucket_metainfo_default_redundancy_optimal_shares.value()
 	__default_redundancy_total_shares_val := bucket_metainfo_default_redundancy_total_shares.value()
 	__placement_val := optional.Placement.value()
+	__versioning_val := optional.Versioning.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bucket_metainfos ( id, project_id, name, partner_id, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bucket_metainfos ( id, project_id, name, partner_id, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement, versioning ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __project_id_val, __name_val, __partner_id_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val)
+	__values = append(__values, __id_val, __project_id_val, __name_val, __partner_id_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val, __versioning_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -12946,7 +14092,7 @@ func (obj *pgxImpl) Get_Node_By_Id(ctx context.Context,
 	node *Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contact_up_transitions, nodes.contact_down_transitions, nodes.contact_transitions_since FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -12955,7 +14101,7 @@ func (obj *pgxImpl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.ContactUpTransitions, &node.ContactDownTransitions, &node.ContactTransitionsSince)
 	if err != nil {
 		return (*Node)(nil), obj.makeErr(err)
 	}
@@ -13011,9 +14157,9 @@ func (obj *pgxImpl) Paged_Node(ctx context.Context,
 	rows []*Node, next *Paged_Node_Continuation, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.id FROM nodes WHERE (nodes.id) > ? ORDER BY nodes.id LIMIT ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contact_up_transitions, nodes.contact_down_transitions, nodes.contact_transitions_since, nodes.id FROM nodes WHERE (nodes.id) > ? ORDER BY nodes.id LIMIT ?")
 
-	var __embed_first_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.id FROM nodes ORDER BY nodes.id LIMIT ?")
+	var __embed_first_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contact_up_transitions, nodes.contact_down_transitions, nodes.contact_transitions_since, nodes.id FROM nodes ORDER BY nodes.id LIMIT ?")
 
 	var __values []interface{}
 
@@ -13040,7 +14186,7 @@ func (obj *pgxImpl) Paged_Node(ctx context.Context,
 
 			for __rows.Next() {
 				node := &Node{}
-				err = __rows.Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &__continuation._value_id)
+				err = __rows.Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.ContactUpTransitions, &node.ContactDownTransitions, &node.ContactTransitionsSince, &__continuation._value_id)
 				if err != nil {
 					return nil, nil, err
 				}
@@ -13326,7 +14472,7 @@ func (obj *pgxImpl) All_WebappSession_By_UserId(ctx context.Context,
 	rows []*WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.last_used_at FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, webapp_session_user_id.value())
@@ -13343,14 +14489,107 @@ func (obj *pgxImpl) All_WebappSession_By_UserId(ctx context.Context,
 			defer __rows.Close()
 
 			for __rows.Next() {
-				webapp_session := &WebappSession{}
-				err = __rows.Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+				webapp_session := &WebappSession{}
+				err = __rows.Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.LastUsedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, webapp_session)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxImpl) Get_WebappSession_By_Id(ctx context.Context,
+	webapp_session_id WebappSession_Id_Field) (
+	webapp_session *WebappSession, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.last_used_at FROM webapp_sessions WHERE webapp_sessions.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, webapp_session_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	webapp_session = &WebappSession{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.LastUsedAt)
+	if err != nil {
+		return (*WebappSession)(nil), obj.makeErr(err)
+	}
+	return webapp_session, nil
+
+}
+
+func (obj *pgxImpl) Count_SecurityEvent_By_UserId(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field) (
+	count int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT COUNT(*) FROM security_events WHERE security_events.user_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, security_event_user_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&count)
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	return count, nil
+
+}
+
+func (obj *pgxImpl) Limited_SecurityEvent_By_UserId_OrderBy_Desc_CreatedAt_Desc_Id(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field,
+	limit int, offset int64) (
+	rows []*SecurityEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT security_events.id, security_events.user_id, security_events.event_type, security_events.ip_address, security_events.created_at, security_events.reviewed_at FROM security_events WHERE security_events.user_id = ? ORDER BY security_events.created_at DESC, security_events.id DESC LIMIT ? OFFSET ?")
+
+	var __values []interface{}
+	__values = append(__values, security_event_user_id.value())
+
+	__values = append(__values, limit, offset)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*SecurityEvent, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				security_event := &SecurityEvent{}
+				err = __rows.Scan(&security_event.Id, &security_event.UserId, &security_event.EventType, &security_event.IpAddress, &security_event.CreatedAt, &security_event.ReviewedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, webapp_session)
+				rows = append(rows, security_event)
 			}
-			if err := __rows.Err(); err != nil {
+			err = __rows.Err()
+			if err != nil {
 				return nil, err
 			}
 			return rows, nil
@@ -13366,25 +14605,51 @@ func (obj *pgxImpl) All_WebappSession_By_UserId(ctx context.Context,
 
 }
 
-func (obj *pgxImpl) Get_WebappSession_By_Id(ctx context.Context,
-	webapp_session_id WebappSession_Id_Field) (
-	webapp_session *WebappSession, err error) {
+func (obj *pgxImpl) First_SecurityEvent_By_UserId_And_EventType_OrderBy_Desc_CreatedAt_Desc_Id(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field,
+	security_event_event_type SecurityEvent_EventType_Field) (
+	security_event *SecurityEvent, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at FROM webapp_sessions WHERE webapp_sessions.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT security_events.id, security_events.user_id, security_events.event_type, security_events.ip_address, security_events.created_at, security_events.reviewed_at FROM security_events WHERE security_events.user_id = ? AND security_events.event_type = ? ORDER BY security_events.created_at DESC, security_events.id DESC LIMIT 1 OFFSET 0")
 
 	var __values []interface{}
-	__values = append(__values, webapp_session_id.value())
+	__values = append(__values, security_event_user_id.value(), security_event_event_type.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
-	if err != nil {
-		return (*WebappSession)(nil), obj.makeErr(err)
+	for {
+		security_event, err = func() (security_event *SecurityEvent, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			if !__rows.Next() {
+				if err := __rows.Err(); err != nil {
+					return nil, err
+				}
+				return nil, nil
+			}
+
+			security_event = &SecurityEvent{}
+			err = __rows.Scan(&security_event.Id, &security_event.UserId, &security_event.EventType, &security_event.IpAddress, &security_event.CreatedAt, &security_event.ReviewedAt)
+			if err != nil {
+				return nil, err
+			}
+
+			return security_event, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return security_event, nil
 	}
-	return webapp_session, nil
 
 }
 
@@ -13393,7 +14658,7 @@ func (obj *pgxImpl) Get_Project_By_Id(ctx context.Context,
 	project *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, project_id.value())
@@ -13402,7 +14667,7 @@ func (obj *pgxImpl) Get_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 	if err != nil {
 		return (*Project)(nil), obj.makeErr(err)
 	}
@@ -13498,6 +14763,29 @@ func (obj *pgxImpl) Get_Project_MaxBuckets_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) Get_ProjectMemberRole_Role_By_MemberId_And_ProjectId(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field) (
+	row *Role_Row, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_member_roles.role FROM project_member_roles WHERE project_member_roles.member_id = ? AND project_member_roles.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_member_role_member_id.value(), project_member_role_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	row = &Role_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.Role)
+	if err != nil {
+		return (*Role_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
 func (obj *pgxImpl) Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	row *BandwidthLimit_UsageLimit_SegmentLimit_Row, err error) {
@@ -13524,7 +14812,7 @@ func (obj *pgxImpl) All_Project(ctx context.Context) (
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects")
 
 	var __values []interface{}
 
@@ -13541,7 +14829,7 @@ func (obj *pgxImpl) All_Project(ctx context.Context) (
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -13568,7 +14856,7 @@ func (obj *pgxImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx cont
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at")
 
 	var __values []interface{}
 	__values = append(__values, project_created_at_less.value())
@@ -13586,7 +14874,7 @@ func (obj *pgxImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx cont
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -13613,7 +14901,7 @@ func (obj *pgxImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Con
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.owner_id = ? ORDER BY projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.owner_id = ? ORDER BY projects.created_at")
 
 	var __values []interface{}
 	__values = append(__values, project_owner_id.value())
@@ -13631,7 +14919,7 @@ func (obj *pgxImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Con
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -13658,7 +14946,7 @@ func (obj *pgxImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Na
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
 
 	var __values []interface{}
 	__values = append(__values, project_member_member_id.value())
@@ -13676,7 +14964,7 @@ func (obj *pgxImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Na
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -13704,7 +14992,7 @@ func (obj *pgxImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, project_created_at_less.value())
@@ -13724,7 +15012,7 @@ func (obj *pgxImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -13797,7 +15085,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Id(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at FROM api_keys WHERE api_keys.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at, api_keys.expires_at FROM api_keys WHERE api_keys.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_id.value())
@@ -13806,7 +15094,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt, &api_key.ExpiresAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -13819,7 +15107,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Head(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at FROM api_keys WHERE api_keys.head = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at, api_keys.expires_at FROM api_keys WHERE api_keys.head = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_head.value())
@@ -13828,7 +15116,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Head(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt, &api_key.ExpiresAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -13842,7 +15130,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at FROM api_keys WHERE api_keys.name = ? AND api_keys.project_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at, api_keys.expires_at FROM api_keys WHERE api_keys.name = ? AND api_keys.project_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_name.value(), api_key_project_id.value())
@@ -13851,7 +15139,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt, &api_key.ExpiresAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -14804,7 +16092,7 @@ func (obj *pgxImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx context.Context
 	bucket_metainfo *BucketMetainfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
 
 	var __values []interface{}
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name.value())
@@ -14813,7 +16101,7 @@ func (obj *pgxImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx context.Context
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 	if err != nil {
 		return (*BucketMetainfo)(nil), obj.makeErr(err)
 	}
@@ -14867,13 +16155,13 @@ func (obj *pgxImpl) Get_BucketMetainfo_Id_By_ProjectId_And_Name(ctx context.Cont
 
 }
 
-func (obj *pgxImpl) Get_BucketMetainfo_Placement_By_ProjectId_And_Name(ctx context.Context,
+func (obj *pgxImpl) Get_BucketMetainfo_Placement_BucketMetainfo_Versioning_By_ProjectId_And_Name(ctx context.Context,
 	bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
 	bucket_metainfo_name BucketMetainfo_Name_Field) (
-	row *Placement_Row, err error) {
+	row *Placement_Versioning_Row, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.placement FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.placement, bucket_metainfos.versioning FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
 
 	var __values []interface{}
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name.value())
@@ -14881,10 +16169,10 @@ func (obj *pgxImpl) Get_BucketMetainfo_Placement_By_ProjectId_And_Name(ctx conte
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	row = &Placement_Row{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.Placement)
+	row = &Placement_Versioning_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.Placement, &row.Versioning)
 	if err != nil {
-		return (*Placement_Row)(nil), obj.makeErr(err)
+		return (*Placement_Versioning_Row)(nil), obj.makeErr(err)
 	}
 	return row, nil
 
@@ -14919,7 +16207,7 @@ func (obj *pgxImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_GreaterOrEqual_
 	rows []*BucketMetainfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater_or_equal.value())
@@ -14939,7 +16227,7 @@ func (obj *pgxImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_GreaterOrEqual_
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 				if err != nil {
 					return nil, err
 				}
@@ -14969,7 +16257,7 @@ func (obj *pgxImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greater_OrderBy
 	rows []*BucketMetainfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater.value())
@@ -14989,7 +16277,7 @@ func (obj *pgxImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greater_OrderBy
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 				if err != nil {
 					return nil, err
 				}
@@ -16037,7 +17325,7 @@ func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contact_up_transitions, nodes.contact_down_transitions, nodes.contact_transitions_since")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -16193,6 +17481,21 @@ func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("exit_success = ?"))
 	}
 
+	if update.ContactUpTransitions._set {
+		__values = append(__values, update.ContactUpTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_up_transitions = ?"))
+	}
+
+	if update.ContactDownTransitions._set {
+		__values = append(__values, update.ContactDownTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_down_transitions = ?"))
+	}
+
+	if update.ContactTransitionsSince._set {
+		__values = append(__values, update.ContactTransitionsSince.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_transitions_since = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -16207,7 +17510,7 @@ func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.ContactUpTransitions, &node.ContactDownTransitions, &node.ContactTransitionsSince)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -16380,6 +17683,21 @@ func (obj *pgxImpl) UpdateNoReturn_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("exit_success = ?"))
 	}
 
+	if update.ContactUpTransitions._set {
+		__values = append(__values, update.ContactUpTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_up_transitions = ?"))
+	}
+
+	if update.ContactDownTransitions._set {
+		__values = append(__values, update.ContactDownTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_down_transitions = ?"))
+	}
+
+	if update.ContactTransitionsSince._set {
+		__values = append(__values, update.ContactTransitionsSince.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_transitions_since = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -16563,6 +17881,21 @@ func (obj *pgxImpl) UpdateNoReturn_Node_By_Id_And_Disqualified_Is_Null_And_ExitF
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("exit_success = ?"))
 	}
 
+	if update.ContactUpTransitions._set {
+		__values = append(__values, update.ContactUpTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_up_transitions = ?"))
+	}
+
+	if update.ContactDownTransitions._set {
+		__values = append(__values, update.ContactDownTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_down_transitions = ?"))
+	}
+
+	if update.ContactTransitionsSince._set {
+		__values = append(__values, update.ContactTransitionsSince.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_transitions_since = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -17074,7 +18407,7 @@ func (obj *pgxImpl) Update_WebappSession_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE webapp_sessions SET "), __sets, __sqlbundle_Literal(" WHERE webapp_sessions.id = ? RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE webapp_sessions SET "), __sets, __sqlbundle_Literal(" WHERE webapp_sessions.id = ? RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.last_used_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -17090,6 +18423,11 @@ func (obj *pgxImpl) Update_WebappSession_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("expires_at = ?"))
 	}
 
+	if update.LastUsedAt._set {
+		__values = append(__values, update.LastUsedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_used_at = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -17103,7 +18441,7 @@ func (obj *pgxImpl) Update_WebappSession_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.LastUsedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -17120,7 +18458,7 @@ func (obj *pgxImpl) Update_Project_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -17166,6 +18504,16 @@ func (obj *pgxImpl) Update_Project_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("max_buckets = ?"))
 	}
 
+	if update.EgressRateLimit._set {
+		__values = append(__values, update.EgressRateLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_rate_limit = ?"))
+	}
+
+	if update.AdminEgressRateLimit._set {
+		__values = append(__values, update.AdminEgressRateLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("admin_egress_rate_limit = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -17179,7 +18527,7 @@ func (obj *pgxImpl) Update_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -17318,7 +18666,7 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.Cont
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -17384,6 +18732,11 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.Cont
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.Versioning._set {
+		__values = append(__values, update.Versioning.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("versioning = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -17397,7 +18750,7 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.Cont
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -18003,6 +19356,34 @@ func (obj *pgxImpl) Delete_ProjectMember_By_MemberId_And_ProjectId(ctx context.C
 
 }
 
+func (obj *pgxImpl) Delete_ProjectMemberRole_By_MemberId_And_ProjectId(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM project_member_roles WHERE project_member_roles.member_id = ? AND project_member_roles.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_member_role_member_id.value(), project_member_role_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (obj *pgxImpl) Delete_ApiKey_By_Id(ctx context.Context,
 	api_key_id ApiKey_Id_Field) (
 	deleted bool, err error) {
@@ -18306,6 +19687,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_member_roles;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -18476,6 +19867,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM security_events;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -18526,6 +19927,26 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM repair_checkpoints;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM repair_bandwidth_rollups;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -18656,6 +20077,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM data_erasure_jobs;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -19035,7 +20466,8 @@ func (obj *pgxcockroachImpl) Create_WebappSession(ctx context.Context,
 	webapp_session_user_id WebappSession_UserId_Field,
 	webapp_session_ip_address WebappSession_IpAddress_Field,
 	webapp_session_user_agent WebappSession_UserAgent_Field,
-	webapp_session_expires_at WebappSession_ExpiresAt_Field) (
+	webapp_session_expires_at WebappSession_ExpiresAt_Field,
+	optional WebappSession_Create_Fields) (
 	webapp_session *WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
 	__id_val := webapp_session_id.value()
@@ -19044,17 +20476,18 @@ func (obj *pgxcockroachImpl) Create_WebappSession(ctx context.Context,
 	__user_agent_val := webapp_session_user_agent.value()
 	__status_val := int(0)
 	__expires_at_val := webapp_session_expires_at.value()
+	__last_used_at_val := optional.LastUsedAt.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO webapp_sessions ( id, user_id, ip_address, user_agent, status, expires_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO webapp_sessions ( id, user_id, ip_address, user_agent, status, expires_at, last_used_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? ) RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.last_used_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __user_id_val, __ip_address_val, __user_agent_val, __status_val, __expires_at_val)
+	__values = append(__values, __id_val, __user_id_val, __ip_address_val, __user_agent_val, __status_val, __expires_at_val, __last_used_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.LastUsedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -19062,6 +20495,40 @@ func (obj *pgxcockroachImpl) Create_WebappSession(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) Create_SecurityEvent(ctx context.Context,
+	security_event_id SecurityEvent_Id_Field,
+	security_event_user_id SecurityEvent_UserId_Field,
+	security_event_event_type SecurityEvent_EventType_Field,
+	security_event_ip_address SecurityEvent_IpAddress_Field,
+	optional SecurityEvent_Create_Fields) (
+	security_event *SecurityEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__id_val := security_event_id.value()
+	__user_id_val := security_event_user_id.value()
+	__event_type_val := security_event_event_type.value()
+	__ip_address_val := security_event_ip_address.value()
+	__created_at_val := __now
+	__reviewed_at_val := optional.ReviewedAt.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO security_events ( id, user_id, event_type, ip_address, created_at, reviewed_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING security_events.id, security_events.user_id, security_events.event_type, security_events.ip_address, security_events.created_at, security_events.reviewed_at")
+
+	var __values []interface{}
+	__values = append(__values, __id_val, __user_id_val, __event_type_val, __ip_address_val, __created_at_val, __reviewed_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	security_event = &SecurityEvent{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&security_event.Id, &security_event.UserId, &security_event.EventType, &security_event.IpAddress, &security_event.CreatedAt, &security_event.ReviewedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return security_event, nil
+
+}
+
 func (obj *pgxcockroachImpl) Create_Project(ctx context.Context,
 	project_id Project_Id_Field,
 	project_name Project_Name_Field,
@@ -19080,19 +20547,21 @@ func (obj *pgxcockroachImpl) Create_Project(ctx context.Context,
 	__rate_limit_val := optional.RateLimit.value()
 	__burst_limit_val := optional.BurstLimit.value()
 	__max_buckets_val := optional.MaxBuckets.value()
+	__egress_rate_limit_val := optional.EgressRateLimit.value()
+	__admin_egress_rate_limit_val := optional.AdminEgressRateLimit.value()
 	__partner_id_val := optional.PartnerId.value()
 	__user_agent_val := optional.UserAgent.value()
 	__owner_id_val := project_owner_id.value()
 	__created_at_val := __now
 
-	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, name, description, usage_limit, bandwidth_limit, rate_limit, burst_limit, max_buckets, partner_id, user_agent, owner_id, created_at")}
-	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
+	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, name, description, usage_limit, bandwidth_limit, rate_limit, burst_limit, max_buckets, egress_rate_limit, admin_egress_rate_limit, partner_id, user_agent, owner_id, created_at")}
+	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
 	var __clause = &__sqlbundle_Hole{SQL: __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("("), __columns, __sqlbundle_Literal(") VALUES ("), __placeholders, __sqlbundle_Literal(")")}}}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO projects "), __clause, __sqlbundle_Literal(" RETURNING projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO projects "), __clause, __sqlbundle_Literal(" RETURNING projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __name_val, __description_val, __usage_limit_val, __bandwidth_limit_val, __rate_limit_val, __burst_limit_val, __max_buckets_val, __partner_id_val, __user_agent_val, __owner_id_val, __created_at_val)
+	__values = append(__values, __id_val, __name_val, __description_val, __usage_limit_val, __bandwidth_limit_val, __rate_limit_val, __burst_limit_val, __max_buckets_val, __egress_rate_limit_val, __admin_egress_rate_limit_val, __partner_id_val, __user_agent_val, __owner_id_val, __created_at_val)
 
 	__optional_columns := __sqlbundle_Literals{Join: ", "}
 	__optional_placeholders := __sqlbundle_Literals{Join: ", "}
@@ -19115,7 +20584,7 @@ func (obj *pgxcockroachImpl) Create_Project(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -19151,6 +20620,34 @@ func (obj *pgxcockroachImpl) Create_ProjectMember(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) ReplaceNoReturn_ProjectMemberRole(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field,
+	project_member_role_role ProjectMemberRole_Role_Field,
+	project_member_role_updated_at ProjectMemberRole_UpdatedAt_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+	__member_id_val := project_member_role_member_id.value()
+	__project_id_val := project_member_role_project_id.value()
+	__role_val := project_member_role_role.value()
+	__updated_at_val := project_member_role_updated_at.value()
+
+	var __embed_stmt = __sqlbundle_Literal("UPSERT INTO project_member_roles ( member_id, project_id, role, updated_at ) VALUES ( ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __member_id_val, __project_id_val, __role_val, __updated_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxcockroachImpl) Create_ApiKey(ctx context.Context,
 	api_key_id ApiKey_Id_Field,
 	api_key_project_id ApiKey_ProjectId_Field,
@@ -19170,17 +20667,18 @@ func (obj *pgxcockroachImpl) Create_ApiKey(ctx context.Context,
 	__partner_id_val := optional.PartnerId.value()
 	__user_agent_val := optional.UserAgent.value()
 	__created_at_val := __now
+	__expires_at_val := optional.ExpiresAt.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO api_keys ( id, project_id, head, name, secret, partner_id, user_agent, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO api_keys ( id, project_id, head, name, secret, partner_id, user_agent, created_at, expires_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at, api_keys.expires_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __project_id_val, __head_val, __name_val, __secret_val, __partner_id_val, __user_agent_val, __created_at_val)
+	__values = append(__values, __id_val, __project_id_val, __head_val, __name_val, __secret_val, __partner_id_val, __user_agent_val, __created_at_val, __expires_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt, &api_key.ExpiresAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -19485,17 +20983,18 @@ func (obj *pgxcockroachImpl) Create_BucketMetainfo(ctx context.Context,
 	__default_redundancy_optimal_shares_val := bucket_metainfo_default_redundancy_optimal_shares.value()
 	__default_redundancy_total_shares_val := bucket_metainfo_default_redundancy_total_shares.value()
 	__placement_val := optional.Placement.value()
+	__versioning_val := optional.Versioning.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bucket_metainfos ( id, project_id, name, partner_id, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bucket_metainfos ( id, project_id, name, partner_id, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement, versioning ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __project_id_val, __name_val, __partner_id_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val)
+	__values = append(__values, __id_val, __project_id_val, __name_val, __partner_id_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val, __versioning_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -20062,7 +21561,7 @@ func (obj *pgxcockroachImpl) Get_Node_By_Id(ctx context.Context,
 	node *Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contact_up_transitions, nodes.contact_down_transitions, nodes.contact_transitions_since FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -20071,7 +21570,7 @@ func (obj *pgxcockroachImpl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.ContactUpTransitions, &node.ContactDownTransitions, &node.ContactTransitionsSince)
 	if err != nil {
 		return (*Node)(nil), obj.makeErr(err)
 	}
@@ -20127,9 +21626,9 @@ func (obj *pgxcockroachImpl) Paged_Node(ctx context.Context,
 	rows []*Node, next *Paged_Node_Continuation, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.id FROM nodes WHERE (nodes.id) > ? ORDER BY nodes.id LIMIT ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contact_up_transitions, nodes.contact_down_transitions, nodes.contact_transitions_since, nodes.id FROM nodes WHERE (nodes.id) > ? ORDER BY nodes.id LIMIT ?")
 
-	var __embed_first_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.id FROM nodes ORDER BY nodes.id LIMIT ?")
+	var __embed_first_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contact_up_transitions, nodes.contact_down_transitions, nodes.contact_transitions_since, nodes.id FROM nodes ORDER BY nodes.id LIMIT ?")
 
 	var __values []interface{}
 
@@ -20156,7 +21655,7 @@ func (obj *pgxcockroachImpl) Paged_Node(ctx context.Context,
 
 			for __rows.Next() {
 				node := &Node{}
-				err = __rows.Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &__continuation._value_id)
+				err = __rows.Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.ContactUpTransitions, &node.ContactDownTransitions, &node.ContactTransitionsSince, &__continuation._value_id)
 				if err != nil {
 					return nil, nil, err
 				}
@@ -20389,69 +21888,161 @@ func (obj *pgxcockroachImpl) Get_User_ProjectLimit_By_Id(ctx context.Context,
 	if err != nil {
 		return (*ProjectLimit_Row)(nil), obj.makeErr(err)
 	}
-	return row, nil
+	return row, nil
+
+}
+
+func (obj *pgxcockroachImpl) Get_User_PaidTier_By_Id(ctx context.Context,
+	user_id User_Id_Field) (
+	row *PaidTier_Row, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT users.paid_tier FROM users WHERE users.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, user_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	row = &PaidTier_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.PaidTier)
+	if err != nil {
+		return (*PaidTier_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
+func (obj *pgxcockroachImpl) Get_User_ProjectStorageLimit_User_ProjectBandwidthLimit_User_ProjectSegmentLimit_By_Id(ctx context.Context,
+	user_id User_Id_Field) (
+	row *ProjectStorageLimit_ProjectBandwidthLimit_ProjectSegmentLimit_Row, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT users.project_storage_limit, users.project_bandwidth_limit, users.project_segment_limit FROM users WHERE users.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, user_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	row = &ProjectStorageLimit_ProjectBandwidthLimit_ProjectSegmentLimit_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.ProjectStorageLimit, &row.ProjectBandwidthLimit, &row.ProjectSegmentLimit)
+	if err != nil {
+		return (*ProjectStorageLimit_ProjectBandwidthLimit_ProjectSegmentLimit_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
+func (obj *pgxcockroachImpl) All_WebappSession_By_UserId(ctx context.Context,
+	webapp_session_user_id WebappSession_UserId_Field) (
+	rows []*WebappSession, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.last_used_at FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, webapp_session_user_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*WebappSession, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				webapp_session := &WebappSession{}
+				err = __rows.Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.LastUsedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, webapp_session)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
 
 }
 
-func (obj *pgxcockroachImpl) Get_User_PaidTier_By_Id(ctx context.Context,
-	user_id User_Id_Field) (
-	row *PaidTier_Row, err error) {
+func (obj *pgxcockroachImpl) Get_WebappSession_By_Id(ctx context.Context,
+	webapp_session_id WebappSession_Id_Field) (
+	webapp_session *WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT users.paid_tier FROM users WHERE users.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.last_used_at FROM webapp_sessions WHERE webapp_sessions.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, user_id.value())
+	__values = append(__values, webapp_session_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	row = &PaidTier_Row{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.PaidTier)
+	webapp_session = &WebappSession{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.LastUsedAt)
 	if err != nil {
-		return (*PaidTier_Row)(nil), obj.makeErr(err)
+		return (*WebappSession)(nil), obj.makeErr(err)
 	}
-	return row, nil
+	return webapp_session, nil
 
 }
 
-func (obj *pgxcockroachImpl) Get_User_ProjectStorageLimit_User_ProjectBandwidthLimit_User_ProjectSegmentLimit_By_Id(ctx context.Context,
-	user_id User_Id_Field) (
-	row *ProjectStorageLimit_ProjectBandwidthLimit_ProjectSegmentLimit_Row, err error) {
+func (obj *pgxcockroachImpl) Count_SecurityEvent_By_UserId(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field) (
+	count int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT users.project_storage_limit, users.project_bandwidth_limit, users.project_segment_limit FROM users WHERE users.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT COUNT(*) FROM security_events WHERE security_events.user_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, user_id.value())
+	__values = append(__values, security_event_user_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	row = &ProjectStorageLimit_ProjectBandwidthLimit_ProjectSegmentLimit_Row{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.ProjectStorageLimit, &row.ProjectBandwidthLimit, &row.ProjectSegmentLimit)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&count)
 	if err != nil {
-		return (*ProjectStorageLimit_ProjectBandwidthLimit_ProjectSegmentLimit_Row)(nil), obj.makeErr(err)
+		return 0, obj.makeErr(err)
 	}
-	return row, nil
+
+	return count, nil
 
 }
 
-func (obj *pgxcockroachImpl) All_WebappSession_By_UserId(ctx context.Context,
-	webapp_session_user_id WebappSession_UserId_Field) (
-	rows []*WebappSession, err error) {
+func (obj *pgxcockroachImpl) Limited_SecurityEvent_By_UserId_OrderBy_Desc_CreatedAt_Desc_Id(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field,
+	limit int, offset int64) (
+	rows []*SecurityEvent, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT security_events.id, security_events.user_id, security_events.event_type, security_events.ip_address, security_events.created_at, security_events.reviewed_at FROM security_events WHERE security_events.user_id = ? ORDER BY security_events.created_at DESC, security_events.id DESC LIMIT ? OFFSET ?")
 
 	var __values []interface{}
-	__values = append(__values, webapp_session_user_id.value())
+	__values = append(__values, security_event_user_id.value())
+
+	__values = append(__values, limit, offset)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*WebappSession, err error) {
+		rows, err = func() (rows []*SecurityEvent, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -20459,14 +22050,15 @@ func (obj *pgxcockroachImpl) All_WebappSession_By_UserId(ctx context.Context,
 			defer __rows.Close()
 
 			for __rows.Next() {
-				webapp_session := &WebappSession{}
-				err = __rows.Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+				security_event := &SecurityEvent{}
+				err = __rows.Scan(&security_event.Id, &security_event.UserId, &security_event.EventType, &security_event.IpAddress, &security_event.CreatedAt, &security_event.ReviewedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, webapp_session)
+				rows = append(rows, security_event)
 			}
-			if err := __rows.Err(); err != nil {
+			err = __rows.Err()
+			if err != nil {
 				return nil, err
 			}
 			return rows, nil
@@ -20482,25 +22074,51 @@ func (obj *pgxcockroachImpl) All_WebappSession_By_UserId(ctx context.Context,
 
 }
 
-func (obj *pgxcockroachImpl) Get_WebappSession_By_Id(ctx context.Context,
-	webapp_session_id WebappSession_Id_Field) (
-	webapp_session *WebappSession, err error) {
+func (obj *pgxcockroachImpl) First_SecurityEvent_By_UserId_And_EventType_OrderBy_Desc_CreatedAt_Desc_Id(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field,
+	security_event_event_type SecurityEvent_EventType_Field) (
+	security_event *SecurityEvent, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at FROM webapp_sessions WHERE webapp_sessions.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT security_events.id, security_events.user_id, security_events.event_type, security_events.ip_address, security_events.created_at, security_events.reviewed_at FROM security_events WHERE security_events.user_id = ? AND security_events.event_type = ? ORDER BY security_events.created_at DESC, security_events.id DESC LIMIT 1 OFFSET 0")
 
 	var __values []interface{}
-	__values = append(__values, webapp_session_id.value())
+	__values = append(__values, security_event_user_id.value(), security_event_event_type.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
-	if err != nil {
-		return (*WebappSession)(nil), obj.makeErr(err)
+	for {
+		security_event, err = func() (security_event *SecurityEvent, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			if !__rows.Next() {
+				if err := __rows.Err(); err != nil {
+					return nil, err
+				}
+				return nil, nil
+			}
+
+			security_event = &SecurityEvent{}
+			err = __rows.Scan(&security_event.Id, &security_event.UserId, &security_event.EventType, &security_event.IpAddress, &security_event.CreatedAt, &security_event.ReviewedAt)
+			if err != nil {
+				return nil, err
+			}
+
+			return security_event, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return security_event, nil
 	}
-	return webapp_session, nil
 
 }
 
@@ -20509,7 +22127,7 @@ func (obj *pgxcockroachImpl) Get_Project_By_Id(ctx context.Context,
 	project *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, project_id.value())
@@ -20518,7 +22136,7 @@ func (obj *pgxcockroachImpl) Get_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 	if err != nil {
 		return (*Project)(nil), obj.makeErr(err)
 	}
@@ -20614,6 +22232,29 @@ func (obj *pgxcockroachImpl) Get_Project_MaxBuckets_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) Get_ProjectMemberRole_Role_By_MemberId_And_ProjectId(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field) (
+	row *Role_Row, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_member_roles.role FROM project_member_roles WHERE project_member_roles.member_id = ? AND project_member_roles.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_member_role_member_id.value(), project_member_role_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	row = &Role_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.Role)
+	if err != nil {
+		return (*Role_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
 func (obj *pgxcockroachImpl) Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	row *BandwidthLimit_UsageLimit_SegmentLimit_Row, err error) {
@@ -20640,7 +22281,7 @@ func (obj *pgxcockroachImpl) All_Project(ctx context.Context) (
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects")
 
 	var __values []interface{}
 
@@ -20657,7 +22298,7 @@ func (obj *pgxcockroachImpl) All_Project(ctx context.Context) (
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -20684,7 +22325,7 @@ func (obj *pgxcockroachImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at")
 
 	var __values []interface{}
 	__values = append(__values, project_created_at_less.value())
@@ -20702,7 +22343,7 @@ func (obj *pgxcockroachImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -20729,7 +22370,7 @@ func (obj *pgxcockroachImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx co
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.owner_id = ? ORDER BY projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.owner_id = ? ORDER BY projects.created_at")
 
 	var __values []interface{}
 	__values = append(__values, project_owner_id.value())
@@ -20747,7 +22388,7 @@ func (obj *pgxcockroachImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx co
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -20774,7 +22415,7 @@ func (obj *pgxcockroachImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_P
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
 
 	var __values []interface{}
 	__values = append(__values, project_member_member_id.value())
@@ -20792,7 +22433,7 @@ func (obj *pgxcockroachImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_P
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -20820,7 +22461,7 @@ func (obj *pgxcockroachImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_Creat
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, project_created_at_less.value())
@@ -20840,7 +22481,7 @@ func (obj *pgxcockroachImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_Creat
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -20913,7 +22554,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Id(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at FROM api_keys WHERE api_keys.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at, api_keys.expires_at FROM api_keys WHERE api_keys.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_id.value())
@@ -20922,7 +22563,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt, &api_key.ExpiresAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -20935,7 +22576,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Head(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at FROM api_keys WHERE api_keys.head = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at, api_keys.expires_at FROM api_keys WHERE api_keys.head = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_head.value())
@@ -20944,7 +22585,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Head(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt, &api_key.ExpiresAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -20958,7 +22599,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Contex
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at FROM api_keys WHERE api_keys.name = ? AND api_keys.project_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.user_agent, api_keys.created_at, api_keys.expires_at FROM api_keys WHERE api_keys.name = ? AND api_keys.project_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_name.value(), api_key_project_id.value())
@@ -20967,7 +22608,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Contex
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.UserAgent, &api_key.CreatedAt, &api_key.ExpiresAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -21920,7 +23561,7 @@ func (obj *pgxcockroachImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx contex
 	bucket_metainfo *BucketMetainfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
 
 	var __values []interface{}
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name.value())
@@ -21929,7 +23570,7 @@ func (obj *pgxcockroachImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx contex
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 	if err != nil {
 		return (*BucketMetainfo)(nil), obj.makeErr(err)
 	}
@@ -21983,13 +23624,13 @@ func (obj *pgxcockroachImpl) Get_BucketMetainfo_Id_By_ProjectId_And_Name(ctx con
 
 }
 
-func (obj *pgxcockroachImpl) Get_BucketMetainfo_Placement_By_ProjectId_And_Name(ctx context.Context,
+func (obj *pgxcockroachImpl) Get_BucketMetainfo_Placement_BucketMetainfo_Versioning_By_ProjectId_And_Name(ctx context.Context,
 	bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
 	bucket_metainfo_name BucketMetainfo_Name_Field) (
-	row *Placement_Row, err error) {
+	row *Placement_Versioning_Row, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.placement FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.placement, bucket_metainfos.versioning FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
 
 	var __values []interface{}
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name.value())
@@ -21997,10 +23638,10 @@ func (obj *pgxcockroachImpl) Get_BucketMetainfo_Placement_By_ProjectId_And_Name(
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	row = &Placement_Row{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.Placement)
+	row = &Placement_Versioning_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.Placement, &row.Versioning)
 	if err != nil {
-		return (*Placement_Row)(nil), obj.makeErr(err)
+		return (*Placement_Versioning_Row)(nil), obj.makeErr(err)
 	}
 	return row, nil
 
@@ -22035,7 +23676,7 @@ func (obj *pgxcockroachImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greate
 	rows []*BucketMetainfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater_or_equal.value())
@@ -22055,7 +23696,7 @@ func (obj *pgxcockroachImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greate
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 				if err != nil {
 					return nil, err
 				}
@@ -22085,7 +23726,7 @@ func (obj *pgxcockroachImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greate
 	rows []*BucketMetainfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater.value())
@@ -22105,7 +23746,7 @@ func (obj *pgxcockroachImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greate
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 				if err != nil {
 					return nil, err
 				}
@@ -23153,7 +24794,7 @@ func (obj *pgxcockroachImpl) Update_Node_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contact_up_transitions, nodes.contact_down_transitions, nodes.contact_transitions_since")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -23309,6 +24950,21 @@ func (obj *pgxcockroachImpl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("exit_success = ?"))
 	}
 
+	if update.ContactUpTransitions._set {
+		__values = append(__values, update.ContactUpTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_up_transitions = ?"))
+	}
+
+	if update.ContactDownTransitions._set {
+		__values = append(__values, update.ContactDownTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_down_transitions = ?"))
+	}
+
+	if update.ContactTransitionsSince._set {
+		__values = append(__values, update.ContactTransitionsSince.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_transitions_since = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -23323,7 +24979,7 @@ func (obj *pgxcockroachImpl) Update_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.ContactUpTransitions, &node.ContactDownTransitions, &node.ContactTransitionsSince)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -23496,6 +25152,21 @@ func (obj *pgxcockroachImpl) UpdateNoReturn_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("exit_success = ?"))
 	}
 
+	if update.ContactUpTransitions._set {
+		__values = append(__values, update.ContactUpTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_up_transitions = ?"))
+	}
+
+	if update.ContactDownTransitions._set {
+		__values = append(__values, update.ContactDownTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_down_transitions = ?"))
+	}
+
+	if update.ContactTransitionsSince._set {
+		__values = append(__values, update.ContactTransitionsSince.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_transitions_since = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -23679,6 +25350,21 @@ func (obj *pgxcockroachImpl) UpdateNoReturn_Node_By_Id_And_Disqualified_Is_Null_
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("exit_success = ?"))
 	}
 
+	if update.ContactUpTransitions._set {
+		__values = append(__values, update.ContactUpTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_up_transitions = ?"))
+	}
+
+	if update.ContactDownTransitions._set {
+		__values = append(__values, update.ContactDownTransitions.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_down_transitions = ?"))
+	}
+
+	if update.ContactTransitionsSince._set {
+		__values = append(__values, update.ContactTransitionsSince.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("contact_transitions_since = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -24190,7 +25876,7 @@ func (obj *pgxcockroachImpl) Update_WebappSession_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE webapp_sessions SET "), __sets, __sqlbundle_Literal(" WHERE webapp_sessions.id = ? RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE webapp_sessions SET "), __sets, __sqlbundle_Literal(" WHERE webapp_sessions.id = ? RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.last_used_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -24206,6 +25892,11 @@ func (obj *pgxcockroachImpl) Update_WebappSession_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("expires_at = ?"))
 	}
 
+	if update.LastUsedAt._set {
+		__values = append(__values, update.LastUsedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_used_at = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -24219,7 +25910,7 @@ func (obj *pgxcockroachImpl) Update_WebappSession_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.LastUsedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -24236,7 +25927,7 @@ func (obj *pgxcockroachImpl) Update_Project_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -24282,6 +25973,16 @@ func (obj *pgxcockroachImpl) Update_Project_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("max_buckets = ?"))
 	}
 
+	if update.EgressRateLimit._set {
+		__values = append(__values, update.EgressRateLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_rate_limit = ?"))
+	}
+
+	if update.AdminEgressRateLimit._set {
+		__values = append(__values, update.AdminEgressRateLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("admin_egress_rate_limit = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -24295,7 +25996,7 @@ func (obj *pgxcockroachImpl) Update_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.EgressRateLimit, &project.AdminEgressRateLimit, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -24434,7 +26135,7 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx con
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.partner_id, bucket_metainfos.user_agent, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.versioning")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -24500,6 +26201,11 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx con
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.Versioning._set {
+		__values = append(__values, update.Versioning.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("versioning = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -24513,7 +26219,7 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx con
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.PartnerId, &bucket_metainfo.UserAgent, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.Versioning)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -25119,6 +26825,34 @@ func (obj *pgxcockroachImpl) Delete_ProjectMember_By_MemberId_And_ProjectId(ctx
 
 }
 
+func (obj *pgxcockroachImpl) Delete_ProjectMemberRole_By_MemberId_And_ProjectId(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM project_member_roles WHERE project_member_roles.member_id = ? AND project_member_roles.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_member_role_member_id.value(), project_member_role_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (obj *pgxcockroachImpl) Delete_ApiKey_By_Id(ctx context.Context,
 	api_key_id ApiKey_Id_Field) (
 	deleted bool, err error) {
@@ -25422,6 +27156,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_member_roles;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -25592,6 +27336,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM security_events;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -25642,6 +27396,26 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM repair_checkpoints;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM repair_bandwidth_rollups;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -25772,6 +27546,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM data_erasure_jobs;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -26184,6 +27968,16 @@ func (rx *Rx) Count_BucketMetainfo_Name_By_ProjectId(ctx context.Context,
 	return tx.Count_BucketMetainfo_Name_By_ProjectId(ctx, bucket_metainfo_project_id)
 }
 
+func (rx *Rx) Count_SecurityEvent_By_UserId(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field) (
+	count int64, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Count_SecurityEvent_By_UserId(ctx, security_event_user_id)
+}
+
 func (rx *Rx) CreateNoReturn_AccountingTimestamps(ctx context.Context,
 	accounting_timestamps_name AccountingTimestamps_Name_Field,
 	accounting_timestamps_value AccountingTimestamps_Value_Field) (
@@ -26508,6 +28302,21 @@ func (rx *Rx) Create_ResetPasswordToken(ctx context.Context,
 
 }
 
+func (rx *Rx) Create_SecurityEvent(ctx context.Context,
+	security_event_id SecurityEvent_Id_Field,
+	security_event_user_id SecurityEvent_UserId_Field,
+	security_event_event_type SecurityEvent_EventType_Field,
+	security_event_ip_address SecurityEvent_IpAddress_Field,
+	optional SecurityEvent_Create_Fields) (
+	security_event *SecurityEvent, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_SecurityEvent(ctx, security_event_id, security_event_user_id, security_event_event_type, security_event_ip_address, optional)
+
+}
+
 func (rx *Rx) Create_StoragenodeBandwidthRollup(ctx context.Context,
 	storagenode_bandwidth_rollup_storagenode_id StoragenodeBandwidthRollup_StoragenodeId_Field,
 	storagenode_bandwidth_rollup_interval_start StoragenodeBandwidthRollup_IntervalStart_Field,
@@ -26601,13 +28410,14 @@ func (rx *Rx) Create_WebappSession(ctx context.Context,
 	webapp_session_user_id WebappSession_UserId_Field,
 	webapp_session_ip_address WebappSession_IpAddress_Field,
 	webapp_session_user_agent WebappSession_UserAgent_Field,
-	webapp_session_expires_at WebappSession_ExpiresAt_Field) (
+	webapp_session_expires_at WebappSession_ExpiresAt_Field,
+	optional WebappSession_Create_Fields) (
 	webapp_session *WebappSession, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_WebappSession(ctx, webapp_session_id, webapp_session_user_id, webapp_session_ip_address, webapp_session_user_agent, webapp_session_expires_at)
+	return tx.Create_WebappSession(ctx, webapp_session_id, webapp_session_user_id, webapp_session_ip_address, webapp_session_user_agent, webapp_session_expires_at, optional)
 
 }
 
@@ -26697,6 +28507,17 @@ func (rx *Rx) Delete_OauthClient_By_Id(ctx context.Context,
 	return tx.Delete_OauthClient_By_Id(ctx, oauth_client_id)
 }
 
+func (rx *Rx) Delete_ProjectMemberRole_By_MemberId_And_ProjectId(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field) (
+	deleted bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_ProjectMemberRole_By_MemberId_And_ProjectId(ctx, project_member_role_member_id, project_member_role_project_id)
+}
+
 func (rx *Rx) Delete_ProjectMember_By_MemberId_And_ProjectId(ctx context.Context,
 	project_member_member_id ProjectMember_MemberId_Field,
 	project_member_project_id ProjectMember_ProjectId_Field) (
@@ -26801,6 +28622,17 @@ func (rx *Rx) Find_AccountingTimestamps_Value_By_Name(ctx context.Context,
 	return tx.Find_AccountingTimestamps_Value_By_Name(ctx, accounting_timestamps_name)
 }
 
+func (rx *Rx) First_SecurityEvent_By_UserId_And_EventType_OrderBy_Desc_CreatedAt_Desc_Id(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field,
+	security_event_event_type SecurityEvent_EventType_Field) (
+	security_event *SecurityEvent, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.First_SecurityEvent_By_UserId_And_EventType_OrderBy_Desc_CreatedAt_Desc_Id(ctx, security_event_user_id, security_event_event_type)
+}
+
 func (rx *Rx) First_StorjscanPayment_BlockNumber_By_Status_OrderBy_Desc_BlockNumber_Desc_LogIndex(ctx context.Context,
 	storjscan_payment_status StorjscanPayment_Status_Field) (
 	row *BlockNumber_Row, err error) {
@@ -26875,15 +28707,15 @@ func (rx *Rx) Get_BucketMetainfo_Id_By_ProjectId_And_Name(ctx context.Context,
 	return tx.Get_BucketMetainfo_Id_By_ProjectId_And_Name(ctx, bucket_metainfo_project_id, bucket_metainfo_name)
 }
 
-func (rx *Rx) Get_BucketMetainfo_Placement_By_ProjectId_And_Name(ctx context.Context,
+func (rx *Rx) Get_BucketMetainfo_Placement_BucketMetainfo_Versioning_By_ProjectId_And_Name(ctx context.Context,
 	bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
 	bucket_metainfo_name BucketMetainfo_Name_Field) (
-	row *Placement_Row, err error) {
+	row *Placement_Versioning_Row, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Get_BucketMetainfo_Placement_By_ProjectId_And_Name(ctx, bucket_metainfo_project_id, bucket_metainfo_name)
+	return tx.Get_BucketMetainfo_Placement_BucketMetainfo_Versioning_By_ProjectId_And_Name(ctx, bucket_metainfo_project_id, bucket_metainfo_name)
 }
 
 func (rx *Rx) Get_CouponCode_By_Name(ctx context.Context,
@@ -26990,6 +28822,17 @@ func (rx *Rx) Get_PeerIdentity_LeafSerialNumber_By_NodeId(ctx context.Context,
 	return tx.Get_PeerIdentity_LeafSerialNumber_By_NodeId(ctx, peer_identity_node_id)
 }
 
+func (rx *Rx) Get_ProjectMemberRole_Role_By_MemberId_And_ProjectId(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field) (
+	row *Role_Row, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_ProjectMemberRole_Role_By_MemberId_And_ProjectId(ctx, project_member_role_member_id, project_member_role_project_id)
+}
+
 func (rx *Rx) Get_Project_BandwidthLimit_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	row *BandwidthLimit_Row, err error) {
@@ -27314,6 +29157,17 @@ func (rx *Rx) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx contex
 	return tx.Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx, project_created_at_less, limit, offset)
 }
 
+func (rx *Rx) Limited_SecurityEvent_By_UserId_OrderBy_Desc_CreatedAt_Desc_Id(ctx context.Context,
+	security_event_user_id SecurityEvent_UserId_Field,
+	limit int, offset int64) (
+	rows []*SecurityEvent, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Limited_SecurityEvent_By_UserId_OrderBy_Desc_CreatedAt_Desc_Id(ctx, security_event_user_id, limit, offset)
+}
+
 func (rx *Rx) Limited_StoragenodePayment_By_NodeId_And_Period_OrderBy_Desc_Id(ctx context.Context,
 	storagenode_payment_node_id StoragenodePayment_NodeId_Field,
 	storagenode_payment_period StoragenodePayment_Period_Field,
@@ -27451,6 +29305,20 @@ func (rx *Rx) ReplaceNoReturn_NodeApiVersion(ctx context.Context,
 
 }
 
+func (rx *Rx) ReplaceNoReturn_ProjectMemberRole(ctx context.Context,
+	project_member_role_member_id ProjectMemberRole_MemberId_Field,
+	project_member_role_project_id ProjectMemberRole_ProjectId_Field,
+	project_member_role_role ProjectMemberRole_Role_Field,
+	project_member_role_updated_at ProjectMemberRole_UpdatedAt_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.ReplaceNoReturn_ProjectMemberRole(ctx, project_member_role_member_id, project_member_role_project_id, project_member_role_role, project_member_role_updated_at)
+
+}
+
 func (rx *Rx) ReplaceNoReturn_StoragenodePaystub(ctx context.Context,
 	storagenode_paystub_period StoragenodePaystub_Period_Field,
 	storagenode_paystub_node_id StoragenodePaystub_NodeId_Field,
@@ -27848,6 +29716,10 @@ type Methods interface {
 		bucket_metainfo_project_id BucketMetainfo_ProjectId_Field) (
 		count int64, err error)
 
+	Count_SecurityEvent_By_UserId(ctx context.Context,
+		security_event_user_id SecurityEvent_UserId_Field) (
+		count int64, err error)
+
 	CreateNoReturn_AccountingTimestamps(ctx context.Context,
 		accounting_timestamps_name AccountingTimestamps_Name_Field,
 		accounting_timestamps_value AccountingTimestamps_Value_Field) (
@@ -28025,6 +29897,14 @@ type Methods interface {
 		reset_password_token_owner_id ResetPasswordToken_OwnerId_Field) (
 		reset_password_token *ResetPasswordToken, err error)
 
+	Create_SecurityEvent(ctx context.Context,
+		security_event_id SecurityEvent_Id_Field,
+		security_event_user_id SecurityEvent_UserId_Field,
+		security_event_event_type SecurityEvent_EventType_Field,
+		security_event_ip_address SecurityEvent_IpAddress_Field,
+		optional SecurityEvent_Create_Fields) (
+		security_event *SecurityEvent, err error)
+
 	Create_StoragenodeBandwidthRollup(ctx context.Context,
 		storagenode_bandwidth_rollup_storagenode_id StoragenodeBandwidthRollup_StoragenodeId_Field,
 		storagenode_bandwidth_rollup_interval_start StoragenodeBandwidthRollup_IntervalStart_Field,
@@ -28076,7 +29956,8 @@ type Methods interface {
 		webapp_session_user_id WebappSession_UserId_Field,
 		webapp_session_ip_address WebappSession_IpAddress_Field,
 		webapp_session_user_agent WebappSession_UserAgent_Field,
-		webapp_session_expires_at WebappSession_ExpiresAt_Field) (
+		webapp_session_expires_at WebappSession_ExpiresAt_Field,
+		optional WebappSession_Create_Fields) (
 		webapp_session *WebappSession, err error)
 
 	Delete_ApiKey_By_Id(ctx context.Context,
@@ -28115,6 +29996,11 @@ type Methods interface {
 		oauth_client_id OauthClient_Id_Field) (
 		deleted bool, err error)
 
+	Delete_ProjectMemberRole_By_MemberId_And_ProjectId(ctx context.Context,
+		project_member_role_member_id ProjectMemberRole_MemberId_Field,
+		project_member_role_project_id ProjectMemberRole_ProjectId_Field) (
+		deleted bool, err error)
+
 	Delete_ProjectMember_By_MemberId_And_ProjectId(ctx context.Context,
 		project_member_member_id ProjectMember_MemberId_Field,
 		project_member_project_id ProjectMember_ProjectId_Field) (
@@ -28156,6 +30042,11 @@ type Methods interface {
 		accounting_timestamps_name AccountingTimestamps_Name_Field) (
 		row *Value_Row, err error)
 
+	First_SecurityEvent_By_UserId_And_EventType_OrderBy_Desc_CreatedAt_Desc_Id(ctx context.Context,
+		security_event_user_id SecurityEvent_UserId_Field,
+		security_event_event_type SecurityEvent_EventType_Field) (
+		security_event *SecurityEvent, err error)
+
 	First_StorjscanPayment_BlockNumber_By_Status_OrderBy_Desc_BlockNumber_Desc_LogIndex(ctx context.Context,
 		storjscan_payment_status StorjscanPayment_Status_Field) (
 		row *BlockNumber_Row, err error)
@@ -28188,10 +30079,10 @@ type Methods interface {
 		bucket_metainfo_name BucketMetainfo_Name_Field) (
 		row *Id_Row, err error)
 
-	Get_BucketMetainfo_Placement_By_ProjectId_And_Name(ctx context.Context,
+	Get_BucketMetainfo_Placement_BucketMetainfo_Versioning_By_ProjectId_And_Name(ctx context.Context,
 		bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
 		bucket_metainfo_name BucketMetainfo_Name_Field) (
-		row *Placement_Row, err error)
+		row *Placement_Versioning_Row, err error)
 
 	Get_CouponCode_By_Name(ctx context.Context,
 		coupon_code_name CouponCode_Name_Field) (
@@ -28237,6 +30128,11 @@ type Methods interface {
 		peer_identity_node_id PeerIdentity_NodeId_Field) (
 		row *LeafSerialNumber_Row, err error)
 
+	Get_ProjectMemberRole_Role_By_MemberId_And_ProjectId(ctx context.Context,
+		project_member_role_member_id ProjectMemberRole_MemberId_Field,
+		project_member_role_project_id ProjectMemberRole_ProjectId_Field) (
+		row *Role_Row, err error)
+
 	Get_Project_BandwidthLimit_By_Id(ctx context.Context,
 		project_id Project_Id_Field) (
 		row *BandwidthLimit_Row, err error)
@@ -28375,6 +30271,11 @@ type Methods interface {
 		limit int, offset int64) (
 		rows []*Project, err error)
 
+	Limited_SecurityEvent_By_UserId_OrderBy_Desc_CreatedAt_Desc_Id(ctx context.Context,
+		security_event_user_id SecurityEvent_UserId_Field,
+		limit int, offset int64) (
+		rows []*SecurityEvent, err error)
+
 	Limited_StoragenodePayment_By_NodeId_And_Period_OrderBy_Desc_Id(ctx context.Context,
 		storagenode_payment_node_id StoragenodePayment_NodeId_Field,
 		storagenode_payment_period StoragenodePayment_Period_Field,
@@ -28439,6 +30340,13 @@ type Methods interface {
 		node_api_version_api_version NodeApiVersion_ApiVersion_Field) (
 		err error)
 
+	ReplaceNoReturn_ProjectMemberRole(ctx context.Context,
+		project_member_role_member_id ProjectMemberRole_MemberId_Field,
+		project_member_role_project_id ProjectMemberRole_ProjectId_Field,
+		project_member_role_role ProjectMemberRole_Role_Field,
+		project_member_role_updated_at ProjectMemberRole_UpdatedAt_Field) (
+		err error)
+
 	ReplaceNoReturn_StoragenodePaystub(ctx context.Context,
 		storagenode_paystub_period StoragenodePaystub_Period_Field,
 		storagenode_paystub_node_id StoragenodePaystub_NodeId_Field,
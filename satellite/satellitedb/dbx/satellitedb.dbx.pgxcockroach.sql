@@ -106,6 +106,15 @@ CREATE TABLE coupon_usages (
 	period timestamp with time zone NOT NULL,
 	PRIMARY KEY ( coupon_id, period )
 );
+CREATE TABLE data_erasure_jobs (
+	id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	status integer NOT NULL,
+	deleted_objects bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	completed_at timestamp with time zone,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE graceful_exit_progress (
 	node_id bytea NOT NULL,
 	bytes_transferred bigint NOT NULL,
@@ -164,6 +173,9 @@ CREATE TABLE nodes (
 	exit_loop_completed_at timestamp with time zone,
 	exit_finished_at timestamp with time zone,
 	exit_success boolean NOT NULL DEFAULT false,
+	contact_up_transitions integer NOT NULL DEFAULT 0,
+	contact_down_transitions integer NOT NULL DEFAULT 0,
+	contact_transitions_since timestamp with time zone,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE node_api_versions (
@@ -237,6 +249,8 @@ CREATE TABLE projects (
 	rate_limit integer,
 	burst_limit integer,
 	max_buckets integer,
+	egress_rate_limit bigint,
+	admin_egress_rate_limit bigint,
 	partner_id bytea,
 	user_agent bytea,
 	owner_id bytea NOT NULL,
@@ -265,6 +279,21 @@ CREATE TABLE registration_tokens (
 	PRIMARY KEY ( secret ),
 	UNIQUE ( owner_id )
 );
+CREATE TABLE repair_bandwidth_rollups (
+	placement integer NOT NULL,
+	interval_start timestamp with time zone NOT NULL,
+	action integer NOT NULL,
+	allocated bigint NOT NULL,
+	PRIMARY KEY ( placement, interval_start, action )
+);
+CREATE TABLE repair_checkpoints (
+	stream_id bytea NOT NULL,
+	position bigint NOT NULL,
+	piece_num integer NOT NULL,
+	node_id bytea NOT NULL,
+	uploaded_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( stream_id, position, piece_num )
+);
 CREATE TABLE repair_queue (
 	stream_id bytea NOT NULL,
 	position bigint NOT NULL,
@@ -272,6 +301,10 @@ CREATE TABLE repair_queue (
 	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
+	held boolean NOT NULL DEFAULT false,
+	attempts integer NOT NULL DEFAULT 0,
+	exhausted_at timestamp with time zone,
+	placement integer,
 	PRIMARY KEY ( stream_id, position )
 );
 CREATE TABLE reputations (
@@ -306,6 +339,15 @@ CREATE TABLE revocations (
 	api_key_id bytea NOT NULL,
 	PRIMARY KEY ( revoked )
 );
+CREATE TABLE security_events (
+	id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	event_type text NOT NULL,
+	ip_address text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	reviewed_at timestamp with time zone,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE segment_pending_audits (
 	node_id bytea NOT NULL,
 	stream_id bytea NOT NULL,
@@ -480,6 +522,7 @@ CREATE TABLE webapp_sessions (
 	user_agent text NOT NULL,
 	status integer NOT NULL,
 	expires_at timestamp with time zone NOT NULL,
+	last_used_at timestamp with time zone,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE api_keys (
@@ -491,6 +534,7 @@ CREATE TABLE api_keys (
 	partner_id bytea,
 	user_agent bytea,
 	created_at timestamp with time zone NOT NULL,
+	expires_at timestamp with time zone,
 	PRIMARY KEY ( id ),
 	UNIQUE ( head ),
 	UNIQUE ( name, project_id )
@@ -513,9 +557,17 @@ CREATE TABLE bucket_metainfos (
 	default_redundancy_optimal_shares integer NOT NULL,
 	default_redundancy_total_shares integer NOT NULL,
 	placement integer,
+	versioning boolean,
 	PRIMARY KEY ( id ),
 	UNIQUE ( project_id, name )
 );
+CREATE TABLE project_member_roles (
+	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	role integer NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( member_id, project_id )
+);
 CREATE TABLE project_members (
 	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -547,6 +599,7 @@ CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket
 CREATE INDEX bucket_bandwidth_rollups_archive_project_id_action_interval_index ON bucket_bandwidth_rollup_archives ( project_id, action, interval_start ) ;
 CREATE INDEX bucket_bandwidth_rollups_archive_action_interval_project_id_index ON bucket_bandwidth_rollup_archives ( action, interval_start, project_id ) ;
 CREATE INDEX bucket_storage_tallies_project_id_interval_start_index ON bucket_storage_tallies ( project_id, interval_start ) ;
+CREATE INDEX data_erasure_jobs_status_created_at_index ON data_erasure_jobs ( status, created_at ) ;
 CREATE INDEX graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index ON graceful_exit_segment_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at ) ;
 CREATE INDEX node_last_ip ON nodes ( last_net ) ;
 CREATE INDEX nodes_dis_unk_off_exit_fin_last_success_index ON nodes ( disqualified, unknown_audit_suspended, offline_suspended, exit_finished_at, last_contact_success ) ;
@@ -559,6 +612,8 @@ CREATE INDEX oauth_tokens_user_id_index ON oauth_tokens ( user_id ) ;
 CREATE INDEX oauth_tokens_client_id_index ON oauth_tokens ( client_id ) ;
 CREATE INDEX repair_queue_updated_at_index ON repair_queue ( updated_at ) ;
 CREATE INDEX repair_queue_num_healthy_pieces_attempted_at_index ON repair_queue ( segment_health, attempted_at ) ;
+CREATE INDEX repair_queue_placement_segment_health_attempted_at_index ON repair_queue ( placement, segment_health, attempted_at ) ;
+CREATE INDEX security_events_user_id_created_at_index ON security_events ( user_id, created_at ) ;
 CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start ) ;
 CREATE INDEX storagenode_bandwidth_rollup_archives_interval_start_index ON storagenode_bandwidth_rollup_archives ( interval_start ) ;
 CREATE INDEX storagenode_payments_node_id_period_index ON storagenode_payments ( node_id, period ) ;
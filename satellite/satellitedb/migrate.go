@@ -1973,6 +1973,176 @@ func (db *satelliteDB) PostgresMigration() *migrate.Migration {
 					`CREATE INDEX storjscan_payments_block_number_log_index_index ON storjscan_payments ( block_number, log_index );`,
 				},
 			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add security_events table",
+				Version:     201,
+				Action: migrate.SQL{
+					`CREATE TABLE security_events (
+						id bytea NOT NULL,
+						user_id bytea NOT NULL,
+						event_type text NOT NULL,
+						ip_address text NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						reviewed_at timestamp with time zone,
+						PRIMARY KEY ( id )
+					);`,
+					`CREATE INDEX security_events_user_id_created_at_index ON security_events ( user_id, created_at ) ;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add project_member_roles table",
+				Version:     202,
+				Action: migrate.SQL{
+					`CREATE TABLE project_member_roles (
+						member_id bytea NOT NULL,
+						project_id bytea NOT NULL,
+						role integer NOT NULL,
+						updated_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( member_id, project_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add held column to repair_queue",
+				Version:     203,
+				Action: migrate.SQL{
+					`ALTER TABLE repair_queue ADD COLUMN held boolean NOT NULL DEFAULT false;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add attempts and exhausted_at columns to repair_queue",
+				Version:     204,
+				Action: migrate.SQL{
+					`ALTER TABLE repair_queue ADD COLUMN attempts integer NOT NULL DEFAULT 0;`,
+					`ALTER TABLE repair_queue ADD COLUMN exhausted_at timestamp with time zone;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add repair_bandwidth_rollups table",
+				Version:     205,
+				Action: migrate.SQL{
+					`CREATE TABLE repair_bandwidth_rollups (
+						placement integer NOT NULL,
+						interval_start timestamp with time zone NOT NULL,
+						action integer NOT NULL,
+						allocated bigint NOT NULL,
+						PRIMARY KEY ( placement, interval_start, action )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add expires_at column to api_keys",
+				Version:     206,
+				Action: migrate.SQL{
+					`ALTER TABLE api_keys ADD COLUMN expires_at timestamp with time zone;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add repair_checkpoints table",
+				Version:     207,
+				Action: migrate.SQL{
+					`CREATE TABLE repair_checkpoints (
+						stream_id bytea NOT NULL,
+						position bigint NOT NULL,
+						piece_num integer NOT NULL,
+						node_id bytea NOT NULL,
+						uploaded_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( stream_id, position, piece_num )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add egress_rate_limit column to projects",
+				Version:     208,
+				Action: migrate.SQL{
+					`ALTER TABLE projects ADD COLUMN egress_rate_limit bigint;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add data_erasure_jobs table",
+				Version:     209,
+				Action: migrate.SQL{
+					`CREATE TABLE data_erasure_jobs (
+						id bytea NOT NULL,
+						user_id bytea NOT NULL,
+						status integer NOT NULL,
+						deleted_objects bigint NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						completed_at timestamp with time zone,
+						PRIMARY KEY ( id )
+					);`,
+					`CREATE INDEX data_erasure_jobs_status_created_at_index ON data_erasure_jobs ( status, created_at ) ;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add versioning column to bucket_metainfos",
+				Version:     210,
+				Action: migrate.SQL{
+					`ALTER TABLE bucket_metainfos ADD COLUMN versioning boolean;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add last_used_at column to webapp_sessions",
+				Version:     211,
+				Action: migrate.SQL{
+					`ALTER TABLE webapp_sessions ADD COLUMN last_used_at timestamp with time zone`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add placement column to repair_queue",
+				Version:     212,
+				Action: migrate.SQL{
+					`ALTER TABLE repair_queue ADD COLUMN placement integer`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add contact transition columns to nodes for flapping detection",
+				Version:     213,
+				Action: migrate.SQL{
+					`ALTER TABLE nodes ADD COLUMN contact_up_transitions integer NOT NULL DEFAULT 0`,
+					`ALTER TABLE nodes ADD COLUMN contact_down_transitions integer NOT NULL DEFAULT 0`,
+					`ALTER TABLE nodes ADD COLUMN contact_transitions_since timestamp with time zone`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add admin_egress_rate_limit column to projects",
+				Version:     214,
+				Action: migrate.SQL{
+					`ALTER TABLE projects ADD COLUMN admin_egress_rate_limit bigint;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add foreign keys to project_member_roles",
+				Version:     215,
+				Action: migrate.SQL{
+					`DELETE FROM project_member_roles WHERE NOT EXISTS ( SELECT 1 FROM project_members WHERE project_members.member_id = project_member_roles.member_id AND project_members.project_id = project_member_roles.project_id )`,
+					`ALTER TABLE project_member_roles ADD CONSTRAINT project_member_roles_member_id_fkey FOREIGN KEY ( member_id ) REFERENCES users( id ) ON DELETE CASCADE`,
+					`ALTER TABLE project_member_roles ADD CONSTRAINT project_member_roles_project_id_fkey FOREIGN KEY ( project_id ) REFERENCES projects( id ) ON DELETE CASCADE`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add index on repair_queue placement",
+				Version:     216,
+				Action: migrate.SQL{
+					`CREATE INDEX repair_queue_placement_segment_health_attempted_at_index ON repair_queue ( placement, segment_health, attempted_at ) ;`,
+				},
+			},
 			// NB: after updating testdata in `testdata`, run
 			//     `go generate` to update `migratez.go`.
 		},
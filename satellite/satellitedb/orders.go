@@ -157,6 +157,25 @@ func (db *ordersDB) UpdateBucketBandwidthInline(ctx context.Context, projectID u
 	return nil
 }
 
+// UpdateRepairBandwidthAllocation updates 'allocated' repair bandwidth for given placement.
+func (db *ordersDB) UpdateRepairBandwidthAllocation(ctx context.Context, placement storj.PlacementConstraint, action pb.PieceAction, amount int64, intervalStart time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	statement := db.db.Rebind(
+		`INSERT INTO repair_bandwidth_rollups (placement, interval_start, action, allocated)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(placement, interval_start, action)
+		DO UPDATE SET allocated = repair_bandwidth_rollups.allocated + ?`,
+	)
+	_, err = db.db.ExecContext(ctx, statement,
+		int(placement), intervalStart.UTC(), action, uint64(amount), uint64(amount),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // UpdateStoragenodeBandwidthSettle updates 'settled' bandwidth for given storage node for the given intervalStart time.
 func (db *ordersDB) UpdateStoragenodeBandwidthSettle(ctx context.Context, storageNode storj.NodeID, action pb.PieceAction, amount int64, intervalStart time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
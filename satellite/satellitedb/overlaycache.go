@@ -215,6 +215,25 @@ func (cache *overlaycache) getNodesNetwork(ctx context.Context, nodeIDs []storj.
 	return nodeNets, Error.Wrap(rows.Err())
 }
 
+// GetNodeStability returns the number of up and down transitions of a node within the current flapping window.
+func (cache *overlaycache) GetNodeStability(ctx context.Context, nodeID storj.NodeID) (stability overlay.NodeStability, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := cache.db.Get_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return overlay.NodeStability{}, overlay.ErrNodeNotFound.New("%v", nodeID)
+	}
+	if err != nil {
+		return overlay.NodeStability{}, Error.Wrap(err)
+	}
+
+	return overlay.NodeStability{
+		UpTransitions:   node.ContactUpTransitions,
+		DownTransitions: node.ContactDownTransitions,
+		Since:           node.ContactTransitionsSince,
+	}, nil
+}
+
 // Get looks up the node by nodeID.
 func (cache *overlaycache) Get(ctx context.Context, id storj.NodeID) (dossier *overlay.NodeDossier, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -309,7 +328,7 @@ func (cache *overlaycache) getOnlineNodesForAuditRepair(ctx context.Context, nod
 	var rows tagsql.Rows
 	rows, err = cache.db.Query(ctx, cache.db.Rebind(`
 		SELECT last_net, id, address, last_ip_port, vetted_at,
-			unknown_audit_suspended, offline_suspended
+			unknown_audit_suspended, offline_suspended, latency_90
 		FROM nodes
 		WHERE id = any($1::bytea[])
 			AND disqualified IS NULL
@@ -327,7 +346,7 @@ func (cache *overlaycache) getOnlineNodesForAuditRepair(ctx context.Context, nod
 		node.Address = &pb.NodeAddress{Transport: pb.NodeTransport_TCP_TLS_GRPC}
 
 		var lastIPPort sql.NullString
-		err = rows.Scan(&node.LastNet, &node.ID, &node.Address.Address, &lastIPPort, &node.Reputation.VettedAt, &node.Reputation.UnknownAuditSuspended, &node.Reputation.OfflineSuspended)
+		err = rows.Scan(&node.LastNet, &node.ID, &node.Address.Address, &lastIPPort, &node.Reputation.VettedAt, &node.Reputation.UnknownAuditSuspended, &node.Reputation.OfflineSuspended, &node.Latency90)
 		if err != nil {
 			return nil, err
 		}
@@ -716,6 +735,52 @@ func (cache *overlaycache) DisqualifyNode(ctx context.Context, nodeID storj.Node
 	return nil
 }
 
+// DisqualifyNodes disqualifies the storage nodes, which aren't disqualified yet, with a single statement.
+// It returns the nodes which were disqualified by the call and the nodes which already were disqualified,
+// unknown nodes aren't included in either.
+func (cache *overlaycache) DisqualifyNodes(ctx context.Context, nodeIDs storj.NodeIDList, disqualifiedAt time.Time, reason overlay.DisqualificationReason) (disqualified, alreadyDisqualified storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(nodeIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	rows, err := cache.db.Query(ctx, cache.db.Rebind(`
+		WITH previous AS (
+			SELECT id FROM nodes WHERE id = any($1::bytea[])
+		), updated AS (
+			UPDATE nodes
+			SET disqualified = $2,
+				disqualification_reason = $3
+			WHERE id = any($1::bytea[])
+				AND disqualified IS NULL
+			RETURNING id
+		)
+		SELECT previous.id, updated.id IS NOT NULL
+		FROM previous
+		LEFT JOIN updated ON updated.id = previous.id
+	`), pgutil.NodeIDArray(nodeIDs), disqualifiedAt.UTC(), int(reason))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var id storj.NodeID
+		var updated bool
+		if err := rows.Scan(&id, &updated); err != nil {
+			return nil, nil, err
+		}
+		if updated {
+			disqualified = append(disqualified, id)
+		} else {
+			alreadyDisqualified = append(alreadyDisqualified, id)
+		}
+	}
+
+	return disqualified, alreadyDisqualified, rows.Err()
+}
+
 // TestSuspendNodeUnknownAudit suspends a storage node for unknown audits.
 func (cache *overlaycache) TestSuspendNodeUnknownAudit(ctx context.Context, nodeID storj.NodeID, suspendedAt time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1229,6 +1294,10 @@ func (cache *overlaycache) UpdateCheckIn(ctx context.Context, node overlay.NodeC
 		return Error.Wrap(err)
 	}
 
+	// contact transitions are counted in windows of config.FlappingWindow,
+	// the counters are reset by the first check-in after a window has passed.
+	transitionsCutoff := timestamp.Add(-config.FlappingWindow)
+
 	// First try the fast path.
 	var res sql.Result
 	res, err = cache.db.ExecContext(ctx, `
@@ -1251,7 +1320,25 @@ func (cache *overlaycache) UpdateCheckIn(ctx context.Context, node overlay.NodeC
 			END,
 			last_ip_port=$16,
 			wallet_features=$17,
-			country_code=$18
+			country_code=$18,
+			contact_up_transitions = CASE WHEN nodes.contact_transitions_since IS NULL OR nodes.contact_transitions_since < $19::timestamptz
+				THEN 0
+				ELSE nodes.contact_up_transitions
+			END + CASE WHEN $8::bool IS TRUE AND nodes.last_contact_failure > nodes.last_contact_success
+				THEN 1
+				ELSE 0
+			END,
+			contact_down_transitions = CASE WHEN nodes.contact_transitions_since IS NULL OR nodes.contact_transitions_since < $19::timestamptz
+				THEN 0
+				ELSE nodes.contact_down_transitions
+			END + CASE WHEN $8::bool IS FALSE AND nodes.last_contact_success > nodes.last_contact_failure
+				THEN 1
+				ELSE 0
+			END,
+			contact_transitions_since = CASE WHEN nodes.contact_transitions_since IS NULL OR nodes.contact_transitions_since < $19::timestamptz
+				THEN $15::timestamptz
+				ELSE nodes.contact_transitions_since
+			END
 		WHERE id = $1
 	`, // args $1 - $4
 		node.NodeID.Bytes(), node.Address.GetAddress(), node.LastNet, node.Address.GetTransport(),
@@ -1269,6 +1356,8 @@ func (cache *overlaycache) UpdateCheckIn(ctx context.Context, node overlay.NodeC
 		walletFeatures,
 		// args $18,
 		node.CountryCode.String(),
+		// args $19,
+		transitionsCutoff,
 	)
 
 	if err == nil {
@@ -1324,7 +1413,25 @@ func (cache *overlaycache) UpdateCheckIn(ctx context.Context, node overlay.NodeC
 				END,
 				last_ip_port=$17,
 				wallet_features=$18,
-				country_code=$19;
+				country_code=$19,
+				contact_up_transitions = CASE WHEN nodes.contact_transitions_since IS NULL OR nodes.contact_transitions_since < $20::timestamptz
+					THEN 0
+					ELSE nodes.contact_up_transitions
+				END + CASE WHEN $9::bool IS TRUE AND nodes.last_contact_failure > nodes.last_contact_success
+					THEN 1
+					ELSE 0
+				END,
+				contact_down_transitions = CASE WHEN nodes.contact_transitions_since IS NULL OR nodes.contact_transitions_since < $20::timestamptz
+					THEN 0
+					ELSE nodes.contact_down_transitions
+				END + CASE WHEN $9::bool IS FALSE AND nodes.last_contact_success > nodes.last_contact_failure
+					THEN 1
+					ELSE 0
+				END,
+				contact_transitions_since = CASE WHEN nodes.contact_transitions_since IS NULL OR nodes.contact_transitions_since < $20::timestamptz
+					THEN $16::timestamptz
+					ELSE nodes.contact_transitions_since
+				END;
 			`,
 		// args $1 - $5
 		node.NodeID.Bytes(), node.Address.GetAddress(), node.LastNet, node.Address.GetTransport(), int(pb.NodeType_STORAGE),
@@ -1342,6 +1449,8 @@ func (cache *overlaycache) UpdateCheckIn(ctx context.Context, node overlay.NodeC
 		walletFeatures,
 		// args $19,
 		node.CountryCode.String(),
+		// args $20,
+		transitionsCutoff,
 	)
 	if err != nil {
 		return Error.Wrap(err)
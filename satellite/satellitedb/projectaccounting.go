@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/jackc/pgx/v4"
@@ -578,6 +579,130 @@ func (db *ProjectAccounting) GetProjectTotal(ctx context.Context, projectID uuid
 	return usage, nil
 }
 
+// GetProjectTotalByInterval retrieves project usage for consecutive intervals of the given length
+// between since and before, the last interval ends at before. The usage of every interval is the
+// same as GetProjectTotal would return for it, but all of them are retrieved with two queries.
+func (db *ProjectAccounting) GetProjectTotalByInterval(ctx context.Context, projectID uuid.UUID, since, before time.Time, interval time.Duration) (_ []accounting.ProjectUsage, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if interval <= 0 {
+		return nil, accounting.ErrInvalidArgument.New("interval must be positive, got %s", interval)
+	}
+
+	var usages []accounting.ProjectUsage
+	for from := since; from.Before(before); from = from.Add(interval) {
+		to := from.Add(interval)
+		if to.After(before) {
+			to = before
+		}
+		usages = append(usages, accounting.ProjectUsage{Since: timeTruncateDown(from), Before: to})
+	}
+	if len(usages) == 0 {
+		return nil, nil
+	}
+	since = usages[0].Since
+
+	// intervalsContaining returns the indexes of the intervals containing t, the bounds of the
+	// intervals are inclusive like in GetProjectTotal, so t may be in two consecutive intervals.
+	intervalsContaining := func(t time.Time) (first, last int) {
+		first = sort.Search(len(usages), func(i int) bool { return !usages[i].Before.Before(t) })
+		last = first
+		for last < len(usages) && !t.Before(usages[last].Since) {
+			last++
+		}
+		return first, last
+	}
+
+	storageQuery := db.db.Rebind(`
+		SELECT
+			bucket_name, interval_start, total_bytes, inline, remote, total_segments_count, object_count
+		FROM
+			bucket_storage_tallies
+		WHERE
+			project_id = ? AND
+			interval_start >= ? AND
+			interval_start <= ?
+		ORDER BY bucket_name, interval_start
+	`)
+	storageRows, err := db.db.QueryContext(ctx, storageQuery, projectID[:], since, before)
+	if err != nil {
+		return nil, err
+	}
+
+	// every interval accounts the consecutive tallies of a bucket inside it, each weighted
+	// by the hours until the next one.
+	previous := make([]*accounting.BucketStorageTally, len(usages))
+	var previousBucket string
+	for storageRows.Next() {
+		var tally accounting.BucketStorageTally
+		var inline, remote int64
+		err = storageRows.Scan(&tally.BucketName, &tally.IntervalStart, &tally.TotalBytes, &inline, &remote, &tally.TotalSegmentCount, &tally.ObjectCount)
+		if err != nil {
+			return nil, errs.Combine(err, storageRows.Close())
+		}
+		if tally.TotalBytes == 0 {
+			tally.TotalBytes = inline + remote
+		}
+
+		if tally.BucketName != previousBucket {
+			for i := range previous {
+				previous[i] = nil
+			}
+			previousBucket = tally.BucketName
+		}
+
+		first, last := intervalsContaining(tally.IntervalStart)
+		for i := first; i < last; i++ {
+			if current := previous[i]; current != nil {
+				hours := tally.IntervalStart.Sub(current.IntervalStart).Hours()
+				usages[i].Storage += memory.Size(current.Bytes()).Float64() * hours
+				usages[i].SegmentCount += float64(current.TotalSegmentCount) * hours
+				usages[i].ObjectCount += float64(current.ObjectCount) * hours
+			}
+			previous[i] = &tally
+		}
+	}
+	err = errs.Combine(storageRows.Err(), storageRows.Close())
+	if err != nil {
+		return nil, err
+	}
+
+	egressQuery := db.db.Rebind(`
+		SELECT
+			interval_start, COALESCE(SUM(settled) + SUM(inline), 0)
+		FROM
+			bucket_bandwidth_rollups
+		WHERE
+			project_id = ? AND
+			interval_start >= ? AND
+			interval_start <= ? AND
+			action = ?
+		GROUP BY interval_start
+	`)
+	egressRows, err := db.db.QueryContext(ctx, egressQuery, projectID[:], since, before, pb.PieceAction_GET)
+	if err != nil {
+		return nil, err
+	}
+	for egressRows.Next() {
+		var intervalStart time.Time
+		var egress int64
+		err = egressRows.Scan(&intervalStart, &egress)
+		if err != nil {
+			return nil, errs.Combine(err, egressRows.Close())
+		}
+
+		first, last := intervalsContaining(intervalStart)
+		for i := first; i < last; i++ {
+			usages[i].Egress += egress
+		}
+	}
+	err = errs.Combine(egressRows.Err(), egressRows.Close())
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
+
 // getTotalEgress returns total egress (settled + inline) of each bucket_bandwidth_rollup
 // in selected time period, project id.
 // only process PieceAction_GET.
@@ -625,6 +750,131 @@ func (db *ProjectAccounting) GetBucketUsageRollups(ctx context.Context, projectI
 	return bucketUsageRollups, nil
 }
 
+// GetBucketUsageRollupsBatched retrieves summed usage rollups for every bucket of particular project for a given period.
+// Unlike GetBucketUsageRollups it doesn't query every bucket separately, the storage usage of all buckets
+// is retrieved with a single windowed query and the bandwidth usage with another one.
+func (db *ProjectAccounting) GetBucketUsageRollupsBatched(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ []accounting.BucketUsageRollup, err error) {
+	defer mon.Task()(&ctx)(&err)
+	since = timeTruncateDown(since.UTC())
+	before = before.UTC()
+
+	// every tally is joined with the start of the next tally of the bucket,
+	// the hours between them are used to weight the usage of the tally.
+	storageQuery := db.db.Rebind(`SELECT bucket_name, interval_start,
+			LEAD(interval_start) OVER (PARTITION BY bucket_name ORDER BY interval_start) AS next_interval_start,
+			total_bytes, inline, remote, metadata_size,
+			total_segments_count, remote_segments_count, inline_segments_count, object_count
+		FROM bucket_storage_tallies
+		WHERE project_id = ? AND interval_start >= ? AND interval_start <= ?
+		ORDER BY bucket_name, interval_start`)
+
+	storageRows, err := db.db.QueryContext(ctx, storageQuery, projectID[:], since, before)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, storageRows.Close()) }()
+
+	var bucketUsageRollups []accounting.BucketUsageRollup
+	indexes := make(map[string]int)
+	for storageRows.Next() {
+		var bucketName []byte
+		var intervalStart time.Time
+		var nextIntervalStart *time.Time
+		var totalBytes, inline, remote, metadataSize int64
+		var totalSegments, remoteSegments, inlineSegments, objectCount int64
+
+		err = storageRows.Scan(&bucketName, &intervalStart, &nextIntervalStart,
+			&totalBytes, &inline, &remote, &metadataSize,
+			&totalSegments, &remoteSegments, &inlineSegments, &objectCount)
+		if err != nil {
+			return nil, err
+		}
+
+		index, ok := indexes[string(bucketName)]
+		if !ok {
+			index = len(bucketUsageRollups)
+			indexes[string(bucketName)] = index
+			bucketUsageRollups = append(bucketUsageRollups, accounting.BucketUsageRollup{
+				ProjectID:  projectID,
+				BucketName: string(bucketName),
+				Since:      since,
+				Before:     before,
+			})
+		}
+
+		// hours are calculated until the next tally,
+		// so the most recent one is skipped
+		if nextIntervalStart == nil {
+			continue
+		}
+		hours := nextIntervalStart.Sub(intervalStart).Hours()
+
+		bucketRollup := &bucketUsageRollups[index]
+		if totalBytes > 0 {
+			bucketRollup.TotalStoredData += memory.Size(totalBytes).GB() * hours
+		} else {
+			bucketRollup.TotalStoredData += memory.Size(remote+inline).GB() * hours
+		}
+		bucketRollup.MetadataSize += memory.Size(metadataSize).GB() * hours
+		if totalSegments > 0 {
+			bucketRollup.TotalSegments += float64(totalSegments) * hours
+		} else {
+			bucketRollup.TotalSegments += float64(remoteSegments+inlineSegments) * hours
+		}
+		bucketRollup.ObjectCount += float64(objectCount) * hours
+	}
+	if err := storageRows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(bucketUsageRollups) == 0 {
+		return nil, nil
+	}
+
+	rollupsQuery := db.db.Rebind(`SELECT bucket_name, SUM(settled), SUM(inline), action
+		FROM bucket_bandwidth_rollups
+		WHERE project_id = ? AND interval_start >= ? AND interval_start <= ?
+		GROUP BY bucket_name, action`)
+
+	rollupRows, err := db.db.QueryContext(ctx, rollupsQuery, projectID[:], since, before)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rollupRows.Close()) }()
+
+	for rollupRows.Next() {
+		var bucketName []byte
+		var action pb.PieceAction
+		var settled, inline int64
+
+		err = rollupRows.Scan(&bucketName, &settled, &inline, &action)
+		if err != nil {
+			return nil, err
+		}
+
+		// only buckets with storage tallies in the period are included,
+		// the same as in GetBucketUsageRollups
+		index, ok := indexes[string(bucketName)]
+		if !ok {
+			continue
+		}
+
+		bucketRollup := &bucketUsageRollups[index]
+		switch action {
+		case pb.PieceAction_GET:
+			bucketRollup.GetEgress += memory.Size(settled + inline).GB()
+		case pb.PieceAction_GET_AUDIT:
+			bucketRollup.AuditEgress += memory.Size(settled + inline).GB()
+		case pb.PieceAction_GET_REPAIR:
+			bucketRollup.RepairEgress += memory.Size(settled + inline).GB()
+		default:
+			continue
+		}
+	}
+
+	return bucketUsageRollups, rollupRows.Err()
+}
+
 // GetSingleBucketUsageRollup retrieves usage rollup for a single bucket of particular project for a given period.
 func (db *ProjectAccounting) GetSingleBucketUsageRollup(ctx context.Context, projectID uuid.UUID, bucket string, since, before time.Time) (_ *accounting.BucketUsageRollup, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -639,6 +889,135 @@ func (db *ProjectAccounting) GetSingleBucketUsageRollup(ctx context.Context, pro
 	return bucketRollup, nil
 }
 
+// GetBucketUsageSeries retrieves usage rollups of a single bucket of particular project for every interval of a given period.
+// since is truncated down to the hour and the interval has to be a whole number of hours which divides the period.
+// The rollups sum up to the rollup of the whole period returned by GetSingleBucketUsageRollup.
+func (db *ProjectAccounting) GetBucketUsageSeries(ctx context.Context, projectID uuid.UUID, bucket string, since, before time.Time, interval time.Duration) (_ []accounting.BucketUsageRollup, err error) {
+	defer mon.Task()(&ctx)(&err)
+	since = timeTruncateDown(since.UTC())
+	before = before.UTC()
+
+	switch {
+	case interval <= 0 || interval%time.Hour != 0:
+		return nil, accounting.ErrInvalidArgument.New("interval must be a positive whole number of hours, got %s", interval)
+	case !before.After(since):
+		return nil, accounting.ErrInvalidArgument.New("before must be after since")
+	case before.Sub(since)%interval != 0:
+		return nil, accounting.ErrInvalidArgument.New("interval %s doesn't divide the period between %s and %s", interval, since, before)
+	case before.Sub(since)/interval > accounting.MaxBucketUsageSeriesPoints:
+		return nil, accounting.ErrInvalidArgument.New("the period between %s and %s has more than %d intervals of %s", since, before, accounting.MaxBucketUsageSeriesPoints, interval)
+	}
+
+	count := int(before.Sub(since) / interval)
+	series := make([]accounting.BucketUsageRollup, count)
+	for i := range series {
+		series[i] = accounting.BucketUsageRollup{
+			ProjectID:  projectID,
+			BucketName: bucket,
+			Since:      since.Add(time.Duration(i) * interval),
+			Before:     since.Add(time.Duration(i+1) * interval),
+		}
+	}
+
+	// point returns the rollup of the interval with the index, the end of the
+	// period is included in the last interval the same as in the rollup of the
+	// whole period.
+	point := func(index int64) *accounting.BucketUsageRollup {
+		if index >= int64(count) {
+			index = int64(count) - 1
+		}
+		return &series[index]
+	}
+
+	// intervalIndex is the SQL expression of the index of the interval interval_start belongs to.
+	const intervalIndex = `CAST(FLOOR((EXTRACT(EPOCH FROM interval_start) - EXTRACT(EPOCH FROM CAST(? AS TIMESTAMPTZ))) / CAST(? AS INT8)) AS INT8)`
+	intervalSeconds := int64(interval / time.Second)
+
+	// hours are calculated until the next tally, so the most recent one is
+	// skipped, the same as in GetSingleBucketUsageRollup.
+	storageQuery := db.db.Rebind(`
+		WITH tallies AS (
+			SELECT interval_start,
+				LEAD(interval_start) OVER (ORDER BY interval_start) AS next_interval_start,
+				CAST(CASE WHEN total_bytes > 0 THEN total_bytes ELSE inline + remote END AS FLOAT8) AS stored,
+				CAST(metadata_size AS FLOAT8) AS metadata_size,
+				CAST(CASE WHEN total_segments_count > 0 THEN total_segments_count ELSE inline_segments_count + remote_segments_count END AS FLOAT8) AS segments,
+				CAST(object_count AS FLOAT8) AS object_count
+			FROM bucket_storage_tallies
+			WHERE project_id = ? AND bucket_name = ? AND interval_start >= ? AND interval_start <= ?
+		), weighted AS (
+			SELECT ` + intervalIndex + ` AS interval_index,
+				CAST(EXTRACT(EPOCH FROM next_interval_start) - EXTRACT(EPOCH FROM interval_start) AS FLOAT8) / 3600 AS hours,
+				stored, metadata_size, segments, object_count
+			FROM tallies
+			WHERE next_interval_start IS NOT NULL
+		)
+		SELECT interval_index, SUM(stored * hours), SUM(metadata_size * hours), SUM(segments * hours), SUM(object_count * hours)
+		FROM weighted
+		GROUP BY interval_index`)
+
+	storageRows, err := db.db.QueryContext(ctx, storageQuery, projectID[:], []byte(bucket), since, before, since, intervalSeconds)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, storageRows.Close()) }()
+
+	for storageRows.Next() {
+		var index int64
+		var stored, metadataSize, segments, objectCount float64
+
+		err = storageRows.Scan(&index, &stored, &metadataSize, &segments, &objectCount)
+		if err != nil {
+			return nil, err
+		}
+
+		rollup := point(index)
+		rollup.TotalStoredData += stored / memory.GB.Float64()
+		rollup.MetadataSize += metadataSize / memory.GB.Float64()
+		rollup.TotalSegments += segments
+		rollup.ObjectCount += objectCount
+	}
+	if err := storageRows.Err(); err != nil {
+		return nil, err
+	}
+
+	rollupsQuery := db.db.Rebind(`SELECT ` + intervalIndex + ` AS interval_index, SUM(settled), SUM(inline), action
+		FROM bucket_bandwidth_rollups
+		WHERE project_id = ? AND bucket_name = ? AND interval_start >= ? AND interval_start <= ?
+		GROUP BY interval_index, action`)
+
+	rollupRows, err := db.db.QueryContext(ctx, rollupsQuery, since, intervalSeconds, projectID[:], []byte(bucket), since, before)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rollupRows.Close()) }()
+
+	for rollupRows.Next() {
+		var index int64
+		var action pb.PieceAction
+		var settled, inline int64
+
+		err = rollupRows.Scan(&index, &settled, &inline, &action)
+		if err != nil {
+			return nil, err
+		}
+
+		rollup := point(index)
+		switch action {
+		case pb.PieceAction_GET:
+			rollup.GetEgress += memory.Size(settled + inline).GB()
+		case pb.PieceAction_GET_AUDIT:
+			rollup.AuditEgress += memory.Size(settled + inline).GB()
+		case pb.PieceAction_GET_REPAIR:
+			rollup.RepairEgress += memory.Size(settled + inline).GB()
+		default:
+			continue
+		}
+	}
+
+	return series, rollupRows.Err()
+}
+
 func (db *ProjectAccounting) getSingleBucketRollup(ctx context.Context, projectID uuid.UUID, bucket string, since, before time.Time) (*accounting.BucketUsageRollup, error) {
 	roullupsQuery := db.db.Rebind(`SELECT SUM(settled), SUM(inline), action
 		FROM bucket_bandwidth_rollups
@@ -932,6 +1311,76 @@ func (db *ProjectAccounting) GetBucketTotals(ctx context.Context, projectID uuid
 	return page, nil
 }
 
+// GetProjectActivity returns paged upload and download activity of the project buckets, newest first.
+func (db *ProjectAccounting) GetProjectActivity(ctx context.Context, projectID uuid.UUID, cursor accounting.ProjectActivityCursor) (_ *accounting.ProjectActivityPage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if cursor.Limit > 50 {
+		cursor.Limit = 50
+	}
+	if cursor.Limit == 0 {
+		return nil, errs.New("limit can not be 0")
+	}
+	if cursor.Page == 0 {
+		return nil, errs.New("page can not be 0")
+	}
+
+	page := &accounting.ProjectActivityPage{
+		Limit:  cursor.Limit,
+		Offset: uint64((cursor.Page - 1) * cursor.Limit),
+	}
+
+	countQuery := db.db.Rebind(`SELECT COUNT(*) FROM bucket_bandwidth_rollups
+		WHERE project_id = ? AND action IN (?, ?)`)
+
+	err = db.db.QueryRowContext(ctx, countQuery, projectID[:], pb.PieceAction_PUT, pb.PieceAction_GET).Scan(&page.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.TotalCount == 0 {
+		return page, nil
+	}
+	if page.Offset > page.TotalCount-1 {
+		return nil, errs.New("page is out of range")
+	}
+
+	activityQuery := db.db.Rebind(`SELECT bucket_name, action, interval_start, inline, allocated, settled
+		FROM bucket_bandwidth_rollups
+		WHERE project_id = ? AND action IN (?, ?)
+		ORDER BY interval_start DESC, bucket_name ASC, action ASC
+		LIMIT ? OFFSET ?`)
+
+	rows, err := db.db.QueryContext(ctx, activityQuery, projectID[:], pb.PieceAction_PUT, pb.PieceAction_GET, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var activity accounting.ProjectActivity
+		var bucketName []byte
+		err = rows.Scan(&bucketName, &activity.Action, &activity.IntervalStart, &activity.Inline, &activity.Allocated, &activity.Settled)
+		if err != nil {
+			return nil, err
+		}
+		activity.BucketName = string(bucketName)
+
+		page.Activities = append(page.Activities, activity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page.PageCount = uint(page.TotalCount / uint64(cursor.Limit))
+	if page.TotalCount%uint64(cursor.Limit) != 0 {
+		page.PageCount++
+	}
+
+	page.CurrentPage = cursor.Page
+	return page, nil
+}
+
 // ArchiveRollupsBefore archives rollups older than a given time.
 func (db *ProjectAccounting) ArchiveRollupsBefore(ctx context.Context, before time.Time, batchSize int) (archivedCount int, err error) {
 	defer mon.Task()(&ctx)(&err)
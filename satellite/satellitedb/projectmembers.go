@@ -5,11 +5,16 @@ package satellitedb
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 
 	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/private/tagsql"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/satellitedb/dbx"
 )
@@ -21,6 +26,8 @@ var _ console.ProjectMembers = (*projectMembers)(nil)
 type projectMembers struct {
 	methods dbx.Methods
 	db      *satelliteDB
+	// tx is the transaction methods are bound to, if any.
+	tx *dbx.Tx
 }
 
 // GetByMemberID is a method for querying project member from the database by memberID.
@@ -154,16 +161,107 @@ func (pm *projectMembers) Insert(ctx context.Context, memberID, projectID uuid.U
 	return projectMemberFromDBX(ctx, createdProjectMember)
 }
 
+// InsertMany is a method for inserting multiple project members into the database.
+// It returns the IDs of the users which weren't members of the project already.
+func (pm *projectMembers) InsertMany(ctx context.Context, memberIDs []uuid.UUID, projectID uuid.UUID) (_ []uuid.UUID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		INSERT INTO project_members (member_id, project_id, created_at)
+		SELECT member_id, $2, now() FROM UNNEST($1::BYTEA[]) AS member_id
+		ON CONFLICT DO NOTHING
+		RETURNING member_id
+	`
+	args := []interface{}{pgutil.UUIDArray(memberIDs), projectID}
+
+	var rows tagsql.Rows
+	if pm.tx != nil {
+		rows, err = pm.tx.Tx.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = pm.db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var inserted []uuid.UUID
+	for rows.Next() {
+		var memberID uuid.UUID
+		if err := rows.Scan(&memberID); err != nil {
+			return nil, err
+		}
+		inserted = append(inserted, memberID)
+	}
+
+	return inserted, rows.Err()
+}
+
 // Delete is a method for deleting project member by memberID and projectID from the database.
 func (pm *projectMembers) Delete(ctx context.Context, memberID, projectID uuid.UUID) (err error) {
 	defer mon.Task()(&ctx)(&err)
-	_, err = pm.methods.Delete_ProjectMember_By_MemberId_And_ProjectId(
-		ctx,
-		dbx.ProjectMember_MemberId(memberID[:]),
-		dbx.ProjectMember_ProjectId(projectID[:]),
+
+	// the role is removed together with the membership, so that it doesn't
+	// apply when the user is added to the project again.
+	return pm.withTx(ctx, func(ctx context.Context, tx dbx.Methods) error {
+		_, err := tx.Delete_ProjectMemberRole_By_MemberId_And_ProjectId(ctx,
+			dbx.ProjectMemberRole_MemberId(memberID[:]),
+			dbx.ProjectMemberRole_ProjectId(projectID[:]),
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Delete_ProjectMember_By_MemberId_And_ProjectId(ctx,
+			dbx.ProjectMember_MemberId(memberID[:]),
+			dbx.ProjectMember_ProjectId(projectID[:]),
+		)
+		return err
+	})
+}
+
+// withTx runs fn within the transaction the repository is bound to, or within a new one.
+func (pm *projectMembers) withTx(ctx context.Context, fn func(context.Context, dbx.Methods) error) error {
+	if pm.tx != nil {
+		return fn(ctx, pm.tx)
+	}
+	return pm.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		return fn(ctx, tx)
+	})
+}
+
+// GetRole is a method for querying the role of a project member.
+func (pm *projectMembers) GetRole(ctx context.Context, memberID, projectID uuid.UUID) (_ console.ProjectMemberRole, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row, err := pm.methods.Get_ProjectMemberRole_Role_By_MemberId_And_ProjectId(ctx,
+		dbx.ProjectMemberRole_MemberId(memberID[:]),
+		dbx.ProjectMemberRole_ProjectId(projectID[:]),
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return console.RoleMember, nil
+	}
+	if err != nil {
+		return 0, err
+	}
 
-	return err
+	return console.ProjectMemberRole(row.Role), nil
+}
+
+// SetRole is a method for assigning a role to a project member.
+func (pm *projectMembers) SetRole(ctx context.Context, memberID, projectID uuid.UUID, role console.ProjectMemberRole) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return pm.methods.ReplaceNoReturn_ProjectMemberRole(ctx,
+		dbx.ProjectMemberRole_MemberId(memberID[:]),
+		dbx.ProjectMemberRole_ProjectId(projectID[:]),
+		dbx.ProjectMemberRole_Role(int(role)),
+		dbx.ProjectMemberRole_UpdatedAt(time.Now()),
+	)
 }
 
 // projectMemberFromDBX is used for creating ProjectMember entity from autogenerated dbx.ProjectMember struct.
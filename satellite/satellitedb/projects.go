@@ -197,6 +197,52 @@ func (projects *projects) UpdateBurstLimit(ctx context.Context, id uuid.UUID, ne
 	return err
 }
 
+// UpdateEgressRateLimit is a method for updating projects egress rate limit.
+// Zero limit removes the limit.
+func (projects *projects) UpdateEgressRateLimit(ctx context.Context, id uuid.UUID, newLimit memory.Size) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if newLimit < 0 {
+		return Error.New("limit can't be set to negative value")
+	}
+
+	egressRateLimit := dbx.Project_EgressRateLimit_Null()
+	if newLimit > 0 {
+		egressRateLimit = dbx.Project_EgressRateLimit(newLimit.Int64())
+	}
+
+	_, err = projects.db.Update_Project_By_Id(ctx,
+		dbx.Project_Id(id[:]),
+		dbx.Project_Update_Fields{
+			EgressRateLimit: egressRateLimit,
+		})
+
+	return err
+}
+
+// UpdateAdminEgressRateLimit is a method for updating projects egress rate limit set by an admin.
+// Zero limit removes the limit.
+func (projects *projects) UpdateAdminEgressRateLimit(ctx context.Context, id uuid.UUID, newLimit memory.Size) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if newLimit < 0 {
+		return Error.New("limit can't be set to negative value")
+	}
+
+	adminEgressRateLimit := dbx.Project_AdminEgressRateLimit_Null()
+	if newLimit > 0 {
+		adminEgressRateLimit = dbx.Project_AdminEgressRateLimit(newLimit.Int64())
+	}
+
+	_, err = projects.db.Update_Project_By_Id(ctx,
+		dbx.Project_Id(id[:]),
+		dbx.Project_Update_Fields{
+			AdminEgressRateLimit: adminEgressRateLimit,
+		})
+
+	return err
+}
+
 // UpdateBucketLimit is a method for updating projects bucket limit.
 func (projects *projects) UpdateBucketLimit(ctx context.Context, id uuid.UUID, newLimit int) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -316,6 +362,89 @@ func (projects *projects) ListByOwnerID(ctx context.Context, ownerID uuid.UUID,
 	return page, rows.Err()
 }
 
+// ListByUserID is a method for querying a page of projects where user is a project member.
+// cursor.Limit is set to 50 if it exceeds 50.
+func (projects *projects) ListByUserID(ctx context.Context, userID uuid.UUID, cursor console.ProjectsCursor) (_ console.ProjectsPage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if cursor.Limit > 50 {
+		cursor.Limit = 50
+	}
+	if cursor.Limit <= 0 {
+		return console.ProjectsPage{}, errs.New("limit must be positive")
+	}
+	if cursor.Page == 0 {
+		return console.ProjectsPage{}, errs.New("page can not be 0")
+	}
+
+	page := console.ProjectsPage{
+		CurrentPage: cursor.Page,
+		Limit:       cursor.Limit,
+		Offset:      int64((cursor.Page - 1) * cursor.Limit),
+	}
+
+	countRow := projects.sdb.QueryRowContext(ctx, projects.sdb.Rebind(`
+		SELECT COUNT(*) FROM project_members WHERE member_id = ?
+	`), userID)
+	err = countRow.Scan(&page.TotalCount)
+	if err != nil {
+		return console.ProjectsPage{}, err
+	}
+	page.PageCount = int(page.TotalCount / int64(cursor.Limit))
+	if page.TotalCount%int64(cursor.Limit) != 0 {
+		page.PageCount++
+	}
+
+	rows, err := projects.sdb.Query(ctx, projects.sdb.Rebind(`
+		SELECT projects.id, projects.name, projects.description,
+			projects.usage_limit, projects.bandwidth_limit, projects.segment_limit,
+			projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.egress_rate_limit, projects.admin_egress_rate_limit,
+			projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at,
+			(SELECT COUNT(*) FROM project_members WHERE project_id = projects.id) AS member_count
+			FROM projects
+			JOIN project_members ON projects.id = project_members.project_id
+			WHERE project_members.member_id = ?
+			ORDER BY projects.name ASC, projects.id ASC
+			OFFSET ? ROWS
+			LIMIT ?
+		`), userID, page.Offset, page.Limit+1) // add 1 to limit to see if there is another page
+	if err != nil {
+		return console.ProjectsPage{}, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	count := 0
+	projectsToSend := make([]console.Project, 0, page.Limit)
+	for rows.Next() {
+		count++
+		if count == page.Limit+1 {
+			// we are done with this page; do not include this project
+			page.Next = true
+			page.NextOffset = page.Offset + int64(page.Limit)
+			break
+		}
+		var memberCount int
+		projectDbx := &dbx.Project{}
+		err = rows.Scan(&projectDbx.Id, &projectDbx.Name, &projectDbx.Description,
+			&projectDbx.UsageLimit, &projectDbx.BandwidthLimit, &projectDbx.SegmentLimit,
+			&projectDbx.RateLimit, &projectDbx.BurstLimit, &projectDbx.MaxBuckets, &projectDbx.EgressRateLimit, &projectDbx.AdminEgressRateLimit,
+			&projectDbx.PartnerId, &projectDbx.UserAgent, &projectDbx.OwnerId, &projectDbx.CreatedAt,
+			&memberCount)
+		if err != nil {
+			return console.ProjectsPage{}, err
+		}
+		nextProject, err := projectFromDBX(ctx, projectDbx)
+		if err != nil {
+			return console.ProjectsPage{}, err
+		}
+		nextProject.MemberCount = memberCount
+		projectsToSend = append(projectsToSend, *nextProject)
+	}
+
+	page.Projects = projectsToSend
+	return page, rows.Err()
+}
+
 // projectFromDBX is used for creating Project entity from autogenerated dbx.Project struct.
 func projectFromDBX(ctx context.Context, project *dbx.Project) (_ *console.Project, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -348,19 +477,21 @@ func projectFromDBX(ctx context.Context, project *dbx.Project) (_ *console.Proje
 	}
 
 	return &console.Project{
-		ID:             id,
-		Name:           project.Name,
-		Description:    project.Description,
-		PartnerID:      partnerID,
-		UserAgent:      userAgent,
-		OwnerID:        ownerID,
-		RateLimit:      project.RateLimit,
-		BurstLimit:     project.BurstLimit,
-		MaxBuckets:     project.MaxBuckets,
-		CreatedAt:      project.CreatedAt,
-		StorageLimit:   (*memory.Size)(project.UsageLimit),
-		BandwidthLimit: (*memory.Size)(project.BandwidthLimit),
-		SegmentLimit:   project.SegmentLimit,
+		ID:                   id,
+		Name:                 project.Name,
+		Description:          project.Description,
+		PartnerID:            partnerID,
+		UserAgent:            userAgent,
+		OwnerID:              ownerID,
+		RateLimit:            project.RateLimit,
+		BurstLimit:           project.BurstLimit,
+		MaxBuckets:           project.MaxBuckets,
+		CreatedAt:            project.CreatedAt,
+		StorageLimit:         (*memory.Size)(project.UsageLimit),
+		BandwidthLimit:       (*memory.Size)(project.BandwidthLimit),
+		SegmentLimit:         project.SegmentLimit,
+		EgressRateLimit:      (*memory.Size)(project.EgressRateLimit),
+		AdminEgressRateLimit: (*memory.Size)(project.AdminEgressRateLimit),
 	}, nil
 }
 
@@ -0,0 +1,92 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/repair/queue"
+)
+
+// ensures that repairCheckpoints implements queue.RepairCheckpoints.
+var _ queue.RepairCheckpoints = (*repairCheckpoints)(nil)
+
+// repairCheckpoints implements storj.io/storj/satellite/repair/queue.RepairCheckpoints.
+type repairCheckpoints struct {
+	db *satelliteDB
+}
+
+// AddPiece records a piece uploaded while repairing the segment.
+func (r *repairCheckpoints) AddPiece(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, piece metabase.Piece) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO repair_checkpoints (stream_id, position, piece_num, node_id, uploaded_at)
+		VALUES (?, ?, ?, ?, now())
+		ON CONFLICT (stream_id, position, piece_num)
+		DO UPDATE SET node_id = EXCLUDED.node_id, uploaded_at = EXCLUDED.uploaded_at
+	`), streamID, position.Encode(), int(piece.Number), piece.StorageNode)
+	return Error.Wrap(err)
+}
+
+// Get returns the pieces recorded for the segment which were uploaded after uploadedAfter.
+func (r *repairCheckpoints) Get(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, uploadedAfter time.Time) (pieces metabase.Pieces, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(`
+		SELECT piece_num, node_id
+		FROM repair_checkpoints
+		WHERE stream_id = ? AND position = ? AND uploaded_at > ?
+		ORDER BY piece_num
+	`), streamID, position.Encode(), uploadedAfter)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var pieceNum int
+		var nodeID storj.NodeID
+		if err := rows.Scan(&pieceNum, &nodeID); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		pieces = append(pieces, metabase.Piece{
+			Number:      uint16(pieceNum),
+			StorageNode: nodeID,
+		})
+	}
+
+	return pieces, Error.Wrap(rows.Err())
+}
+
+// Delete removes the checkpoint of the segment.
+func (r *repairCheckpoints) Delete(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(`
+		DELETE FROM repair_checkpoints WHERE stream_id = ? AND position = ?
+	`), streamID, position.Encode())
+	return Error.Wrap(err)
+}
+
+// DeleteUploadedBefore removes the recorded pieces which were uploaded before the given time.
+func (r *repairCheckpoints) DeleteUploadedBefore(ctx context.Context, before time.Time) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(`
+		DELETE FROM repair_checkpoints WHERE uploaded_at < ?
+	`), before)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	deleted, err = result.RowsAffected()
+	return deleted, Error.Wrap(err)
+}
@@ -9,8 +9,10 @@ import (
 	"errors"
 	"time"
 
+	"github.com/jackc/pgtype"
 	"github.com/zeebo/errs"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/private/dbutil"
 	"storj.io/private/dbutil/pgutil"
@@ -34,6 +36,7 @@ func (r *repairQueue) Insert(ctx context.Context, seg *queue.InjuredSegment) (al
 	var query string
 
 	// we want to insert the segment if it is not in the queue, but update the segment health if it already is in the queue
+	// a change of the segment health resets the repair attempts, so an exhausted segment is repaired again once its pieces changed
 	// we also want to know if the result was an insert or an update - this is the reasoning for the xmax section of the postgres query
 	// and the separate cockroach query (which the xmax trick does not work for)
 	switch r.db.impl {
@@ -41,14 +44,16 @@ func (r *repairQueue) Insert(ctx context.Context, seg *queue.InjuredSegment) (al
 		query = `
 			INSERT INTO repair_queue
 			(
-				stream_id, position, segment_health
+				stream_id, position, segment_health, held, placement
 			)
 			VALUES (
-				$1, $2, $3
+				$1, $2, $3, $4, $5
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=$3, updated_at=current_timestamp
+			SET segment_health=$3, held=$4, placement=$5, updated_at=current_timestamp,
+				exhausted_at=CASE WHEN repair_queue.segment_health = $3 THEN repair_queue.exhausted_at END,
+				attempts=CASE WHEN repair_queue.segment_health = $3 THEN repair_queue.attempts ELSE 0 END
 			RETURNING (xmax != 0) AS alreadyInserted
 		`
 	case dbutil.Cockroach:
@@ -60,18 +65,20 @@ func (r *repairQueue) Insert(ctx context.Context, seg *queue.InjuredSegment) (al
 			)
 			INSERT INTO repair_queue
 			(
-				stream_id, position, segment_health
+				stream_id, position, segment_health, held, placement
 			)
 			VALUES (
-				$1, $2, $3
+				$1, $2, $3, $4, $5
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=$3, updated_at=current_timestamp
+			SET segment_health=$3, held=$4, placement=$5, updated_at=current_timestamp,
+				exhausted_at=CASE WHEN repair_queue.segment_health = $3 THEN repair_queue.exhausted_at END,
+				attempts=CASE WHEN repair_queue.segment_health = $3 THEN repair_queue.attempts ELSE 0 END
 			RETURNING (SELECT alreadyInserted FROM inserted)
 		`
 	}
-	rows, err := r.db.QueryContext(ctx, query, seg.StreamID, seg.Position.Encode(), seg.SegmentHealth)
+	rows, err := r.db.QueryContext(ctx, query, seg.StreamID, seg.Position.Encode(), seg.SegmentHealth, seg.Held, int(seg.Placement))
 	if err != nil {
 		return false, err
 	}
@@ -102,6 +109,7 @@ func (r *repairQueue) InsertBatch(
 	var query string
 
 	// we want to insert the segment if it is not in the queue, but update the segment health if it already is in the queue
+	// a change of the segment health resets the repair attempts, so an exhausted segment is repaired again once its pieces changed
 	// we also want to know if the result was an insert or an update - this is the reasoning for the xmax section of the postgres query
 	// and the separate cockroach query (which the xmax trick does not work for)
 	switch r.db.impl {
@@ -109,17 +117,21 @@ func (r *repairQueue) InsertBatch(
 		query = `
 			INSERT INTO repair_queue
 			(
-				stream_id, position, segment_health
+				stream_id, position, segment_health, held, placement
 			)
 			VALUES (
 				UNNEST($1::BYTEA[]),
 				UNNEST($2::INT8[]),
-				UNNEST($3::double precision[])
+				UNNEST($3::double precision[]),
+				UNNEST($4::BOOL[]),
+				UNNEST($5::INT4[])
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=EXCLUDED.segment_health, updated_at=current_timestamp
-			RETURNING NOT(xmax != 0) AS newlyInserted
+			SET segment_health=EXCLUDED.segment_health, held=EXCLUDED.held, placement=EXCLUDED.placement, updated_at=current_timestamp,
+				exhausted_at=CASE WHEN repair_queue.segment_health = EXCLUDED.segment_health THEN repair_queue.exhausted_at END,
+				attempts=CASE WHEN repair_queue.segment_health = EXCLUDED.segment_health THEN repair_queue.attempts ELSE 0 END
+			RETURNING NOT(xmax != 0) AS newlyInserted, inserted_at
 		`
 	case dbutil.Cockroach:
 		// TODO it's not optimal solution but crdb is not used in prod for repair queue
@@ -128,23 +140,30 @@ func (r *repairQueue) InsertBatch(
 				SELECT
 					UNNEST($1::BYTEA[]) AS stream_id,
 					UNNEST($2::INT8[]) AS position,
-					UNNEST($3::double precision[]) AS segment_health
+					UNNEST($3::double precision[]) AS segment_health,
+					UNNEST($4::BOOL[]) AS held,
+					UNNEST($5::INT4[]) AS placement
 			),
 			do_insert AS (
 				INSERT INTO repair_queue (
-					stream_id, position, segment_health
+					stream_id, position, segment_health, held, placement
 				)
-				SELECT stream_id, position, segment_health
+				SELECT stream_id, position, segment_health, held, placement
 				FROM to_insert
 				ON CONFLICT (stream_id, position)
 				DO UPDATE
 				SET
 					segment_health=EXCLUDED.segment_health,
-					updated_at=current_timestamp
+					held=EXCLUDED.held,
+					placement=EXCLUDED.placement,
+					updated_at=current_timestamp,
+					exhausted_at=CASE WHEN repair_queue.segment_health = EXCLUDED.segment_health THEN repair_queue.exhausted_at END,
+					attempts=CASE WHEN repair_queue.segment_health = EXCLUDED.segment_health THEN repair_queue.attempts ELSE 0 END
 				RETURNING false
 			)
 			SELECT
-				(repair_queue.stream_id IS NULL) AS newlyInserted
+				(repair_queue.stream_id IS NULL) AS newlyInserted,
+				COALESCE(repair_queue.inserted_at, current_timestamp) AS inserted_at
 			FROM to_insert
 			LEFT JOIN repair_queue
 				ON to_insert.stream_id = repair_queue.stream_id
@@ -156,12 +175,16 @@ func (r *repairQueue) InsertBatch(
 		StreamIDs      []uuid.UUID
 		Positions      []int64
 		SegmentHealths []float64
+		Held           []bool
+		Placements     []int32
 	}
 
 	for _, segment := range segments {
 		insertData.StreamIDs = append(insertData.StreamIDs, segment.StreamID)
 		insertData.Positions = append(insertData.Positions, int64(segment.Position.Encode()))
 		insertData.SegmentHealths = append(insertData.SegmentHealths, segment.SegmentHealth)
+		insertData.Held = append(insertData.Held, segment.Held)
+		insertData.Placements = append(insertData.Placements, int32(segment.Placement))
 	}
 
 	rows, err := r.db.QueryContext(
@@ -169,6 +192,8 @@ func (r *repairQueue) InsertBatch(
 		pgutil.UUIDArray(insertData.StreamIDs),
 		pgutil.Int8Array(insertData.Positions),
 		pgutil.Float8Array(insertData.SegmentHealths),
+		boolArray(insertData.Held),
+		pgutil.Int4Array(insertData.Placements),
 	)
 
 	if err != nil {
@@ -179,7 +204,7 @@ func (r *repairQueue) InsertBatch(
 	i := 0
 	for rows.Next() {
 		var isNewlyInserted bool
-		err = rows.Scan(&isNewlyInserted)
+		err = rows.Scan(&isNewlyInserted, &segments[i].InsertedAt)
 		if err != nil {
 			return newlyInsertedSegments, err
 		}
@@ -194,29 +219,52 @@ func (r *repairQueue) InsertBatch(
 	return newlyInsertedSegments, rows.Err()
 }
 
-func (r *repairQueue) Select(ctx context.Context) (seg *queue.InjuredSegment, err error) {
+func (r *repairQueue) Select(ctx context.Context, includedPlacements ...storj.PlacementConstraint) (seg *queue.InjuredSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	// segments inserted before the placement was stored have the default
+	// placement. The column is compared directly, so that the placement index
+	// can be used.
+	var placementFilter string
+	var args []interface{}
+	if len(includedPlacements) > 0 {
+		placements := make([]int32, 0, len(includedPlacements))
+		includesDefault := false
+		for _, placement := range includedPlacements {
+			placements = append(placements, int32(placement))
+			includesDefault = includesDefault || placement == storj.EveryCountry
+		}
+		if includesDefault {
+			placementFilter = "AND (placement = ANY($1::INT4[]) OR placement IS NULL)"
+		} else {
+			placementFilter = "AND placement = ANY($1::INT4[])"
+		}
+		args = append(args, pgutil.Int4Array(placements))
+	}
+
+	var placement sql.NullInt32
 	segment := queue.InjuredSegment{}
 	switch r.db.impl {
 	case dbutil.Cockroach:
 		err = r.db.QueryRowContext(ctx, `
-				UPDATE repair_queue SET attempted_at = now()
-				WHERE attempted_at IS NULL OR attempted_at < now() - interval '6 hours'
-				ORDER BY segment_health ASC, attempted_at NULLS FIRST
+				UPDATE repair_queue SET attempted_at = now(), attempts = attempts + 1
+				WHERE exhausted_at IS NULL AND (attempted_at IS NULL OR attempted_at < now() - interval '6 hours')
+				`+placementFilter+`
+				ORDER BY segment_health ASC, held DESC, attempted_at NULLS FIRST
 				LIMIT 1
-				RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health
-		`).Scan(&segment.StreamID, &segment.Position, &segment.AttemptedAt,
-			&segment.UpdatedAt, &segment.InsertedAt, &segment.SegmentHealth)
+				RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health, held, attempts, placement
+		`, args...).Scan(&segment.StreamID, &segment.Position, &segment.AttemptedAt,
+			&segment.UpdatedAt, &segment.InsertedAt, &segment.SegmentHealth, &segment.Held, &segment.Attempts, &placement)
 	case dbutil.Postgres:
 		err = r.db.QueryRowContext(ctx, `
-				UPDATE repair_queue SET attempted_at = now() WHERE (stream_id, position) = (
+				UPDATE repair_queue SET attempted_at = now(), attempts = attempts + 1 WHERE (stream_id, position) = (
 					SELECT stream_id, position FROM repair_queue
-					WHERE attempted_at IS NULL OR attempted_at < now() - interval '6 hours'
-					ORDER BY segment_health ASC, attempted_at NULLS FIRST FOR UPDATE SKIP LOCKED LIMIT 1
-				) RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health
-		`).Scan(&segment.StreamID, &segment.Position, &segment.AttemptedAt,
-			&segment.UpdatedAt, &segment.InsertedAt, &segment.SegmentHealth)
+					WHERE exhausted_at IS NULL AND (attempted_at IS NULL OR attempted_at < now() - interval '6 hours')
+					`+placementFilter+`
+					ORDER BY segment_health ASC, held DESC, attempted_at NULLS FIRST FOR UPDATE SKIP LOCKED LIMIT 1
+				) RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health, held, attempts, placement
+		`, args...).Scan(&segment.StreamID, &segment.Position, &segment.AttemptedAt,
+			&segment.UpdatedAt, &segment.InsertedAt, &segment.SegmentHealth, &segment.Held, &segment.Attempts, &placement)
 	default:
 		return seg, errs.New("unhandled database: %v", r.db.impl)
 	}
@@ -226,6 +274,7 @@ func (r *repairQueue) Select(ctx context.Context) (seg *queue.InjuredSegment, er
 		}
 		return nil, err
 	}
+	segment.Placement = storj.PlacementConstraint(placement.Int32)
 	return &segment, err
 }
 
@@ -248,7 +297,7 @@ func (r *repairQueue) SelectN(ctx context.Context, limit int) (segs []queue.Inju
 	}
 	// TODO: strictly enforce order-by or change tests
 	rows, err := r.db.QueryContext(ctx,
-		r.db.Rebind(`SELECT stream_id, position, attempted_at, updated_at, segment_health
+		r.db.Rebind(`SELECT stream_id, position, attempted_at, updated_at, segment_health, held, attempts, placement
 					FROM repair_queue LIMIT ?`), limit,
 	)
 	if err != nil {
@@ -258,11 +307,13 @@ func (r *repairQueue) SelectN(ctx context.Context, limit int) (segs []queue.Inju
 
 	for rows.Next() {
 		var seg queue.InjuredSegment
+		var placement sql.NullInt32
 		err = rows.Scan(&seg.StreamID, &seg.Position, &seg.AttemptedAt,
-			&seg.UpdatedAt, &seg.SegmentHealth)
+			&seg.UpdatedAt, &seg.SegmentHealth, &seg.Held, &seg.Attempts, &placement)
 		if err != nil {
 			return segs, Error.Wrap(err)
 		}
+		seg.Placement = storj.PlacementConstraint(placement.Int32)
 		segs = append(segs, seg)
 	}
 
@@ -272,8 +323,22 @@ func (r *repairQueue) SelectN(ctx context.Context, limit int) (segs []queue.Inju
 func (r *repairQueue) Count(ctx context.Context) (count int, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	// Count every segment regardless of how recently repair was last attempted
-	err = r.db.QueryRowContext(ctx, r.db.Rebind(`SELECT COUNT(*) as count FROM repair_queue`)).Scan(&count)
+	// Count every active segment regardless of how recently repair was last attempted
+	err = r.db.QueryRowContext(ctx, r.db.Rebind(`SELECT COUNT(*) as count FROM repair_queue WHERE exhausted_at IS NULL`)).Scan(&count)
+
+	return count, Error.Wrap(err)
+}
+
+func (r *repairQueue) MarkExhausted(ctx context.Context, seg *queue.InjuredSegment) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(`UPDATE repair_queue SET exhausted_at = now() WHERE stream_id = ? AND position = ?`), seg.StreamID, seg.Position.Encode())
+	return Error.Wrap(err)
+}
+
+func (r *repairQueue) CountExhausted(ctx context.Context) (count int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = r.db.QueryRowContext(ctx, r.db.Rebind(`SELECT COUNT(*) as count FROM repair_queue WHERE exhausted_at IS NOT NULL`)).Scan(&count)
 
 	return count, Error.Wrap(err)
 }
@@ -293,3 +358,34 @@ func (r *repairQueue) TestingSetAttemptedTime(ctx context.Context, streamID uuid
 	count, err := res.RowsAffected()
 	return count, Error.Wrap(err)
 }
+
+// TestingSetInsertedTime sets inserted time for a segment.
+func (r *repairQueue) TestingSetInsertedTime(ctx context.Context, streamID uuid.UUID,
+	position metabase.SegmentPosition, t time.Time) (rowsAffected int64, err error) {
+
+	defer mon.Task()(&ctx)(&err)
+	res, err := r.db.ExecContext(ctx,
+		r.db.Rebind(`UPDATE repair_queue SET inserted_at = ? WHERE stream_id = ? AND position = ?`),
+		t, streamID, position.Encode(),
+	)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	count, err := res.RowsAffected()
+	return count, Error.Wrap(err)
+}
+
+// boolArray returns an object usable by pg drivers for passing a []bool slice
+// into a database as type BOOL[].
+func boolArray(bools []bool) *pgtype.BoolArray {
+	pgtypeBoolArray := make([]pgtype.Bool, len(bools))
+	for i, b := range bools {
+		pgtypeBoolArray[i].Bool = b
+		pgtypeBoolArray[i].Status = pgtype.Present
+	}
+	return &pgtype.BoolArray{
+		Elements:   pgtypeBoolArray,
+		Dimensions: []pgtype.ArrayDimension{{Length: int32(len(bools)), LowerBound: 1}},
+		Status:     pgtype.Present,
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"storj.io/common/pb"
 	"storj.io/common/storj"
+	"storj.io/private/dbutil/pgutil"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/reputation"
 	"storj.io/storj/satellite/satellitedb/dbx"
@@ -281,6 +282,96 @@ func (reputations *reputations) UnsuspendNodeUnknownAudit(ctx context.Context, n
 	return Error.Wrap(err)
 }
 
+// ExportAll returns up to limit reputations of the nodes with an ID greater than cursor, ordered by node ID.
+func (reputations *reputations) ExportAll(ctx context.Context, cursor storj.NodeID, limit int, asOfSystemInterval time.Duration) (_ []reputation.NodeInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := reputations.db.QueryContext(ctx, `
+		SELECT
+			id, audit_success_count, total_audit_count, vetted_at,
+			disqualified, disqualification_reason, unknown_audit_suspended,
+			offline_suspended, under_review, online_score, audit_history,
+			audit_reputation_alpha, audit_reputation_beta,
+			unknown_audit_reputation_alpha, unknown_audit_reputation_beta
+		FROM reputations
+		`+reputations.db.impl.AsOfSystemInterval(asOfSystemInterval)+`
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var infos []reputation.NodeInfo
+	for rows.Next() {
+		var dbNode dbx.Reputation
+		err = rows.Scan(
+			&dbNode.Id, &dbNode.AuditSuccessCount, &dbNode.TotalAuditCount, &dbNode.VettedAt,
+			&dbNode.Disqualified, &dbNode.DisqualificationReason, &dbNode.UnknownAuditSuspended,
+			&dbNode.OfflineSuspended, &dbNode.UnderReview, &dbNode.OnlineScore, &dbNode.AuditHistory,
+			&dbNode.AuditReputationAlpha, &dbNode.AuditReputationBeta,
+			&dbNode.UnknownAuditReputationAlpha, &dbNode.UnknownAuditReputationBeta,
+		)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		nodeID, err := storj.NodeIDFromBytes(dbNode.Id)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		info, err := dbxToReputationInfo(&dbNode)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		infos = append(infos, reputation.NodeInfo{
+			NodeID: nodeID,
+			Info:   info,
+		})
+	}
+
+	return infos, Error.Wrap(rows.Err())
+}
+
+// GetAuditScores returns the audit reputation scores of the nodes which have a reputation.
+func (reputations *reputations) GetAuditScores(ctx context.Context, nodeIDs []storj.NodeID) (_ map[storj.NodeID]float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	scores := make(map[storj.NodeID]float64, len(nodeIDs))
+	if len(nodeIDs) == 0 {
+		return scores, nil
+	}
+
+	rows, err := reputations.db.QueryContext(ctx, `
+		SELECT id, audit_reputation_alpha, audit_reputation_beta
+		FROM reputations
+		WHERE id = any($1::bytea[])
+	`, pgutil.NodeIDArray(nodeIDs))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var nodeID storj.NodeID
+		var alpha, beta float64
+		if err := rows.Scan(&nodeID, &alpha, &beta); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		if alpha+beta <= 0 {
+			scores[nodeID] = 1
+			continue
+		}
+		scores[nodeID] = alpha / (alpha + beta)
+	}
+
+	return scores, Error.Wrap(rows.Err())
+}
+
 func (reputations *reputations) populateCreateFields(update updateNodeStats) dbx.Reputation_Create_Fields {
 	createFields := dbx.Reputation_Create_Fields{}
 
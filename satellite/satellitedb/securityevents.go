@@ -0,0 +1,172 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that *securityEvents implements console.SecurityEvents.
+var _ console.SecurityEvents = (*securityEvents)(nil)
+
+// securityEvents is an implementation of console.SecurityEvents.
+type securityEvents struct {
+	methods dbx.Methods
+	db      *satelliteDB
+}
+
+// Insert stores a new security event.
+func (events *securityEvents) Insert(ctx context.Context, event console.SecurityEvent) (_ *console.SecurityEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if event.ID.IsZero() {
+		event.ID, err = uuid.New()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dbxEvent, err := events.methods.Create_SecurityEvent(ctx,
+		dbx.SecurityEvent_Id(event.ID[:]),
+		dbx.SecurityEvent_UserId(event.UserID[:]),
+		dbx.SecurityEvent_EventType(string(event.Type)),
+		dbx.SecurityEvent_IpAddress(event.IPAddress),
+		dbx.SecurityEvent_Create_Fields{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return securityEventFromDBX(dbxEvent)
+}
+
+// GetLatest returns the user's newest security event of the given type.
+func (events *securityEvents) GetLatest(ctx context.Context, userID uuid.UUID, eventType console.SecurityEventType) (_ *console.SecurityEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxEvent, err := events.methods.First_SecurityEvent_By_UserId_And_EventType_OrderBy_Desc_CreatedAt_Desc_Id(ctx,
+		dbx.SecurityEvent_UserId(userID[:]),
+		dbx.SecurityEvent_EventType(string(eventType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if dbxEvent == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return securityEventFromDBX(dbxEvent)
+}
+
+// ListByUserID returns a page of the user's security events, newest first.
+// cursor.Limit is set to 50 if it exceeds 50.
+func (events *securityEvents) ListByUserID(ctx context.Context, userID uuid.UUID, cursor console.SecurityEventsCursor) (_ console.SecurityEventsPage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if cursor.Limit > 50 {
+		cursor.Limit = 50
+	}
+	if cursor.Limit <= 0 {
+		return console.SecurityEventsPage{}, errs.New("limit must be positive")
+	}
+	if cursor.Page == 0 {
+		return console.SecurityEventsPage{}, errs.New("page can not be 0")
+	}
+
+	page := console.SecurityEventsPage{
+		CurrentPage: cursor.Page,
+		Limit:       cursor.Limit,
+		Offset:      int64((cursor.Page - 1) * cursor.Limit),
+	}
+
+	page.TotalCount, err = events.methods.Count_SecurityEvent_By_UserId(ctx, dbx.SecurityEvent_UserId(userID[:]))
+	if err != nil {
+		return console.SecurityEventsPage{}, err
+	}
+	page.PageCount = int(page.TotalCount / int64(cursor.Limit))
+	if page.TotalCount%int64(cursor.Limit) != 0 {
+		page.PageCount++
+	}
+
+	// add 1 to limit to see if there is another page
+	dbxEvents, err := events.methods.Limited_SecurityEvent_By_UserId_OrderBy_Desc_CreatedAt_Desc_Id(ctx,
+		dbx.SecurityEvent_UserId(userID[:]),
+		page.Limit+1, page.Offset,
+	)
+	if err != nil {
+		return console.SecurityEventsPage{}, err
+	}
+
+	if len(dbxEvents) > page.Limit {
+		// we are done with this page; do not include the extra event
+		dbxEvents = dbxEvents[:page.Limit]
+		page.Next = true
+		page.NextOffset = page.Offset + int64(page.Limit)
+	}
+
+	page.Events = make([]console.SecurityEvent, 0, len(dbxEvents))
+	for _, dbxEvent := range dbxEvents {
+		event, err := securityEventFromDBX(dbxEvent)
+		if err != nil {
+			return console.SecurityEventsPage{}, err
+		}
+		page.Events = append(page.Events, *event)
+	}
+
+	return page, nil
+}
+
+// MarkReviewed marks the user's security events with the given IDs as reviewed.
+// Events which were already reviewed keep their original review time.
+func (events *securityEvents) MarkReviewed(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, reviewedAt time.Time) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := events.db.ExecContext(ctx, `
+		UPDATE security_events
+		SET reviewed_at = $3
+		WHERE user_id = $1
+			AND id = ANY($2::BYTEA[])
+			AND reviewed_at IS NULL
+	`, userID, pgutil.UUIDArray(ids), reviewedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// securityEventFromDBX converts a dbx security event to a console.SecurityEvent.
+func securityEventFromDBX(event *dbx.SecurityEvent) (*console.SecurityEvent, error) {
+	id, err := uuid.FromBytes(event.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.FromBytes(event.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &console.SecurityEvent{
+		ID:         id,
+		UserID:     userID,
+		Type:       console.SecurityEventType(event.EventType),
+		IPAddress:  event.IpAddress,
+		CreatedAt:  event.CreatedAt,
+		ReviewedAt: event.ReviewedAt,
+	}, nil
+}
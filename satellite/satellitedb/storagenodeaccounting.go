@@ -238,6 +238,35 @@ func (db *StoragenodeAccounting) GetBandwidthSince(ctx context.Context, latestRo
 
 }
 
+// GetRepairBandwidthByPlacement returns the repair bandwidth allocated in the given period, summed by placement and action.
+func (db *StoragenodeAccounting) GetRepairBandwidthByPlacement(ctx context.Context, from, to time.Time) (_ []accounting.RepairBandwidthRollup, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(`
+		SELECT placement, action, SUM(allocated)::INT8
+		FROM repair_bandwidth_rollups
+		WHERE interval_start >= ? AND interval_start < ?
+		GROUP BY placement, action
+		ORDER BY placement, action
+	`), from.UTC(), to.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var rollups []accounting.RepairBandwidthRollup
+	for rows.Next() {
+		var rollup accounting.RepairBandwidthRollup
+		var placement int
+		if err := rows.Scan(&placement, &rollup.Action, &rollup.Allocated); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		rollup.Placement = storj.PlacementConstraint(placement)
+		rollups = append(rollups, rollup)
+	}
+	return rollups, Error.Wrap(rows.Err())
+}
+
 // SaveRollup records raw tallies of at rest data to the database.
 func (db *StoragenodeAccounting) SaveRollup(ctx context.Context, latestRollup time.Time, stats accounting.RollupStats) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -13,6 +13,7 @@ import (
 
 	"storj.io/common/memory"
 	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/satellitedb/dbx"
 )
@@ -76,6 +77,47 @@ func (users *users) GetByEmail(ctx context.Context, email string) (_ *console.Us
 	return userFromDBX(ctx, user)
 }
 
+// GetIDsByEmails is a method for querying the IDs of verified users by emails from the database.
+// The IDs are keyed by the emails as given, emails which don't belong to a user are left out.
+func (users *users) GetIDsByEmails(ctx context.Context, emails []string) (_ map[string]uuid.UUID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	normalized := make(map[string][]string, len(emails))
+	normalizedEmails := make([]string, 0, len(emails))
+	for _, email := range emails {
+		key := normalizeEmail(email)
+		if _, ok := normalized[key]; !ok {
+			normalizedEmails = append(normalizedEmails, key)
+		}
+		normalized[key] = append(normalized[key], email)
+	}
+
+	rows, err := users.db.Query(ctx, `
+		SELECT id, normalized_email
+		FROM users
+		WHERE normalized_email = ANY($1::TEXT[])
+			AND status != 0
+	`, pgutil.TextArray(normalizedEmails))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	ids := make(map[string]uuid.UUID, len(emails))
+	for rows.Next() {
+		var id uuid.UUID
+		var normalizedEmail string
+		if err := rows.Scan(&id, &normalizedEmail); err != nil {
+			return nil, err
+		}
+		for _, email := range normalized[normalizedEmail] {
+			ids[email] = id
+		}
+	}
+
+	return ids, rows.Err()
+}
+
 // GetUnverifiedNeedingReminder returns users in need of a reminder to verify their email.
 func (users *users) GetUnverifiedNeedingReminder(ctx context.Context, firstReminder, secondReminder, cutoff time.Time) (usersNeedingReminder []*console.User, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -24,7 +24,8 @@ func (db *webappSessions) Create(ctx context.Context, id, userID uuid.UUID, addr
 	defer mon.Task()(&ctx)(&err)
 
 	dbxSession, err := db.db.Create_WebappSession(ctx, dbx.WebappSession_Id(id.Bytes()), dbx.WebappSession_UserId(userID.Bytes()),
-		dbx.WebappSession_IpAddress(address), dbx.WebappSession_UserAgent(userAgent), dbx.WebappSession_ExpiresAt(expiresAt))
+		dbx.WebappSession_IpAddress(address), dbx.WebappSession_UserAgent(userAgent), dbx.WebappSession_ExpiresAt(expiresAt),
+		dbx.WebappSession_Create_Fields{LastUsedAt: dbx.WebappSession_LastUsedAt(time.Now())})
 	if err != nil {
 		return session, err
 	}
@@ -44,6 +45,17 @@ func (db *webappSessions) GetBySessionID(ctx context.Context, sessionID uuid.UUI
 	return getSessionFromDBX(dbxSession)
 }
 
+// UpdateLastUsedAt updates the time the webapp session was last used.
+func (db *webappSessions) UpdateLastUsedAt(ctx context.Context, sessionID uuid.UUID, lastUsedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Update_WebappSession_By_Id(ctx, dbx.WebappSession_Id(sessionID.Bytes()), dbx.WebappSession_Update_Fields{
+		LastUsedAt: dbx.WebappSession_LastUsedAt(lastUsedAt),
+	})
+
+	return err
+}
+
 // GetAllByUserID gets all webapp sessions with userID.
 func (db *webappSessions) GetAllByUserID(ctx context.Context, userID uuid.UUID) (sessions []consoleauth.WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -86,11 +98,12 @@ func getSessionFromDBX(dbxSession *dbx.WebappSession) (consoleauth.WebappSession
 		return consoleauth.WebappSession{}, err
 	}
 	return consoleauth.WebappSession{
-		ID:        id,
-		UserID:    userID,
-		Address:   dbxSession.IpAddress,
-		UserAgent: dbxSession.UserAgent,
-		Status:    dbxSession.Status,
-		ExpiresAt: dbxSession.ExpiresAt,
+		ID:         id,
+		UserID:     userID,
+		Address:    dbxSession.IpAddress,
+		UserAgent:  dbxSession.UserAgent,
+		Status:     dbxSession.Status,
+		ExpiresAt:  dbxSession.ExpiresAt,
+		LastUsedAt: dbxSession.LastUsedAt,
 	}, nil
 }
@@ -0,0 +1,279 @@
+// AUTOGENERATED BY private/apigen
+// DO NOT EDIT.
+
+export interface Project {
+    id: string;
+    name: string;
+    description: string;
+    partnerId: string;
+    userAgent: string;
+    ownerId: string;
+    rateLimit: number | null;
+    burstLimit: number | null;
+    maxBuckets: number | null;
+    createdAt: string;
+    memberCount: number;
+    storageLimit: string | null;
+    bandwidthLimit: string | null;
+    segmentLimit: number | null;
+}
+
+export interface ProjectInfo {
+    name: string;
+    description: string;
+    storageLimit: string;
+    bandwidthLimit: string;
+    createdAt: string;
+}
+
+export interface ProjectsPage {
+    projects: Project[];
+    next: boolean;
+    nextOffset: number;
+    limit: number;
+    offset: number;
+    pageCount: number;
+    currentPage: number;
+    totalCount: number;
+}
+
+export interface BucketUsageRollup {
+    projectID: string;
+    bucketName: string;
+    totalStoredData: number;
+    totalSegments: number;
+    objectCount: number;
+    metadataSize: number;
+    repairEgress: number;
+    getEgress: number;
+    auditEgress: number;
+    since: string;
+    before: string;
+}
+
+export interface CreateAPIKeyResponse {
+    key: string;
+    keyInfo: APIKeyInfo | null;
+}
+
+export interface APIKeyInfo {
+    id: string;
+    projectId: string;
+    partnerId: string;
+    userAgent: string;
+    name: string;
+    createdAt: string;
+}
+
+export interface CreateAPIKeyRequest {
+    projectID: string;
+    name: string;
+}
+
+export interface RenameAPIKeyRequest {
+    name: string;
+}
+
+export interface ResponseUser {
+    id: string;
+    fullName: string;
+    shortName: string;
+    email: string;
+    partnerId: string;
+    userAgent: string;
+    projectLimit: number;
+    isProfessional: boolean;
+    position: string;
+    companyName: string;
+    employeeCount: string;
+    haveSalesContact: boolean;
+    paidTier: boolean;
+    isMFAEnabled: boolean;
+    mfaRecoveryCodeCount: number;
+}
+
+async function handleError(response: Response): Promise<never> {
+    const body = await response.json().catch(() => ({}));
+    throw new Error(body.error || response.statusText);
+}
+
+export class ProjectManagementHttpApiV0 {
+    private readonly ROOT_PATH: string = '/api/v0/projects';
+
+    /**
+     * Creates new Project with given info.
+     */
+    public async genCreateProject(projectInfo: ProjectInfo): Promise<Project> {
+        const path = `${this.ROOT_PATH}/create`;
+        const response = await fetch(path, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(projectInfo),
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as Project);
+    }
+
+    /**
+     * Updates project with given info.
+     */
+    public async genUpdateProject(id: string, projectInfo: ProjectInfo): Promise<Project> {
+        const path = `${this.ROOT_PATH}/update/${id}`;
+        const response = await fetch(path, {
+            method: 'PATCH',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(projectInfo),
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as Project);
+    }
+
+    /**
+     * Deletes project by id.
+     */
+    public async genDeleteProject(id: string): Promise<void> {
+        const path = `${this.ROOT_PATH}/delete/${id}`;
+        const response = await fetch(path, {
+            method: 'DELETE',
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+    }
+
+    /**
+     * Gets a page of projects user has.
+     */
+    public async genGetUsersProjects(limit?: number, page?: number): Promise<ProjectsPage> {
+        const query = new URLSearchParams();
+        if (limit !== undefined) {
+            query.set('limit', String(limit));
+        }
+        if (page !== undefined) {
+            query.set('page', String(page));
+        }
+        const path = `${this.ROOT_PATH}/?${query.toString()}`;
+        const response = await fetch(path, {
+            method: 'GET',
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as ProjectsPage);
+    }
+
+    /**
+     * Gets project's single bucket usage by bucket ID.
+     */
+    public async genGetSingleBucketUsageRollup(projectID: string, bucket: string, since: Date, before: Date): Promise<BucketUsageRollup> {
+        const query = new URLSearchParams();
+        query.set('projectID', projectID);
+        query.set('bucket', bucket);
+        query.set('since', since.toISOString());
+        query.set('before', before.toISOString());
+        const path = `${this.ROOT_PATH}/bucket-rollup?${query.toString()}`;
+        const response = await fetch(path, {
+            method: 'GET',
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as BucketUsageRollup);
+    }
+
+    /**
+     * Gets project's all buckets usage.
+     */
+    public async genGetBucketUsageRollups(projectID: string, since: Date, before: Date): Promise<BucketUsageRollup[]> {
+        const query = new URLSearchParams();
+        query.set('projectID', projectID);
+        query.set('since', since.toISOString());
+        query.set('before', before.toISOString());
+        const path = `${this.ROOT_PATH}/bucket-rollups?${query.toString()}`;
+        const response = await fetch(path, {
+            method: 'GET',
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as BucketUsageRollup[]);
+    }
+
+    /**
+     * Gets project's single bucket usage for every interval of the period, the interval is a duration, e.g. 1h or 24h.
+     */
+    public async genGetBucketUsageSeries(projectID: string, bucket: string, since: Date, before: Date, interval: string): Promise<BucketUsageRollup[]> {
+        const query = new URLSearchParams();
+        query.set('projectID', projectID);
+        query.set('bucket', bucket);
+        query.set('since', since.toISOString());
+        query.set('before', before.toISOString());
+        query.set('interval', interval);
+        const path = `${this.ROOT_PATH}/bucket-usage-series?${query.toString()}`;
+        const response = await fetch(path, {
+            method: 'GET',
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as BucketUsageRollup[]);
+    }
+}
+
+export class APIKeyManagementHttpApiV0 {
+    private readonly ROOT_PATH: string = '/api/v0/apikeys';
+
+    /**
+     * Creates new macaroon API key with given info.
+     */
+    public async genCreateAPIKey(apikeyInfo: CreateAPIKeyRequest): Promise<CreateAPIKeyResponse> {
+        const path = `${this.ROOT_PATH}/create`;
+        const response = await fetch(path, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(apikeyInfo),
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as CreateAPIKeyResponse);
+    }
+
+    /**
+     * Renames API key with given id, the key's secret is left unchanged.
+     */
+    public async genRenameAPIKey(id: string, apikeyInfo: RenameAPIKeyRequest): Promise<APIKeyInfo> {
+        const path = `${this.ROOT_PATH}/rename/${id}`;
+        const response = await fetch(path, {
+            method: 'PATCH',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(apikeyInfo),
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as APIKeyInfo);
+    }
+}
+
+export class UserManagementHttpApiV0 {
+    private readonly ROOT_PATH: string = '/api/v0/users';
+
+    /**
+     * Gets User by request context.
+     */
+    public async genGetUser(): Promise<ResponseUser> {
+        const path = `${this.ROOT_PATH}/`;
+        const response = await fetch(path, {
+            method: 'GET',
+        });
+        if (!response.ok) {
+            return handleError(response);
+        }
+        return response.json().then((body) => body as ResponseUser);
+    }
+}